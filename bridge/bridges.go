@@ -4,7 +4,9 @@ package bridge
 import (
 	"github.com/MichaelMure/git-bug/bridge/core"
 	_ "github.com/MichaelMure/git-bug/bridge/github"
+	_ "github.com/MichaelMure/git-bug/bridge/gitlab"
 	_ "github.com/MichaelMure/git-bug/bridge/launchpad"
+	_ "github.com/MichaelMure/git-bug/bridge/mbox"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/repository"
 )
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/repository"
@@ -17,6 +18,13 @@ var ErrExportNorSupported = errors.New("export is not supported")
 
 var bridgeImpl map[string]reflect.Type
 
+// the configuration keys holding the time of the last successful import and
+// export, for the next ones to only handle what changed since
+const (
+	keyLastImport = "last-import"
+	keyLastExport = "last-export"
+)
+
 // Bridge is a wrapper around a BridgeImpl that will bind low-level
 // implementation with utility code to provide high-level functions.
 type Bridge struct {
@@ -279,7 +287,20 @@ func (b *Bridge) ImportAll() error {
 		return err
 	}
 
-	return importer.ImportAll(b.repo)
+	since, err := b.lastSync(keyLastImport)
+	if err != nil {
+		return err
+	}
+
+	// what changes during the import is imported the next time
+	start := time.Now()
+
+	err = importer.ImportAll(b.repo, since)
+	if err != nil {
+		return err
+	}
+
+	return b.storeLastSync(keyLastImport, start)
 }
 
 func (b *Bridge) Import(id string) error {
@@ -317,7 +338,19 @@ func (b *Bridge) ExportAll() error {
 		return err
 	}
 
-	return exporter.ExportAll(b.repo)
+	since, err := b.lastSync(keyLastExport)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+
+	err = exporter.ExportAll(b.repo, since)
+	if err != nil {
+		return err
+	}
+
+	return b.storeLastSync(keyLastExport, start)
 }
 
 func (b *Bridge) Export(id string) error {
@@ -338,3 +371,25 @@ func (b *Bridge) Export(id string) error {
 
 	return exporter.Export(b.repo, id)
 }
+
+// lastSync return the time of the last successful import or export, or the
+// zero time if there was none
+func (b *Bridge) lastSync(key string) (time.Time, error) {
+	value, ok := b.conf[key]
+	if !ok {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid %s time", key)
+	}
+
+	return t, nil
+}
+
+func (b *Bridge) storeLastSync(key string, t time.Time) error {
+	value := t.UTC().Format(time.RFC3339)
+	b.conf[key] = value
+	return b.storeConfig(Configuration{key: value})
+}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"time"
+
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/repository"
 )
@@ -27,12 +29,16 @@ type BridgeImpl interface {
 
 type Importer interface {
 	Init(conf Configuration) error
-	ImportAll(repo *cache.RepoCache) error
+	// ImportAll import the issues changed since the given time, or all of
+	// them if it's the zero time
+	ImportAll(repo *cache.RepoCache, since time.Time) error
 	Import(repo *cache.RepoCache, id string) error
 }
 
 type Exporter interface {
 	Init(conf Configuration) error
-	ExportAll(repo *cache.RepoCache) error
+	// ExportAll export the bugs changed since the given time, or all of them
+	// if it's the zero time
+	ExportAll(repo *cache.RepoCache, since time.Time) error
 	Export(repo *cache.RepoCache, id string) error
 }
@@ -33,7 +33,7 @@ func (*Github) Configure(repo repository.RepoCommon) (core.Configuration, error)
 	fmt.Println()
 	fmt.Println("The token will have the following scopes:")
 	fmt.Println("  - user:email: to be able to read public-only users email")
-	// fmt.Println("The token will have the \"repo\" permission, giving it read/write access to your repositories and issues. There is no narrower scope available, sorry :-|")
+	fmt.Println("  - repo: to be able to export the bugs as issues. There is no narrower scope available, sorry :-|")
 	fmt.Println()
 
 	projectUser, projectName, err := promptURL()
@@ -125,7 +125,8 @@ func requestTokenWith2FA(note, username, password, otpCode string) (*http.Respon
 	}{
 		// user:email is requested to be able to read public emails
 		//     - a private email will stay private, even with this token
-		Scopes:      []string{"user:email"},
+		// repo is requested to be able to create and update the issues
+		Scopes:      []string{"user:email", "repo"},
 		Note:        note,
 		Fingerprint: randomFingerprint(),
 	}
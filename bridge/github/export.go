@@ -0,0 +1,357 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/pkg/errors"
+)
+
+// githubExporter implement the Exporter interface. The github API v4 doesn't
+// allow to create issues, the API v3 is used instead.
+type githubExporter struct {
+	conf    core.Configuration
+	client  *http.Client
+	baseUrl string
+	// login is the login of the owner of the token, the author of the issues
+	// and comments created on github
+	login string
+}
+
+// the parts of an issue or a comment given by the API v3 that matter for the
+// export
+type restIssue struct {
+	Number  int    `json:"number"`
+	NodeId  string `json:"node_id"`
+	HtmlUrl string `json:"html_url"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	Labels  []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+type restComment struct {
+	NodeId  string `json:"node_id"`
+	HtmlUrl string `json:"html_url"`
+}
+
+func (ge *githubExporter) Init(conf core.Configuration) error {
+	ge.conf = conf
+	ge.client = &http.Client{Timeout: 30 * time.Second}
+	ge.baseUrl = githubV3Url
+	return nil
+}
+
+// ExportAll export the bugs changed since the given time, oldest first
+func (ge *githubExporter) ExportAll(repo *cache.RepoCache, since time.Time) error {
+	query := cache.NewQuery()
+	query.OrderBy = cache.OrderByCreation
+
+	for _, id := range repo.QueryBugs(query) {
+		b, err := repo.ResolveBug(id)
+		if err != nil {
+			return err
+		}
+
+		if b.Snapshot().LastEditTime().Before(since) {
+			continue
+		}
+
+		err = ge.exportBug(repo, b)
+		if err != nil {
+			return errors.Wrapf(err, "bug %s", b.HumanId())
+		}
+	}
+
+	return nil
+}
+
+// Export export a single bug
+func (ge *githubExporter) Export(repo *cache.RepoCache, id string) error {
+	b, err := repo.ResolveBugPrefix(id)
+	if err != nil {
+		return err
+	}
+
+	return ge.exportBug(repo, b)
+}
+
+// exportBug create the issue of a bug if it's not on github yet, add the
+// comments not there yet, and update the title, the status and the labels of
+// the issue if they differ. The issues and comments created are recorded in
+// the metadata of their operation, as they would be if they were imported.
+func (ge *githubExporter) exportBug(repo *cache.RepoCache, b *cache.BugCache) error {
+	snap := b.Snapshot()
+
+	// a restricted bug must not leak on github
+	if !snap.Visibility.IsPublic() {
+		return nil
+	}
+
+	err := ge.ensureLogin()
+	if err != nil {
+		return err
+	}
+
+	user, err := repo.GetUser()
+	if err != nil {
+		return err
+	}
+
+	createOp := snap.Operations[0]
+	createMetadata := createOp.AllMetadata()
+
+	var issue restIssue
+
+	if createMetadata[keyGithubId] != "" {
+		number, ok := ge.issueNumber(createMetadata[keyGithubUrl])
+		if !ok {
+			// imported from another project
+			return nil
+		}
+
+		err = ge.request(http.MethodGet, fmt.Sprintf("issues/%d", number), nil, &issue)
+		if err != nil {
+			return err
+		}
+	} else {
+		createHash, err := createOp.Hash()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("export bug: %s\n", snap.Title)
+
+		item, err := snap.SearchTimelineItem(createHash)
+		if err != nil {
+			return err
+		}
+
+		err = ge.request(http.MethodPost, "issues", map[string]interface{}{
+			"title":  snap.Title,
+			"body":   ge.attributedBody(user, snap.Author, item.(*bug.CreateTimelineItem).Message),
+			"labels": labelNames(snap.Labels),
+		}, &issue)
+		if err != nil {
+			return err
+		}
+
+		err = b.SetMetadataRaw(user, time.Now(), createHash, map[string]string{
+			keyGithubId:  issue.NodeId,
+			keyGithubUrl: issue.HtmlUrl,
+		})
+		if err != nil {
+			return err
+		}
+
+		// committed right away, for a failure later on to not lead to a
+		// duplicated issue on the next export
+		err = b.CommitAsNeeded()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, item := range snap.Timeline {
+		comment, ok := item.(*bug.AddCommentTimelineItem)
+		if !ok {
+			continue
+		}
+
+		err = ge.ensureComment(b, user, issue.Number, comment)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = ge.ensureIssueState(issue, snap)
+	if err != nil {
+		return err
+	}
+
+	return b.CommitAsNeeded()
+}
+
+// ensureComment create a comment on github if it's not there yet
+func (ge *githubExporter) ensureComment(b *cache.BugCache, user bug.Person, number int, comment *bug.AddCommentTimelineItem) error {
+	if hasGithubId(b, comment.Hash()) {
+		return nil
+	}
+
+	fmt.Println("export comment")
+
+	var created restComment
+	err := ge.request(http.MethodPost, fmt.Sprintf("issues/%d/comments", number), map[string]interface{}{
+		"body": ge.attributedBody(user, comment.Author, comment.Message),
+	}, &created)
+	if err != nil {
+		return err
+	}
+
+	err = b.SetMetadataRaw(user, time.Now(), comment.Hash(), map[string]string{
+		keyGithubId:  created.NodeId,
+		keyGithubUrl: created.HtmlUrl,
+	})
+	if err != nil {
+		return err
+	}
+
+	return b.CommitAsNeeded()
+}
+
+// ensureIssueState update the title, the status and the labels of the issue
+// on github if they differ from the bug
+func (ge *githubExporter) ensureIssueState(issue restIssue, snap *bug.Snapshot) error {
+	changes := make(map[string]interface{})
+
+	if issue.Title != snap.Title {
+		changes["title"] = snap.Title
+	}
+
+	if issue.State != snap.Status.String() {
+		changes["state"] = snap.Status.String()
+	}
+
+	var current []string
+	for _, label := range issue.Labels {
+		current = append(current, label.Name)
+	}
+	wanted := labelNames(snap.Labels)
+	sort.Strings(current)
+	sort.Strings(wanted)
+	if strings.Join(current, "\n") != strings.Join(wanted, "\n") {
+		changes["labels"] = wanted
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	fmt.Printf("update issue #%d\n", issue.Number)
+
+	return ge.request(http.MethodPatch, fmt.Sprintf("issues/%d", issue.Number), changes, nil)
+}
+
+// attributedBody return the message to post on github, mentioning its author
+// when it's not the owner of the token
+func (ge *githubExporter) attributedBody(user bug.Person, author bug.Person, message string) string {
+	if author.Login == ge.login || (author.Name == user.Name && author.Email == user.Email) {
+		return message
+	}
+
+	return fmt.Sprintf("_%s wrote:_\n\n%s", author.DisplayName(), message)
+}
+
+// issueNumber return the number of an issue of the project from its url
+func (ge *githubExporter) issueNumber(url string) (int, bool) {
+	prefix := fmt.Sprintf("https://github.com/%s/%s/issues/", ge.conf[keyUser], ge.conf[keyProject])
+	if !strings.HasPrefix(url, prefix) {
+		return 0, false
+	}
+
+	var number int
+	_, err := fmt.Sscanf(strings.TrimPrefix(url, prefix), "%d", &number)
+	return number, err == nil
+}
+
+// ensureLogin fetch the login of the owner of the token, once
+func (ge *githubExporter) ensureLogin() error {
+	if ge.login != "" {
+		return nil
+	}
+
+	var user struct {
+		Login string `json:"login"`
+	}
+
+	err := ge.do(http.MethodGet, ge.baseUrl+"/user", nil, &user)
+	if err != nil {
+		return err
+	}
+
+	ge.login = user.Login
+
+	return nil
+}
+
+// request call the API v3 on a path relative to the project
+func (ge *githubExporter) request(method string, path string, body interface{}, result interface{}) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/%s", ge.baseUrl, ge.conf[keyUser], ge.conf[keyProject], path)
+	return ge.do(method, url, body, result)
+}
+
+func (ge *githubExporter) do(method string, url string, body interface{}, result interface{}) error {
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "token "+ge.conf[keyToken])
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := ge.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: %s: %s", method, url, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(respBody, result)
+}
+
+// hasGithubId tell if the operation has been imported from github, or
+// already exported
+func hasGithubId(b *cache.BugCache, hash git.Hash) bool {
+	for _, op := range b.Snapshot().Operations {
+		opHash, err := op.Hash()
+		if err != nil || opHash != hash {
+			continue
+		}
+		_, ok := op.GetMetadata(keyGithubId)
+		return ok
+	}
+	return false
+}
+
+func labelNames(labels []bug.Label) []string {
+	result := make([]string, len(labels))
+	for i, label := range labels {
+		result[i] = label.String()
+	}
+	return result
+}
@@ -0,0 +1,239 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeGithub is the part of the API v3 used by the export
+type fakeGithub struct {
+	mu       sync.Mutex
+	issues   map[int]map[string]interface{}
+	comments map[int][]string
+	requests []string
+	// failComment is the body of a comment to refuse to create
+	failComment string
+}
+
+func (f *fakeGithub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	var body map[string]interface{}
+	if r.Body != nil {
+		data, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+	}
+
+	var number int
+	var sub string
+	path := r.URL.Path
+
+	switch {
+	case path == "/user":
+		_ = json.NewEncoder(w).Encode(map[string]string{"login": "testuser-gh"})
+
+	case r.Method == http.MethodPost && path == "/repos/user/project/issues":
+		number = len(f.issues) + 1
+		issue := map[string]interface{}{
+			"number":   number,
+			"node_id":  fmt.Sprintf("I_%d", number),
+			"html_url": fmt.Sprintf("https://github.com/user/project/issues/%d", number),
+			"title":    body["title"],
+			"body":     body["body"],
+			"state":    "open",
+			"labels":   []interface{}{},
+		}
+		for _, name := range body["labels"].([]interface{}) {
+			issue["labels"] = append(issue["labels"].([]interface{}), map[string]interface{}{"name": name})
+		}
+		f.issues[number] = issue
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(issue)
+
+	default:
+		n, _ := fmt.Sscanf(path, "/repos/user/project/issues/%d/%s", &number, &sub)
+		issue, ok := f.issues[number]
+		if n == 0 || !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		switch {
+		case sub == "comments" && r.Method == http.MethodPost && body["body"] == f.failComment:
+			http.Error(w, `{"message":"Server Error"}`, http.StatusInternalServerError)
+
+		case sub == "comments" && r.Method == http.MethodPost:
+			f.comments[number] = append(f.comments[number], body["body"].(string))
+			id := len(f.comments[number])
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"node_id":  fmt.Sprintf("IC_%d_%d", number, id),
+				"html_url": fmt.Sprintf("https://github.com/user/project/issues/%d#issuecomment-%d", number, id),
+			})
+
+		case sub == "" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(issue)
+
+		case sub == "" && r.Method == http.MethodPatch:
+			for key, value := range body {
+				if key != "labels" {
+					issue[key] = value
+					continue
+				}
+				var labels []interface{}
+				for _, name := range value.([]interface{}) {
+					labels = append(labels, map[string]interface{}{"name": name})
+				}
+				issue["labels"] = labels
+			}
+			_ = json.NewEncoder(w).Encode(issue)
+
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	fake := &fakeGithub{
+		issues:   make(map[int]map[string]interface{}),
+		comments: make(map[int][]string),
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backend := newTestRepo(t, dir)
+	defer backend.Close()
+
+	b, err := backend.NewBug("title", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, b.AddComment("comment"))
+	_, err = b.ChangeLabels([]string{"bug"}, nil)
+	assert.NoError(t, err)
+
+	other := bug.Person{Name: "Alice", Email: "alice@example.com"}
+	assert.NoError(t, b.AddCommentRaw(other, time.Now(), "from alice", nil, nil))
+	assert.NoError(t, b.Commit())
+
+	restricted, err := backend.NewBug("internal", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, restricted.SetVisibility(bug.VisibilityInternal))
+	assert.NoError(t, restricted.Commit())
+
+	exporter := &githubExporter{}
+	assert.NoError(t, exporter.Init(core.Configuration{
+		keyUser:    "user",
+		keyProject: "project",
+		keyToken:   "token",
+	}))
+	exporter.baseUrl = server.URL
+
+	assert.NoError(t, exporter.ExportAll(backend, time.Time{}))
+
+	// the restricted bug is not exported
+	assert.Len(t, fake.issues, 1)
+	assert.Equal(t, "title", fake.issues[1]["title"])
+	assert.Equal(t, "message", fake.issues[1]["body"])
+	assert.Equal(t, []string{"comment", "_Alice wrote:_\n\nfrom alice"}, fake.comments[1])
+
+	// the ids are recorded as if the issue had been imported
+	imported, err := backend.ResolveBugCreateMetadata(keyGithubId, "I_1")
+	assert.NoError(t, err)
+	assert.Equal(t, b.Id(), imported.Id())
+	_, err = b.ResolveTargetWithMetadata(keyGithubId, "IC_1_2")
+	assert.NoError(t, err)
+
+	// exporting again change nothing
+	fake.requests = nil
+	assert.NoError(t, exporter.ExportAll(backend, time.Time{}))
+	assert.Len(t, fake.issues, 1)
+	assert.Len(t, fake.comments[1], 2)
+	assert.Equal(t, []string{"GET /repos/user/project/issues/1"}, fake.requests)
+
+	// the changes of the bug update the issue
+	assert.NoError(t, b.Close())
+	assert.NoError(t, b.SetTitle("new title"))
+	assert.NoError(t, b.Commit())
+
+	assert.NoError(t, exporter.Export(backend, b.Id()))
+	assert.Equal(t, "closed", fake.issues[1]["state"])
+	assert.Equal(t, "new title", fake.issues[1]["title"])
+
+	// the bugs unchanged since the last export are skipped
+	fake.requests = nil
+	assert.NoError(t, exporter.ExportAll(backend, time.Now().Add(time.Hour)))
+	assert.Empty(t, fake.requests)
+}
+
+func TestExportFailure(t *testing.T) {
+	fake := &fakeGithub{
+		issues:      make(map[int]map[string]interface{}),
+		comments:    make(map[int][]string),
+		failComment: "second",
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backend := newTestRepo(t, dir)
+
+	b, err := backend.NewBug("title", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, b.AddComment("first"))
+	assert.NoError(t, b.AddComment("second"))
+	assert.NoError(t, b.Commit())
+
+	conf := core.Configuration{
+		keyUser:    "user",
+		keyProject: "project",
+		keyToken:   "token",
+	}
+
+	exporter := &githubExporter{}
+	assert.NoError(t, exporter.Init(conf))
+	exporter.baseUrl = server.URL
+
+	assert.Error(t, exporter.ExportAll(backend, time.Time{}))
+	assert.Len(t, fake.issues, 1)
+	assert.Equal(t, []string{"first"}, fake.comments[1])
+	assert.NoError(t, backend.Close())
+
+	// what has been created before the failure is known by the next export
+	repo, err := repository.NewGitRepo(dir, nil)
+	assert.NoError(t, err)
+	backend, err = cache.NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer backend.Close()
+
+	fake.failComment = ""
+	exporter = &githubExporter{}
+	assert.NoError(t, exporter.Init(conf))
+	exporter.baseUrl = server.URL
+
+	assert.NoError(t, exporter.ExportAll(backend, time.Time{}))
+	assert.Len(t, fake.issues, 1)
+	assert.Equal(t, []string{"first", "second"}, fake.comments[1])
+}
@@ -24,7 +24,7 @@ func (*Github) NewImporter() core.Importer {
 }
 
 func (*Github) NewExporter() core.Exporter {
-	return nil
+	return &githubExporter{}
 }
 
 func buildClient(conf core.Configuration) *githubv4.Client {
@@ -3,7 +3,9 @@ package github
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/MichaelMure/git-bug/bridge/core"
 	"github.com/MichaelMure/git-bug/bug"
@@ -20,20 +22,42 @@ type githubImporter struct {
 	client *githubv4.Client
 	conf   core.Configuration
 	ghost  bug.Person
+	// viewer is the login of the owner of the token
+	viewer string
+	// identities are the persons used for the github users, by login
+	identities map[string]bug.Person
 }
 
 func (gi *githubImporter) Init(conf core.Configuration) error {
 	gi.conf = conf
 	gi.client = buildClient(conf)
 
+	err := gi.fetchViewer()
+	if err != nil {
+		return err
+	}
+
 	return gi.fetchGhost()
 }
 
-func (gi *githubImporter) ImportAll(repo *cache.RepoCache) error {
+// ImportAll import the issues updated since the given time, along with their
+// whole timeline. The already imported items are skipped.
+func (gi *githubImporter) ImportAll(repo *cache.RepoCache, since time.Time) error {
+	err := gi.loadIdentities(repo)
+	if err != nil {
+		return err
+	}
+
+	var sinceVar *githubv4.DateTime
+	if !since.IsZero() {
+		sinceVar = &githubv4.DateTime{Time: since}
+	}
+
 	q := &issueTimelineQuery{}
 	variables := map[string]interface{}{
 		"owner":         githubv4.String(gi.conf[keyUser]),
 		"name":          githubv4.String(gi.conf[keyProject]),
+		"since":         sinceVar,
 		"issueFirst":    githubv4.Int(1),
 		"issueAfter":    (*githubv4.String)(nil),
 		"timelineFirst": githubv4.Int(10),
@@ -69,7 +93,10 @@ func (gi *githubImporter) ImportAll(repo *cache.RepoCache) error {
 		}
 
 		for _, itemEdge := range q.Repository.Issues.Nodes[0].Timeline.Edges {
-			gi.ensureTimelineItem(b, itemEdge.Cursor, itemEdge.Node, variables)
+			err = gi.ensureTimelineItem(b, itemEdge.Cursor, itemEdge.Node, variables)
+			if err != nil {
+				return err
+			}
 		}
 
 		if !issue.Timeline.PageInfo.HasNextPage {
@@ -124,7 +151,7 @@ func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline
 		if err == bug.ErrBugNotExist {
 			b, err = repo.NewBugRaw(
 				gi.makePerson(issue.Author),
-				issue.CreatedAt.Unix(),
+				issue.CreatedAt.Time,
 				// Todo: this might not be the initial title, we need to query the
 				// timeline to be sure
 				issue.Title,
@@ -162,7 +189,7 @@ func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline
 			// we create the bug as soon as we have a legit first edition
 			b, err = repo.NewBugRaw(
 				gi.makePerson(issue.Author),
-				issue.CreatedAt.Unix(),
+				issue.CreatedAt.Time,
 				// Todo: this might not be the initial title, we need to query the
 				// timeline to be sure
 				issue.Title,
@@ -195,7 +222,7 @@ func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline
 		if b == nil {
 			return repo.NewBugRaw(
 				gi.makePerson(issue.Author),
-				issue.CreatedAt.Unix(),
+				issue.CreatedAt.Time,
 				// Todo: this might not be the initial title, we need to query the
 				// timeline to be sure
 				issue.Title,
@@ -216,6 +243,7 @@ func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline
 	variables := map[string]interface{}{
 		"owner":           rootVariables["owner"],
 		"name":            rootVariables["name"],
+		"since":           rootVariables["since"],
 		"issueFirst":      rootVariables["issueFirst"],
 		"issueAfter":      rootVariables["issueAfter"],
 		"issueEditLast":   githubv4.Int(10),
@@ -244,7 +272,7 @@ func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline
 				// we create the bug as soon as we have a legit first edition
 				b, err = repo.NewBugRaw(
 					gi.makePerson(issue.Author),
-					issue.CreatedAt.Unix(),
+					issue.CreatedAt.Time,
 					// Todo: this might not be the initial title, we need to query the
 					// timeline to be sure
 					issue.Title,
@@ -285,7 +313,7 @@ func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline
 	if b == nil {
 		return repo.NewBugRaw(
 			gi.makePerson(issue.Author),
-			issue.CreatedAt.Unix(),
+			issue.CreatedAt.Time,
 			// Todo: this might not be the initial title, we need to query the
 			// timeline to be sure
 			issue.Title,
@@ -304,6 +332,10 @@ func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline
 func (gi *githubImporter) ensureTimelineItem(b *cache.BugCache, cursor githubv4.String, item timelineItem, rootVariables map[string]interface{}) error {
 	fmt.Printf("import %s\n", item.Typename)
 
+	// the changes exported from git-bug come back as new events, already
+	// applied locally
+	snap := b.Snapshot()
+
 	switch item.Typename {
 	case "IssueComment":
 		return gi.ensureComment(b, cursor, item.IssueComment, rootVariables)
@@ -314,9 +346,12 @@ func (gi *githubImporter) ensureTimelineItem(b *cache.BugCache, cursor githubv4.
 		if err != cache.ErrNoMatchingOp {
 			return err
 		}
+		if hasLabel(snap.Labels, string(item.LabeledEvent.Label.Name)) {
+			return nil
+		}
 		_, err = b.ChangeLabelsRaw(
 			gi.makePerson(item.LabeledEvent.Actor),
-			item.LabeledEvent.CreatedAt.Unix(),
+			item.LabeledEvent.CreatedAt.Time,
 			[]string{
 				string(item.LabeledEvent.Label.Name),
 			},
@@ -331,9 +366,12 @@ func (gi *githubImporter) ensureTimelineItem(b *cache.BugCache, cursor githubv4.
 		if err != cache.ErrNoMatchingOp {
 			return err
 		}
+		if !hasLabel(snap.Labels, string(item.UnlabeledEvent.Label.Name)) {
+			return nil
+		}
 		_, err = b.ChangeLabelsRaw(
 			gi.makePerson(item.UnlabeledEvent.Actor),
-			item.UnlabeledEvent.CreatedAt.Unix(),
+			item.UnlabeledEvent.CreatedAt.Time,
 			nil,
 			[]string{
 				string(item.UnlabeledEvent.Label.Name),
@@ -348,9 +386,12 @@ func (gi *githubImporter) ensureTimelineItem(b *cache.BugCache, cursor githubv4.
 		if err != cache.ErrNoMatchingOp {
 			return err
 		}
+		if snap.Status == bug.ClosedStatus {
+			return nil
+		}
 		return b.CloseRaw(
 			gi.makePerson(item.ClosedEvent.Actor),
-			item.ClosedEvent.CreatedAt.Unix(),
+			item.ClosedEvent.CreatedAt.Time,
 			map[string]string{keyGithubId: id},
 		)
 
@@ -360,9 +401,12 @@ func (gi *githubImporter) ensureTimelineItem(b *cache.BugCache, cursor githubv4.
 		if err != cache.ErrNoMatchingOp {
 			return err
 		}
+		if snap.Status == bug.OpenStatus {
+			return nil
+		}
 		return b.OpenRaw(
 			gi.makePerson(item.ReopenedEvent.Actor),
-			item.ReopenedEvent.CreatedAt.Unix(),
+			item.ReopenedEvent.CreatedAt.Time,
 			map[string]string{keyGithubId: id},
 		)
 
@@ -372,9 +416,12 @@ func (gi *githubImporter) ensureTimelineItem(b *cache.BugCache, cursor githubv4.
 		if err != cache.ErrNoMatchingOp {
 			return err
 		}
+		if snap.Title == string(item.RenamedTitleEvent.CurrentTitle) {
+			return nil
+		}
 		return b.SetTitleRaw(
 			gi.makePerson(item.RenamedTitleEvent.Actor),
-			item.RenamedTitleEvent.CreatedAt.Unix(),
+			item.RenamedTitleEvent.CreatedAt.Time,
 			string(item.RenamedTitleEvent.CurrentTitle),
 			map[string]string{keyGithubId: id},
 		)
@@ -408,7 +455,7 @@ func (gi *githubImporter) ensureComment(b *cache.BugCache, cursor githubv4.Strin
 		if err == cache.ErrNoMatchingOp {
 			err = b.AddCommentRaw(
 				gi.makePerson(comment.Author),
-				comment.CreatedAt.Unix(),
+				comment.CreatedAt.Time,
 				cleanupText(string(comment.Body)),
 				nil,
 				map[string]string{
@@ -441,7 +488,7 @@ func (gi *githubImporter) ensureComment(b *cache.BugCache, cursor githubv4.Strin
 
 			err = b.AddCommentRaw(
 				gi.makePerson(comment.Author),
-				comment.CreatedAt.Unix(),
+				comment.CreatedAt.Time,
 				cleanupText(string(*edit.Diff)),
 				nil,
 				map[string]string{
@@ -470,6 +517,7 @@ func (gi *githubImporter) ensureComment(b *cache.BugCache, cursor githubv4.Strin
 	variables := map[string]interface{}{
 		"owner":             rootVariables["owner"],
 		"name":              rootVariables["name"],
+		"since":             rootVariables["since"],
 		"issueFirst":        rootVariables["issueFirst"],
 		"issueAfter":        rootVariables["issueAfter"],
 		"timelineFirst":     githubv4.Int(1),
@@ -545,7 +593,7 @@ func (gi *githubImporter) ensureCommentEdit(b *cache.BugCache, target git.Hash,
 		// comment edition
 		err := b.EditCommentRaw(
 			gi.makePerson(edit.Editor),
-			edit.CreatedAt.Unix(),
+			edit.CreatedAt.Time,
 			target,
 			cleanupText(string(*edit.Diff)),
 			map[string]string{
@@ -560,11 +608,16 @@ func (gi *githubImporter) ensureCommentEdit(b *cache.BugCache, target git.Hash,
 	return nil
 }
 
-// makePerson create a bug.Person from the Github data
+// makePerson create a bug.Person from the Github data, or return the known
+// identity of the github user
 func (gi *githubImporter) makePerson(actor *actor) bug.Person {
 	if actor == nil {
 		return gi.ghost
 	}
+
+	if person, ok := gi.identities[string(actor.Login)]; ok {
+		return person
+	}
 	var name string
 	var email string
 
@@ -592,6 +645,52 @@ func (gi *githubImporter) makePerson(actor *actor) bug.Person {
 	}
 }
 
+// loadIdentities map the github users to the identities of the repository:
+// the owner of the token is the user of the repository, and a login already
+// known keep its identity, even if the user changed its name or avatar since
+func (gi *githubImporter) loadIdentities(repo *cache.RepoCache) error {
+	gi.identities = make(map[string]bug.Person)
+
+	// the ids are sorted, for the choice to be stable when several identities
+	// share a login
+	ids := repo.AllIdentityIds()
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		excerpt, err := repo.ResolveIdentity(id)
+		if err != nil {
+			return err
+		}
+		if excerpt.Login == "" {
+			continue
+		}
+		if _, ok := gi.identities[excerpt.Login]; !ok {
+			gi.identities[excerpt.Login] = excerpt.Person()
+		}
+	}
+
+	// without a configured user, the owner of the token is imported like the
+	// others
+	if user, err := repo.GetUser(); err == nil && gi.viewer != "" {
+		gi.identities[gi.viewer] = user
+	}
+
+	return nil
+}
+
+func (gi *githubImporter) fetchViewer() error {
+	var q viewerQuery
+
+	err := gi.client.Query(context.TODO(), &q, nil)
+	if err != nil {
+		return err
+	}
+
+	gi.viewer = string(q.Viewer.Login)
+
+	return nil
+}
+
 func (gi *githubImporter) fetchGhost() error {
 	var q userQuery
 
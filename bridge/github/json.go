@@ -0,0 +1,283 @@
+package github
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the JSON bridge read and write the issues as a JSON file, in the format of
+// the GitHub REST API, as given by the API itself, by "gh issue list --json"
+// or in the migration archives, without talking to GitHub
+
+const keyJsonPath = "path"
+
+// keyGithubNumber is the metadata holding the number of an issue, to find it
+// again when neither its id nor its url are known
+const keyGithubNumber = "github-number"
+
+func init() {
+	core.Register(&GithubJson{})
+}
+
+type GithubJson struct{}
+
+func (*GithubJson) Target() string {
+	return "github-json"
+}
+
+func (*GithubJson) NewImporter() core.Importer {
+	return &jsonImporter{}
+}
+
+func (*GithubJson) NewExporter() core.Exporter {
+	return &jsonExporter{}
+}
+
+func (*GithubJson) Configure(repo repository.RepoCommon) (core.Configuration, error) {
+	conf := make(core.Configuration)
+
+	for {
+		fmt.Print("Path of the JSON file of the issues: ")
+
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			fmt.Println("Value is empty")
+			continue
+		}
+
+		conf[keyJsonPath] = line
+		return conf, nil
+	}
+}
+
+func (*GithubJson) ValidateConfig(conf core.Configuration) error {
+	if _, ok := conf[keyJsonPath]; !ok {
+		return fmt.Errorf("missing %s key", keyJsonPath)
+	}
+
+	return nil
+}
+
+// jsonIssue is an issue in the GitHub REST format. The variants of the other
+// sources are read as well, and the exported issues also hold their git-bug id
+// to be imported back in the same repository.
+type jsonIssue struct {
+	GitBugId string `json:"git_bug_id,omitempty"`
+	Number   int    `json:"number"`
+	// Id is a number in the REST API, and the node id for "gh"
+	Id      json.RawMessage `json:"id,omitempty"`
+	NodeId  string          `json:"node_id,omitempty"`
+	Url     string          `json:"url,omitempty"`
+	HtmlUrl string          `json:"html_url,omitempty"`
+	Title   string          `json:"title"`
+	Body    string          `json:"body"`
+	State   string          `json:"state"`
+	User    *jsonUser       `json:"user,omitempty"`
+	// Author is the user for "gh"
+	Author *jsonUser    `json:"author,omitempty"`
+	Labels []jsonLabel  `json:"labels"`
+	// Comments is only a count in the REST API
+	Comments  jsonComments `json:"comments"`
+	CreatedAt *time.Time   `json:"created_at,omitempty"`
+	UpdatedAt *time.Time   `json:"updated_at,omitempty"`
+	ClosedAt  *time.Time   `json:"closed_at,omitempty"`
+	// the timestamps for "gh"
+	CreatedAtGh *time.Time `json:"createdAt,omitempty"`
+	UpdatedAtGh *time.Time `json:"updatedAt,omitempty"`
+	ClosedAtGh  *time.Time `json:"closedAt,omitempty"`
+}
+
+type jsonComment struct {
+	GitBugId    string          `json:"git_bug_id,omitempty"`
+	Id          json.RawMessage `json:"id,omitempty"`
+	NodeId      string          `json:"node_id,omitempty"`
+	Url         string          `json:"url,omitempty"`
+	HtmlUrl     string          `json:"html_url,omitempty"`
+	Body        string          `json:"body"`
+	User        *jsonUser       `json:"user,omitempty"`
+	Author      *jsonUser       `json:"author,omitempty"`
+	CreatedAt   *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time      `json:"updated_at,omitempty"`
+	CreatedAtGh *time.Time      `json:"createdAt,omitempty"`
+}
+
+type jsonUser struct {
+	Login     string `json:"login"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	AvatarUrl string `json:"avatar_url,omitempty"`
+}
+
+type jsonLabel struct {
+	Name string `json:"name"`
+}
+
+type jsonComments []jsonComment
+
+// UnmarshalJSON accept the url of the user, as in the migration archives
+func (u *jsonUser) UnmarshalJSON(data []byte) error {
+	var url string
+	if json.Unmarshal(data, &url) == nil {
+		*u = jsonUser{Login: path.Base(url)}
+		return nil
+	}
+
+	type plain jsonUser
+	return json.Unmarshal(data, (*plain)(u))
+}
+
+// UnmarshalJSON accept the url of the label, as in the migration archives
+func (l *jsonLabel) UnmarshalJSON(data []byte) error {
+	var url string
+	if json.Unmarshal(data, &url) == nil {
+		*l = jsonLabel{Name: path.Base(url)}
+		return nil
+	}
+
+	type plain jsonLabel
+	return json.Unmarshal(data, (*plain)(l))
+}
+
+// UnmarshalJSON ignore the number of comments given by the REST API
+func (c *jsonComments) UnmarshalJSON(data []byte) error {
+	var count int
+	if json.Unmarshal(data, &count) == nil {
+		*c = nil
+		return nil
+	}
+
+	var comments []jsonComment
+	err := json.Unmarshal(data, &comments)
+	if err != nil {
+		return err
+	}
+
+	*c = comments
+	return nil
+}
+
+// readJsonIssues read a list of issues, or a single issue
+func readJsonIssues(data []byte) ([]jsonIssue, error) {
+	var issues []jsonIssue
+	err := json.Unmarshal(data, &issues)
+	if err == nil {
+		return issues, nil
+	}
+
+	var issue jsonIssue
+	if json.Unmarshal(data, &issue) == nil && issue.Title != "" {
+		return []jsonIssue{issue}, nil
+	}
+
+	return nil, err
+}
+
+// stringId return the id when it's a node id, given as a string
+func stringId(raw json.RawMessage) string {
+	var id string
+	if json.Unmarshal(raw, &id) == nil {
+		return id
+	}
+	return ""
+}
+
+func firstTime(times ...*time.Time) time.Time {
+	for _, t := range times {
+		if t != nil && !t.IsZero() {
+			return *t
+		}
+	}
+	return time.Time{}
+}
+
+func (i *jsonIssue) nodeId() string {
+	if i.NodeId != "" {
+		return i.NodeId
+	}
+	return stringId(i.Id)
+}
+
+// webUrl return the url of the issue on the website, the REST API having its
+// own url
+func (i *jsonIssue) webUrl() string {
+	if i.HtmlUrl != "" {
+		return i.HtmlUrl
+	}
+	if strings.HasPrefix(i.Url, githubV3Url) {
+		return ""
+	}
+	return i.Url
+}
+
+func (i *jsonIssue) author() *jsonUser {
+	if i.User != nil {
+		return i.User
+	}
+	return i.Author
+}
+
+func (i *jsonIssue) createdAt() time.Time {
+	return firstTime(i.CreatedAt, i.CreatedAtGh)
+}
+
+// updatedAt return the last time the issue changed, used for the changes
+// found on an issue already imported
+func (i *jsonIssue) updatedAt() time.Time {
+	return firstTime(i.UpdatedAt, i.UpdatedAtGh, i.CreatedAt, i.CreatedAtGh)
+}
+
+func (i *jsonIssue) closedAt() time.Time {
+	return firstTime(i.ClosedAt, i.ClosedAtGh, i.UpdatedAt, i.UpdatedAtGh)
+}
+
+func (c *jsonComment) nodeId() string {
+	if c.NodeId != "" {
+		return c.NodeId
+	}
+	return stringId(c.Id)
+}
+
+func (c *jsonComment) author() *jsonUser {
+	if c.User != nil {
+		return c.User
+	}
+	return c.Author
+}
+
+// person convert a user to a person, the deleted users being the ghost user
+// as on GitHub
+func (u *jsonUser) person() bug.Person {
+	if u == nil || (u.Login == "" && u.Name == "") {
+		return bug.Person{Name: "Ghost", Login: "ghost"}
+	}
+
+	return bug.Person{
+		Name:      u.Name,
+		Email:     u.Email,
+		Login:     u.Login,
+		AvatarUrl: u.AvatarUrl,
+	}
+}
+
+func newJsonUser(p bug.Person) *jsonUser {
+	return &jsonUser{
+		Login:     p.Login,
+		Name:      p.Name,
+		Email:     p.Email,
+		AvatarUrl: p.AvatarUrl,
+	}
+}
@@ -0,0 +1,186 @@
+package github
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/pkg/errors"
+)
+
+// jsonExporter implement the Exporter interface
+type jsonExporter struct {
+	conf core.Configuration
+}
+
+func (je *jsonExporter) Init(conf core.Configuration) error {
+	je.conf = conf
+	return nil
+}
+
+// ExportAll write every bug in the file, replacing its content
+func (je *jsonExporter) ExportAll(repo *cache.RepoCache, since time.Time) error {
+	query := cache.NewQuery()
+	query.OrderBy = cache.OrderByCreation
+
+	var issues []jsonIssue
+
+	for _, id := range repo.QueryBugs(query) {
+		b, err := repo.ResolveBug(id)
+		if err != nil {
+			return err
+		}
+		issues = append(issues, newJsonIssue(repo, b))
+	}
+
+	return je.write(issues)
+}
+
+// Export write a single bug in the file, replacing its previous version if
+// any and keeping the other issues
+func (je *jsonExporter) Export(repo *cache.RepoCache, id string) error {
+	b, err := repo.ResolveBugPrefix(id)
+	if err != nil {
+		return err
+	}
+
+	var issues []jsonIssue
+
+	data, err := ioutil.ReadFile(je.conf[keyJsonPath])
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		issues, err = readJsonIssues(data)
+		if err != nil {
+			return errors.Wrap(err, "failed to read the issues")
+		}
+	}
+
+	issue := newJsonIssue(repo, b)
+
+	replaced := false
+	for i := range issues {
+		if issues[i].GitBugId == b.Id() {
+			issues[i] = issue
+			replaced = true
+		}
+	}
+	if !replaced {
+		issues = append(issues, issue)
+	}
+
+	return je.write(issues)
+}
+
+func (je *jsonExporter) write(issues []jsonIssue) error {
+	if issues == nil {
+		issues = []jsonIssue{}
+	}
+
+	data, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(je.conf[keyJsonPath], data, 0644)
+}
+
+// newJsonIssue convert a bug to an issue. The comments are given in full
+// rather than as a count, as "gh" does.
+func newJsonIssue(repo *cache.RepoCache, b *cache.BugCache) jsonIssue {
+	snap := b.Snapshot()
+
+	createMetadata := snap.Operations[0].AllMetadata()
+
+	issue := jsonIssue{
+		GitBugId: b.Id(),
+		Number:   issueNumber(repo, b.Id(), createMetadata),
+		NodeId:   createMetadata[keyGithubId],
+		HtmlUrl:  createMetadata[keyGithubUrl],
+		Title:    snap.Title,
+		State:    snap.Status.String(),
+		User:     newJsonUser(snap.Author),
+		Labels:   []jsonLabel{},
+		Comments: jsonComments{},
+	}
+
+	createdAt := snap.CreatedAt.UTC()
+	updatedAt := snap.LastEditTime().UTC()
+	issue.CreatedAt = &createdAt
+	issue.UpdatedAt = &updatedAt
+
+	if snap.Status == bug.ClosedStatus {
+		closedAt := updatedAt
+		for _, op := range snap.Operations {
+			if op, ok := op.(*bug.SetStatusOperation); ok && op.Status == bug.ClosedStatus {
+				closedAt = op.Time().UTC()
+			}
+		}
+		issue.ClosedAt = &closedAt
+	}
+
+	for _, label := range snap.Labels {
+		issue.Labels = append(issue.Labels, jsonLabel{Name: label.String()})
+	}
+
+	metadata := make(map[git.Hash]map[string]string)
+	for _, op := range snap.Operations {
+		hash, err := op.Hash()
+		if err == nil {
+			metadata[hash] = op.AllMetadata()
+		}
+	}
+
+	for _, item := range snap.Timeline {
+		switch item := item.(type) {
+		case *bug.CreateTimelineItem:
+			issue.Body = item.Message
+
+		case *bug.AddCommentTimelineItem:
+			createdAt := item.CreatedAt.Time().UTC()
+			updatedAt := item.LastEdit.Time().UTC()
+			issue.Comments = append(issue.Comments, jsonComment{
+				GitBugId:  string(item.Hash()),
+				NodeId:    metadata[item.Hash()][keyGithubId],
+				Body:      item.Message,
+				User:      newJsonUser(item.Author),
+				CreatedAt: &createdAt,
+				UpdatedAt: &updatedAt,
+			})
+		}
+	}
+
+	return issue
+}
+
+// issueNumber return the number of the issue on GitHub if the bug has been
+// imported, or its alias otherwise
+func issueNumber(repo *cache.RepoCache, id string, createMetadata map[string]string) int {
+	if number, err := strconv.Atoi(createMetadata[keyGithubNumber]); err == nil {
+		return number
+	}
+
+	// imported by the github bridge, with the url of the issue
+	if url := createMetadata[keyGithubUrl]; strings.Contains(url, "/issues/") {
+		if number, err := strconv.Atoi(path.Base(url)); err == nil {
+			return number
+		}
+	}
+
+	if alias, ok := repo.Alias(id); ok {
+		if number, err := strconv.Atoi(strings.TrimPrefix(alias, bug.AliasPrefix)); err == nil {
+			return number
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,270 @@
+package github
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/pkg/errors"
+)
+
+// keyGithubCommentHash is the metadata identifying a comment given without id
+// nor url, from a hash of its content
+const keyGithubCommentHash = "github-comment-hash"
+
+// jsonImporter implement the Importer interface
+type jsonImporter struct {
+	conf core.Configuration
+}
+
+func (ji *jsonImporter) Init(conf core.Configuration) error {
+	ji.conf = conf
+	return nil
+}
+
+// ImportAll import every issue of the file, the already imported comments
+// being skipped
+func (ji *jsonImporter) ImportAll(repo *cache.RepoCache, since time.Time) error {
+	return ji.importIssues(repo, func(issue *jsonIssue) bool { return true })
+}
+
+// Import import the issue of the file with the given number
+func (ji *jsonImporter) Import(repo *cache.RepoCache, id string) error {
+	number, err := strconv.Atoi(strings.TrimPrefix(id, "#"))
+	if err != nil {
+		return fmt.Errorf("invalid issue number %s", id)
+	}
+
+	return ji.importIssues(repo, func(issue *jsonIssue) bool { return issue.Number == number })
+}
+
+func (ji *jsonImporter) importIssues(repo *cache.RepoCache, selected func(issue *jsonIssue) bool) error {
+	data, err := ioutil.ReadFile(ji.conf[keyJsonPath])
+	if err != nil {
+		return err
+	}
+
+	issues, err := readJsonIssues(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the issues")
+	}
+
+	// the oldest first, for the bugs to be numbered in the same order
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].createdAt().Before(issues[j].createdAt())
+	})
+
+	for i := range issues {
+		issue := &issues[i]
+		if !selected(issue) {
+			continue
+		}
+
+		err := ji.importIssue(repo, issue)
+		if err != nil {
+			return errors.Wrapf(err, "issue #%d", issue.Number)
+		}
+	}
+
+	return nil
+}
+
+func (ji *jsonImporter) importIssue(repo *cache.RepoCache, issue *jsonIssue) error {
+	b, err := ji.ensureIssue(repo, issue)
+	if err != nil {
+		return err
+	}
+
+	for i := range issue.Comments {
+		err := ji.ensureComment(b, &issue.Comments[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	err = ji.ensureState(b, issue)
+	if err != nil {
+		return err
+	}
+
+	return b.CommitAsNeeded()
+}
+
+// resolveIssue find the bug of an issue already imported, or exported from
+// this repository
+func resolveIssue(repo *cache.RepoCache, issue *jsonIssue) (*cache.BugCache, error) {
+	if issue.GitBugId != "" {
+		b, err := repo.ResolveBugPrefix(issue.GitBugId)
+		if err != bug.ErrBugNotExist {
+			return b, err
+		}
+	}
+
+	lookups := []struct{ key, value string }{
+		{keyGithubId, issue.nodeId()},
+		{keyGithubUrl, issue.webUrl()},
+	}
+	if issue.Number > 0 {
+		lookups = append(lookups, struct{ key, value string }{keyGithubNumber, strconv.Itoa(issue.Number)})
+	}
+
+	for _, lookup := range lookups {
+		if lookup.value == "" {
+			continue
+		}
+		b, err := repo.ResolveBugCreateMetadata(lookup.key, lookup.value)
+		if err != bug.ErrBugNotExist {
+			return b, err
+		}
+	}
+
+	return nil, bug.ErrBugNotExist
+}
+
+func (ji *jsonImporter) ensureIssue(repo *cache.RepoCache, issue *jsonIssue) (*cache.BugCache, error) {
+	b, err := resolveIssue(repo, issue)
+	if err == nil {
+		return b, nil
+	}
+	if err != bug.ErrBugNotExist {
+		return nil, err
+	}
+
+	metadata := make(map[string]string)
+	if id := issue.nodeId(); id != "" {
+		metadata[keyGithubId] = id
+	}
+	if url := issue.webUrl(); url != "" {
+		metadata[keyGithubUrl] = url
+	}
+	if issue.Number > 0 {
+		metadata[keyGithubNumber] = strconv.Itoa(issue.Number)
+	}
+
+	return repo.NewBugRaw(
+		issue.author().person(),
+		issue.createdAt(),
+		issue.Title,
+		cleanupText(issue.Body),
+		nil,
+		metadata,
+	)
+}
+
+// commentKey return the metadata identifying a comment
+func commentKey(comment *jsonComment) (string, string) {
+	if id := comment.nodeId(); id != "" {
+		return keyGithubId, id
+	}
+
+	if comment.HtmlUrl != "" {
+		return keyGithubUrl, comment.HtmlUrl
+	}
+
+	data := fmt.Sprintf("%s\n%d\n%s",
+		comment.author().Login,
+		firstTime(comment.CreatedAt, comment.CreatedAtGh).Unix(),
+		comment.Body,
+	)
+	return keyGithubCommentHash, fmt.Sprintf("%x", sha256.Sum256([]byte(data)))
+}
+
+func (ji *jsonImporter) ensureComment(b *cache.BugCache, comment *jsonComment) error {
+	if comment.GitBugId != "" {
+		_, err := b.Snapshot().SearchTimelineItem(git.Hash(comment.GitBugId))
+		if err == nil {
+			// exported from this bug
+			return nil
+		}
+	}
+
+	key, value := commentKey(comment)
+
+	_, err := b.ResolveTargetWithMetadata(key, value)
+	if err == nil {
+		// already imported
+		return nil
+	}
+	if err != cache.ErrNoMatchingOp {
+		return err
+	}
+
+	message := cleanupText(comment.Body)
+	if message == "" {
+		return nil
+	}
+
+	return b.AddCommentRaw(
+		comment.author().person(),
+		firstTime(comment.CreatedAt, comment.CreatedAtGh),
+		message,
+		nil,
+		map[string]string{key: value},
+	)
+}
+
+// ensureState apply the title, the labels and the status of the issue, as
+// they were when the file was written
+func (ji *jsonImporter) ensureState(b *cache.BugCache, issue *jsonIssue) error {
+	snap := b.Snapshot()
+	// the author of a change is unknown, the author of the issue is the best
+	// guess
+	author := issue.author().person()
+	date := issue.updatedAt()
+
+	if issue.Title != "" && issue.Title != snap.Title {
+		err := b.SetTitleRaw(author, date, issue.Title, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	wanted := make(map[string]bool, len(issue.Labels))
+	var added []string
+	for _, label := range issue.Labels {
+		wanted[label.Name] = true
+		if !hasLabel(snap.Labels, label.Name) {
+			added = append(added, label.Name)
+		}
+	}
+
+	var removed []string
+	for _, label := range snap.Labels {
+		if !wanted[label.String()] {
+			removed = append(removed, label.String())
+		}
+	}
+
+	if len(added) > 0 || len(removed) > 0 {
+		_, err := b.ChangeLabelsRaw(author, date, added, removed, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case strings.EqualFold(issue.State, "closed") && snap.Status != bug.ClosedStatus:
+		return b.CloseRaw(author, issue.closedAt(), nil)
+	case strings.EqualFold(issue.State, "open") && snap.Status != bug.OpenStatus:
+		return b.OpenRaw(author, date, nil)
+	}
+
+	return nil
+}
+
+func hasLabel(labels []bug.Label, name string) bool {
+	for _, label := range labels {
+		if label.String() == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package github
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// restIssues are issues as given by the REST API, the comments being added
+// as in the migration archives
+const restIssues = `[
+  {
+    "id": 1,
+    "node_id": "MDU6SXNzdWUx",
+    "number": 1347,
+    "url": "https://api.github.com/repos/octocat/Hello-World/issues/1347",
+    "html_url": "https://github.com/octocat/Hello-World/issues/1347",
+    "title": "Found a bug",
+    "body": "I'm having a problem with this.\r\n",
+    "state": "closed",
+    "user": {"login": "octocat", "avatar_url": "https://github.com/images/error/octocat_happy.gif"},
+    "labels": [{"name": "bug"}, "https://github.com/octocat/Hello-World/labels/ui"],
+    "comments": [
+      {
+        "node_id": "MDEyOklzc3VlQ29tbWVudDE=",
+        "body": "Me too",
+        "user": "https://github.com/hubot",
+        "created_at": "2011-04-14T16:00:49Z"
+      }
+    ],
+    "created_at": "2011-04-10T20:09:31Z",
+    "updated_at": "2011-04-15T20:09:31Z",
+    "closed_at": "2011-04-15T20:09:31Z"
+  }
+]`
+
+// ghIssues are issues as given by "gh issue list --json"
+const ghIssues = `[
+  {
+    "id": "I_kwDOAbc",
+    "number": 2,
+    "url": "https://github.com/octocat/Hello-World/issues/2",
+    "title": "Add a feature",
+    "body": "Please",
+    "state": "OPEN",
+    "author": {"login": "monalisa", "name": "Mona Lisa"},
+    "labels": [],
+    "comments": [
+      {"id": "IC_kwDOAbc", "author": {"login": "octocat"}, "body": "Sure", "createdAt": "2021-01-02T10:00:00Z"}
+    ],
+    "createdAt": "2021-01-01T10:00:00Z"
+  }
+]`
+
+func newTestRepo(t *testing.T, dir string) *cache.RepoCache {
+	repo, err := repository.InitGitRepo(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.StoreConfig("user.name", "testuser"))
+	assert.NoError(t, repo.StoreConfig("user.email", "testuser@example.com"))
+
+	backend, err := cache.NewRepoCache(repo)
+	assert.NoError(t, err)
+
+	return backend
+}
+
+func TestJsonImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backend := newTestRepo(t, filepath.Join(dir, "repo"))
+	defer backend.Close()
+
+	for _, content := range []string{restIssues, ghIssues} {
+		path := filepath.Join(dir, "issues.json")
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+
+		importer := &jsonImporter{}
+		assert.NoError(t, importer.Init(core.Configuration{keyJsonPath: path}))
+
+		// importing twice doesn't duplicate anything
+		assert.NoError(t, importer.ImportAll(backend, time.Time{}))
+		assert.NoError(t, importer.ImportAll(backend, time.Time{}))
+	}
+
+	assert.Len(t, backend.AllBugsIds(), 2)
+
+	b, err := backend.ResolveBugCreateMetadata(keyGithubId, "MDU6SXNzdWUx")
+	assert.NoError(t, err)
+	snap := b.Snapshot()
+	assert.Equal(t, "Found a bug", snap.Title)
+	assert.Equal(t, "I'm having a problem with this.", snap.Comments[0].Message)
+	assert.Equal(t, "octocat", snap.Author.Login)
+	assert.Equal(t, bug.ClosedStatus, snap.Status)
+	assert.Equal(t, []bug.Label{"bug", "ui"}, snap.Labels)
+	assert.Len(t, snap.Comments, 2)
+	assert.Equal(t, "hubot", snap.Comments[1].Author.Login)
+
+	b, err = backend.ResolveBugCreateMetadata(keyGithubUrl, "https://github.com/octocat/Hello-World/issues/2")
+	assert.NoError(t, err)
+	snap = b.Snapshot()
+	assert.Equal(t, "Mona Lisa", snap.Author.Name)
+	assert.Equal(t, bug.OpenStatus, snap.Status)
+	assert.Len(t, snap.Comments, 2)
+}
+
+func TestJsonRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "issues.json")
+	conf := core.Configuration{keyJsonPath: path}
+
+	source := newTestRepo(t, filepath.Join(dir, "source"))
+	defer source.Close()
+
+	b, err := source.NewBug("local bug", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, b.AddComment("a comment"))
+	_, err = b.ChangeLabels([]string{"bug"}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, b.Close())
+
+	exporter := &jsonExporter{}
+	assert.NoError(t, exporter.Init(conf))
+	assert.NoError(t, exporter.ExportAll(source, time.Time{}))
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	issues, err := readJsonIssues(data)
+	assert.NoError(t, err)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, 1, issues[0].Number)
+	assert.Equal(t, "closed", issues[0].State)
+	assert.NotNil(t, issues[0].ClosedAt)
+
+	importer := &jsonImporter{}
+	assert.NoError(t, importer.Init(conf))
+
+	// importing back in the same repository change nothing
+	assert.NoError(t, importer.ImportAll(source, time.Time{}))
+	assert.Len(t, source.AllBugsIds(), 1)
+	assert.Len(t, b.Snapshot().Comments, 2)
+	assert.Len(t, b.Snapshot().Operations, 4)
+
+	target := newTestRepo(t, filepath.Join(dir, "target"))
+	defer target.Close()
+
+	assert.NoError(t, importer.ImportAll(target, time.Time{}))
+	assert.NoError(t, importer.ImportAll(target, time.Time{}))
+
+	ids := target.AllBugsIds()
+	assert.Len(t, ids, 1)
+	imported, err := target.ResolveBug(ids[0])
+	assert.NoError(t, err)
+
+	snap := imported.Snapshot()
+	assert.Equal(t, "local bug", snap.Title)
+	assert.Equal(t, "a comment", snap.Comments[1].Message)
+	assert.Equal(t, []bug.Label{"bug"}, snap.Labels)
+	assert.Equal(t, bug.ClosedStatus, snap.Status)
+}
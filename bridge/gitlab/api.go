@@ -0,0 +1,189 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// the page size of the listings
+const perPage = 100
+
+// the parts of the objects given by the API v4 that matter for the bridge
+
+type apiUser struct {
+	Id        int    `json:"id"`
+	Username  string `json:"username"`
+	Name      string `json:"name"`
+	AvatarUrl string `json:"avatar_url"`
+}
+
+type apiIssue struct {
+	Id          int       `json:"id"`
+	Iid         int       `json:"iid"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	State       string    `json:"state"`
+	Labels      []string  `json:"labels"`
+	Author      *apiUser  `json:"author"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	WebUrl      string    `json:"web_url"`
+}
+
+type apiNote struct {
+	Id        int       `json:"id"`
+	Body      string    `json:"body"`
+	Author    *apiUser  `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+	// System is true for the notes recording a change of the issue, like
+	// "closed"
+	System bool `json:"system"`
+}
+
+type apiLabelEvent struct {
+	Id        int       `json:"id"`
+	User      *apiUser  `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+	Label     *struct {
+		Name string `json:"name"`
+	} `json:"label"`
+	// Action is "add" or "remove"
+	Action string `json:"action"`
+}
+
+// the states of the issues, as named by GitLab
+const (
+	stateOpened = "opened"
+	stateClosed = "closed"
+)
+
+// client call the API v4 of a GitLab instance, on a project
+type client struct {
+	http    *http.Client
+	apiUrl  string
+	project string
+	token   string
+}
+
+func newClient(conf core.Configuration) *client {
+	return &client{
+		http:    &http.Client{Timeout: 30 * time.Second},
+		apiUrl:  baseUrl(conf) + "/api/v4",
+		project: conf[keyProject],
+		token:   conf[keyToken],
+	}
+}
+
+// projectPath return the path of an endpoint of the project
+func (c *client) projectPath(format string, args ...interface{}) string {
+	return "/projects/" + url.PathEscape(c.project) + fmt.Sprintf(format, args...)
+}
+
+// currentUser return the owner of the token
+func (c *client) currentUser() (*apiUser, error) {
+	var user apiUser
+	_, err := c.do(http.MethodGet, "/user", nil, nil, &user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// list read all the pages of a listing into result, which must be a pointer
+// to a slice
+func (c *client) list(path string, query url.Values, result interface{}) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("per_page", strconv.Itoa(perPage))
+
+	var items []json.RawMessage
+	page := "1"
+
+	for page != "" {
+		query.Set("page", page)
+
+		var pageItems []json.RawMessage
+		header, err := c.do(http.MethodGet, path, query, nil, &pageItems)
+		if err != nil {
+			return err
+		}
+
+		items = append(items, pageItems...)
+		page = header.Get("X-Next-Page")
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, result)
+}
+
+func (c *client) do(method string, path string, query url.Values, body interface{}, result interface{}) (http.Header, error) {
+	u := c.apiUrl + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest(method, u, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	if result == nil {
+		return resp.Header, nil
+	}
+
+	return resp.Header, json.Unmarshal(respBody, result)
+}
+
+// person create a bug.Person from the GitLab data. GitLab doesn't give the
+// emails of the users.
+func (u *apiUser) person() bug.Person {
+	return bug.Person{
+		Name:      u.Name,
+		Login:     u.Username,
+		AvatarUrl: u.AvatarUrl,
+	}
+}
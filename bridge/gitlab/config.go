@@ -0,0 +1,179 @@
+package gitlab
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/repository"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const keyBaseUrl = "base-url"
+const keyProject = "project"
+const keyToken = "token"
+
+const defaultBaseUrl = "https://gitlab.com"
+
+func (*Gitlab) Configure(repo repository.RepoCommon) (core.Configuration, error) {
+	conf := make(core.Configuration)
+
+	fmt.Println()
+	fmt.Println("git-bug needs a personal access token with the \"api\" scope, created in the settings of your GitLab profile. The token is stored in the repository git config.")
+	fmt.Println()
+
+	baseUrl, err := promptBaseUrl()
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := promptProject(baseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := promptToken()
+	if err != nil {
+		return nil, err
+	}
+
+	conf[keyBaseUrl] = baseUrl
+	conf[keyProject] = project
+	conf[keyToken] = token
+
+	return conf, nil
+}
+
+func (*Gitlab) ValidateConfig(conf core.Configuration) error {
+	if _, ok := conf[keyProject]; !ok {
+		return fmt.Errorf("missing %s key", keyProject)
+	}
+
+	if _, ok := conf[keyToken]; !ok {
+		return fmt.Errorf("missing %s key", keyToken)
+	}
+
+	if baseUrl, ok := conf[keyBaseUrl]; ok {
+		if err := validateBaseUrl(baseUrl); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// baseUrl return the url of the GitLab instance, without trailing slash
+func baseUrl(conf core.Configuration) string {
+	if baseUrl, ok := conf[keyBaseUrl]; ok && baseUrl != "" {
+		return strings.TrimRight(baseUrl, "/")
+	}
+	return defaultBaseUrl
+}
+
+func validateBaseUrl(baseUrl string) error {
+	u, err := url.Parse(baseUrl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid %s %q, expected an http or https url", keyBaseUrl, baseUrl)
+	}
+	return nil
+}
+
+// projectPath return the path of a project, like "group/project", from the
+// path itself or from the url of the project
+func projectPath(baseUrl string, input string) string {
+	input = strings.TrimPrefix(input, strings.TrimRight(baseUrl, "/"))
+	input = strings.TrimSuffix(input, ".git")
+
+	// the url of a page of the project, like /group/project/-/issues
+	if i := strings.Index(input, "/-/"); i >= 0 {
+		input = input[:i]
+	}
+
+	return strings.Trim(input, "/")
+}
+
+func promptBaseUrl() (string, error) {
+	for {
+		line, err := prompt("GitLab instance URL", defaultBaseUrl)
+		if err != nil {
+			return "", err
+		}
+
+		if err := validateBaseUrl(line); err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		return strings.TrimRight(line, "/"), nil
+	}
+}
+
+func promptProject(baseUrl string) (string, error) {
+	for {
+		line, err := prompt("Project path or URL, like group/project", "")
+		if err != nil {
+			return "", err
+		}
+
+		project := projectPath(baseUrl, line)
+		if !strings.Contains(project, "/") {
+			fmt.Println("invalid project, expected a path like group/project")
+			continue
+		}
+
+		return project, nil
+	}
+}
+
+func promptToken() (string, error) {
+	for {
+		fmt.Print("token: ")
+
+		byteToken, err := terminal.ReadPassword(int(syscall.Stdin))
+		// new line for coherent formatting, ReadPassword clip the normal new line
+		// entered by the user
+		fmt.Println()
+
+		if err != nil {
+			return "", err
+		}
+
+		if len(byteToken) > 0 {
+			return strings.TrimSpace(string(byteToken)), nil
+		}
+
+		fmt.Println("token is empty")
+	}
+}
+
+func prompt(question string, defaultValue string) (string, error) {
+	for {
+		if defaultValue != "" {
+			fmt.Printf("%s [%s]: ", question, defaultValue)
+		} else {
+			fmt.Printf("%s: ", question)
+		}
+
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+
+		line = strings.TrimSpace(line)
+
+		if line == "" && defaultValue != "" {
+			return defaultValue, nil
+		}
+
+		if line == "" {
+			fmt.Println("empty input")
+			continue
+		}
+
+		return line, nil
+	}
+}
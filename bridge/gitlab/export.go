@@ -0,0 +1,275 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/pkg/errors"
+)
+
+// gitlabExporter implement the Exporter interface
+type gitlabExporter struct {
+	conf   core.Configuration
+	client *client
+	// owner is the owner of the token, the author of the issues and notes
+	// created on gitlab
+	owner *apiUser
+}
+
+func (ge *gitlabExporter) Init(conf core.Configuration) error {
+	ge.conf = conf
+	ge.client = newClient(conf)
+	return nil
+}
+
+// ExportAll export the bugs changed since the given time, oldest first
+func (ge *gitlabExporter) ExportAll(repo *cache.RepoCache, since time.Time) error {
+	query := cache.NewQuery()
+	query.OrderBy = cache.OrderByCreation
+
+	for _, id := range repo.QueryBugs(query) {
+		b, err := repo.ResolveBug(id)
+		if err != nil {
+			return err
+		}
+
+		if b.Snapshot().LastEditTime().Before(since) {
+			continue
+		}
+
+		err = ge.exportBug(repo, b)
+		if err != nil {
+			return errors.Wrapf(err, "bug %s", b.HumanId())
+		}
+	}
+
+	return nil
+}
+
+// Export export a single bug
+func (ge *gitlabExporter) Export(repo *cache.RepoCache, id string) error {
+	b, err := repo.ResolveBugPrefix(id)
+	if err != nil {
+		return err
+	}
+
+	return ge.exportBug(repo, b)
+}
+
+// exportBug create the issue of a bug if it's not on gitlab yet, add the
+// comments not there yet as notes, and update the title, the status and the
+// labels of the issue if they differ. The issues and notes created are
+// recorded in the metadata of their operation, as they would be if they were
+// imported.
+func (ge *gitlabExporter) exportBug(repo *cache.RepoCache, b *cache.BugCache) error {
+	snap := b.Snapshot()
+
+	// a restricted bug must not leak on gitlab
+	if !snap.Visibility.IsPublic() {
+		return nil
+	}
+
+	if ge.owner == nil {
+		owner, err := ge.client.currentUser()
+		if err != nil {
+			return err
+		}
+		ge.owner = owner
+	}
+
+	user, err := repo.GetUser()
+	if err != nil {
+		return err
+	}
+
+	createOp := snap.Operations[0]
+	createMetadata := createOp.AllMetadata()
+
+	var issue apiIssue
+
+	if url, ok := createMetadata[keyGitlabUrl]; ok {
+		iid, ok := ge.issueIid(url)
+		if !ok {
+			// imported from another project
+			return nil
+		}
+
+		_, err = ge.client.do(http.MethodGet, ge.client.projectPath("/issues/%d", iid), nil, nil, &issue)
+		if err != nil {
+			return err
+		}
+	} else {
+		createHash, err := createOp.Hash()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("export bug: %s\n", snap.Title)
+
+		item, err := snap.SearchTimelineItem(createHash)
+		if err != nil {
+			return err
+		}
+
+		_, err = ge.client.do(http.MethodPost, ge.client.projectPath("/issues"), nil, map[string]interface{}{
+			"title":       snap.Title,
+			"description": ge.attributedBody(user, snap.Author, item.(*bug.CreateTimelineItem).Message),
+			"labels":      strings.Join(labelNames(snap.Labels), ","),
+		}, &issue)
+		if err != nil {
+			return err
+		}
+
+		err = b.SetMetadataRaw(user, time.Now(), createHash, map[string]string{
+			keyGitlabId:  strconv.Itoa(issue.Id),
+			keyGitlabUrl: issue.WebUrl,
+		})
+		if err != nil {
+			return err
+		}
+
+		// committed right away, for a failure later on to not lead to a
+		// duplicated issue on the next export
+		err = b.CommitAsNeeded()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, item := range snap.Timeline {
+		comment, ok := item.(*bug.AddCommentTimelineItem)
+		if !ok {
+			continue
+		}
+
+		err = ge.ensureNote(b, user, issue.Iid, comment)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = ge.ensureIssueState(issue, snap)
+	if err != nil {
+		return err
+	}
+
+	return b.CommitAsNeeded()
+}
+
+// ensureNote create the note of a comment on gitlab if it's not there yet
+func (ge *gitlabExporter) ensureNote(b *cache.BugCache, user bug.Person, iid int, comment *bug.AddCommentTimelineItem) error {
+	if hasGitlabId(b, comment.Hash()) {
+		return nil
+	}
+
+	fmt.Println("export comment")
+
+	var note apiNote
+	_, err := ge.client.do(http.MethodPost, ge.client.projectPath("/issues/%d/notes", iid), nil, map[string]interface{}{
+		"body": ge.attributedBody(user, comment.Author, comment.Message),
+	}, &note)
+	if err != nil {
+		return err
+	}
+
+	err = b.SetMetadataRaw(user, time.Now(), comment.Hash(), map[string]string{
+		keyGitlabId: fmt.Sprintf("note-%d", note.Id),
+	})
+	if err != nil {
+		return err
+	}
+
+	return b.CommitAsNeeded()
+}
+
+// ensureIssueState update the title, the status and the labels of the issue
+// on gitlab if they differ from the bug
+func (ge *gitlabExporter) ensureIssueState(issue apiIssue, snap *bug.Snapshot) error {
+	changes := make(map[string]interface{})
+
+	if issue.Title != snap.Title {
+		changes["title"] = snap.Title
+	}
+
+	switch {
+	case snap.Status == bug.ClosedStatus && issue.State != stateClosed:
+		changes["state_event"] = "close"
+	case snap.Status == bug.OpenStatus && issue.State == stateClosed:
+		changes["state_event"] = "reopen"
+	}
+
+	current := append([]string{}, issue.Labels...)
+	wanted := labelNames(snap.Labels)
+	sort.Strings(current)
+	sort.Strings(wanted)
+	if strings.Join(current, "\n") != strings.Join(wanted, "\n") {
+		changes["labels"] = strings.Join(wanted, ",")
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	fmt.Printf("update issue #%d\n", issue.Iid)
+
+	_, err := ge.client.do(http.MethodPut, ge.client.projectPath("/issues/%d", issue.Iid), nil, changes, nil)
+	return err
+}
+
+// attributedBody return the message to post on gitlab, mentioning its author
+// when it's not the owner of the token
+func (ge *gitlabExporter) attributedBody(user bug.Person, author bug.Person, message string) string {
+	if author.Login == ge.owner.Username || (author.Name == user.Name && author.Email == user.Email) {
+		return message
+	}
+
+	return fmt.Sprintf("_%s wrote:_\n\n%s", author.DisplayName(), message)
+}
+
+// issueIid return the number of an issue of the project from its url, like
+// https://gitlab.com/group/project/-/issues/12
+func (ge *gitlabExporter) issueIid(url string) (int, bool) {
+	prefix := fmt.Sprintf("%s/%s/", baseUrl(ge.conf), ge.conf[keyProject])
+	if !strings.HasPrefix(url, prefix) {
+		return 0, false
+	}
+
+	rest := strings.TrimPrefix(strings.TrimPrefix(url, prefix), "-/")
+	if path.Dir(rest) != "issues" {
+		return 0, false
+	}
+
+	iid, err := strconv.Atoi(path.Base(rest))
+	return iid, err == nil
+}
+
+// hasGitlabId tell if the operation has been imported from gitlab, or
+// already exported
+func hasGitlabId(b *cache.BugCache, hash git.Hash) bool {
+	for _, op := range b.Snapshot().Operations {
+		opHash, err := op.Hash()
+		if err != nil || opHash != hash {
+			continue
+		}
+		_, ok := op.GetMetadata(keyGitlabId)
+		return ok
+	}
+	return false
+}
+
+func labelNames(labels []bug.Label) []string {
+	result := make([]string, len(labels))
+	for i, label := range labels {
+		result[i] = label.String()
+	}
+	return result
+}
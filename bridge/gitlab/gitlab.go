@@ -0,0 +1,25 @@
+// Package gitlab contains the GitLab bridge implementation, working with
+// gitlab.com as well as with the self-hosted instances
+package gitlab
+
+import (
+	"github.com/MichaelMure/git-bug/bridge/core"
+)
+
+func init() {
+	core.Register(&Gitlab{})
+}
+
+type Gitlab struct{}
+
+func (*Gitlab) Target() string {
+	return "gitlab"
+}
+
+func (*Gitlab) NewImporter() core.Importer {
+	return &gitlabImporter{}
+}
+
+func (*Gitlab) NewExporter() core.Exporter {
+	return &gitlabExporter{}
+}
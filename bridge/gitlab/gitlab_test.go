@@ -0,0 +1,502 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeGitlab is the part of the API v4 used by the bridge, on the project
+// group/project. The listings are served one item per page, to exercise the
+// pagination.
+type fakeGitlab struct {
+	mu          sync.Mutex
+	url         string
+	issues      []*apiIssue
+	notes       map[int][]apiNote
+	labelEvents map[int][]apiLabelEvent
+	nextId      int
+	requests    []string
+	// failNote is the body of a note to refuse to create
+	failNote string
+}
+
+var (
+	alice = &apiUser{Id: 1, Username: "alice", Name: "Alice", AvatarUrl: "https://example.com/alice.png"}
+	owner = &apiUser{Id: 2, Username: "owner", Name: "Owner"}
+)
+
+func newFakeGitlab() *fakeGitlab {
+	return &fakeGitlab{
+		notes:       make(map[int][]apiNote),
+		labelEvents: make(map[int][]apiLabelEvent),
+	}
+}
+
+func (f *fakeGitlab) id() int {
+	f.nextId++
+	return f.nextId
+}
+
+func (f *fakeGitlab) addIssue(author *apiUser, title string, description string, created time.Time) *apiIssue {
+	iid := len(f.issues) + 1
+	issue := &apiIssue{
+		Id:          f.id(),
+		Iid:         iid,
+		Title:       title,
+		Description: description,
+		State:       stateOpened,
+		Author:      author,
+		CreatedAt:   created,
+		UpdatedAt:   created,
+		WebUrl:      fmt.Sprintf("%s/group/project/-/issues/%d", f.url, iid),
+	}
+	f.issues = append(f.issues, issue)
+	return issue
+}
+
+func (f *fakeGitlab) addNote(iid int, author *apiUser, body string, system bool, created time.Time) {
+	f.notes[iid] = append(f.notes[iid], apiNote{
+		Id:        f.id(),
+		Body:      body,
+		Author:    author,
+		CreatedAt: created,
+		System:    system,
+	})
+}
+
+func (f *fakeGitlab) addLabelEvent(iid int, user *apiUser, name string, action string, created time.Time) {
+	event := apiLabelEvent{Id: f.id(), User: user, CreatedAt: created, Action: action}
+	event.Label = &struct {
+		Name string `json:"name"`
+	}{name}
+	f.labelEvents[iid] = append(f.labelEvents[iid], event)
+}
+
+func (f *fakeGitlab) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.EscapedPath(), "/api/v4")
+	f.requests = append(f.requests, r.Method+" "+path)
+
+	if r.Header.Get("PRIVATE-TOKEN") != "token" {
+		http.Error(w, `{"message":"401 Unauthorized"}`, http.StatusUnauthorized)
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Body != nil {
+		data, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+	}
+
+	if path == "/user" {
+		_ = json.NewEncoder(w).Encode(owner)
+		return
+	}
+
+	const prefix = "/projects/group%2Fproject/issues"
+	if !strings.HasPrefix(path, prefix) {
+		http.NotFound(w, r)
+		return
+	}
+	rest := strings.Trim(strings.TrimPrefix(path, prefix), "/")
+
+	if rest == "" {
+		switch r.Method {
+		case http.MethodGet:
+			var items []interface{}
+			for _, issue := range f.issues {
+				if since := r.URL.Query().Get("updated_after"); since != "" {
+					t, _ := time.Parse(time.RFC3339, since)
+					if issue.UpdatedAt.Before(t) {
+						continue
+					}
+				}
+				items = append(items, issue)
+			}
+			f.writePage(w, r, items)
+
+		case http.MethodPost:
+			issue := f.addIssue(owner, body["title"].(string), body["description"].(string), time.Now())
+			issue.Labels = splitLabels(body["labels"].(string))
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(issue)
+		}
+		return
+	}
+
+	parts := strings.SplitN(rest, "/", 2)
+	iid, err := strconv.Atoi(parts[0])
+	if err != nil || iid < 1 || iid > len(f.issues) {
+		http.NotFound(w, r)
+		return
+	}
+	issue := f.issues[iid-1]
+	sub := ""
+	if len(parts) > 1 {
+		sub = parts[1]
+	}
+
+	switch {
+	case sub == "" && r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(issue)
+
+	case sub == "" && r.Method == http.MethodPut:
+		now := time.Now()
+		if title, ok := body["title"].(string); ok {
+			f.addNote(iid, owner, fmt.Sprintf("changed title from **%s** to **{+%s+}**", issue.Title, title), true, now)
+			issue.Title = title
+		}
+		switch body["state_event"] {
+		case "close":
+			issue.State = stateClosed
+			f.addNote(iid, owner, "closed", true, now)
+		case "reopen":
+			issue.State = stateOpened
+			f.addNote(iid, owner, "reopened", true, now)
+		}
+		if labels, ok := body["labels"].(string); ok {
+			issue.Labels = splitLabels(labels)
+		}
+		issue.UpdatedAt = now
+		_ = json.NewEncoder(w).Encode(issue)
+
+	case sub == "notes" && r.Method == http.MethodGet:
+		var items []interface{}
+		for _, note := range f.notes[iid] {
+			items = append(items, note)
+		}
+		f.writePage(w, r, items)
+
+	case sub == "notes" && r.Method == http.MethodPost && body["body"] == f.failNote:
+		http.Error(w, `{"message":"500 Internal Server Error"}`, http.StatusInternalServerError)
+
+	case sub == "notes" && r.Method == http.MethodPost:
+		f.addNote(iid, owner, body["body"].(string), false, time.Now())
+		notes := f.notes[iid]
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(notes[len(notes)-1])
+
+	case sub == "resource_label_events" && r.Method == http.MethodGet:
+		var items []interface{}
+		for _, event := range f.labelEvents[iid] {
+			items = append(items, event)
+		}
+		f.writePage(w, r, items)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// writePage write a single item of a listing, and the number of the next page
+func (f *fakeGitlab) writePage(w http.ResponseWriter, r *http.Request, items []interface{}) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	if page < len(items) {
+		w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+	}
+
+	result := []interface{}{}
+	if page <= len(items) {
+		result = append(result, items[page-1])
+	}
+
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+func splitLabels(labels string) []string {
+	if labels == "" {
+		return []string{}
+	}
+	return strings.Split(labels, ",")
+}
+
+func newTestRepo(t *testing.T, dir string) *cache.RepoCache {
+	repo, err := repository.InitGitRepo(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.StoreConfig("user.name", "testuser"))
+	assert.NoError(t, repo.StoreConfig("user.email", "testuser@example.com"))
+
+	backend, err := cache.NewRepoCache(repo)
+	assert.NoError(t, err)
+
+	return backend
+}
+
+func newTestServer() (*fakeGitlab, *httptest.Server, core.Configuration) {
+	fake := newFakeGitlab()
+	server := httptest.NewServer(fake)
+	fake.url = server.URL
+
+	return fake, server, core.Configuration{
+		keyBaseUrl: server.URL,
+		keyProject: "group/project",
+		keyToken:   "token",
+	}
+}
+
+func TestImport(t *testing.T) {
+	fake, server, conf := newTestServer()
+	defer server.Close()
+
+	start := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	fake.addIssue(alice, "new title", "description\r\n", start)
+	fake.addNote(1, alice, "a comment", false, start.Add(time.Minute))
+	fake.addLabelEvent(1, alice, "bug", "add", start.Add(2*time.Minute))
+	fake.addNote(1, nil, "closed", true, start.Add(3*time.Minute))
+	fake.addNote(1, owner, "reopened", true, start.Add(4*time.Minute))
+	fake.addNote(1, alice, "changed title from **{-old-} title** to **{+new+} title**", true, start.Add(5*time.Minute))
+	fake.addLabelEvent(1, alice, "bug", "remove", start.Add(6*time.Minute))
+	fake.addNote(1, alice, "added ~1 label", true, start.Add(7*time.Minute))
+	fake.addIssue(owner, "second", "", start.Add(time.Hour))
+
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backend := newTestRepo(t, dir)
+	defer backend.Close()
+
+	importer := &gitlabImporter{}
+	assert.NoError(t, importer.Init(conf))
+	assert.NoError(t, importer.ImportAll(backend, time.Time{}))
+
+	assert.Len(t, backend.AllBugsIds(), 2)
+
+	b, err := backend.ResolveBugCreateMetadata(keyGitlabUrl, server.URL+"/group/project/-/issues/1")
+	assert.NoError(t, err)
+
+	snap := b.Snapshot()
+	assert.Equal(t, "new title", snap.Title)
+	assert.Equal(t, bug.OpenStatus, snap.Status)
+	assert.Empty(t, snap.Labels)
+	assert.Equal(t, "Alice", snap.Author.Name)
+	assert.Equal(t, "alice", snap.Author.Login)
+	assert.Len(t, snap.Comments, 2)
+	assert.Equal(t, "description", snap.Comments[0].Message)
+	assert.Equal(t, "a comment", snap.Comments[1].Message)
+
+	// the change of title is an echo of the current title, the other system
+	// notes are all imported
+	assert.Len(t, snap.Operations, 6)
+	assert.Equal(t, "Ghost User", snap.Operations[3].GetAuthor().Name)
+	assert.Equal(t, "testuser", snap.Operations[4].GetAuthor().Name)
+
+	// the owner of the token is the user of the repository
+	second, err := backend.ResolveBugCreateMetadata(keyGitlabUrl, server.URL+"/group/project/-/issues/2")
+	assert.NoError(t, err)
+	assert.Equal(t, "testuser", second.Snapshot().Author.Name)
+
+	// importing again doesn't duplicate anything
+	assert.NoError(t, importer.ImportAll(backend, time.Time{}))
+	assert.Len(t, backend.AllBugsIds(), 2)
+	assert.Len(t, b.Snapshot().Operations, 6)
+
+	// only the issues updated since are fetched
+	fake.requests = nil
+	assert.NoError(t, importer.ImportAll(backend, start.Add(30*time.Minute)))
+	assert.NotContains(t, fake.requests, "GET /projects/group%2Fproject/issues/1/notes")
+	assert.Contains(t, fake.requests, "GET /projects/group%2Fproject/issues/2/notes")
+
+	// a single issue
+	fake.addNote(2, alice, "late comment", false, start.Add(2*time.Hour))
+	assert.NoError(t, importer.Import(backend, "#2"))
+	assert.Len(t, second.Snapshot().Comments, 2)
+}
+
+func TestExport(t *testing.T) {
+	fake, server, conf := newTestServer()
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backend := newTestRepo(t, dir)
+	defer backend.Close()
+
+	b, err := backend.NewBug("title", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, b.AddComment("comment"))
+	_, err = b.ChangeLabels([]string{"bug"}, nil)
+	assert.NoError(t, err)
+
+	other := bug.Person{Name: "Alice", Email: "alice@example.com"}
+	assert.NoError(t, b.AddCommentRaw(other, time.Now(), "from alice", nil, nil))
+	assert.NoError(t, b.Commit())
+
+	restricted, err := backend.NewBug("internal", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, restricted.SetVisibility(bug.VisibilityInternal))
+	assert.NoError(t, restricted.Commit())
+
+	exporter := &gitlabExporter{}
+	assert.NoError(t, exporter.Init(conf))
+	assert.NoError(t, exporter.ExportAll(backend, time.Time{}))
+
+	// the restricted bug is not exported
+	assert.Len(t, fake.issues, 1)
+	assert.Equal(t, "title", fake.issues[0].Title)
+	assert.Equal(t, "message", fake.issues[0].Description)
+	assert.Equal(t, []string{"bug"}, fake.issues[0].Labels)
+	assert.Len(t, fake.notes[1], 2)
+	assert.Equal(t, "comment", fake.notes[1][0].Body)
+	assert.Equal(t, "_Alice wrote:_\n\nfrom alice", fake.notes[1][1].Body)
+
+	// the ids are recorded as if the issue had been imported
+	imported, err := backend.ResolveBugCreateMetadata(keyGitlabUrl, fake.issues[0].WebUrl)
+	assert.NoError(t, err)
+	assert.Equal(t, b.Id(), imported.Id())
+	_, err = b.ResolveTargetWithMetadata(keyGitlabId, fmt.Sprintf("note-%d", fake.notes[1][1].Id))
+	assert.NoError(t, err)
+
+	// exporting again change nothing
+	fake.requests = nil
+	assert.NoError(t, exporter.ExportAll(backend, time.Time{}))
+	assert.Len(t, fake.notes[1], 2)
+	assert.Equal(t, []string{"GET /projects/group%2Fproject/issues/1"}, fake.requests)
+
+	// the changes of the bug update the issue
+	assert.NoError(t, b.Close())
+	assert.NoError(t, b.SetTitle("new title"))
+	_, err = b.ChangeLabels(nil, []string{"bug"})
+	assert.NoError(t, err)
+	assert.NoError(t, b.Commit())
+
+	assert.NoError(t, exporter.Export(backend, b.Id()))
+	assert.Equal(t, stateClosed, fake.issues[0].State)
+	assert.Equal(t, "new title", fake.issues[0].Title)
+	assert.Empty(t, fake.issues[0].Labels)
+
+	// importing back what was exported doesn't duplicate anything
+	operations := len(b.Snapshot().Operations)
+
+	importer := &gitlabImporter{}
+	assert.NoError(t, importer.Init(conf))
+	assert.NoError(t, importer.ImportAll(backend, time.Time{}))
+	assert.Len(t, backend.AllBugsIds(), 2)
+	assert.Len(t, b.Snapshot().Operations, operations)
+
+	// the bugs unchanged since the last export are skipped
+	fake.requests = nil
+	assert.NoError(t, exporter.ExportAll(backend, time.Now().Add(time.Hour)))
+	assert.Empty(t, fake.requests)
+}
+
+func TestExportThenImportInNewProcess(t *testing.T) {
+	fake, server, conf := newTestServer()
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backend := newTestRepo(t, dir)
+
+	b, err := backend.NewBug("title", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, b.AddComment("comment"))
+	assert.NoError(t, b.Commit())
+	id := b.Id()
+
+	exporter := &gitlabExporter{}
+	assert.NoError(t, exporter.Init(conf))
+	assert.NoError(t, exporter.ExportAll(backend, time.Time{}))
+	assert.Len(t, fake.notes[1], 1)
+	assert.NoError(t, backend.Close())
+
+	// the metadata of the exported items are read back from the repository,
+	// in a fresh cache
+	repo, err := repository.NewGitRepo(dir, nil)
+	assert.NoError(t, err)
+	backend, err = cache.NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer backend.Close()
+
+	importer := &gitlabImporter{}
+	assert.NoError(t, importer.Init(conf))
+	assert.NoError(t, importer.ImportAll(backend, time.Time{}))
+
+	assert.Len(t, backend.AllBugsIds(), 1)
+	b, err = backend.ResolveBug(id)
+	assert.NoError(t, err)
+	assert.Len(t, b.Snapshot().Comments, 2)
+	assert.Len(t, b.Snapshot().Operations, 4)
+}
+
+func TestExportFailure(t *testing.T) {
+	fake, server, conf := newTestServer()
+	defer server.Close()
+	fake.failNote = "second"
+
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backend := newTestRepo(t, dir)
+
+	b, err := backend.NewBug("title", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, b.AddComment("first"))
+	assert.NoError(t, b.AddComment("second"))
+	assert.NoError(t, b.Commit())
+
+	exporter := &gitlabExporter{}
+	assert.NoError(t, exporter.Init(conf))
+	assert.Error(t, exporter.ExportAll(backend, time.Time{}))
+	assert.Len(t, fake.issues, 1)
+	assert.Len(t, fake.notes[1], 1)
+	assert.NoError(t, backend.Close())
+
+	// what has been created before the failure is known by the next export
+	repo, err := repository.NewGitRepo(dir, nil)
+	assert.NoError(t, err)
+	backend, err = cache.NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer backend.Close()
+
+	fake.failNote = ""
+	exporter = &gitlabExporter{}
+	assert.NoError(t, exporter.Init(conf))
+	assert.NoError(t, exporter.ExportAll(backend, time.Time{}))
+	assert.Len(t, fake.issues, 1)
+	assert.Len(t, fake.notes[1], 2)
+}
+
+func TestProjectPath(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"group/project", "group/project"},
+		{"/group/sub/project/", "group/sub/project"},
+		{"https://gitlab.example.com/group/project", "group/project"},
+		{"https://gitlab.example.com/group/project.git", "group/project"},
+		{"https://gitlab.example.com/group/project/-/issues", "group/project"},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, projectPath("https://gitlab.example.com/", c.input), c.input)
+	}
+}
@@ -0,0 +1,346 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/pkg/errors"
+)
+
+// keyGitlabId is the metadata holding the id of the GitLab object an
+// operation comes from. The ids of the notes and the label events are
+// prefixed, as they can collide.
+const keyGitlabId = "gitlab-id"
+
+// keyGitlabUrl is the metadata holding the url of the issue a bug comes from,
+// unique even across several GitLab instances
+const keyGitlabUrl = "gitlab-url"
+
+// ghost is the author of the content of the deleted users
+var ghost = bug.Person{Name: "Ghost User", Login: "ghost"}
+
+// titleNoteRegexp match the system note of a change of title. The titles are
+// given with the changed parts highlighted, like "foo {+bar+}".
+var titleNoteRegexp = regexp.MustCompile(`(?s)^changed title from \*\*(.*)\*\* to \*\*(.*)\*\*$`)
+
+// removedRegexp match the removed parts of a title given by a system note
+var removedRegexp = regexp.MustCompile(`\{-.*?-\}`)
+
+// gitlabImporter implement the Importer interface
+type gitlabImporter struct {
+	conf   core.Configuration
+	client *client
+	// identities are the persons used for the gitlab users, by username
+	identities map[string]bug.Person
+}
+
+func (gi *gitlabImporter) Init(conf core.Configuration) error {
+	gi.conf = conf
+	gi.client = newClient(conf)
+	return nil
+}
+
+// ImportAll import the issues updated since the given time, along with all
+// their notes and label events. The already imported items are skipped.
+func (gi *gitlabImporter) ImportAll(repo *cache.RepoCache, since time.Time) error {
+	err := gi.loadIdentities(repo)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{
+		"order_by": {"created_at"},
+		"sort":     {"asc"},
+		"scope":    {"all"},
+	}
+	if !since.IsZero() {
+		query.Set("updated_after", since.UTC().Format(time.RFC3339))
+	}
+
+	var issues []apiIssue
+	err = gi.client.list(gi.client.projectPath("/issues"), query, &issues)
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		err := gi.importIssue(repo, issue)
+		if err != nil {
+			return errors.Wrapf(err, "issue #%d", issue.Iid)
+		}
+	}
+
+	return nil
+}
+
+// Import import the issue with the given number
+func (gi *gitlabImporter) Import(repo *cache.RepoCache, id string) error {
+	iid, err := strconv.Atoi(strings.TrimPrefix(id, "#"))
+	if err != nil {
+		return fmt.Errorf("invalid issue number %s", id)
+	}
+
+	err = gi.loadIdentities(repo)
+	if err != nil {
+		return err
+	}
+
+	var issue apiIssue
+	_, err = gi.client.do(http.MethodGet, gi.client.projectPath("/issues/%d", iid), nil, nil, &issue)
+	if err != nil {
+		return err
+	}
+
+	return gi.importIssue(repo, issue)
+}
+
+// timelineEvent is a note or a label event, to import them in order
+type timelineEvent struct {
+	createdAt time.Time
+	ensure    func() error
+}
+
+func (gi *gitlabImporter) importIssue(repo *cache.RepoCache, issue apiIssue) error {
+	b, err := gi.ensureIssue(repo, issue)
+	if err != nil {
+		return err
+	}
+
+	var notes []apiNote
+	err = gi.client.list(gi.client.projectPath("/issues/%d/notes", issue.Iid), url.Values{
+		"order_by": {"created_at"},
+		"sort":     {"asc"},
+	}, &notes)
+	if err != nil {
+		return err
+	}
+
+	var labelEvents []apiLabelEvent
+	err = gi.client.list(gi.client.projectPath("/issues/%d/resource_label_events", issue.Iid), nil, &labelEvents)
+	if err != nil {
+		return err
+	}
+
+	var events []timelineEvent
+	for i := range notes {
+		note := notes[i]
+		events = append(events, timelineEvent{note.CreatedAt, func() error {
+			return gi.ensureNote(b, note)
+		}})
+	}
+	for i := range labelEvents {
+		event := labelEvents[i]
+		events = append(events, timelineEvent{event.CreatedAt, func() error {
+			return gi.ensureLabelEvent(b, event)
+		}})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].createdAt.Before(events[j].createdAt)
+	})
+
+	for _, event := range events {
+		err := event.ensure()
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.CommitAsNeeded()
+}
+
+func (gi *gitlabImporter) ensureIssue(repo *cache.RepoCache, issue apiIssue) (*cache.BugCache, error) {
+	b, err := repo.ResolveBugCreateMetadata(keyGitlabUrl, issue.WebUrl)
+	if err != bug.ErrBugNotExist {
+		return b, err
+	}
+
+	fmt.Printf("import issue: %s\n", issue.Title)
+
+	return repo.NewBugRaw(
+		gi.makePerson(issue.Author),
+		issue.CreatedAt,
+		// GitLab doesn't give the initial title, but the changes of title
+		// leading to the current one are imported from the notes
+		issue.Title,
+		cleanupText(issue.Description),
+		nil,
+		map[string]string{
+			keyGitlabId:  strconv.Itoa(issue.Id),
+			keyGitlabUrl: issue.WebUrl,
+		},
+	)
+}
+
+// ensureNote import a note, either a comment or a system note recording a
+// change of the status or the title. The other system notes are ignored, the
+// labels being imported from the label events.
+func (gi *gitlabImporter) ensureNote(b *cache.BugCache, note apiNote) error {
+	id := fmt.Sprintf("note-%d", note.Id)
+
+	_, err := b.ResolveTargetWithMetadata(keyGitlabId, id)
+	if err != cache.ErrNoMatchingOp {
+		return err
+	}
+
+	author := gi.makePerson(note.Author)
+	date := note.CreatedAt
+	metadata := map[string]string{keyGitlabId: id}
+
+	if !note.System {
+		fmt.Println("import comment")
+		return b.AddCommentRaw(author, date, cleanupText(note.Body), nil, metadata)
+	}
+
+	// the changes exported from git-bug come back as new notes, already
+	// applied locally
+	snap := b.Snapshot()
+
+	switch {
+	case note.Body == "closed" || strings.HasPrefix(note.Body, "closed via "):
+		if snap.Status == bug.ClosedStatus {
+			return nil
+		}
+		return b.CloseRaw(author, date, metadata)
+
+	case note.Body == "reopened":
+		if snap.Status == bug.OpenStatus {
+			return nil
+		}
+		return b.OpenRaw(author, date, metadata)
+
+	case titleNoteRegexp.MatchString(note.Body):
+		title := cleanupTitle(titleNoteRegexp.FindStringSubmatch(note.Body)[2])
+		if title == "" || title == snap.Title {
+			return nil
+		}
+		return b.SetTitleRaw(author, date, title, metadata)
+	}
+
+	return nil
+}
+
+func (gi *gitlabImporter) ensureLabelEvent(b *cache.BugCache, event apiLabelEvent) error {
+	// the label has been deleted since
+	if event.Label == nil {
+		return nil
+	}
+
+	id := fmt.Sprintf("label-%d", event.Id)
+
+	_, err := b.ResolveTargetWithMetadata(keyGitlabId, id)
+	if err != cache.ErrNoMatchingOp {
+		return err
+	}
+
+	var added, removed []string
+	exist := hasLabel(b.Snapshot().Labels, event.Label.Name)
+
+	switch event.Action {
+	case "add":
+		if exist {
+			return nil
+		}
+		added = []string{event.Label.Name}
+	case "remove":
+		if !exist {
+			return nil
+		}
+		removed = []string{event.Label.Name}
+	default:
+		return nil
+	}
+
+	_, err = b.ChangeLabelsRaw(
+		gi.makePerson(event.User),
+		event.CreatedAt,
+		added,
+		removed,
+		map[string]string{keyGitlabId: id},
+	)
+	return err
+}
+
+// loadIdentities map the gitlab users to the identities of the repository:
+// the owner of the token is the user of the repository, and a username
+// already known keep its identity, even if the user changed its name or
+// avatar since
+func (gi *gitlabImporter) loadIdentities(repo *cache.RepoCache) error {
+	gi.identities = make(map[string]bug.Person)
+
+	// the ids are sorted, for the choice to be stable when several identities
+	// share a login
+	ids := repo.AllIdentityIds()
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		excerpt, err := repo.ResolveIdentity(id)
+		if err != nil {
+			return err
+		}
+		if excerpt.Login == "" {
+			continue
+		}
+		if _, ok := gi.identities[excerpt.Login]; !ok {
+			gi.identities[excerpt.Login] = excerpt.Person()
+		}
+	}
+
+	owner, err := gi.client.currentUser()
+	if err != nil {
+		return err
+	}
+
+	// without a configured user, the owner of the token is imported like the
+	// others
+	if user, err := repo.GetUser(); err == nil {
+		gi.identities[owner.Username] = user
+	}
+
+	return nil
+}
+
+// makePerson return the person of a gitlab user, or its known identity
+func (gi *gitlabImporter) makePerson(user *apiUser) bug.Person {
+	if user == nil {
+		return ghost
+	}
+
+	if person, ok := gi.identities[user.Username]; ok {
+		return person
+	}
+
+	return user.person()
+}
+
+func cleanupText(text string) string {
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	return strings.TrimSpace(text)
+}
+
+// cleanupTitle remove the highlighting of the changes from a title given by
+// a system note
+func cleanupTitle(title string) string {
+	title = removedRegexp.ReplaceAllString(title, "")
+	title = strings.Replace(title, "{+", "", -1)
+	title = strings.Replace(title, "+}", "", -1)
+	return strings.TrimSpace(title)
+}
+
+func hasLabel(labels []bug.Label, name string) bool {
+	for _, label := range labels {
+		if label.String() == name {
+			return true
+		}
+	}
+	return false
+}
@@ -30,7 +30,9 @@ func (li *launchpadImporter) makePerson(owner LPPerson) bug.Person {
 	}
 }
 
-func (li *launchpadImporter) ImportAll(repo *cache.RepoCache) error {
+// ImportAll import every bug of the project, launchpad giving no efficient
+// way to only list the changed ones
+func (li *launchpadImporter) ImportAll(repo *cache.RepoCache, since time.Time) error {
 	lpAPI := new(launchpadAPI)
 
 	err := lpAPI.Init()
@@ -57,7 +59,7 @@ func (li *launchpadImporter) ImportAll(repo *cache.RepoCache) error {
 			createdAt, _ := time.Parse(time.RFC3339, lpBug.CreatedAt)
 			b, err = repo.NewBugRaw(
 				li.makePerson(lpBug.Owner),
-				createdAt.Unix(),
+				createdAt,
 				lpBug.Title,
 				lpBug.Description,
 				nil,
@@ -98,7 +100,7 @@ func (li *launchpadImporter) ImportAll(repo *cache.RepoCache) error {
 			createdAt, _ := time.Parse(time.RFC3339, lpMessage.CreatedAt)
 			err = b.AddCommentRaw(
 				li.makePerson(lpMessage.Owner),
-				createdAt.Unix(),
+				createdAt,
 				lpMessage.Content,
 				nil,
 				map[string]string{
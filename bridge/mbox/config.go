@@ -0,0 +1,75 @@
+package mbox
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+const keyPath = "path"
+const keyTag = "tag"
+
+const defaultTag = "[BUG]"
+
+func (*Mbox) Configure(repo repository.RepoCommon) (core.Configuration, error) {
+	conf := make(core.Configuration)
+
+	path, err := prompt("Path of the mbox archive", "")
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := prompt("Subject tag of the bug reports", defaultTag)
+	if err != nil {
+		return nil, err
+	}
+
+	conf[keyPath] = path
+	conf[keyTag] = tag
+
+	return conf, nil
+}
+
+func (*Mbox) ValidateConfig(conf core.Configuration) error {
+	if _, ok := conf[keyPath]; !ok {
+		return fmt.Errorf("missing %s key", keyPath)
+	}
+
+	if _, ok := conf[keyTag]; !ok {
+		return fmt.Errorf("missing %s key", keyTag)
+	}
+
+	return nil
+}
+
+func prompt(question string, defaultValue string) (string, error) {
+	for {
+		if defaultValue != "" {
+			fmt.Printf("%s [%s]: ", question, defaultValue)
+		} else {
+			fmt.Printf("%s: ", question)
+		}
+
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+
+		line = strings.TrimSpace(line)
+
+		if line == "" && defaultValue != "" {
+			return defaultValue, nil
+		}
+
+		if line == "" {
+			fmt.Println("Value is empty")
+			continue
+		}
+
+		return line, nil
+	}
+}
@@ -0,0 +1,216 @@
+package mbox
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/pkg/errors"
+)
+
+const keyMboxMessageId = "mbox-message-id"
+const keyMboxInReplyTo = "mbox-in-reply-to"
+
+// replyPrefixRegex match the prefixes added to the subject of the replies
+var replyPrefixRegex = regexp.MustCompile(`(?i)^\s*((re|fwd?|aw)\s*:\s*)+`)
+
+// mboxImporter implement the Importer interface
+type mboxImporter struct {
+	conf core.Configuration
+}
+
+func (mi *mboxImporter) Init(conf core.Configuration) error {
+	mi.conf = conf
+	return nil
+}
+
+// ImportAll import every bug report thread of the archive. The archive is
+// read entirely every time, the already imported messages being skipped.
+func (mi *mboxImporter) ImportAll(repo *cache.RepoCache, since time.Time) error {
+	return mi.importThreads(repo, func(rootId string) bool { return true })
+}
+
+// Import import the bug report thread starting with the given message id
+func (mi *mboxImporter) Import(repo *cache.RepoCache, id string) error {
+	id = cleanId(id)
+	return mi.importThreads(repo, func(rootId string) bool { return rootId == id })
+}
+
+func (mi *mboxImporter) importThreads(repo *cache.RepoCache, selected func(rootId string) bool) error {
+	f, err := os.Open(mi.conf[keyPath])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	messages, err := parseMbox(f)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the mbox archive")
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Date.Before(messages[j].Date)
+	})
+
+	threads := newThreads(messages)
+	bugs := make(map[string]*cache.BugCache)
+
+	// the bugs are created first, in case a reply is dated before its parent
+	for _, msg := range messages {
+		if threads.root(msg) != msg || !selected(msg.Id) || !isBugReport(msg.Subject, mi.conf[keyTag]) {
+			continue
+		}
+
+		b, err := mi.ensureBug(repo, msg)
+		if err != nil {
+			return err
+		}
+		bugs[msg.Id] = b
+	}
+
+	for _, msg := range messages {
+		b, ok := bugs[threads.root(msg).Id]
+		if !ok || msg.Id == threads.root(msg).Id {
+			continue
+		}
+
+		err := mi.ensureComment(b, msg)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, b := range bugs {
+		err := b.CommitAsNeeded()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (mi *mboxImporter) ensureBug(repo *cache.RepoCache, msg *message) (*cache.BugCache, error) {
+	b, err := repo.ResolveBugCreateMetadata(keyMboxMessageId, msg.Id)
+	if err == nil {
+		return b, nil
+	}
+	if err != bug.ErrBugNotExist {
+		return nil, err
+	}
+
+	b, err = repo.NewBugRaw(
+		msg.From,
+		msg.Date,
+		bugTitle(msg.Subject, mi.conf[keyTag]),
+		msg.Body,
+		nil,
+		map[string]string{
+			keyMboxMessageId: msg.Id,
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to add the bug of message %s", msg.Id)
+	}
+
+	return b, nil
+}
+
+func (mi *mboxImporter) ensureComment(b *cache.BugCache, msg *message) error {
+	_, err := b.ResolveTargetWithMetadata(keyMboxMessageId, msg.Id)
+	if err == nil {
+		// already imported
+		return nil
+	}
+	if err != cache.ErrNoMatchingOp {
+		return err
+	}
+
+	// a message without text, like a lone attachment, has nothing to import
+	if msg.Body == "" {
+		return nil
+	}
+
+	err = b.AddCommentRaw(
+		msg.From,
+		msg.Date,
+		msg.Body,
+		nil,
+		map[string]string{
+			keyMboxMessageId: msg.Id,
+			keyMboxInReplyTo: msg.Parent,
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to add the comment of message %s", msg.Id)
+	}
+
+	return nil
+}
+
+// threads find the first message of the thread of each message of the archive
+type threads struct {
+	byId  map[string]*message
+	roots map[*message]*message
+}
+
+func newThreads(messages []*message) *threads {
+	t := &threads{
+		byId:  make(map[string]*message, len(messages)),
+		roots: make(map[*message]*message, len(messages)),
+	}
+
+	for _, msg := range messages {
+		t.byId[msg.Id] = msg
+	}
+
+	return t
+}
+
+func (t *threads) root(msg *message) *message {
+	if root, ok := t.roots[msg]; ok {
+		return root
+	}
+
+	// walk up the parents, stopping at a message missing from the archive or
+	// at a loop in the replies
+	visited := map[*message]bool{msg: true}
+	root := msg
+	for {
+		parent, ok := t.byId[root.Parent]
+		if !ok || visited[parent] {
+			break
+		}
+		visited[parent] = true
+		root = parent
+	}
+
+	t.roots[msg] = root
+	return root
+}
+
+func tagRegex(tag string) *regexp.Regexp {
+	return regexp.MustCompile("(?i)" + regexp.QuoteMeta(tag))
+}
+
+// isBugReport return true if the subject hold the tag of the bug reports
+func isBugReport(subject string, tag string) bool {
+	return tagRegex(tag).MatchString(subject)
+}
+
+// bugTitle remove the reply prefixes and the tag from a subject
+func bugTitle(subject string, tag string) string {
+	title := replyPrefixRegex.ReplaceAllString(subject, "")
+	title = tagRegex(tag).ReplaceAllString(title, "")
+	title = strings.Join(strings.Fields(title), " ")
+	if title == "" {
+		return "(no subject)"
+	}
+
+	return title
+}
@@ -0,0 +1,25 @@
+// Package mbox contains the bridge importing the bug reports sent to a mailing
+// list, from a plain-text mbox archive
+package mbox
+
+import (
+	"github.com/MichaelMure/git-bug/bridge/core"
+)
+
+func init() {
+	core.Register(&Mbox{})
+}
+
+type Mbox struct{}
+
+func (*Mbox) Target() string {
+	return "mbox"
+}
+
+func (*Mbox) NewImporter() core.Importer {
+	return &mboxImporter{}
+}
+
+func (*Mbox) NewExporter() core.Exporter {
+	return nil
+}
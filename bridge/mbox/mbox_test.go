@@ -0,0 +1,121 @@
+package mbox
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+const archive = `From rene@descartes.fr Mon Jan  7 10:00:00 2019
+From: =?utf-8?q?Ren=C3=A9_Descartes?= <rene@descartes.fr>
+Subject: [BUG] Crash on start
+Date: Mon, 7 Jan 2019 10:00:00 +0100
+Message-ID: <1@descartes.fr>
+
+The program crash when started.
+>From the logs, it's a segfault.
+
+From blaise@pascal.fr Mon Jan  7 12:00:00 2019
+From: Blaise Pascal <blaise@pascal.fr>
+Subject: Re: [BUG] Crash on start
+Date: Mon, 7 Jan 2019 12:00:00 +0100
+Message-ID: <2@pascal.fr>
+In-Reply-To: <1@descartes.fr>
+Content-Type: multipart/alternative; boundary="xyz"
+
+--xyz
+Content-Type: text/html
+
+<p>Not reproducible</p>
+--xyz
+Content-Type: text/plain
+Content-Transfer-Encoding: quoted-printable
+
+Not reproducible, which version=3F
+--xyz--
+
+From rene@descartes.fr Mon Jan  7 13:00:00 2019
+From: =?utf-8?q?Ren=C3=A9_Descartes?= <rene@descartes.fr>
+Subject: Re: [BUG] Crash on start
+Date: Mon, 7 Jan 2019 13:00:00 +0100
+Message-ID: <3@descartes.fr>
+References: <1@descartes.fr> <2@pascal.fr>
+
+The latest one.
+
+From blaise@pascal.fr Tue Jan  8 10:00:00 2019
+From: Blaise Pascal <blaise@pascal.fr>
+Subject: Release 1.0
+Date: Tue, 8 Jan 2019 10:00:00 +0100
+Message-ID: <4@pascal.fr>
+
+Not a bug report.
+`
+
+func TestParseMbox(t *testing.T) {
+	messages, err := parseMbox(strings.NewReader(archive))
+	assert.NoError(t, err)
+	assert.Len(t, messages, 4)
+
+	assert.Equal(t, "1@descartes.fr", messages[0].Id)
+	assert.Equal(t, bug.Person{Name: "René Descartes", Email: "rene@descartes.fr"}, messages[0].From)
+	assert.Equal(t, "The program crash when started.\nFrom the logs, it's a segfault.", messages[0].Body)
+
+	assert.Equal(t, "1@descartes.fr", messages[1].Parent)
+	assert.Equal(t, "Not reproducible, which version?", messages[1].Body)
+
+	assert.Equal(t, "2@pascal.fr", messages[2].Parent)
+}
+
+func TestBugTitle(t *testing.T) {
+	assert.Equal(t, "Crash on start", bugTitle("[BUG] Crash on start", "[BUG]"))
+	assert.Equal(t, "Crash on start", bugTitle("Re: RE: [bug]  Crash on start", "[BUG]"))
+	assert.Equal(t, "(no subject)", bugTitle("[BUG]", "[BUG]"))
+}
+
+func TestImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "archive.mbox")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(archive), 0644))
+
+	repo, err := repository.InitGitRepo(filepath.Join(dir, "repo"))
+	assert.NoError(t, err)
+	assert.NoError(t, repo.StoreConfig("user.name", "testuser"))
+	assert.NoError(t, repo.StoreConfig("user.email", "testuser@example.com"))
+
+	backend, err := cache.NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer backend.Close()
+
+	importer := &mboxImporter{}
+	assert.NoError(t, importer.Init(core.Configuration{keyPath: path, keyTag: defaultTag}))
+
+	// importing twice doesn't duplicate anything
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, importer.ImportAll(backend, time.Time{}))
+
+		ids := backend.AllBugsIds()
+		assert.Len(t, ids, 1)
+
+		b, err := backend.ResolveBug(ids[0])
+		assert.NoError(t, err)
+
+		snap := b.Snapshot()
+		assert.Equal(t, "Crash on start", snap.Title)
+		assert.Len(t, snap.Comments, 3)
+		assert.Equal(t, "Blaise Pascal", snap.Comments[1].Author.Name)
+		assert.Equal(t, "The latest one.", snap.Comments[2].Message)
+	}
+}
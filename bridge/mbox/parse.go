@@ -0,0 +1,211 @@
+package mbox
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+// message is an email of the archive
+type message struct {
+	Id string
+	// Parent is the id of the message this one reply to, if any
+	Parent  string
+	From    bug.Person
+	Date    time.Time
+	Subject string
+	Body    string
+}
+
+// escapedFromRegex match the lines of a message starting with "From ",
+// escaped by a '>' to not be confused with the start of a new message
+var escapedFromRegex = regexp.MustCompile(`^>+From `)
+
+// parseMbox read the messages of an mbox archive
+func parseMbox(r io.Reader) ([]*message, error) {
+	var result []*message
+	var current bytes.Buffer
+	started := false
+	previousEmpty := true
+
+	flush := func() error {
+		if !started {
+			return nil
+		}
+		msg, err := parseMessage(current.Bytes())
+		if err != nil {
+			return err
+		}
+		result = append(result, msg)
+		current.Reset()
+		return nil
+	}
+
+	reader := bufio.NewReader(r)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if line == "" && err == io.EOF {
+			break
+		}
+
+		switch {
+		case previousEmpty && strings.HasPrefix(line, "From "):
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			started = true
+		case escapedFromRegex.MatchString(line):
+			current.WriteString(line[1:])
+		case started:
+			current.WriteString(line)
+		}
+
+		previousEmpty = strings.TrimRight(line, "\r\n") == ""
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func parseMessage(raw []byte) (*message, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &message{
+		Id:      cleanId(msg.Header.Get("Message-Id")),
+		Subject: decodeHeader(msg.Header.Get("Subject")),
+	}
+
+	from := msg.Header.Get("From")
+	address, err := mail.ParseAddress(from)
+	if err == nil {
+		result.From = bug.Person{Name: address.Name, Email: address.Address}
+		if result.From.Name == "" {
+			result.From.Name = address.Address
+		}
+	} else {
+		result.From = bug.Person{Name: decodeHeader(from)}
+	}
+
+	result.Date, err = msg.Header.Date()
+	if err != nil {
+		return nil, fmt.Errorf("message %s: %v", result.Id, err)
+	}
+
+	// In-Reply-To is the direct parent, References is the whole chain
+	if parent := cleanId(msg.Header.Get("In-Reply-To")); parent != "" {
+		result.Parent = parent
+	} else if references := strings.Fields(msg.Header.Get("References")); len(references) > 0 {
+		result.Parent = cleanId(references[len(references)-1])
+	}
+
+	body, err := readBody(msg.Header, msg.Body)
+	if err != nil {
+		return nil, fmt.Errorf("message %s: %v", result.Id, err)
+	}
+
+	body = strings.Replace(body, "\r\n", "\n", -1)
+	result.Body = text.Sanitize(strings.TrimSpace(body))
+
+	// without an id, the message can't be imported twice without duplicate
+	if result.Id == "" {
+		hash := sha256.Sum256(raw)
+		result.Id = fmt.Sprintf("%x@git-bug", hash[:16])
+	}
+
+	return result, nil
+}
+
+// readBody return the plain-text content of a message or a part of a message
+func readBody(header mail.Header, body io.Reader) (string, error) {
+	mediaType := "text/plain"
+	var params map[string]string
+
+	if contentType := header.Get("Content-Type"); contentType != "" {
+		var err error
+		mediaType, params, err = mime.ParseMediaType(contentType)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	switch strings.ToLower(header.Get("Content-Transfer-Encoding")) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return "", nil
+			}
+			if err != nil {
+				return "", err
+			}
+
+			content, err := readBody(mail.Header(part.Header), part)
+			if err != nil {
+				return "", err
+			}
+			if content != "" {
+				return content, nil
+			}
+		}
+
+	case mediaType == "text/plain":
+		content, err := ioutil.ReadAll(body)
+		if err != nil {
+			return "", err
+		}
+		return string(content), nil
+
+	default:
+		// attachments and html are ignored
+		return "", nil
+	}
+}
+
+// decodeHeader decode the non-ASCII words of a header
+func decodeHeader(value string) string {
+	decoded, err := new(mime.WordDecoder).DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+func cleanId(id string) string {
+	return strings.Trim(strings.TrimSpace(id), "<>")
+}
@@ -0,0 +1,341 @@
+package bug
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/pkg/errors"
+)
+
+// aliasesRef is where the sequential aliases of the bugs are stored. The
+// aliases are pushed and pulled along the bugs, and reconciled on merge so
+// that all the clones of a repository converge to the same numbers.
+const aliasesRefPattern = "refs/aliases/"
+const aliasesRemoteRefPattern = "refs/remotes/%s/aliases/"
+const aliasesRef = aliasesRefPattern + "bugs"
+const aliasesEntryName = "aliases"
+
+func aliasesRemoteRef(remote string) string {
+	return fmt.Sprintf(aliasesRemoteRefPattern, remote) + "bugs"
+}
+
+// AliasPrefix is the prefix marking a sequential alias in place of a bug id
+const AliasPrefix = "#"
+
+// Aliases map short incremental numbers to bug ids. The ids stay the canonical
+// identifiers, the aliases are only a convenience for humans.
+type Aliases struct {
+	byNumber map[int]string
+	byId     map[string]int
+	last     int
+	dirty    bool
+}
+
+func newAliases() *Aliases {
+	return &Aliases{
+		byNumber: make(map[int]string),
+		byId:     make(map[string]int),
+	}
+}
+
+// ReadAliases read the aliases stored in the repository. If none exist yet,
+// an empty set is returned.
+func ReadAliases(repo repository.Repo) (*Aliases, error) {
+	aliases, _, err := readAliases(repo, aliasesRef)
+	return aliases, err
+}
+
+// readAliases read the aliases stored in a ref, and return them along the
+// commit of the ref, empty if the ref doesn't exist
+func readAliases(repo repository.Repo, ref string) (*Aliases, git.Hash, error) {
+	aliases := newAliases()
+
+	exist, err := repo.RefExist(ref)
+	if err != nil {
+		return nil, "", err
+	}
+	if !exist {
+		return aliases, "", nil
+	}
+
+	hashes, err := repo.ListCommits(ref)
+	if err != nil {
+		return nil, "", err
+	}
+	head := hashes[len(hashes)-1]
+
+	entries, err := repo.ListEntries(head)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "can't list git tree entries")
+	}
+
+	for _, entry := range entries {
+		if entry.Name != aliasesEntryName {
+			continue
+		}
+
+		data, err := repo.ReadData(entry.Hash)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to read git blob data")
+		}
+
+		err = aliases.parse(data)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return aliases, head, nil
+}
+
+func (a *Aliases) parse(data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		parts := strings.Fields(line)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid alias line: %s", line)
+		}
+
+		number, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return errors.Wrap(err, "invalid alias number")
+		}
+
+		a.set(number, parts[1])
+	}
+
+	return scanner.Err()
+}
+
+func (a *Aliases) set(number int, id string) {
+	a.byNumber[number] = id
+	a.byId[id] = number
+	if number > a.last {
+		a.last = number
+	}
+}
+
+// Assign return the alias of a bug, assigning the next number if it doesn't
+// have one yet. Numbers are never reused, even if the bug is removed.
+func (a *Aliases) Assign(id string) int {
+	if number, ok := a.byId[id]; ok {
+		return number
+	}
+
+	a.set(a.last+1, id)
+	a.dirty = true
+
+	return a.last
+}
+
+// Alias return the alias of a bug, if any
+func (a *Aliases) Alias(id string) (int, bool) {
+	number, ok := a.byId[id]
+	return number, ok
+}
+
+// Last return the highest number assigned, 0 if none
+func (a *Aliases) Last() int {
+	return a.last
+}
+
+// Resolve return the id of the bug with the given alias, if any
+func (a *Aliases) Resolve(number int) (string, bool) {
+	id, ok := a.byNumber[number]
+	return id, ok
+}
+
+// Write store the aliases in the repository, if they changed since they were
+// read.
+func (a *Aliases) Write(repo repository.Repo) error {
+	if !a.dirty {
+		return nil
+	}
+
+	exist, err := repo.RefExist(aliasesRef)
+	if err != nil {
+		return err
+	}
+
+	var parent git.Hash
+	if exist {
+		hashes, err := repo.ListCommits(aliasesRef)
+		if err != nil {
+			return err
+		}
+		parent = hashes[len(hashes)-1]
+	}
+
+	return a.write(repo, parent)
+}
+
+// write store the aliases in a new commit with the given parent, if any
+func (a *Aliases) write(repo repository.Repo, parent git.Hash) error {
+	numbers := make([]int, 0, len(a.byNumber))
+	for number := range a.byNumber {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	var buffer bytes.Buffer
+	for _, number := range numbers {
+		_, _ = fmt.Fprintf(&buffer, "%d %s\n", number, a.byNumber[number])
+	}
+
+	blobHash, err := repo.StoreData(buffer.Bytes())
+	if err != nil {
+		return err
+	}
+
+	treeHash, err := repo.StoreTree([]repository.TreeEntry{
+		{ObjectType: repository.Blob, Hash: blobHash, Name: aliasesEntryName},
+	})
+	if err != nil {
+		return err
+	}
+
+	var commitHash git.Hash
+	if parent != "" {
+		commitHash, err = repo.StoreCommitWithParent(treeHash, parent)
+	} else {
+		commitHash, err = repo.StoreCommit(treeHash)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = repo.UpdateRef(aliasesRef, commitHash)
+	if err != nil {
+		return err
+	}
+
+	a.dirty = false
+	return nil
+}
+
+// MergeRemote reconcile the aliases with the ones of a remote, as fetched by
+// Fetch, and store the result on top of the remote aliases so that it can be
+// pushed back.
+//
+// The result only depends on the union of both sets of aliases, so that all
+// the clones converge to the same numbers whatever the order of the merges:
+// each number goes to the first created bug claiming it, according to the
+// given ordering, and the bugs left without a number get new ones, in the same
+// order.
+func (a *Aliases) MergeRemote(repo repository.Repo, remote string, createdBefore func(id1, id2 string) bool) error {
+	remoteAliases, remoteHead, err := readAliases(repo, aliasesRemoteRef(remote))
+	if err != nil {
+		return err
+	}
+	if remoteHead == "" {
+		return nil
+	}
+
+	a.merge(remoteAliases, createdBefore)
+
+	if a.equal(remoteAliases) {
+		a.dirty = false
+		return repo.UpdateRef(aliasesRef, remoteHead)
+	}
+
+	return a.write(repo, remoteHead)
+}
+
+func (a *Aliases) merge(other *Aliases, createdBefore func(id1, id2 string) bool) {
+	type claim struct {
+		number int
+		id     string
+	}
+
+	var claims []claim
+	for number, id := range a.byNumber {
+		claims = append(claims, claim{number, id})
+	}
+	for number, id := range other.byNumber {
+		if a.byNumber[number] != id {
+			claims = append(claims, claim{number, id})
+		}
+	}
+
+	sort.Slice(claims, func(i, j int) bool {
+		if claims[i].number != claims[j].number {
+			return claims[i].number < claims[j].number
+		}
+		return createdBefore(claims[i].id, claims[j].id)
+	})
+
+	merged := newAliases()
+	var left []string
+
+	for _, c := range claims {
+		if _, ok := merged.byId[c.id]; ok {
+			continue
+		}
+		if _, ok := merged.byNumber[c.number]; ok {
+			left = append(left, c.id)
+			continue
+		}
+		merged.set(c.number, c.id)
+	}
+
+	sort.Slice(left, func(i, j int) bool {
+		return createdBefore(left[i], left[j])
+	})
+
+	for _, id := range left {
+		// the bug might have won another number later on
+		if _, ok := merged.byId[id]; !ok {
+			merged.set(merged.last+1, id)
+		}
+	}
+
+	if !a.equal(merged) {
+		a.byNumber = merged.byNumber
+		a.byId = merged.byId
+		a.last = merged.last
+		a.dirty = true
+	}
+}
+
+func (a *Aliases) equal(other *Aliases) bool {
+	if len(a.byNumber) != len(other.byNumber) {
+		return false
+	}
+	for number, id := range a.byNumber {
+		if other.byNumber[number] != id {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseAlias parse an alias in the form "#N"
+func ParseAlias(s string) (int, bool) {
+	if !strings.HasPrefix(s, AliasPrefix) {
+		return 0, false
+	}
+
+	number, err := strconv.Atoi(strings.TrimPrefix(s, AliasPrefix))
+	if err != nil || number <= 0 {
+		return 0, false
+	}
+
+	return number, true
+}
+
+// FormatAlias format an alias for human consumption
+func FormatAlias(number int) string {
+	return fmt.Sprintf("%s%d", AliasPrefix, number)
+}
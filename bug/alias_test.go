@@ -0,0 +1,97 @@
+package bug
+
+import (
+	"testing"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAliases(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	aliases, err := ReadAliases(repo)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, aliases.Assign("aaaa"))
+	assert.Equal(t, 2, aliases.Assign("bbbb"))
+	assert.Equal(t, 1, aliases.Assign("aaaa"))
+
+	err = aliases.Write(repo)
+	assert.NoError(t, err)
+
+	aliases, err = ReadAliases(repo)
+	assert.NoError(t, err)
+
+	id, ok := aliases.Resolve(2)
+	assert.True(t, ok)
+	assert.Equal(t, "bbbb", id)
+
+	number, ok := aliases.Alias("aaaa")
+	assert.True(t, ok)
+	assert.Equal(t, 1, number)
+
+	assert.Equal(t, 3, aliases.Assign("cccc"))
+}
+
+func TestParseAlias(t *testing.T) {
+	number, ok := ParseAlias("#12")
+	assert.True(t, ok)
+	assert.Equal(t, 12, number)
+
+	_, ok = ParseAlias("12")
+	assert.False(t, ok)
+	_, ok = ParseAlias("#0")
+	assert.False(t, ok)
+	_, ok = ParseAlias("#abc")
+	assert.False(t, ok)
+
+	assert.Equal(t, "#12", FormatAlias(12))
+}
+
+func TestAliasesMerge(t *testing.T) {
+	// the bugs are created in the order of their id
+	createdBefore := func(id1, id2 string) bool {
+		return id1 < id2
+	}
+
+	build := func(ids ...string) *Aliases {
+		aliases := newAliases()
+		for _, id := range ids {
+			aliases.Assign(id)
+		}
+		return aliases
+	}
+
+	// two clones numbered their own bugs after the shared ones
+	local := build("aaaa", "cccc", "eeee")
+	remote := build("aaaa", "bbbb", "dddd")
+
+	merged1 := build("aaaa", "cccc", "eeee")
+	merged1.merge(remote, createdBefore)
+	merged2 := build("aaaa", "bbbb", "dddd")
+	merged2.merge(local, createdBefore)
+
+	// both sides converge to the same numbers
+	assert.True(t, merged1.equal(merged2))
+
+	expected := map[string]int{
+		"aaaa": 1,
+		// first created wins the conflicting number
+		"bbbb": 2,
+		"cccc": 4,
+		"dddd": 3,
+		"eeee": 5,
+	}
+	for id, number := range expected {
+		actual, ok := merged1.Alias(id)
+		assert.True(t, ok)
+		assert.Equal(t, number, actual, id)
+	}
+	assert.Equal(t, 5, merged1.Last())
+
+	// merging again doesn't change anything
+	merged1.dirty = false
+	merged1.merge(merged2, createdBefore)
+	assert.False(t, merged1.dirty)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/MichaelMure/git-bug/repository"
 	"github.com/MichaelMure/git-bug/util/git"
@@ -113,16 +114,22 @@ func ReadRemoteBug(repo repository.ClockedRepo, remote string, id string) (*Bug,
 
 // readBug will read and parse a Bug from git
 func readBug(repo repository.ClockedRepo, ref string) (*Bug, error) {
-	hashes, err := repo.ListCommits(ref)
+	refSplit := strings.Split(ref, "/")
+	id := refSplit[len(refSplit)-1]
+
+	return readBugRevision(repo, id, ref)
+}
+
+// readBugRevision read the bug with the given id as of a revision, either a
+// ref or the hash of a commit not referenced yet
+func readBugRevision(repo repository.ClockedRepo, id string, revision string) (*Bug, error) {
+	hashes, err := repo.ListCommits(revision)
 
 	// TODO: this is not perfect, it might be a command invoke error
 	if err != nil {
 		return nil, ErrBugNotExist
 	}
 
-	refSplit := strings.Split(ref, "/")
-	id := refSplit[len(refSplit)-1]
-
 	if len(id) != idLength {
 		return nil, fmt.Errorf("invalid ref length")
 	}
@@ -131,6 +138,8 @@ func readBug(repo repository.ClockedRepo, ref string) (*Bug, error) {
 		id: id,
 	}
 
+	decrypter := &packDecrypter{repo: repo}
+
 	// Load each OperationPack
 	for _, hash := range hashes {
 		entries, err := repo.ListEntries(hash)
@@ -204,6 +213,11 @@ func readBug(repo repository.ClockedRepo, ref string) (*Bug, error) {
 			return nil, errors.Wrap(err, "failed to read git blob data")
 		}
 
+		data, err = decrypter.read(data)
+		if err != nil {
+			return nil, err
+		}
+
 		opp := &OperationPack{}
 		err = json.Unmarshal(data, &opp)
 
@@ -274,6 +288,23 @@ func ListLocalIds(repo repository.Repo) ([]string, error) {
 	return refsToIds(refs), nil
 }
 
+// ListLocalRefHashes list the commit hash referenced by each local bug,
+// indexed by bug id
+func ListLocalRefHashes(repo repository.Repo) (map[string]git.Hash, error) {
+	refs, err := repo.ListRefHashes(bugsRefPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]git.Hash, len(refs))
+
+	for ref, hash := range refs {
+		result[strings.TrimPrefix(ref, bugsRefPattern)] = hash
+	}
+
+	return result, nil
+}
+
 func refsToIds(refs []string) []string {
 	ids := make([]string, len(refs))
 
@@ -592,6 +623,12 @@ func FormatHumanID(id string) string {
 	return fmt.Sprintf(format, id)
 }
 
+// LastCommit return the hash of the last git commit of the bug, that is,
+// the commit referenced by the bug ref once committed
+func (bug *Bug) LastCommit() git.Hash {
+	return bug.lastCommit
+}
+
 // CreateLamportTime return the Lamport time of creation
 func (bug *Bug) CreateLamportTime() lamport.Time {
 	return bug.createTime
@@ -640,6 +677,34 @@ func (bug *Bug) LastOp() Operation {
 
 // Compile a bug in a easily usable snapshot
 func (bug *Bug) Compile() Snapshot {
+	return bug.compileFiltered(func(op Operation) bool {
+		return true
+	})
+}
+
+// CompileUpTo compile a bug in a easily usable snapshot, using only the first
+// n operations
+func (bug *Bug) CompileUpTo(n int) Snapshot {
+	count := 0
+	return bug.compileFiltered(func(op Operation) bool {
+		count++
+		return count <= n
+	})
+}
+
+// CompileAt compile a bug in a easily usable snapshot representing the state
+// of the bug at the given time, that is, using only the operations issued at
+// or before this time.
+func (bug *Bug) CompileAt(t time.Time) Snapshot {
+	unix := t.Unix()
+	return bug.compileFiltered(func(op Operation) bool {
+		return op.GetUnixTime() <= unix
+	})
+}
+
+// compileFiltered compile a snapshot using only the operations accepted by
+// the filter
+func (bug *Bug) compileFiltered(filter func(op Operation) bool) Snapshot {
 	snap := Snapshot{
 		id:     bug.id,
 		Status: OpenStatus,
@@ -649,6 +714,9 @@ func (bug *Bug) Compile() Snapshot {
 
 	for it.Next() {
 		op := it.Value()
+		if !filter(op) {
+			continue
+		}
 		op.Apply(&snap)
 		snap.Operations = append(snap.Operations, op)
 	}
@@ -1,25 +1,246 @@
 package bug
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
 	"github.com/pkg/errors"
 )
 
 // Fetch retrieve update from a remote
 // This does not change the local bugs state
 func Fetch(repo repository.Repo, remote string) (string, error) {
+	return FetchContext(context.Background(), repo, remote)
+}
+
+// FetchContext retrieve update from a remote, stopping when the context is
+// canceled
+func FetchContext(ctx context.Context, repo repository.Repo, remote string) (string, error) {
 	remoteRefSpec := fmt.Sprintf(bugsRemoteRefPattern, remote)
 	fetchRefSpec := fmt.Sprintf("%s*:%s*", bugsRefPattern, remoteRefSpec)
 
-	return repo.FetchRefs(remote, fetchRefSpec)
+	stdout, err := fetchRefs(ctx, repo, remote, fetchRefSpec)
+	if err != nil {
+		return stdout, err
+	}
+
+	// a wildcard is used so that the fetch doesn't fail if the remote has no
+	// policy. The remote policy is forced, as it's only adopted after being
+	// checked against the local one: a rewritten policy must not make the
+	// fetch fail.
+	policyRefSpec := fmt.Sprintf("+%s*:%s*", policyRefPattern, fmt.Sprintf(policyRemoteRefPattern, remote))
+
+	policyStdout, err := fetchRefs(ctx, repo, remote, policyRefSpec)
+	if err != nil {
+		return stdout + policyStdout, err
+	}
+
+	aliasesRefSpec := fmt.Sprintf("%s*:%s*", aliasesRefPattern, fmt.Sprintf(aliasesRemoteRefPattern, remote))
+
+	aliasesStdout, err := fetchRefs(ctx, repo, remote, aliasesRefSpec)
+
+	return stdout + policyStdout + aliasesStdout, err
+}
+
+// fetchRefs fetch git refs from a remote, interrupting git when the context
+// is canceled if the repository support it
+func fetchRefs(ctx context.Context, repo repository.Repo, remote string, refSpec string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	if cancelable, ok := repo.(repository.CancelableRepo); ok {
+		return cancelable.FetchRefsContext(ctx, remote, refSpec)
+	}
+
+	return repo.FetchRefs(remote, refSpec)
 }
 
 // Push update a remote with the local changes
-func Push(repo repository.Repo, remote string) (string, error) {
-	return repo.PushRefs(remote, bugsRefPattern+"*")
+//
+// The local bugs are compared with the last known state of the remote (the
+// remote references updated by Fetch and Push), and only the bugs with new
+// operations are pushed. A PushResult is returned for each of them.
+func Push(repo repository.ClockedRepo, remote string) ([]PushResult, error) {
+	return PushContext(context.Background(), repo, remote)
+}
+
+// PushContext update a remote with the local changes, stopping when the
+// context is canceled
+func PushContext(ctx context.Context, repo repository.ClockedRepo, remote string) ([]PushResult, error) {
+	remoteRefSpec := fmt.Sprintf(bugsRemoteRefPattern, remote)
+
+	results, refSpecs, heads, err := bugsMissingOnRemote(repo, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	policyChanged, err := refMissingOnRemote(repo, policyRef, policyRemoteRef(remote))
+	if err != nil {
+		return nil, err
+	}
+
+	aliasesChanged, err := refMissingOnRemote(repo, aliasesRef, aliasesRemoteRef(remote))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(refSpecs) == 0 && !policyChanged && !aliasesChanged {
+		return nil, nil
+	}
+
+	pushRefSpecs := refSpecs
+	if policyChanged {
+		pushRefSpecs = append(pushRefSpecs, policyRef)
+	}
+	if aliasesChanged {
+		pushRefSpecs = append(pushRefSpecs, aliasesRef)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if cancelable, ok := repo.(repository.CancelableRepo); ok {
+		_, err = cancelable.PushRefsContext(ctx, remote, pushRefSpecs...)
+	} else {
+		_, err = repo.PushRefs(remote, pushRefSpecs...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Record the new state of the remote so that the next push doesn't send
+	// these operations again.
+	for i, result := range results {
+		err = repo.UpdateRef(remoteRefSpec+result.Id, heads[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if policyChanged {
+		err = repo.CopyRef(policyRef, policyRemoteRef(remote))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if aliasesChanged {
+		err = repo.CopyRef(aliasesRef, aliasesRemoteRef(remote))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// PushPreview return what Push would send to the remote, without pushing
+func PushPreview(repo repository.ClockedRepo, remote string) ([]PushResult, error) {
+	results, _, _, err := bugsMissingOnRemote(repo, remote)
+	return results, err
+}
+
+// bugsMissingOnRemote find the local bugs with operations not part of the last
+// known state of the remote, with their ref and their last commit
+func bugsMissingOnRemote(repo repository.ClockedRepo, remote string) ([]PushResult, []string, []git.Hash, error) {
+	localRefs, err := repo.ListRefs(bugsRefPattern)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	remoteRefSpec := fmt.Sprintf(bugsRemoteRefPattern, remote)
+
+	var results []PushResult
+	var refs []string
+	var heads []git.Hash
+
+	for _, localRef := range localRefs {
+		refSplitted := strings.Split(localRef, "/")
+		id := refSplitted[len(refSplitted)-1]
+
+		localBug, err := readBug(repo, localRef)
+		if err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "local bug %s is not readable", id)
+		}
+
+		count, err := opsMissingOnRemote(repo, localBug, remoteRefSpec+id)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if count == 0 {
+			continue
+		}
+
+		results = append(results, PushResult{
+			Id:      id,
+			OpCount: count,
+		})
+		refs = append(refs, localRef)
+		heads = append(heads, localBug.lastCommit)
+	}
+
+	return results, refs, heads, nil
+}
+
+// refMissingOnRemote tell if a local ref exists and differ from the last
+// known state of the remote
+func refMissingOnRemote(repo repository.Repo, localRef string, remoteRef string) (bool, error) {
+	local, err := repo.ListRefHashes(localRef)
+	if err != nil {
+		return false, err
+	}
+	if len(local) == 0 {
+		return false, nil
+	}
+
+	remoteHashes, err := repo.ListRefHashes(remoteRef)
+	if err != nil {
+		return false, err
+	}
+
+	return local[localRef] != remoteHashes[remoteRef], nil
+}
+
+// opsMissingOnRemote count the operations of a local bug that are not part
+// of the last known state of the same bug on the remote
+func opsMissingOnRemote(repo repository.Repo, localBug *Bug, remoteRef string) (int, error) {
+	remoteExist, err := repo.RefExist(remoteRef)
+	if err != nil {
+		return 0, err
+	}
+
+	remoteCommits := make(map[git.Hash]bool)
+
+	if remoteExist {
+		commits, err := repo.ListCommits(remoteRef)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, hash := range commits {
+			remoteCommits[hash] = true
+		}
+	}
+
+	// the remote already has everything (and possibly more)
+	if remoteCommits[localBug.lastCommit] {
+		return 0, nil
+	}
+
+	count := 0
+	for _, pack := range localBug.packs {
+		if !remoteCommits[pack.commitHash] {
+			count += len(pack.Operations)
+		}
+	}
+
+	return count, nil
 }
 
 // Pull will do a Fetch + MergeAll
@@ -41,12 +262,69 @@ func Pull(repo repository.ClockedRepo, remote string) error {
 }
 
 // MergeAll will merge all the available remote bug
+//
+// If an allow-list of trusted authors is configured, remote bugs bringing
+// operations from other authors are not merged but put in quarantine. They
+// can later be reviewed and accepted with AcceptQuarantined.
+//
+// The policy of the remote is adopted first if it's a descendant of the local
+// one. Remote bugs bringing operations not allowed by the policy are
+// rejected as invalid.
 func MergeAll(repo repository.ClockedRepo, remote string) <-chan MergeResult {
+	return mergeAll(context.Background(), repo, remote, false)
+}
+
+// MergeAllContext merge all the available remote bug, like MergeAll, until
+// the context is canceled. The bugs merged so far are kept, and a last
+// MergeResult hold the error of the context.
+func MergeAllContext(ctx context.Context, repo repository.ClockedRepo, remote string) <-chan MergeResult {
+	return mergeAll(ctx, repo, remote, false)
+}
+
+// RemoteBugCount return the number of bugs fetched from a remote, that is
+// the number of results MergeAll will produce
+func RemoteBugCount(repo repository.Repo, remote string) (int, error) {
+	remoteRefs, err := repo.ListRefs(fmt.Sprintf(bugsRemoteRefPattern, remote))
+	if err != nil {
+		return 0, err
+	}
+	return len(remoteRefs), nil
+}
+
+// PreviewMergeAll return what MergeAll would do, without changing anything.
+// The local policy is used even if the remote one would be adopted.
+func PreviewMergeAll(repo repository.ClockedRepo, remote string) <-chan MergeResult {
+	return mergeAll(context.Background(), repo, remote, true)
+}
+
+func mergeAll(ctx context.Context, repo repository.ClockedRepo, remote string, dryRun bool) <-chan MergeResult {
 	out := make(chan MergeResult)
 
 	go func() {
 		defer close(out)
 
+		trusted, err := ReadTrustedAuthors(repo)
+		if err != nil {
+			out <- MergeResult{Err: err}
+			return
+		}
+
+		// a rejected remote policy is reported as a warning, and the bugs are
+		// merged with the local one, for a broken policy pushed to the remote
+		// to not prevent every clone from pulling
+		if !dryRun {
+			err = mergePolicy(repo, remote)
+			if err != nil {
+				out <- MergeResult{Warning: err}
+			}
+		}
+
+		policy, err := ReadPolicy(repo)
+		if err != nil {
+			out <- MergeResult{Err: err}
+			return
+		}
+
 		remoteRefSpec := fmt.Sprintf(bugsRemoteRefPattern, remote)
 		remoteRefs, err := repo.ListRefs(remoteRefSpec)
 
@@ -56,9 +334,25 @@ func MergeAll(repo repository.ClockedRepo, remote string) <-chan MergeResult {
 		}
 
 		for _, remoteRef := range remoteRefs {
+			if err := ctx.Err(); err != nil {
+				out <- MergeResult{Err: err}
+				return
+			}
+
 			refSplitted := strings.Split(remoteRef, "/")
 			id := refSplitted[len(refSplitted)-1]
 
+			// the bug has been soft-deleted locally, don't bring it back
+			trashed, err := IsTrashed(repo, id)
+			if err != nil {
+				out <- newMergeError(err, id)
+				return
+			}
+			if trashed {
+				out <- newMergeStatus(MergeStatusNothing, id, nil)
+				continue
+			}
+
 			remoteBug, err := readBug(repo, remoteRef)
 
 			if err != nil {
@@ -72,45 +366,46 @@ func MergeAll(repo repository.ClockedRepo, remote string) <-chan MergeResult {
 				continue
 			}
 
-			localRef := bugsRefPattern + remoteBug.Id()
-			localExist, err := repo.RefExist(localRef)
-
+			violation, err := policyViolation(repo, remoteBug, policy)
 			if err != nil {
 				out <- newMergeError(err, id)
-				continue
+				return
 			}
 
-			// the bug is not local yet, simply create the reference
-			if !localExist {
-				err := repo.CopyRef(remoteRef, localRef)
-
-				if err != nil {
-					out <- newMergeError(err, id)
-					return
-				}
-
-				out <- newMergeStatus(MergeStatusNew, id, remoteBug)
+			if violation != "" {
+				out <- newMergeInvalidStatus(id, "policy violation: "+violation)
 				continue
 			}
 
-			localBug, err := readBug(repo, localRef)
-
+			untrusted, err := untrustedAuthors(repo, remoteBug, trusted)
 			if err != nil {
-				out <- newMergeError(errors.Wrap(err, "local bug is not readable"), id)
+				out <- newMergeError(err, id)
 				return
 			}
 
-			updated, err := localBug.Merge(repo, remoteBug)
+			if len(untrusted) > 0 {
+				if !dryRun {
+					err := repo.CopyRef(remoteRef, bugsQuarantineRefPattern+id)
+					if err != nil {
+						out <- newMergeError(err, id)
+						return
+					}
+				}
 
-			if err != nil {
-				out <- newMergeInvalidStatus(id, errors.Wrap(err, "merge failed").Error())
-				return
+				out <- newMergeQuarantinedStatus(id, untrusted)
+				continue
 			}
 
-			if updated {
-				out <- newMergeStatus(MergeStatusUpdated, id, localBug)
+			var result MergeResult
+			if dryRun {
+				result = previewMergeBug(repo, remoteBug)
 			} else {
-				out <- newMergeStatus(MergeStatusNothing, id, localBug)
+				result = mergeBug(repo, remoteRef, remoteBug)
+			}
+			out <- result
+
+			if result.Err != nil {
+				return
 			}
 		}
 	}()
@@ -118,6 +413,80 @@ func MergeAll(repo repository.ClockedRepo, remote string) <-chan MergeResult {
 	return out
 }
 
+// mergeBug merge a single valid remote bug into the local one, creating it
+// if needed
+func mergeBug(repo repository.ClockedRepo, remoteRef string, remoteBug *Bug) MergeResult {
+	id := remoteBug.Id()
+	localRef := bugsRefPattern + id
+	localExist, err := repo.RefExist(localRef)
+
+	if err != nil {
+		return newMergeError(err, id)
+	}
+
+	// the bug is not local yet, simply create the reference
+	if !localExist {
+		err := repo.CopyRef(remoteRef, localRef)
+
+		if err != nil {
+			return newMergeError(err, id)
+		}
+
+		return newMergeStatus(MergeStatusNew, id, remoteBug)
+	}
+
+	localBug, err := readBug(repo, localRef)
+
+	if err != nil {
+		return newMergeError(errors.Wrap(err, "local bug is not readable"), id)
+	}
+
+	updated, err := localBug.Merge(repo, remoteBug)
+
+	if err != nil {
+		return newMergeInvalidStatus(id, errors.Wrap(err, "merge failed").Error())
+	}
+
+	if updated {
+		return newMergeStatus(MergeStatusUpdated, id, localBug)
+	}
+
+	return newMergeStatus(MergeStatusNothing, id, localBug)
+}
+
+// previewMergeBug tell what mergeBug would do with a valid remote bug. The
+// bug of the result is the remote one.
+func previewMergeBug(repo repository.ClockedRepo, remoteBug *Bug) MergeResult {
+	id := remoteBug.Id()
+	localRef := bugsRefPattern + id
+	localExist, err := repo.RefExist(localRef)
+
+	if err != nil {
+		return newMergeError(err, id)
+	}
+
+	if !localExist {
+		return newMergeStatus(MergeStatusNew, id, remoteBug)
+	}
+
+	localHashes, err := repo.ListRefHashes(localRef)
+	if err != nil {
+		return newMergeError(err, id)
+	}
+
+	ancestor, err := repo.FindCommonAncestor(localHashes[localRef], remoteBug.lastCommit)
+	if err != nil {
+		return newMergeError(err, id)
+	}
+
+	// the local bug already has all the remote commits
+	if ancestor == remoteBug.lastCommit {
+		return newMergeStatus(MergeStatusNothing, id, remoteBug)
+	}
+
+	return newMergeStatus(MergeStatusUpdated, id, remoteBug)
+}
+
 // MergeStatus represent the result of a merge operation of a bug
 type MergeStatus int
 
@@ -127,11 +496,15 @@ const (
 	MergeStatusInvalid
 	MergeStatusUpdated
 	MergeStatusNothing
+	MergeStatusQuarantined
 )
 
 type MergeResult struct {
 	// Err is set when a terminal error occur in the process
 	Err error
+	// Warning is set for a problem not tied to a bug that doesn't stop the
+	// merge, like a rejected remote policy
+	Warning error
 
 	Id     string
 	Status MergeStatus
@@ -139,11 +512,19 @@ type MergeResult struct {
 	// Only set for invalid status
 	Reason string
 
-	// Not set for invalid status
+	// Only set for quarantined status
+	Untrusted []Person
+
+	// Not set for invalid and quarantined status, as well as for the bugs
+	// soft-deleted locally
 	Bug *Bug
 }
 
 func (mr MergeResult) String() string {
+	if mr.Warning != nil {
+		return mr.Warning.Error()
+	}
+
 	switch mr.Status {
 	case MergeStatusNew:
 		return "new"
@@ -153,6 +534,12 @@ func (mr MergeResult) String() string {
 		return "updated"
 	case MergeStatusNothing:
 		return "nothing to do"
+	case MergeStatusQuarantined:
+		names := make([]string, len(mr.Untrusted))
+		for i, p := range mr.Untrusted {
+			names[i] = p.DisplayName()
+		}
+		return fmt.Sprintf("quarantined, untrusted authors: %s", strings.Join(names, ", "))
 	default:
 		panic("unknown merge status")
 	}
@@ -175,6 +562,14 @@ func newMergeStatus(status MergeStatus, id string, bug *Bug) MergeResult {
 	}
 }
 
+func newMergeQuarantinedStatus(id string, untrusted []Person) MergeResult {
+	return MergeResult{
+		Id:        id,
+		Status:    MergeStatusQuarantined,
+		Untrusted: untrusted,
+	}
+}
+
 func newMergeInvalidStatus(id string, reason string) MergeResult {
 	return MergeResult{
 		Id:     id,
@@ -182,3 +577,18 @@ func newMergeInvalidStatus(id string, reason string) MergeResult {
 		Reason: reason,
 	}
 }
+
+// PushResult represent the result of a push operation of a bug
+type PushResult struct {
+	Id string
+
+	// OpCount is the number of operations sent to the remote
+	OpCount int
+}
+
+func (pr PushResult) String() string {
+	if pr.OpCount == 1 {
+		return "1 operation sent"
+	}
+	return fmt.Sprintf("%d operations sent", pr.OpCount)
+}
@@ -1,6 +1,8 @@
 package bug
 
 import (
+	"context"
+
 	"github.com/MichaelMure/git-bug/repository"
 	"github.com/stretchr/testify/assert"
 
@@ -114,6 +116,380 @@ func TestPushPull(t *testing.T) {
 	}
 }
 
+func TestMergeAllCanceled(t *testing.T) {
+	repoA, repoB, remote := setupRepos(t)
+	defer cleanupRepos(repoA, repoB, remote)
+
+	for _, title := range []string{"bug1", "bug2"} {
+		b, _, err := Create(rene, unix, title, "message")
+		assert.Nil(t, err)
+		err = b.Commit(repoA)
+		assert.Nil(t, err)
+	}
+
+	_, err := Push(repoA, "origin")
+	assert.Nil(t, err)
+
+	_, err = Fetch(repoB, "origin")
+	assert.Nil(t, err)
+
+	count, err := RemoteBugCount(repoB, "origin")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var results []MergeResult
+	for result := range MergeAllContext(ctx, repoB, "origin") {
+		results = append(results, result)
+	}
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, context.Canceled, results[0].Err)
+
+	bugs := allBugs(t, ReadAllLocalBugs(repoB))
+	assert.Len(t, bugs, 0)
+
+	_, err = FetchContext(ctx, repoB, "origin")
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestPushPullAliases(t *testing.T) {
+	repoA, repoB, remote := setupRepos(t)
+	defer cleanupRepos(repoA, repoB, remote)
+
+	createdBefore := func(id1, id2 string) bool {
+		return id1 < id2
+	}
+
+	aliasesA, err := ReadAliases(repoA)
+	assert.Nil(t, err)
+	aliasesA.Assign("aaaa")
+	aliasesA.Assign("cccc")
+	err = aliasesA.Write(repoA)
+	assert.Nil(t, err)
+
+	// A --> remote --> B
+	_, err = Push(repoA, "origin")
+	assert.Nil(t, err)
+
+	aliasesB, err := ReadAliases(repoB)
+	assert.Nil(t, err)
+	aliasesB.Assign("bbbb")
+	err = aliasesB.Write(repoB)
+	assert.Nil(t, err)
+
+	_, err = Fetch(repoB, "origin")
+	assert.Nil(t, err)
+	err = aliasesB.MergeRemote(repoB, "origin", createdBefore)
+	assert.Nil(t, err)
+
+	// the first created bug keep #1, the other one is renumbered after the
+	// remote aliases
+	number, _ := aliasesB.Alias("aaaa")
+	assert.Equal(t, 1, number)
+	number, _ = aliasesB.Alias("cccc")
+	assert.Equal(t, 2, number)
+	number, _ = aliasesB.Alias("bbbb")
+	assert.Equal(t, 3, number)
+
+	// B --> remote --> A
+	_, err = Push(repoB, "origin")
+	assert.Nil(t, err)
+
+	_, err = Fetch(repoA, "origin")
+	assert.Nil(t, err)
+	err = aliasesA.MergeRemote(repoA, "origin", createdBefore)
+	assert.Nil(t, err)
+
+	aliasesA, err = ReadAliases(repoA)
+	assert.Nil(t, err)
+	assert.True(t, aliasesA.equal(aliasesB))
+}
+
+func TestPushDelta(t *testing.T) {
+	repoA, repoB, remote := setupRepos(t)
+	defer cleanupRepos(repoA, repoB, remote)
+
+	bug1, _, err := Create(rene, unix, "bug1", "message")
+	assert.Nil(t, err)
+	err = bug1.Commit(repoA)
+	assert.Nil(t, err)
+
+	results, err := Push(repoA, "origin")
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, bug1.Id(), results[0].Id)
+	assert.Equal(t, 1, results[0].OpCount)
+
+	// nothing new, nothing sent
+	results, err = Push(repoA, "origin")
+	assert.Nil(t, err)
+	assert.Len(t, results, 0)
+
+	_, err = AddComment(bug1, rene, unix, "comment 1")
+	assert.Nil(t, err)
+	_, err = AddComment(bug1, rene, unix, "comment 2")
+	assert.Nil(t, err)
+	err = bug1.Commit(repoA)
+	assert.Nil(t, err)
+
+	bug2, _, err := Create(rene, unix, "bug2", "message")
+	assert.Nil(t, err)
+	err = bug2.Commit(repoA)
+	assert.Nil(t, err)
+
+	results, err = Push(repoA, "origin")
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+
+	sent := make(map[string]int)
+	for _, result := range results {
+		sent[result.Id] = result.OpCount
+	}
+	assert.Equal(t, 2, sent[bug1.Id()])
+	assert.Equal(t, 1, sent[bug2.Id()])
+
+	// B already has everything once pulled
+	err = Pull(repoB, "origin")
+	assert.Nil(t, err)
+
+	results, err = Push(repoB, "origin")
+	assert.Nil(t, err)
+	assert.Len(t, results, 0)
+}
+
+func TestQuarantine(t *testing.T) {
+	repoA, repoB, remote := setupRepos(t)
+	defer cleanupRepos(repoA, repoB, remote)
+
+	err := repoB.StoreConfig("git-bug.trusted-authors", "someone@example.com")
+	assert.Nil(t, err)
+
+	bug1, _, err := Create(rene, unix, "bug1", "message")
+	assert.Nil(t, err)
+	err = bug1.Commit(repoA)
+	assert.Nil(t, err)
+
+	_, err = Push(repoA, "origin")
+	assert.Nil(t, err)
+
+	_, err = Fetch(repoB, "origin")
+	assert.Nil(t, err)
+
+	for result := range MergeAll(repoB, "origin") {
+		assert.Nil(t, result.Err)
+		assert.Equal(t, MergeStatusQuarantined, result.Status)
+		assert.Equal(t, []Person{rene}, result.Untrusted)
+	}
+
+	ids, err := ListLocalIds(repoB)
+	assert.Nil(t, err)
+	assert.Len(t, ids, 0)
+
+	ids, err = ListQuarantinedIds(repoB)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{bug1.Id()}, ids)
+
+	result, err := AcceptQuarantined(repoB, bug1.Id())
+	assert.Nil(t, err)
+	assert.Equal(t, MergeStatusNew, result.Status)
+
+	ids, err = ListQuarantinedIds(repoB)
+	assert.Nil(t, err)
+	assert.Len(t, ids, 0)
+
+	ids, err = ListLocalIds(repoB)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{bug1.Id()}, ids)
+
+	// once accepted, the same operations are not quarantined again
+	for result := range MergeAll(repoB, "origin") {
+		assert.Nil(t, result.Err)
+		assert.Equal(t, MergeStatusNothing, result.Status)
+	}
+}
+
+func TestPolicyEnforcement(t *testing.T) {
+	repoA, repoB, remote := setupRepos(t)
+	defer cleanupRepos(repoA, repoB, remote)
+
+	policy, err := ParsePolicy([]byte("restrict close someone@example.com"))
+	assert.Nil(t, err)
+	err = WritePolicy(repoA, policy, false)
+	assert.Nil(t, err)
+
+	bug1, _, err := Create(rene, unix, "bug1", "message")
+	assert.Nil(t, err)
+	err = bug1.Commit(repoA)
+	assert.Nil(t, err)
+
+	bug2, _, err := Create(rene, unix, "bug2", "message")
+	assert.Nil(t, err)
+	_, err = Close(bug2, rene, unix)
+	assert.Nil(t, err)
+	err = bug2.Commit(repoA)
+	assert.Nil(t, err)
+
+	_, err = Push(repoA, "origin")
+	assert.Nil(t, err)
+
+	_, err = Fetch(repoB, "origin")
+	assert.Nil(t, err)
+
+	// the policy is not signed
+	err = repoB.StoreConfig(policyRequireSignatureConfigKey, "false")
+	assert.Nil(t, err)
+
+	for result := range MergeAll(repoB, "origin") {
+		assert.Nil(t, result.Err)
+		switch result.Id {
+		case bug1.Id():
+			assert.Equal(t, MergeStatusNew, result.Status)
+		case bug2.Id():
+			assert.Equal(t, MergeStatusInvalid, result.Status)
+		}
+	}
+
+	// the policy of the remote has been adopted
+	read, err := ReadPolicy(repoB)
+	assert.Nil(t, err)
+	assert.Equal(t, policy, read)
+
+	ids, err := ListLocalIds(repoB)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{bug1.Id()}, ids)
+}
+
+func TestPolicyRejected(t *testing.T) {
+	repoA, repoB, remote := setupRepos(t)
+	defer cleanupRepos(repoA, repoB, remote)
+
+	policy, err := ParsePolicy([]byte("restrict close someone@example.com"))
+	assert.Nil(t, err)
+	err = WritePolicy(repoA, policy, false)
+	assert.Nil(t, err)
+
+	bug1, _, err := Create(rene, unix, "bug1", "message")
+	assert.Nil(t, err)
+	_, err = Close(bug1, rene, unix)
+	assert.Nil(t, err)
+	err = bug1.Commit(repoA)
+	assert.Nil(t, err)
+
+	_, err = Push(repoA, "origin")
+	assert.Nil(t, err)
+
+	_, err = Fetch(repoB, "origin")
+	assert.Nil(t, err)
+
+	// an unsigned policy is rejected by default with a warning, the bugs are
+	// merged with the local policy
+	var warnings []error
+	for result := range MergeAll(repoB, "origin") {
+		assert.Nil(t, result.Err)
+		if result.Warning != nil {
+			warnings = append(warnings, result.Warning)
+			continue
+		}
+		assert.Equal(t, MergeStatusNew, result.Status)
+	}
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0].Error(), "remote policy rejected")
+
+	read, err := ReadPolicy(repoB)
+	assert.Nil(t, err)
+	assert.True(t, read.IsEmpty())
+
+	// a policy this version can't parse is rejected as well, even unsigned
+	// ones being accepted
+	err = repoB.StoreConfig(policyRequireSignatureConfigKey, "false")
+	assert.Nil(t, err)
+
+	blobHash, err := repoB.StoreData([]byte("restrict unknown-action someone@example.com"))
+	assert.Nil(t, err)
+	treeHash, err := repoB.StoreTree([]repository.TreeEntry{
+		{ObjectType: repository.Blob, Hash: blobHash, Name: policyEntryName},
+	})
+	assert.Nil(t, err)
+	commitHash, err := repoB.StoreCommit(treeHash)
+	assert.Nil(t, err)
+	err = repoB.UpdateRef(policyRemoteRef("origin"), commitHash)
+	assert.Nil(t, err)
+
+	warnings = nil
+	for result := range MergeAll(repoB, "origin") {
+		assert.Nil(t, result.Err)
+		if result.Warning != nil {
+			warnings = append(warnings, result.Warning)
+		}
+	}
+	assert.Len(t, warnings, 1)
+
+	read, err = ReadPolicy(repoB)
+	assert.Nil(t, err)
+	assert.True(t, read.IsEmpty())
+
+	// a rejected policy doesn't prevent pulling
+	assert.Nil(t, Pull(repoB, "origin"))
+}
+
+func TestTrash(t *testing.T) {
+	repoA, repoB, remote := setupRepos(t)
+	defer cleanupRepos(repoA, repoB, remote)
+
+	bug1, _, err := Create(rene, unix, "bug1", "message")
+	assert.Nil(t, err)
+	err = bug1.Commit(repoA)
+	assert.Nil(t, err)
+
+	_, err = Push(repoA, "origin")
+	assert.Nil(t, err)
+
+	err = Pull(repoB, "origin")
+	assert.Nil(t, err)
+
+	err = Trash(repoB, bug1.Id())
+	assert.Nil(t, err)
+
+	ids, err := ListLocalIds(repoB)
+	assert.Nil(t, err)
+	assert.Len(t, ids, 0)
+
+	ids, err = ListTrashedIds(repoB)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{bug1.Id()}, ids)
+
+	// a trashed bug is not brought back by a pull
+	err = Pull(repoB, "origin")
+	assert.Nil(t, err)
+
+	ids, err = ListLocalIds(repoB)
+	assert.Nil(t, err)
+	assert.Len(t, ids, 0)
+
+	err = Restore(repoB, bug1.Id())
+	assert.Nil(t, err)
+
+	ids, err = ListLocalIds(repoB)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{bug1.Id()}, ids)
+
+	ids, err = ListTrashedIds(repoB)
+	assert.Nil(t, err)
+	assert.Len(t, ids, 0)
+
+	err = Trash(repoB, bug1.Id())
+	assert.Nil(t, err)
+	err = Purge(repoB, bug1.Id())
+	assert.Nil(t, err)
+
+	ids, err = ListTrashedIds(repoB)
+	assert.Nil(t, err)
+	assert.Len(t, ids, 0)
+}
+
 func allBugs(t testing.TB, bugs <-chan StreamedBug) []*Bug {
 	var result []*Bug
 	for streamed := range bugs {
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"testing"
+	"time"
 )
 
 func TestBugId(t *testing.T) {
@@ -92,3 +93,24 @@ func TestBugSerialisation(t *testing.T) {
 		t.Fatal(diff)
 	}
 }
+
+func TestCompileUpToAndAt(t *testing.T) {
+	b := NewBug()
+
+	b.Append(NewCreateOp(rene, unix, "title", "message", nil))
+	b.Append(NewSetTitleOp(rene, unix+10, "title2", "title"))
+	b.Append(NewSetStatusOp(rene, unix+20, ClosedStatus))
+
+	snap := b.CompileUpTo(2)
+	assert.Equal(t, "title2", snap.Title)
+	assert.Equal(t, OpenStatus, snap.Status)
+	assert.Len(t, snap.Operations, 2)
+
+	snap = b.CompileAt(time.Unix(unix+5, 0))
+	assert.Equal(t, "title", snap.Title)
+	assert.Len(t, snap.Operations, 1)
+
+	snap = b.CompileAt(time.Unix(unix+20, 0))
+	assert.Equal(t, ClosedStatus, snap.Status)
+	assert.Len(t, snap.Operations, 3)
+}
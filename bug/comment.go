@@ -22,7 +22,7 @@ func (c Comment) FormatTimeRel() string {
 }
 
 func (c Comment) FormatTime() string {
-	return c.UnixTime.Time().Format("Mon Jan 2 15:04:05 2006 +0200")
+	return c.UnixTime.Time().Format("Mon Jan 2 15:04:05 2006 -0700")
 }
 
 // Sign post method for gqlgen
@@ -0,0 +1,301 @@
+package bug
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SnapshotDiff describe the structural changes between two snapshots of the
+// same bug
+type SnapshotDiff struct {
+	TitleChanged bool
+	OldTitle     string
+	NewTitle     string
+
+	StatusChanged bool
+	OldStatus     Status
+	NewStatus     Status
+
+	AddedLabels   []Label
+	RemovedLabels []Label
+
+	AddedComments  int
+	EditedComments int
+
+	EstimateChanged bool
+	OldEstimate     float64
+	NewEstimate     float64
+
+	VisibilityChanged bool
+	NewVisibility     Visibility
+
+	AddedVotes   []Person
+	RemovedVotes []Person
+
+	AddedFixedIn []FixedIn
+
+	AssigneeChanged bool
+	NewAssignee     *Person
+}
+
+// Diff compute the changes needed to go from the snapshot a to the snapshot b.
+// Both snapshots are expected to come from the same bug, b being the most
+// recent one.
+func Diff(a, b *Snapshot) SnapshotDiff {
+	var diff SnapshotDiff
+
+	if a.Title != b.Title {
+		diff.TitleChanged = true
+		diff.OldTitle = a.Title
+		diff.NewTitle = b.Title
+	}
+
+	if a.Status != b.Status {
+		diff.StatusChanged = true
+		diff.OldStatus = a.Status
+		diff.NewStatus = b.Status
+	}
+
+	diff.AddedLabels = labelsDifference(b.Labels, a.Labels)
+	diff.RemovedLabels = labelsDifference(a.Labels, b.Labels)
+
+	common := len(a.Comments)
+	if len(b.Comments) < common {
+		common = len(b.Comments)
+	}
+
+	for i := 0; i < common; i++ {
+		if a.Comments[i].Message != b.Comments[i].Message {
+			diff.EditedComments++
+		}
+	}
+
+	if len(b.Comments) > len(a.Comments) {
+		diff.AddedComments = len(b.Comments) - len(a.Comments)
+	}
+
+	if a.Estimate != b.Estimate {
+		diff.EstimateChanged = true
+		diff.OldEstimate = a.Estimate
+		diff.NewEstimate = b.Estimate
+	}
+
+	if a.Visibility.String() != b.Visibility.String() {
+		diff.VisibilityChanged = true
+		diff.NewVisibility = b.Visibility
+	}
+
+	diff.AddedVotes = personsDifference(b.Votes, a.Votes)
+	diff.RemovedVotes = personsDifference(a.Votes, b.Votes)
+
+	diff.AddedFixedIn = fixedInDifference(b.FixedIn, a.FixedIn)
+
+	if !samePerson(a.Assignee, b.Assignee) {
+		diff.AssigneeChanged = true
+		diff.NewAssignee = b.Assignee
+	}
+
+	return diff
+}
+
+// IsEmpty return true if the diff doesn't hold any change
+func (diff SnapshotDiff) IsEmpty() bool {
+	return len(diff.Changes()) == 0
+}
+
+// Changes return a human readable description of each change
+func (diff SnapshotDiff) Changes() []string {
+	var changes []string
+
+	if diff.TitleChanged {
+		if diff.OldTitle == "" {
+			changes = append(changes, fmt.Sprintf("title set to \"%s\"", diff.NewTitle))
+		} else {
+			changes = append(changes, fmt.Sprintf("title changed from \"%s\" to \"%s\"", diff.OldTitle, diff.NewTitle))
+		}
+	}
+
+	if diff.StatusChanged {
+		changes = append(changes, fmt.Sprintf("status changed to %s", diff.NewStatus))
+	}
+
+	if len(diff.AddedLabels) > 0 {
+		changes = append(changes, fmt.Sprintf("labels added: %s", joinLabels(diff.AddedLabels)))
+	}
+
+	if len(diff.RemovedLabels) > 0 {
+		changes = append(changes, fmt.Sprintf("labels removed: %s", joinLabels(diff.RemovedLabels)))
+	}
+
+	if diff.AddedComments > 0 {
+		changes = append(changes, plural(diff.AddedComments, "comment")+" added")
+	}
+
+	if diff.EditedComments > 0 {
+		changes = append(changes, plural(diff.EditedComments, "comment")+" edited")
+	}
+
+	if diff.EstimateChanged {
+		changes = append(changes, fmt.Sprintf("estimate changed from %g to %g", diff.OldEstimate, diff.NewEstimate))
+	}
+
+	if diff.VisibilityChanged {
+		changes = append(changes, fmt.Sprintf("visibility changed to %s", diff.NewVisibility))
+	}
+
+	if len(diff.AddedVotes) > 0 {
+		changes = append(changes, plural(len(diff.AddedVotes), "vote")+" added")
+	}
+
+	if len(diff.RemovedVotes) > 0 {
+		changes = append(changes, plural(len(diff.RemovedVotes), "vote")+" removed")
+	}
+
+	for _, fix := range diff.AddedFixedIn {
+		changes = append(changes, fmt.Sprintf("fixed in %s", fix))
+	}
+
+	if diff.AssigneeChanged {
+		if diff.NewAssignee == nil {
+			changes = append(changes, "unassigned")
+		} else {
+			changes = append(changes, fmt.Sprintf("assigned to %s", diff.NewAssignee.DisplayName()))
+		}
+	}
+
+	return changes
+}
+
+// String return a one line summary of the diff
+func (diff SnapshotDiff) String() string {
+	return strings.Join(diff.Changes(), ", ")
+}
+
+// HistoryEntry is the changes made by a single operation
+type HistoryEntry struct {
+	Operation Operation
+	Author    Person
+	Diff      SnapshotDiff
+}
+
+// History return, for each operation of the bug, the changes it made. The
+// operations without visible effect are skipped.
+func History(bug Interface) []HistoryEntry {
+	var result []HistoryEntry
+
+	snap := Snapshot{
+		id:     bugFromInterface(bug).id,
+		Status: OpenStatus,
+	}
+
+	it := NewOperationIterator(bug)
+
+	for it.Next() {
+		op := it.Value()
+
+		before := snap.clone()
+		op.Apply(&snap)
+		snap.Operations = append(snap.Operations, op)
+
+		diff := Diff(&before, &snap)
+		if diff.IsEmpty() {
+			continue
+		}
+
+		result = append(result, HistoryEntry{
+			Operation: op,
+			Author:    op.base().Author,
+			Diff:      diff,
+		})
+	}
+
+	return result
+}
+
+// clone return a copy of the snapshot that won't be affected by further
+// operations applied on the original
+func (snap *Snapshot) clone() Snapshot {
+	clone := *snap
+
+	clone.Comments = append([]Comment(nil), snap.Comments...)
+	clone.Labels = append([]Label(nil), snap.Labels...)
+	clone.Votes = append([]Person(nil), snap.Votes...)
+	clone.FixedIn = append([]FixedIn(nil), snap.FixedIn...)
+	clone.Timeline = append([]TimelineItem(nil), snap.Timeline...)
+	clone.Operations = append([]Operation(nil), snap.Operations...)
+
+	return clone
+}
+
+// fixedInDifference return the fixes of a that are not in b
+func fixedInDifference(a, b []FixedIn) []FixedIn {
+	var result []FixedIn
+
+outer:
+	for _, fix := range a {
+		for _, other := range b {
+			if fix == other {
+				continue outer
+			}
+		}
+		result = append(result, fix)
+	}
+
+	return result
+}
+
+// labelsDifference return the labels of a that are not in b
+func labelsDifference(a, b []Label) []Label {
+	var result []Label
+
+outer:
+	for _, label := range a {
+		for _, other := range b {
+			if label == other {
+				continue outer
+			}
+		}
+		result = append(result, label)
+	}
+
+	return result
+}
+
+// personsDifference return the persons of a that are not in b
+func personsDifference(a, b []Person) []Person {
+	var result []Person
+
+outer:
+	for _, person := range a {
+		for _, other := range b {
+			if person == other {
+				continue outer
+			}
+		}
+		result = append(result, person)
+	}
+
+	return result
+}
+
+func samePerson(a, b *Person) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func joinLabels(labels []Label) string {
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = label.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", word)
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
@@ -0,0 +1,54 @@
+package bug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	b, _, err := Create(rene, unix, "title", "message")
+	assert.NoError(t, err)
+
+	before := b.Compile()
+
+	_, err = SetTitle(b, rene, unix, "new title")
+	assert.NoError(t, err)
+	_, _, err = ChangeLabels(b, rene, unix, []string{"bug"}, nil)
+	assert.NoError(t, err)
+	_, err = AddComment(b, rene, unix, "another message")
+	assert.NoError(t, err)
+
+	after := b.Compile()
+
+	diff := Diff(&before, &after)
+
+	assert.True(t, diff.TitleChanged)
+	assert.Equal(t, "title", diff.OldTitle)
+	assert.Equal(t, "new title", diff.NewTitle)
+	assert.False(t, diff.StatusChanged)
+	assert.Equal(t, []Label{"bug"}, diff.AddedLabels)
+	assert.Empty(t, diff.RemovedLabels)
+	assert.Equal(t, 1, diff.AddedComments)
+	assert.Equal(t, "title changed from \"title\" to \"new title\", labels added: bug, 1 comment added", diff.String())
+
+	assert.True(t, Diff(&after, &after).IsEmpty())
+}
+
+func TestHistory(t *testing.T) {
+	b, _, err := Create(rene, unix, "title", "message")
+	assert.NoError(t, err)
+
+	_, err = Close(b, rene, unix)
+	assert.NoError(t, err)
+	// no effect, already closed
+	_, err = Close(b, rene, unix)
+	assert.NoError(t, err)
+
+	history := History(b)
+
+	assert.Len(t, history, 2)
+	assert.Equal(t, rene, history[0].Author)
+	assert.Equal(t, "title set to \"title\", 1 comment added", history[0].Diff.String())
+	assert.Equal(t, "status changed to closed", history[1].Diff.String())
+}
@@ -0,0 +1,232 @@
+package bug
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/pkg/errors"
+)
+
+// encryptionKeyConfigKey is the git config key holding the symmetric key
+// encrypting the operation packs and the attached files, encoded in base64.
+// Being in the local configuration, the key is never pushed and must be shared
+// out-of-band.
+const encryptionKeyConfigKey = "git-bug.encryption-key"
+
+// encryptionKeySize is the size of the key, for AES-256
+const encryptionKeySize = 32
+
+// encryptedPackPrefix mark the blobs of the encrypted operation packs. It is
+// followed by the nonce and the sealed json of the pack.
+var encryptedPackPrefix = []byte("git-bug encrypted pack v1\n")
+
+// encryptedMediaPrefix mark the blobs of the encrypted attached files, in the
+// same way
+var encryptedMediaPrefix = []byte("git-bug encrypted media v1\n")
+
+// ErrNoEncryptionKey is returned when reading an encrypted bug without the
+// key of the repository
+var ErrNoEncryptionKey = errors.New("the bug is encrypted and no encryption key is configured")
+
+// GenerateEncryptionKey create a new random key, encoded as stored in the
+// configuration
+func GenerateEncryptionKey() (string, error) {
+	key := make([]byte, encryptionKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// SetEncryptionKey store the key of the repository. From then, the new
+// operation packs are encrypted with it.
+func SetEncryptionKey(repo repository.RepoCommon, key string) error {
+	key = strings.TrimSpace(key)
+	if _, err := decodeEncryptionKey(key); err != nil {
+		return err
+	}
+	return repo.StoreConfig(encryptionKeyConfigKey, key)
+}
+
+// ReadEncryptionKey return the key of the repository, or nil if the
+// operation packs are not encrypted
+func ReadEncryptionKey(repo repository.RepoCommon) ([]byte, error) {
+	configs, err := repo.ReadConfigs(encryptionKeyConfigKey)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := configs[encryptionKeyConfigKey]
+	if !ok || value == "" {
+		return nil, nil
+	}
+
+	return decodeEncryptionKey(value)
+}
+
+func decodeEncryptionKey(value string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid encryption key")
+	}
+	if len(key) != encryptionKeySize {
+		return nil, fmt.Errorf("invalid encryption key: expected %d bytes, got %d", encryptionKeySize, len(key))
+	}
+	return key, nil
+}
+
+// IsEncryptedPack tell if the data of a blob is an encrypted operation pack
+func IsEncryptedPack(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedPackPrefix)
+}
+
+// encryptPack seal the serialized operation pack with the key
+func encryptPack(key []byte, data []byte) ([]byte, error) {
+	return encryptBlob(key, encryptedPackPrefix, data)
+}
+
+// decryptPack open an encrypted operation pack with the key
+func decryptPack(key []byte, data []byte) ([]byte, error) {
+	result, err := decryptBlob(key, encryptedPackPrefix, data)
+	if err == errTruncatedBlob {
+		return nil, fmt.Errorf("truncated encrypted pack")
+	}
+	if err != nil {
+		return nil, errors.New("failed to decrypt the bug, the encryption key might be wrong")
+	}
+	return result, nil
+}
+
+// StoreMedia store an attached file in the repository and return its hash.
+// The blob is encrypted if the repo has an encryption key, the hash being the
+// one of the encrypted blob.
+func StoreMedia(repo repository.Repo, data []byte) (git.Hash, error) {
+	key, err := ReadEncryptionKey(repo)
+	if err != nil {
+		return "", err
+	}
+
+	if key != nil {
+		data, err = encryptBlob(key, encryptedMediaPrefix, data)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return repo.StoreData(data)
+}
+
+// ReadMedia read an attached file from the repository, decrypting it if
+// needed
+func ReadMedia(repo repository.Repo, hash git.Hash) ([]byte, error) {
+	data, err := repo.ReadData(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(data, encryptedMediaPrefix) {
+		return data, nil
+	}
+
+	key, err := ReadEncryptionKey(repo)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, ErrNoEncryptionKey
+	}
+
+	result, err := decryptBlob(key, encryptedMediaPrefix, data)
+	if err == errTruncatedBlob {
+		return nil, fmt.Errorf("truncated encrypted file %s", hash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the file %s, the encryption key might be wrong", hash)
+	}
+	return result, nil
+}
+
+// errTruncatedBlob is returned when an encrypted blob is too short to hold
+// its nonce
+var errTruncatedBlob = errors.New("truncated encrypted blob")
+
+// encryptBlob seal some data with the key, the prefix marking the kind of
+// blob being authenticated with it
+func encryptBlob(key []byte, prefix []byte, data []byte) ([]byte, error) {
+	gcm, err := newPackCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	result := append([]byte{}, prefix...)
+	result = append(result, nonce...)
+	return gcm.Seal(result, nonce, data, prefix), nil
+}
+
+// decryptBlob open a blob sealed by encryptBlob with the same prefix
+func decryptBlob(key []byte, prefix []byte, data []byte) ([]byte, error) {
+	gcm, err := newPackCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := data[len(prefix):]
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errTruncatedBlob
+	}
+
+	nonce, sealed := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+
+	return gcm.Open(nil, nonce, sealed, prefix)
+}
+
+func newPackCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// packDecrypter decrypt the operation packs of a bug as needed, reading the
+// key of the repository only once
+type packDecrypter struct {
+	repo   repository.RepoCommon
+	key    []byte
+	loaded bool
+}
+
+// read return the json of an operation pack from the data of its blob
+func (d *packDecrypter) read(data []byte) ([]byte, error) {
+	if !IsEncryptedPack(data) {
+		return data, nil
+	}
+
+	if !d.loaded {
+		key, err := ReadEncryptionKey(d.repo)
+		if err != nil {
+			return nil, err
+		}
+		d.key = key
+		d.loaded = true
+	}
+
+	if d.key == nil {
+		return nil, ErrNoEncryptionKey
+	}
+
+	return decryptPack(d.key, data)
+}
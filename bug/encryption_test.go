@@ -0,0 +1,97 @@
+package bug
+
+import (
+	"testing"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptPack(t *testing.T) {
+	encoded, err := GenerateEncryptionKey()
+	require.NoError(t, err)
+	key, err := decodeEncryptionKey(encoded)
+	require.NoError(t, err)
+
+	data := []byte(`{"ops":[]}`)
+
+	encrypted, err := encryptPack(key, data)
+	require.NoError(t, err)
+	assert.True(t, IsEncryptedPack(encrypted))
+	assert.NotContains(t, string(encrypted), "ops")
+
+	decrypted, err := decryptPack(key, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, data, decrypted)
+
+	other, err := GenerateEncryptionKey()
+	require.NoError(t, err)
+	otherKey, err := decodeEncryptionKey(other)
+	require.NoError(t, err)
+
+	_, err = decryptPack(otherKey, encrypted)
+	assert.Error(t, err)
+
+	_, err = decodeEncryptionKey("dG9vIHNob3J0")
+	assert.Error(t, err)
+}
+
+func TestEncryptedBug(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	key, err := GenerateEncryptionKey()
+	require.NoError(t, err)
+	require.NoError(t, SetEncryptionKey(repo, key))
+
+	bug1 := NewBug()
+	bug1.Append(createOp)
+	bug1.Append(addCommentOp)
+	require.NoError(t, bug1.Commit(repo))
+
+	data, err := repo.ReadData(bug1.rootPack)
+	require.NoError(t, err)
+	assert.True(t, IsEncryptedPack(data))
+	assert.NotContains(t, string(data), "message")
+
+	bug2, err := ReadLocalBug(repo, bug1.Id())
+	require.NoError(t, err)
+	assert.Equal(t, bug1.Compile().Title, bug2.Compile().Title)
+
+	// without the key, the bug can't be read
+	require.NoError(t, repo.RmConfigs(encryptionKeyConfigKey))
+	_, err = ReadLocalBug(repo, bug1.Id())
+	assert.Equal(t, ErrNoEncryptionKey, err)
+}
+
+func TestEncryptedMedia(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	plain, err := StoreMedia(repo, []byte("plain file"))
+	require.NoError(t, err)
+
+	key, err := GenerateEncryptionKey()
+	require.NoError(t, err)
+	require.NoError(t, SetEncryptionKey(repo, key))
+
+	hash, err := StoreMedia(repo, []byte("secret file"))
+	require.NoError(t, err)
+
+	data, err := repo.ReadData(hash)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+
+	data, err = ReadMedia(repo, hash)
+	require.NoError(t, err)
+	assert.Equal(t, "secret file", string(data))
+
+	// the files stored before enabling the encryption stay readable
+	data, err = ReadMedia(repo, plain)
+	require.NoError(t, err)
+	assert.Equal(t, "plain file", string(data))
+
+	// without the key, the file can't be read
+	require.NoError(t, repo.RmConfigs(encryptionKeyConfigKey))
+	_, err = ReadMedia(repo, hash)
+	assert.Equal(t, ErrNoEncryptionKey, err)
+}
@@ -3,6 +3,7 @@ package bug
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/MichaelMure/git-bug/util/text"
@@ -48,3 +49,85 @@ func (l Label) Validate() error {
 
 	return nil
 }
+
+// Canonical return the label rewritten according to the given policy
+func (l Label) Canonical(policy LabelPolicy) Label {
+	switch policy {
+	case LabelPolicyNone:
+		return l
+	case LabelPolicyLowercase:
+		return Label(strings.ToLower(collapseSpaces(string(l))))
+	default:
+		return Label(collapseSpaces(string(l)))
+	}
+}
+
+// Equivalent tell if two labels should be considered the same, ignoring the
+// casing and the whitespaces. For example, "Good first issue" is equivalent
+// to "good  first issue".
+func (l Label) Equivalent(other Label) bool {
+	return l.equivalenceKey() == other.equivalenceKey()
+}
+
+func (l Label) equivalenceKey() string {
+	return strings.ToLower(collapseSpaces(string(l)))
+}
+
+func collapseSpaces(str string) string {
+	return strings.Join(strings.Fields(str), " ")
+}
+
+// SortLabels sort a list of labels in a deterministic order, ignoring the
+// casing first so that equivalent labels end up next to each other
+func SortLabels(labels []Label) {
+	sort.Slice(labels, func(i, j int) bool {
+		ki, kj := labels[i].equivalenceKey(), labels[j].equivalenceKey()
+		if ki != kj {
+			return ki < kj
+		}
+		return string(labels[i]) < string(labels[j])
+	})
+}
+
+// LabelPolicy define how the labels are canonicalized when they are added to
+// a bug
+type LabelPolicy int
+
+const (
+	// LabelPolicyTrim remove the leading and trailing whitespaces and collapse
+	// the inner ones
+	LabelPolicyTrim LabelPolicy = iota
+	// LabelPolicyLowercase does the same as LabelPolicyTrim and lowercase the label
+	LabelPolicyLowercase
+	// LabelPolicyNone keep the labels as they are
+	LabelPolicyNone
+)
+
+// LabelPolicyFromString parse a label policy as found in the configuration
+func LabelPolicyFromString(str string) (LabelPolicy, error) {
+	switch strings.ToLower(str) {
+	case "", "trim":
+		return LabelPolicyTrim, nil
+	case "lowercase":
+		return LabelPolicyLowercase, nil
+	case "none":
+		return LabelPolicyNone, nil
+	default:
+		return 0, fmt.Errorf("unknown label policy %s", str)
+	}
+}
+
+// LabelPolicies are all the label policies, in the order of their values
+var LabelPolicies = []LabelPolicy{LabelPolicyTrim, LabelPolicyLowercase, LabelPolicyNone}
+
+// String return the label policy as found in the configuration
+func (p LabelPolicy) String() string {
+	switch p {
+	case LabelPolicyLowercase:
+		return "lowercase"
+	case LabelPolicyNone:
+		return "none"
+	default:
+		return "trim"
+	}
+}
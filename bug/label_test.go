@@ -0,0 +1,51 @@
+package bug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelCanonical(t *testing.T) {
+	label := Label("  Good   first issue ")
+
+	assert.Equal(t, label, label.Canonical(LabelPolicyNone))
+	assert.Equal(t, Label("Good first issue"), label.Canonical(LabelPolicyTrim))
+	assert.Equal(t, Label("good first issue"), label.Canonical(LabelPolicyLowercase))
+}
+
+func TestLabelEquivalent(t *testing.T) {
+	assert.True(t, Label("Bug").Equivalent("bug"))
+	assert.True(t, Label("good first issue").Equivalent("Good  first issue"))
+	assert.False(t, Label("bug").Equivalent("bugs"))
+}
+
+func TestSortLabels(t *testing.T) {
+	labels := []Label{"bug", "Feature", "Bug", "api"}
+
+	SortLabels(labels)
+
+	assert.Equal(t, []Label{"api", "Bug", "bug", "Feature"}, labels)
+}
+
+func TestChangeLabelsEquivalence(t *testing.T) {
+	b, _, err := Create(rene, unix, "title", "message")
+	assert.NoError(t, err)
+
+	_, _, err = ChangeLabels(b, rene, unix, []string{"Bug"}, nil)
+	assert.NoError(t, err)
+
+	// an equivalent label is already set
+	results, _, err := ChangeLabels(b, rene, unix, []string{"bug"}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, LabelChangeAlreadySet, results[0].Status)
+
+	// removing an equivalent label remove the existing one
+	results, op, err := ChangeLabels(b, rene, unix, nil, []string{"bug"})
+	assert.NoError(t, err)
+	assert.Equal(t, LabelChangeRemoved, results[0].Status)
+	assert.Equal(t, []Label{"Bug"}, op.Removed)
+
+	snap := b.Compile()
+	assert.Len(t, snap.Labels, 0)
+}
@@ -1,8 +1,6 @@
 package bug
 
 import (
-	"fmt"
-
 	"github.com/MichaelMure/git-bug/util/git"
 	"github.com/MichaelMure/git-bug/util/text"
 )
@@ -59,7 +57,7 @@ func (op *AddCommentOperation) Validate() error {
 	}
 
 	if !text.Safe(op.Message) {
-		return fmt.Errorf("message is not fully printable")
+		return ErrInvalidField{Field: "message", Message: "message is not fully printable"}
 	}
 
 	return nil
@@ -0,0 +1,149 @@
+package bug
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+var _ Operation = &AddFixedInOperation{}
+
+var commitRangeRegexp = regexp.MustCompile(`^[0-9a-f]{4,40}(\.\.[0-9a-f]{4,40})?$`)
+
+// FixedIn link a bug to the release and the commits fixing it
+type FixedIn struct {
+	// Release is usually a git tag, empty if not released yet
+	Release string `json:"release,omitempty"`
+	// Commit is a commit hash or a commit range ("a..b"), empty if unknown
+	Commit string `json:"commit,omitempty"`
+}
+
+// String return a human readable form of the fix, "release (commit)"
+func (f FixedIn) String() string {
+	switch {
+	case f.Release == "":
+		return f.Commit
+	case f.Commit == "":
+		return f.Release
+	default:
+		return fmt.Sprintf("%s (%s)", f.Release, f.Commit)
+	}
+}
+
+// Match tell if the fix match a release or a prefix of one of its commits
+func (f FixedIn) Match(query string) bool {
+	if query == "" {
+		return false
+	}
+
+	if f.Release == query {
+		return true
+	}
+
+	for _, commit := range strings.Split(f.Commit, "..") {
+		if commit != "" && strings.HasPrefix(commit, query) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f FixedIn) Validate() error {
+	if f.Release == "" && f.Commit == "" {
+		return fmt.Errorf("release and commit are empty")
+	}
+
+	if strings.ContainsAny(f.Release, " \t\n") {
+		return fmt.Errorf("release contains whitespaces")
+	}
+
+	if f.Commit != "" && !commitRangeRegexp.MatchString(f.Commit) {
+		return fmt.Errorf("invalid commit or commit range %s", f.Commit)
+	}
+
+	return nil
+}
+
+// HasFixedIn tell if the bug is already linked to a fix. A fix without
+// release is known as soon as the commit is.
+func (snap *Snapshot) HasFixedIn(fix FixedIn) bool {
+	for _, existing := range snap.FixedIn {
+		if existing == fix {
+			return true
+		}
+		if fix.Release == "" && fix.Commit != "" && existing.Commit == fix.Commit {
+			return true
+		}
+	}
+	return false
+}
+
+// AddFixedInOperation will link a bug to the release and the commits fixing
+// it. A bug can be fixed in multiple releases, for example with backports.
+type AddFixedInOperation struct {
+	OpBase
+	Release string `json:"release,omitempty"`
+	Commit  string `json:"commit,omitempty"`
+}
+
+func (op *AddFixedInOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *AddFixedInOperation) Hash() (git.Hash, error) {
+	return hashOperation(op)
+}
+
+func (op *AddFixedInOperation) FixedIn() FixedIn {
+	return FixedIn{Release: op.Release, Commit: op.Commit}
+}
+
+func (op *AddFixedInOperation) Apply(snapshot *Snapshot) {
+	fix := op.FixedIn()
+
+	if snapshot.HasFixedIn(fix) {
+		return
+	}
+
+	for i, existing := range snapshot.FixedIn {
+		// the commit was recorded before being released
+		if existing.Release == "" && existing.Commit == fix.Commit {
+			snapshot.FixedIn[i] = fix
+			return
+		}
+	}
+
+	snapshot.FixedIn = append(snapshot.FixedIn, fix)
+}
+
+func (op *AddFixedInOperation) Validate() error {
+	if err := opBaseValidate(op, AddFixedInOp); err != nil {
+		return err
+	}
+
+	return op.FixedIn().Validate()
+}
+
+// Sign post method for gqlgen
+func (op *AddFixedInOperation) IsAuthored() {}
+
+func NewAddFixedInOp(author Person, unixTime int64, release string, commit string) *AddFixedInOperation {
+	return &AddFixedInOperation{
+		OpBase:  newOpBase(AddFixedInOp, author, unixTime),
+		Release: release,
+		Commit:  commit,
+	}
+}
+
+// Convenience function to apply the operation
+func AddFixedIn(b Interface, author Person, unixTime int64, release string, commit string) (*AddFixedInOperation, error) {
+	op := NewAddFixedInOp(author, unixTime, release, commit)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
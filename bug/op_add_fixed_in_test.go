@@ -0,0 +1,48 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddFixedIn(t *testing.T) {
+	snapshot := Snapshot{}
+
+	var rene = Person{
+		Name:  "René Descartes",
+		Email: "rene@descartes.fr",
+	}
+
+	unix := time.Now().Unix()
+
+	NewAddFixedInOp(rene, unix, "", "abcdef0").Apply(&snapshot)
+	assert.Equal(t, []FixedIn{{Commit: "abcdef0"}}, snapshot.FixedIn)
+
+	// the release complete the commit recorded before
+	NewAddFixedInOp(rene, unix, "v1.0.0", "abcdef0").Apply(&snapshot)
+	assert.Equal(t, []FixedIn{{Release: "v1.0.0", Commit: "abcdef0"}}, snapshot.FixedIn)
+
+	// same fix twice is a no-op
+	NewAddFixedInOp(rene, unix, "v1.0.0", "abcdef0").Apply(&snapshot)
+	assert.Len(t, snapshot.FixedIn, 1)
+
+	// the commit alone is already known
+	NewAddFixedInOp(rene, unix, "", "abcdef0").Apply(&snapshot)
+	assert.Len(t, snapshot.FixedIn, 1)
+
+	// backport
+	NewAddFixedInOp(rene, unix, "v0.9.1", "").Apply(&snapshot)
+	assert.Len(t, snapshot.FixedIn, 2)
+
+	assert.True(t, snapshot.FixedIn[0].Match("v1.0.0"))
+	assert.True(t, snapshot.FixedIn[0].Match("abcd"))
+	assert.False(t, snapshot.FixedIn[0].Match("v1.0"))
+	assert.True(t, FixedIn{Commit: "1234567..abcdef0"}.Match("abc"))
+
+	assert.NoError(t, NewAddFixedInOp(rene, unix, "v1.0.0", "1234567..abcdef0").Validate())
+	assert.Error(t, NewAddFixedInOp(rene, unix, "", "").Validate())
+	assert.Error(t, NewAddFixedInOp(rene, unix, "v1 0", "").Validate())
+	assert.Error(t, NewAddFixedInOp(rene, unix, "", "master").Validate())
+}
@@ -0,0 +1,52 @@
+package bug
+
+import (
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+var _ Operation = &AddVoteOperation{}
+
+// AddVoteOperation will add a vote from its author to a bug. A Person can
+// only vote once, additional votes are ignored.
+type AddVoteOperation struct {
+	OpBase
+}
+
+func (op *AddVoteOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *AddVoteOperation) Hash() (git.Hash, error) {
+	return hashOperation(op)
+}
+
+func (op *AddVoteOperation) Apply(snapshot *Snapshot) {
+	if snapshot.HasVoted(op.Author) {
+		return
+	}
+
+	snapshot.Votes = append(snapshot.Votes, op.Author)
+}
+
+func (op *AddVoteOperation) Validate() error {
+	return opBaseValidate(op, AddVoteOp)
+}
+
+// Sign post method for gqlgen
+func (op *AddVoteOperation) IsAuthored() {}
+
+func NewAddVoteOp(author Person, unixTime int64) *AddVoteOperation {
+	return &AddVoteOperation{
+		OpBase: newOpBase(AddVoteOp, author, unixTime),
+	}
+}
+
+// Convenience function to apply the operation
+func AddVote(b Interface, author Person, unixTime int64) (*AddVoteOperation, error) {
+	op := NewAddVoteOp(author, unixTime)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
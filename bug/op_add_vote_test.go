@@ -0,0 +1,41 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVote(t *testing.T) {
+	snapshot := Snapshot{}
+
+	var rene = Person{
+		Name:  "René Descartes",
+		Email: "rene@descartes.fr",
+	}
+
+	var isaac = Person{
+		Name:  "Isaac Newton",
+		Email: "isaac@newton.uk",
+	}
+
+	unix := time.Now().Unix()
+
+	NewAddVoteOp(rene, unix).Apply(&snapshot)
+	NewAddVoteOp(isaac, unix).Apply(&snapshot)
+	assert.Equal(t, []Person{rene, isaac}, snapshot.Votes)
+
+	// one vote per person
+	NewAddVoteOp(rene, unix).Apply(&snapshot)
+	assert.Equal(t, []Person{rene, isaac}, snapshot.Votes)
+
+	NewRemoveVoteOp(rene, unix).Apply(&snapshot)
+	assert.Equal(t, []Person{isaac}, snapshot.Votes)
+	assert.False(t, snapshot.HasVoted(rene))
+	assert.True(t, snapshot.HasVoted(isaac))
+
+	// removing a missing vote is a no-op
+	NewRemoveVoteOp(rene, unix).Apply(&snapshot)
+	assert.Equal(t, []Person{isaac}, snapshot.Votes)
+}
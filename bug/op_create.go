@@ -1,7 +1,6 @@
 package bug
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/MichaelMure/git-bug/util/git"
@@ -32,12 +31,14 @@ func (op *CreateOperation) Apply(snapshot *Snapshot) {
 	comment := Comment{
 		Message:  op.Message,
 		Author:   op.Author,
+		Files:    op.Files,
 		UnixTime: Timestamp(op.UnixTime),
 	}
 
 	snapshot.Comments = []Comment{comment}
 	snapshot.Author = op.Author
 	snapshot.CreatedAt = op.Time()
+	snapshot.Visibility = VisibilityPublic
 
 	hash, err := op.Hash()
 	if err != nil {
@@ -63,19 +64,19 @@ func (op *CreateOperation) Validate() error {
 	}
 
 	if text.Empty(op.Title) {
-		return fmt.Errorf("title is empty")
+		return ErrInvalidField{Field: "title", Message: "title is empty"}
 	}
 
 	if strings.Contains(op.Title, "\n") {
-		return fmt.Errorf("title should be a single line")
+		return ErrInvalidField{Field: "title", Message: "title should be a single line"}
 	}
 
 	if !text.Safe(op.Title) {
-		return fmt.Errorf("title is not fully printable")
+		return ErrInvalidField{Field: "title", Message: "title is not fully printable"}
 	}
 
 	if !text.Safe(op.Message) {
-		return fmt.Errorf("message is not fully printable")
+		return ErrInvalidField{Field: "message", Message: "message is not fully printable"}
 	}
 
 	return nil
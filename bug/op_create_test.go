@@ -33,8 +33,9 @@ func TestCreate(t *testing.T) {
 		Comments: []Comment{
 			comment,
 		},
-		Author:    rene,
-		CreatedAt: create.Time(),
+		Author:     rene,
+		CreatedAt:  create.Time(),
+		Visibility: VisibilityPublic,
 		Timeline: []TimelineItem{
 			&CreateTimelineItem{
 				CommentTimelineItem: NewCommentTimelineItem(hash, comment),
@@ -47,3 +48,20 @@ func TestCreate(t *testing.T) {
 		t.Fatal(diff)
 	}
 }
+
+func TestCreateInvalidField(t *testing.T) {
+	var rene = Person{
+		Name:  "René Descartes",
+		Email: "rene@descartes.fr",
+	}
+
+	err := NewCreateOp(rene, time.Now().Unix(), "multi\nline", "message", nil).Validate()
+
+	invalid, ok := err.(ErrInvalidField)
+	if !ok {
+		t.Fatalf("expected an ErrInvalidField, got %v", err)
+	}
+	if invalid.Field != "title" {
+		t.Fatalf("expected the title to be invalid, got %s", invalid.Field)
+	}
+}
@@ -30,6 +30,7 @@ func (op *EditCommentOperation) Apply(snapshot *Snapshot) {
 	// crypto signature are needed.
 
 	var target TimelineItem
+	var targetIndex int
 	var commentIndex int
 
 	for i, item := range snapshot.Timeline {
@@ -37,12 +38,13 @@ func (op *EditCommentOperation) Apply(snapshot *Snapshot) {
 
 		if h == op.Target {
 			target = snapshot.Timeline[i]
+			targetIndex = i
 			break
 		}
 
 		// Track the index in the []Comment
 		switch item.(type) {
-		case *CreateTimelineItem, *CommentTimelineItem:
+		case *CreateTimelineItem, *AddCommentTimelineItem:
 			commentIndex++
 		}
 	}
@@ -53,19 +55,26 @@ func (op *EditCommentOperation) Apply(snapshot *Snapshot) {
 	}
 
 	comment := Comment{
+		Author:   op.Author,
 		Message:  op.Message,
 		Files:    op.Files,
 		UnixTime: Timestamp(op.UnixTime),
 	}
 
+	// the item is edited as a copy, as it can be shared with a copy of the
+	// snapshot made before
 	switch target.(type) {
 	case *CreateTimelineItem:
-		item := target.(*CreateTimelineItem)
+		item := *target.(*CreateTimelineItem)
+		item.CommentTimelineItem = item.clone()
 		item.Append(comment)
+		snapshot.Timeline[targetIndex] = &item
 
 	case *AddCommentTimelineItem:
-		item := target.(*AddCommentTimelineItem)
+		item := *target.(*AddCommentTimelineItem)
+		item.CommentTimelineItem = item.clone()
 		item.Append(comment)
+		snapshot.Timeline[targetIndex] = &item
 	}
 
 	snapshot.Comments[commentIndex].Message = op.Message
@@ -86,7 +95,7 @@ func (op *EditCommentOperation) Validate() error {
 	}
 
 	if !text.Safe(op.Message) {
-		return fmt.Errorf("message is not fully printable")
+		return ErrInvalidField{Field: "message", Message: "message is not fully printable"}
 	}
 
 	return nil
@@ -50,4 +50,5 @@ func TestEdit(t *testing.T) {
 	assert.Equal(t, len(snapshot.Timeline[1].(*AddCommentTimelineItem).History), 2)
 	assert.Equal(t, snapshot.Comments[0].Message, "create edited")
 	assert.Equal(t, snapshot.Comments[1].Message, "comment edited")
+	assert.Equal(t, snapshot.Timeline[1].(*AddCommentTimelineItem).History[1].Author, rene)
 }
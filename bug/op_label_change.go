@@ -2,10 +2,8 @@ package bug
 
 import (
 	"fmt"
-	"sort"
 
 	"github.com/MichaelMure/git-bug/util/git"
-	"github.com/pkg/errors"
 )
 
 var _ Operation = &LabelChangeOperation{}
@@ -51,9 +49,7 @@ AddLoop:
 	}
 
 	// Sort
-	sort.Slice(snapshot.Labels, func(i, j int) bool {
-		return string(snapshot.Labels[i]) < string(snapshot.Labels[j])
-	})
+	SortLabels(snapshot.Labels)
 
 	hash, err := op.Hash()
 	if err != nil {
@@ -80,13 +76,13 @@ func (op *LabelChangeOperation) Validate() error {
 
 	for _, l := range op.Added {
 		if err := l.Validate(); err != nil {
-			return errors.Wrap(err, "added label")
+			return ErrInvalidField{Field: "added", Message: "added label: " + err.Error()}
 		}
 	}
 
 	for _, l := range op.Removed {
 		if err := l.Validate(); err != nil {
-			return errors.Wrap(err, "removed label")
+			return ErrInvalidField{Field: "removed", Message: "removed label: " + err.Error()}
 		}
 	}
 
@@ -131,13 +127,13 @@ func ChangeLabels(b Interface, author Person, unixTime int64, add, remove []stri
 		label := Label(str)
 
 		// check for duplicate
-		if labelExist(added, label) {
+		if _, ok := findEquivalentLabel(added, label); ok {
 			results = append(results, LabelChangeResult{Label: label, Status: LabelChangeDuplicateInOp})
 			continue
 		}
 
-		// check that the label doesn't already exist
-		if labelExist(snap.Labels, label) {
+		// check that the label, or an equivalent one, doesn't already exist
+		if _, ok := findEquivalentLabel(snap.Labels, label); ok {
 			results = append(results, LabelChangeResult{Label: label, Status: LabelChangeAlreadySet})
 			continue
 		}
@@ -149,15 +145,17 @@ func ChangeLabels(b Interface, author Person, unixTime int64, add, remove []stri
 	for _, str := range remove {
 		label := Label(str)
 
-		// check for duplicate
-		if labelExist(removed, label) {
-			results = append(results, LabelChangeResult{Label: label, Status: LabelChangeDuplicateInOp})
+		// check that the label, or an equivalent one, actually exist
+		existing, ok := findEquivalentLabel(snap.Labels, label)
+		if !ok {
+			results = append(results, LabelChangeResult{Label: label, Status: LabelChangeDoesntExist})
 			continue
 		}
+		label = existing
 
-		// check that the label actually exist
-		if !labelExist(snap.Labels, label) {
-			results = append(results, LabelChangeResult{Label: label, Status: LabelChangeDoesntExist})
+		// check for duplicate
+		if labelExist(removed, label) {
+			results = append(results, LabelChangeResult{Label: label, Status: LabelChangeDuplicateInOp})
 			continue
 		}
 
@@ -190,6 +188,22 @@ func labelExist(labels []Label, label Label) bool {
 	return false
 }
 
+// findEquivalentLabel search for a label equivalent to the given one, preferring
+// an exact match
+func findEquivalentLabel(labels []Label, label Label) (Label, bool) {
+	if labelExist(labels, label) {
+		return label, true
+	}
+
+	for _, l := range labels {
+		if l.Equivalent(label) {
+			return l, true
+		}
+	}
+
+	return "", false
+}
+
 type LabelChangeStatus int
 
 const (
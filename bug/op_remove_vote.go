@@ -0,0 +1,52 @@
+package bug
+
+import (
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+var _ Operation = &RemoveVoteOperation{}
+
+// RemoveVoteOperation will remove the vote of its author from a bug
+type RemoveVoteOperation struct {
+	OpBase
+}
+
+func (op *RemoveVoteOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *RemoveVoteOperation) Hash() (git.Hash, error) {
+	return hashOperation(op)
+}
+
+func (op *RemoveVoteOperation) Apply(snapshot *Snapshot) {
+	for i, voter := range snapshot.Votes {
+		if voter == op.Author {
+			snapshot.Votes = append(snapshot.Votes[:i], snapshot.Votes[i+1:]...)
+			return
+		}
+	}
+}
+
+func (op *RemoveVoteOperation) Validate() error {
+	return opBaseValidate(op, RemoveVoteOp)
+}
+
+// Sign post method for gqlgen
+func (op *RemoveVoteOperation) IsAuthored() {}
+
+func NewRemoveVoteOp(author Person, unixTime int64) *RemoveVoteOperation {
+	return &RemoveVoteOperation{
+		OpBase: newOpBase(RemoveVoteOp, author, unixTime),
+	}
+}
+
+// Convenience function to apply the operation
+func RemoveVote(b Interface, author Person, unixTime int64) (*RemoveVoteOperation, error) {
+	op := NewRemoveVoteOp(author, unixTime)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
@@ -0,0 +1,61 @@
+package bug
+
+import (
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/pkg/errors"
+)
+
+var _ Operation = &SetAssigneeOperation{}
+
+// SetAssigneeOperation will change the person in charge of a bug, or leave
+// it unassigned if Assignee is nil
+type SetAssigneeOperation struct {
+	OpBase
+	Assignee *Person `json:"assignee,omitempty"`
+}
+
+func (op *SetAssigneeOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *SetAssigneeOperation) Hash() (git.Hash, error) {
+	return hashOperation(op)
+}
+
+func (op *SetAssigneeOperation) Apply(snapshot *Snapshot) {
+	snapshot.Assignee = op.Assignee
+}
+
+func (op *SetAssigneeOperation) Validate() error {
+	if err := opBaseValidate(op, SetAssigneeOp); err != nil {
+		return err
+	}
+
+	if op.Assignee != nil {
+		if err := op.Assignee.Validate(); err != nil {
+			return errors.Wrap(err, "assignee")
+		}
+	}
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *SetAssigneeOperation) IsAuthored() {}
+
+func NewSetAssigneeOp(author Person, unixTime int64, assignee *Person) *SetAssigneeOperation {
+	return &SetAssigneeOperation{
+		OpBase:   newOpBase(SetAssigneeOp, author, unixTime),
+		Assignee: assignee,
+	}
+}
+
+// Convenience function to apply the operation
+func SetAssignee(b Interface, author Person, unixTime int64, assignee *Person) (*SetAssigneeOperation, error) {
+	op := NewSetAssigneeOp(author, unixTime, assignee)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
@@ -0,0 +1,28 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAssignee(t *testing.T) {
+	snapshot := Snapshot{}
+
+	var rene = Person{
+		Name:  "René Descartes",
+		Email: "rene@descartes.fr",
+	}
+
+	unix := time.Now().Unix()
+
+	NewSetAssigneeOp(rene, unix, &rene).Apply(&snapshot)
+	assert.Equal(t, &rene, snapshot.Assignee)
+
+	NewSetAssigneeOp(rene, unix, nil).Apply(&snapshot)
+	assert.Nil(t, snapshot.Assignee)
+
+	assert.NoError(t, NewSetAssigneeOp(rene, unix, nil).Validate())
+	assert.Error(t, NewSetAssigneeOp(rene, unix, &Person{}).Validate())
+}
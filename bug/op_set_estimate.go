@@ -0,0 +1,65 @@
+package bug
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+var _ Operation = &SetEstimateOperation{}
+
+// SetEstimateOperation will change the estimate of the effort needed to
+// resolve a bug. The unit (story points, hours ...) is left to the users.
+type SetEstimateOperation struct {
+	OpBase
+	Estimate float64 `json:"estimate"`
+}
+
+func (op *SetEstimateOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *SetEstimateOperation) Hash() (git.Hash, error) {
+	return hashOperation(op)
+}
+
+func (op *SetEstimateOperation) Apply(snapshot *Snapshot) {
+	snapshot.Estimate = op.Estimate
+}
+
+func (op *SetEstimateOperation) Validate() error {
+	if err := opBaseValidate(op, SetEstimateOp); err != nil {
+		return err
+	}
+
+	if math.IsNaN(op.Estimate) || math.IsInf(op.Estimate, 0) {
+		return fmt.Errorf("estimate is not a number")
+	}
+
+	if op.Estimate < 0 {
+		return fmt.Errorf("estimate is negative")
+	}
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *SetEstimateOperation) IsAuthored() {}
+
+func NewSetEstimateOp(author Person, unixTime int64, estimate float64) *SetEstimateOperation {
+	return &SetEstimateOperation{
+		OpBase:   newOpBase(SetEstimateOp, author, unixTime),
+		Estimate: estimate,
+	}
+}
+
+// Convenience function to apply the operation
+func SetEstimate(b Interface, author Person, unixTime int64, estimate float64) (*SetEstimateOperation, error) {
+	op := NewSetEstimateOp(author, unixTime, estimate)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
@@ -52,15 +52,15 @@ func (op *SetTitleOperation) Validate() error {
 	}
 
 	if text.Empty(op.Title) {
-		return fmt.Errorf("title is empty")
+		return ErrInvalidField{Field: "title", Message: "title is empty"}
 	}
 
 	if strings.Contains(op.Title, "\n") {
-		return fmt.Errorf("title should be a single line")
+		return ErrInvalidField{Field: "title", Message: "title should be a single line"}
 	}
 
 	if !text.Safe(op.Title) {
-		return fmt.Errorf("title should be fully printable")
+		return ErrInvalidField{Field: "title", Message: "title should be fully printable"}
 	}
 
 	if strings.Contains(op.Was, "\n") {
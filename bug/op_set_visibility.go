@@ -0,0 +1,53 @@
+package bug
+
+import (
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+var _ Operation = &SetVisibilityOperation{}
+
+// SetVisibilityOperation will change the audience allowed to see a bug
+type SetVisibilityOperation struct {
+	OpBase
+	Visibility Visibility `json:"visibility"`
+}
+
+func (op *SetVisibilityOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *SetVisibilityOperation) Hash() (git.Hash, error) {
+	return hashOperation(op)
+}
+
+func (op *SetVisibilityOperation) Apply(snapshot *Snapshot) {
+	snapshot.Visibility = op.Visibility
+}
+
+func (op *SetVisibilityOperation) Validate() error {
+	if err := opBaseValidate(op, SetVisibilityOp); err != nil {
+		return err
+	}
+
+	return op.Visibility.Validate()
+}
+
+// Sign post method for gqlgen
+func (op *SetVisibilityOperation) IsAuthored() {}
+
+func NewSetVisibilityOp(author Person, unixTime int64, visibility Visibility) *SetVisibilityOperation {
+	return &SetVisibilityOperation{
+		OpBase:     newOpBase(SetVisibilityOp, author, unixTime),
+		Visibility: visibility,
+	}
+}
+
+// Convenience function to apply the operation
+func SetVisibility(b Interface, author Person, unixTime int64, visibility Visibility) (*SetVisibilityOperation, error) {
+	op := NewSetVisibilityOp(author, unixTime, visibility)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
@@ -23,6 +23,12 @@ const (
 	EditCommentOp
 	NoOpOp
 	SetMetadataOp
+	AddVoteOp
+	RemoveVoteOp
+	SetEstimateOp
+	SetVisibilityOp
+	AddFixedInOp
+	SetAssigneeOp
 )
 
 // Operation define the interface to fulfill for an edit operation of a Bug
@@ -31,10 +37,17 @@ type Operation interface {
 	base() *OpBase
 	// Hash return the hash of the operation, to be used for back references
 	Hash() (git.Hash, error)
+	// GetType return the type of the operation
+	GetType() OperationType
 	// Time return the time when the operation was added
 	Time() time.Time
+	// GetAuthor return the author of the operation
+	GetAuthor() Person
 	// GetUnixTime return the unix timestamp when the operation was added
 	GetUnixTime() int64
+	// OriginalTime return the time when the operation was added, in the
+	// timezone of its author
+	OriginalTime() time.Time
 	// GetFiles return the files needed by this operation
 	GetFiles() []git.Hash
 	// Apply the operation to a Snapshot to create the final state
@@ -49,6 +62,18 @@ type Operation interface {
 	AllMetadata() map[string]string
 }
 
+// ErrInvalidField is returned when a field of an operation has an invalid
+// value, typically because of a user input
+type ErrInvalidField struct {
+	// Field is the name of the invalid field, like "title"
+	Field   string
+	Message string
+}
+
+func (e ErrInvalidField) Error() string {
+	return e.Message
+}
+
 func hashRaw(data []byte) git.Hash {
 	hasher := sha256.New()
 	// Write can't fail
@@ -76,10 +101,12 @@ func hashOperation(op Operation) (git.Hash, error) {
 
 // OpBase implement the common code for all operations
 type OpBase struct {
-	OperationType OperationType     `json:"type"`
-	Author        Person            `json:"author"`
-	UnixTime      int64             `json:"timestamp"`
-	Metadata      map[string]string `json:"metadata,omitempty"`
+	OperationType OperationType `json:"type"`
+	Author        Person        `json:"author"`
+	UnixTime      int64         `json:"timestamp"`
+	// The UTC offset of the author, in seconds, when the operation was added
+	TzOffset int               `json:"tz_offset,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
 	// Not serialized. Store the op's hash in memory.
 	hash git.Hash
 	// Not serialized. Store the extra metadata compiled from SetMetadataOperation
@@ -89,16 +116,43 @@ type OpBase struct {
 
 // newOpBase is the constructor for an OpBase
 func newOpBase(opType OperationType, author Person, unixTime int64) OpBase {
+	_, offset := time.Unix(unixTime, 0).Zone()
+
 	return OpBase{
 		OperationType: opType,
 		Author:        author,
 		UnixTime:      unixTime,
+		TzOffset:      offset,
 	}
 }
 
-// Time return the time when the operation was added
+// SetTzOffset record the UTC offset of the timezone of a time as the one of
+// the author, for the operations imported from elsewhere
+func (op *OpBase) SetTzOffset(t time.Time) {
+	_, op.TzOffset = t.Zone()
+	op.hash = ""
+}
+
+// Time return the time when the operation was added, in the display timezone
 func (op *OpBase) Time() time.Time {
-	return time.Unix(op.UnixTime, 0)
+	return Timestamp(op.UnixTime).Time()
+}
+
+// OriginalTime return the time when the operation was added, in the timezone
+// of its author. The operations created before the offset was recorded are
+// in UTC.
+func (op *OpBase) OriginalTime() time.Time {
+	return time.Unix(op.UnixTime, 0).In(time.FixedZone("", op.TzOffset))
+}
+
+// GetType return the type of the operation
+func (op *OpBase) GetType() OperationType {
+	return op.OperationType
+}
+
+// GetAuthor return the author of the operation
+func (op *OpBase) GetAuthor() Person {
+	return op.Author
 }
 
 // GetUnixTime return the unix timestamp when the operation was added
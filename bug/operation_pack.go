@@ -49,15 +49,7 @@ func (opp *OperationPack) UnmarshalJSON(data []byte) error {
 	}
 
 	for _, raw := range aux.Operations {
-		var t struct {
-			OperationType OperationType `json:"type"`
-		}
-
-		if err := json.Unmarshal(raw, &t); err != nil {
-			return err
-		}
-
-		op, err := opp.unmarshalOp(raw, t.OperationType)
+		op, err := UnmarshalOperation(raw)
 		if err != nil {
 			return err
 		}
@@ -71,7 +63,21 @@ func (opp *OperationPack) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func (opp *OperationPack) unmarshalOp(raw []byte, _type OperationType) (Operation, error) {
+// UnmarshalOperation decode a single operation from its JSON form, as found
+// in an OperationPack
+func UnmarshalOperation(raw []byte) (Operation, error) {
+	var t struct {
+		OperationType OperationType `json:"type"`
+	}
+
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return nil, err
+	}
+
+	return unmarshalOp(raw, t.OperationType)
+}
+
+func unmarshalOp(raw []byte, _type OperationType) (Operation, error) {
 	switch _type {
 	case CreateOp:
 		op := &CreateOperation{}
@@ -97,6 +103,34 @@ func (opp *OperationPack) unmarshalOp(raw []byte, _type OperationType) (Operatio
 		op := &EditCommentOperation{}
 		err := json.Unmarshal(raw, &op)
 		return op, err
+	case SetMetadataOp:
+		op := &SetMetadataOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case AddVoteOp:
+		op := &AddVoteOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case RemoveVoteOp:
+		op := &RemoveVoteOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case SetEstimateOp:
+		op := &SetEstimateOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case SetVisibilityOp:
+		op := &SetVisibilityOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case AddFixedInOp:
+		op := &AddFixedInOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case SetAssigneeOp:
+		op := &SetAssigneeOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
 	default:
 		return nil, fmt.Errorf("unknown operation type %v", _type)
 	}
@@ -128,7 +162,7 @@ func (opp *OperationPack) Validate() error {
 }
 
 // Write will serialize and store the OperationPack as a git blob and return
-// its hash
+// its hash. The blob is encrypted if the repo has an encryption key.
 func (opp *OperationPack) Write(repo repository.Repo) (git.Hash, error) {
 	data, err := json.Marshal(opp)
 
@@ -136,6 +170,18 @@ func (opp *OperationPack) Write(repo repository.Repo) (git.Hash, error) {
 		return "", err
 	}
 
+	key, err := ReadEncryptionKey(repo)
+	if err != nil {
+		return "", err
+	}
+
+	if key != nil {
+		data, err = encryptPack(key, data)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	hash, err := repo.StoreData(data)
 
 	if err != nil {
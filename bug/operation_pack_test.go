@@ -16,6 +16,13 @@ func TestOperationPackSerialize(t *testing.T) {
 	opp.Append(addCommentOp)
 	opp.Append(setStatusOp)
 	opp.Append(labelChangeOp)
+	opp.Append(NewAddVoteOp(rene, unix))
+	opp.Append(NewRemoveVoteOp(rene, unix))
+	opp.Append(NewSetEstimateOp(rene, unix, 2.5))
+	opp.Append(NewSetVisibilityOp(rene, unix, VisibilityInternal))
+	opp.Append(NewAddFixedInOp(rene, unix, "v1.2.0", "abcdef0..1234567"))
+	opp.Append(NewSetAssigneeOp(rene, unix, &rene))
+	opp.Append(NewSetAssigneeOp(rene, unix, nil))
 
 	opMeta := NewCreateOp(rene, unix, "title", "message", nil)
 	opMeta.SetMetadata("key", "value")
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/avatar"
 	"github.com/MichaelMure/git-bug/util/text"
 )
 
@@ -16,8 +17,20 @@ type Person struct {
 	AvatarUrl string `json:"avatar_url"`
 }
 
-// GetUser will query the repository for user detail and build the corresponding Person
+// adoptedUserConfigSection hold the identity adopted with SetUser
+const adoptedUserConfigSection = "git-bug.user"
+
+// GetUser will query the repository for user detail and build the corresponding Person.
+// An identity adopted with SetUser take precedence over the git configuration.
 func GetUser(repo repository.Repo) (Person, error) {
+	adopted, err := AdoptedUser(repo)
+	if err != nil {
+		return Person{}, err
+	}
+	if adopted != nil {
+		return *adopted, nil
+	}
+
 	name, err := repo.GetUserName()
 	if err != nil {
 		return Person{}, err
@@ -37,6 +50,68 @@ func GetUser(repo repository.Repo) (Person, error) {
 	return Person{Name: name, Email: email}, nil
 }
 
+// AdoptedUser return the identity adopted with SetUser, or nil if the user
+// is still the one configured in git
+func AdoptedUser(repo repository.RepoCommon) (*Person, error) {
+	configs, err := repo.ReadConfigs(adoptedUserConfigSection + ".")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return &Person{
+		Name:      configs[adoptedUserConfigSection+".name"],
+		Email:     configs[adoptedUserConfigSection+".email"],
+		Login:     configs[adoptedUserConfigSection+".login"],
+		AvatarUrl: configs[adoptedUserConfigSection+".avatar-url"],
+	}, nil
+}
+
+// SetUser make an existing identity, like one imported by a bridge, the
+// author of the next operations done in this repository. As the identity is
+// stored in the git-bug configuration, the git commits keep the user.name and
+// user.email of git.
+func SetUser(repo repository.RepoCommon, p Person) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	if err := ResetUser(repo); err != nil {
+		return err
+	}
+
+	for key, value := range map[string]string{
+		"name":       p.Name,
+		"email":      p.Email,
+		"login":      p.Login,
+		"avatar-url": p.AvatarUrl,
+	} {
+		if value == "" {
+			continue
+		}
+		err := repo.StoreConfig(adoptedUserConfigSection+"."+key, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ResetUser forget the identity adopted with SetUser, to go back to the one
+// configured in git
+func ResetUser(repo repository.RepoCommon) error {
+	adopted, err := AdoptedUser(repo)
+	if err != nil || adopted == nil {
+		return err
+	}
+
+	return repo.RmConfigs(adoptedUserConfigSection)
+}
+
 // Match tell is the Person match the given query string
 func (p Person) Match(query string) bool {
 	query = strings.ToLower(query)
@@ -93,3 +168,32 @@ func (p Person) DisplayName() string {
 
 	panic("invalid person data")
 }
+
+// Avatar return the url of the avatar of the person, or a generated image
+// showing its initials if it has none
+func (p Person) Avatar() string {
+	if p.AvatarUrl != "" {
+		return p.AvatarUrl
+	}
+	return avatar.DataURL(p.initials(), p.avatarKey())
+}
+
+// TerminalAvatar return a colored block showing the initials of the person
+func (p Person) TerminalAvatar() string {
+	return avatar.Terminal(p.initials(), p.avatarKey())
+}
+
+func (p Person) initials() string {
+	if p.Name != "" {
+		return avatar.Initials(p.Name)
+	}
+	return avatar.Initials(p.Login)
+}
+
+// avatarKey identify the person to pick the colors of its avatar
+func (p Person) avatarKey() string {
+	if p.Email != "" {
+		return strings.ToLower(p.Email)
+	}
+	return p.Name + "/" + p.Login
+}
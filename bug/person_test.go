@@ -0,0 +1,44 @@
+package bug
+
+import (
+	"testing"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetUser(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	gitUser, err := GetUser(repo)
+	require.NoError(t, err)
+
+	imported := Person{
+		Name:      "René Descartes",
+		Login:     "rene",
+		AvatarUrl: "https://example.com/rene.png",
+	}
+
+	require.NoError(t, SetUser(repo, imported))
+
+	user, err := GetUser(repo)
+	require.NoError(t, err)
+	assert.Equal(t, imported, user)
+
+	// adopting another identity replace the previous one entirely
+	other := Person{Name: "Other", Email: "other@example.com"}
+	require.NoError(t, SetUser(repo, other))
+
+	user, err = GetUser(repo)
+	require.NoError(t, err)
+	assert.Equal(t, other, user)
+
+	require.NoError(t, ResetUser(repo))
+
+	user, err = GetUser(repo)
+	require.NoError(t, err)
+	assert.Equal(t, gitUser, user)
+
+	assert.Error(t, SetUser(repo, Person{}))
+}
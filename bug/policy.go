@@ -0,0 +1,593 @@
+package bug
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/pkg/errors"
+)
+
+// policyRef is where the access policy of the bugs is stored. It is pushed
+// and pulled along the bugs so that every clone enforce the same policy.
+const policyRefPattern = "refs/policy/"
+const policyRemoteRefPattern = "refs/remotes/%s/policy/"
+const policyRef = policyRefPattern + "bugs"
+const policyEntryName = "policy"
+
+// policyRemoteRef return the ref holding the last known policy of a remote
+func policyRemoteRef(remote string) string {
+	return fmt.Sprintf(policyRemoteRefPattern, remote) + "bugs"
+}
+
+// policyRequireSignatureConfigKey is the git config key telling if the
+// policy must be signed with a key trusted by the local GPG keyring. It is
+// required unless explicitly set to "false".
+const policyRequireSignatureConfigKey = "git-bug.policy-require-signature"
+
+// The actions that can be restricted by a policy. On top of one action per
+// type of operation, closing and reopening a bug can be restricted
+// independently.
+const (
+	ActionCreate        = "create"
+	ActionSetTitle      = "set-title"
+	ActionAddComment    = "add-comment"
+	ActionSetStatus     = "set-status"
+	ActionClose         = "close"
+	ActionReopen        = "reopen"
+	ActionLabelChange   = "label-change"
+	ActionEditComment   = "edit-comment"
+	ActionSetMetadata   = "set-metadata"
+	ActionAddVote       = "add-vote"
+	ActionRemoveVote    = "remove-vote"
+	ActionSetEstimate   = "set-estimate"
+	ActionSetVisibility = "set-visibility"
+	ActionAddFixedIn    = "add-fixed-in"
+	ActionSetAssignee   = "set-assignee"
+)
+
+// PolicyActions is the list of all the actions that can be restricted
+var PolicyActions = []string{
+	ActionCreate,
+	ActionSetTitle,
+	ActionAddComment,
+	ActionSetStatus,
+	ActionClose,
+	ActionReopen,
+	ActionLabelChange,
+	ActionEditComment,
+	ActionSetMetadata,
+	ActionAddVote,
+	ActionRemoveVote,
+	ActionSetEstimate,
+	ActionSetVisibility,
+	ActionAddFixedIn,
+	ActionSetAssignee,
+}
+
+// Policy define who is allowed to perform which action on which bug.
+// Everything not explicitly restricted is allowed to everyone.
+//
+// A policy is written as a list of lines:
+//
+//	# a comment
+//	team <name> <email or login>,...
+//	restrict <action>,... <team, email or login>,... [label:<label>]...
+//
+// A restriction with labels only apply to the bugs having at least one of
+// them. When several restrictions apply to an action, being allowed by one of
+// them is enough.
+//
+// Note: as the authors are not authenticated, the policy protects against
+// mistakes and casual vandalism, not against a determined attacker.
+type Policy struct {
+	Teams map[string][]string
+	Rules []PolicyRule
+}
+
+// PolicyRule restrict some actions to some identities or teams
+type PolicyRule struct {
+	Actions []string
+	Who     []string
+	Labels  []string
+}
+
+// NewPolicy create an empty policy, allowing everything
+func NewPolicy() *Policy {
+	return &Policy{
+		Teams: make(map[string][]string),
+	}
+}
+
+// ParsePolicy parse the textual form of a policy
+func ParsePolicy(data []byte) (*Policy, error) {
+	policy := NewPolicy()
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+
+		switch fields[0] {
+		case "team":
+			if len(fields) != 3 {
+				return nil, fmt.Errorf("invalid team line: %s", line)
+			}
+			policy.Teams[fields[1]] = splitList(fields[2])
+
+		case "restrict":
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("invalid restrict line: %s", line)
+			}
+
+			rule := PolicyRule{
+				Actions: splitList(fields[1]),
+				Who:     splitList(fields[2]),
+			}
+
+			for _, action := range rule.Actions {
+				if !isPolicyAction(action) {
+					return nil, fmt.Errorf("unknown action \"%s\", expected one of: %s",
+						action, strings.Join(PolicyActions, ", "))
+				}
+			}
+
+			for _, condition := range fields[3:] {
+				if !strings.HasPrefix(condition, "label:") {
+					return nil, fmt.Errorf("invalid condition \"%s\"", condition)
+				}
+				rule.Labels = append(rule.Labels, strings.TrimPrefix(condition, "label:"))
+			}
+
+			policy.Rules = append(policy.Rules, rule)
+
+		default:
+			return nil, fmt.Errorf("invalid policy line: %s", line)
+		}
+	}
+
+	return policy, scanner.Err()
+}
+
+func splitList(s string) []string {
+	var result []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func isPolicyAction(action string) bool {
+	for _, a := range PolicyActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// String return the textual form of the policy, as parsed by ParsePolicy
+func (p *Policy) String() string {
+	var buffer bytes.Buffer
+
+	teams := make([]string, 0, len(p.Teams))
+	for team := range p.Teams {
+		teams = append(teams, team)
+	}
+	sort.Strings(teams)
+
+	for _, team := range teams {
+		_, _ = fmt.Fprintf(&buffer, "team %s %s\n", team, strings.Join(p.Teams[team], ","))
+	}
+
+	for _, rule := range p.Rules {
+		_, _ = fmt.Fprintf(&buffer, "restrict %s %s",
+			strings.Join(rule.Actions, ","), strings.Join(rule.Who, ","))
+		for _, label := range rule.Labels {
+			_, _ = fmt.Fprintf(&buffer, " label:%s", label)
+		}
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
+
+// IsEmpty tell if the policy doesn't restrict anything
+func (p *Policy) IsEmpty() bool {
+	return len(p.Rules) == 0
+}
+
+// Allowed tell if a Person is allowed to perform an action on a bug
+func (p *Policy) Allowed(person Person, action string, snap *Snapshot) bool {
+	restricted := false
+
+	for _, rule := range p.Rules {
+		if !rule.applies(action, snap) {
+			continue
+		}
+
+		restricted = true
+
+		if p.matchAny(person, rule.Who) {
+			return true
+		}
+	}
+
+	return !restricted
+}
+
+// AllowedActions return the actions a Person is allowed to perform on a bug
+func (p *Policy) AllowedActions(person Person, snap *Snapshot) []string {
+	var result []string
+	for _, action := range PolicyActions {
+		if p.Allowed(person, action, snap) {
+			result = append(result, action)
+		}
+	}
+	return result
+}
+
+// CheckOperation return an error if the author of an operation is not
+// allowed to apply it on the given state of a bug
+func (p *Policy) CheckOperation(op Operation, snap *Snapshot) error {
+	author := op.base().Author
+
+	for _, action := range OperationActions(op) {
+		if !p.Allowed(author, action, snap) {
+			return fmt.Errorf("%s is not allowed to %s", author.DisplayName(), action)
+		}
+	}
+
+	return nil
+}
+
+// OperationActions return the actions performed by an operation
+func OperationActions(op Operation) []string {
+	switch op := op.(type) {
+	case *CreateOperation:
+		return []string{ActionCreate}
+	case *SetTitleOperation:
+		return []string{ActionSetTitle}
+	case *AddCommentOperation:
+		return []string{ActionAddComment}
+	case *SetStatusOperation:
+		if op.Status == ClosedStatus {
+			return []string{ActionSetStatus, ActionClose}
+		}
+		return []string{ActionSetStatus, ActionReopen}
+	case *LabelChangeOperation:
+		return []string{ActionLabelChange}
+	case *EditCommentOperation:
+		return []string{ActionEditComment}
+	case *SetMetadataOperation:
+		return []string{ActionSetMetadata}
+	case *AddVoteOperation:
+		return []string{ActionAddVote}
+	case *RemoveVoteOperation:
+		return []string{ActionRemoveVote}
+	case *SetEstimateOperation:
+		return []string{ActionSetEstimate}
+	case *SetVisibilityOperation:
+		return []string{ActionSetVisibility}
+	case *AddFixedInOperation:
+		return []string{ActionAddFixedIn}
+	case *SetAssigneeOperation:
+		return []string{ActionSetAssignee}
+	}
+
+	return nil
+}
+
+func (r PolicyRule) applies(action string, snap *Snapshot) bool {
+	found := false
+	for _, a := range r.Actions {
+		if a == action {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+
+	if len(r.Labels) == 0 {
+		return true
+	}
+
+	if snap == nil {
+		return false
+	}
+
+	for _, label := range r.Labels {
+		for _, l := range snap.Labels {
+			if l.Equivalent(Label(label)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (p *Policy) matchAny(person Person, who []string) bool {
+	for _, w := range who {
+		if members, ok := p.Teams[w]; ok {
+			if matchPerson(person, members) {
+				return true
+			}
+			continue
+		}
+
+		if matchPerson(person, []string{w}) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPerson(person Person, identities []string) bool {
+	for _, identity := range identities {
+		if person.Email != "" && strings.EqualFold(person.Email, identity) {
+			return true
+		}
+		if person.Login != "" && strings.EqualFold(person.Login, identity) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadPolicy read the policy stored in the repository. If none exist, an
+// empty policy is returned. The local policy is trusted as is: it has been
+// written locally, or verified when adopted from a remote.
+func ReadPolicy(repo repository.Repo) (*Policy, error) {
+	exist, err := repo.RefExist(policyRef)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return NewPolicy(), nil
+	}
+
+	hashes, err := repo.ListCommits(policyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return readPolicyCommit(repo, hashes[len(hashes)-1])
+}
+
+// readPolicy read the last version of a policy that can't be trusted yet,
+// verifying its signature unless the configuration says otherwise
+func readPolicy(repo repository.Repo, ref string) (*Policy, error) {
+	hashes, err := repo.ListCommits(ref)
+	if err != nil {
+		return nil, err
+	}
+	head := hashes[len(hashes)-1]
+
+	err = verifyPolicySignature(repo, head)
+	if err != nil {
+		return nil, err
+	}
+
+	return readPolicyCommit(repo, head)
+}
+
+func readPolicyCommit(repo repository.Repo, head git.Hash) (*Policy, error) {
+	entries, err := repo.ListEntries(head)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't list git tree entries")
+	}
+
+	for _, entry := range entries {
+		if entry.Name != policyEntryName {
+			continue
+		}
+
+		data, err := repo.ReadData(entry.Hash)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read git blob data")
+		}
+
+		return ParsePolicy(data)
+	}
+
+	return NewPolicy(), nil
+}
+
+// policyRequireSignature tell if the configuration requires the policy to
+// be signed
+func policyRequireSignature(repo repository.RepoCommon) (bool, error) {
+	configs, err := repo.ReadConfigs(policyRequireSignatureConfigKey)
+	if err != nil {
+		return false, err
+	}
+
+	return configs[policyRequireSignatureConfigKey] != "false", nil
+}
+
+func verifyPolicySignature(repo repository.Repo, commit git.Hash) error {
+	required, err := policyRequireSignature(repo)
+	if err != nil {
+		return err
+	}
+	if !required {
+		return nil
+	}
+
+	signing, ok := repo.(repository.SigningRepo)
+	if !ok {
+		return fmt.Errorf("the repository can't verify the signature of the policy")
+	}
+
+	err = signing.VerifyCommit(commit)
+	if err != nil {
+		return errors.Wrap(err, "the signature of the policy is not valid")
+	}
+
+	return nil
+}
+
+// WritePolicy store a new version of the policy in the repository,
+// optionally signed with the key of the user
+func WritePolicy(repo repository.Repo, policy *Policy, sign bool) error {
+	blobHash, err := repo.StoreData([]byte(policy.String()))
+	if err != nil {
+		return err
+	}
+
+	treeHash, err := repo.StoreTree([]repository.TreeEntry{
+		{ObjectType: repository.Blob, Hash: blobHash, Name: policyEntryName},
+	})
+	if err != nil {
+		return err
+	}
+
+	exist, err := repo.RefExist(policyRef)
+	if err != nil {
+		return err
+	}
+
+	var parent git.Hash
+	if exist {
+		hashes, err := repo.ListCommits(policyRef)
+		if err != nil {
+			return err
+		}
+		parent = hashes[len(hashes)-1]
+	}
+
+	var commitHash git.Hash
+
+	switch {
+	case sign:
+		signing, ok := repo.(repository.SigningRepo)
+		if !ok {
+			return fmt.Errorf("the repository can't sign the policy")
+		}
+		commitHash, err = signing.StoreSignedCommit(treeHash, parent)
+	case parent != "":
+		commitHash, err = repo.StoreCommitWithParent(treeHash, parent)
+	default:
+		commitHash, err = repo.StoreCommit(treeHash)
+	}
+	if err != nil {
+		return err
+	}
+
+	return repo.UpdateRef(policyRef, commitHash)
+}
+
+// mergePolicy adopt the policy of a remote if it's a descendant of the local
+// one. A remote policy that diverged is ignored, the local one is kept. The
+// remote policy must be valid and, unless the configuration says otherwise,
+// signed, to not let anyone able to push rewrite it.
+func mergePolicy(repo repository.Repo, remote string) error {
+	remoteRef := policyRemoteRef(remote)
+
+	remoteExist, err := repo.RefExist(remoteRef)
+	if err != nil {
+		return err
+	}
+	if !remoteExist {
+		return nil
+	}
+
+	remoteHashes, err := repo.ListCommits(remoteRef)
+	if err != nil {
+		return err
+	}
+	remoteHead := remoteHashes[len(remoteHashes)-1]
+
+	localExist, err := repo.RefExist(policyRef)
+	if err != nil {
+		return err
+	}
+
+	if localExist {
+		localHashes, err := repo.ListCommits(policyRef)
+		if err != nil {
+			return err
+		}
+		localHead := localHashes[len(localHashes)-1]
+
+		if localHead == remoteHead {
+			return nil
+		}
+
+		ancestor, err := repo.FindCommonAncestor(localHead, remoteHead)
+		if err != nil {
+			return err
+		}
+
+		// the local policy is ahead or diverged
+		if ancestor != localHead {
+			return nil
+		}
+	}
+
+	_, err = readPolicy(repo, remoteRef)
+	if err != nil {
+		return errors.Wrap(err, "remote policy rejected")
+	}
+
+	return repo.CopyRef(remoteRef, policyRef)
+}
+
+// policyViolation return a description of the first operation of a remote
+// bug, not already merged locally, that the policy doesn't allow
+func policyViolation(repo repository.ClockedRepo, remoteBug *Bug, policy *Policy) (string, error) {
+	if policy.IsEmpty() {
+		return "", nil
+	}
+
+	localRef := bugsRefPattern + remoteBug.Id()
+	localExist, err := repo.RefExist(localRef)
+	if err != nil {
+		return "", err
+	}
+
+	localCommits := make(map[git.Hash]bool)
+
+	if localExist {
+		hashes, err := repo.ListCommits(localRef)
+		if err != nil {
+			return "", err
+		}
+		for _, hash := range hashes {
+			localCommits[hash] = true
+		}
+	}
+
+	snap := &Snapshot{
+		id:     remoteBug.id,
+		Status: OpenStatus,
+	}
+
+	for _, pack := range remoteBug.packs {
+		for _, op := range pack.Operations {
+			if !localCommits[pack.commitHash] {
+				if err := policy.CheckOperation(op, snap); err != nil {
+					return err.Error(), nil
+				}
+			}
+
+			op.Apply(snap)
+			snap.Operations = append(snap.Operations, op)
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,80 @@
+package bug
+
+import (
+	"testing"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPolicy = `# only the maintainers can close
+team maintainers rene@descartes.fr,jane
+restrict close maintainers
+restrict label-change,set-title bob label:security
+`
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"rene@descartes.fr", "jane"}, policy.Teams["maintainers"])
+	assert.Len(t, policy.Rules, 2)
+	assert.Equal(t, []string{"security"}, policy.Rules[1].Labels)
+
+	// the textual form can be parsed back
+	parsed, err := ParsePolicy([]byte(policy.String()))
+	assert.Nil(t, err)
+	assert.Equal(t, policy, parsed)
+
+	_, err = ParsePolicy([]byte("restrict fly everyone"))
+	assert.NotNil(t, err)
+
+	_, err = ParsePolicy([]byte("allow close everyone"))
+	assert.NotNil(t, err)
+}
+
+func TestPolicyAllowed(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	assert.Nil(t, err)
+
+	jane := Person{Name: "Jane", Login: "jane"}
+	bob := Person{Name: "Bob", Login: "bob"}
+
+	snap := &Snapshot{}
+
+	assert.True(t, policy.Allowed(rene, ActionClose, snap))
+	assert.True(t, policy.Allowed(jane, ActionClose, snap))
+	assert.False(t, policy.Allowed(bob, ActionClose, snap))
+	assert.True(t, policy.Allowed(bob, ActionReopen, snap))
+
+	// the label restriction only apply to the bugs with the label
+	assert.True(t, policy.Allowed(rene, ActionSetTitle, snap))
+	snap.Labels = []Label{"Security"}
+	assert.False(t, policy.Allowed(rene, ActionSetTitle, snap))
+	assert.True(t, policy.Allowed(bob, ActionSetTitle, snap))
+
+	assert.NotContains(t, policy.AllowedActions(bob, snap), ActionClose)
+	assert.Nil(t, policy.CheckOperation(setStatusOp, snap))
+}
+
+func TestPolicyReadWrite(t *testing.T) {
+	mockRepo := repository.NewMockRepoForTest()
+
+	policy, err := ReadPolicy(mockRepo)
+	assert.Nil(t, err)
+	assert.True(t, policy.IsEmpty())
+
+	policy, err = ParsePolicy([]byte(testPolicy))
+	assert.Nil(t, err)
+
+	err = WritePolicy(mockRepo, policy, false)
+	assert.Nil(t, err)
+
+	read, err := ReadPolicy(mockRepo)
+	assert.Nil(t, err)
+	assert.Equal(t, policy, read)
+
+	// signing is not supported by the mock repo
+	err = WritePolicy(mockRepo, policy, true)
+	assert.NotNil(t, err)
+}
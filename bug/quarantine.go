@@ -0,0 +1,107 @@
+package bug
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// bugsQuarantineRefPattern is where the remote bugs with operations from
+// untrusted authors are stored, waiting for a review
+const bugsQuarantineRefPattern = "refs/quarantine/bugs/"
+
+// QuarantinedBug is a remote bug waiting for a review before being merged
+type QuarantinedBug struct {
+	Bug *Bug
+
+	// Untrusted are the authors of the operations needing a review
+	Untrusted []Person
+}
+
+// ListQuarantinedIds list the ids of the bugs in quarantine
+func ListQuarantinedIds(repo repository.Repo) ([]string, error) {
+	refs, err := repo.ListRefs(bugsQuarantineRefPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return refsToIds(refs), nil
+}
+
+// ReadQuarantined read a bug in quarantine
+func ReadQuarantined(repo repository.ClockedRepo, id string) (*QuarantinedBug, error) {
+	b, err := readBug(repo, bugsQuarantineRefPattern+id)
+	if err != nil {
+		return nil, err
+	}
+
+	trusted, err := ReadTrustedAuthors(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	untrusted, err := untrustedAuthors(repo, b, trusted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuarantinedBug{
+		Bug:       b,
+		Untrusted: untrusted,
+	}, nil
+}
+
+// AcceptQuarantined merge a bug in quarantine into the local bugs and
+// release it from the quarantine
+func AcceptQuarantined(repo repository.ClockedRepo, id string) (MergeResult, error) {
+	ref := bugsQuarantineRefPattern + id
+
+	b, err := readBug(repo, ref)
+	if err != nil {
+		return MergeResult{}, err
+	}
+
+	if err := b.Validate(); err != nil {
+		return MergeResult{}, fmt.Errorf("quarantined bug is invalid: %v", err)
+	}
+
+	policy, err := ReadPolicy(repo)
+	if err != nil {
+		return MergeResult{}, err
+	}
+
+	violation, err := policyViolation(repo, b, policy)
+	if err != nil {
+		return MergeResult{}, err
+	}
+	if violation != "" {
+		return MergeResult{}, fmt.Errorf("quarantined bug violates the policy: %s", violation)
+	}
+
+	result := mergeBug(repo, ref, b)
+	if result.Err != nil {
+		return result, result.Err
+	}
+
+	if result.Status == MergeStatusInvalid {
+		return result, fmt.Errorf("can't merge the quarantined bug: %s", result.Reason)
+	}
+
+	return result, repo.RemoveRef(ref)
+}
+
+// RejectQuarantined drop a bug in quarantine. Note that the bug will be put
+// in quarantine again on the next pull if the remote still has it.
+func RejectQuarantined(repo repository.Repo, id string) error {
+	ref := bugsQuarantineRefPattern + id
+
+	exist, err := repo.RefExist(ref)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return ErrBugNotExist
+	}
+
+	return repo.RemoveRef(ref)
+}
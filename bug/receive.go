@@ -0,0 +1,211 @@
+package bug
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/pkg/errors"
+)
+
+// ZeroHash is the hash given by git for the old value of a created ref, or
+// the new value of a deleted one
+const ZeroHash = git.Hash("0000000000000000000000000000000000000000")
+
+const receiveMaxPackSizeConfigKey = "git-bug.receive.max-pack-size"
+const receiveMaxOperationsConfigKey = "git-bug.receive.max-operations"
+
+// defaultMaxPackSize is the default maximum size of a pushed operation pack
+const defaultMaxPackSize = 1 << 20
+
+// ReceiveLimits are the limits enforced on the bugs pushed to a shared
+// repository
+type ReceiveLimits struct {
+	// MaxPackSize is the maximum size in bytes of the blob of an operation
+	// pack, 0 for no limit
+	MaxPackSize int
+
+	// MaxOperations is the maximum number of operations pushed at once on a
+	// bug, 0 for no limit
+	MaxOperations int
+}
+
+// ReadReceiveLimits read the limits from the configuration of the repo
+func ReadReceiveLimits(repo repository.RepoCommon) (ReceiveLimits, error) {
+	limits := ReceiveLimits{
+		MaxPackSize: defaultMaxPackSize,
+	}
+
+	configs, err := repo.ReadConfigs("git-bug.receive.")
+	if err != nil {
+		return limits, err
+	}
+
+	for key, target := range map[string]*int{
+		receiveMaxPackSizeConfigKey:   &limits.MaxPackSize,
+		receiveMaxOperationsConfigKey: &limits.MaxOperations,
+	} {
+		value, ok := configs[key]
+		if !ok {
+			continue
+		}
+
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return limits, fmt.Errorf("invalid %s: %s", key, value)
+		}
+		*target = n
+	}
+
+	return limits, nil
+}
+
+// ValidateReceivedRef check the update of a ref pushed to a shared
+// repository, before it's accepted. It's meant to be run from a pre-receive
+// hook, where the pushed objects are readable but the refs not updated yet.
+//
+// The bugs must be well formed, only fast-forwarded, within the limits, and
+// their new operations allowed by the current policy of the repository. An
+// updated policy must be valid and, if the configuration requires it, signed.
+// The refs not belonging to git-bug are left alone.
+func ValidateReceivedRef(repo repository.ClockedRepo, ref string, oldHash git.Hash, newHash git.Hash, limits ReceiveLimits) error {
+	isBug := strings.HasPrefix(ref, bugsRefPattern)
+	isPolicy := strings.HasPrefix(ref, policyRefPattern)
+
+	if !isBug && !isPolicy {
+		return nil
+	}
+
+	if newHash == ZeroHash {
+		return fmt.Errorf("deleting %s is not allowed", ref)
+	}
+
+	if oldHash != ZeroHash {
+		ancestor, err := repo.FindCommonAncestor(oldHash, newHash)
+		if err != nil {
+			return err
+		}
+		if ancestor != oldHash {
+			return fmt.Errorf("non fast-forward update")
+		}
+	}
+
+	if isPolicy {
+		return validateReceivedPolicy(repo, ref, newHash)
+	}
+
+	return validateReceivedBug(repo, ref, oldHash, newHash, limits)
+}
+
+func validateReceivedPolicy(repo repository.ClockedRepo, ref string, newHash git.Hash) error {
+	if ref != policyRef {
+		return fmt.Errorf("unknown policy ref")
+	}
+
+	_, err := readPolicy(repo, string(newHash))
+	return err
+}
+
+func validateReceivedBug(repo repository.ClockedRepo, ref string, oldHash git.Hash, newHash git.Hash, limits ReceiveLimits) error {
+	id := strings.TrimPrefix(ref, bugsRefPattern)
+	hash := git.Hash(id)
+	if len(id) != idLength || !hash.IsValid() {
+		return fmt.Errorf("invalid bug id")
+	}
+
+	hashes, err := repo.ListCommits(string(newHash))
+	if err != nil {
+		return err
+	}
+
+	if hashes[0] != hash {
+		return fmt.Errorf("the first commit doesn't match the id of the bug")
+	}
+
+	known := make(map[git.Hash]bool)
+	if oldHash != ZeroHash {
+		oldHashes, err := repo.ListCommits(string(oldHash))
+		if err != nil {
+			return err
+		}
+		for _, hash := range oldHashes {
+			known[hash] = true
+		}
+	}
+
+	if limits.MaxPackSize > 0 {
+		err := checkPackSizes(repo, hashes, known, limits.MaxPackSize)
+		if err != nil {
+			return err
+		}
+	}
+
+	b, err := readBugRevision(repo, id, string(newHash))
+	if err != nil {
+		return err
+	}
+
+	if err := b.Validate(); err != nil {
+		return errors.Wrap(err, "invalid bug")
+	}
+
+	if limits.MaxOperations > 0 {
+		count := 0
+		for _, pack := range b.packs {
+			if !known[pack.commitHash] {
+				count += len(pack.Operations)
+			}
+		}
+		if count > limits.MaxOperations {
+			return fmt.Errorf("%d operations pushed at once, the limit is %d", count, limits.MaxOperations)
+		}
+	}
+
+	policy, err := ReadPolicy(repo)
+	if err != nil {
+		return err
+	}
+
+	violation, err := policyViolation(repo, b, policy)
+	if err != nil {
+		return err
+	}
+	if violation != "" {
+		return fmt.Errorf("policy violation: %s", violation)
+	}
+
+	return nil
+}
+
+// checkPackSizes check the size of the operation packs of the new commits
+func checkPackSizes(repo repository.Repo, hashes []git.Hash, known map[git.Hash]bool, max int) error {
+	for _, hash := range hashes {
+		if known[hash] {
+			continue
+		}
+
+		entries, err := repo.ListEntries(hash)
+		if err != nil {
+			return errors.Wrap(err, "can't list git tree entries")
+		}
+
+		for _, entry := range entries {
+			if entry.Name != opsEntryName {
+				continue
+			}
+
+			data, err := repo.ReadData(entry.Hash)
+			if err != nil {
+				return errors.Wrap(err, "failed to read git blob data")
+			}
+
+			if len(data) > max {
+				return fmt.Errorf("operation pack of %d bytes, the limit is %d", len(data), max)
+			}
+		}
+	}
+
+	return nil
+}
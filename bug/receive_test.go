@@ -0,0 +1,77 @@
+package bug
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateReceivedRef(t *testing.T) {
+	repo := createRepo(false)
+	defer cleanupRepo(repo)
+
+	var rene = Person{
+		Name:  "René Descartes",
+		Email: "rene@descartes.fr",
+	}
+
+	unix := time.Now().Unix()
+
+	b, _, err := Create(rene, unix, "title", "message")
+	require.NoError(t, err)
+	require.NoError(t, b.Commit(repo))
+	first := b.lastCommit
+
+	_, err = AddComment(b, rene, unix, "comment")
+	require.NoError(t, err)
+	require.NoError(t, b.Commit(repo))
+	second := b.lastCommit
+
+	ref := bugsRefPattern + b.Id()
+	limits := ReceiveLimits{MaxPackSize: defaultMaxPackSize}
+
+	// refs not belonging to git-bug are ignored
+	assert.NoError(t, ValidateReceivedRef(repo, "refs/heads/master", ZeroHash, second, limits))
+
+	assert.NoError(t, ValidateReceivedRef(repo, ref, ZeroHash, second, limits))
+	assert.NoError(t, ValidateReceivedRef(repo, ref, first, second, limits))
+
+	assert.Error(t, ValidateReceivedRef(repo, ref, second, ZeroHash, limits))
+	assert.Error(t, ValidateReceivedRef(repo, bugsRefPattern+"invalid", ZeroHash, second, limits))
+	assert.Error(t, ValidateReceivedRef(repo, bugsRefPattern+strings.Repeat("a", idLength), ZeroHash, second, limits))
+
+	// going backward is not a fast-forward
+	err = ValidateReceivedRef(repo, ref, second, first, limits)
+	assert.EqualError(t, err, "non fast-forward update")
+
+	// only the new operations count
+	limits.MaxOperations = 1
+	assert.Error(t, ValidateReceivedRef(repo, ref, ZeroHash, second, limits))
+	assert.NoError(t, ValidateReceivedRef(repo, ref, first, second, limits))
+
+	limits = ReceiveLimits{MaxPackSize: 10}
+	assert.Error(t, ValidateReceivedRef(repo, ref, ZeroHash, second, limits))
+}
+
+func TestReadReceiveLimits(t *testing.T) {
+	repo := createRepo(false)
+	defer cleanupRepo(repo)
+
+	limits, err := ReadReceiveLimits(repo)
+	require.NoError(t, err)
+	assert.Equal(t, ReceiveLimits{MaxPackSize: defaultMaxPackSize}, limits)
+
+	require.NoError(t, repo.StoreConfig(receiveMaxPackSizeConfigKey, "0"))
+	require.NoError(t, repo.StoreConfig(receiveMaxOperationsConfigKey, "50"))
+
+	limits, err = ReadReceiveLimits(repo)
+	require.NoError(t, err)
+	assert.Equal(t, ReceiveLimits{MaxOperations: 50}, limits)
+
+	require.NoError(t, repo.StoreConfig(receiveMaxOperationsConfigKey, "many"))
+	_, err = ReadReceiveLimits(repo)
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package bug
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// FetchRefSpecs return the refspecs to configure on a remote so that a plain
+// git fetch also retrieve its bugs, policy and aliases, the same way as Fetch
+func FetchRefSpecs(remote string) []string {
+	return []string{
+		fmt.Sprintf("%s*:%s*", bugsRefPattern, fmt.Sprintf(bugsRemoteRefPattern, remote)),
+		fmt.Sprintf("%s*:%s*", policyRefPattern, fmt.Sprintf(policyRemoteRefPattern, remote)),
+		fmt.Sprintf("%s*:%s*", aliasesRefPattern, fmt.Sprintf(aliasesRemoteRefPattern, remote)),
+	}
+}
+
+// PushRefSpecs return the refspecs to configure on a remote so that a plain
+// git push also send the bugs, policy and aliases
+func PushRefSpecs() []string {
+	return []string{
+		fmt.Sprintf("%s*:%s*", bugsRefPattern, bugsRefPattern),
+		fmt.Sprintf("%s*:%s*", policyRefPattern, policyRefPattern),
+		fmt.Sprintf("%s*:%s*", aliasesRefPattern, aliasesRefPattern),
+	}
+}
+
+// MissingFetchRefSpecs return the refspecs of FetchRefSpecs not configured
+// on the remote
+func MissingFetchRefSpecs(repo repository.RemoteRepo, remote string) ([]string, error) {
+	return missingRefSpecs(repo, fmt.Sprintf("remote.%s.fetch", remote), FetchRefSpecs(remote))
+}
+
+// MissingPushRefSpecs return the refspecs of PushRefSpecs not configured on
+// the remote
+func MissingPushRefSpecs(repo repository.RemoteRepo, remote string) ([]string, error) {
+	return missingRefSpecs(repo, fmt.Sprintf("remote.%s.push", remote), PushRefSpecs())
+}
+
+func missingRefSpecs(repo repository.RemoteRepo, key string, refSpecs []string) ([]string, error) {
+	configured, err := repo.ReadConfigValues(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+
+	for _, refSpec := range refSpecs {
+		found := false
+		for _, c := range configured {
+			// a forced update is fine as well
+			if strings.TrimPrefix(c, "+") == refSpec {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, refSpec)
+		}
+	}
+
+	return missing, nil
+}
+
+// AddFetchRefSpecs configure the missing fetch refspecs on the remote, and
+// return them
+func AddFetchRefSpecs(repo repository.RemoteRepo, remote string) ([]string, error) {
+	missing, err := MissingFetchRefSpecs(repo, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	return missing, addConfigValues(repo, fmt.Sprintf("remote.%s.fetch", remote), missing)
+}
+
+// AddPushRefSpecs configure the missing push refspecs on the remote, and
+// return them.
+//
+// Git only push the configured refspecs when there are some, so the current
+// branch is added first if nothing was configured, for git push to keep
+// pushing it.
+func AddPushRefSpecs(repo repository.RemoteRepo, remote string) ([]string, error) {
+	key := fmt.Sprintf("remote.%s.push", remote)
+
+	configured, err := repo.ReadConfigValues(key)
+	if err != nil {
+		return nil, err
+	}
+
+	missing, err := MissingPushRefSpecs(repo, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(configured) == 0 && len(missing) > 0 {
+		missing = append([]string{"HEAD"}, missing...)
+	}
+
+	return missing, addConfigValues(repo, key, missing)
+}
+
+func addConfigValues(repo repository.RemoteRepo, key string, values []string) error {
+	for _, value := range values {
+		err := repo.AddConfig(key, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
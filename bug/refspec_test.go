@@ -0,0 +1,55 @@
+package bug
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefSpecs(t *testing.T) {
+	repoA, repoB, remote := setupRepos(t)
+	defer cleanupRepos(repoA, repoB, remote)
+
+	missing, err := MissingFetchRefSpecs(repoB, "origin")
+	require.NoError(t, err)
+	assert.Len(t, missing, 3)
+
+	added, err := AddFetchRefSpecs(repoB, "origin")
+	require.NoError(t, err)
+	assert.Equal(t, missing, added)
+
+	missing, err = MissingFetchRefSpecs(repoB, "origin")
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+
+	// the current branch is kept pushed
+	added, err = AddPushRefSpecs(repoB, "origin")
+	require.NoError(t, err)
+	assert.Equal(t, append([]string{"HEAD"}, PushRefSpecs()...), added)
+
+	added, err = AddPushRefSpecs(repoB, "origin")
+	require.NoError(t, err)
+	assert.Empty(t, added)
+
+	bug1, _, err := Create(rene, unix, "bug1", "message")
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit(repoA))
+	_, err = Push(repoA, "origin")
+	require.NoError(t, err)
+
+	// a plain git fetch retrieve the bugs
+	cmd := exec.Command("git", "fetch", "origin")
+	cmd.Dir = repoB.GetPath()
+	require.NoError(t, cmd.Run())
+
+	for merge := range MergeAll(repoB, "origin") {
+		require.NoError(t, merge.Err)
+		assert.Equal(t, MergeStatusNew, merge.Status)
+	}
+
+	ids, err := ListLocalIds(repoB)
+	require.NoError(t, err)
+	assert.Equal(t, []string{bug1.Id()}, ids)
+}
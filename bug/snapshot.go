@@ -2,6 +2,8 @@ package bug
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/MichaelMure/git-bug/util/git"
@@ -11,10 +13,18 @@ import (
 type Snapshot struct {
 	id string
 
-	Status    Status
-	Title     string
-	Comments  []Comment
-	Labels    []Label
+	Status   Status
+	Title    string
+	Comments []Comment
+	Labels   []Label
+	Votes    []Person
+	Estimate float64
+	// Visibility is the audience allowed to see the bug, public if empty
+	Visibility Visibility
+	// FixedIn is the releases and commits fixing the bug
+	FixedIn []FixedIn
+	// Assignee is the person in charge of the bug, if any
+	Assignee  *Person
 	Author    Person
 	CreatedAt time.Time
 
@@ -59,6 +69,16 @@ func (snap *Snapshot) LastEditUnix() int64 {
 	return snap.Operations[len(snap.Operations)-1].GetUnixTime()
 }
 
+// HasVoted tell if the given Person voted for the bug
+func (snap *Snapshot) HasVoted(p Person) bool {
+	for _, voter := range snap.Votes {
+		if voter == p {
+			return true
+		}
+	}
+	return false
+}
+
 // SearchTimelineItem will search in the timeline for an item matching the given hash
 func (snap *Snapshot) SearchTimelineItem(hash git.Hash) (TimelineItem, error) {
 	for i := range snap.Timeline {
@@ -69,3 +89,58 @@ func (snap *Snapshot) SearchTimelineItem(hash git.Hash) (TimelineItem, error) {
 
 	return nil, fmt.Errorf("timeline item not found")
 }
+
+// CommentItems return the timeline items of the comments, in the same order
+// as the Comments
+func (snap *Snapshot) CommentItems() []*CommentTimelineItem {
+	var result []*CommentTimelineItem
+
+	for _, item := range snap.Timeline {
+		switch item := item.(type) {
+		case *CreateTimelineItem:
+			result = append(result, &item.CommentTimelineItem)
+		case *AddCommentTimelineItem:
+			result = append(result, &item.CommentTimelineItem)
+		}
+	}
+
+	return result
+}
+
+// SearchComment find a comment by its index in the comments, like "2" or
+// "#2", or by a prefix of the hash of the operation that added it, of at
+// least 4 characters. It return the comment and its index.
+func (snap *Snapshot) SearchComment(ref string) (*CommentTimelineItem, int, error) {
+	items := snap.CommentItems()
+
+	digits := strings.TrimPrefix(ref, "#")
+	if index, err := strconv.Atoi(digits); err == nil && (digits != ref || len(ref) < 4) {
+		if index < 0 || index >= len(items) {
+			return nil, 0, fmt.Errorf("no comment #%d, the bug has %d", index, len(items))
+		}
+		return items[index], index, nil
+	}
+
+	if len(ref) < 4 {
+		return nil, 0, fmt.Errorf("the hash prefix %s is too short", ref)
+	}
+
+	var found *CommentTimelineItem
+	foundIndex := 0
+
+	for i, item := range items {
+		if strings.HasPrefix(string(item.Hash()), ref) {
+			if found != nil {
+				return nil, 0, fmt.Errorf("multiple comments match the prefix %s", ref)
+			}
+			found = item
+			foundIndex = i
+		}
+	}
+
+	if found == nil {
+		return nil, 0, fmt.Errorf("no comment match the prefix %s", ref)
+	}
+
+	return found, foundIndex, nil
+}
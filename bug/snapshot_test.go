@@ -0,0 +1,51 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchComment(t *testing.T) {
+	snapshot := Snapshot{}
+
+	var rene = Person{
+		Name:  "René Descartes",
+		Email: "rene@descartes.fr",
+	}
+
+	unix := time.Now().Unix()
+
+	NewCreateOp(rene, unix, "title", "create", nil).Apply(&snapshot)
+	NewSetTitleOp(rene, unix, "title2", "title").Apply(&snapshot)
+
+	comment := NewAddCommentOp(rene, unix, "comment", nil)
+	comment.Apply(&snapshot)
+	hash, err := comment.Hash()
+	assert.NoError(t, err)
+
+	assert.Len(t, snapshot.CommentItems(), 2)
+
+	item, index, err := snapshot.SearchComment("1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, index)
+	assert.Equal(t, "comment", item.Message)
+
+	item, index, err = snapshot.SearchComment("#0")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, index)
+	assert.Equal(t, "create", item.Message)
+
+	item, index, err = snapshot.SearchComment(string(hash)[:8])
+	assert.NoError(t, err)
+	assert.Equal(t, 1, index)
+	assert.Equal(t, "comment", item.Message)
+
+	_, _, err = snapshot.SearchComment("2")
+	assert.Error(t, err)
+	_, _, err = snapshot.SearchComment("abc")
+	assert.Error(t, err)
+	_, _, err = snapshot.SearchComment("zzzzzzz")
+	assert.Error(t, err)
+}
@@ -1,9 +1,98 @@
 package bug
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// timezoneConfigKey is the git config key holding the timezone used to
+// display the times
+const timezoneConfigKey = "git-bug.timezone"
+
+// displayLocation hold the timezone in which the times are presented to the
+// user. It's a setting of the process, read concurrently by the formatters.
+var displayLocation atomic.Value
+
+func init() {
+	displayLocation.Store(time.Local)
+}
 
 type Timestamp int64
 
+// Time return the timestamp as a time in the display timezone
 func (t Timestamp) Time() time.Time {
-	return time.Unix(int64(t), 0)
+	return time.Unix(int64(t), 0).In(DisplayLocation())
+}
+
+// DisplayLocation return the timezone in which the times are presented
+func DisplayLocation() *time.Location {
+	return displayLocation.Load().(*time.Location)
+}
+
+// SetDisplayLocation change the timezone in which the times are presented.
+// It can be called while the times are being formatted.
+func SetDisplayLocation(loc *time.Location) {
+	displayLocation.Store(loc)
+}
+
+// ParseLocation parse a timezone setting: "local", "utc", a fixed offset
+// like "+05:30" or a name of the tz database like "Europe/Paris"
+func ParseLocation(s string) (*time.Location, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "local":
+		return time.Local, nil
+	case "utc":
+		return time.UTC, nil
+	}
+
+	if s[0] == '+' || s[0] == '-' {
+		offset, err := parseOffset(s)
+		if err != nil {
+			return nil, err
+		}
+		return time.FixedZone(s, offset), nil
+	}
+
+	loc, err := time.LoadLocation(s)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone \"%s\"", s)
+	}
+
+	return loc, nil
+}
+
+// parseOffset parse a UTC offset like "+05:30", "-0800" or "+02" into seconds
+func parseOffset(s string) (int, error) {
+	for _, layout := range []string{"-07:00", "-0700", "-07"} {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			_, offset := t.Zone()
+			return offset, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid timezone offset \"%s\"", s)
+}
+
+// LoadDisplayLocation set the display timezone from the configuration of the
+// repo. The local timezone is used by default. The timezone being a setting
+// of the process, the one of the repo a command is run from apply to all the
+// repos it serves.
+func LoadDisplayLocation(repo repository.RepoCommon) error {
+	configs, err := repo.ReadConfigs(timezoneConfigKey)
+	if err != nil {
+		return err
+	}
+
+	loc, err := ParseLocation(configs[timezoneConfigKey])
+	if err != nil {
+		return err
+	}
+
+	SetDisplayLocation(loc)
+	return nil
 }
@@ -0,0 +1,73 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocation(t *testing.T) {
+	loc, err := ParseLocation("")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Local, loc)
+
+	loc, err = ParseLocation("UTC")
+	assert.NoError(t, err)
+	assert.Equal(t, time.UTC, loc)
+
+	for input, expected := range map[string]int{
+		"+05:30": 5*3600 + 30*60,
+		"-0800":  -8 * 3600,
+		"+02":    2 * 3600,
+	} {
+		loc, err = ParseLocation(input)
+		assert.NoError(t, err, input)
+		_, offset := time.Unix(0, 0).In(loc).Zone()
+		assert.Equal(t, expected, offset, input)
+	}
+
+	for _, invalid := range []string{"+5h", "Mars/Olympus_Mons"} {
+		_, err = ParseLocation(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestOperationTimezone(t *testing.T) {
+	defer SetDisplayLocation(time.Local)
+
+	rene := Person{Name: "René Descartes", Email: "rene@descartes.fr"}
+	unix := time.Now().Unix()
+
+	op := NewSetTitleOp(rene, unix, "title", "was")
+	op.TzOffset = 9 * 3600
+
+	SetDisplayLocation(time.UTC)
+
+	// the time is normalized to the display timezone
+	assert.Equal(t, time.UTC, op.Time().Location())
+	assert.Equal(t, unix, op.Time().Unix())
+
+	// the time of the author is kept
+	_, offset := op.OriginalTime().Zone()
+	assert.Equal(t, 9*3600, offset)
+	assert.Equal(t, unix, op.OriginalTime().Unix())
+}
+
+func TestDisplayLocationConcurrent(t *testing.T) {
+	defer SetDisplayLocation(time.Local)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = Timestamp(i).Time()
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		SetDisplayLocation(time.FixedZone("", i*60))
+	}
+
+	<-done
+}
@@ -65,6 +65,13 @@ func (c *CommentTimelineItem) Append(comment Comment) {
 	})
 }
 
+// clone return a copy of the item that won't be affected by its later edits
+func (c *CommentTimelineItem) clone() CommentTimelineItem {
+	clone := *c
+	clone.History = append([]CommentHistoryStep(nil), c.History...)
+	return clone
+}
+
 // Edited say if the comment was edited
 func (c *CommentTimelineItem) Edited() bool {
 	return len(c.History) > 1
@@ -0,0 +1,111 @@
+package bug
+
+// TimelineIterator iterate over the timeline items of a bug, compiling the
+// operations only as needed. It allows to page through a large bug without
+// building the complete timeline.
+//
+// Note: a returned item reflect the state of the bug up to this item. A later
+// edition (for example an EditCommentOperation) is only visible on this item
+// once the iterator went past this edition, or once ApplyEdits is called.
+type TimelineIterator struct {
+	ops   *OperationIterator
+	snap  Snapshot
+	index int
+}
+
+func NewTimelineIterator(bug Interface) *TimelineIterator {
+	return &TimelineIterator{
+		ops: NewOperationIterator(bug),
+		snap: Snapshot{
+			// Note: the id is not set yet if the bug is not committed
+			id:     bugFromInterface(bug).id,
+			Status: OpenStatus,
+		},
+		index: -1,
+	}
+}
+
+// Next advance the iterator to the next timeline item. It returns false when
+// there is no more items.
+func (it *TimelineIterator) Next() bool {
+	it.index++
+
+	// apply operations until a new item is available, as some operations
+	// don't produce timeline items
+	for it.index >= len(it.snap.Timeline) {
+		if !it.ops.Next() {
+			return false
+		}
+
+		op := it.ops.Value()
+		op.Apply(&it.snap)
+		it.snap.Operations = append(it.snap.Operations, op)
+	}
+
+	return true
+}
+
+// Value return the current timeline item
+func (it *TimelineIterator) Value() TimelineItem {
+	if it.index < 0 || it.index >= len(it.snap.Timeline) {
+		panic("Iterator is not valid anymore")
+	}
+
+	return it.snap.Timeline[it.index]
+}
+
+// ApplyEdits apply the editions of the remaining operations to the items
+// already iterated over, without producing the following items. The items
+// are replaced in the snapshot of the iterator, not changed in place. The
+// iterator is exhausted afterward.
+func (it *TimelineIterator) ApplyEdits() {
+	for it.ops.Next() {
+		if op, ok := it.ops.Value().(*EditCommentOperation); ok {
+			op.Apply(&it.snap)
+		}
+	}
+}
+
+// Snapshot return the snapshot compiled so far
+func (it *TimelineIterator) Snapshot() *Snapshot {
+	return &it.snap
+}
+
+// TimelinePage return at most limit timeline items, starting at the offset.
+// Only the operations needed to produce these items are compiled, along with
+// the later editions of these items.
+func TimelinePage(bug Interface, offset int, limit int) []TimelineItem {
+	return FilterTimelinePage(bug, nil, offset, limit)
+}
+
+// FilterTimelinePage return at most limit timeline items accepted by the
+// filter, after skipping the offset first ones. A nil filter accept all the
+// items, a negative limit return all the items after the offset.
+func FilterTimelinePage(bug Interface, filter func(TimelineItem) bool, offset int, limit int) []TimelineItem {
+	it := NewTimelineIterator(bug)
+
+	// the indexes are kept rather than the items, as the editions applied
+	// next replace the edited items
+	var indexes []int
+	skipped := 0
+
+	for (limit < 0 || len(indexes) < limit) && it.Next() {
+		if filter != nil && !filter(it.Value()) {
+			continue
+		}
+		if skipped < offset {
+			skipped++
+			continue
+		}
+		indexes = append(indexes, it.index)
+	}
+
+	it.ApplyEdits()
+
+	result := make([]TimelineItem, len(indexes))
+	for i, index := range indexes {
+		result[i] = it.snap.Timeline[index]
+	}
+
+	return result
+}
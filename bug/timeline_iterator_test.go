@@ -0,0 +1,78 @@
+package bug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimelineIterator(t *testing.T) {
+	b := NewBug()
+
+	b.Append(NewCreateOp(rene, unix, "title", "message", nil))
+	for i := 0; i < 10; i++ {
+		b.Append(NewAddCommentOp(rene, unix, "comment", nil))
+		// no timeline item for votes
+		b.Append(NewAddVoteOp(rene, unix))
+	}
+
+	it := NewTimelineIterator(b)
+
+	count := 0
+	for it.Next() {
+		assert.NotNil(t, it.Value())
+		count++
+	}
+
+	assert.Equal(t, 11, count)
+	assert.Equal(t, b.Compile().Timeline, it.Snapshot().Timeline)
+
+	page := TimelinePage(b, 2, 3)
+	assert.Len(t, page, 3)
+	assert.Equal(t, b.Compile().Timeline[2:5], page)
+
+	// out of range
+	assert.Len(t, TimelinePage(b, 20, 3), 0)
+}
+
+func TestTimelinePageEdits(t *testing.T) {
+	b := NewBug()
+
+	create := NewCreateOp(rene, unix, "title", "message", nil)
+	b.Append(create)
+	b.Append(NewAddCommentOp(rene, unix, "comment", nil))
+	b.Append(NewAddCommentOp(rene, unix, "comment2", nil))
+
+	hash, err := create.Hash()
+	assert.NoError(t, err)
+	b.Append(NewEditCommentOp(rene, unix, hash, "edited", nil))
+
+	// the edition come after the page, but is visible on its items
+	page := TimelinePage(b, 0, 1)
+	assert.Len(t, page, 1)
+	assert.Equal(t, "edited", page[0].(*CreateTimelineItem).Message)
+	assert.True(t, page[0].(*CreateTimelineItem).Edited())
+}
+
+func TestFilterTimelinePage(t *testing.T) {
+	b := NewBug()
+
+	b.Append(NewCreateOp(rene, unix, "title", "message", nil))
+	for i := 0; i < 5; i++ {
+		b.Append(NewAddCommentOp(rene, unix, "comment", nil))
+		b.Append(NewSetTitleOp(rene, unix, "title", "title"))
+	}
+
+	titles := func(item TimelineItem) bool {
+		_, ok := item.(*SetTitleTimelineItem)
+		return ok
+	}
+
+	page := FilterTimelinePage(b, titles, 1, 2)
+	assert.Len(t, page, 2)
+	assert.Equal(t, b.Compile().Timeline[4], page[0])
+	assert.Equal(t, b.Compile().Timeline[6], page[1])
+
+	assert.Len(t, FilterTimelinePage(b, titles, 0, -1), 5)
+	assert.Len(t, FilterTimelinePage(b, nil, 3, -1), 8)
+}
@@ -0,0 +1,83 @@
+package bug
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// bugsTrashRefPattern is where the soft-deleted bugs are moved. As they are
+// out of the bugs namespace, they are ignored by the queries and not pushed.
+const bugsTrashRefPattern = "refs/trash/bugs/"
+
+// Trash soft-delete a local bug. It can be restored later with Restore or
+// definitely deleted with Purge.
+func Trash(repo repository.Repo, id string) error {
+	return moveRef(repo, bugsRefPattern+id, bugsTrashRefPattern+id)
+}
+
+// Restore move back a soft-deleted bug in the local bugs
+func Restore(repo repository.Repo, id string) error {
+	return moveRef(repo, bugsTrashRefPattern+id, bugsRefPattern+id)
+}
+
+// Purge definitely delete a soft-deleted bug. Note that if a remote still has
+// this bug, it will be merged again on the next pull.
+func Purge(repo repository.Repo, id string) error {
+	ref := bugsTrashRefPattern + id
+
+	exist, err := repo.RefExist(ref)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return ErrBugNotExist
+	}
+
+	return repo.RemoveRef(ref)
+}
+
+// ListTrashedIds list the ids of the soft-deleted bugs
+func ListTrashedIds(repo repository.Repo) ([]string, error) {
+	refs, err := repo.ListRefs(bugsTrashRefPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return refsToIds(refs), nil
+}
+
+// ReadTrashedBug read a soft-deleted bug
+func ReadTrashedBug(repo repository.ClockedRepo, id string) (*Bug, error) {
+	return readBug(repo, bugsTrashRefPattern+id)
+}
+
+// IsTrashed tell if a bug has been soft-deleted
+func IsTrashed(repo repository.Repo, id string) (bool, error) {
+	return repo.RefExist(bugsTrashRefPattern + id)
+}
+
+func moveRef(repo repository.Repo, source string, dest string) error {
+	exist, err := repo.RefExist(source)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return ErrBugNotExist
+	}
+
+	exist, err = repo.RefExist(dest)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return fmt.Errorf("%s already exist", dest)
+	}
+
+	err = repo.CopyRef(source, dest)
+	if err != nil {
+		return err
+	}
+
+	return repo.RemoveRef(source)
+}
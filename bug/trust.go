@@ -0,0 +1,98 @@
+package bug
+
+import (
+	"strings"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// trustedAuthorsConfigKey is the git config key holding the comma separated
+// list of trusted authors (email or login)
+const trustedAuthorsConfigKey = "git-bug.trusted-authors"
+
+// TrustedAuthors is an allow-list of authors whose operations can be merged
+// from a remote without review. An empty list means that everyone is trusted.
+//
+// Note: as the authors are not authenticated, this is a protection against
+// spam and casual vandalism, not against a determined attacker.
+type TrustedAuthors map[string]bool
+
+// ReadTrustedAuthors read the allow-list of trusted authors from the
+// configuration of the repo
+func ReadTrustedAuthors(repo repository.RepoCommon) (TrustedAuthors, error) {
+	configs, err := repo.ReadConfigs(trustedAuthorsConfigKey)
+	if err != nil {
+		return nil, err
+	}
+
+	trusted := make(TrustedAuthors)
+
+	for _, value := range configs {
+		for _, author := range strings.Split(value, ",") {
+			author = strings.TrimSpace(author)
+			if author != "" {
+				trusted[strings.ToLower(author)] = true
+			}
+		}
+	}
+
+	return trusted, nil
+}
+
+// IsTrusted tell if the operations of a Person can be merged without review
+func (ta TrustedAuthors) IsTrusted(p Person) bool {
+	if len(ta) == 0 {
+		return true
+	}
+
+	if p.Email != "" && ta[strings.ToLower(p.Email)] {
+		return true
+	}
+
+	return p.Login != "" && ta[strings.ToLower(p.Login)]
+}
+
+// untrustedAuthors return the authors of the operations of a remote bug that
+// are not already merged locally and are not trusted
+func untrustedAuthors(repo repository.ClockedRepo, remoteBug *Bug, trusted TrustedAuthors) ([]Person, error) {
+	if len(trusted) == 0 {
+		return nil, nil
+	}
+
+	localRef := bugsRefPattern + remoteBug.Id()
+	localExist, err := repo.RefExist(localRef)
+	if err != nil {
+		return nil, err
+	}
+
+	localCommits := make(map[string]bool)
+
+	if localExist {
+		hashes, err := repo.ListCommits(localRef)
+		if err != nil {
+			return nil, err
+		}
+		for _, hash := range hashes {
+			localCommits[string(hash)] = true
+		}
+	}
+
+	var result []Person
+	seen := make(map[Person]bool)
+
+	for _, pack := range remoteBug.packs {
+		if localCommits[string(pack.commitHash)] {
+			continue
+		}
+
+		for _, op := range pack.Operations {
+			author := op.base().Author
+			if !trusted.IsTrusted(author) && !seen[author] {
+				seen[author] = true
+				result = append(result, author)
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,231 @@
+package bug
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/MichaelMure/git-bug/util/lamport"
+)
+
+// VerifyProblem is a corrupted or forged entry found in the data of the bugs
+type VerifyProblem struct {
+	// Ref is the git reference holding the entry
+	Ref string `json:"ref"`
+	// Commit is the commit with the problem, empty if the problem is about
+	// the whole ref
+	Commit git.Hash `json:"commit,omitempty"`
+	// Problem describe what is wrong
+	Problem string `json:"problem"`
+}
+
+func (p VerifyProblem) String() string {
+	if p.Commit == "" {
+		return fmt.Sprintf("%s: %s", p.Ref, p.Problem)
+	}
+	return fmt.Sprintf("%s (commit %s): %s", p.Ref, p.Commit.String()[:7], p.Problem)
+}
+
+// VerifyReport is the outcome of the verification of the bugs
+type VerifyReport struct {
+	// Checked is the number of bugs checked
+	Checked int `json:"checked"`
+	// Problems are the problems found, in the order of the refs
+	Problems []VerifyProblem `json:"problems"`
+}
+
+// VerifyLocalBugs check the data of all the local bugs and of the policy
+func VerifyLocalBugs(repo repository.ClockedRepo) (VerifyReport, error) {
+	return verifyRefs(repo, bugsRefPattern, policyRef)
+}
+
+// VerifyRemoteBugs check the data of the bugs and of the policy fetched from
+// a remote, before they are merged
+func VerifyRemoteBugs(repo repository.ClockedRepo, remote string) (VerifyReport, error) {
+	return verifyRefs(repo, fmt.Sprintf(bugsRemoteRefPattern, remote), policyRemoteRef(remote))
+}
+
+func verifyRefs(repo repository.ClockedRepo, refPrefix string, policyRef string) (VerifyReport, error) {
+	var report VerifyReport
+
+	refs, err := repo.ListRefs(refPrefix)
+	if err != nil {
+		return report, err
+	}
+
+	for _, ref := range refs {
+		report.Checked++
+		report.Problems = append(report.Problems, verifyBug(repo, ref)...)
+	}
+
+	exist, err := repo.RefExist(policyRef)
+	if err != nil {
+		return report, err
+	}
+	if exist {
+		// this checks the signature as well, if it's required
+		if _, err := readPolicy(repo, policyRef); err != nil {
+			report.Problems = append(report.Problems, VerifyProblem{
+				Ref:     policyRef,
+				Problem: err.Error(),
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// verifyBug check every commit of a bug. Unlike readBug, it doesn't stop at
+// the first problem and doesn't update the clocks of the repository, as the
+// values read can't be trusted yet.
+func verifyBug(repo repository.ClockedRepo, ref string) []VerifyProblem {
+	var problems []VerifyProblem
+
+	report := func(commit git.Hash, format string, a ...interface{}) {
+		problems = append(problems, VerifyProblem{
+			Ref:     ref,
+			Commit:  commit,
+			Problem: fmt.Sprintf(format, a...),
+		})
+	}
+
+	refSplit := strings.Split(ref, "/")
+	id := refSplit[len(refSplit)-1]
+
+	bug := Bug{id: id}
+
+	if len(id) != idLength {
+		report("", "invalid id length")
+	}
+
+	hashes, err := repo.ListCommits(ref)
+	if err != nil {
+		report("", "can't list the commits: %v", err)
+		return problems
+	}
+
+	decrypter := &packDecrypter{repo: repo}
+	var prevEditTime lamport.Time
+
+	for i, hash := range hashes {
+		entries, err := repo.ListEntries(hash)
+		if err != nil {
+			report(hash, "can't list git tree entries: %v", err)
+			continue
+		}
+
+		var opsEntry, rootEntry *repository.TreeEntry
+		var createTime, editTime uint64
+		createFound, editFound := false, false
+
+		for j := range entries {
+			entry := entries[j]
+			switch {
+			case entry.Name == opsEntryName:
+				opsEntry = &entry
+			case entry.Name == rootEntryName:
+				rootEntry = &entry
+			case strings.HasPrefix(entry.Name, createClockEntryPrefix):
+				_, err := fmt.Sscanf(entry.Name, createClockEntryPattern, &createTime)
+				if err != nil {
+					report(hash, "can't read the create lamport time")
+				}
+				createFound = true
+			case strings.HasPrefix(entry.Name, editClockEntryPrefix):
+				_, err := fmt.Sscanf(entry.Name, editClockEntryPattern, &editTime)
+				if err != nil {
+					report(hash, "can't read the edit lamport time")
+				}
+				editFound = true
+			}
+		}
+
+		if i == 0 {
+			if !createFound {
+				report(hash, "missing the create lamport time")
+			}
+			bug.createTime = lamport.Time(createTime)
+		} else if createFound && lamport.Time(createTime) != bug.createTime {
+			report(hash, "the create lamport time changed")
+		}
+
+		if !editFound {
+			report(hash, "missing the edit lamport time")
+		} else if i > 0 && lamport.Time(editTime) <= prevEditTime {
+			report(hash, "the edit lamport time %d is not after the previous one %d", editTime, prevEditTime)
+		}
+		if lamport.Time(editTime) > prevEditTime {
+			prevEditTime = lamport.Time(editTime)
+		}
+
+		if rootEntry == nil {
+			report(hash, "invalid tree, missing the root entry")
+		} else if i == 0 {
+			bug.rootPack = rootEntry.Hash
+		} else if rootEntry.Hash != bug.rootPack {
+			report(hash, "the root entry changed")
+		}
+
+		if opsEntry == nil {
+			report(hash, "invalid tree, missing the ops entry")
+			continue
+		}
+
+		data, err := repo.ReadData(opsEntry.Hash)
+		if err != nil {
+			report(hash, "failed to read git blob data: %v", err)
+			continue
+		}
+
+		data, err = decrypter.read(data)
+		if err != nil {
+			report(hash, "%v", err)
+			continue
+		}
+
+		opp := &OperationPack{}
+		if err := json.Unmarshal(data, &opp); err != nil {
+			report(hash, "failed to decode OperationPack json: %v", err)
+			continue
+		}
+		opp.commitHash = hash
+
+		if opp.IsEmpty() {
+			report(hash, "empty operation pack")
+		}
+
+		for _, op := range opp.Operations {
+			if err := op.Validate(); err != nil {
+				report(hash, "invalid operation: %v", err)
+			}
+		}
+
+		bug.packs = append(bug.packs, *opp)
+	}
+
+	// the checks of the whole bug, the packs already reported are left out
+	// to not report the same problem twice
+	if len(bug.packs) > 0 {
+		firstOp := bug.FirstOp()
+		if firstOp == nil || firstOp.base().OperationType != CreateOp {
+			report("", "first operation should be a Create op")
+		}
+
+		createCount := 0
+		it := NewOperationIterator(&bug)
+		for it.Next() {
+			if it.Value().base().OperationType == CreateOp {
+				createCount++
+			}
+		}
+		if createCount > 1 {
+			report("", "only one Create op allowed")
+		}
+	} else if len(hashes) > 0 {
+		report("", "bug has no readable operations")
+	}
+
+	return problems
+}
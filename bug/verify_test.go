@@ -0,0 +1,51 @@
+package bug
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyLocalBugs(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	b := NewBug()
+	b.Append(createOp)
+	require.NoError(t, b.Commit(repo))
+
+	report, err := VerifyLocalBugs(repo)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Checked)
+	assert.Empty(t, report.Problems)
+
+	// forge a commit with an anonymous author and a clock going back
+	forged := OperationPack{}
+	forged.Append(NewAddCommentOp(Person{}, unix, "spam", nil))
+	opsHash, err := forged.Write(repo)
+	require.NoError(t, err)
+
+	emptyBlobHash, err := repo.StoreData([]byte{})
+	require.NoError(t, err)
+
+	treeHash, err := repo.StoreTree([]repository.TreeEntry{
+		{ObjectType: repository.Blob, Hash: opsHash, Name: opsEntryName},
+		{ObjectType: repository.Blob, Hash: b.rootPack, Name: rootEntryName},
+		{ObjectType: repository.Blob, Hash: emptyBlobHash, Name: fmt.Sprintf(editClockEntryPattern, b.editTime)},
+	})
+	require.NoError(t, err)
+
+	commitHash, err := repo.StoreCommitWithParent(treeHash, b.lastCommit)
+	require.NoError(t, err)
+	require.NoError(t, repo.UpdateRef(bugsRefPattern+b.Id(), commitHash))
+
+	report, err = VerifyLocalBugs(repo)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Checked)
+	require.Len(t, report.Problems, 2)
+	assert.Equal(t, commitHash, report.Problems[0].Commit)
+	assert.Contains(t, report.Problems[0].Problem, "edit lamport time")
+	assert.Contains(t, report.Problems[1].Problem, "author")
+}
@@ -0,0 +1,116 @@
+package bug
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Visibility define the audience of a bug. It allows a single repository to
+// host bugs visible to different audiences when served by the web UI.
+//
+// Note: anyone with a clone of the repository can read all the bugs, the
+// visibility only restrict what is exposed by the servers.
+type Visibility string
+
+const (
+	// VisibilityPublic is the default visibility, the bug is visible to everyone
+	VisibilityPublic Visibility = "public"
+	// VisibilityInternal restrict the bug to the internal audience
+	VisibilityInternal Visibility = "internal"
+)
+
+// visibilityTeamPrefix is the prefix of the visibilities restricting a bug
+// to a team, as in "team-backend"
+const visibilityTeamPrefix = "team-"
+
+// TeamVisibility return the visibility restricting a bug to a team
+func TeamVisibility(team string) Visibility {
+	return Visibility(visibilityTeamPrefix + team)
+}
+
+// VisibilityFromString parse a visibility. An empty string give the public
+// visibility.
+func VisibilityFromString(str string) (Visibility, error) {
+	v := Visibility(strings.ToLower(strings.TrimSpace(str)))
+	if v == "" {
+		return VisibilityPublic, nil
+	}
+
+	return v, v.Validate()
+}
+
+// Validate check if the visibility is one of public, internal or team-<name>
+func (v Visibility) Validate() error {
+	switch {
+	case v == VisibilityPublic, v == VisibilityInternal:
+		return nil
+	case strings.HasPrefix(string(v), visibilityTeamPrefix) && len(v) > len(visibilityTeamPrefix):
+		if strings.ContainsAny(string(v), " \t\n,") {
+			return fmt.Errorf("invalid team in visibility %s", v)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown visibility %s, expected public, internal or team-<name>", v)
+	}
+}
+
+// Team return the team of a visibility restricted to a team, if any
+func (v Visibility) Team() (string, bool) {
+	if !strings.HasPrefix(string(v), visibilityTeamPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(string(v), visibilityTeamPrefix), true
+}
+
+// IsPublic tell if the visibility is public. The zero value is public.
+func (v Visibility) IsPublic() bool {
+	return v == "" || v == VisibilityPublic
+}
+
+func (v Visibility) String() string {
+	if v == "" {
+		return string(VisibilityPublic)
+	}
+	return string(v)
+}
+
+// Audience is the set of visibilities a viewer is allowed to see, on top of
+// the public bugs. A nil Audience sees everything.
+type Audience map[Visibility]bool
+
+// AudienceFromString parse a comma separated list of visibilities, or "all"
+// for an unrestricted audience. An empty string give an audience only seeing
+// the public bugs.
+func AudienceFromString(str string) (Audience, error) {
+	if strings.TrimSpace(str) == "all" {
+		return nil, nil
+	}
+
+	audience := make(Audience)
+
+	for _, item := range strings.Split(str, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		v, err := VisibilityFromString(item)
+		if err != nil {
+			return nil, err
+		}
+
+		audience[v] = true
+	}
+
+	return audience, nil
+}
+
+// CanSee tell if the audience is allowed to see a bug with the given
+// visibility
+func (a Audience) CanSee(v Visibility) bool {
+	if a == nil || v.IsPublic() {
+		return true
+	}
+
+	return a[v]
+}
@@ -0,0 +1,55 @@
+package bug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisibilityFromString(t *testing.T) {
+	var tests = []struct {
+		input    string
+		expected Visibility
+		ok       bool
+	}{
+		{"", VisibilityPublic, true},
+		{"public", VisibilityPublic, true},
+		{"Internal", VisibilityInternal, true},
+		{"team-backend", TeamVisibility("backend"), true},
+		{"team-", "", false},
+		{"secret", "", false},
+	}
+
+	for _, test := range tests {
+		v, err := VisibilityFromString(test.input)
+		assert.Equal(t, test.ok, err == nil, test.input)
+		if test.ok {
+			assert.Equal(t, test.expected, v)
+		}
+	}
+
+	team, ok := TeamVisibility("backend").Team()
+	assert.True(t, ok)
+	assert.Equal(t, "backend", team)
+}
+
+func TestAudience(t *testing.T) {
+	all, err := AudienceFromString("all")
+	assert.Nil(t, err)
+	assert.True(t, all.CanSee(VisibilityInternal))
+
+	public, err := AudienceFromString("")
+	assert.Nil(t, err)
+	assert.True(t, public.CanSee(VisibilityPublic))
+	assert.True(t, public.CanSee(""))
+	assert.False(t, public.CanSee(VisibilityInternal))
+
+	backend, err := AudienceFromString("internal, team-backend")
+	assert.Nil(t, err)
+	assert.True(t, backend.CanSee(VisibilityInternal))
+	assert.True(t, backend.CanSee(TeamVisibility("backend")))
+	assert.False(t, backend.CanSee(TeamVisibility("frontend")))
+
+	_, err = AudienceFromString("internal,secret")
+	assert.NotNil(t, err)
+}
@@ -10,7 +10,9 @@ type WithSnapshot struct {
 	snap *Snapshot
 }
 
-// Snapshot return the current snapshot
+// Snapshot return the current snapshot. A snapshot is never changed once
+// returned: the new operations are applied on a copy, so that the snapshots
+// given out can be read while the bug is changed.
 func (b *WithSnapshot) Snapshot() *Snapshot {
 	if b.snap == nil {
 		snap := b.Bug.Compile()
@@ -27,8 +29,10 @@ func (b *WithSnapshot) Append(op Operation) {
 		return
 	}
 
-	op.Apply(b.snap)
-	b.snap.Operations = append(b.snap.Operations, op)
+	snap := b.snap.clone()
+	op.Apply(&snap)
+	snap.Operations = append(snap.Operations, op)
+	b.snap = &snap
 }
 
 // Commit intercept Bug.Commit() to update the snapshot efficiently
@@ -43,11 +47,13 @@ func (b *WithSnapshot) Commit(repo repository.ClockedRepo) error {
 	// Commit() shouldn't change anything of the bug state apart from the
 	// initial ID set
 
-	if b.snap == nil {
+	if b.snap == nil || b.snap.id == b.Bug.id {
 		return nil
 	}
 
-	b.snap.id = b.Bug.id
+	snap := *b.snap
+	snap.id = b.Bug.id
+	b.snap = &snap
 	return nil
 }
 
@@ -56,3 +62,43 @@ func (b *WithSnapshot) Merge(repo repository.Repo, other Interface) (bool, error
 	b.snap = nil
 	return b.Bug.Merge(repo, other)
 }
+
+// Rebase read the bug again if its ref moved since it was read or committed,
+// by another process or another copy of the bug, and replay the operations not
+// committed yet on top of it. Without that, the next commit would overwrite
+// the changes of the ref. It return true if the bug has been read again.
+func (b *WithSnapshot) Rebase(repo repository.ClockedRepo) (bool, error) {
+	// never committed, there is no ref to move
+	if b.lastCommit == "" {
+		return false, nil
+	}
+
+	ref := bugsRefPattern + b.id
+	hashes, err := repo.ListRefHashes(ref)
+	if err != nil {
+		return false, err
+	}
+
+	head, ok := hashes[ref]
+	if !ok || head == b.lastCommit {
+		return false, nil
+	}
+
+	fresh, err := readBug(repo, ref)
+	if err != nil {
+		return false, err
+	}
+
+	for _, op := range b.staging.Operations {
+		fresh.Append(op)
+	}
+
+	b.Bug = fresh
+
+	if b.snap != nil {
+		snap := fresh.Compile()
+		b.snap = &snap
+	}
+
+	return true, nil
+}
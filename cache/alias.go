@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+const idSchemeConfigKey = "git-bug.id-scheme"
+
+// IdScheme define how the bugs are identified for humans
+type IdScheme int
+
+const (
+	_ IdScheme = iota
+	// IdSchemeHash only display the hash based ids
+	IdSchemeHash
+	// IdSchemeSequential additionally display the short incremental aliases
+	IdSchemeSequential
+)
+
+// IdSchemeFromString parse an id scheme, as found in the configuration. An
+// empty string give the default scheme.
+func IdSchemeFromString(str string) (IdScheme, error) {
+	switch str {
+	case "hash":
+		return IdSchemeHash, nil
+	case "", "sequential":
+		return IdSchemeSequential, nil
+	default:
+		return 0, fmt.Errorf("unknown id scheme %s", str)
+	}
+}
+
+// loadAliases read the id scheme from the configuration and the existing
+// aliases of the repo
+func (c *RepoCache) loadAliases() error {
+	configs, err := c.repo.ReadConfigs(idSchemeConfigKey)
+	if err != nil {
+		return err
+	}
+
+	c.idScheme, err = IdSchemeFromString(configs[idSchemeConfigKey])
+	if err != nil {
+		return err
+	}
+
+	c.aliases, err = bug.ReadAliases(c.repo)
+	return err
+}
+
+// assignAliases give an alias to the bugs that don't have one yet. The bugs
+// are numbered in creation order. The aliases are assigned whatever the id
+// scheme, so that they can always be used to designate a bug. Nothing is done
+// if the cache is read-only.
+func (c *RepoCache) assignAliases() error {
+	// the aliases are assigned by the process holding the lock
+	if c.readOnly {
+		return nil
+	}
+
+	c.muBug.Lock()
+	defer c.muBug.Unlock()
+
+	var missing []*BugExcerpt
+	for id, excerpt := range c.excerpts {
+		if _, ok := c.aliases.Alias(id); !ok {
+			missing = append(missing, excerpt)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	sort.Slice(missing, func(i, j int) bool {
+		return c.createdBefore(missing[i].Id, missing[j].Id)
+	})
+
+	for _, excerpt := range missing {
+		c.aliases.Assign(excerpt.Id)
+	}
+
+	return c.aliases.Write(c.repo)
+}
+
+// mergeAliases reconcile the local aliases with the ones of a remote
+func (c *RepoCache) mergeAliases(remote string) error {
+	c.muBug.Lock()
+	defer c.muBug.Unlock()
+
+	return c.aliases.MergeRemote(c.repo, remote, c.createdBefore)
+}
+
+// createdBefore order the bugs by creation time, then by id. The bugs
+// unknown locally come last. The caller must hold muBug.
+func (c *RepoCache) createdBefore(id1, id2 string) bool {
+	excerpt1, ok1 := c.excerpts[id1]
+	excerpt2, ok2 := c.excerpts[id2]
+
+	switch {
+	case ok1 && !ok2:
+		return true
+	case !ok1 && ok2:
+		return false
+	case ok1 && ok2 && excerpt1.CreateLamportTime != excerpt2.CreateLamportTime:
+		return excerpt1.CreateLamportTime < excerpt2.CreateLamportTime
+	default:
+		return id1 < id2
+	}
+}
+
+// IdScheme return how the bugs are identified for humans
+func (c *RepoCache) IdScheme() IdScheme {
+	return c.idScheme
+}
+
+// Alias return the sequential alias of a bug, formatted for humans, if any
+func (c *RepoCache) Alias(id string) (string, bool) {
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
+	number, ok := c.aliases.Alias(id)
+	if !ok {
+		return "", false
+	}
+	return bug.FormatAlias(number), true
+}
+
+// DisplayId return the id of a bug formatted for humans: the human id, along
+// the alias if the id scheme is sequential. The aliases are padded so that
+// the ids are aligned in a list.
+func (c *RepoCache) DisplayId(id string) string {
+	humanId := bug.FormatHumanID(id)
+
+	if c.idScheme != IdSchemeSequential {
+		return humanId
+	}
+
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
+	number, ok := c.aliases.Alias(id)
+	width := len(bug.FormatAlias(c.aliases.Last()))
+	if !ok {
+		return fmt.Sprintf("%*s %s", width, "", humanId)
+	}
+
+	return fmt.Sprintf("%-*s %s", width, bug.FormatAlias(number), humanId)
+}
+
+// resolveAlias return the id of the bug with the given alias, in the form "#N"
+func (c *RepoCache) resolveAlias(alias string) (string, bool) {
+	number, ok := bug.ParseAlias(alias)
+	if !ok {
+		return "", false
+	}
+
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
+	id, ok := c.aliases.Resolve(number)
+	if !ok {
+		return "", false
+	}
+
+	// the aliased bug might have been removed since
+	if _, ok := c.excerpts[id]; !ok {
+		return "", false
+	}
+
+	return id, true
+}
@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"sort"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// QueryBugsAt evaluate a query against the bugs as they were at the given
+// time, according to the timestamps of their operations, and return the
+// state of the matching bugs at that time, sorted as requested. The bugs
+// created later are left out.
+//
+// Unlike QueryBugs, every bug has to be read and compiled again, which make
+// it much more expensive.
+func (c *RepoCache) QueryBugsAt(query *Query, t time.Time) ([]*bug.Snapshot, error) {
+	if query == nil {
+		query = NewQuery()
+	}
+
+	var filtered []*BugExcerpt
+	snapshots := make(map[string]*bug.Snapshot)
+
+	for _, id := range c.AllBugsIds() {
+		b, err := c.ResolveBug(id)
+		if err != nil {
+			return nil, err
+		}
+
+		excerpt, snap := b.excerptAt(t)
+		if excerpt == nil {
+			continue
+		}
+
+		// the edit clock is the current one, without it the edits are sorted
+		// by the time of the last one before t
+		excerpt.EditLamportTime = 0
+
+		if query.Audience.CanSee(excerpt.Visibility) && query.Match(excerpt) {
+			filtered = append(filtered, excerpt)
+			snapshots[id] = snap
+		}
+	}
+
+	sort.Sort(querySorter(query, filtered))
+
+	result := make([]*bug.Snapshot, len(filtered))
+	for i, excerpt := range filtered {
+		result[i] = snapshots[excerpt.Id]
+	}
+
+	return result, nil
+}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBugsAt(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	defer c.Close()
+
+	author, err := bug.GetUser(repo)
+	require.NoError(t, err)
+
+	now := time.Now()
+	day := int64(24 * 60 * 60)
+	daysAgo := func(n int64) time.Time {
+		return time.Unix(now.Unix()-n*day, 0)
+	}
+
+	b1, err := c.NewBugRaw(author, daysAgo(10), "old title", "message", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, b1.CloseRaw(author, daysAgo(5), nil))
+	require.NoError(t, b1.SetTitleRaw(author, daysAgo(1), "new title", nil))
+	require.NoError(t, b1.Commit())
+
+	b2, err := c.NewBugRaw(author, daysAgo(2), "second", "message", nil, nil)
+	require.NoError(t, err)
+
+	open, err := ParseQuery("status:open")
+	require.NoError(t, err)
+
+	snapshots, err := c.QueryBugsAt(open, daysAgo(7))
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, b1.Id(), snapshots[0].Id())
+	assert.Equal(t, "old title", snapshots[0].Title)
+
+	snapshots, err = c.QueryBugsAt(open, daysAgo(3))
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 0)
+
+	snapshots, err = c.QueryBugsAt(open, now)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, b2.Id(), snapshots[0].Id())
+
+	// the bugs created later are left out
+	snapshots, err = c.QueryBugsAt(nil, daysAgo(3))
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, bug.ClosedStatus, snapshots[0].Status)
+
+	snapshots, err = c.QueryBugsAt(nil, daysAgo(20))
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 0)
+}
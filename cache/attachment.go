@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+// MaxAttachmentSize is the largest file that can be attached, the same as
+// for the uploads of the web UI
+const MaxAttachmentSize = 100 * 1000 * 1000
+
+// attachmentReferenceRegexp match the markdown links to the files served
+// by the web UI, like [name](/gitfile/hash) or ![name](/gitfile/hash)
+var attachmentReferenceRegexp = regexp.MustCompile(`!?\[([^\]]*)\]\(/gitfile/([0-9a-f]{40})\)`)
+
+// Attachment is a file attached to a comment of a bug
+type Attachment struct {
+	Hash git.Hash `json:"hash"`
+	// Name is the name given in the reference of the file in the message,
+	// empty if the message doesn't reference it
+	Name string `json:"name,omitempty"`
+	// Comment is the index of the comment, 0 being the message of the bug
+	Comment int `json:"comment"`
+}
+
+// StoreAttachment store the content of a file in the repository, to be
+// attached to a new bug or comment. It return the hash of the file and a
+// markdown reference to it, to add to the message.
+func (c *RepoCache) StoreAttachment(path string, data []byte) (git.Hash, string, error) {
+	if err := c.checkWritable(); err != nil {
+		return "", "", err
+	}
+
+	if len(data) > MaxAttachmentSize {
+		return "", "", fmt.Errorf("%s is too big, the limit is %d bytes", path, MaxAttachmentSize)
+	}
+
+	hash, err := bug.StoreMedia(c.repo, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	return hash, AttachmentReference(filepath.Base(path), hash, data), nil
+}
+
+// ReadAttachment read the content of an attached file, decrypted
+func (c *RepoCache) ReadAttachment(hash git.Hash) ([]byte, error) {
+	return bug.ReadMedia(c.repo, hash)
+}
+
+// AttachmentVisible tell if a file is attached to a bug the audience can see,
+// for the file to be served to it
+func (c *RepoCache) AttachmentVisible(hash git.Hash, audience bug.Audience) bool {
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
+	for _, excerpt := range c.excerpts {
+		if !audience.CanSee(excerpt.Visibility) {
+			continue
+		}
+		for _, file := range excerpt.Files {
+			if file == hash {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// AttachmentReference return a markdown reference to an attached file, as
+// an image if it is one so that the web UI display it
+func AttachmentReference(name string, hash git.Hash, data []byte) string {
+	name = strings.NewReplacer("[", "", "]", "").Replace(name)
+	reference := fmt.Sprintf("[%s](/gitfile/%s)", name, hash)
+
+	if strings.HasPrefix(http.DetectContentType(data), "image/") {
+		reference = "!" + reference
+	}
+
+	return reference
+}
+
+// Attachments return the files attached to the comments of the bug, in the
+// order of the comments
+func (c *BugCache) Attachments() []Attachment {
+	var result []Attachment
+
+	for i, comment := range c.Snapshot().Comments {
+		names := make(map[git.Hash]string)
+		for _, match := range attachmentReferenceRegexp.FindAllStringSubmatch(comment.Message, -1) {
+			names[git.Hash(match[2])] = match[1]
+		}
+
+		for _, hash := range comment.Files {
+			result = append(result, Attachment{
+				Hash:    hash,
+				Name:    names[hash],
+				Comment: i,
+			})
+		}
+	}
+
+	return result
+}
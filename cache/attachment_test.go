@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"os"
+	"testing"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheAttachments(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	defer c.Close()
+
+	log, logRef, err := c.StoreAttachment("/tmp/crash.log", []byte("panic: oops"))
+	require.NoError(t, err)
+	assert.Equal(t, "[crash.log](/gitfile/"+string(log)+")", logRef)
+
+	// a PNG signature is enough to be detected as an image
+	png, pngRef, err := c.StoreAttachment("screen[1].png", []byte("\x89PNG\x0D\x0A\x1A\x0A"))
+	require.NoError(t, err)
+	assert.Equal(t, "![screen1.png](/gitfile/"+string(png)+")", pngRef)
+
+	b, err := c.NewBugWithFiles("title", "see "+logRef, []git.Hash{log})
+	require.NoError(t, err)
+	require.NoError(t, b.AddCommentWithFiles(pngRef, []git.Hash{png}))
+	require.NoError(t, b.Commit())
+
+	assert.Equal(t, []Attachment{
+		{Hash: log, Name: "crash.log", Comment: 0},
+		{Hash: png, Name: "screen1.png", Comment: 1},
+	}, b.Attachments())
+
+	data, err := c.ReadAttachment(log)
+	require.NoError(t, err)
+	assert.Equal(t, "panic: oops", string(data))
+}
+
+func TestCacheAttachmentVisible(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	defer c.Close()
+
+	public, _, err := c.StoreAttachment("public.txt", []byte("public"))
+	require.NoError(t, err)
+	internal, _, err := c.StoreAttachment("internal.txt", []byte("internal"))
+	require.NoError(t, err)
+	dangling, _, err := c.StoreAttachment("dangling.txt", []byte("dangling"))
+	require.NoError(t, err)
+
+	_, err = c.NewBugWithFiles("public", "message", []git.Hash{public})
+	require.NoError(t, err)
+
+	b, err := c.NewBugWithFiles("internal", "message", []git.Hash{internal})
+	require.NoError(t, err)
+	require.NoError(t, b.SetVisibility(bug.VisibilityInternal))
+	require.NoError(t, b.Commit())
+
+	publicOnly := bug.Audience{}
+
+	assert.True(t, c.AttachmentVisible(public, publicOnly))
+	assert.False(t, c.AttachmentVisible(internal, publicOnly))
+	assert.True(t, c.AttachmentVisible(internal, nil))
+
+	// a blob not attached to any bug is never visible
+	assert.False(t, c.AttachmentVisible(dangling, nil))
+}
@@ -0,0 +1,258 @@
+package cache
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/pkg/errors"
+)
+
+// auditOperationNames are the names of the operation types in the audit log
+var auditOperationNames = map[bug.OperationType]string{
+	bug.CreateOp:        "create",
+	bug.SetTitleOp:      "set-title",
+	bug.AddCommentOp:    "add-comment",
+	bug.SetStatusOp:     "set-status",
+	bug.LabelChangeOp:   "label-change",
+	bug.EditCommentOp:   "edit-comment",
+	bug.NoOpOp:          "noop",
+	bug.SetMetadataOp:   "set-metadata",
+	bug.AddVoteOp:       "add-vote",
+	bug.RemoveVoteOp:    "remove-vote",
+	bug.SetEstimateOp:   "set-estimate",
+	bug.SetVisibilityOp: "set-visibility",
+	bug.AddFixedInOp:    "add-fixed-in",
+	bug.SetAssigneeOp:   "set-assignee",
+}
+
+// AuditEntry is an operation in the audit log. Each entry is chained to the
+// previous one with its hash, so that changing, removing or reordering the
+// entries is detected.
+type AuditEntry struct {
+	Seq       int        `json:"seq"`
+	Bug       string     `json:"bug"`
+	Operation git.Hash   `json:"op"`
+	Type      string     `json:"type"`
+	Author    bug.Person `json:"author"`
+	Time      time.Time  `json:"time"`
+	// Prev is the hash of the previous entry, empty for the first one
+	Prev string `json:"prev"`
+	// Hash is the SHA-256 of the entry without this field
+	Hash string `json:"hash,omitempty"`
+}
+
+func (e AuditEntry) computeHash() (string, error) {
+	e.Hash = ""
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// AuditLog is the log of all the operations of the bugs, by time
+type AuditLog struct {
+	Entries []AuditEntry
+	// Signature is an armored detached signature of the hash of the last
+	// entry, if the log is signed
+	Signature string
+}
+
+// Head return the hash of the last entry, which vouch for the whole log
+func (l *AuditLog) Head() string {
+	if len(l.Entries) == 0 {
+		return ""
+	}
+	return l.Entries[len(l.Entries)-1].Hash
+}
+
+// auditSignature is the last line of a signed log
+type auditSignature struct {
+	Signature string `json:"signature"`
+}
+
+// AuditLog build the audit log of all the operations of the bugs, ordered by
+// time. As long as no operation older than the last one is pulled, a later
+// log extend an earlier one, with the same entries.
+func (c *RepoCache) AuditLog() (*AuditLog, error) {
+	type auditOp struct {
+		bug   string
+		index int
+		op    bug.Operation
+	}
+
+	var ops []auditOp
+
+	for _, id := range c.AllBugsIds() {
+		b, err := c.ResolveBug(id)
+		if err != nil {
+			return nil, err
+		}
+		for i, op := range b.Snapshot().Operations {
+			ops = append(ops, auditOp{bug: id, index: i, op: op})
+		}
+	}
+
+	sort.Slice(ops, func(i, j int) bool {
+		ti, tj := ops[i].op.GetUnixTime(), ops[j].op.GetUnixTime()
+		if ti != tj {
+			return ti < tj
+		}
+		if ops[i].bug != ops[j].bug {
+			return ops[i].bug < ops[j].bug
+		}
+		return ops[i].index < ops[j].index
+	})
+
+	log := &AuditLog{Entries: make([]AuditEntry, 0, len(ops))}
+	prev := ""
+
+	for i, item := range ops {
+		hash, err := item.op.Hash()
+		if err != nil {
+			return nil, err
+		}
+
+		entry := AuditEntry{
+			Seq:       i + 1,
+			Bug:       item.bug,
+			Operation: hash,
+			Type:      auditOperationNames[item.op.GetType()],
+			Author:    item.op.GetAuthor(),
+			Time:      item.op.Time().UTC(),
+			Prev:      prev,
+		}
+
+		entry.Hash, err = entry.computeHash()
+		if err != nil {
+			return nil, err
+		}
+
+		log.Entries = append(log.Entries, entry)
+		prev = entry.Hash
+	}
+
+	return log, nil
+}
+
+// SignAuditLog sign the head of the log with the key of the user
+func (c *RepoCache) SignAuditLog(log *AuditLog) error {
+	signing, ok := c.repo.(repository.SigningRepo)
+	if !ok {
+		return fmt.Errorf("the repository can't sign the audit log")
+	}
+
+	signature, err := signing.SignData([]byte(log.Head()))
+	if err != nil {
+		return err
+	}
+
+	log.Signature = signature
+	return nil
+}
+
+// VerifyAuditLog check that the entries of the log are correctly chained, and
+// that its signature is valid if it's signed. It doesn't compare the log with
+// the bugs, which might have been trashed since.
+func (c *RepoCache) VerifyAuditLog(log *AuditLog) error {
+	prev := ""
+
+	for i, entry := range log.Entries {
+		if entry.Seq != i+1 {
+			return fmt.Errorf("entry %d: unexpected sequence number %d", i+1, entry.Seq)
+		}
+		if entry.Prev != prev {
+			return fmt.Errorf("entry %d: not chained to the previous entry", entry.Seq)
+		}
+
+		hash, err := entry.computeHash()
+		if err != nil {
+			return err
+		}
+		if hash != entry.Hash {
+			return fmt.Errorf("entry %d: the hash doesn't match the content", entry.Seq)
+		}
+
+		prev = entry.Hash
+	}
+
+	if log.Signature == "" {
+		return nil
+	}
+
+	signing, ok := c.repo.(repository.SigningRepo)
+	if !ok {
+		return fmt.Errorf("the repository can't verify the signature of the audit log")
+	}
+
+	return signing.VerifyData([]byte(log.Head()), log.Signature)
+}
+
+// WriteAuditLog write the log as JSON lines, one per entry, followed by the
+// signature if any
+func WriteAuditLog(w io.Writer, log *AuditLog) error {
+	encoder := json.NewEncoder(w)
+
+	for _, entry := range log.Entries {
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+
+	if log.Signature != "" {
+		return encoder.Encode(auditSignature{Signature: log.Signature})
+	}
+
+	return nil
+}
+
+// ReadAuditLog read a log written by WriteAuditLog
+func ReadAuditLog(r io.Reader) (*AuditLog, error) {
+	log := &AuditLog{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		if log.Signature != "" {
+			return nil, fmt.Errorf("line %d: unexpected data after the signature", line)
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(scanner.Bytes(), &fields); err != nil {
+			return nil, errors.Wrapf(err, "line %d", line)
+		}
+
+		if _, ok := fields["signature"]; ok {
+			var signature auditSignature
+			if err := json.Unmarshal(scanner.Bytes(), &signature); err != nil {
+				return nil, errors.Wrapf(err, "line %d", line)
+			}
+			log.Signature = signature.Signature
+			continue
+		}
+
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, errors.Wrapf(err, "line %d", line)
+		}
+		log.Entries = append(log.Entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return log, nil
+}
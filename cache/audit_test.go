@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	defer c.Close()
+
+	author, err := bug.GetUser(repo)
+	require.NoError(t, err)
+	other := bug.Person{Name: "other", Email: "other@example.com"}
+
+	now := time.Now()
+
+	b1, err := c.NewBugRaw(author, now.Add(-10*time.Second), "first", "message", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, b1.CloseRaw(other, now, nil))
+	require.NoError(t, b1.Commit())
+
+	b2, err := c.NewBugRaw(other, now.Add(-5*time.Second), "second", "message", nil, nil)
+	require.NoError(t, err)
+
+	log, err := c.AuditLog()
+	require.NoError(t, err)
+	require.Len(t, log.Entries, 3)
+
+	// ordered by time across the bugs
+	assert.Equal(t, b1.Id(), log.Entries[0].Bug)
+	assert.Equal(t, "create", log.Entries[0].Type)
+	assert.Equal(t, "", log.Entries[0].Prev)
+	assert.Equal(t, b2.Id(), log.Entries[1].Bug)
+	assert.Equal(t, log.Entries[0].Hash, log.Entries[1].Prev)
+	assert.Equal(t, "set-status", log.Entries[2].Type)
+	assert.Equal(t, other, log.Entries[2].Author)
+	assert.Equal(t, log.Entries[2].Hash, log.Head())
+
+	assert.NoError(t, c.VerifyAuditLog(log))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteAuditLog(&buf, log))
+	read, err := ReadAuditLog(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, len(log.Entries), len(read.Entries))
+	assert.NoError(t, c.VerifyAuditLog(read))
+
+	// changing an entry is detected
+	read.Entries[1].Author = author
+	assert.Error(t, c.VerifyAuditLog(read))
+	read.Entries[1].Author = other
+	assert.NoError(t, c.VerifyAuditLog(read))
+
+	// so is removing one
+	read.Entries = append(read.Entries[:1], read.Entries[2:]...)
+	assert.Error(t, c.VerifyAuditLog(read))
+}
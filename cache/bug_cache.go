@@ -3,6 +3,8 @@ package cache
 import (
 	"fmt"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/MichaelMure/git-bug/bug"
@@ -11,7 +13,15 @@ import (
 
 type BugCache struct {
 	repoCache *RepoCache
-	bug       *bug.WithSnapshot
+
+	// mu protect the bug and its snapshot, compiled lazily and updated by the
+	// new operations. It can be held while taking the lock of the RepoCache,
+	// not the other way around.
+	mu  sync.Mutex
+	bug *bug.WithSnapshot
+	// pending tell if the bug has operations not committed yet, readable
+	// without the lock
+	pending int32
 }
 
 func NewBugCache(repoCache *RepoCache, b *bug.Bug) *BugCache {
@@ -21,10 +31,27 @@ func NewBugCache(repoCache *RepoCache, b *bug.Bug) *BugCache {
 	}
 }
 
+// Snapshot return the current state of the bug, compiled on the first call.
+// The snapshot is never changed once returned, the later changes of the bug
+// give a new one.
 func (c *BugCache) Snapshot() *bug.Snapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.bug.Snapshot()
 }
 
+// TimelinePage return at most limit timeline items of the bug accepted by
+// the filter, after skipping the offset first ones. Only the operations
+// needed to produce them are compiled. A nil filter accept all the items, a
+// negative limit return all the items after the offset.
+func (c *BugCache) TimelinePage(filter func(bug.TimelineItem) bool, offset int, limit int) []bug.TimelineItem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return bug.FilterTimelinePage(c.bug, filter, offset, limit)
+}
+
+// Id return the id of the bug. It doesn't take the lock, the id of a bug in the
+// cache never change.
 func (c *BugCache) Id() string {
 	return c.bug.Id()
 }
@@ -33,8 +60,44 @@ func (c *BugCache) HumanId() string {
 	return c.bug.HumanId()
 }
 
+// History return the changes made by each operation of the bug
+func (c *BugCache) History() []bug.HistoryEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return bug.History(c.bug)
+}
+
 func (c *BugCache) notifyUpdated() error {
-	return c.repoCache.bugUpdated(c.bug.Id())
+	return c.repoCache.bugUpdated(c)
+}
+
+// statusChanged notify the cache and fire the hooks after a change of status
+func (c *BugCache) statusChanged(author bug.Person) error {
+	err := c.notifyUpdated()
+	if err != nil {
+		return err
+	}
+
+	event := newHookEvent(HookStatusChanged, c.Snapshot())
+	event.Author = &author
+	c.repoCache.fireHook(event)
+
+	return nil
+}
+
+// changed notify the cache and fire the hooks after a change of the bug other
+// than a new comment or a change of status
+func (c *BugCache) changed(author bug.Person) error {
+	err := c.notifyUpdated()
+	if err != nil {
+		return err
+	}
+
+	event := newHookEvent(HookBugUpdated, c.Snapshot())
+	event.Author = &author
+	c.repoCache.fireHook(event)
+
+	return nil
 }
 
 var ErrNoMatchingOp = fmt.Errorf("no matching operation found")
@@ -53,14 +116,14 @@ func (e ErrMultipleMatchOp) Error() string {
 	return fmt.Sprintf("Multiple matching operation found:\n%s", strings.Join(casted, "\n"))
 }
 
-// ResolveTargetWithMetadata will find an operation that has the matching metadata
+// ResolveTargetWithMetadata will find an operation that has the matching metadata.
+// The operations of the snapshot are used, as the metadata added afterward by
+// a SetMetadataOperation are only attached to their target when compiled.
 func (c *BugCache) ResolveTargetWithMetadata(key string, value string) (git.Hash, error) {
 	// preallocate but empty
 	matching := make([]git.Hash, 0, 5)
 
-	it := bug.NewOperationIterator(c.bug)
-	for it.Next() {
-		op := it.Value()
+	for _, op := range c.Snapshot().Operations {
 		opValue, ok := op.GetMetadata(key)
 		if ok && value == opValue {
 			h, err := op.Hash()
@@ -92,42 +155,106 @@ func (c *BugCache) AddCommentWithFiles(message string, files []git.Hash) error {
 		return err
 	}
 
-	return c.AddCommentRaw(author, time.Now().Unix(), message, files, nil)
+	return c.AddCommentAs(author, message, files, nil)
+}
+
+// AddCommentAs add a comment on behalf of an author, if the policy allows it
+func (c *BugCache) AddCommentAs(author bug.Person, message string, files []git.Hash, metadata map[string]string) error {
+	err := c.checkAllowed(author, bug.ActionAddComment)
+	if err != nil {
+		return err
+	}
+
+	return c.AddCommentRaw(author, time.Now(), message, files, metadata)
 }
 
-func (c *BugCache) AddCommentRaw(author bug.Person, unixTime int64, message string, files []git.Hash, metadata map[string]string) error {
-	op, err := bug.AddCommentWithFiles(c.bug, author, unixTime, message, files)
+func (c *BugCache) AddCommentRaw(author bug.Person, date time.Time, message string, files []git.Hash, metadata map[string]string) error {
+	if err := c.repoCache.checkWritable(); err != nil {
+		return err
+	}
+
+	author = c.repoCache.sanitizePerson(author)
+	message = c.repoCache.sanitizeText(message)
+
+	c.mu.Lock()
+	op, err := bug.AddCommentWithFiles(c.bug, author, date.Unix(), message, files)
 	if err != nil {
+		c.unlock()
 		return err
 	}
 
+	op.SetTzOffset(date)
+
 	for key, value := range metadata {
 		op.SetMetadata(key, value)
 	}
+	c.unlock()
 
-	return c.notifyUpdated()
+	err = c.notifyUpdated()
+	if err != nil {
+		return err
+	}
+
+	event := newHookEvent(HookCommentAdded, c.Snapshot())
+	event.Author = &author
+	event.Message = message
+	c.repoCache.fireHook(event)
+
+	return nil
 }
 
 func (c *BugCache) ChangeLabels(added []string, removed []string) ([]bug.LabelChangeResult, error) {
+	return c.ChangeLabelsWithMetadata(added, removed, nil)
+}
+
+// ChangeLabelsWithMetadata change the labels like ChangeLabels, storing some
+// metadata in the operation
+func (c *BugCache) ChangeLabelsWithMetadata(added []string, removed []string, metadata map[string]string) ([]bug.LabelChangeResult, error) {
 	author, err := bug.GetUser(c.repoCache.repo)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.ChangeLabelsRaw(author, time.Now().Unix(), added, removed, nil)
+	return c.ChangeLabelsAs(author, added, removed, metadata)
+}
+
+// ChangeLabelsAs change the labels on behalf of an author, if the policy allows it
+func (c *BugCache) ChangeLabelsAs(author bug.Person, added []string, removed []string, metadata map[string]string) ([]bug.LabelChangeResult, error) {
+	err := c.checkAllowed(author, bug.ActionLabelChange)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ChangeLabelsRaw(author, time.Now(), added, removed, metadata)
 }
 
-func (c *BugCache) ChangeLabelsRaw(author bug.Person, unixTime int64, added []string, removed []string, metadata map[string]string) ([]bug.LabelChangeResult, error) {
-	changes, op, err := bug.ChangeLabels(c.bug, author, unixTime, added, removed)
+func (c *BugCache) ChangeLabelsRaw(author bug.Person, date time.Time, added []string, removed []string, metadata map[string]string) ([]bug.LabelChangeResult, error) {
+	if err := c.repoCache.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	author = c.repoCache.sanitizePerson(author)
+
+	added, err := c.repoCache.canonicalLabels(added)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	changes, op, err := bug.ChangeLabels(c.bug, author, date.Unix(), added, removed)
 	if err != nil {
+		c.unlock()
 		return changes, err
 	}
 
+	op.SetTzOffset(date)
+
 	for key, value := range metadata {
 		op.SetMetadata(key, value)
 	}
+	c.unlock()
 
-	err = c.notifyUpdated()
+	err = c.changed(author)
 	if err != nil {
 		return nil, err
 	}
@@ -136,100 +263,555 @@ func (c *BugCache) ChangeLabelsRaw(author bug.Person, unixTime int64, added []st
 }
 
 func (c *BugCache) Open() error {
+	return c.OpenWithMetadata(nil)
+}
+
+// OpenWithMetadata reopen the bug like Open, storing some metadata in the
+// operation
+func (c *BugCache) OpenWithMetadata(metadata map[string]string) error {
 	author, err := bug.GetUser(c.repoCache.repo)
 	if err != nil {
 		return err
 	}
 
-	return c.OpenRaw(author, time.Now().Unix(), nil)
+	return c.OpenAs(author, metadata)
 }
 
-func (c *BugCache) OpenRaw(author bug.Person, unixTime int64, metadata map[string]string) error {
-	op, err := bug.Open(c.bug, author, unixTime)
+// OpenAs reopen the bug on behalf of an author, if the policy allows it
+func (c *BugCache) OpenAs(author bug.Person, metadata map[string]string) error {
+	err := c.checkAllowed(author, bug.ActionSetStatus, bug.ActionReopen)
 	if err != nil {
 		return err
 	}
 
+	return c.OpenRaw(author, time.Now(), metadata)
+}
+
+func (c *BugCache) OpenRaw(author bug.Person, date time.Time, metadata map[string]string) error {
+	if err := c.repoCache.checkWritable(); err != nil {
+		return err
+	}
+
+	author = c.repoCache.sanitizePerson(author)
+
+	c.mu.Lock()
+	op, err := bug.Open(c.bug, author, date.Unix())
+	if err != nil {
+		c.unlock()
+		return err
+	}
+
+	op.SetTzOffset(date)
+
 	for key, value := range metadata {
 		op.SetMetadata(key, value)
 	}
+	c.unlock()
 
-	return c.notifyUpdated()
+	return c.statusChanged(author)
 }
 
 func (c *BugCache) Close() error {
+	return c.CloseWithMetadata(nil)
+}
+
+// CloseWithMetadata close the bug like Close, storing some metadata in the
+// operation
+func (c *BugCache) CloseWithMetadata(metadata map[string]string) error {
 	author, err := bug.GetUser(c.repoCache.repo)
 	if err != nil {
 		return err
 	}
 
-	return c.CloseRaw(author, time.Now().Unix(), nil)
+	return c.CloseAs(author, metadata)
 }
 
-func (c *BugCache) CloseRaw(author bug.Person, unixTime int64, metadata map[string]string) error {
-	op, err := bug.Close(c.bug, author, unixTime)
+// CloseAs close the bug on behalf of an author, if the policy allows it
+func (c *BugCache) CloseAs(author bug.Person, metadata map[string]string) error {
+	err := c.checkAllowed(author, bug.ActionSetStatus, bug.ActionClose)
 	if err != nil {
 		return err
 	}
 
+	return c.CloseRaw(author, time.Now(), metadata)
+}
+
+func (c *BugCache) CloseRaw(author bug.Person, date time.Time, metadata map[string]string) error {
+	if err := c.repoCache.checkWritable(); err != nil {
+		return err
+	}
+
+	author = c.repoCache.sanitizePerson(author)
+
+	c.mu.Lock()
+	op, err := bug.Close(c.bug, author, date.Unix())
+	if err != nil {
+		c.unlock()
+		return err
+	}
+
+	op.SetTzOffset(date)
+
 	for key, value := range metadata {
 		op.SetMetadata(key, value)
 	}
+	c.unlock()
 
-	return c.notifyUpdated()
+	return c.statusChanged(author)
+}
+
+func (c *BugCache) AddVote() error {
+	author, err := bug.GetUser(c.repoCache.repo)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkAllowed(author, bug.ActionAddVote)
+	if err != nil {
+		return err
+	}
+
+	return c.AddVoteRaw(author, time.Now(), nil)
+}
+
+func (c *BugCache) AddVoteRaw(author bug.Person, date time.Time, metadata map[string]string) error {
+	if err := c.repoCache.checkWritable(); err != nil {
+		return err
+	}
+
+	author = c.repoCache.sanitizePerson(author)
+
+	c.mu.Lock()
+	op, err := bug.AddVote(c.bug, author, date.Unix())
+	if err != nil {
+		c.unlock()
+		return err
+	}
+
+	op.SetTzOffset(date)
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+	c.unlock()
+
+	return c.changed(author)
+}
+
+func (c *BugCache) RemoveVote() error {
+	author, err := bug.GetUser(c.repoCache.repo)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkAllowed(author, bug.ActionRemoveVote)
+	if err != nil {
+		return err
+	}
+
+	return c.RemoveVoteRaw(author, time.Now(), nil)
+}
+
+func (c *BugCache) RemoveVoteRaw(author bug.Person, date time.Time, metadata map[string]string) error {
+	if err := c.repoCache.checkWritable(); err != nil {
+		return err
+	}
+
+	author = c.repoCache.sanitizePerson(author)
+
+	c.mu.Lock()
+	op, err := bug.RemoveVote(c.bug, author, date.Unix())
+	if err != nil {
+		c.unlock()
+		return err
+	}
+
+	op.SetTzOffset(date)
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+	c.unlock()
+
+	return c.changed(author)
 }
 
 func (c *BugCache) SetTitle(title string) error {
+	return c.SetTitleWithMetadata(title, nil)
+}
+
+// SetTitleWithMetadata change the title like SetTitle, storing some metadata
+// in the operation
+func (c *BugCache) SetTitleWithMetadata(title string, metadata map[string]string) error {
 	author, err := bug.GetUser(c.repoCache.repo)
 	if err != nil {
 		return err
 	}
 
-	return c.SetTitleRaw(author, time.Now().Unix(), title, nil)
+	return c.SetTitleAs(author, title, metadata)
 }
 
-func (c *BugCache) SetTitleRaw(author bug.Person, unixTime int64, title string, metadata map[string]string) error {
-	op, err := bug.SetTitle(c.bug, author, unixTime, title)
+// SetTitleAs change the title on behalf of an author, if the policy allows it
+func (c *BugCache) SetTitleAs(author bug.Person, title string, metadata map[string]string) error {
+	err := c.checkAllowed(author, bug.ActionSetTitle)
 	if err != nil {
 		return err
 	}
 
+	return c.SetTitleRaw(author, time.Now(), title, metadata)
+}
+
+func (c *BugCache) SetTitleRaw(author bug.Person, date time.Time, title string, metadata map[string]string) error {
+	if err := c.repoCache.checkWritable(); err != nil {
+		return err
+	}
+
+	author = c.repoCache.sanitizePerson(author)
+	title = c.repoCache.sanitizeText(title)
+
+	c.mu.Lock()
+	op, err := bug.SetTitle(c.bug, author, date.Unix(), title)
+	if err != nil {
+		c.unlock()
+		return err
+	}
+
+	op.SetTzOffset(date)
+
 	for key, value := range metadata {
 		op.SetMetadata(key, value)
 	}
+	c.unlock()
 
-	return c.notifyUpdated()
+	return c.changed(author)
+}
+
+func (c *BugCache) SetEstimate(estimate float64) error {
+	author, err := bug.GetUser(c.repoCache.repo)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkAllowed(author, bug.ActionSetEstimate)
+	if err != nil {
+		return err
+	}
+
+	return c.SetEstimateRaw(author, time.Now(), estimate, nil)
+}
+
+func (c *BugCache) SetEstimateRaw(author bug.Person, date time.Time, estimate float64, metadata map[string]string) error {
+	if err := c.repoCache.checkWritable(); err != nil {
+		return err
+	}
+
+	author = c.repoCache.sanitizePerson(author)
+
+	c.mu.Lock()
+	op, err := bug.SetEstimate(c.bug, author, date.Unix(), estimate)
+	if err != nil {
+		c.unlock()
+		return err
+	}
+
+	op.SetTzOffset(date)
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+	c.unlock()
+
+	return c.changed(author)
+}
+
+func (c *BugCache) SetAssignee(assignee *bug.Person) error {
+	author, err := bug.GetUser(c.repoCache.repo)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkAllowed(author, bug.ActionSetAssignee)
+	if err != nil {
+		return err
+	}
+
+	return c.SetAssigneeRaw(author, time.Now(), assignee, nil)
+}
+
+func (c *BugCache) SetAssigneeRaw(author bug.Person, date time.Time, assignee *bug.Person, metadata map[string]string) error {
+	if err := c.repoCache.checkWritable(); err != nil {
+		return err
+	}
+
+	author = c.repoCache.sanitizePerson(author)
+	if assignee != nil {
+		sanitized := c.repoCache.sanitizePerson(*assignee)
+		assignee = &sanitized
+	}
+
+	c.mu.Lock()
+	op, err := bug.SetAssignee(c.bug, author, date.Unix(), assignee)
+	if err != nil {
+		c.unlock()
+		return err
+	}
+
+	op.SetTzOffset(date)
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+	c.unlock()
+
+	return c.changed(author)
+}
+
+func (c *BugCache) SetVisibility(visibility bug.Visibility) error {
+	author, err := bug.GetUser(c.repoCache.repo)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkAllowed(author, bug.ActionSetVisibility)
+	if err != nil {
+		return err
+	}
+
+	return c.SetVisibilityRaw(author, time.Now(), visibility, nil)
+}
+
+func (c *BugCache) SetVisibilityRaw(author bug.Person, date time.Time, visibility bug.Visibility, metadata map[string]string) error {
+	if err := c.repoCache.checkWritable(); err != nil {
+		return err
+	}
+
+	author = c.repoCache.sanitizePerson(author)
+
+	c.mu.Lock()
+	op, err := bug.SetVisibility(c.bug, author, date.Unix(), visibility)
+	if err != nil {
+		c.unlock()
+		return err
+	}
+
+	op.SetTzOffset(date)
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+	c.unlock()
+
+	return c.changed(author)
+}
+
+func (c *BugCache) AddFixedIn(release string, commit string) error {
+	author, err := bug.GetUser(c.repoCache.repo)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkAllowed(author, bug.ActionAddFixedIn)
+	if err != nil {
+		return err
+	}
+
+	return c.AddFixedInRaw(author, time.Now(), release, commit, nil)
+}
+
+func (c *BugCache) AddFixedInRaw(author bug.Person, date time.Time, release string, commit string, metadata map[string]string) error {
+	if err := c.repoCache.checkWritable(); err != nil {
+		return err
+	}
+
+	author = c.repoCache.sanitizePerson(author)
+
+	c.mu.Lock()
+	op, err := bug.AddFixedIn(c.bug, author, date.Unix(), release, commit)
+	if err != nil {
+		c.unlock()
+		return err
+	}
+
+	op.SetTzOffset(date)
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+	c.unlock()
+
+	return c.changed(author)
 }
 
 func (c *BugCache) EditComment(target git.Hash, message string) error {
+	return c.EditCommentWithMetadata(target, message, nil)
+}
+
+// EditCommentWithMetadata edit a comment like EditComment, storing some
+// metadata in the operation
+func (c *BugCache) EditCommentWithMetadata(target git.Hash, message string, metadata map[string]string) error {
 	author, err := bug.GetUser(c.repoCache.repo)
 	if err != nil {
 		return err
 	}
 
-	return c.EditCommentRaw(author, time.Now().Unix(), target, message, nil)
+	return c.EditCommentAs(author, target, message, metadata)
 }
 
-func (c *BugCache) EditCommentRaw(author bug.Person, unixTime int64, target git.Hash, message string, metadata map[string]string) error {
-	op, err := bug.EditComment(c.bug, author, unixTime, target, message)
+// EditCommentAs edit a comment on behalf of an author, if the policy allows it
+func (c *BugCache) EditCommentAs(author bug.Person, target git.Hash, message string, metadata map[string]string) error {
+	err := c.checkAllowed(author, bug.ActionEditComment)
 	if err != nil {
 		return err
 	}
 
+	return c.EditCommentRaw(author, time.Now(), target, message, metadata)
+}
+
+func (c *BugCache) EditCommentRaw(author bug.Person, date time.Time, target git.Hash, message string, metadata map[string]string) error {
+	if err := c.repoCache.checkWritable(); err != nil {
+		return err
+	}
+
+	author = c.repoCache.sanitizePerson(author)
+	message = c.repoCache.sanitizeText(message)
+
+	c.mu.Lock()
+	op, err := bug.EditComment(c.bug, author, date.Unix(), target, message)
+	if err != nil {
+		c.unlock()
+		return err
+	}
+
+	op.SetTzOffset(date)
+
 	for key, value := range metadata {
 		op.SetMetadata(key, value)
 	}
+	c.unlock()
+
+	return c.changed(author)
+}
+
+// SetMetadataRaw add some metadata to a previous operation, like the id of
+// its copy in an external tracker once exported. The metadata already set on
+// the operation are kept. It's not a change of the bug, no hook is fired.
+func (c *BugCache) SetMetadataRaw(author bug.Person, date time.Time, target git.Hash, newMetadata map[string]string) error {
+	if err := c.repoCache.checkWritable(); err != nil {
+		return err
+	}
+
+	author = c.repoCache.sanitizePerson(author)
+
+	c.mu.Lock()
+	op, err := bug.SetMetadata(c.bug, author, date.Unix(), target, newMetadata)
+	if err != nil {
+		c.unlock()
+		return err
+	}
+
+	op.SetTzOffset(date)
+	c.unlock()
 
 	return c.notifyUpdated()
 }
 
+// LastOperation return the last operation of the bug, the one created by the
+// last change
+func (c *BugCache) LastOperation() bug.Operation {
+	ops := c.Snapshot().Operations
+	if len(ops) == 0 {
+		return nil
+	}
+	return ops[len(ops)-1]
+}
+
 func (c *BugCache) Commit() error {
-	return c.bug.Commit(c.repoCache.repo)
+	if err := c.repoCache.checkWritable(); err != nil {
+		return err
+	}
+
+	c.repoCache.muCommit.Lock()
+	c.mu.Lock()
+	// the ref might have moved since the bug was loaded, by another copy of
+	// the bug evicted from the cache or by another process
+	rebased, err := c.bug.Rebase(c.repoCache.repo)
+	if err == nil {
+		err = c.bug.Commit(c.repoCache.repo)
+	}
+	lastCommit := c.bug.LastCommit()
+	c.unlock()
+	c.repoCache.muCommit.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = c.repoCache.bugCommitted(c.Id(), lastCommit)
+	if err != nil || !rebased {
+		return err
+	}
+
+	// the excerpt was computed without the changes of the ref
+	return c.notifyUpdated()
+}
+
+// rebase bring the bug up to date with its ref, moved by another process,
+// keeping the operations not committed yet
+func (c *BugCache) rebase() error {
+	c.repoCache.muCommit.Lock()
+	defer c.repoCache.muCommit.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, err := c.bug.Rebase(c.repoCache.repo)
+	return err
 }
 
 func (c *BugCache) CommitAsNeeded() error {
-	if c.bug.HasPendingOp() {
-		return c.bug.Commit(c.repoCache.repo)
+	if c.hasPendingOp() {
+		return c.Commit()
 	}
 	return nil
 }
+
+// hasPendingOp tell if the bug has operations not committed yet. It doesn't
+// take the lock, to be usable while holding the one of the RepoCache.
+func (c *BugCache) hasPendingOp() bool {
+	return atomic.LoadInt32(&c.pending) == 1
+}
+
+// unlock release the lock after a change of the bug, recording if it has
+// operations not committed yet
+func (c *BugCache) unlock() {
+	var pending int32
+	if c.bug.HasPendingOp() {
+		pending = 1
+	}
+	atomic.StoreInt32(&c.pending, pending)
+	c.mu.Unlock()
+}
+
+// excerpt build the excerpt of the bug, with its scores
+func (c *BugCache) excerpt() *BugExcerpt {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.repoCache.newExcerpt(c.bug, c.bug.Snapshot())
+}
+
+// excerptAt build the excerpt of the bug as it was at the given time, with
+// its scores. It return nil if the bug didn't exist yet.
+func (c *BugCache) excerptAt(t time.Time) (*BugExcerpt, *bug.Snapshot) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	snap := c.bug.CompileAt(t)
+	if len(snap.Operations) == 0 {
+		return nil, nil
+	}
+	return c.repoCache.newExcerpt(c.bug, &snap), &snap
+}
+
+// append add an operation built outside of the bug package
+func (c *BugCache) append(op bug.Operation) {
+	c.mu.Lock()
+	defer c.unlock()
+	c.bug.Append(op)
+}
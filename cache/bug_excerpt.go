@@ -4,24 +4,50 @@ import (
 	"encoding/gob"
 
 	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/util/git"
 	"github.com/MichaelMure/git-bug/util/lamport"
 )
 
 // BugExcerpt hold a subset of the bug values to be able to sort and filter bugs
 // efficiently without having to read and compile each raw bugs.
+//
+// The field names are part of the cache file format: renaming a field in Go
+// must not change its JSON name.
 type BugExcerpt struct {
-	Id string
+	Id string `json:"id"`
 
-	CreateLamportTime lamport.Time
-	EditLamportTime   lamport.Time
-	CreateUnixTime    int64
-	EditUnixTime      int64
+	CreateLamportTime lamport.Time `json:"create_lamport_time"`
+	EditLamportTime   lamport.Time `json:"edit_lamport_time"`
+	CreateUnixTime    int64        `json:"create_unix_time"`
+	EditUnixTime      int64        `json:"edit_unix_time"`
 
-	Status bug.Status
-	Author bug.Person
-	Labels []bug.Label
+	Title  string      `json:"title"`
+	Status bug.Status  `json:"status"`
+	Author bug.Person  `json:"author"`
+	Labels []bug.Label `json:"labels"`
+	Votes  int         `json:"votes"`
 
-	CreateMetadata map[string]string
+	// Participants are the authors of the comments, Actors the authors of any
+	// operation
+	Participants []bug.Person `json:"participants"`
+	Actors       []bug.Person `json:"actors"`
+
+	Estimate   float64        `json:"estimate"`
+	Visibility bug.Visibility `json:"visibility"`
+	FixedIn    []bug.FixedIn  `json:"fixed_in,omitempty"`
+	Assignee   *bug.Person    `json:"assignee,omitempty"`
+
+	// CloseUnixTime is when the bug has been closed, if it is
+	CloseUnixTime int64 `json:"close_unix_time,omitempty"`
+
+	CreateMetadata map[string]string `json:"create_metadata"`
+	// Metadata are the values of the metadata of all the operations, by key
+	Metadata map[string][]string `json:"metadata,omitempty"`
+	// Files are the files attached by the operations
+	Files []git.Hash `json:"files,omitempty"`
+
+	// Scores are the results of the registered scorers, see Scorer
+	Scores map[string]float64 `json:"scores,omitempty"`
 }
 
 func NewBugExcerpt(b bug.Interface, snap *bug.Snapshot) *BugExcerpt {
@@ -31,14 +57,114 @@ func NewBugExcerpt(b bug.Interface, snap *bug.Snapshot) *BugExcerpt {
 		EditLamportTime:   b.EditLamportTime(),
 		CreateUnixTime:    b.FirstOp().GetUnixTime(),
 		EditUnixTime:      snap.LastEditUnix(),
+		Title:             snap.Title,
 		Status:            snap.Status,
 		Author:            snap.Author,
 		Labels:            snap.Labels,
+		Votes:             len(snap.Votes),
+		Participants:      participants(snap),
+		Actors:            actors(snap),
+		Estimate:          snap.Estimate,
+		Visibility:        snap.Visibility,
+		FixedIn:           snap.FixedIn,
+		Assignee:          snap.Assignee,
+		CloseUnixTime:     closeUnixTime(snap),
 		CreateMetadata:    b.FirstOp().AllMetadata(),
+		Metadata:          opsMetadata(snap),
+		Files:             opsFiles(snap),
+	}
+}
+
+// opsFiles return the distinct files attached by the operations of a bug
+func opsFiles(snap *bug.Snapshot) []git.Hash {
+	var result []git.Hash
+	seen := make(map[git.Hash]bool)
+
+	for _, op := range snap.Operations {
+		for _, file := range op.GetFiles() {
+			if !seen[file] {
+				seen[file] = true
+				result = append(result, file)
+			}
+		}
+	}
+
+	return result
+}
+
+// participants return the distinct authors of the comments of a bug
+func participants(snap *bug.Snapshot) []bug.Person {
+	var result []bug.Person
+	for _, comment := range snap.Comments {
+		result = appendPerson(result, comment.Author)
+	}
+	return result
+}
+
+// actors return the distinct authors of the operations of a bug
+func actors(snap *bug.Snapshot) []bug.Person {
+	var result []bug.Person
+	for _, op := range snap.Operations {
+		result = appendPerson(result, op.GetAuthor())
 	}
+	return result
 }
 
-// Package initialisation used to register the type for (de)serialization
+// opsMetadata return the distinct values of the metadata of all the
+// operations of a bug, by key
+func opsMetadata(snap *bug.Snapshot) map[string][]string {
+	var result map[string][]string
+
+	for _, op := range snap.Operations {
+		for key, value := range op.AllMetadata() {
+			if result == nil {
+				result = make(map[string][]string)
+			}
+			if !containsString(result[key], value) {
+				result[key] = append(result[key], value)
+			}
+		}
+	}
+
+	return result
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// closeUnixTime return when a closed bug has been closed for the last time,
+// or zero if the bug is open
+func closeUnixTime(snap *bug.Snapshot) int64 {
+	if snap.Status != bug.ClosedStatus {
+		return 0
+	}
+
+	for i := len(snap.Operations) - 1; i >= 0; i-- {
+		if op, ok := snap.Operations[i].(*bug.SetStatusOperation); ok && op.Status == bug.ClosedStatus {
+			return op.UnixTime
+		}
+	}
+
+	return 0
+}
+
+func appendPerson(persons []bug.Person, person bug.Person) []bug.Person {
+	for _, p := range persons {
+		if p == person {
+			return persons
+		}
+	}
+	return append(persons, person)
+}
+
+// Package initialisation used to register the type for the deserialization
+// of the legacy gob cache
 func init() {
 	gob.Register(BugExcerpt{})
 }
@@ -116,3 +242,22 @@ func (b BugsByEditTime) Less(i, j int) bool {
 func (b BugsByEditTime) Swap(i, j int) {
 	b[i], b[j] = b[j], b[i]
 }
+
+type BugsByVotes []*BugExcerpt
+
+func (b BugsByVotes) Len() int {
+	return len(b)
+}
+
+func (b BugsByVotes) Less(i, j int) bool {
+	if b[i].Votes != b[j].Votes {
+		return b[i].Votes < b[j].Votes
+	}
+
+	// for the same amount of votes, the oldest bug come first
+	return BugsByCreationTime(b).Less(j, i)
+}
+
+func (b BugsByVotes) Swap(i, j int) {
+	b[i], b[j] = b[j], b[i]
+}
@@ -0,0 +1,300 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/pkg/errors"
+)
+
+// bundleVersion is the version of the format of the bundles, documented in
+// doc/bundle.md
+const bundleVersion = 1
+
+// bundleOriginMetadataKey is the metadata of the Create operation of an
+// imported bug holding the id of the bug in the exporting repository, to
+// recognize it on later imports
+const bundleOriginMetadataKey = "bundle-origin"
+
+// Bundle is a portable export of bugs as a JSON document, holding the
+// operations of the bugs as stored in git and the files they reference
+type Bundle struct {
+	Version int                 `json:"version"`
+	Bugs    []BundledBug        `json:"bugs"`
+	Media   map[git.Hash][]byte `json:"media,omitempty"`
+}
+
+// BundledBug is a bug in a Bundle
+type BundledBug struct {
+	Id         string          `json:"id"`
+	Operations []bug.Operation `json:"ops"`
+}
+
+func (b *BundledBug) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		Id         string            `json:"id"`
+		Operations []json.RawMessage `json:"ops"`
+	}{}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	b.Id = aux.Id
+	b.Operations = nil
+
+	for _, raw := range aux.Operations {
+		// the hash of the operation is not taken from the raw data, as the
+		// bundle might have been formatted differently
+		op, err := bug.UnmarshalOperation(raw)
+		if err != nil {
+			return errors.Wrapf(err, "bug %s", aux.Id)
+		}
+		b.Operations = append(b.Operations, op)
+	}
+
+	return nil
+}
+
+// BundleImportResult is the outcome of the import of a bundle
+type BundleImportResult struct {
+	// New are the ids of the bugs created
+	New []string
+	// Updated are the ids of the known bugs that received new operations
+	Updated []string
+	// Unchanged is the number of known bugs already up to date
+	Unchanged int
+}
+
+// ExportBundle export the given bugs, along with the files they reference
+func (c *RepoCache) ExportBundle(ids []string) (*Bundle, error) {
+	bundle := &Bundle{
+		Version: bundleVersion,
+		Bugs:    make([]BundledBug, 0, len(ids)),
+		Media:   make(map[git.Hash][]byte),
+	}
+
+	for _, id := range ids {
+		b, err := c.ResolveBug(id)
+		if err != nil {
+			return nil, err
+		}
+
+		ops := b.Snapshot().Operations
+
+		for _, op := range ops {
+			for _, file := range op.GetFiles() {
+				if _, ok := bundle.Media[file]; ok {
+					continue
+				}
+				data, err := c.repo.ReadData(file)
+				if err != nil {
+					return nil, errors.Wrapf(err, "bug %s: can't read file %s", b.HumanId(), file)
+				}
+				bundle.Media[file] = data
+			}
+		}
+
+		bundle.Bugs = append(bundle.Bugs, BundledBug{
+			Id:         id,
+			Operations: ops,
+		})
+	}
+
+	return bundle, nil
+}
+
+// ImportBundle import the bugs of a bundle. A bug already known, either
+// because it has the same id or because it's been imported before, only
+// receive the operations it doesn't have yet. The other bugs are created with
+// their operations unchanged, but a new id.
+func (c *RepoCache) ImportBundle(bundle *Bundle) (BundleImportResult, error) {
+	var result BundleImportResult
+
+	if err := c.checkWritable(); err != nil {
+		return result, err
+	}
+
+	if bundle.Version != bundleVersion {
+		return result, fmt.Errorf("unknown bundle version %d", bundle.Version)
+	}
+
+	for hash, data := range bundle.Media {
+		stored, err := c.repo.StoreData(data)
+		if err != nil {
+			return result, err
+		}
+		if stored != hash {
+			return result, fmt.Errorf("the content of the file %s doesn't match its hash", hash)
+		}
+	}
+
+	for _, bundled := range bundle.Bugs {
+		if len(bundled.Operations) == 0 {
+			return result, fmt.Errorf("bug %s has no operations", bundled.Id)
+		}
+
+		existing, err := c.resolveBundledBug(bundled)
+		if err != nil {
+			return result, errors.Wrapf(err, "bug %s", bundled.Id)
+		}
+
+		if existing == nil {
+			id, err := c.importBundledBug(bundled)
+			if err != nil {
+				return result, errors.Wrapf(err, "bug %s", bundled.Id)
+			}
+			result.New = append(result.New, id)
+			continue
+		}
+
+		updated, err := existing.appendMissingOps(bundled.Operations)
+		if err != nil {
+			return result, errors.Wrapf(err, "bug %s", bundled.Id)
+		}
+		if updated {
+			result.Updated = append(result.Updated, existing.Id())
+		} else {
+			result.Unchanged++
+		}
+	}
+
+	return result, nil
+}
+
+// resolveBundledBug find the local version of a bundled bug, if any
+func (c *RepoCache) resolveBundledBug(bundled BundledBug) (*BugCache, error) {
+	origins := []string{bundled.Id}
+
+	// a bug imported before keeps the id of its original version, even
+	// through several exports
+	b := bug.NewBug()
+	for _, op := range bundled.Operations {
+		b.Append(op)
+	}
+	b.Compile()
+	if origin, ok := b.FirstOp().GetMetadata(bundleOriginMetadataKey); ok {
+		origins = append(origins, origin)
+	}
+
+	for _, origin := range origins {
+		if c.hasBug(origin) {
+			return c.ResolveBug(origin)
+		}
+
+		existing, err := c.ResolveBugCreateMetadata(bundleOriginMetadataKey, origin)
+		if err == nil {
+			return existing, nil
+		}
+		if err != bug.ErrBugNotExist {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// importBundledBug create a new bug with the operations of a bundled bug
+func (c *RepoCache) importBundledBug(bundled BundledBug) (string, error) {
+	b := bug.NewBug()
+	for _, op := range bundled.Operations {
+		b.Append(op)
+	}
+
+	// the bugs imported before already carry the id of their original version
+	b.Compile()
+	first := b.FirstOp()
+	if _, ok := first.GetMetadata(bundleOriginMetadataKey); !ok {
+		target, err := first.Hash()
+		if err != nil {
+			return "", err
+		}
+
+		b.Append(bug.NewSetMetadataOp(first.GetAuthor(), first.GetUnixTime(), target, map[string]string{
+			bundleOriginMetadataKey: bundled.Id,
+		}))
+	}
+
+	if err := b.Validate(); err != nil {
+		return "", err
+	}
+
+	err := b.Commit(c.repo)
+	if err != nil {
+		return "", err
+	}
+
+	cached := NewBugCache(c, b)
+
+	c.muBug.Lock()
+	c.refHashes[b.Id()] = b.LastCommit()
+	c.muBug.Unlock()
+
+	err = c.bugUpdated(cached)
+	if err != nil {
+		return "", err
+	}
+
+	err = c.assignAliases()
+	if err != nil {
+		return "", err
+	}
+
+	return b.Id(), nil
+}
+
+// appendMissingOps append the operations the bug doesn't have yet, and commit
+// them. It return true if any operation was added.
+func (c *BugCache) appendMissingOps(ops []bug.Operation) (bool, error) {
+	known := make(map[git.Hash]bool)
+	for _, op := range c.Snapshot().Operations {
+		hash, err := op.Hash()
+		if err != nil {
+			return false, err
+		}
+		known[hash] = true
+	}
+
+	added := false
+
+	for _, op := range ops {
+		hash, err := op.Hash()
+		if err != nil {
+			return false, err
+		}
+		if known[hash] || isBundleOriginOp(op) {
+			continue
+		}
+		if _, ok := op.(*bug.CreateOperation); ok {
+			return false, fmt.Errorf("the bundled bug doesn't match the local one")
+		}
+
+		c.append(op)
+		known[hash] = true
+		added = true
+	}
+
+	if !added {
+		return false, nil
+	}
+
+	err := c.notifyUpdated()
+	if err != nil {
+		return false, err
+	}
+
+	return true, c.Commit()
+}
+
+// isBundleOriginOp tell if an operation only record the origin of an imported
+// bug, which is pointless on the other versions of the bug
+func isBundleOriginOp(op bug.Operation) bool {
+	setMetadata, ok := op.(*bug.SetMetadataOperation)
+	if !ok || len(setMetadata.NewMetadata) != 1 {
+		return false
+	}
+	_, ok = setMetadata.NewMetadata[bundleOriginMetadataKey]
+	return ok
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTrip serialize and parse back a bundle, as done between the export
+// and the import
+func roundTrip(t *testing.T, bundle *Bundle) *Bundle {
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	require.NoError(t, err)
+
+	var result Bundle
+	require.NoError(t, json.Unmarshal(data, &result))
+	return &result
+}
+
+func TestBundle(t *testing.T) {
+	repoA := createTestRepo(t)
+	defer os.RemoveAll(repoA.GetPath())
+	repoB := createTestRepo(t)
+	defer os.RemoveAll(repoB.GetPath())
+
+	a, err := NewRepoCache(repoA)
+	require.NoError(t, err)
+	defer a.Close()
+
+	b, err := NewRepoCache(repoB)
+	require.NoError(t, err)
+	defer b.Close()
+
+	file, err := repoA.StoreData([]byte("a screenshot"))
+	require.NoError(t, err)
+
+	bugA, err := a.NewBugWithFiles("title", "message", []git.Hash{file})
+	require.NoError(t, err)
+	target, err := bugA.Snapshot().Operations[0].Hash()
+	require.NoError(t, err)
+	require.NoError(t, bugA.EditComment(target, "edited message"))
+	require.NoError(t, bugA.Commit())
+
+	bundle, err := a.ExportBundle(a.AllBugsIds())
+	require.NoError(t, err)
+	assert.Len(t, bundle.Media, 1)
+
+	result, err := b.ImportBundle(roundTrip(t, bundle))
+	require.NoError(t, err)
+	require.Len(t, result.New, 1)
+
+	bugB, err := b.ResolveBug(result.New[0])
+	require.NoError(t, err)
+	assert.Equal(t, "edited message", bugB.Snapshot().Comments[0].Message)
+	assert.Equal(t, []git.Hash{file}, bugB.Snapshot().Operations[0].GetFiles())
+
+	data, err := repoB.ReadData(file)
+	require.NoError(t, err)
+	assert.Equal(t, "a screenshot", string(data))
+
+	// importing again doesn't duplicate the bug
+	result, err = b.ImportBundle(roundTrip(t, bundle))
+	require.NoError(t, err)
+	assert.Equal(t, BundleImportResult{Unchanged: 1}, result)
+
+	// only the new operations are added
+	require.NoError(t, bugA.AddComment("new comment"))
+	require.NoError(t, bugA.Commit())
+
+	bundle, err = a.ExportBundle(a.AllBugsIds())
+	require.NoError(t, err)
+
+	result, err = b.ImportBundle(roundTrip(t, bundle))
+	require.NoError(t, err)
+	assert.Equal(t, BundleImportResult{Updated: []string{bugB.Id()}}, result)
+	assert.Len(t, bugB.Snapshot().Comments, 2)
+
+	// the bug is recognized on the way back
+	bundle, err = b.ExportBundle(b.AllBugsIds())
+	require.NoError(t, err)
+
+	result, err = a.ImportBundle(roundTrip(t, bundle))
+	require.NoError(t, err)
+	assert.Equal(t, BundleImportResult{Unchanged: 1}, result)
+	assert.Len(t, a.AllBugsIds(), 1)
+}
+
+func TestBundleVersion(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.ImportBundle(&Bundle{Version: 42})
+	assert.Error(t, err)
+}
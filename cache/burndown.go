@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// BurndownPoint is the remaining estimate of a milestone at a given time
+type BurndownPoint struct {
+	Time      time.Time
+	Remaining float64
+	Open      int
+}
+
+// Burndown is the evolution of the remaining estimate of a milestone
+type Burndown struct {
+	Milestone string
+	// Bugs is the number of bugs in the milestone
+	Bugs int
+	// Unestimated is the number of bugs without estimate
+	Unestimated int
+	// Points has one entry per day, from the creation of the first bug of the
+	// milestone to the given end time
+	Points []BurndownPoint
+}
+
+// Burndown compute the burndown of a milestone, that is, the bugs with the
+// given label.
+//
+// For each day, the remaining estimate is the sum of the estimates of the
+// bugs created and still open at the end of this day, according to the
+// status changes. Note that the current labels and estimates are used for
+// the whole period.
+func (c *RepoCache) Burndown(milestone string, end time.Time) (*Burndown, error) {
+	result := &Burndown{Milestone: milestone}
+
+	filter := LabelFilter(milestone)
+
+	var ids []string
+
+	c.muBug.RLock()
+	for id, excerpt := range c.excerpts {
+		if filter(excerpt) {
+			ids = append(ids, id)
+		}
+	}
+	c.muBug.RUnlock()
+
+	var snapshots []*bug.Snapshot
+
+	for _, id := range ids {
+		b, err := c.ResolveBug(id)
+		if err != nil {
+			return nil, err
+		}
+
+		snap := b.Snapshot()
+		snapshots = append(snapshots, snap)
+
+		if snap.Estimate == 0 {
+			result.Unestimated++
+		}
+	}
+
+	result.Bugs = len(snapshots)
+
+	if len(snapshots) == 0 {
+		return result, nil
+	}
+
+	start := end
+	for _, snap := range snapshots {
+		if snap.CreatedAt.Before(start) {
+			start = snap.CreatedAt
+		}
+	}
+
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		dayEnd := day.AddDate(0, 0, 1)
+		if dayEnd.After(end) {
+			dayEnd = end
+		}
+
+		point := BurndownPoint{Time: dayEnd}
+
+		for _, snap := range snapshots {
+			if snap.CreatedAt.After(dayEnd) {
+				continue
+			}
+
+			if statusAt(snap, dayEnd) == bug.OpenStatus {
+				point.Remaining += snap.Estimate
+				point.Open++
+			}
+		}
+
+		result.Points = append(result.Points, point)
+	}
+
+	return result, nil
+}
+
+// statusAt return the status of a bug at the given time
+func statusAt(snap *bug.Snapshot, t time.Time) bug.Status {
+	status := bug.OpenStatus
+
+	for _, op := range snap.Operations {
+		setStatus, ok := op.(*bug.SetStatusOperation)
+		if !ok {
+			continue
+		}
+
+		if op.Time().After(t) {
+			continue
+		}
+
+		status = setStatus.Status
+	}
+
+	return status
+}
@@ -0,0 +1,385 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+// The cache file is made of JSON lines. The first line is a header holding
+// the version of the format, each of the following lines is a record with a
+// kind and its data:
+//
+//	{"kind":"header","version":1}
+//	{"kind":"bug","id":"...","hash":"...","excerpt":{...}}
+//	{"kind":"identity","id":"...","excerpt":{...}}
+//
+// This format is forward compatible: unknown fields and unknown kinds of
+// records are ignored, and a file with a newer version is read as well. It's
+// also resilient: a record that can't be read is skipped and the
+// corresponding bug is compiled again, instead of throwing away the whole
+// cache.
+//
+// The version of the header is only increased for changes of the layout, the
+// older files being then built again. The data added to the excerpts doesn't
+// change the layout: it's tracked by the version of each bug record instead,
+// so that only the bugs having an older excerpt are compiled again.
+const cacheFile = "cache.jsonl"
+const formatVersion = 1
+
+// excerptVersion is the version of the data of the bug excerpts, recorded in
+// each bug record.
+//
+// Version history:
+// 1: initial version
+// 2: participants and actors in the excerpts
+// 3: titles and scores in the excerpts
+// 4: close time in the excerpts
+// 5: metadata of all the operations in the excerpts
+// 6: assignee in the excerpts
+// 7: attached files in the excerpts
+const excerptVersion = 7
+
+// legacyCacheFile is the gob encoded cache file used before the JSON lines
+// format. It is migrated on the first load.
+const legacyCacheFile = "cache"
+const legacyFormatVersion = 2
+
+// maxRecordSize is the maximum size of a single line of the cache file
+const maxRecordSize = 16 * 1024 * 1024
+
+const (
+	recordKindHeader   = "header"
+	recordKindBug      = "bug"
+	recordKindIdentity = "identity"
+)
+
+// cacheRecord is a line of the cache file. The data is decoded separately
+// according to the kind, so that a broken record doesn't prevent reading the
+// others.
+type cacheRecord struct {
+	Kind    string          `json:"kind"`
+	Version uint            `json:"version,omitempty"`
+	Id      string          `json:"id,omitempty"`
+	Hash    git.Hash        `json:"hash,omitempty"`
+	Excerpt json.RawMessage `json:"excerpt,omitempty"`
+}
+
+func cacheFilePath(repo repository.Repo) string {
+	return path.Join(repo.GetGitDir(), "git-bug", cacheFile)
+}
+
+func legacyCacheFilePath(repo repository.Repo) string {
+	return path.Join(repo.GetGitDir(), "git-bug", legacyCacheFile)
+}
+
+// cacheContent is the data read from a cache file
+type cacheContent struct {
+	excerpts   map[string]*BugExcerpt
+	refHashes  map[string]git.Hash
+	identities map[string]*IdentityExcerpt
+}
+
+// load will try to read from the disk the bug cache file, migrating the
+// legacy file if needed
+func (c *RepoCache) load() error {
+	content, err := readCacheFile(cacheFilePath(c.repo))
+	if os.IsNotExist(err) {
+		return c.migrateLegacy()
+	}
+	if err != nil {
+		return err
+	}
+
+	c.muBug.Lock()
+	c.refHashes = content.refHashes
+	c.identities = content.identities
+	// also index the identities of a cache written by an older version
+	c.resetExcerpts(content.excerpts)
+	c.muBug.Unlock()
+
+	return nil
+}
+
+// readCacheFile read and decode a cache file
+func readCacheFile(filePath string) (*cacheContent, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+
+	if !scanner.Scan() {
+		if scanner.Err() != nil {
+			return nil, scanner.Err()
+		}
+		return nil, fmt.Errorf("empty cache file")
+	}
+
+	var header cacheRecord
+	err = json.Unmarshal(scanner.Bytes(), &header)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache header: %v", err)
+	}
+	if header.Kind != recordKindHeader {
+		return nil, fmt.Errorf("missing cache header")
+	}
+	if header.Version < formatVersion {
+		return nil, fmt.Errorf("unknown cache format version %v", header.Version)
+	}
+
+	content := &cacheContent{
+		excerpts:   make(map[string]*BugExcerpt),
+		refHashes:  make(map[string]git.Hash),
+		identities: make(map[string]*IdentityExcerpt),
+	}
+
+	for scanner.Scan() {
+		var record cacheRecord
+		err := json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			// likely a truncated write, the bug will be compiled again
+			continue
+		}
+
+		switch record.Kind {
+		case recordKindBug:
+			// an older excerpt miss some data, the bug is compiled again
+			if record.Version < excerptVersion {
+				continue
+			}
+
+			var excerpt BugExcerpt
+			err := json.Unmarshal(record.Excerpt, &excerpt)
+			if err != nil || record.Id == "" {
+				continue
+			}
+			content.excerpts[record.Id] = &excerpt
+			content.refHashes[record.Id] = record.Hash
+
+		case recordKindIdentity:
+			var identity IdentityExcerpt
+			err := json.Unmarshal(record.Excerpt, &identity)
+			if err != nil || record.Id == "" {
+				continue
+			}
+			content.identities[record.Id] = &identity
+
+		default:
+			// written by a newer version, ignore
+		}
+	}
+
+	// a line too long stop the reading, the remaining bugs are simply
+	// compiled again
+	if err := scanner.Err(); err != nil && err != bufio.ErrTooLong {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// migrateLegacy read the legacy gob cache file, if any, and convert it to
+// the current format
+func (c *RepoCache) migrateLegacy() error {
+	legacyPath := legacyCacheFilePath(c.repo)
+
+	f, err := os.Open(legacyPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	aux := struct {
+		Version   uint
+		Excerpts  map[string]*BugExcerpt
+		RefHashes map[string]git.Hash
+	}{}
+
+	err = gob.NewDecoder(f).Decode(&aux)
+	if err != nil {
+		// a truncated file can't be recovered, it's replaced by a new cache
+		if !c.readOnly {
+			_ = os.Remove(legacyPath)
+		}
+		return fmt.Errorf("corrupted legacy cache file: %v", err)
+	}
+
+	if aux.Version < 1 || aux.Version > legacyFormatVersion {
+		return fmt.Errorf("unknown legacy cache format version %v", aux.Version)
+	}
+
+	// the first version didn't record the ref hashes, the bugs will simply
+	// be compiled again
+	if aux.RefHashes == nil {
+		aux.RefHashes = make(map[string]git.Hash)
+	}
+
+	c.muBug.Lock()
+	c.refHashes = aux.RefHashes
+	c.resetExcerpts(aux.Excerpts)
+	c.muBug.Unlock()
+
+	if c.readOnly {
+		return nil
+	}
+
+	err = c.write()
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(legacyPath)
+}
+
+// write will serialize on disk the bug cache file. The file is replaced
+// atomically to never leave a half written cache behind. Nothing is written
+// if the cache is read-only.
+func (c *RepoCache) write() error {
+	if c.readOnly {
+		return nil
+	}
+
+	// exclusive lock, to avoid concurrent writes of the file
+	c.muBug.Lock()
+	defer c.muBug.Unlock()
+
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+
+	err := encoder.Encode(cacheRecord{
+		Kind:    recordKindHeader,
+		Version: formatVersion,
+	})
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(c.excerpts))
+	for id := range c.excerpts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		excerpt, err := json.Marshal(c.excerpts[id])
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(cacheRecord{
+			Kind:    recordKindBug,
+			Version: excerptVersion,
+			Id:      id,
+			Hash:    c.refHashes[id],
+			Excerpt: excerpt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	identityIds := make([]string, 0, len(c.identities))
+	for id := range c.identities {
+		identityIds = append(identityIds, id)
+	}
+	sort.Strings(identityIds)
+
+	for _, id := range identityIds {
+		identity, err := json.Marshal(c.identities[id])
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(cacheRecord{
+			Kind:    recordKindIdentity,
+			Id:      id,
+			Excerpt: identity,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	filePath := cacheFilePath(c.repo)
+	tmpPath := filePath + ".tmp"
+
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(data.Bytes())
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, filePath)
+}
+
+// Rebuild discard the cache and build it again from the bugs of the
+// repository. The operations not committed yet are lost.
+func (c *RepoCache) Rebuild() error {
+	c.ClearAllBugs()
+
+	err := c.buildCache()
+	if err != nil {
+		return err
+	}
+
+	err = c.assignAliases()
+	if err != nil {
+		return err
+	}
+
+	return c.write()
+}
+
+// CacheInfo describe the cache file of a repository
+type CacheInfo struct {
+	Path          string
+	FormatVersion uint
+	Bugs          int
+	Identities    int
+	Size          int64
+}
+
+// CacheInfo return a description of the cache file
+func (c *RepoCache) CacheInfo() (CacheInfo, error) {
+	filePath := cacheFilePath(c.repo)
+
+	stat, err := os.Stat(filePath)
+	if err != nil {
+		return CacheInfo{}, err
+	}
+
+	c.muBug.RLock()
+	bugs := len(c.excerpts)
+	identities := len(c.identities)
+	c.muBug.RUnlock()
+
+	return CacheInfo{
+		Path:          filePath,
+		FormatVersion: formatVersion,
+		Bugs:          bugs,
+		Identities:    identities,
+		Size:          stat.Size(),
+	}, nil
+}
@@ -0,0 +1,259 @@
+package cache
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheFileRoundTrip(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+
+	b, err := c.NewBug("title", "message")
+	assert.NoError(t, err)
+	_, err = b.ChangeLabels([]string{"label"}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, b.Commit())
+
+	expected := c.excerpts[b.Id()]
+	assert.NoError(t, c.Close())
+
+	c, err = NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	assert.Equal(t, expected, c.excerpts[b.Id()])
+	assert.Equal(t, b.bug.LastCommit(), c.refHashes[b.Id()])
+}
+
+func TestCacheFilePartialRead(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+
+	b1, err := c.NewBug("title 1", "message")
+	assert.NoError(t, err)
+	b2, err := c.NewBug("title 2", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, c.Close())
+
+	// break the record of the first bug and add records from the future
+	data, err := ioutil.ReadFile(cacheFilePath(repo))
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	for i, line := range lines {
+		if strings.Contains(line, b1.Id()) {
+			lines[i] = line[:len(line)/2]
+		}
+	}
+	lines = append(lines, `{"kind":"future","data":42}`)
+
+	err = ioutil.WriteFile(cacheFilePath(repo), []byte(strings.Join(lines, "\n")+"\n"), 0644)
+	assert.NoError(t, err)
+
+	c, err = NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	// the broken record has been compiled again
+	assert.Len(t, c.AllBugsIds(), 2)
+	assert.Equal(t, b1.Id(), c.excerpts[b1.Id()].Id)
+	assert.Equal(t, b2.Id(), c.excerpts[b2.Id()].Id)
+}
+
+func TestCacheFileVersions(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+
+	b1, err := c.NewBug("title 1", "message")
+	assert.NoError(t, err)
+	b2, err := c.NewBug("title 2", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, c.Close())
+
+	// a header from a newer version, and an excerpt of the first bug from an
+	// older version missing the attached files
+	data, err := ioutil.ReadFile(cacheFilePath(repo))
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	lines[0] = `{"kind":"header","version":42}`
+	for i, line := range lines {
+		if strings.Contains(line, b1.Id()) {
+			lines[i] = strings.Replace(line, fmt.Sprintf(`"version":%d,`, excerptVersion), "", 1)
+		}
+	}
+
+	err = ioutil.WriteFile(cacheFilePath(repo), []byte(strings.Join(lines, "\n")+"\n"), 0644)
+	assert.NoError(t, err)
+
+	content, err := readCacheFile(cacheFilePath(repo))
+	assert.NoError(t, err)
+	assert.NotContains(t, content.excerpts, b1.Id())
+	assert.Contains(t, content.excerpts, b2.Id())
+
+	// the older excerpt is compiled again
+	c, err = NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	assert.Len(t, c.AllBugsIds(), 2)
+	assert.Equal(t, b1.Id(), c.excerpts[b1.Id()].Id)
+}
+
+func TestCacheFileMigration(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+
+	b, err := c.NewBug("title", "message")
+	assert.NoError(t, err)
+
+	// write the cache in the legacy format instead
+	aux := struct {
+		Version   uint
+		Excerpts  map[string]*BugExcerpt
+		RefHashes map[string]git.Hash
+	}{
+		Version:   legacyFormatVersion,
+		Excerpts:  c.excerpts,
+		RefHashes: c.refHashes,
+	}
+	expected := c.excerpts[b.Id()]
+	assert.NoError(t, c.Close())
+
+	f, err := os.Create(legacyCacheFilePath(repo))
+	assert.NoError(t, err)
+	assert.NoError(t, gob.NewEncoder(f).Encode(aux))
+	assert.NoError(t, f.Close())
+	assert.NoError(t, os.Remove(cacheFilePath(repo)))
+
+	c, err = NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	assert.Equal(t, expected, c.excerpts[b.Id()])
+
+	_, err = os.Stat(legacyCacheFilePath(repo))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(cacheFilePath(repo))
+	assert.NoError(t, err)
+}
+
+func TestCacheRebuild(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	b, err := c.NewBug("title", "message")
+	assert.NoError(t, err)
+
+	// corrupt the excerpt in memory
+	c.excerpts[b.Id()].Labels = nil
+	c.excerpts["unknown"] = &BugExcerpt{Id: "unknown"}
+
+	assert.NoError(t, c.Rebuild())
+	assert.Equal(t, []string{b.Id()}, c.AllBugsIds())
+
+	info, err := c.CacheInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, info.Bugs)
+}
+
+func TestCacheFileCorruptedLegacy(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	_, err = c.NewBug("title", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, c.Close())
+
+	// a truncated legacy file
+	assert.NoError(t, os.Remove(cacheFilePath(repo)))
+	assert.NoError(t, ioutil.WriteFile(legacyCacheFilePath(repo), []byte{0x42, 0x13}, 0644))
+
+	c, err = NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	// the cache is rebuilt and the broken file discarded
+	assert.Len(t, c.AllBugsIds(), 1)
+	_, err = os.Stat(legacyCacheFilePath(repo))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCacheVerify(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	b1, err := c.NewBug("title 1", "message")
+	assert.NoError(t, err)
+	b2, err := c.NewBug("title 2", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, c.write())
+
+	report, err := c.VerifyCache()
+	assert.NoError(t, err)
+	assert.True(t, report.Clean())
+	assert.Equal(t, 2, report.Checked)
+
+	// a wrong excerpt with the right hash, a missing bug and an unknown bug
+	c.excerpts[b1.Id()].Votes = 42
+	delete(c.excerpts, b2.Id())
+	c.excerpts["unknown"] = &BugExcerpt{Id: "unknown"}
+	assert.NoError(t, c.write())
+
+	report, err = c.VerifyCache()
+	assert.NoError(t, err)
+	assert.False(t, report.Clean())
+	assert.Equal(t, []string{b1.Id()}, report.Stale)
+	assert.Equal(t, []string{b2.Id()}, report.Missing)
+	assert.Equal(t, []string{"unknown"}, report.Orphaned)
+
+	assert.NoError(t, c.RepairCache(report))
+	assert.Equal(t, 0, c.excerpts[b1.Id()].Votes)
+
+	report, err = c.VerifyCache()
+	assert.NoError(t, err)
+	assert.True(t, report.Clean())
+
+	// an unreadable file is rebuilt
+	assert.NoError(t, ioutil.WriteFile(cacheFilePath(repo), []byte("{\"kind\":"), 0644))
+
+	report, err = c.VerifyCache()
+	assert.NoError(t, err)
+	assert.Error(t, report.Corrupted)
+
+	assert.NoError(t, c.RepairCache(report))
+
+	report, err = c.VerifyCache()
+	assert.NoError(t, err)
+	assert.True(t, report.Clean())
+}
@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// Contribution is the activity of a person on the bugs during a period
+type Contribution struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+	Login string `json:"login,omitempty"`
+	// Opened is the number of bugs created
+	Opened int `json:"opened"`
+	// Closed is the number of times a bug has been closed
+	Closed int `json:"closed"`
+	// Comments is the number of comments added, not counting the messages
+	// of the bugs created
+	Comments int `json:"comments"`
+	// Operations is the number of operations of any kind
+	Operations int `json:"operations"`
+}
+
+// DisplayName return a non-empty string to display, representing the person
+func (c Contribution) DisplayName() string {
+	return bug.Person{Name: c.Name, Email: c.Email, Login: c.Login}.DisplayName()
+}
+
+// Contributions compute the activity of each person during a period, from
+// since (included) to until (excluded). A zero time leave the period open on
+// this side. The contributions are sorted by number of operations, the most
+// active first.
+func (c *RepoCache) Contributions(since time.Time, until time.Time) ([]Contribution, error) {
+	var ids []string
+
+	// the bugs not edited since the start of the period have nothing in it
+	c.muBug.RLock()
+	for id, excerpt := range c.excerpts {
+		if since.IsZero() || excerpt.EditUnixTime >= since.Unix() {
+			ids = append(ids, id)
+		}
+	}
+	c.muBug.RUnlock()
+
+	byPerson := make(map[string]*Contribution)
+
+	for _, id := range ids {
+		b, err := c.ResolveBug(id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, op := range b.Snapshot().Operations {
+			t := op.Time()
+			if (!since.IsZero() && t.Before(since)) || (!until.IsZero() && !t.Before(until)) {
+				continue
+			}
+
+			author := op.GetAuthor()
+			key := identityId(author)
+			contribution, ok := byPerson[key]
+			if !ok {
+				contribution = &Contribution{
+					Name:  author.Name,
+					Email: author.Email,
+					Login: author.Login,
+				}
+				byPerson[key] = contribution
+			}
+
+			contribution.Operations++
+
+			switch op := op.(type) {
+			case *bug.CreateOperation:
+				contribution.Opened++
+			case *bug.AddCommentOperation:
+				contribution.Comments++
+			case *bug.SetStatusOperation:
+				if op.Status == bug.ClosedStatus {
+					contribution.Closed++
+				}
+			}
+		}
+	}
+
+	result := make([]Contribution, 0, len(byPerson))
+	for _, contribution := range byPerson {
+		result = append(result, *contribution)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Operations != result[j].Operations {
+			return result[i].Operations > result[j].Operations
+		}
+		return strings.ToLower(result[i].DisplayName()) < strings.ToLower(result[j].DisplayName())
+	})
+
+	return result, nil
+}
+
+// ParseDate parse a date of the query language: a day, a time or a
+// duration before now, like "2018-09-01", "2018-09-01T15:04:05Z" or "30d"
+func ParseDate(value string) (time.Time, error) {
+	return parseQueryDate(value, time.Now())
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheContributions(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	author, err := bug.GetUser(repo)
+	assert.NoError(t, err)
+	other := bug.Person{Name: "other", Email: "other@example.com"}
+
+	now := time.Now()
+	day := 24 * time.Hour
+
+	b1, err := c.NewBugRaw(author, now.Add(-10*day), "first", "message", nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, b1.AddCommentRaw(other, now.Add(-2*day), "comment", nil, nil))
+	assert.NoError(t, b1.CloseRaw(author, now.Add(-day), nil))
+	assert.NoError(t, b1.Commit())
+
+	b2, err := c.NewBugRaw(other, now.Add(-day), "second", "message", nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, b2.AddCommentRaw(other, now, "comment", nil, nil))
+	assert.NoError(t, b2.Commit())
+
+	all, err := c.Contributions(time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Contribution{
+		{Name: "other", Email: "other@example.com", Opened: 1, Comments: 2, Operations: 3},
+		{Name: author.Name, Email: author.Email, Opened: 1, Closed: 1, Operations: 2},
+	}, all)
+
+	// the creation of the first bug is before the period, the last comment
+	// after
+	since := now.Add(-5 * day)
+	until := now.Add(-day / 2)
+	period, err := c.Contributions(since, until)
+	assert.NoError(t, err)
+	assert.Equal(t, []Contribution{
+		{Name: "other", Email: "other@example.com", Opened: 1, Comments: 1, Operations: 2},
+		{Name: author.Name, Email: author.Email, Closed: 1, Operations: 1},
+	}, period)
+}
@@ -0,0 +1,318 @@
+package cache
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/process"
+)
+
+// minGitVersion is the oldest version of git supported, the first one with
+// "git verify-commit"
+var minGitVersion = []int{2, 1, 0}
+
+// Diagnostic is a problem found in the configuration of a repository
+type Diagnostic struct {
+	// Check is the name of the check that found the problem
+	Check string
+	// Problem describe what is wrong
+	Problem string
+	// Advice tell how to fix the problem by hand, when it can't be fixed
+	// automatically
+	Advice string
+	// Fix repair the problem, nil if it can't be done automatically
+	Fix func() error
+	// FixDescription describe what Fix does
+	FixDescription string
+}
+
+// Diagnose check a repository for common misconfigurations. It works on the
+// repository directly, as some of these problems prevent the cache from
+// loading.
+func Diagnose(repo repository.Repo) ([]Diagnostic, error) {
+	var result []Diagnostic
+
+	for _, check := range []func(repository.Repo) ([]Diagnostic, error){
+		checkGitVersion,
+		checkIdentity,
+		checkRefSpecs,
+		checkLock,
+		checkCacheVersion,
+		checkLockFilesystem,
+	} {
+		diagnostics, err := check(repo)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, diagnostics...)
+	}
+
+	return result, nil
+}
+
+// DiagnoseStartup run the checks cheap enough to be done on every start, for
+// the problems likely to cause errors that are hard to understand
+func DiagnoseStartup(repo repository.Repo) ([]Diagnostic, error) {
+	var result []Diagnostic
+
+	for _, check := range []func(repository.Repo) ([]Diagnostic, error){
+		checkGitVersion,
+		checkLockFilesystem,
+	} {
+		diagnostics, err := check(repo)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, diagnostics...)
+	}
+
+	return result, nil
+}
+
+func checkGitVersion(repo repository.Repo) ([]Diagnostic, error) {
+	version, err := repository.GitVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	if !versionAtLeast(version, minGitVersion) {
+		return []Diagnostic{{
+			Check:   "git",
+			Problem: fmt.Sprintf("git %s is too old, some features won't work", version),
+			Advice:  fmt.Sprintf("upgrade git to %s or later", formatVersionNumbers(minGitVersion)),
+		}}, nil
+	}
+
+	return nil, nil
+}
+
+// versionAtLeast compare a version like "2.20.1" or "2.20.1.windows.1" with
+// a minimum. A version that can't be parsed is assumed recent enough.
+func versionAtLeast(version string, min []int) bool {
+	parts := strings.Split(version, ".")
+
+	for i, m := range min {
+		if i >= len(parts) {
+			return m == 0
+		}
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return true
+		}
+		if n != m {
+			return n > m
+		}
+	}
+
+	return true
+}
+
+func formatVersionNumbers(version []int) string {
+	parts := make([]string, len(version))
+	for i, n := range version {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ".")
+}
+
+func checkIdentity(repo repository.Repo) ([]Diagnostic, error) {
+	var result []Diagnostic
+
+	name, err := repo.GetUserName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		result = append(result, Diagnostic{
+			Check:   "identity",
+			Problem: "no user name configured, new bugs and comments can't be authored",
+			Advice:  `git config --global user.name "John Doe"`,
+		})
+	}
+
+	email, err := repo.GetUserEmail()
+	if err != nil {
+		return nil, err
+	}
+	if email == "" {
+		result = append(result, Diagnostic{
+			Check:   "identity",
+			Problem: "no user email configured, new bugs and comments can't be authored",
+			Advice:  "git config --global user.email johndoe@example.com",
+		})
+	}
+
+	return result, nil
+}
+
+func checkRefSpecs(repo repository.Repo) ([]Diagnostic, error) {
+	remoteRepo, ok := repo.(repository.RemoteRepo)
+	if !ok {
+		return nil, nil
+	}
+
+	remotes, err := remoteRepo.ListRemotes()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Diagnostic
+
+	for _, remote := range remotes {
+		missing, err := bug.MissingFetchRefSpecs(remoteRepo, remote)
+		if err != nil {
+			return nil, err
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		remote := remote
+		result = append(result, Diagnostic{
+			Check:          "refspec",
+			Problem:        fmt.Sprintf("git fetch doesn't retrieve the bugs of the remote %s", remote),
+			Advice:         fmt.Sprintf("git bug remote setup %s", remote),
+			FixDescription: fmt.Sprintf("add the refspecs %s to the remote", strings.Join(missing, ", ")),
+			Fix: func() error {
+				_, err := bug.AddFetchRefSpecs(remoteRepo, remote)
+				return err
+			},
+		})
+	}
+
+	return result, nil
+}
+
+func checkLock(repo repository.Repo) ([]Diagnostic, error) {
+	lockPath := repoLockFilePath(repo)
+
+	data, err := ioutil.ReadFile(lockPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err == nil && process.IsRunning(pid) {
+		return []Diagnostic{{
+			Check:   "lock",
+			Problem: fmt.Sprintf("the repository is locked by the process pid %d", pid),
+			Advice:  "wait for this process to finish, or stop it",
+		}}, nil
+	}
+
+	return []Diagnostic{{
+		Check:          "lock",
+		Problem:        "a lock file is left by a process that is not running anymore",
+		FixDescription: "remove the lock file",
+		Fix: func() error {
+			return os.Remove(lockPath)
+		},
+	}}, nil
+}
+
+func checkCacheVersion(repo repository.Repo) ([]Diagnostic, error) {
+	removeCache := func() error {
+		for _, filePath := range []string{cacheFilePath(repo), legacyCacheFilePath(repo)} {
+			err := os.Remove(filePath)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if _, err := os.Stat(legacyCacheFilePath(repo)); err == nil {
+		return []Diagnostic{{
+			Check:          "cache",
+			Problem:        "the cache is in a format from an older version of git-bug",
+			FixDescription: "remove the cache, to build it again on the next use",
+			Fix:            removeCache,
+		}}, nil
+	}
+
+	f, err := os.Open(cacheFilePath(repo))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var header cacheRecord
+	scanner := bufio.NewScanner(f)
+	if scanner.Scan() {
+		err = json.Unmarshal(scanner.Bytes(), &header)
+	}
+
+	switch {
+	case err != nil || header.Kind != recordKindHeader:
+		return []Diagnostic{{
+			Check:          "cache",
+			Problem:        "the cache file is corrupted",
+			FixDescription: "remove the cache, to build it again on the next use",
+			Fix:            removeCache,
+		}}, nil
+	case header.Version < formatVersion:
+		return []Diagnostic{{
+			Check:          "cache",
+			Problem:        fmt.Sprintf("the cache is in the format version %d, from an older version of git-bug", header.Version),
+			FixDescription: "remove the cache, to build it again on the next use",
+			Fix:            removeCache,
+		}}, nil
+	}
+
+	return nil, nil
+}
+
+func checkLockFilesystem(repo repository.Repo) ([]Diagnostic, error) {
+	dir := path.Dir(repoLockFilePath(repo))
+
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		// nothing stored by git-bug yet
+		return nil, nil
+	}
+
+	// the lock relies on the exclusive creation of a file
+	probe := path.Join(dir, "lock-probe")
+	f, err := os.OpenFile(probe, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return []Diagnostic{{
+			Check:   "filesystem",
+			Problem: fmt.Sprintf("can't create files in %s: %v", dir, err),
+			Advice:  "check the permissions of this directory",
+		}}, nil
+	}
+	_ = f.Close()
+	defer os.Remove(probe)
+
+	_, err = os.OpenFile(probe, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+	if !os.IsExist(err) {
+		return []Diagnostic{{
+			Check:   "filesystem",
+			Problem: "the filesystem doesn't support the exclusive creation of files, concurrent uses of git-bug might corrupt the cache",
+			Advice:  "move the repository to a local filesystem",
+		}}, nil
+	}
+
+	if fsType := networkFilesystem(dir); fsType != "" {
+		return []Diagnostic{{
+			Check:   "filesystem",
+			Problem: fmt.Sprintf("the repository is on a network filesystem (%s), the lock can't see the uses of git-bug on other computers", fsType),
+			Advice:  "only use git-bug on this repository from one computer at a time",
+		}}, nil
+	}
+
+	return nil, nil
+}
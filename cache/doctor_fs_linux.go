@@ -0,0 +1,26 @@
+package cache
+
+import (
+	"syscall"
+)
+
+// networkFilesystems are the magic numbers of the network filesystems, as
+// found in statfs(2)
+var networkFilesystems = map[uint32]string{
+	0x6969:     "nfs",
+	0x517b:     "smb",
+	0xff534d42: "cifs",
+	0xfe534d42: "smb2",
+	0x564c:     "ncp",
+	0x5346414f: "afs",
+}
+
+// networkFilesystem return the type of the filesystem of a directory if it's
+// a network one, or an empty string
+func networkFilesystem(dir string) string {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return ""
+	}
+	return networkFilesystems[uint32(stat.Type)]
+}
@@ -0,0 +1,9 @@
+// +build !linux
+
+package cache
+
+// networkFilesystem return the type of the filesystem of a directory if it's
+// a network one, or an empty string. It's only detected on Linux.
+func networkFilesystem(dir string) string {
+	return ""
+}
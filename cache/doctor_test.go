@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findDiagnostic(diagnostics []Diagnostic, check string) *Diagnostic {
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Check == check {
+			return &diagnostic
+		}
+	}
+	return nil
+}
+
+func TestDiagnose(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	// build the cache
+	c, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	require.NoError(t, c.Close())
+
+	diagnostics, err := Diagnose(repo)
+	require.NoError(t, err)
+	assert.Nil(t, findDiagnostic(diagnostics, "refspec"))
+	assert.Nil(t, findDiagnostic(diagnostics, "lock"))
+	assert.Nil(t, findDiagnostic(diagnostics, "cache"))
+	assert.Nil(t, findDiagnostic(diagnostics, "filesystem"))
+
+	// a remote without the refspecs of the bugs
+	require.NoError(t, repo.AddRemote("origin", "https://example.com/repo.git"))
+
+	// a lock left by a crash
+	require.NoError(t, ioutil.WriteFile(repoLockFilePath(repo), []byte("999999"), 0644))
+
+	// a cache in an old format
+	require.NoError(t, ioutil.WriteFile(cacheFilePath(repo), []byte(`{"kind":"header","version":0}`+"\n"), 0644))
+
+	diagnostics, err = Diagnose(repo)
+	require.NoError(t, err)
+
+	for _, check := range []string{"refspec", "lock", "cache"} {
+		diagnostic := findDiagnostic(diagnostics, check)
+		require.NotNil(t, diagnostic, check)
+		require.NotNil(t, diagnostic.Fix, check)
+		require.NoError(t, diagnostic.Fix(), check)
+	}
+
+	diagnostics, err = Diagnose(repo)
+	require.NoError(t, err)
+	assert.Nil(t, findDiagnostic(diagnostics, "refspec"))
+	assert.Nil(t, findDiagnostic(diagnostics, "lock"))
+	assert.Nil(t, findDiagnostic(diagnostics, "cache"))
+
+	// the cache is built again
+	c, err = NewRepoCache(repo)
+	require.NoError(t, err)
+	require.NoError(t, c.Close())
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	assert.True(t, versionAtLeast("2.20.1", []int{2, 1, 0}))
+	assert.True(t, versionAtLeast("2.1", []int{2, 1, 0}))
+	assert.True(t, versionAtLeast("2.17.1.windows.2", []int{2, 1, 0}))
+	assert.False(t, versionAtLeast("2.0.5", []int{2, 1, 0}))
+	assert.False(t, versionAtLeast("1.9.1", []int{2, 1, 0}))
+}
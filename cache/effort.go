@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"errors"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// EffortWeek is the activity on the bugs during a week
+type EffortWeek struct {
+	Start time.Time `json:"start"`
+	// Opened is the number of bugs created
+	Opened int `json:"opened"`
+	// Closed is the number of times a bug has been closed
+	Closed int `json:"closed"`
+	// Open is the number of bugs open at the end of the week
+	Open int `json:"open"`
+}
+
+// EffortReport is the activity on the bugs during a period, by week and by
+// person
+type EffortReport struct {
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+	// Weeks start on monday, the first and the last ones might be partial
+	Weeks         []EffortWeek   `json:"weeks"`
+	Contributions []Contribution `json:"contributions"`
+}
+
+// Effort compute the activity on the bugs from since (included) to until
+// (excluded), by week and by person. A zero until mean now.
+//
+// Only the bugs edited during or after the period are read, the status of
+// the others can't have changed since and is taken from their excerpt.
+func (c *RepoCache) Effort(since time.Time, until time.Time) (*EffortReport, error) {
+	if until.IsZero() {
+		until = time.Now()
+	}
+	if since.IsZero() || !since.Before(until) {
+		return nil, errors.New("the period of the effort report is empty")
+	}
+
+	result := &EffortReport{Since: since, Until: until}
+
+	var ids []string
+	// the bugs open during the whole period
+	unchangedOpen := 0
+
+	c.muBug.RLock()
+	for id, excerpt := range c.excerpts {
+		if excerpt.EditUnixTime >= since.Unix() {
+			ids = append(ids, id)
+		} else if excerpt.Status == bug.OpenStatus {
+			unchangedOpen++
+		}
+	}
+	c.muBug.RUnlock()
+
+	var snapshots []*bug.Snapshot
+	for _, id := range ids {
+		b, err := c.ResolveBug(id)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, b.Snapshot())
+	}
+
+	for start := since; start.Before(until); start = nextMonday(start) {
+		end := nextMonday(start)
+		if end.After(until) {
+			end = until
+		}
+
+		week := EffortWeek{Start: start, Open: unchangedOpen}
+
+		for _, snap := range snapshots {
+			for _, op := range snap.Operations {
+				t := op.Time()
+				if t.Before(start) || !t.Before(end) {
+					continue
+				}
+
+				switch op := op.(type) {
+				case *bug.CreateOperation:
+					week.Opened++
+				case *bug.SetStatusOperation:
+					if op.Status == bug.ClosedStatus {
+						week.Closed++
+					}
+				}
+			}
+
+			if snap.CreatedAt.Before(end) && statusAt(snap, end) == bug.OpenStatus {
+				week.Open++
+			}
+		}
+
+		result.Weeks = append(result.Weeks, week)
+	}
+
+	contributions, err := c.Contributions(since, until)
+	if err != nil {
+		return nil, err
+	}
+	result.Contributions = contributions
+
+	return result, nil
+}
+
+// nextMonday return the start of the monday following the given time
+func nextMonday(t time.Time) time.Time {
+	days := (8 - int(t.Weekday())) % 7
+	if days == 0 {
+		days = 7
+	}
+	return time.Date(t.Year(), t.Month(), t.Day()+days, 0, 0, 0, 0, t.Location())
+}
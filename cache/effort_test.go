@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheEffort(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	defer c.Close()
+
+	author, err := bug.GetUser(repo)
+	require.NoError(t, err)
+
+	// two full weeks in the past, starting on a monday
+	since := nextMonday(time.Now()).AddDate(0, 0, -21)
+	until := since.AddDate(0, 0, 14)
+
+	// the middle of the nth day of the period
+	day := func(n int) time.Time {
+		return since.AddDate(0, 0, n).Add(12 * time.Hour)
+	}
+
+	// open during the whole period, and not read
+	_, err = c.NewBugRaw(author, day(-30), "old", "message", nil, nil)
+	require.NoError(t, err)
+
+	b1, err := c.NewBugRaw(author, day(-7), "closed in the first week", "message", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, b1.CloseRaw(author, day(1), nil))
+	require.NoError(t, b1.Commit())
+
+	_, err = c.NewBugRaw(author, day(2), "opened in the first week", "message", nil, nil)
+	require.NoError(t, err)
+
+	b3, err := c.NewBugRaw(author, day(8), "opened and closed in the second week", "message", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, b3.CloseRaw(author, day(9), nil))
+	require.NoError(t, b3.Commit())
+
+	report, err := c.Effort(since, until)
+	require.NoError(t, err)
+
+	assert.Equal(t, []EffortWeek{
+		{Start: since, Opened: 1, Closed: 1, Open: 2},
+		{Start: since.AddDate(0, 0, 7), Opened: 1, Closed: 1, Open: 2},
+	}, report.Weeks)
+
+	require.Len(t, report.Contributions, 1)
+	assert.Equal(t, 4, report.Contributions[0].Operations)
+
+	_, err = c.Effort(until, since)
+	assert.Error(t, err)
+}
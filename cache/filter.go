@@ -1,6 +1,11 @@
 package cache
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/MichaelMure/git-bug/bug"
 )
 
@@ -26,11 +31,43 @@ func AuthorFilter(query string) Filter {
 	}
 }
 
-// LabelFilter return a Filter that match a label
+// ParticipantFilter return a Filter that match a person who commented on a
+// bug, including its author
+func ParticipantFilter(query string) Filter {
+	return func(excerpt *BugExcerpt) bool {
+		for _, p := range excerpt.Participants {
+			if p.Match(query) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ActorFilter return a Filter that match a person who did anything on a bug
+func ActorFilter(query string) Filter {
+	return func(excerpt *BugExcerpt) bool {
+		for _, p := range excerpt.Actors {
+			if p.Match(query) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AssigneeFilter return a Filter that match the person in charge of a bug
+func AssigneeFilter(query string) Filter {
+	return func(excerpt *BugExcerpt) bool {
+		return excerpt.Assignee != nil && excerpt.Assignee.Match(query)
+	}
+}
+
+// LabelFilter return a Filter that match a label, or an equivalent one
 func LabelFilter(label string) Filter {
 	return func(excerpt *BugExcerpt) bool {
 		for _, l := range excerpt.Labels {
-			if string(l) == label {
+			if l.Equivalent(bug.Label(label)) {
 				return true
 			}
 		}
@@ -38,6 +75,110 @@ func LabelFilter(label string) Filter {
 	}
 }
 
+// VisibilityFilter return a Filter that match a bug visibility
+func VisibilityFilter(query string) (Filter, error) {
+	visibility, err := bug.VisibilityFromString(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(excerpt *BugExcerpt) bool {
+		return excerpt.Visibility.String() == visibility.String()
+	}, nil
+}
+
+// FixedInFilter return a Filter that match a release or a commit fixing the
+// bug
+func FixedInFilter(query string) Filter {
+	return func(excerpt *BugExcerpt) bool {
+		for _, fix := range excerpt.FixedIn {
+			if fix.Match(query) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// CreatedAfterFilter return a Filter that match the bugs created after a time
+func CreatedAfterFilter(t time.Time) Filter {
+	return func(excerpt *BugExcerpt) bool {
+		return excerpt.CreateUnixTime > t.Unix()
+	}
+}
+
+// CreatedBeforeFilter return a Filter that match the bugs created before a
+// time
+func CreatedBeforeFilter(t time.Time) Filter {
+	return func(excerpt *BugExcerpt) bool {
+		return excerpt.CreateUnixTime < t.Unix()
+	}
+}
+
+// EditedAfterFilter return a Filter that match the bugs edited after a time
+func EditedAfterFilter(t time.Time) Filter {
+	return func(excerpt *BugExcerpt) bool {
+		return excerpt.EditUnixTime > t.Unix()
+	}
+}
+
+// EditedBeforeFilter return a Filter that match the bugs last edited before
+// a time
+func EditedBeforeFilter(t time.Time) Filter {
+	return func(excerpt *BugExcerpt) bool {
+		return excerpt.EditUnixTime < t.Unix()
+	}
+}
+
+// ScoreFilter return a Filter that compare a score of a bug with a value,
+// given as ">0.8", ">=0.8", "<0.2", "<=0.2" or "0.8" (at least). A missing
+// score is zero.
+func ScoreFilter(name string, query string) (Filter, error) {
+	operator := ">="
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(query, op) {
+			operator = op
+			query = query[len(op):]
+			break
+		}
+	}
+
+	value, err := strconv.ParseFloat(query, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid score value %s", query)
+	}
+
+	return func(excerpt *BugExcerpt) bool {
+		score := excerpt.Scores[name]
+		switch operator {
+		case ">":
+			return score > value
+		case "<":
+			return score < value
+		case "<=":
+			return score <= value
+		case "=":
+			return score == value
+		default:
+			return score >= value
+		}
+	}, nil
+}
+
+// NoFixedInFilter return a Filter that match the bugs not linked to a fix
+func NoFixedInFilter() Filter {
+	return func(excerpt *BugExcerpt) bool {
+		return len(excerpt.FixedIn) == 0
+	}
+}
+
+// NoAssigneeFilter return a Filter that match the unassigned bugs
+func NoAssigneeFilter() Filter {
+	return func(excerpt *BugExcerpt) bool {
+		return excerpt.Assignee == nil
+	}
+}
+
 // NoLabelFilter return a Filter that match the absence of labels
 func NoLabelFilter() Filter {
 	return func(excerpt *BugExcerpt) bool {
@@ -45,12 +186,44 @@ func NoLabelFilter() Filter {
 	}
 }
 
+// And return a Filter that match if all the filters match
+func And(filters ...Filter) Filter {
+	return func(excerpt *BugExcerpt) bool {
+		for _, f := range filters {
+			if !f(excerpt) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or return a Filter that match if any of the filters match
+func Or(filters ...Filter) Filter {
+	return func(excerpt *BugExcerpt) bool {
+		for _, f := range filters {
+			if f(excerpt) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not return a Filter that match if the filter doesn't
+func Not(filter Filter) Filter {
+	return func(excerpt *BugExcerpt) bool {
+		return !filter(excerpt)
+	}
+}
+
 // Filters is a collection of Filter that implement a complex filter
 type Filters struct {
-	Status    []Filter
-	Author    []Filter
-	Label     []Filter
-	NoFilters []Filter
+	Status     []Filter
+	Author     []Filter
+	Label      []Filter
+	Visibility []Filter
+	NoFilters  []Filter
 }
 
 // Match check if a bug match the set of filters
@@ -67,6 +240,10 @@ func (f *Filters) Match(excerpt *BugExcerpt) bool {
 		return false
 	}
 
+	if match := f.orMatch(f.Visibility, excerpt); !match {
+		return false
+	}
+
 	if match := f.andMatch(f.NoFilters, excerpt); !match {
 		return false
 	}
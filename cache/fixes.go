@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+// FixTrailerKeywords are the keys of the commit message trailers referencing
+// the bugs fixed by a commit. They can also be suffixed with "-bug".
+var FixTrailerKeywords = []string{
+	"fix", "fixes", "fixed",
+	"close", "closes", "closed",
+	"resolve", "resolves", "resolved",
+}
+
+// fixTrailerRegexp match the trailers of a commit message referencing the
+// bugs fixed by the commit, such as "Fixes: 3f2a7c1" or "Closes: #12, #13"
+var fixTrailerRegexp = regexp.MustCompile(`(?im)^(?:` + strings.Join(FixTrailerKeywords, "|") + `)(?:-bug)?:[ \t]*(.+)$`)
+
+// minFixRefLength is the minimal length of a bug id prefix in a trailer, to
+// avoid matching a bug by chance
+const minFixRefLength = 7
+
+// FixReference is a commit of the source code declaring that it fixes a bug
+type FixReference struct {
+	Commit git.Hash
+	BugId  string
+	// Recorded is false if the bug was already linked to this fix
+	Recorded bool
+	// Closed is true if the bug has been closed by the scan
+	Closed bool
+}
+
+// parseFixTrailers return the bug references found in the trailers of a
+// commit message
+func parseFixTrailers(message string) []string {
+	var result []string
+
+	separator := func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	}
+
+	for _, match := range fixTrailerRegexp.FindAllStringSubmatch(message, -1) {
+		for _, ref := range strings.FieldsFunc(match[1], separator) {
+			if strings.HasPrefix(ref, "#") || len(ref) >= minFixRefLength {
+				result = append(result, ref)
+			}
+		}
+	}
+
+	return result
+}
+
+// ScanFixes read the commits of a revision range and link the bugs referenced
+// by their trailers ("Fixes: <bug>") to the commit and to the given release,
+// if any. If close is true, the referenced bugs still open are closed.
+//
+// The references that don't match a bug, like the "Fixes: <commit>" trailers
+// about the source code itself, are ignored.
+func (c *RepoCache) ScanFixes(revRange string, release string, close bool) ([]FixReference, error) {
+	if err := c.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	history, ok := c.repo.(repository.HistoryRepo)
+	if !ok {
+		return nil, fmt.Errorf("the repository doesn't give access to the source code history")
+	}
+
+	commits, err := history.ReadCommitMessages(revRange)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []FixReference
+
+	for _, commit := range commits {
+		for _, ref := range parseFixTrailers(commit.Message) {
+			b, err := c.ResolveBugPrefix(ref)
+			if err != nil {
+				continue
+			}
+
+			fix := FixReference{
+				Commit: commit.Hash,
+				BugId:  b.Id(),
+			}
+
+			if !b.Snapshot().HasFixedIn(bug.FixedIn{Release: release, Commit: string(commit.Hash)}) {
+				err = b.AddFixedIn(release, string(commit.Hash))
+				if err != nil {
+					return result, err
+				}
+				fix.Recorded = true
+			}
+
+			if close && b.Snapshot().Status == bug.OpenStatus {
+				err = b.Close()
+				if err != nil {
+					return result, err
+				}
+				fix.Closed = true
+			}
+
+			err = b.CommitAsNeeded()
+			if err != nil {
+				return result, err
+			}
+
+			result = append(result, fix)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFixTrailers(t *testing.T) {
+	message := `Fix the crash on startup
+
+Some explanation. Fixes: not a trailer
+
+Fixes: 1234567abc
+closes: #12, #13
+Resolved-bug: abc
+Signed-off-by: René Descartes <rene@descartes.fr>`
+
+	assert.Equal(t, []string{"1234567abc", "#12", "#13"}, parseFixTrailers(message))
+}
+
+func TestCacheScanFixes(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	b, err := c.NewBug("title", "message")
+	assert.NoError(t, err)
+
+	gitCommit := func(message string) {
+		cmd := exec.Command("git", "commit", "--allow-empty", "-q", "-m", message)
+		cmd.Dir = repo.GetPath()
+		assert.NoError(t, cmd.Run())
+	}
+
+	gitCommit("initial commit")
+	gitCommit("fix the bug\n\nFixes: " + b.Id()[:10])
+	gitCommit("unrelated\n\nFixes: 0000000000")
+
+	fixes, err := c.ScanFixes("HEAD~2..HEAD", "v1.0.0", true)
+	assert.NoError(t, err)
+	assert.Len(t, fixes, 1)
+	assert.Equal(t, b.Id(), fixes[0].BugId)
+	assert.True(t, fixes[0].Recorded)
+	assert.True(t, fixes[0].Closed)
+
+	snap := b.Snapshot()
+	assert.Equal(t, bug.ClosedStatus, snap.Status)
+	assert.Len(t, snap.FixedIn, 1)
+	assert.Equal(t, "v1.0.0", snap.FixedIn[0].Release)
+	assert.Equal(t, string(fixes[0].Commit), snap.FixedIn[0].Commit)
+
+	query, err := ParseQuery("fixed-in:v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{b.Id()}, c.QueryBugs(query))
+
+	// scanning again is a no-op
+	fixes, err = c.ScanFixes("HEAD~2..HEAD", "v1.0.0", true)
+	assert.NoError(t, err)
+	assert.Len(t, fixes, 1)
+	assert.False(t, fixes[0].Recorded)
+	assert.False(t, fixes[0].Closed)
+}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"sort"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// RepoHealth describe the state of a cached repository
+type RepoHealth struct {
+	Name string `json:"name,omitempty"`
+	Path string `json:"path"`
+	// CacheLoaded is true when the bug excerpts are loaded and the cache can
+	// answer queries
+	CacheLoaded bool       `json:"cache_loaded"`
+	LoadedAt    time.Time  `json:"loaded_at"`
+	Bugs        int        `json:"bugs"`
+	LastMerge   *time.Time `json:"last_merge,omitempty"`
+	// Accessible is true when the underlying git repository can be read
+	Accessible bool   `json:"accessible"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Ready tell if the repository is able to serve requests
+func (h RepoHealth) Ready() bool {
+	return h.CacheLoaded && h.Accessible
+}
+
+// Health check and report the state of the repository
+func (c *RepoCache) Health() RepoHealth {
+	c.muBug.RLock()
+	health := RepoHealth{
+		Path:        c.repo.GetPath(),
+		CacheLoaded: c.excerpts != nil,
+		LoadedAt:    c.loadedAt,
+		Bugs:        len(c.excerpts),
+	}
+
+	if !c.lastMerge.IsZero() {
+		lastMerge := c.lastMerge
+		health.LastMerge = &lastMerge
+	}
+	c.muBug.RUnlock()
+
+	_, err := bug.ListLocalIds(c.repo)
+	if err != nil {
+		health.Error = err.Error()
+	} else {
+		health.Accessible = true
+	}
+
+	return health
+}
+
+// Health check and report the state of all the repositories, sorted by name
+func (c *MultiRepoCache) Health() []RepoHealth {
+	result := make([]RepoHealth, 0, len(c.repos))
+
+	for name, repo := range c.repos {
+		health := repo.Health()
+		health.Name = name
+		result = append(result, health)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// Ready tell if all the repositories are able to serve requests
+func (c *MultiRepoCache) Ready() bool {
+	if len(c.repos) == 0 {
+		return false
+	}
+
+	for _, health := range c.Health() {
+		if !health.Ready() {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,169 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// HookEventKind is the kind of mutation triggering the hooks. It's also the
+// name of the script run for it.
+type HookEventKind string
+
+const (
+	HookBugCreated    HookEventKind = "bug-created"
+	HookBugUpdated    HookEventKind = "bug-updated"
+	HookStatusChanged HookEventKind = "status-changed"
+	HookCommentAdded  HookEventKind = "comment-added"
+	HookMergeApplied  HookEventKind = "merge-applied"
+	HookSyncFailed    HookEventKind = "sync-failed"
+)
+
+// HookKinds are all the kind of events triggering the hooks
+var HookKinds = []HookEventKind{
+	HookBugCreated,
+	HookBugUpdated,
+	HookStatusChanged,
+	HookCommentAdded,
+	HookMergeApplied,
+	HookSyncFailed,
+}
+
+// HookEvent describe a mutation of a bug, given to the hooks
+type HookEvent struct {
+	Kind  HookEventKind `json:"kind"`
+	BugId string        `json:"bug_id"`
+	Title string        `json:"title"`
+	// Status is the status of the bug after the mutation
+	Status string `json:"status"`
+	// Author is not set for a merge
+	Author *bug.Person `json:"author,omitempty"`
+	// Message is the new comment, for comment-added
+	Message string `json:"message,omitempty"`
+	// Remote and MergeStatus are set for merge-applied
+	Remote      string `json:"remote,omitempty"`
+	MergeStatus string `json:"merge_status,omitempty"`
+	// Remote or Bridge, and Error are set for sync-failed, which isn't about
+	// a particular bug
+	Bridge string `json:"bridge,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// HookHandler is a function called for each mutation, in-process. It's called
+// synchronously, after the mutation is done, and should return quickly.
+type HookHandler func(event HookEvent)
+
+// hookTimeout is how long a hook script can run before being killed
+const hookTimeout = 30 * time.Second
+
+// HooksDir return the directory holding the hook scripts of a repository.
+// A script is run when its name match the kind of the event, like
+// "comment-added", and it is executable.
+func HooksDir(repo repository.Repo) string {
+	return path.Join(repo.GetGitDir(), "git-bug", "hooks")
+}
+
+// RegisterHook add an in-process handler for the mutations done through this
+// cache. The returned function remove the handler.
+func (c *RepoCache) RegisterHook(handler HookHandler) func() {
+	c.muHooks.Lock()
+	defer c.muHooks.Unlock()
+
+	h := &handler
+	c.hooks[h] = struct{}{}
+
+	return func() {
+		c.muHooks.Lock()
+		defer c.muHooks.Unlock()
+		delete(c.hooks, h)
+	}
+}
+
+func newHookEvent(kind HookEventKind, snap *bug.Snapshot) HookEvent {
+	return HookEvent{
+		Kind:   kind,
+		BugId:  snap.Id(),
+		Title:  snap.Title,
+		Status: snap.Status.String(),
+	}
+}
+
+// FireSyncFailed report the failure of a background synchronisation with a
+// remote or a bridge to the hooks
+func (c *RepoCache) FireSyncFailed(remote string, bridge string, err error) {
+	c.fireHook(HookEvent{
+		Kind:   HookSyncFailed,
+		Remote: remote,
+		Bridge: bridge,
+		Error:  err.Error(),
+	})
+}
+
+// fireHook run the hook script for the event, if any, send it to the
+// webhooks, then call the in-process handlers. A failing script is reported
+// but doesn't fail the mutation, which is already done.
+func (c *RepoCache) fireHook(event HookEvent) {
+	err := c.runHookScript(event)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "hook %s failed: %v\n", event.Kind, err)
+	}
+
+	c.sendWebhooks(event)
+
+	c.muHooks.Lock()
+	handlers := make([]HookHandler, 0, len(c.hooks))
+	for h := range c.hooks {
+		handlers = append(handlers, *h)
+	}
+	c.muHooks.Unlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// runHookScript run the script of the event, with the event as JSON on its
+// standard input and the main values in the environment
+func (c *RepoCache) runHookScript(event HookEvent) error {
+	script := path.Join(HooksDir(c.repo), string(event.Kind))
+
+	stat, err := os.Stat(script)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if stat.IsDir() || stat.Mode()&0111 == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, script)
+	cmd.Dir = c.repo.GetPath()
+	cmd.Stdin = bytes.NewReader(data)
+	// the output of the hooks must not mix with the output of the commands
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"GIT_BUG_EVENT="+string(event.Kind),
+		"GIT_BUG_BUG_ID="+event.BugId,
+	)
+
+	return cmd.Run()
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheHooks(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	var events []HookEvent
+	remove := c.RegisterHook(func(event HookEvent) {
+		events = append(events, event)
+	})
+
+	// a script recording the event it receives
+	output := path.Join(repo.GetPath(), "hook-output")
+	assert.NoError(t, os.MkdirAll(HooksDir(repo), 0755))
+	script := "#!/bin/sh\ncat > " + output + "\n"
+	assert.NoError(t, ioutil.WriteFile(path.Join(HooksDir(repo), string(HookCommentAdded)), []byte(script), 0755))
+
+	b, err := c.NewBug("title", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, b.AddComment("comment"))
+	assert.NoError(t, b.Close())
+
+	assert.Len(t, events, 3)
+	assert.Equal(t, HookBugCreated, events[0].Kind)
+	assert.Equal(t, b.Id(), events[0].BugId)
+	assert.Equal(t, "testuser", events[0].Author.Name)
+	assert.Equal(t, HookCommentAdded, events[1].Kind)
+	assert.Equal(t, "comment", events[1].Message)
+	assert.Equal(t, HookStatusChanged, events[2].Kind)
+	assert.Equal(t, "closed", events[2].Status)
+
+	data, err := ioutil.ReadFile(output)
+	assert.NoError(t, err)
+
+	var received HookEvent
+	assert.NoError(t, json.Unmarshal(data, &received))
+	assert.Equal(t, events[1], received)
+
+	// a removed handler is not called anymore
+	remove()
+	assert.NoError(t, b.Open())
+	assert.Len(t, events, 3)
+}
@@ -0,0 +1,315 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+const identityHumanIdLength = 7
+
+// ErrIdentityNotExist is returned when no identity match an id or a prefix
+var ErrIdentityNotExist = errors.New("identity doesn't exist")
+
+// ErrMultipleMatchIdentity is returned when a prefix match multiple identities
+type ErrMultipleMatchIdentity struct {
+	Matching []string
+}
+
+func (e ErrMultipleMatchIdentity) Error() string {
+	return fmt.Sprintf("Multiple matching identities found:\n%s", strings.Join(e.Matching, "\n"))
+}
+
+// IdentityExcerpt hold a person having done something on the bugs of the
+// repository, to be able to list and search them without reading the bugs.
+//
+// The field names are part of the cache file format: renaming a field in Go
+// must not change its JSON name.
+type IdentityExcerpt struct {
+	Id        string `json:"id"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Login     string `json:"login,omitempty"`
+	AvatarUrl string `json:"avatar_url,omitempty"`
+}
+
+// identityId compute the id of a person, derived from all its values
+func identityId(p bug.Person) string {
+	data := strings.Join([]string{p.Name, p.Email, p.Login, p.AvatarUrl}, "\n")
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(data)))
+}
+
+func NewIdentityExcerpt(p bug.Person) *IdentityExcerpt {
+	return &IdentityExcerpt{
+		Id:        identityId(p),
+		Name:      p.Name,
+		Email:     p.Email,
+		Login:     p.Login,
+		AvatarUrl: p.AvatarUrl,
+	}
+}
+
+// HumanId return the short version of the id
+func (i *IdentityExcerpt) HumanId() string {
+	return i.Id[:identityHumanIdLength]
+}
+
+// Person return the identity as a bug.Person
+func (i *IdentityExcerpt) Person() bug.Person {
+	return bug.Person{
+		Name:      i.Name,
+		Email:     i.Email,
+		Login:     i.Login,
+		AvatarUrl: i.AvatarUrl,
+	}
+}
+
+// Avatar return the url of the avatar of the identity, or a generated image
+// showing its initials if it has none
+func (i *IdentityExcerpt) Avatar() string {
+	return i.Person().Avatar()
+}
+
+// DisplayName return a non-empty string to display, representing the identity
+func (i *IdentityExcerpt) DisplayName() string {
+	return i.Person().DisplayName()
+}
+
+// indexIdentities record the authors of the operations of a bug and its
+// assignee as identities. The caller must hold the lock.
+func (c *RepoCache) indexIdentities(excerpt *BugExcerpt) {
+	for _, actor := range excerpt.Actors {
+		identity := NewIdentityExcerpt(actor)
+		c.identities[identity.Id] = identity
+	}
+	if excerpt.Assignee != nil {
+		identity := NewIdentityExcerpt(*excerpt.Assignee)
+		c.identities[identity.Id] = identity
+	}
+}
+
+// setExcerpt store the excerpt of a bug and index its identities and its
+// metadata. The caller must hold the lock.
+func (c *RepoCache) setExcerpt(id string, excerpt *BugExcerpt) {
+	c.unindexMetadata(id)
+	c.excerpts[id] = excerpt
+	c.indexIdentities(excerpt)
+	c.indexMetadata(id, excerpt)
+}
+
+// removeExcerpt remove the excerpt of a bug from the cache and from the
+// metadata index. The identities are kept. The caller must hold the lock.
+func (c *RepoCache) removeExcerpt(id string) {
+	c.unindexMetadata(id)
+	delete(c.excerpts, id)
+}
+
+// resetExcerpts replace all the excerpts of the cache, and index them again.
+// The caller must hold the lock.
+func (c *RepoCache) resetExcerpts(excerpts map[string]*BugExcerpt) {
+	c.excerpts = make(map[string]*BugExcerpt, len(excerpts))
+	c.metadata = make(map[metadataEntry]map[string]struct{})
+
+	for id, excerpt := range excerpts {
+		c.setExcerpt(id, excerpt)
+	}
+}
+
+// AllIdentityIds return all known identity ids
+func (c *RepoCache) AllIdentityIds() []string {
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
+	result := make([]string, 0, len(c.identities))
+	for id := range c.identities {
+		result = append(result, id)
+	}
+
+	return result
+}
+
+// ResolveIdentity retrieve an identity matching the exact given id
+func (c *RepoCache) ResolveIdentity(id string) (*IdentityExcerpt, error) {
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
+	identity, ok := c.identities[id]
+	if !ok {
+		return nil, ErrIdentityNotExist
+	}
+
+	return identity, nil
+}
+
+// ResolveIdentityPrefix retrieve an identity matching an id prefix. It fails
+// if multiple identities match.
+func (c *RepoCache) ResolveIdentityPrefix(prefix string) (*IdentityExcerpt, error) {
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
+	var matching []string
+
+	for id := range c.identities {
+		if strings.HasPrefix(id, prefix) {
+			matching = append(matching, id)
+		}
+	}
+
+	if len(matching) > 1 {
+		sort.Strings(matching)
+		return nil, ErrMultipleMatchIdentity{Matching: matching}
+	}
+
+	if len(matching) == 0 {
+		return nil, ErrIdentityNotExist
+	}
+
+	return c.identities[matching[0]], nil
+}
+
+// QueryIdentities return the identities matching a query, sorted by name.
+//
+// The query is a list of terms that must all match. A term can be restricted
+// to a field with "name:", "email:" or "login:", otherwise it can match any
+// of them. Ex: `name:"René Descartes"`, `email:example.com rene`
+func (c *RepoCache) QueryIdentities(query string) ([]*IdentityExcerpt, error) {
+	matchers, err := parseIdentityQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*IdentityExcerpt
+
+	c.muBug.RLock()
+	for _, identity := range c.identities {
+		ok := true
+		for _, matcher := range matchers {
+			if !matcher(identity) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			result = append(result, identity)
+		}
+	}
+	c.muBug.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		ni, nj := strings.ToLower(result[i].DisplayName()), strings.ToLower(result[j].DisplayName())
+		if ni != nj {
+			return ni < nj
+		}
+		return result[i].Id < result[j].Id
+	})
+
+	return result, nil
+}
+
+// IdentitiesByEmail return the identities having exactly the given email,
+// ignoring the case, sorted like QueryIdentities
+func (c *RepoCache) IdentitiesByEmail(email string) ([]*IdentityExcerpt, error) {
+	if email == "" {
+		return nil, nil
+	}
+
+	identities, err := c.QueryIdentities("")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*IdentityExcerpt
+	for _, identity := range identities {
+		if strings.EqualFold(identity.Email, email) {
+			result = append(result, identity)
+		}
+	}
+
+	return result, nil
+}
+
+type identityMatcher func(identity *IdentityExcerpt) bool
+
+func parseIdentityQuery(query string) ([]identityMatcher, error) {
+	fields, err := splitQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []identityMatcher
+
+	for _, field := range fields {
+		name, value := "", field
+		if split := strings.SplitN(field, ":", 2); len(split) == 2 {
+			name, value = strings.ToLower(split[0]), split[1]
+		}
+
+		value = strings.ToLower(removeQuote(value))
+		if value == "" {
+			return nil, fmt.Errorf("empty value for qualifier %s", name)
+		}
+
+		contains := func(s string) bool {
+			return strings.Contains(strings.ToLower(s), value)
+		}
+
+		switch name {
+		case "":
+			result = append(result, func(i *IdentityExcerpt) bool {
+				return contains(i.Name) || contains(i.Email) || contains(i.Login)
+			})
+		case "name":
+			result = append(result, func(i *IdentityExcerpt) bool { return contains(i.Name) })
+		case "email":
+			result = append(result, func(i *IdentityExcerpt) bool { return contains(i.Email) })
+		case "login":
+			result = append(result, func(i *IdentityExcerpt) bool { return contains(i.Login) })
+		default:
+			return nil, fmt.Errorf("unknown qualifier name %s", name)
+		}
+	}
+
+	return result, nil
+}
+
+// splitQuery split a query on the spaces, except in quoted values
+func splitQuery(query string) ([]string, error) {
+	var result []string
+	var current []rune
+	var quote rune
+
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+			current = append(current, r)
+		case unicode.In(r, unicode.Quotation_Mark):
+			quote = closingQuote(r)
+			current = append(current, r)
+		case unicode.IsSpace(r):
+			if len(current) > 0 {
+				result = append(result, string(current))
+				current = nil
+			}
+		default:
+			current = append(current, r)
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in query %s", query)
+	}
+
+	if len(current) > 0 {
+		result = append(result, string(current))
+	}
+
+	return result, nil
+}
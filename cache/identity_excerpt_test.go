@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheIdentities(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+
+	rene := bug.Person{Name: "René Descartes", Email: "rene@descartes.fr"}
+	blaise := bug.Person{Name: "Blaise Pascal", Email: "blaise@pascal.fr", Login: "bpascal"}
+
+	b, err := c.NewBugRaw(rene, time.Now(), "title", "message", nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, b.AddCommentRaw(blaise, time.Now(), "comment", nil, nil))
+	assert.NoError(t, b.Commit())
+
+	_, err = c.NewBug("other", "message")
+	assert.NoError(t, err)
+
+	// the author of each operation is indexed
+	assert.Len(t, c.AllIdentityIds(), 3)
+
+	all, err := c.QueryIdentities("")
+	assert.NoError(t, err)
+	assert.Len(t, all, 3)
+	assert.Equal(t, "Blaise Pascal (bpascal)", all[0].DisplayName())
+
+	found, err := c.QueryIdentities(`name:"rené desc"`)
+	assert.NoError(t, err)
+	assert.Len(t, found, 1)
+	assert.Equal(t, rene, found[0].Person())
+
+	found, err = c.QueryIdentities("email:.fr pascal")
+	assert.NoError(t, err)
+	assert.Len(t, found, 1)
+	assert.Equal(t, blaise, found[0].Person())
+
+	_, err = c.QueryIdentities("age:42")
+	assert.Error(t, err)
+	_, err = c.QueryIdentities(`name:"rene`)
+	assert.Error(t, err)
+
+	identity := NewIdentityExcerpt(rene)
+	resolved, err := c.ResolveIdentityPrefix(identity.HumanId())
+	assert.NoError(t, err)
+	assert.Equal(t, identity, resolved)
+
+	_, err = c.ResolveIdentityPrefix("")
+	assert.IsType(t, ErrMultipleMatchIdentity{}, err)
+	_, err = c.ResolveIdentityPrefix("xyz")
+	assert.Equal(t, ErrIdentityNotExist, err)
+
+	// the identities are persisted in the cache file
+	assert.NoError(t, c.Close())
+
+	c, err = NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	resolved, err = c.ResolveIdentity(identity.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, identity, resolved)
+	assert.Len(t, c.AllIdentityIds(), 3)
+}
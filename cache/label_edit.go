@@ -0,0 +1,198 @@
+package cache
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// LabelEdit is a planned change of the labels of a bug
+type LabelEdit struct {
+	Id      string
+	Added   []bug.Label
+	Removed []bug.Label
+}
+
+// PlanLabelRename compute the changes needed on every bug to replace the
+// labels equivalent to any of the sources by the target, like "Bug" and
+// "defect" by "bug". Renaming a label is done with a single source. The
+// changes are sorted by id.
+func (c *RepoCache) PlanLabelRename(sources []string, target string) ([]LabelEdit, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no label to rename")
+	}
+
+	policy, err := c.LabelPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	// the spelling of the target is kept as given, to be able to fix the
+	// casing of a label
+	to := bug.Label(c.sanitizeText(target)).Canonical(policy)
+	if err := to.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid label: %v", err)
+	}
+
+	from := make([]bug.Label, len(sources))
+	for i, source := range sources {
+		from[i] = bug.Label(source)
+	}
+
+	var result []LabelEdit
+
+	c.muBug.RLock()
+	for id, excerpt := range c.excerpts {
+		edit := LabelEdit{Id: id}
+		hasTarget := false
+		matched := false
+
+		for _, label := range excerpt.Labels {
+			if label == to {
+				hasTarget = true
+			}
+			for _, source := range from {
+				if label.Equivalent(source) {
+					matched = true
+					if label != to {
+						edit.Removed = append(edit.Removed, label)
+					}
+					break
+				}
+			}
+		}
+
+		if !matched {
+			continue
+		}
+		if !hasTarget {
+			edit.Added = []bug.Label{to}
+		}
+		if len(edit.Added)+len(edit.Removed) > 0 {
+			result = append(result, edit)
+		}
+	}
+	c.muBug.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+
+	return result, nil
+}
+
+// PlanLabelEdit compute the changes needed on the given bugs to add and
+// remove labels. The labels to add are canonicalized like for a single bug,
+// and only added to the bugs not having an equivalent one. The changes are
+// sorted by id.
+func (c *RepoCache) PlanLabelEdit(ids []string, add []string, remove []string) ([]LabelEdit, error) {
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, fmt.Errorf("no label to add or remove")
+	}
+
+	canonical, err := c.canonicalLabels(add)
+	if err != nil {
+		return nil, err
+	}
+
+	added := make([]bug.Label, 0, len(canonical))
+	for _, str := range canonical {
+		label := bug.Label(str)
+		if err := label.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid label: %v", err)
+		}
+		for _, r := range remove {
+			if label.Equivalent(bug.Label(r)) {
+				return nil, fmt.Errorf("the label %s is both added and removed", label)
+			}
+		}
+		if !labelsContainEquivalent(added, label) {
+			added = append(added, label)
+		}
+	}
+
+	removed := make([]bug.Label, len(remove))
+	for i, str := range remove {
+		removed[i] = bug.Label(str)
+	}
+
+	var result []LabelEdit
+
+	c.muBug.RLock()
+	for _, id := range ids {
+		excerpt, ok := c.excerpts[id]
+		if !ok {
+			c.muBug.RUnlock()
+			return nil, bug.ErrBugNotExist
+		}
+
+		edit := LabelEdit{Id: id}
+
+		for _, label := range added {
+			if !labelsContainEquivalent(excerpt.Labels, label) {
+				edit.Added = append(edit.Added, label)
+			}
+		}
+		for _, label := range excerpt.Labels {
+			if labelsContainEquivalent(removed, label) {
+				edit.Removed = append(edit.Removed, label)
+			}
+		}
+
+		if len(edit.Added)+len(edit.Removed) > 0 {
+			result = append(result, edit)
+		}
+	}
+	c.muBug.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+
+	return result, nil
+}
+
+func labelsContainEquivalent(labels []bug.Label, label bug.Label) bool {
+	for _, l := range labels {
+		if l.Equivalent(label) {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyLabelEdit change the labels of a bug as planned by PlanLabelRename or
+// PlanLabelEdit, and commit the change
+func (c *BugCache) ApplyLabelEdit(edit LabelEdit) error {
+	if err := c.repoCache.checkWritable(); err != nil {
+		return err
+	}
+
+	author, err := bug.GetUser(c.repoCache.repo)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkAllowed(author, bug.ActionLabelChange)
+	if err != nil {
+		return err
+	}
+
+	// the operation is built directly, as bug.ChangeLabels would refuse to
+	// add a label equivalent to a removed one
+	op := bug.NewLabelChangeOperation(author, time.Now().Unix(), edit.Added, edit.Removed)
+	if err := op.Validate(); err != nil {
+		return err
+	}
+
+	c.append(op)
+
+	err = c.notifyUpdated()
+	if err != nil {
+		return err
+	}
+
+	return c.Commit()
+}
@@ -0,0 +1,133 @@
+package cache
+
+import (
+	"os"
+	"testing"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelRename(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	defer c.Close()
+
+	b1, err := c.NewBug("first", "message")
+	require.NoError(t, err)
+	_, err = b1.ChangeLabels([]string{"Bug", "ui"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, b1.Commit())
+
+	b2, err := c.NewBug("second", "message")
+	require.NoError(t, err)
+	_, err = b2.ChangeLabels([]string{"defect", "bug"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, b2.Commit())
+
+	b3, err := c.NewBug("third", "message")
+	require.NoError(t, err)
+	_, err = b3.ChangeLabels([]string{"ui"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, b3.Commit())
+
+	// "bug" is equivalent to "Bug", so the second bug got "Bug" as well
+	assert.Equal(t, []bug.Label{"Bug", "defect"}, b2.Snapshot().Labels)
+
+	renames, err := c.PlanLabelRename([]string{"bug", "defect"}, "kind/bug")
+	require.NoError(t, err)
+
+	expected := []LabelEdit{
+		{Id: b1.Id(), Added: []bug.Label{"kind/bug"}, Removed: []bug.Label{"Bug"}},
+		{Id: b2.Id(), Added: []bug.Label{"kind/bug"}, Removed: []bug.Label{"Bug", "defect"}},
+	}
+	if b2.Id() < b1.Id() {
+		expected[0], expected[1] = expected[1], expected[0]
+	}
+	assert.Equal(t, expected, renames)
+
+	for _, rename := range renames {
+		b, err := c.ResolveBug(rename.Id)
+		require.NoError(t, err)
+		require.NoError(t, b.ApplyLabelEdit(rename))
+	}
+
+	assert.Equal(t, []bug.Label{"kind/bug", "ui"}, b1.Snapshot().Labels)
+	assert.Equal(t, []bug.Label{"kind/bug"}, b2.Snapshot().Labels)
+	assert.Equal(t, []bug.Label{"ui"}, b3.Snapshot().Labels)
+
+	// fixing the casing of a label
+	renames, err = c.PlanLabelRename([]string{"ui"}, "UI")
+	require.NoError(t, err)
+	require.Len(t, renames, 2)
+	for _, rename := range renames {
+		assert.Equal(t, []bug.Label{"UI"}, rename.Added)
+		assert.Equal(t, []bug.Label{"ui"}, rename.Removed)
+		if rename.Id == b3.Id() {
+			require.NoError(t, b3.ApplyLabelEdit(rename))
+		}
+	}
+	assert.Equal(t, []bug.Label{"UI"}, b3.Snapshot().Labels)
+
+	// nothing to do
+	renames, err = c.PlanLabelRename([]string{"missing"}, "other")
+	require.NoError(t, err)
+	assert.Empty(t, renames)
+
+	_, err = c.PlanLabelRename([]string{"ui"}, "")
+	assert.Error(t, err)
+}
+
+func TestLabelEditPlan(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	defer c.Close()
+
+	b1, err := c.NewBug("first", "message")
+	require.NoError(t, err)
+	_, err = b1.ChangeLabels([]string{"Old", "keep"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, b1.Commit())
+
+	b2, err := c.NewBug("second", "message")
+	require.NoError(t, err)
+	_, err = b2.ChangeLabels([]string{"new"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, b2.Commit())
+
+	b3, err := c.NewBug("third", "message")
+	require.NoError(t, err)
+
+	// the spelling of "new" already used in the repo is reused
+	edits, err := c.PlanLabelEdit([]string{b1.Id(), b2.Id(), b3.Id()}, []string{"NEW"}, []string{"old"})
+	require.NoError(t, err)
+
+	byId := make(map[string]LabelEdit)
+	for _, edit := range edits {
+		byId[edit.Id] = edit
+	}
+	assert.Len(t, edits, 2)
+	assert.Equal(t, []bug.Label{"new"}, byId[b1.Id()].Added)
+	assert.Equal(t, []bug.Label{"Old"}, byId[b1.Id()].Removed)
+	assert.Equal(t, []bug.Label{"new"}, byId[b3.Id()].Added)
+	assert.Empty(t, byId[b3.Id()].Removed)
+
+	for _, edit := range edits {
+		b, err := c.ResolveBug(edit.Id)
+		require.NoError(t, err)
+		require.NoError(t, b.ApplyLabelEdit(edit))
+	}
+	assert.Equal(t, []bug.Label{"keep", "new"}, b1.Snapshot().Labels)
+
+	_, err = c.PlanLabelEdit([]string{b1.Id()}, []string{"x"}, []string{"X"})
+	assert.Error(t, err)
+	_, err = c.PlanLabelEdit([]string{b1.Id()}, nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"container/list"
+)
+
+// lruIdCache track the usage order of a set of ids, to find the least
+// recently used one. It's not thread safe.
+type lruIdCache struct {
+	order    *list.List
+	elements map[string]*list.Element
+}
+
+func newLRUIdCache() *lruIdCache {
+	return &lruIdCache{
+		order:    list.New(),
+		elements: make(map[string]*list.Element),
+	}
+}
+
+// Touch mark an id as the most recently used, adding it if needed
+func (lru *lruIdCache) Touch(id string) {
+	if elem, ok := lru.elements[id]; ok {
+		lru.order.MoveToFront(elem)
+		return
+	}
+
+	lru.elements[id] = lru.order.PushFront(id)
+}
+
+// Remove forget an id
+func (lru *lruIdCache) Remove(id string) {
+	if elem, ok := lru.elements[id]; ok {
+		lru.order.Remove(elem)
+		delete(lru.elements, id)
+	}
+}
+
+// Len return the number of tracked ids
+func (lru *lruIdCache) Len() int {
+	return lru.order.Len()
+}
+
+// Evict remove the least recently used ids until at most max ids are
+// tracked, skipping the ids that must be kept. It returns the removed ids.
+func (lru *lruIdCache) Evict(max int, keep func(id string) bool) []string {
+	var evicted []string
+
+	elem := lru.order.Back()
+	for elem != nil && lru.order.Len() > max {
+		prev := elem.Prev()
+		id := elem.Value.(string)
+
+		if !keep(id) {
+			lru.order.Remove(elem)
+			delete(lru.elements, id)
+			evicted = append(evicted, id)
+		}
+
+		elem = prev
+	}
+
+	return evicted
+}
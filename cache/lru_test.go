@@ -0,0 +1,27 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUIdCache(t *testing.T) {
+	lru := newLRUIdCache()
+
+	lru.Touch("a")
+	lru.Touch("b")
+	lru.Touch("c")
+	lru.Touch("a")
+
+	assert.Equal(t, 3, lru.Len())
+
+	// "b" is the least recently used but must be kept
+	evicted := lru.Evict(1, func(id string) bool { return id == "b" })
+
+	assert.Equal(t, []string{"c", "a"}, evicted)
+	assert.Equal(t, 1, lru.Len())
+
+	lru.Remove("b")
+	assert.Equal(t, 0, lru.Len())
+}
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// metadataEntry is a metadata value of an operation, as indexed by the cache
+type metadataEntry struct {
+	key   string
+	value string
+}
+
+// indexMetadata record the bug as having the metadata of its operations. The
+// caller must hold the lock.
+func (c *RepoCache) indexMetadata(id string, excerpt *BugExcerpt) {
+	add := func(key, value string) {
+		entry := metadataEntry{key: key, value: value}
+		ids, ok := c.metadata[entry]
+		if !ok {
+			ids = make(map[string]struct{})
+			c.metadata[entry] = ids
+		}
+		ids[id] = struct{}{}
+	}
+
+	// the create metadata are also part of Metadata, unless the excerpt has
+	// been written by an older version
+	for key, value := range excerpt.CreateMetadata {
+		add(key, value)
+	}
+	for key, values := range excerpt.Metadata {
+		for _, value := range values {
+			add(key, value)
+		}
+	}
+}
+
+// unindexMetadata remove the bug from the metadata index, according to its
+// current excerpt. The caller must hold the lock.
+func (c *RepoCache) unindexMetadata(id string) {
+	excerpt, ok := c.excerpts[id]
+	if !ok {
+		return
+	}
+
+	remove := func(key, value string) {
+		entry := metadataEntry{key: key, value: value}
+		delete(c.metadata[entry], id)
+		if len(c.metadata[entry]) == 0 {
+			delete(c.metadata, entry)
+		}
+	}
+
+	for key, value := range excerpt.CreateMetadata {
+		remove(key, value)
+	}
+	for key, values := range excerpt.Metadata {
+		for _, value := range values {
+			remove(key, value)
+		}
+	}
+}
+
+// ResolveBugMetadata retrieve a bug that has the exact given metadata on any
+// of its operations. It fails if multiple bugs match.
+func (c *RepoCache) ResolveBugMetadata(key string, value string) (*BugCache, error) {
+	return c.resolveBugMetadata(key, value, func(excerpt *BugExcerpt) bool {
+		return true
+	})
+}
+
+// resolveBugMetadata retrieve the bug having the given metadata in the index
+// and accepted by the filter
+func (c *RepoCache) resolveBugMetadata(key string, value string, filter func(excerpt *BugExcerpt) bool) (*BugCache, error) {
+	// preallocate but empty
+	matching := make([]string, 0, 5)
+
+	c.muBug.RLock()
+	for id := range c.metadata[metadataEntry{key: key, value: value}] {
+		if filter(c.excerpts[id]) {
+			matching = append(matching, id)
+		}
+	}
+	c.muBug.RUnlock()
+
+	if len(matching) > 1 {
+		return nil, bug.ErrMultipleMatch{Matching: matching}
+	}
+
+	if len(matching) == 0 {
+		return nil, bug.ErrBugNotExist
+	}
+
+	return c.ResolveBug(matching[0])
+}
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheMetadataIndex(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+
+	author, err := bug.GetUser(repo)
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	b1, err := c.NewBugRaw(author, now, "first", "message", nil, map[string]string{"origin": "1"})
+	assert.NoError(t, err)
+	assert.NoError(t, b1.AddCommentRaw(author, now, "comment", nil, map[string]string{"origin": "1-comment"}))
+	assert.NoError(t, b1.Commit())
+
+	b2, err := c.NewBugRaw(author, now, "second", "message", nil, map[string]string{"origin": "2"})
+	assert.NoError(t, err)
+	assert.NoError(t, b2.AddCommentRaw(author, now, "comment", nil, map[string]string{"shared": "x"}))
+	assert.NoError(t, b2.Commit())
+
+	assert.NoError(t, b1.AddCommentRaw(author, now, "comment", nil, map[string]string{"shared": "x"}))
+	assert.NoError(t, b1.Commit())
+
+	resolved, err := c.ResolveBugCreateMetadata("origin", "2")
+	assert.NoError(t, err)
+	assert.Equal(t, b2.Id(), resolved.Id())
+
+	// only the create operation count for ResolveBugCreateMetadata
+	_, err = c.ResolveBugCreateMetadata("origin", "1-comment")
+	assert.Equal(t, bug.ErrBugNotExist, err)
+
+	resolved, err = c.ResolveBugMetadata("origin", "1-comment")
+	assert.NoError(t, err)
+	assert.Equal(t, b1.Id(), resolved.Id())
+
+	_, err = c.ResolveBugMetadata("shared", "x")
+	assert.IsType(t, bug.ErrMultipleMatch{}, err)
+
+	// the index is rebuilt from the cache file
+	assert.NoError(t, c.Close())
+	c, err = NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	resolved, err = c.ResolveBugMetadata("origin", "1-comment")
+	assert.NoError(t, err)
+	assert.Equal(t, b1.Id(), resolved.Id())
+
+	assert.NoError(t, c.TrashBug(b2.Id()))
+
+	_, err = c.ResolveBugCreateMetadata("origin", "2")
+	assert.Equal(t, bug.ErrBugNotExist, err)
+
+	resolved, err = c.ResolveBugMetadata("shared", "x")
+	assert.NoError(t, err)
+	assert.Equal(t, b1.Id(), resolved.Id())
+}
@@ -2,6 +2,9 @@ package cache
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/MichaelMure/git-bug/repository"
 )
@@ -29,6 +32,18 @@ func (c *MultiRepoCache) RegisterRepository(ref string, repo repository.ClockedR
 	return nil
 }
 
+// RegisterRepositoryReadOnly register a named repository, opened read-only.
+// Use this for multi-repo setup that don't modify the bugs.
+func (c *MultiRepoCache) RegisterRepositoryReadOnly(ref string, repo repository.ClockedRepo) error {
+	r, err := NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+
+	c.repos[ref] = r
+	return nil
+}
+
 // RegisterDefaultRepository register a unnamed repository. Use this for mono-repo setup
 func (c *MultiRepoCache) RegisterDefaultRepository(repo repository.ClockedRepo) error {
 	r, err := NewRepoCache(repo)
@@ -57,11 +72,140 @@ func (c *MultiRepoCache) DefaultRepo() (*RepoCache, error) {
 func (c *MultiRepoCache) ResolveRepo(ref string) (*RepoCache, error) {
 	r, ok := c.repos[ref]
 	if !ok {
-		return nil, fmt.Errorf("unknown repo")
+		return nil, fmt.Errorf("unknown repository %s", ref)
 	}
 	return r, nil
 }
 
+// Names return the names of the repositories, sorted
+func (c *MultiRepoCache) Names() []string {
+	result := make([]string, 0, len(c.repos))
+	for name := range c.repos {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// QualifiedId identify a bug across the repositories, by the name of the
+// repository holding it and its id. It's written "<repository>/<id>".
+type QualifiedId struct {
+	Repo string
+	Id   string
+}
+
+// SplitQualifiedId split an id or prefix qualified by the name of its
+// repository, like "project/3f5a"
+func SplitQualifiedId(qualified string) (repo string, id string, ok bool) {
+	i := strings.LastIndex(qualified, "/")
+	if i <= 0 || i == len(qualified)-1 {
+		return "", "", false
+	}
+	return qualified[:i], qualified[i+1:], true
+}
+
+// DisplayId return the id to show to the user for a bug, prefixed by the name
+// of its repository
+func (c *MultiRepoCache) DisplayId(id QualifiedId) string {
+	r, ok := c.repos[id.Repo]
+	if !ok {
+		return id.Repo + "/" + id.Id
+	}
+	return id.Repo + "/" + r.DisplayId(id.Id)
+}
+
+// QueryBugs return the bugs of all the repositories matching the query,
+// sorted together
+func (c *MultiRepoCache) QueryBugs(query *Query) []QualifiedId {
+	if query == nil {
+		query = NewQuery()
+	}
+
+	var all []*BugExcerpt
+	// the same bug can be in multiple repositories, the repository is found
+	// by excerpt rather than by id
+	repoOf := make(map[*BugExcerpt]string)
+
+	for name, r := range c.repos {
+		for _, excerpt := range r.filterExcerpts(query) {
+			all = append(all, excerpt)
+			repoOf[excerpt] = name
+		}
+	}
+
+	sort.Stable(querySorter(query, all))
+
+	result := make([]QualifiedId, len(all))
+	for i, excerpt := range all {
+		result[i] = QualifiedId{Repo: repoOf[excerpt], Id: excerpt.Id}
+	}
+
+	return result
+}
+
+// ResolveBugPrefix retrieve a bug from an id prefix qualified by the name of
+// its repository, like "project/3f5a", along with its repository
+func (c *MultiRepoCache) ResolveBugPrefix(qualified string) (*RepoCache, *BugCache, error) {
+	name, prefix, ok := SplitQualifiedId(qualified)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s is not a bug id prefixed by a repository name", qualified)
+	}
+
+	r, ok := c.repos[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown repository %s", name)
+	}
+
+	b, err := r.ResolveBugPrefix(prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r, b, nil
+}
+
+// Alias return the sequential alias of a bug, from the repository holding it
+func (c *MultiRepoCache) Alias(id string) (string, bool) {
+	for _, r := range c.repos {
+		if alias, ok := r.Alias(id); ok {
+			return alias, true
+		}
+	}
+	return "", false
+}
+
+// RepoOfBug return the repository holding a bug
+func (c *MultiRepoCache) RepoOfBug(id string) (*RepoCache, bool) {
+	for _, r := range c.repos {
+		if r.hasBug(id) {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// Watch refresh the repositories at the given interval, until stop is closed.
+// It return the first error encountered, if any, while the other repositories
+// keep being refreshed until stop is closed.
+func (c *MultiRepoCache) Watch(interval time.Duration, stop <-chan struct{}) error {
+	errs := make(chan error, len(c.repos))
+
+	for _, r := range c.repos {
+		go func(r *RepoCache) {
+			errs <- r.Watch(interval, stop)
+		}(r)
+	}
+
+	for range c.repos {
+		err := <-errs
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Close will do anything that is needed to close the cache properly
 func (c *MultiRepoCache) Close() error {
 	for _, cachedRepo := range c.repos {
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiRepoCacheQuery(t *testing.T) {
+	repoA := createTestRepo(t)
+	defer os.RemoveAll(repoA.GetPath())
+	repoB := createTestRepo(t)
+	defer os.RemoveAll(repoB.GetPath())
+
+	multi := NewMultiRepoCache()
+	assert.NoError(t, multi.RegisterRepository("a", repoA))
+	assert.NoError(t, multi.RegisterRepository("b", repoB))
+	defer multi.Close()
+
+	assert.Equal(t, []string{"a", "b"}, multi.Names())
+
+	a, err := multi.ResolveRepo("a")
+	assert.NoError(t, err)
+	b, err := multi.ResolveRepo("b")
+	assert.NoError(t, err)
+
+	author, err := bug.GetUser(repoA)
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	bugA, err := a.NewBugRaw(author, now, "in a", "message", nil, nil)
+	assert.NoError(t, err)
+	bugB, err := b.NewBugRaw(author, now.Add(10*time.Second), "in b", "message", nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, bugB.Close())
+
+	query, err := ParseQuery("sort:id")
+	assert.NoError(t, err)
+	ids := multi.QueryBugs(query)
+	assert.Len(t, ids, 2)
+	assert.Contains(t, ids, QualifiedId{Repo: "a", Id: bugA.Id()})
+	assert.Contains(t, ids, QualifiedId{Repo: "b", Id: bugB.Id()})
+
+	query, err = ParseQuery("status:closed")
+	assert.NoError(t, err)
+	assert.Equal(t, []QualifiedId{{Repo: "b", Id: bugB.Id()}}, multi.QueryBugs(query))
+
+	qualified := "b/" + bugB.HumanId()
+	r, resolved, err := multi.ResolveBugPrefix(qualified)
+	assert.NoError(t, err)
+	assert.Equal(t, b, r)
+	assert.Equal(t, bugB.Id(), resolved.Id())
+
+	_, _, err = multi.ResolveBugPrefix("a/" + bugB.HumanId())
+	assert.Error(t, err)
+	_, _, err = multi.ResolveBugPrefix("unknown/" + bugB.HumanId())
+	assert.Error(t, err)
+
+	assert.Equal(t, "a/"+a.DisplayId(bugA.Id()), multi.DisplayId(QualifiedId{Repo: "a", Id: bugA.Id()}))
+}
+
+func TestSplitQualifiedId(t *testing.T) {
+	var tests = []struct {
+		input string
+		repo  string
+		id    string
+		ok    bool
+	}{
+		{"project/3f5a", "project", "3f5a", true},
+		{"group/project/3f5a", "group/project", "3f5a", true},
+		{"3f5a", "", "", false},
+		{"/3f5a", "", "", false},
+		{"project/", "", "", false},
+	}
+
+	for _, test := range tests {
+		repo, id, ok := SplitQualifiedId(test.input)
+		assert.Equal(t, test.ok, ok, test.input)
+		assert.Equal(t, test.repo, repo, test.input)
+		assert.Equal(t, test.id, id, test.input)
+	}
+}
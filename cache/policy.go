@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// ErrNotAllowed is returned when the policy doesn't allow the user to perform
+// an action
+type ErrNotAllowed struct {
+	Action string
+}
+
+func (e ErrNotAllowed) Error() string {
+	return fmt.Sprintf("the policy doesn't allow you to %s", e.Action)
+}
+
+// loadPolicy read the access policy of the repo
+func (c *RepoCache) loadPolicy() error {
+	policy, err := bug.ReadPolicy(c.repo)
+	if err != nil {
+		return err
+	}
+
+	c.muBug.Lock()
+	c.policy = policy
+	c.muBug.Unlock()
+
+	return nil
+}
+
+// Policy return the access policy of the repo
+func (c *RepoCache) Policy() *bug.Policy {
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+	return c.policy
+}
+
+// SetPolicy store a new access policy, optionally signed with the key of the
+// user
+func (c *RepoCache) SetPolicy(policy *bug.Policy, sign bool) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+
+	err := bug.WritePolicy(c.repo, policy, sign)
+	if err != nil {
+		return err
+	}
+
+	return c.loadPolicy()
+}
+
+// checkAllowed return an error if the policy doesn't allow the user to
+// perform one of the actions on a bug, nil for a new bug
+func (c *RepoCache) checkAllowed(author bug.Person, snap *bug.Snapshot, actions ...string) error {
+	policy := c.Policy()
+
+	for _, action := range actions {
+		if !policy.Allowed(author, action, snap) {
+			return ErrNotAllowed{Action: action}
+		}
+	}
+
+	return nil
+}
+
+// AllowedActions return the actions the user is allowed to perform on the
+// bug according to the policy, so that the UIs can hide or disable the others
+func (c *BugCache) AllowedActions() ([]string, error) {
+	author, err := bug.GetUser(c.repoCache.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.AllowedActionsAs(author), nil
+}
+
+// AllowedActionsAs return the actions an author is allowed to perform on the
+// bug according to the policy
+func (c *BugCache) AllowedActionsAs(author bug.Person) []string {
+	return c.repoCache.Policy().AllowedActions(author, c.Snapshot())
+}
+
+// Allowed tell if the user is allowed to perform an action on the bug
+// according to the policy
+func (c *BugCache) Allowed(action string) (bool, error) {
+	author, err := bug.GetUser(c.repoCache.repo)
+	if err != nil {
+		return false, err
+	}
+
+	return c.repoCache.Policy().Allowed(author, action, c.Snapshot()), nil
+}
+
+// checkAllowed return an error if the policy doesn't allow the author to
+// perform one of the actions on the bug
+func (c *BugCache) checkAllowed(author bug.Person, actions ...string) error {
+	return c.repoCache.checkAllowed(author, c.Snapshot(), actions...)
+}
@@ -2,14 +2,31 @@ package cache
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
+
+	"github.com/MichaelMure/git-bug/bug"
 )
 
 type Query struct {
 	Filters
 	OrderBy
 	OrderDirection
+
+	// Expr is the boolean combination of filters parsed from the query
+	// language, nil to match everything. It's combined with the Filters.
+	Expr Filter
+
+	// Sorted tell if the order was given by the query, directly or through a
+	// saved query, rather than being the default one
+	Sorted bool
+
+	// Audience restrict the result to the bugs visible by an audience. It's
+	// not part of the query language but set by the servers, nil for no
+	// restriction.
+	Audience bug.Audience
 }
 
 // Return an identity query with default sorting (creation-desc)
@@ -23,107 +40,137 @@ func NewQuery() *Query {
 // ParseQuery parse a query DSL
 //
 // Ex: "status:open author:descartes sort:edit-asc"
+// Ex: `(label:"needs design" OR label:ux) -status:closed`
 //
 // Supported filter qualifiers and syntax are described in docs/queries.md
 func ParseQuery(query string) (*Query, error) {
-	fields := splitQuery(query)
+	return parseQuery(query, nil, 0)
+}
 
-	result := &Query{
-		OrderBy:        OrderByCreation,
-		OrderDirection: OrderDescending,
+// ParseQueryWithSaved parse a query DSL that can reference saved queries with
+// "@name"
+func ParseQueryWithSaved(query string, saved SavedQueryLookup) (*Query, error) {
+	return parseQuery(query, saved, 0)
+}
+
+// Match check if a bug match the query
+func (q *Query) Match(excerpt *BugExcerpt) bool {
+	if q.Expr != nil && !q.Expr(excerpt) {
+		return false
 	}
 
-	sortingDone := false
+	return q.Filters.Match(excerpt)
+}
 
-	for _, field := range fields {
-		split := strings.Split(field, ":")
-		if len(split) != 2 {
-			return nil, fmt.Errorf("can't parse \"%s\"", field)
-		}
+// qualifierFilter return the Filter for a single "name:value" qualifier
+func qualifierFilter(name string, value string) (Filter, error) {
+	switch name {
+	case "status", "state":
+		return StatusFilter(value)
 
-		qualifierName := split[0]
-		qualifierQuery := removeQuote(split[1])
+	case "author":
+		return AuthorFilter(value), nil
 
-		switch qualifierName {
-		case "status", "state":
-			f, err := StatusFilter(qualifierQuery)
-			if err != nil {
-				return nil, err
-			}
-			result.Status = append(result.Status, f)
+	case "participant":
+		return ParticipantFilter(value), nil
 
-		case "author":
-			f := AuthorFilter(qualifierQuery)
-			result.Author = append(result.Author, f)
+	case "actor":
+		return ActorFilter(value), nil
 
-		case "label":
-			f := LabelFilter(qualifierQuery)
-			result.Label = append(result.Label, f)
+	case "assignee":
+		return AssigneeFilter(value), nil
 
-		case "no":
-			err := result.parseNoFilter(qualifierQuery)
-			if err != nil {
-				return nil, err
-			}
+	case "label":
+		return LabelFilter(value), nil
 
-		case "sort":
-			if sortingDone {
-				return nil, fmt.Errorf("multiple sorting")
-			}
+	case "visibility":
+		return VisibilityFilter(value)
 
-			err := result.parseSorting(qualifierQuery)
-			if err != nil {
-				return nil, err
-			}
+	case "fixed-in":
+		return FixedInFilter(value), nil
 
-			sortingDone = true
+	case "created-after", "created-before", "edited-after", "edited-before":
+		t, err := parseQueryDate(value, time.Now())
+		if err != nil {
+			return nil, err
+		}
 
+		switch name {
+		case "created-after":
+			return CreatedAfterFilter(t), nil
+		case "created-before":
+			return CreatedBeforeFilter(t), nil
+		case "edited-after":
+			return EditedAfterFilter(t), nil
 		default:
-			return nil, fmt.Errorf("unknow qualifier name %s", qualifierName)
+			return EditedBeforeFilter(t), nil
 		}
-	}
 
-	return result, nil
-}
+	case "no":
+		return noFilter(value)
 
-func splitQuery(query string) []string {
-	lastQuote := rune(0)
-	f := func(c rune) bool {
-		switch {
-		case c == lastQuote:
-			lastQuote = rune(0)
-			return false
-		case lastQuote != rune(0):
-			return false
-		case unicode.In(c, unicode.Quotation_Mark):
-			lastQuote = c
-			return false
-		default:
-			return unicode.IsSpace(c)
+	default:
+		if strings.HasPrefix(name, scoreQualifierPrefix) {
+			scorer := strings.TrimPrefix(name, scoreQualifierPrefix)
+			if !isScorer(scorer) {
+				return nil, fmt.Errorf("unknown score %s", scorer)
+			}
+			return ScoreFilter(scorer, value)
 		}
+		return nil, fmt.Errorf("unknown qualifier name %s", name)
 	}
-
-	return strings.FieldsFunc(query, f)
 }
 
 func removeQuote(field string) string {
-	if len(field) >= 2 {
-		if field[0] == '"' && field[len(field)-1] == '"' {
-			return field[1 : len(field)-1]
+	runes := []rune(field)
+	if len(runes) >= 2 {
+		if unicode.In(runes[0], unicode.Quotation_Mark) && runes[len(runes)-1] == closingQuote(runes[0]) {
+			return string(runes[1 : len(runes)-1])
 		}
 	}
 	return field
 }
 
-func (q *Query) parseNoFilter(query string) error {
+// parseQueryDate parse the value of a date qualifier: either a date
+// ("2018-09-01", local time), a date and time ("2018-09-01T15:04:05Z") or a
+// duration before now ("12h", "30d", "2w").
+func parseQueryDate(value string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	if len(value) >= 2 {
+		count, err := strconv.Atoi(value[:len(value)-1])
+		if err == nil && count >= 0 {
+			switch value[len(value)-1] {
+			case 'h':
+				return now.Add(-time.Duration(count) * time.Hour), nil
+			case 'd':
+				return now.AddDate(0, 0, -count), nil
+			case 'w':
+				return now.AddDate(0, 0, -7*count), nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %s, expected a date (2018-09-01), a time (2018-09-01T15:04:05Z) or a duration (30d)", value)
+}
+
+func noFilter(query string) (Filter, error) {
 	switch query {
 	case "label":
-		q.NoFilters = append(q.NoFilters, NoLabelFilter())
+		return NoLabelFilter(), nil
+	case "fixed-in":
+		return NoFixedInFilter(), nil
+	case "assignee":
+		return NoAssigneeFilter(), nil
 	default:
-		return fmt.Errorf("unknown \"no\" filter %s", query)
+		return nil, fmt.Errorf("unknown \"no\" filter %s", query)
 	}
-
-	return nil
 }
 
 func (q *Query) parseSorting(query string) error {
@@ -152,6 +199,14 @@ func (q *Query) parseSorting(query string) error {
 		q.OrderBy = OrderByEdit
 		q.OrderDirection = OrderAscending
 
+	// default DESC
+	case "votes", "votes-desc":
+		q.OrderBy = OrderByVotes
+		q.OrderDirection = OrderDescending
+	case "votes-asc":
+		q.OrderBy = OrderByVotes
+		q.OrderDirection = OrderAscending
+
 	default:
 		return fmt.Errorf("unknow sorting %s", query)
 	}
@@ -0,0 +1,482 @@
+package cache
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrQuerySyntax is returned when a query can't be parsed. It points at the
+// offending token.
+type ErrQuerySyntax struct {
+	// Column is the position of the token in the query, starting at 1
+	Column int
+	Token  string
+	Reason string
+}
+
+func (e ErrQuerySyntax) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("invalid query at column %d: %s", e.Column, e.Reason)
+	}
+	return fmt.Sprintf("invalid query at column %d (\"%s\"): %s", e.Column, e.Token, e.Reason)
+}
+
+type queryTokenKind int
+
+const (
+	_ queryTokenKind = iota
+	tokenTerm
+	tokenOr
+	tokenAnd
+	tokenNot
+	tokenLParen
+	tokenRParen
+	tokenSaved
+)
+
+// maxSavedQueryDepth is how deep saved queries can reference each other,
+// which also stop the cycles
+const maxSavedQueryDepth = 10
+
+// SavedQueryLookup return the saved query with the given name, if any
+type SavedQueryLookup func(name string) (string, bool)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	// column of the token, starting at 1
+	column int
+
+	// only for terms and saved queries
+	name  string
+	value string
+}
+
+func (t queryToken) syntaxError(reason string) error {
+	return ErrQuerySyntax{Column: t.column, Token: t.text, Reason: reason}
+}
+
+// closingQuote return the rune closing a quotation opened by the given rune
+func closingQuote(r rune) rune {
+	switch r {
+	case '“':
+		return '”'
+	case '‘':
+		return '’'
+	case '«':
+		return '»'
+	default:
+		return r
+	}
+}
+
+// tokenizeQuery split a query into tokens: parentheses, the OR, AND and NOT
+// keywords, the "-" negation, the "@name" saved queries and the "name:value"
+// qualifiers. Values can be quoted to include spaces or parentheses.
+func tokenizeQuery(query string) ([]queryToken, error) {
+	runes := []rune(query)
+	var tokens []queryToken
+
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: tokenLParen, text: "(", column: i + 1})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: tokenRParen, text: ")", column: i + 1})
+			i++
+
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]):
+			tokens = append(tokens, queryToken{kind: tokenNot, text: "-", column: i + 1})
+			i++
+
+		default:
+			start := i
+			var quote rune
+			quoteStart := 0
+
+			for i < len(runes) {
+				r := runes[i]
+				if quote != 0 {
+					if r == quote {
+						quote = 0
+					}
+				} else if unicode.In(r, unicode.Quotation_Mark) {
+					quote = closingQuote(r)
+					quoteStart = i
+				} else if unicode.IsSpace(r) || r == '(' || r == ')' {
+					break
+				}
+				i++
+			}
+
+			if quote != 0 {
+				return nil, ErrQuerySyntax{
+					Column: quoteStart + 1,
+					Token:  string(runes[quoteStart:]),
+					Reason: "unterminated quote",
+				}
+			}
+
+			token, err := newWordToken(string(runes[start:i]), start+1)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token)
+		}
+	}
+
+	return tokens, nil
+}
+
+func newWordToken(word string, column int) (queryToken, error) {
+	token := queryToken{text: word, column: column}
+
+	switch strings.ToUpper(word) {
+	case "OR":
+		token.kind = tokenOr
+		return token, nil
+	case "AND":
+		token.kind = tokenAnd
+		return token, nil
+	case "NOT":
+		token.kind = tokenNot
+		return token, nil
+	}
+
+	if strings.HasPrefix(word, "@") {
+		token.kind = tokenSaved
+		token.name = strings.ToLower(word[1:])
+		if token.name == "" {
+			return token, token.syntaxError("missing saved query name")
+		}
+		return token, nil
+	}
+
+	split := strings.SplitN(word, ":", 2)
+	if len(split) != 2 {
+		return token, token.syntaxError("expected a qualifier such as label:bug")
+	}
+
+	token.kind = tokenTerm
+	token.name = strings.ToLower(split[0])
+	token.value = removeQuote(split[1])
+
+	if token.name == "" {
+		return token, token.syntaxError("missing qualifier name")
+	}
+	if token.value == "" {
+		return token, token.syntaxError("missing qualifier value")
+	}
+
+	return token, nil
+}
+
+// queryParser is a recursive descent parser for the following grammar, where
+// two filters next to each other are implicitly combined with AND:
+//
+//	or    := and ( "OR" and )*
+//	and   := unary ( "AND"? unary )*
+//	unary := ( "NOT" | "-" ) unary | "(" or ")" | qualifier | "@" name
+//
+// The sorting qualifier can only be used at the top level, outside of OR,
+// NOT and parentheses. A saved query is parsed as if it was in parentheses,
+// but its sorting is used at the top level if the query doesn't have one.
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+	query  *Query
+
+	saved SavedQueryLookup
+	depth int
+
+	sortingDone  bool
+	savedSorting *Query
+}
+
+func parseQuery(query string, saved SavedQueryLookup, depth int) (*Query, error) {
+	tokens, err := tokenizeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &queryParser{
+		tokens: tokens,
+		query:  NewQuery(),
+		saved:  saved,
+		depth:  depth,
+	}
+
+	return p.parse()
+}
+
+func (p *queryParser) parse() (*Query, error) {
+	if len(p.tokens) == 0 {
+		return p.query, nil
+	}
+
+	expr, err := p.parseOr(true)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		token := p.tokens[p.pos]
+		if token.kind == tokenRParen {
+			return nil, token.syntaxError("unbalanced parenthesis")
+		}
+		return nil, token.syntaxError("unexpected token")
+	}
+
+	p.query.Expr = expr
+
+	if !p.sortingDone && p.savedSorting != nil {
+		p.query.OrderBy = p.savedSorting.OrderBy
+		p.query.OrderDirection = p.savedSorting.OrderDirection
+		p.sortingDone = true
+	}
+
+	p.query.Sorted = p.sortingDone
+
+	return p.query, nil
+}
+
+// parseSaved parse the saved query referenced by a token. The saved query is
+// returned along its filter, nil to match everything.
+func (p *queryParser) parseSaved(token queryToken) (Filter, *Query, bool, error) {
+	if p.saved == nil {
+		return nil, nil, false, token.syntaxError("saved queries are not available")
+	}
+
+	str, ok := p.saved(token.name)
+	if !ok {
+		return nil, nil, false, token.syntaxError("unknown saved query")
+	}
+
+	if p.depth >= maxSavedQueryDepth {
+		return nil, nil, false, token.syntaxError("saved queries nested too deeply")
+	}
+
+	tokens, err := tokenizeQuery(str)
+	if err != nil {
+		return nil, nil, false, token.syntaxError(fmt.Sprintf("in the saved query: %v", err))
+	}
+
+	sub := &queryParser{
+		tokens: tokens,
+		query:  NewQuery(),
+		saved:  p.saved,
+		depth:  p.depth + 1,
+	}
+
+	query, err := sub.parse()
+	if err != nil {
+		return nil, nil, false, token.syntaxError(fmt.Sprintf("in the saved query: %v", err))
+	}
+
+	return query.Expr, query, sub.sortingDone, nil
+}
+
+func (p *queryParser) peek() (queryToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return queryToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// endColumn is the column just after the end of the query, for the errors
+// about a missing token
+func (p *queryParser) endColumn() int {
+	last := p.tokens[len(p.tokens)-1]
+	return last.column + len([]rune(last.text))
+}
+
+func (p *queryParser) parseOr(topLevel bool) (Filter, error) {
+	var branches []Filter
+	var sortToken *queryToken
+
+	for {
+		branch, sorting, err := p.parseAnd(topLevel)
+		if err != nil {
+			return nil, err
+		}
+		if sorting != nil {
+			sortToken = sorting
+		}
+		branches = append(branches, branch)
+
+		token, ok := p.peek()
+		if !ok || token.kind != tokenOr {
+			break
+		}
+		p.pos++
+
+		if _, ok := p.peek(); !ok {
+			return nil, token.syntaxError("expected a filter after OR")
+		}
+	}
+
+	if len(branches) == 1 {
+		return branches[0], nil
+	}
+
+	if sortToken != nil {
+		return nil, sortToken.syntaxError("sorting can't be combined with OR")
+	}
+
+	return Or(branches...), nil
+}
+
+// parseAnd parse a sequence of filters combined with AND. It also return the
+// sorting token, if one has been found.
+func (p *queryParser) parseAnd(topLevel bool) (Filter, *queryToken, error) {
+	var filters []Filter
+	var sortToken *queryToken
+	parsed := 0
+
+	for {
+		token, ok := p.peek()
+		if !ok || token.kind == tokenOr || token.kind == tokenRParen {
+			break
+		}
+
+		if token.kind == tokenAnd {
+			if parsed == 0 {
+				return nil, nil, token.syntaxError("expected a filter before AND")
+			}
+			p.pos++
+			if next, ok := p.peek(); !ok || next.kind == tokenOr || next.kind == tokenRParen {
+				return nil, nil, token.syntaxError("expected a filter after AND")
+			}
+			continue
+		}
+
+		if token.kind == tokenTerm && token.name == "sort" {
+			if !topLevel {
+				return nil, nil, token.syntaxError("sorting can only be used at the top level")
+			}
+			if p.sortingDone {
+				return nil, nil, token.syntaxError("multiple sorting")
+			}
+			err := p.query.parseSorting(token.value)
+			if err != nil {
+				return nil, nil, token.syntaxError(err.Error())
+			}
+			p.sortingDone = true
+			sortToken = &p.tokens[p.pos]
+			p.pos++
+			parsed++
+			continue
+		}
+
+		if token.kind == tokenSaved && topLevel {
+			filter, query, sorted, err := p.parseSaved(token)
+			if err != nil {
+				return nil, nil, err
+			}
+			if sorted && p.savedSorting == nil {
+				p.savedSorting = query
+			}
+			if filter != nil {
+				filters = append(filters, filter)
+			}
+			p.pos++
+			parsed++
+			continue
+		}
+
+		filter, err := p.parseUnary()
+		if err != nil {
+			return nil, nil, err
+		}
+		filters = append(filters, filter)
+		parsed++
+	}
+
+	if parsed == 0 {
+		token, ok := p.peek()
+		if !ok {
+			return nil, nil, ErrQuerySyntax{Column: p.endColumn(), Reason: "expected a filter"}
+		}
+		return nil, nil, token.syntaxError("expected a filter")
+	}
+
+	switch len(filters) {
+	case 0:
+		// only sorting
+		return nil, sortToken, nil
+	case 1:
+		return filters[0], sortToken, nil
+	default:
+		return And(filters...), sortToken, nil
+	}
+}
+
+func (p *queryParser) parseUnary() (Filter, error) {
+	token, _ := p.peek()
+	p.pos++
+
+	switch token.kind {
+	case tokenNot:
+		next, ok := p.peek()
+		if !ok || next.kind == tokenOr || next.kind == tokenAnd || next.kind == tokenRParen {
+			return nil, token.syntaxError("expected a filter to negate")
+		}
+		if next.kind == tokenTerm && next.name == "sort" {
+			return nil, next.syntaxError("sorting can't be negated")
+		}
+
+		filter, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(filter), nil
+
+	case tokenLParen:
+		if next, ok := p.peek(); ok && next.kind == tokenRParen {
+			return nil, token.syntaxError("empty parentheses")
+		}
+
+		filter, err := p.parseOr(false)
+		if err != nil {
+			return nil, err
+		}
+
+		closing, ok := p.peek()
+		if !ok || closing.kind != tokenRParen {
+			return nil, token.syntaxError("unclosed parenthesis")
+		}
+		p.pos++
+
+		return filter, nil
+
+	case tokenTerm:
+		filter, err := qualifierFilter(token.name, token.value)
+		if err != nil {
+			return nil, token.syntaxError(err.Error())
+		}
+		return filter, nil
+
+	case tokenSaved:
+		// the sorting of the saved query is ignored out of the top level
+		filter, _, _, err := p.parseSaved(token)
+		if err != nil {
+			return nil, err
+		}
+		if filter == nil {
+			return And(), nil
+		}
+		return filter, nil
+
+	default:
+		return nil, token.syntaxError("unexpected token")
+	}
+}
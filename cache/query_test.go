@@ -1,6 +1,11 @@
 package cache
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
 
 func TestQueryParse(t *testing.T) {
 
@@ -19,11 +24,27 @@ func TestQueryParse(t *testing.T) {
 		{"author:rene", true},
 		{`author:"René Descartes"`, true},
 
+		{"participant:rene", true},
+		{"actor:rene", true},
+		{"assignee:rene", true},
+
 		{"label:hello", true},
 		{`label:"Good first issue"`, true},
 
+		{"visibility:internal", true},
+		{"visibility:team-backend", true},
+		{"visibility:secret", false},
+
 		{"sort:edit", true},
+		{"sort:votes", true},
+		{"sort:votes-asc", true},
 		{"sort:unknown", false},
+
+		{"created-after:2018-09-01", true},
+		{"created-before:30d", true},
+		{"edited-after:2w", true},
+		{"edited-before:2018-09-01T15:04:05Z", true},
+		{"created-after:yesterday", false},
 	}
 
 	for _, test := range tests {
@@ -33,3 +54,218 @@ func TestQueryParse(t *testing.T) {
 		}
 	}
 }
+
+func TestQueryParseOperators(t *testing.T) {
+	var tests = []struct {
+		input string
+		ok    bool
+	}{
+		{"label:a OR label:b", true},
+		{"label:a AND label:b", true},
+		{"label:a label:b", true},
+		{"NOT label:a", true},
+		{"not label:a", true},
+		{"-label:a", true},
+		{"(label:a OR label:b) -status:closed", true},
+		{`label:"needs design" OR label:ux -status:closed`, true},
+		{`label:"a (b)"`, true},
+		{"((label:a))", true},
+		{"label:a sort:edit", true},
+		{"sort:edit (label:a OR label:b)", true},
+
+		{"label:a OR", false},
+		{"OR label:a", false},
+		{"label:a AND", false},
+		{"NOT", false},
+		{"-", false},
+		{"()", false},
+		{"(label:a", false},
+		{"label:a)", false},
+		{`label:"unterminated`, false},
+		{"-sort:edit", false},
+		{"(sort:edit)", false},
+		{"label:a OR sort:edit", false},
+		{"sort:edit sort:id", false},
+		{"unknown:value", false},
+	}
+
+	for _, test := range tests {
+		_, err := ParseQuery(test.input)
+		if (err == nil) != test.ok {
+			t.Fatalf("Unexpected parse result for %s, expected: %v, err: %v", test.input, test.ok, err)
+		}
+	}
+}
+
+func TestQueryParseError(t *testing.T) {
+	var tests = []struct {
+		input  string
+		column int
+		token  string
+	}{
+		{"label:a gibberish", 9, "gibberish"},
+		{"status:open OR status:unknown", 16, "status:unknown"},
+		{`label:a label:"oops`, 15, `"oops`},
+		{"(label:a OR label:b", 1, "("},
+		{"label:a ) label:b", 9, ")"},
+		{"label:a OR sort:edit", 12, "sort:edit"},
+		{"label:é OR", 9, "OR"},
+	}
+
+	for _, test := range tests {
+		_, err := ParseQuery(test.input)
+		syntaxErr, ok := err.(ErrQuerySyntax)
+		if !ok {
+			t.Fatalf("Expected a syntax error for %s, got: %v", test.input, err)
+		}
+		if syntaxErr.Column != test.column || syntaxErr.Token != test.token {
+			t.Fatalf("Unexpected error for %s: %v", test.input, err)
+		}
+	}
+}
+
+func TestQueryMatch(t *testing.T) {
+	design := &BugExcerpt{
+		Status: bug.OpenStatus,
+		Labels: []bug.Label{"needs design"},
+	}
+	ux := &BugExcerpt{
+		Status: bug.ClosedStatus,
+		Labels: []bug.Label{"ux"},
+	}
+	other := &BugExcerpt{
+		Status: bug.OpenStatus,
+		Labels: []bug.Label{"bug"},
+	}
+
+	var tests = []struct {
+		input    string
+		expected []bool
+	}{
+		{"", []bool{true, true, true}},
+		{`label:"needs design" OR label:ux`, []bool{true, true, false}},
+		{`label:"needs design" OR label:ux -status:closed`, []bool{true, false, false}},
+		{`(label:"needs design" OR label:ux) AND status:open`, []bool{true, false, false}},
+		{"NOT label:bug", []bool{true, true, false}},
+		{"-(label:bug OR label:ux)", []bool{true, false, false}},
+		{"status:open -label:bug sort:edit", []bool{true, false, false}},
+	}
+
+	for _, test := range tests {
+		query, err := ParseQuery(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, excerpt := range []*BugExcerpt{design, ux, other} {
+			if query.Match(excerpt) != test.expected[i] {
+				t.Fatalf("Unexpected match for %s on excerpt %d, expected: %v", test.input, i, test.expected[i])
+			}
+		}
+	}
+}
+
+func TestQueryMatchPersons(t *testing.T) {
+	rene := bug.Person{Name: "René Descartes"}
+	isaac := bug.Person{Name: "Isaac Newton", Login: "inewton"}
+
+	commented := &BugExcerpt{
+		Participants: []bug.Person{rene, isaac},
+		Actors:       []bug.Person{rene, isaac},
+	}
+	labeled := &BugExcerpt{
+		Participants: []bug.Person{rene},
+		Actors:       []bug.Person{rene, isaac},
+		Assignee:     &isaac,
+	}
+
+	var tests = []struct {
+		input    string
+		expected []bool
+	}{
+		{"participant:descartes", []bool{true, true}},
+		{"participant:inewton", []bool{true, false}},
+		{"actor:newton", []bool{true, true}},
+		{"actor:leibniz", []bool{false, false}},
+		{"assignee:newton", []bool{false, true}},
+		{"no:assignee", []bool{true, false}},
+	}
+
+	for _, test := range tests {
+		query, err := ParseQuery(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, excerpt := range []*BugExcerpt{commented, labeled} {
+			if query.Match(excerpt) != test.expected[i] {
+				t.Fatalf("Unexpected match for %s on excerpt %d, expected: %v", test.input, i, test.expected[i])
+			}
+		}
+	}
+}
+
+func TestQueryParseDate(t *testing.T) {
+	now := time.Date(2018, 9, 20, 12, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		input    string
+		expected time.Time
+		ok       bool
+	}{
+		{"2018-09-01", time.Date(2018, 9, 1, 0, 0, 0, 0, time.Local), true},
+		{"2018-09-01T15:04:05Z", time.Date(2018, 9, 1, 15, 4, 5, 0, time.UTC), true},
+		{"12h", time.Date(2018, 9, 20, 0, 0, 0, 0, time.UTC), true},
+		{"30d", time.Date(2018, 8, 21, 12, 0, 0, 0, time.UTC), true},
+		{"2w", time.Date(2018, 9, 6, 12, 0, 0, 0, time.UTC), true},
+		{"d", time.Time{}, false},
+		{"-3d", time.Time{}, false},
+		{"30y", time.Time{}, false},
+		{"yesterday", time.Time{}, false},
+	}
+
+	for _, test := range tests {
+		result, err := parseQueryDate(test.input, now)
+		if (err == nil) != test.ok {
+			t.Fatalf("Unexpected parse result for %s, expected: %v, err: %v", test.input, test.ok, err)
+		}
+		if err == nil && !result.Equal(test.expected) {
+			t.Fatalf("Unexpected date for %s: %v, expected: %v", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestQueryMatchDate(t *testing.T) {
+	old := &BugExcerpt{
+		CreateUnixTime: time.Now().AddDate(0, 0, -60).Unix(),
+		EditUnixTime:   time.Now().AddDate(0, 0, -1).Unix(),
+	}
+	recent := &BugExcerpt{
+		CreateUnixTime: time.Now().AddDate(0, 0, -2).Unix(),
+		EditUnixTime:   time.Now().AddDate(0, 0, -2).Unix(),
+	}
+
+	var tests = []struct {
+		input    string
+		expected []bool
+	}{
+		{"created-after:30d", []bool{false, true}},
+		{"created-before:30d", []bool{true, false}},
+		{"edited-after:36h", []bool{true, false}},
+		{"edited-before:36h", []bool{false, true}},
+		{"created-before:30d OR edited-before:36h", []bool{true, true}},
+	}
+
+	for _, test := range tests {
+		query, err := ParseQuery(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, excerpt := range []*BugExcerpt{old, recent} {
+			if query.Match(excerpt) != test.expected[i] {
+				t.Fatalf("Unexpected match for %s on excerpt %d, expected: %v", test.input, i, test.expected[i])
+			}
+		}
+	}
+}
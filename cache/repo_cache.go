@@ -1,65 +1,247 @@
 package cache
 
 import (
-	"bytes"
-	"encoding/gob"
+	"container/heap"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/repository"
 	"github.com/MichaelMure/git-bug/util/git"
 	"github.com/MichaelMure/git-bug/util/process"
+	"github.com/MichaelMure/git-bug/util/text"
+	"github.com/pkg/errors"
 )
 
-const cacheFile = "cache"
-const formatVersion = 1
+const labelPolicyConfigKey = "git-bug.label-policy"
+const textPolicyConfigKey = "git-bug.text-policy"
+const maxLoadedBugsConfigKey = "git-bug.cache-max-loaded-bugs"
+const defaultMaxLoadedBugs = 1000
 
 type RepoCache struct {
 	// the underlying repo
 	repo repository.ClockedRepo
+	// if true, the lock is not taken and the bugs can't be modified
+	readOnly bool
+
+	// protect the excerpts, the identities, the loaded bugs, the aliases,
+	// lastMerge and the policy against concurrent accesses
+	muBug sync.RWMutex
 	// excerpt of bugs data for all bugs
 	excerpts map[string]*BugExcerpt
+	// the commit hash of each bug ref at the time its excerpt was computed,
+	// to update only the bugs that changed
+	refHashes map[string]git.Hash
+	// the authors of the operations of the bugs, including the removed ones
+	identities map[string]*IdentityExcerpt
+	// the bugs having each metadata value, for the lookups of the bridges
+	metadata map[metadataEntry]map[string]struct{}
 	// bug loaded in memory
 	bugs map[string]*BugCache
+	// usage order of the loaded bugs
+	loadedBugs *lruIdCache
+	// the maximum number of bugs kept in memory, 0 for no limit
+	maxLoadedBugs int
+	// how the unsafe characters of new data are handled
+	textPolicy text.Policy
+	// how the bugs are identified for humans
+	idScheme IdScheme
+	// sequential aliases of the bugs
+	aliases *bug.Aliases
+	// when the cache was loaded
+	loadedAt time.Time
+	// when the last merge of remote bugs happened, if any
+	lastMerge time.Time
+	// who is allowed to do what on the bugs
+	policy *bug.Policy
+
+	// serialize the commits of the bugs, for two copies of a bug to not both
+	// commit on top of the same head. It's taken before the lock of the bug.
+	muCommit sync.Mutex
+
+	// protect the subscribers against concurrent accesses
+	muSubscribers sync.Mutex
+	// the channels notified of the changes of the bugs
+	subscribers map[chan BugEvent]struct{}
+
+	// protect the hooks against concurrent accesses
+	muHooks sync.Mutex
+	// the in-process hook handlers
+	hooks map[*HookHandler]struct{}
+
+	// the webhooks configured in the repository
+	webhooks []Webhook
+	// cancel the retries of the deliveries of the webhooks
+	webhooksCtx    context.Context
+	webhooksCancel func()
+	// the pending deliveries of the webhooks
+	deliveries sync.WaitGroup
 }
 
-func NewRepoCache(r repository.ClockedRepo) (*RepoCache, error) {
-	c := &RepoCache{
-		repo: r,
-		bugs: make(map[string]*BugCache),
+// ErrReadOnly is returned when trying to modify the bugs through a read-only
+// cache
+var ErrReadOnly = fmt.Errorf("the bug cache is opened read-only")
+
+// ErrLocked is returned when the repository is already used by another
+// process holding the lock
+type ErrLocked struct {
+	Pid int
+}
+
+func (e ErrLocked) Error() string {
+	return fmt.Sprintf("the repository you want to access is already locked by the process pid %d", e.Pid)
+}
+
+func newRepoCache(r repository.ClockedRepo) *RepoCache {
+	return &RepoCache{
+		repo:        r,
+		bugs:        make(map[string]*BugCache),
+		identities:  make(map[string]*IdentityExcerpt),
+		metadata:    make(map[metadataEntry]map[string]struct{}),
+		loadedBugs:  newLRUIdCache(),
+		subscribers: make(map[chan BugEvent]struct{}),
+		hooks:       make(map[*HookHandler]struct{}),
 	}
+}
+
+func NewRepoCache(r repository.ClockedRepo) (*RepoCache, error) {
+	c := newRepoCache(r)
 
 	err := c.lock()
 	if err != nil {
 		return &RepoCache{}, err
 	}
 
+	err = c.loadSettings()
+	if err != nil {
+		return nil, err
+	}
+
 	err = c.load()
 	if err == nil {
-		return c, nil
+		err = c.updateCache()
+		if err != nil {
+			return nil, err
+		}
+
+		c.loadedAt = time.Now()
+		return c, c.assignAliases()
 	}
 
+	warnInvalidCache(err)
+
 	err = c.buildCache()
 	if err != nil {
 		return nil, err
 	}
 
+	c.loadedAt = time.Now()
+
+	err = c.assignAliases()
+	if err != nil {
+		return nil, err
+	}
+
 	return c, c.write()
 }
 
+// NewRepoCacheReadOnly open the cache of a repository without taking the
+// lock, so that it can be used concurrently with another process, like a
+// reporting tool next to the web UI. The bugs can't be modified, and the cache
+// file is never written: if it's missing or outdated, the bugs are compiled
+// in memory.
+func NewRepoCacheReadOnly(r repository.ClockedRepo) (*RepoCache, error) {
+	c := newRepoCache(r)
+	c.readOnly = true
+
+	err := c.loadSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.load()
+	if err == nil {
+		err = c.updateCache()
+	} else {
+		warnInvalidCache(err)
+		err = c.buildCache()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.loadedAt = time.Now()
+	return c, nil
+}
+
+// warnInvalidCache tell the user why the cache is built again, unless it's
+// simply the first use
+func warnInvalidCache(err error) {
+	if os.IsNotExist(err) {
+		return
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "Invalid bug cache (%v), rebuilding.\n", err)
+}
+
+// loadSettings read the configuration, the aliases, the webhooks and the
+// policy of the repository
+func (c *RepoCache) loadSettings() error {
+	err := c.loadMaxLoadedBugs()
+	if err != nil {
+		return err
+	}
+
+	err = c.loadTextPolicy()
+	if err != nil {
+		return err
+	}
+
+	err = c.loadAliases()
+	if err != nil {
+		return err
+	}
+
+	err = c.loadWebhooks()
+	if err != nil {
+		return err
+	}
+
+	return c.loadPolicy()
+}
+
+// ReadOnly tell if the cache has been opened read-only
+func (c *RepoCache) ReadOnly() bool {
+	return c.readOnly
+}
+
+// checkWritable fail if the cache has been opened read-only
+func (c *RepoCache) checkWritable() error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+	return nil
+}
+
 // GetPath returns the path to the repo.
 func (c *RepoCache) GetPath() string {
 	return c.repo.GetPath()
 }
 
+// GetGitDir returns the path to the git directory of the repo.
+func (c *RepoCache) GetGitDir() string {
+	return c.repo.GetGitDir()
+}
+
 // GetPath returns the path to the repo.
 func (c *RepoCache) GetCoreEditor() (string, error) {
 	return c.repo.GetCoreEditor()
@@ -75,6 +257,11 @@ func (c *RepoCache) GetUserEmail() (string, error) {
 	return c.repo.GetUserEmail()
 }
 
+// GetUser return the identity authoring the changes, the adopted one if any
+func (c *RepoCache) GetUser() (bug.Person, error) {
+	return bug.GetUser(c.repo)
+}
+
 // StoreConfig store a single key/value pair in the config of the repo
 func (c *RepoCache) StoreConfig(key string, value string) error {
 	return c.repo.StoreConfig(key, value)
@@ -113,109 +300,313 @@ func (c *RepoCache) lock() error {
 }
 
 func (c *RepoCache) Close() error {
+	c.closeSubscribers()
+	c.waitWebhooks()
+
+	if c.readOnly {
+		return nil
+	}
+
+	// flush the cache before releasing the lock
+	err := c.write()
+	if err != nil {
+		return err
+	}
+
 	lockPath := repoLockFilePath(c.repo)
 	return os.Remove(lockPath)
 }
 
 // bugUpdated is a callback to trigger when the excerpt of a bug changed,
 // that is each time a bug is updated
-func (c *RepoCache) bugUpdated(id string) error {
-	b, ok := c.bugs[id]
-	if !ok {
-		panic("missing bug in the cache")
-	}
+func (c *RepoCache) bugUpdated(b *BugCache) error {
+	excerpt := b.excerpt()
 
-	c.excerpts[id] = NewBugExcerpt(b.bug, b.Snapshot())
+	c.muBug.Lock()
 
-	return c.write()
-}
+	kind := BugUpdated
+	if _, ok := c.excerpts[b.Id()]; !ok {
+		kind = BugCreated
+	}
 
-// load will try to read from the disk the bug cache file
-func (c *RepoCache) load() error {
-	f, err := os.Open(cacheFilePath(c.repo))
+	// the bug might have been evicted from memory while still in use
+	c.addLoadedBug(b)
+	c.setExcerpt(b.Id(), excerpt)
+	c.muBug.Unlock()
+
+	err := c.write()
 	if err != nil {
 		return err
 	}
 
-	decoder := gob.NewDecoder(f)
-
-	aux := struct {
-		Version  uint
-		Excerpts map[string]*BugExcerpt
-	}{}
+	c.notify(BugEvent{Kind: kind, Id: b.Id()})
+	return nil
+}
 
-	err = decoder.Decode(&aux)
+// loadMaxLoadedBugs read from the configuration the maximum number of bugs
+// kept in memory
+func (c *RepoCache) loadMaxLoadedBugs() error {
+	configs, err := c.repo.ReadConfigs(maxLoadedBugsConfigKey)
 	if err != nil {
 		return err
 	}
 
-	if aux.Version != 1 {
-		return fmt.Errorf("unknown cache format version %v", aux.Version)
+	value, ok := configs[maxLoadedBugsConfigKey]
+	if !ok {
+		c.maxLoadedBugs = defaultMaxLoadedBugs
+		return nil
+	}
+
+	c.maxLoadedBugs, err = strconv.Atoi(value)
+	if err != nil || c.maxLoadedBugs < 0 {
+		return fmt.Errorf("invalid value for %s: %s", maxLoadedBugsConfigKey, value)
 	}
 
-	c.excerpts = aux.Excerpts
 	return nil
 }
 
-// write will serialize on disk the bug cache file
-func (c *RepoCache) write() error {
-	var data bytes.Buffer
-
-	aux := struct {
-		Version  uint
-		Excerpts map[string]*BugExcerpt
-	}{
-		Version:  formatVersion,
-		Excerpts: c.excerpts,
+// addLoadedBug keep a bug in memory, evicting the least recently used ones if
+// needed. The bugs with uncommitted operations are never evicted. An evicted
+// bug still held by a caller stays usable, its commits being rebased on the
+// changes of the new copy.
+// The caller must hold the write lock.
+func (c *RepoCache) addLoadedBug(b *BugCache) {
+	c.bugs[b.Id()] = b
+	c.loadedBugs.Touch(b.Id())
+
+	if c.maxLoadedBugs == 0 {
+		return
 	}
 
-	encoder := gob.NewEncoder(&data)
+	evicted := c.loadedBugs.Evict(c.maxLoadedBugs, func(id string) bool {
+		return c.bugs[id].hasPendingOp()
+	})
 
-	err := encoder.Encode(aux)
-	if err != nil {
-		return err
+	for _, id := range evicted {
+		delete(c.bugs, id)
 	}
+}
+
+// removeLoadedBug drop a bug from memory, if loaded.
+// The caller must hold the write lock.
+func (c *RepoCache) removeLoadedBug(id string) {
+	delete(c.bugs, id)
+	c.loadedBugs.Remove(id)
+}
 
-	f, err := os.Create(cacheFilePath(c.repo))
+// load will try to read from the disk the bug cache file
+func (c *RepoCache) buildCache() error {
+	_, _ = fmt.Fprintf(os.Stderr, "Building bug cache... ")
+
+	ids, err := bug.ListLocalIds(c.repo)
 	if err != nil {
 		return err
 	}
 
-	_, err = f.Write(data.Bytes())
+	excerpts, refHashes, err := c.compileBugs(ids, stderrProgress("Building bug cache... "))
 	if err != nil {
 		return err
 	}
 
-	return f.Close()
+	c.muBug.Lock()
+	c.refHashes = refHashes
+	c.resetExcerpts(excerpts)
+	c.muBug.Unlock()
+
+	_, _ = fmt.Fprintln(os.Stderr, "Done.")
+	return nil
 }
 
-func cacheFilePath(repo repository.Repo) string {
-	return path.Join(repo.GetPath(), ".git", "git-bug", cacheFile)
+// compiledBug is the result of the compilation of a bug by a worker
+type compiledBug struct {
+	id      string
+	snap    *bug.Snapshot
+	excerpt *BugExcerpt
+	hash    git.Hash
+	err     error
 }
 
-func (c *RepoCache) buildCache() error {
-	_, _ = fmt.Fprintf(os.Stderr, "Building bug cache... ")
+// compileBugs read and compile the given bugs concurrently with a pool of
+// workers, one per CPU. The progress callback, if any, is called after each
+// bug from a single goroutine.
+func (c *RepoCache) compileBugs(ids []string, progress func(done, total int)) (map[string]*BugExcerpt, map[string]git.Hash, error) {
+	excerpts := make(map[string]*BugExcerpt, len(ids))
+	refHashes := make(map[string]git.Hash, len(ids))
+	// kept until the scores are computed, once all the bugs are compiled
+	snaps := make(map[string]*bug.Snapshot, len(ids))
+
+	jobs := make(chan string)
+	results := make(chan compiledBug)
+	stop := make(chan struct{})
+
+	workers := runtime.NumCPU()
+	if workers > len(ids) {
+		workers = len(ids)
+	}
 
-	c.excerpts = make(map[string]*BugExcerpt)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for id := range jobs {
+				result := compiledBug{id: id}
+
+				b, err := bug.ReadLocalBug(c.repo, id)
+				if err != nil {
+					result.err = err
+				} else {
+					snap := b.Compile()
+					result.snap = &snap
+					result.excerpt = NewBugExcerpt(b, &snap)
+					result.hash = b.LastCommit()
+				}
+
+				select {
+				case results <- result:
+				case <-stop:
+					return
+				}
+			}
+		}()
+	}
 
-	allBugs := bug.ReadAllLocalBugs(c.repo)
+	go func() {
+		defer close(jobs)
+		for _, id := range ids {
+			select {
+			case jobs <- id:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for result := range results {
+		if result.err != nil {
+			// unblock the workers and the feeder, then report the error
+			close(stop)
+			return nil, nil, result.err
+		}
+
+		excerpts[result.id] = result.excerpt
+		refHashes[result.id] = result.hash
+		snaps[result.id] = result.snap
 
-	for b := range allBugs {
-		if b.Err != nil {
-			return b.Err
+		if progress != nil {
+			progress(len(excerpts), len(ids))
 		}
+	}
+
+	c.scoreExcerpts(excerpts, snaps)
+
+	return excerpts, refHashes, nil
+}
+
+// stderrProgress return a progress callback rewriting a counter after the
+// given prefix on the standard error
+func stderrProgress(prefix string) func(done, total int) {
+	return func(done, total int) {
+		_, _ = fmt.Fprintf(os.Stderr, "\r%s%d/%d ", prefix, done, total)
+	}
+}
 
-		snap := b.Bug.Compile()
-		c.excerpts[b.Bug.Id()] = NewBugExcerpt(b.Bug, &snap)
+// updateCache bring a loaded cache up to date with the repository, by
+// recompiling only the bugs whose ref moved since the cache was written and
+// dropping the bugs that disappeared.
+func (c *RepoCache) updateCache() error {
+	outdated, removed, err := c.changedBugs()
+	if err != nil {
+		return err
+	}
+
+	if len(outdated) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	prefix := fmt.Sprintf("Updating bug cache (%d changed)... ", len(outdated)+len(removed))
+	_, _ = fmt.Fprint(os.Stderr, prefix)
+
+	excerpts, refHashes, err := c.compileBugs(outdated, stderrProgress(prefix))
+	if err != nil {
+		return err
 	}
 
+	c.muBug.Lock()
+	for id, excerpt := range excerpts {
+		c.setExcerpt(id, excerpt)
+		c.refHashes[id] = refHashes[id]
+	}
+	for _, id := range removed {
+		c.removeExcerpt(id)
+		delete(c.refHashes, id)
+	}
+	c.muBug.Unlock()
+
 	_, _ = fmt.Fprintln(os.Stderr, "Done.")
-	return nil
+
+	return c.write()
+}
+
+// changedBugs compare the bug refs with the cache, and return the bugs whose
+// ref moved or appeared, and the bugs that disappeared
+func (c *RepoCache) changedBugs() (outdated []string, removed []string, err error) {
+	current, err := bug.ListLocalRefHashes(c.repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
+	for id, hash := range current {
+		_, ok := c.excerpts[id]
+		if !ok || c.refHashes[id] != hash {
+			outdated = append(outdated, id)
+		}
+	}
+	for id := range c.excerpts {
+		if _, ok := current[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+
+	return outdated, removed, nil
+}
+
+// bugCommitted is a callback to trigger when a bug has been committed, to
+// keep track of its new ref
+func (c *RepoCache) bugCommitted(id string, hash git.Hash) error {
+	c.muBug.Lock()
+	c.refHashes[id] = hash
+	c.muBug.Unlock()
+
+	return c.write()
+}
+
+// hasBug tell if the bug is known by the cache
+func (c *RepoCache) hasBug(id string) bool {
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+	_, ok := c.excerpts[id]
+	return ok
 }
 
 // ResolveBug retrieve a bug matching the exact given id
 func (c *RepoCache) ResolveBug(id string) (*BugCache, error) {
+	c.muBug.Lock()
 	cached, ok := c.bugs[id]
+	if ok {
+		c.loadedBugs.Touch(id)
+	}
+	c.muBug.Unlock()
 	if ok {
 		return cached, nil
 	}
@@ -225,23 +616,49 @@ func (c *RepoCache) ResolveBug(id string) (*BugCache, error) {
 		return nil, err
 	}
 
+	c.muBug.Lock()
+	defer c.muBug.Unlock()
+
+	// the bug might have been loaded concurrently in the meantime
+	cached, ok = c.bugs[id]
+	if ok {
+		return cached, nil
+	}
+
 	cached = NewBugCache(c, b)
-	c.bugs[id] = cached
+	c.addLoadedBug(cached)
 
 	return cached, nil
 }
 
-// ResolveBugPrefix retrieve a bug matching an id prefix. It fails if multiple
-// bugs match.
+// ResolveBugPrefix retrieve a bug from any reference a human could use: a
+// full id, a human id or any other id prefix, or a sequential alias ("#N").
+// Ids are case insensitive. It fails if multiple bugs match.
 func (c *RepoCache) ResolveBugPrefix(prefix string) (*BugCache, error) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+
+	if strings.HasPrefix(prefix, bug.AliasPrefix) {
+		id, ok := c.resolveAlias(prefix)
+		if !ok {
+			return nil, bug.ErrBugNotExist
+		}
+		return c.ResolveBug(id)
+	}
+
+	if !isIdPrefix(prefix) {
+		return nil, bug.ErrBugNotExist
+	}
+
 	// preallocate but empty
 	matching := make([]string, 0, 5)
 
+	c.muBug.RLock()
 	for id := range c.excerpts {
 		if strings.HasPrefix(id, prefix) {
 			matching = append(matching, id)
 		}
 	}
+	c.muBug.RUnlock()
 
 	if len(matching) > 1 {
 		return nil, bug.ErrMultipleMatch{Matching: matching}
@@ -254,52 +671,116 @@ func (c *RepoCache) ResolveBugPrefix(prefix string) (*BugCache, error) {
 	return c.ResolveBug(matching[0])
 }
 
+// isIdPrefix tell if a string could be the prefix of a bug id
+func isIdPrefix(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f') {
+			return false
+		}
+	}
+
+	return true
+}
+
 // ResolveBugCreateMetadata retrieve a bug that has the exact given metadata on
 // its Create operation, that is, the first operation. It fails if multiple bugs
 // match.
 func (c *RepoCache) ResolveBugCreateMetadata(key string, value string) (*BugCache, error) {
-	// preallocate but empty
-	matching := make([]string, 0, 5)
+	return c.resolveBugMetadata(key, value, func(excerpt *BugExcerpt) bool {
+		return excerpt.CreateMetadata[key] == value
+	})
+}
 
-	for id, excerpt := range c.excerpts {
-		if excerpt.CreateMetadata[key] == value {
-			matching = append(matching, id)
-		}
+func (c *RepoCache) QueryBugs(query *Query) []string {
+	if query == nil {
+		return c.AllBugsIds()
 	}
 
-	if len(matching) > 1 {
-		return nil, bug.ErrMultipleMatch{Matching: matching}
-	}
+	filtered := c.filterExcerpts(query)
 
-	if len(matching) == 0 {
-		return nil, bug.ErrBugNotExist
-	}
+	sort.Sort(querySorter(query, filtered))
 
-	return c.ResolveBug(matching[0])
+	return excerptIds(filtered)
 }
 
-func (c *RepoCache) QueryBugs(query *Query) []string {
+// QueryBugsPage return a page of the bugs matching the query: at most limit
+// ids after skipping the first offset ones, along with the total number of
+// matching bugs. Only the bugs up to the end of the page are sorted, which
+// make it much cheaper than QueryBugs to display the first pages.
+func (c *RepoCache) QueryBugsPage(query *Query, offset int, limit int) ([]string, int) {
 	if query == nil {
-		return c.AllBugsIds()
+		query = NewQuery()
+	}
+
+	filtered := c.filterExcerpts(query)
+	total := len(filtered)
+
+	if offset >= total || limit <= 0 {
+		return []string{}, total
+	}
+
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	// keep the first "end" bugs in a heap having the last of them at the root
+	h := &excerptHeap{
+		less: func(a, b *BugExcerpt) bool {
+			return querySorter(query, []*BugExcerpt{a, b}).Less(0, 1)
+		},
+	}
+
+	for _, excerpt := range filtered {
+		if h.Len() < end {
+			heap.Push(h, excerpt)
+			continue
+		}
+		if h.less(excerpt, h.items[0]) {
+			h.items[0] = excerpt
+			heap.Fix(h, 0)
+		}
 	}
 
+	page := h.items
+	sort.Sort(querySorter(query, page))
+
+	return excerptIds(page[offset:]), total
+}
+
+// filterExcerpts return the excerpts of the bugs matching the query, unsorted
+func (c *RepoCache) filterExcerpts(query *Query) []*BugExcerpt {
 	var filtered []*BugExcerpt
 
+	c.muBug.RLock()
 	for _, excerpt := range c.excerpts {
-		if query.Match(excerpt) {
+		if query.Audience.CanSee(excerpt.Visibility) && query.Match(excerpt) {
 			filtered = append(filtered, excerpt)
 		}
 	}
+	c.muBug.RUnlock()
 
+	return filtered
+}
+
+// querySorter return the sort.Interface ordering the excerpts as requested
+// by the query
+func querySorter(query *Query, excerpts []*BugExcerpt) sort.Interface {
 	var sorter sort.Interface
 
 	switch query.OrderBy {
 	case OrderById:
-		sorter = BugsById(filtered)
+		sorter = BugsById(excerpts)
 	case OrderByCreation:
-		sorter = BugsByCreationTime(filtered)
+		sorter = BugsByCreationTime(excerpts)
 	case OrderByEdit:
-		sorter = BugsByEditTime(filtered)
+		sorter = BugsByEditTime(excerpts)
+	case OrderByVotes:
+		sorter = BugsByVotes(excerpts)
 	default:
 		panic("missing sort type")
 	}
@@ -308,19 +789,45 @@ func (c *RepoCache) QueryBugs(query *Query) []string {
 		sorter = sort.Reverse(sorter)
 	}
 
-	sort.Sort(sorter)
+	return sorter
+}
 
-	result := make([]string, len(filtered))
+func excerptIds(excerpts []*BugExcerpt) []string {
+	result := make([]string, len(excerpts))
 
-	for i, val := range filtered {
+	for i, val := range excerpts {
 		result[i] = val.Id
 	}
 
 	return result
 }
 
+// excerptHeap is a heap of excerpts having the last one according to less at
+// the root
+type excerptHeap struct {
+	items []*BugExcerpt
+	less  func(a, b *BugExcerpt) bool
+}
+
+func (h excerptHeap) Len() int           { return len(h.items) }
+func (h excerptHeap) Less(i, j int) bool { return h.less(h.items[j], h.items[i]) }
+func (h excerptHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *excerptHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(*BugExcerpt))
+}
+
+func (h *excerptHeap) Pop() interface{} {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
 // AllBugsIds return all known bug ids
 func (c *RepoCache) AllBugsIds() []string {
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
 	result := make([]string, len(c.excerpts))
 
 	i := 0
@@ -332,41 +839,161 @@ func (c *RepoCache) AllBugsIds() []string {
 	return result
 }
 
+// ClearBug remove a bug from memory. It's still available in the
+// repository and will be read again if needed.
+func (c *RepoCache) ClearBug(id string) {
+	c.muBug.Lock()
+	defer c.muBug.Unlock()
+
+	c.removeLoadedBug(id)
+}
+
 // ClearAllBugs clear all bugs kept in memory
 func (c *RepoCache) ClearAllBugs() {
+	c.muBug.Lock()
+	defer c.muBug.Unlock()
+
 	c.bugs = make(map[string]*BugCache)
+	c.loadedBugs = newLRUIdCache()
+}
+
+// Warm load in memory and compile the n most recently edited bugs, to avoid
+// the latency of their first access. No more bugs than the limit of bugs kept
+// in memory are loaded. The progress callback, if any, is called after each
+// bug. It return the number of bugs loaded.
+func (c *RepoCache) Warm(n int, progress func(done, total int)) (int, error) {
+	query := NewQuery()
+	query.OrderBy = OrderByEdit
+	query.OrderDirection = OrderDescending
+
+	c.muBug.RLock()
+	maxLoadedBugs := c.maxLoadedBugs
+	c.muBug.RUnlock()
+
+	if maxLoadedBugs > 0 && n > maxLoadedBugs {
+		n = maxLoadedBugs
+	}
+
+	ids, _ := c.QueryBugsPage(query, 0, n)
+
+	for i, id := range ids {
+		b, err := c.ResolveBug(id)
+		if err != nil {
+			return i, err
+		}
+
+		b.Snapshot()
+
+		if progress != nil {
+			progress(i+1, len(ids))
+		}
+	}
+
+	return len(ids), nil
 }
 
 // ValidLabels list valid labels
 //
 // Note: in the future, a proper label policy could be implemented where valid
 // labels are defined in a configuration file. Until that, the default behavior
-// is to return the list of labels already used.
+// is to return the list of labels already used, with only one spelling for
+// equivalent labels.
 func (c *RepoCache) ValidLabels() []bug.Label {
 	set := map[bug.Label]interface{}{}
 
+	c.muBug.RLock()
 	for _, excerpt := range c.excerpts {
 		for _, l := range excerpt.Labels {
 			set[l] = nil
 		}
 	}
+	c.muBug.RUnlock()
 
-	result := make([]bug.Label, len(set))
-
-	i := 0
+	all := make([]bug.Label, 0, len(set))
 	for l := range set {
-		result[i] = l
-		i++
+		all = append(all, l)
 	}
 
-	// Sort
-	sort.Slice(result, func(i, j int) bool {
-		return string(result[i]) < string(result[j])
-	})
+	bug.SortLabels(all)
+
+	// equivalent labels are next to each other once sorted, only keep the first one
+	result := make([]bug.Label, 0, len(all))
+	for _, l := range all {
+		if len(result) > 0 && result[len(result)-1].Equivalent(l) {
+			continue
+		}
+		result = append(result, l)
+	}
 
 	return result
 }
 
+// LabelPolicy read the label policy from the configuration of the repo
+func (c *RepoCache) LabelPolicy() (bug.LabelPolicy, error) {
+	configs, err := c.repo.ReadConfigs(labelPolicyConfigKey)
+	if err != nil {
+		return 0, err
+	}
+
+	return bug.LabelPolicyFromString(configs[labelPolicyConfigKey])
+}
+
+// loadTextPolicy read the text policy from the configuration of the repo
+func (c *RepoCache) loadTextPolicy() error {
+	configs, err := c.repo.ReadConfigs(textPolicyConfigKey)
+	if err != nil {
+		return err
+	}
+
+	c.textPolicy, err = text.PolicyFromString(configs[textPolicyConfigKey])
+	return err
+}
+
+// sanitizeText process a text input according to the text policy of the repo
+func (c *RepoCache) sanitizeText(s string) string {
+	return c.textPolicy.Apply(s)
+}
+
+// sanitizePerson process the texts of a Person according to the text policy
+// of the repo
+func (c *RepoCache) sanitizePerson(p bug.Person) bug.Person {
+	p.Name = c.sanitizeText(p.Name)
+	p.Login = c.sanitizeText(p.Login)
+	p.Email = c.sanitizeText(p.Email)
+	return p
+}
+
+// canonicalLabels rewrite labels according to the label policy, and reuse
+// the spelling of an equivalent label if one is already used in the repo
+func (c *RepoCache) canonicalLabels(labels []string) ([]string, error) {
+	if len(labels) == 0 {
+		return labels, nil
+	}
+
+	policy, err := c.LabelPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	valid := c.ValidLabels()
+	result := make([]string, len(labels))
+
+	for i, str := range labels {
+		label := bug.Label(c.sanitizeText(str)).Canonical(policy)
+
+		for _, l := range valid {
+			if l.Equivalent(label) {
+				label = l
+				break
+			}
+		}
+
+		result[i] = string(label)
+	}
+
+	return result, nil
+}
+
 // NewBug create a new bug
 // The new bug is written in the repository (commit)
 func (c *RepoCache) NewBug(title string, message string) (*BugCache, error) {
@@ -381,18 +1008,39 @@ func (c *RepoCache) NewBugWithFiles(title string, message string, files []git.Ha
 		return nil, err
 	}
 
-	return c.NewBugRaw(author, time.Now().Unix(), title, message, files, nil)
+	return c.NewBugAs(author, title, message, files)
+}
+
+// NewBugAs create a new bug on behalf of an author, if the policy allows it
+// The new bug is written in the repository (commit)
+func (c *RepoCache) NewBugAs(author bug.Person, title string, message string, files []git.Hash) (*BugCache, error) {
+	err := c.checkAllowed(author, nil, bug.ActionCreate)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.NewBugRaw(author, time.Now(), title, message, files, nil)
 }
 
 // NewBugWithFilesMeta create a new bug with attached files for the message, as
 // well as metadata for the Create operation.
 // The new bug is written in the repository (commit)
-func (c *RepoCache) NewBugRaw(author bug.Person, unixTime int64, title string, message string, files []git.Hash, metadata map[string]string) (*BugCache, error) {
-	b, op, err := bug.CreateWithFiles(author, unixTime, title, message, files)
+func (c *RepoCache) NewBugRaw(author bug.Person, date time.Time, title string, message string, files []git.Hash, metadata map[string]string) (*BugCache, error) {
+	if err := c.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	author = c.sanitizePerson(author)
+	title = c.sanitizeText(title)
+	message = c.sanitizeText(message)
+
+	b, op, err := bug.CreateWithFiles(author, date.Unix(), title, message, files)
 	if err != nil {
 		return nil, err
 	}
 
+	op.SetTzOffset(date)
+
 	for key, value := range metadata {
 		op.SetMetadata(key, value)
 	}
@@ -403,13 +1051,26 @@ func (c *RepoCache) NewBugRaw(author bug.Person, unixTime int64, title string, m
 	}
 
 	cached := NewBugCache(c, b)
-	c.bugs[b.Id()] = cached
 
-	err = c.bugUpdated(b.Id())
+	c.muBug.Lock()
+	c.refHashes[b.Id()] = b.LastCommit()
+	c.muBug.Unlock()
+
+	err = c.bugUpdated(cached)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.assignAliases()
 	if err != nil {
 		return nil, err
 	}
 
+	event := newHookEvent(HookBugCreated, cached.Snapshot())
+	event.Author = &author
+	event.Message = message
+	c.fireHook(event)
+
 	return cached, nil
 }
 
@@ -419,15 +1080,44 @@ func (c *RepoCache) Fetch(remote string) (string, error) {
 	return bug.Fetch(c.repo, remote)
 }
 
+// FetchContext retrieve update from a remote, stopping when the context is
+// canceled
+func (c *RepoCache) FetchContext(ctx context.Context, remote string) (string, error) {
+	return bug.FetchContext(ctx, c.repo, remote)
+}
+
+// RemoteBugCount return the number of bugs fetched from a remote, that is
+// the number of results of MergeAll
+func (c *RepoCache) RemoteBugCount(remote string) (int, error) {
+	return bug.RemoteBugCount(c.repo, remote)
+}
+
 // MergeAll will merge all the available remote bug
 func (c *RepoCache) MergeAll(remote string) <-chan bug.MergeResult {
+	return c.MergeAllContext(context.Background(), remote)
+}
+
+// MergeAllContext will merge all the available remote bug, until the
+// context is canceled. The bugs merged before are kept in the cache.
+func (c *RepoCache) MergeAllContext(ctx context.Context, remote string) <-chan bug.MergeResult {
 	out := make(chan bug.MergeResult)
 
+	if err := c.checkWritable(); err != nil {
+		go func() {
+			out <- bug.MergeResult{Err: err}
+			close(out)
+		}()
+		return out
+	}
+
 	// Intercept merge results to update the cache properly
 	go func() {
 		defer close(out)
 
-		results := bug.MergeAll(c.repo, remote)
+		var events []BugEvent
+		var hookEvents []HookEvent
+
+		results := bug.MergeAllContext(ctx, c.repo, remote)
 		for result := range results {
 			out <- result
 
@@ -441,28 +1131,304 @@ func (c *RepoCache) MergeAll(remote string) <-chan bug.MergeResult {
 			case bug.MergeStatusNew, bug.MergeStatusUpdated:
 				b := result.Bug
 				snap := b.Compile()
-				c.excerpts[id] = NewBugExcerpt(b, &snap)
+				excerpt := c.newExcerpt(b, &snap)
+
+				c.muBug.Lock()
+				// drop the now outdated version loaded in memory, if any
+				c.removeLoadedBug(id)
+				c.setExcerpt(id, excerpt)
+				c.refHashes[id] = b.LastCommit()
+				c.muBug.Unlock()
+
+				events = append(events, mergeEvent(result))
+
+				hookEvent := newHookEvent(HookMergeApplied, &snap)
+				hookEvent.Remote = remote
+				hookEvent.MergeStatus = result.String()
+				hookEvents = append(hookEvents, hookEvent)
 			}
 		}
 
-		err := c.write()
+		c.muBug.Lock()
+		c.lastMerge = time.Now()
+		c.muBug.Unlock()
+
+		// the policy of the remote might have been adopted
+		err := c.loadPolicy()
+		if err != nil {
+			out <- bug.MergeResult{Err: errors.Wrap(err, "loading the policy")}
+		}
+
+		// a broken aliases ref of the remote must not prevent the merge of the
+		// bugs: the error is reported as a warning and the new bugs get their
+		// alias on the next merge, to not assign numbers the remote might
+		// already have given
+		err = c.mergeAliases(remote)
+		if err != nil {
+			out <- bug.MergeResult{Warning: errors.Wrap(err, "merging the aliases")}
+		} else {
+			err = c.assignAliases()
+			if err != nil {
+				out <- bug.MergeResult{Err: errors.Wrap(err, "assigning the aliases")}
+			}
+		}
+
+		err = c.write()
 
 		// No easy way out here ..
 		if err != nil {
 			panic(err)
 		}
+
+		for _, event := range events {
+			c.notify(event)
+		}
+
+		for _, event := range hookEvents {
+			c.fireHook(event)
+		}
 	}()
 
 	return out
 }
 
+// TrashBug soft-delete a bug. It is hidden from the queries and not pushed
+// anymore, until restored.
+func (c *RepoCache) TrashBug(id string) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+
+	err := bug.Trash(c.repo, id)
+	if err != nil {
+		return err
+	}
+
+	c.muBug.Lock()
+	c.removeLoadedBug(id)
+	c.removeExcerpt(id)
+	delete(c.refHashes, id)
+	c.muBug.Unlock()
+
+	err = c.write()
+	if err != nil {
+		return err
+	}
+
+	c.notify(BugEvent{Kind: BugRemoved, Id: id})
+	return nil
+}
+
+// ListTrashed return the soft-deleted bugs
+func (c *RepoCache) ListTrashed() ([]*bug.Bug, error) {
+	ids, err := bug.ListTrashedIds(c.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*bug.Bug, len(ids))
+
+	for i, id := range ids {
+		result[i], err = bug.ReadTrashedBug(c.repo, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// RestoreBug restore a soft-deleted bug matching the given prefix
+func (c *RepoCache) RestoreBug(prefix string) (*BugCache, error) {
+	if err := c.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	id, err := c.resolveTrashedPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bug.Restore(c.repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := bug.ReadLocalBug(c.repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := b.Compile()
+	excerpt := c.newExcerpt(b, &snap)
+
+	c.muBug.Lock()
+	c.setExcerpt(id, excerpt)
+	c.refHashes[id] = b.LastCommit()
+	c.muBug.Unlock()
+
+	err = c.write()
+	if err != nil {
+		return nil, err
+	}
+
+	c.notify(BugEvent{Kind: BugCreated, Id: id})
+
+	return c.ResolveBug(id)
+}
+
+// PurgeBug definitely delete a soft-deleted bug matching the given prefix
+func (c *RepoCache) PurgeBug(prefix string) (string, error) {
+	if err := c.checkWritable(); err != nil {
+		return "", err
+	}
+
+	id, err := c.resolveTrashedPrefix(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	return id, bug.Purge(c.repo, id)
+}
+
+func (c *RepoCache) resolveTrashedPrefix(prefix string) (string, error) {
+	ids, err := bug.ListTrashedIds(c.repo)
+	if err != nil {
+		return "", err
+	}
+
+	return resolvePrefix(ids, prefix)
+}
+
+// ListQuarantined return the bugs waiting in quarantine for a review
+func (c *RepoCache) ListQuarantined() ([]*bug.QuarantinedBug, error) {
+	ids, err := bug.ListQuarantinedIds(c.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*bug.QuarantinedBug, len(ids))
+
+	for i, id := range ids {
+		result[i], err = bug.ReadQuarantined(c.repo, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// AcceptQuarantined merge a bug in quarantine matching the given prefix
+func (c *RepoCache) AcceptQuarantined(prefix string) (bug.MergeResult, error) {
+	if err := c.checkWritable(); err != nil {
+		return bug.MergeResult{}, err
+	}
+
+	id, err := c.resolveQuarantinedPrefix(prefix)
+	if err != nil {
+		return bug.MergeResult{}, err
+	}
+
+	result, err := bug.AcceptQuarantined(c.repo, id)
+	if err != nil {
+		return result, err
+	}
+
+	switch result.Status {
+	case bug.MergeStatusNew, bug.MergeStatusUpdated:
+		snap := result.Bug.Compile()
+		excerpt := c.newExcerpt(result.Bug, &snap)
+
+		c.muBug.Lock()
+		// drop the now outdated version loaded in memory, if any
+		c.removeLoadedBug(id)
+		c.setExcerpt(id, excerpt)
+		c.refHashes[id] = result.Bug.LastCommit()
+		c.muBug.Unlock()
+	}
+
+	err = c.assignAliases()
+	if err != nil {
+		return result, err
+	}
+
+	err = c.write()
+	if err != nil {
+		return result, err
+	}
+
+	switch result.Status {
+	case bug.MergeStatusNew, bug.MergeStatusUpdated:
+		c.notify(mergeEvent(result))
+
+		snap := result.Bug.Compile()
+		event := newHookEvent(HookMergeApplied, &snap)
+		event.MergeStatus = result.String()
+		c.fireHook(event)
+	}
+
+	return result, nil
+}
+
+// RejectQuarantined drop a bug in quarantine matching the given prefix
+func (c *RepoCache) RejectQuarantined(prefix string) (string, error) {
+	if err := c.checkWritable(); err != nil {
+		return "", err
+	}
+
+	id, err := c.resolveQuarantinedPrefix(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	return id, bug.RejectQuarantined(c.repo, id)
+}
+
+func (c *RepoCache) resolveQuarantinedPrefix(prefix string) (string, error) {
+	ids, err := bug.ListQuarantinedIds(c.repo)
+	if err != nil {
+		return "", err
+	}
+
+	return resolvePrefix(ids, prefix)
+}
+
+// resolvePrefix find the unique id matching a prefix in a list of ids
+func resolvePrefix(ids []string, prefix string) (string, error) {
+	// preallocate but empty
+	matching := make([]string, 0, 5)
+
+	for _, id := range ids {
+		if strings.HasPrefix(id, prefix) {
+			matching = append(matching, id)
+		}
+	}
+
+	if len(matching) > 1 {
+		return "", bug.ErrMultipleMatch{Matching: matching}
+	}
+
+	if len(matching) == 0 {
+		return "", bug.ErrBugNotExist
+	}
+
+	return matching[0], nil
+}
+
 // Push update a remote with the local changes
-func (c *RepoCache) Push(remote string) (string, error) {
+func (c *RepoCache) Push(remote string) ([]bug.PushResult, error) {
 	return bug.Push(c.repo, remote)
 }
 
+// PushContext update a remote with the local changes, stopping when the
+// context is canceled
+func (c *RepoCache) PushContext(ctx context.Context, remote string) ([]bug.PushResult, error) {
+	return bug.PushContext(ctx, c.repo, remote)
+}
+
 func repoLockFilePath(repo repository.Repo) string {
-	return path.Join(repo.GetPath(), ".git", "git-bug", lockfile)
+	return path.Join(repo.GetGitDir(), "git-bug", lockfile)
 }
 
 // repoIsAvailable check is the given repository is locked by a Cache.
@@ -505,7 +1471,7 @@ func repoIsAvailable(repo repository.Repo) error {
 		}
 
 		if process.IsRunning(pid) {
-			return fmt.Errorf("the repository you want to access is already locked by the process pid %d", pid)
+			return ErrLocked{Pid: pid}
 		}
 
 		// The lock file is just laying there after a crash, clean it
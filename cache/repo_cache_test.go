@@ -0,0 +1,495 @@
+package cache
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestRepo(t *testing.T) *repository.GitRepo {
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+
+	repo, err := repository.InitGitRepo(dir)
+	assert.NoError(t, err)
+
+	assert.NoError(t, repo.StoreConfig("user.name", "testuser"))
+	assert.NoError(t, repo.StoreConfig("user.email", "testuser@example.com"))
+
+	return repo
+}
+
+func TestCacheConcurrentAccess(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	b, err := c.NewBug("title", "message")
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(3)
+
+		go func() {
+			defer wg.Done()
+			_, err := c.NewBug("concurrent", "message")
+			assert.NoError(t, err)
+		}()
+
+		go func() {
+			defer wg.Done()
+			c.QueryBugs(nil)
+			c.ValidLabels()
+		}()
+
+		go func() {
+			defer wg.Done()
+			_, err := c.ResolveBugPrefix(b.Id()[:7])
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Len(t, c.AllBugsIds(), 5)
+}
+
+func TestBugCacheConcurrentAccess(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+
+	b, err := c.NewBug("title", "message")
+	assert.NoError(t, err)
+	id := b.Id()
+	assert.NoError(t, c.Close())
+
+	c, err = NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	// the snapshot is compiled lazily, by whichever goroutine comes first
+	b, err = c.ResolveBug(id)
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			// the snapshots given out are read while the bug is changed
+			for j := 0; j < 100; j++ {
+				snap := b.Snapshot()
+				for _, comment := range snap.Comments {
+					_ = comment.Message
+				}
+				for _, label := range snap.Labels {
+					_ = label.String()
+				}
+			}
+			b.History()
+		}()
+
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, b.AddComment("concurrent"))
+			_, err := b.ChangeLabels([]string{fmt.Sprintf("label%d", i)}, nil)
+			assert.NoError(t, err)
+			assert.NoError(t, b.CommitAsNeeded())
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Len(t, b.Snapshot().Comments, 5)
+	assert.Len(t, b.Snapshot().Labels, 4)
+	assert.False(t, b.hasPendingOp())
+}
+
+func TestCacheIncrementalUpdate(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+
+	b1, err := c.NewBug("title 1", "message")
+	assert.NoError(t, err)
+	b2, err := c.NewBug("title 2", "message")
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.Close())
+
+	// change the bugs behind the back of the cache
+	raw, err := bug.ReadLocalBug(repo, b1.Id())
+	assert.NoError(t, err)
+	_, _, err = bug.ChangeLabels(raw, bug.Person{Name: "testuser", Email: "testuser@example.com"}, time.Now().Unix(), []string{"updated"}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, raw.Commit(repo))
+
+	assert.NoError(t, bug.Trash(repo, b2.Id()))
+
+	c, err = NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	assert.Equal(t, []string{b1.Id()}, c.AllBugsIds())
+	assert.Equal(t, []bug.Label{"updated"}, c.excerpts[b1.Id()].Labels)
+}
+
+func TestCacheLoadedBugsEviction(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	assert.NoError(t, repo.StoreConfig(maxLoadedBugsConfigKey, "2"))
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		b, err := c.NewBug("title", "message")
+		assert.NoError(t, err)
+		ids = append(ids, b.Id())
+	}
+
+	assert.Len(t, c.bugs, 2)
+	assert.NotContains(t, c.bugs, ids[0])
+
+	// an evicted bug is read again
+	_, err = c.ResolveBug(ids[0])
+	assert.NoError(t, err)
+	assert.Contains(t, c.bugs, ids[0])
+
+	c.ClearBug(ids[0])
+	assert.NotContains(t, c.bugs, ids[0])
+}
+
+func TestCacheEvictedBugStillHeld(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	assert.NoError(t, repo.StoreConfig(maxLoadedBugsConfigKey, "1"))
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	held, err := c.NewBug("title", "message")
+	assert.NoError(t, err)
+
+	// evict the bug while it's still held, and load a second copy
+	_, err = c.NewBug("other", "message")
+	assert.NoError(t, err)
+	assert.NotContains(t, c.bugs, held.Id())
+
+	second, err := c.ResolveBug(held.Id())
+	assert.NoError(t, err)
+	assert.True(t, held != second)
+
+	assert.NoError(t, second.AddComment("from the copy"))
+	_, err = second.ChangeLabels([]string{"copy"}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, second.Commit())
+
+	// the commit of the held bug keep the changes of the copy
+	assert.NoError(t, held.AddComment("from the held bug"))
+	assert.NoError(t, held.Commit())
+
+	raw, err := bug.ReadLocalBug(repo, held.Id())
+	assert.NoError(t, err)
+	snap := raw.Compile()
+	assert.Len(t, snap.Comments, 3)
+	assert.Equal(t, "from the copy", snap.Comments[1].Message)
+	assert.Equal(t, "from the held bug", snap.Comments[2].Message)
+	assert.Len(t, held.Snapshot().Comments, 3)
+	assert.Equal(t, []bug.Label{"copy"}, c.excerpts[held.Id()].Labels)
+}
+
+func TestCacheWarm(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	assert.NoError(t, repo.StoreConfig(maxLoadedBugsConfigKey, "2"))
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		b, err := c.NewBug("title", "message")
+		assert.NoError(t, err)
+		ids = append(ids, b.Id())
+	}
+
+	c.ClearAllBugs()
+
+	// the last created bug is the most recently edited one
+	loaded, err := c.Warm(1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, loaded)
+	assert.Len(t, c.bugs, 1)
+	assert.Contains(t, c.bugs, ids[2])
+
+	// no more bugs than the limit are loaded
+	loaded, err = c.Warm(10, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, loaded)
+	assert.Len(t, c.bugs, 2)
+}
+
+func TestCacheReadOnly(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	writer, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer writer.Close()
+
+	b, err := writer.NewBug("title", "message")
+	assert.NoError(t, err)
+
+	// the lock held by the writer doesn't prevent to read the bugs
+	reader, err := NewRepoCacheReadOnly(repo)
+	assert.NoError(t, err)
+	assert.True(t, reader.ReadOnly())
+	assert.Len(t, reader.AllBugsIds(), 1)
+
+	readBug, err := reader.ResolveBug(b.Id())
+	assert.NoError(t, err)
+	assert.Equal(t, "title", readBug.Snapshot().Title)
+
+	// the bugs can't be modified
+	_, err = reader.NewBug("other", "message")
+	assert.Equal(t, ErrReadOnly, err)
+	assert.Equal(t, ErrReadOnly, readBug.AddComment("comment"))
+	assert.Equal(t, ErrReadOnly, readBug.Close())
+
+	// the changes of the writer are picked up by a refresh
+	assert.NoError(t, b.AddComment("comment"))
+	assert.NoError(t, b.Commit())
+	_, err = reader.Refresh()
+	assert.NoError(t, err)
+	readBug, err = reader.ResolveBug(b.Id())
+	assert.NoError(t, err)
+	assert.Len(t, readBug.Snapshot().Comments, 2)
+
+	// closing the reader doesn't release the lock of the writer
+	assert.NoError(t, reader.Close())
+	_, err = NewRepoCache(repo)
+	assert.Error(t, err)
+}
+
+func TestCacheQueryBugsPage(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	for i := 0; i < 7; i++ {
+		_, err := c.NewBug("title", "message")
+		assert.NoError(t, err)
+	}
+
+	for _, orderBy := range []OrderBy{OrderById, OrderByCreation, OrderByEdit, OrderByVotes} {
+		query := NewQuery()
+		query.OrderBy = orderBy
+
+		all := c.QueryBugs(query)
+
+		var paged []string
+		for offset := 0; offset < len(all); offset += 3 {
+			page, total := c.QueryBugsPage(query, offset, 3)
+			assert.Equal(t, 7, total)
+			paged = append(paged, page...)
+		}
+		assert.Equal(t, all, paged)
+	}
+
+	page, total := c.QueryBugsPage(nil, 10, 3)
+	assert.Empty(t, page)
+	assert.Equal(t, 7, total)
+}
+
+func TestCacheCompileBugs(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	var ids []string
+	for i := 0; i < 20; i++ {
+		b, err := c.NewBug("title", "message")
+		assert.NoError(t, err)
+		ids = append(ids, b.Id())
+	}
+
+	var calls []int
+	excerpts, refHashes, err := c.compileBugs(ids, func(done, total int) {
+		assert.Equal(t, len(ids), total)
+		calls = append(calls, done)
+	})
+	assert.NoError(t, err)
+	assert.Len(t, excerpts, len(ids))
+	assert.Len(t, refHashes, len(ids))
+	assert.Len(t, calls, len(ids))
+	assert.Equal(t, len(ids), calls[len(calls)-1])
+
+	for _, id := range ids {
+		assert.Equal(t, c.refHashes[id], refHashes[id])
+		assert.Equal(t, id, excerpts[id].Id)
+	}
+
+	// a missing bug abort the build
+	_, _, err = c.compileBugs(append(ids, "not-a-bug"), nil)
+	assert.Error(t, err)
+}
+
+func TestCacheQueryAudience(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	public, err := c.NewBug("public", "message")
+	assert.NoError(t, err)
+
+	internal, err := c.NewBug("internal", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, internal.SetVisibility(bug.VisibilityInternal))
+	assert.NoError(t, internal.Commit())
+
+	query, err := ParseQuery("visibility:internal")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{internal.Id()}, c.QueryBugs(query))
+
+	query = NewQuery()
+	assert.Len(t, c.QueryBugs(query), 2)
+
+	query.Audience = bug.Audience{}
+	assert.Equal(t, []string{public.Id()}, c.QueryBugs(query))
+
+	query.Audience = bug.Audience{bug.VisibilityInternal: true}
+	assert.Len(t, c.QueryBugs(query), 2)
+}
+
+func TestCacheResolveBugPrefix(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	b1, err := c.NewBug("first", "message")
+	assert.NoError(t, err)
+	b2, err := c.NewBug("second", "message")
+	assert.NoError(t, err)
+
+	var tests = []struct {
+		ref      string
+		expected string
+	}{
+		{b1.Id(), b1.Id()},
+		{b2.Id(), b2.Id()},
+		{b1.HumanId(), b1.Id()},
+		{strings.ToUpper(b2.HumanId()), b2.Id()},
+		{" " + b1.HumanId() + " ", b1.Id()},
+		{"#1", b1.Id()},
+		{"#2", b2.Id()},
+	}
+
+	for _, test := range tests {
+		b, err := c.ResolveBugPrefix(test.ref)
+		assert.NoError(t, err, test.ref)
+		assert.Equal(t, test.expected, b.Id(), test.ref)
+	}
+
+	for _, ref := range []string{"", "#3", "#0", "#", "zz", "hello"} {
+		_, err := c.ResolveBugPrefix(ref)
+		assert.Equal(t, bug.ErrBugNotExist, err, ref)
+	}
+}
+
+func TestCacheMergeBrokenAliases(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	// a remote aliases ref with content this version can't parse
+	blob, err := repo.StoreData([]byte("not an alias\n"))
+	assert.NoError(t, err)
+	tree, err := repo.StoreTree([]repository.TreeEntry{
+		{ObjectType: repository.Blob, Hash: blob, Name: "aliases"},
+	})
+	assert.NoError(t, err)
+	commit, err := repo.StoreCommit(tree)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.UpdateRef("refs/remotes/origin/aliases/bugs", commit))
+
+	var warnings []error
+	for result := range c.MergeAll("origin") {
+		assert.NoError(t, result.Err)
+		if result.Warning != nil {
+			warnings = append(warnings, result.Warning)
+		}
+	}
+
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0].Error(), "merging the aliases")
+}
+
+func TestCacheRawTimezone(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+
+	author := bug.Person{Name: "René Descartes", Email: "rene@descartes.fr"}
+	tokyo := time.FixedZone("", 9*3600)
+
+	// the offset of an imported operation is the one of its source, not the
+	// one of the machine importing it
+	b, err := c.NewBugRaw(author, time.Unix(1000, 0).In(tokyo), "title", "message", nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, b.AddCommentRaw(author, time.Unix(2000, 0).UTC(), "comment", nil, nil))
+	assert.NoError(t, b.Commit())
+
+	read, err := bug.ReadLocalBug(repo, b.Id())
+	assert.NoError(t, err)
+
+	ops := read.Compile().Operations
+	_, offset := ops[0].OriginalTime().Zone()
+	assert.Equal(t, 9*3600, offset)
+	_, offset = ops[1].OriginalTime().Zone()
+	assert.Equal(t, 0, offset)
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// savedQueryConfigPrefix is where the saved queries are stored in the git
+// config, one key per query: git-bug.query.<name>
+const savedQueryConfigPrefix = "git-bug.query."
+
+// savedQueryNameRegexp is what git accept as a config key name
+var savedQueryNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// SavedQuery is a query stored under a name, to be used as "@name"
+type SavedQuery struct {
+	Name  string
+	Query string
+}
+
+// SavedQueries return the saved queries, sorted by name
+func (c *RepoCache) SavedQueries() ([]SavedQuery, error) {
+	configs, err := c.repo.ReadConfigs(savedQueryConfigPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]SavedQuery, 0, len(configs))
+	for key, value := range configs {
+		result = append(result, SavedQuery{
+			Name:  strings.TrimPrefix(key, savedQueryConfigPrefix),
+			Query: value,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// SavedQuery return the saved query with the given name, if any
+func (c *RepoCache) SavedQuery(name string) (string, bool) {
+	name = strings.ToLower(name)
+
+	if !savedQueryNameRegexp.MatchString(name) {
+		return "", false
+	}
+
+	key := savedQueryConfigPrefix + name
+
+	configs, err := c.repo.ReadConfigs(key)
+	if err != nil {
+		return "", false
+	}
+
+	query, ok := configs[key]
+	return query, ok
+}
+
+// SaveQuery store a query under a name, replacing the previous one if any.
+// The query must be valid.
+func (c *RepoCache) SaveQuery(name string, query string) error {
+	name = strings.ToLower(name)
+
+	if !savedQueryNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid saved query name %s, only letters, digits and dashes are allowed", name)
+	}
+
+	// the query can't reference itself, directly or not
+	lookup := func(other string) (string, bool) {
+		if other == name {
+			return query, true
+		}
+		return c.SavedQuery(other)
+	}
+
+	_, err := ParseQueryWithSaved(query, lookup)
+	if err != nil {
+		return err
+	}
+
+	return c.repo.StoreConfig(savedQueryConfigPrefix+name, query)
+}
+
+// RemoveSavedQuery remove a saved query
+func (c *RepoCache) RemoveSavedQuery(name string) error {
+	name = strings.ToLower(name)
+
+	if _, ok := c.SavedQuery(name); !ok {
+		return fmt.Errorf("no saved query named %s", name)
+	}
+
+	return c.repo.RmConfigs(savedQueryConfigPrefix + name)
+}
+
+// ParseQuery parse a query DSL, resolving the "@name" references to the saved
+// queries of the repository
+func (c *RepoCache) ParseQuery(query string) (*Query, error) {
+	return ParseQueryWithSaved(query, c.SavedQuery)
+}
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheSavedQueries(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	open, err := c.NewBug("open", "message")
+	assert.NoError(t, err)
+	closed, err := c.NewBug("closed", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, closed.Close())
+	assert.NoError(t, closed.Commit())
+
+	assert.NoError(t, c.SaveQuery("Open", "status:open sort:id-asc"))
+	assert.NoError(t, c.SaveQuery("closed", "-@open"))
+	assert.Error(t, c.SaveQuery("bad name", "status:open"))
+	assert.Error(t, c.SaveQuery("bad", "status:unknown"))
+	assert.Error(t, c.SaveQuery("loop", "@loop"))
+	assert.Error(t, c.SaveQuery("unknown", "@nothing"))
+
+	saved, err := c.SavedQueries()
+	assert.NoError(t, err)
+	assert.Equal(t, []SavedQuery{
+		{Name: "closed", Query: "-@open"},
+		{Name: "open", Query: "status:open sort:id-asc"},
+	}, saved)
+
+	query, err := c.ParseQuery("@open")
+	assert.NoError(t, err)
+	assert.Equal(t, OrderById, query.OrderBy)
+	assert.Equal(t, OrderAscending, query.OrderDirection)
+	assert.True(t, query.Sorted)
+	assert.Equal(t, []string{open.Id()}, c.QueryBugs(query))
+
+	// the explicit sorting win
+	query, err = c.ParseQuery("@open sort:edit")
+	assert.NoError(t, err)
+	assert.Equal(t, OrderByEdit, query.OrderBy)
+
+	query, err = c.ParseQuery("@closed")
+	assert.NoError(t, err)
+	assert.False(t, query.Sorted)
+	assert.Equal(t, []string{closed.Id()}, c.QueryBugs(query))
+
+	query, err = c.ParseQuery("@open OR @closed")
+	assert.NoError(t, err)
+	assert.Len(t, c.QueryBugs(query), 2)
+
+	_, err = c.ParseQuery("@nothing")
+	assert.Error(t, err)
+
+	_, err = ParseQuery("@open")
+	assert.Error(t, err)
+
+	assert.NoError(t, c.RemoveSavedQuery("open"))
+	_, ok := c.SavedQuery("open")
+	assert.False(t, ok)
+	assert.Error(t, c.RemoveSavedQuery("open"))
+}
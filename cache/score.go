@@ -0,0 +1,374 @@
+package cache
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// Scorer compute a score of a bug between 0 and 1, stored in its excerpt
+// each time the bug is compiled by the cache. The scores can be queried with
+// "score-<name>:>0.8" and are shown as hints in the UIs above
+// ScoreHintThreshold.
+type Scorer interface {
+	// Name identify the score in the excerpts and in the queries
+	Name() string
+	// Hint describe a high score to the user, like "likely a duplicate"
+	Hint() string
+	// Score compute the score of a bug. The other bugs of the repository are
+	// available through the corpus.
+	Score(snap *bug.Snapshot, corpus *Corpus) float64
+}
+
+// scoreQualifierPrefix is the prefix of the query qualifiers filtering on
+// a score
+const scoreQualifierPrefix = "score-"
+
+// ScoreHintThreshold is the score from which a hint is shown in the UIs
+const ScoreHintThreshold = 0.7
+
+var scorersMu sync.RWMutex
+var scorers = map[string]Scorer{}
+
+// RegisterScorer add a scorer run on every bug compiled by the cache. The
+// scores of the bugs already in the cache are computed on the next rebuild.
+func RegisterScorer(scorer Scorer) {
+	scorersMu.Lock()
+	defer scorersMu.Unlock()
+	scorers[scorer.Name()] = scorer
+}
+
+// Scorers return the registered scorers, sorted by name
+func Scorers() []Scorer {
+	scorersMu.RLock()
+	defer scorersMu.RUnlock()
+
+	result := make([]Scorer, 0, len(scorers))
+	for _, scorer := range scorers {
+		result = append(result, scorer)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name() < result[j].Name()
+	})
+
+	return result
+}
+
+func isScorer(name string) bool {
+	scorersMu.RLock()
+	defer scorersMu.RUnlock()
+	_, ok := scorers[name]
+	return ok
+}
+
+func init() {
+	RegisterScorer(duplicateScorer{})
+	RegisterScorer(spamScorer{})
+}
+
+// Corpus give the scorers access to the excerpts of the bugs of the
+// repository, including the ones being compiled
+type Corpus struct {
+	base    map[string]*BugExcerpt
+	updated map[string]*BugExcerpt
+	memo    map[string]interface{}
+}
+
+// Each call f for every bug of the corpus
+func (c *Corpus) Each(f func(excerpt *BugExcerpt)) {
+	for _, excerpt := range c.updated {
+		f(excerpt)
+	}
+	for id, excerpt := range c.base {
+		if _, ok := c.updated[id]; !ok {
+			f(excerpt)
+		}
+	}
+}
+
+// Memo return the value stored for a key, built on the first call. It allows
+// a scorer to index the corpus once for all the bugs scored together.
+func (c *Corpus) Memo(key string, build func() interface{}) interface{} {
+	if value, ok := c.memo[key]; ok {
+		return value
+	}
+	value := build()
+	c.memo[key] = value
+	return value
+}
+
+// scoreExcerpts compute the scores of the given excerpts, compared with the
+// bugs of the cache. The caller must not hold the lock.
+func (c *RepoCache) scoreExcerpts(excerpts map[string]*BugExcerpt, snaps map[string]*bug.Snapshot) {
+	registered := Scorers()
+	if len(registered) == 0 {
+		return
+	}
+
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
+	corpus := &Corpus{
+		base:    c.excerpts,
+		updated: excerpts,
+		memo:    make(map[string]interface{}),
+	}
+
+	for id, excerpt := range excerpts {
+		excerpt.Scores = nil
+		for _, scorer := range registered {
+			score := scorer.Score(snaps[id], corpus)
+			if score <= 0 {
+				continue
+			}
+			if excerpt.Scores == nil {
+				excerpt.Scores = make(map[string]float64)
+			}
+			excerpt.Scores[scorer.Name()] = score
+		}
+	}
+}
+
+// newExcerpt create the excerpt of a bug, with its scores. The caller must
+// not hold the lock.
+func (c *RepoCache) newExcerpt(b bug.Interface, snap *bug.Snapshot) *BugExcerpt {
+	excerpt := NewBugExcerpt(b, snap)
+	c.scoreExcerpts(
+		map[string]*BugExcerpt{excerpt.Id: excerpt},
+		map[string]*bug.Snapshot{excerpt.Id: snap},
+	)
+	return excerpt
+}
+
+// Hints return the descriptions of the high scores of a bug
+func (e *BugExcerpt) Hints() []string {
+	var result []string
+
+	for _, scorer := range Scorers() {
+		score := e.Scores[scorer.Name()]
+		if score >= ScoreHintThreshold {
+			result = append(result, fmt.Sprintf("%s (%.0f%%)", scorer.Hint(), score*100))
+		}
+	}
+
+	return result
+}
+
+// Scores return the scores of the bug, as computed when it was last compiled
+func (c *BugCache) Scores() map[string]float64 {
+	c.repoCache.muBug.RLock()
+	defer c.repoCache.muBug.RUnlock()
+
+	excerpt, ok := c.repoCache.excerpts[c.Id()]
+	if !ok {
+		return nil
+	}
+	return excerpt.Scores
+}
+
+// Hints return the descriptions of the high scores of the bug
+func (c *BugCache) Hints() []string {
+	c.repoCache.muBug.RLock()
+	defer c.repoCache.muBug.RUnlock()
+
+	excerpt, ok := c.repoCache.excerpts[c.Id()]
+	if !ok {
+		return nil
+	}
+	return excerpt.Hints()
+}
+
+/*
+ * Duplicates
+ */
+
+// duplicateMinTokens is the minimum number of significant words in a title
+// to compare it with the others
+const duplicateMinTokens = 2
+
+// duplicateScorer compare the title of a bug with the titles of the older
+// bugs, so that the original report is not flagged as a duplicate of the
+// newer ones
+type duplicateScorer struct{}
+
+type titleIndex struct {
+	tokens map[string]map[string]bool
+	byWord map[string][]*BugExcerpt
+}
+
+func (duplicateScorer) Name() string {
+	return "duplicate"
+}
+
+func (duplicateScorer) Hint() string {
+	return "likely a duplicate"
+}
+
+func (duplicateScorer) Score(snap *bug.Snapshot, corpus *Corpus) float64 {
+	index := corpus.Memo("duplicate", func() interface{} {
+		index := titleIndex{
+			tokens: make(map[string]map[string]bool),
+			byWord: make(map[string][]*BugExcerpt),
+		}
+		corpus.Each(func(excerpt *BugExcerpt) {
+			tokens := titleTokens(excerpt.Title)
+			index.tokens[excerpt.Id] = tokens
+			for token := range tokens {
+				index.byWord[token] = append(index.byWord[token], excerpt)
+			}
+		})
+		return index
+	}).(titleIndex)
+
+	tokens := titleTokens(snap.Title)
+	if len(tokens) < duplicateMinTokens {
+		return 0
+	}
+
+	id := snap.Id()
+	created := snap.CreatedAt.Unix()
+
+	best := 0.0
+	seen := make(map[string]bool)
+
+	for token := range tokens {
+		for _, other := range index.byWord[token] {
+			if other.Id == id || seen[other.Id] {
+				continue
+			}
+			seen[other.Id] = true
+
+			older := other.CreateUnixTime < created ||
+				(other.CreateUnixTime == created && other.Id < id)
+			if !older || len(index.tokens[other.Id]) < duplicateMinTokens {
+				continue
+			}
+
+			if similarity := jaccard(tokens, index.tokens[other.Id]); similarity > best {
+				best = similarity
+			}
+		}
+	}
+
+	return best
+}
+
+// stopWords are the words ignored when comparing titles
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "when": true,
+	"not": true, "can": true, "cannot": true, "does": true, "doesn": true,
+	"from": true, "into": true, "this": true, "that": true, "are": true,
+	"was": true, "bug": true, "issue": true, "error": true,
+}
+
+// titleTokens return the significant words of a title
+func titleTokens(title string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	result := make(map[string]bool, len(words))
+	for _, word := range words {
+		if len([]rune(word)) < 3 || stopWords[word] {
+			continue
+		}
+		result[word] = true
+	}
+
+	return result
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	common := 0
+	for token := range a {
+		if b[token] {
+			common++
+		}
+	}
+
+	union := len(a) + len(b) - common
+	if union == 0 {
+		return 0
+	}
+
+	return float64(common) / float64(union)
+}
+
+/*
+ * Spam
+ */
+
+var linkRegex = regexp.MustCompile(`(?i)https?://`)
+
+// spamPhrases are the phrases frequently found in spam
+var spamPhrases = []string{
+	"buy now", "click here", "casino", "viagra", "free money", "crypto",
+	"loan", "seo services", "whatsapp", "earn $", "work from home",
+}
+
+// spamScorer estimate the likelihood of a bug to be spam from its title and
+// its first message, with a few heuristics
+type spamScorer struct{}
+
+func (spamScorer) Name() string {
+	return "spam"
+}
+
+func (spamScorer) Hint() string {
+	return "likely spam"
+}
+
+func (spamScorer) Score(snap *bug.Snapshot, corpus *Corpus) float64 {
+	message := ""
+	if len(snap.Comments) > 0 {
+		message = snap.Comments[0].Message
+	}
+
+	text := strings.ToLower(snap.Title + "\n" + message)
+	score := 0.0
+
+	for _, phrase := range spamPhrases {
+		if strings.Contains(text, phrase) {
+			score += 0.3
+		}
+	}
+
+	links := len(linkRegex.FindAllStringIndex(message, -1))
+	switch {
+	case links >= 5:
+		score += 0.4
+	case links > 0 && len(strings.Fields(message)) < 3*links+5:
+		// mostly links, with barely any explanation
+		score += 0.3
+	}
+
+	if shouting(snap.Title) {
+		score += 0.2
+	}
+
+	if score > 1 {
+		return 1
+	}
+
+	return score
+}
+
+// shouting return true if a text is mostly in uppercase
+func shouting(s string) bool {
+	letters, upper := 0, 0
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters >= 10 && float64(upper)/float64(letters) > 0.7
+}
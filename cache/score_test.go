@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheScores(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	author, err := bug.GetUser(repo)
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	original, err := c.NewBugRaw(author, now.Add(-100*time.Second), "Crash when opening a large file", "message", nil, nil)
+	assert.NoError(t, err)
+	duplicate, err := c.NewBugRaw(author, now, "crash opening large file", "message", nil, nil)
+	assert.NoError(t, err)
+	spam, err := c.NewBugRaw(author, now, "BUY NOW CHEAP WATCHES", "click here http://example.com", nil, nil)
+	assert.NoError(t, err)
+
+	// the original report is not a duplicate of the newer one
+	assert.Zero(t, original.Scores()["duplicate"])
+	assert.True(t, duplicate.Scores()["duplicate"] > 0.7)
+	assert.Len(t, duplicate.Hints(), 1)
+	assert.True(t, spam.Scores()["spam"] >= ScoreHintThreshold)
+	assert.Zero(t, original.Scores()["spam"])
+
+	query, err := ParseQuery("score-duplicate:>0.5")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{duplicate.Id()}, c.QueryBugs(query))
+
+	query, err = ParseQuery("score-spam:<0.1")
+	assert.NoError(t, err)
+	assert.Len(t, c.QueryBugs(query), 2)
+
+	// the scores survive a rebuild
+	assert.NoError(t, c.Rebuild())
+	assert.True(t, c.excerpts[duplicate.Id()].Scores["duplicate"] > 0.7)
+	assert.Zero(t, c.excerpts[original.Id()].Scores["duplicate"])
+}
+
+func TestScoreFilter(t *testing.T) {
+	excerpt := &BugExcerpt{Scores: map[string]float64{"spam": 0.5}}
+
+	var tests = []struct {
+		input    string
+		expected bool
+	}{
+		{"0.5", true},
+		{">0.5", false},
+		{">=0.5", true},
+		{"<0.6", true},
+		{"<=0.4", false},
+		{"=0.5", true},
+	}
+
+	for _, test := range tests {
+		filter, err := ScoreFilter("spam", test.input)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, filter(excerpt), test.input)
+	}
+
+	_, err := ScoreFilter("spam", ">high")
+	assert.Error(t, err)
+
+	_, err = ParseQuery("score-unknown:>0.5")
+	assert.Error(t, err)
+}
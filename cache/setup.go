@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// SetUser make a person the author of the next changes in this repository,
+// instead of the identity configured in git
+func (c *RepoCache) SetUser(p bug.Person) error {
+	return bug.SetUser(c.repo, p)
+}
+
+// AdoptedUser return the identity adopted with SetUser, or nil if the user
+// is the one configured in git
+func (c *RepoCache) AdoptedUser() (*bug.Person, error) {
+	return bug.AdoptedUser(c.repo)
+}
+
+// ResetUser forget the identity adopted with SetUser, to go back to the one
+// configured in git
+func (c *RepoCache) ResetUser() error {
+	return bug.ResetUser(c.repo)
+}
+
+// ListRemotes return the names of the git remotes of the repository
+func (c *RepoCache) ListRemotes() ([]string, error) {
+	remoteRepo, ok := c.repo.(repository.RemoteRepo)
+	if !ok {
+		return nil, fmt.Errorf("the repository can't list its remotes")
+	}
+
+	return remoteRepo.ListRemotes()
+}
+
+// RemoteIsSetup tell if a plain git fetch retrieve the bugs of a remote
+func (c *RepoCache) RemoteIsSetup(remote string) (bool, error) {
+	remoteRepo, ok := c.repo.(repository.RemoteRepo)
+	if !ok {
+		return false, fmt.Errorf("the repository can't configure its remotes")
+	}
+
+	missing, err := bug.MissingFetchRefSpecs(remoteRepo, remote)
+	if err != nil {
+		return false, err
+	}
+
+	return len(missing) == 0, nil
+}
+
+// SetupRemote configure the refspecs of a remote so that a plain git fetch,
+// and optionally a plain git push, exchange the bugs as well. It return the
+// refspecs added.
+func (c *RepoCache) SetupRemote(remote string, push bool) ([]string, error) {
+	remoteRepo, ok := c.repo.(repository.RemoteRepo)
+	if !ok {
+		return nil, fmt.Errorf("the repository can't configure its remotes")
+	}
+
+	added, err := bug.AddFetchRefSpecs(remoteRepo, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	if push {
+		pushAdded, err := bug.AddPushRefSpecs(remoteRepo, remote)
+		if err != nil {
+			return nil, err
+		}
+		added = append(added, pushAdded...)
+	}
+
+	return added, nil
+}
+
+// SetLabelPolicy store the label policy in the configuration of the repo.
+// It only applies to the labels added from now on.
+func (c *RepoCache) SetLabelPolicy(policy bug.LabelPolicy) error {
+	return c.repo.StoreConfig(labelPolicyConfigKey, policy.String())
+}
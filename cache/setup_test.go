@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"os"
+	"testing"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheSetup(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, repo.AddRemote("origin", "https://example.com/repo.git"))
+
+	remotes, err := c.ListRemotes()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"origin"}, remotes)
+
+	ok, err := c.RemoteIsSetup("origin")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	added, err := c.SetupRemote("origin", true)
+	require.NoError(t, err)
+	assert.Equal(t, "HEAD", added[len(bug.FetchRefSpecs("origin"))])
+
+	ok, err = c.RemoteIsSetup("origin")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	added, err = c.SetupRemote("origin", true)
+	require.NoError(t, err)
+	assert.Empty(t, added)
+
+	policy, err := c.LabelPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, bug.LabelPolicyTrim, policy)
+
+	require.NoError(t, c.SetLabelPolicy(bug.LabelPolicyLowercase))
+
+	policy, err = c.LabelPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, bug.LabelPolicyLowercase, policy)
+
+	rene := bug.Person{Name: "René Descartes", Email: "rene@descartes.fr"}
+	require.NoError(t, c.SetUser(rene))
+
+	user, err := bug.GetUser(repo)
+	require.NoError(t, err)
+	assert.Equal(t, rene.Email, user.Email)
+}
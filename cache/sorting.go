@@ -7,6 +7,7 @@ const (
 	OrderById
 	OrderByCreation
 	OrderByEdit
+	OrderByVotes
 )
 
 type OrderDirection int
@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// Statistics summarize the bugs of a repository
+type Statistics struct {
+	Bugs   int `json:"bugs"`
+	Open   int `json:"open"`
+	Closed int `json:"closed"`
+	// ByLabel is the number of bugs holding each label
+	ByLabel map[string]int `json:"by_label"`
+	// ByAuthor is the number of bugs opened by each author, by display name
+	ByAuthor map[string]int `json:"by_author"`
+	// AverageTimeToClose is the mean time between the creation and the last
+	// closing of the closed bugs, in nanoseconds in JSON
+	AverageTimeToClose time.Duration `json:"average_time_to_close"`
+}
+
+// Statistics compute the statistics of the bugs of the repository. They are
+// computed from the excerpts only, without reading any bug.
+func (c *RepoCache) Statistics() Statistics {
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
+	stats := Statistics{
+		Bugs:     len(c.excerpts),
+		ByLabel:  make(map[string]int),
+		ByAuthor: make(map[string]int),
+	}
+
+	var totalToClose time.Duration
+	closedWithTime := 0
+
+	for _, excerpt := range c.excerpts {
+		switch excerpt.Status {
+		case bug.OpenStatus:
+			stats.Open++
+		case bug.ClosedStatus:
+			stats.Closed++
+		}
+
+		for _, label := range excerpt.Labels {
+			stats.ByLabel[label.String()]++
+		}
+
+		stats.ByAuthor[excerpt.Author.DisplayName()]++
+
+		if excerpt.Status == bug.ClosedStatus && excerpt.CloseUnixTime >= excerpt.CreateUnixTime {
+			totalToClose += time.Duration(excerpt.CloseUnixTime-excerpt.CreateUnixTime) * time.Second
+			closedWithTime++
+		}
+	}
+
+	if closedWithTime > 0 {
+		stats.AverageTimeToClose = totalToClose / time.Duration(closedWithTime)
+	}
+
+	return stats
+}
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheStatistics(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	author, err := bug.GetUser(repo)
+	assert.NoError(t, err)
+
+	now := time.Now()
+	day := 24 * time.Hour
+
+	b1, err := c.NewBugRaw(author, now.Add(-4*day), "first", "message", nil, nil)
+	assert.NoError(t, err)
+	_, err = b1.ChangeLabelsRaw(author, now.Add(-4*day), []string{"bug"}, nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, b1.CloseRaw(author, now.Add(-3*day), nil))
+	assert.NoError(t, b1.Commit())
+
+	b2, err := c.NewBugRaw(author, now.Add(-4*day), "second", "message", nil, nil)
+	assert.NoError(t, err)
+	// reopened then closed again, only the last closing count
+	assert.NoError(t, b2.CloseRaw(author, now.Add(-3*day), nil))
+	assert.NoError(t, b2.OpenRaw(author, now.Add(-2*day), nil))
+	assert.NoError(t, b2.CloseRaw(author, now.Add(-day), nil))
+	assert.NoError(t, b2.Commit())
+
+	b3, err := c.NewBugRaw(bug.Person{Name: "other", Email: "other@example.com"}, now, "third", "message", nil, nil)
+	assert.NoError(t, err)
+	_, err = b3.ChangeLabelsRaw(author, now, []string{"bug", "ui"}, nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, b3.Commit())
+
+	stats := c.Statistics()
+
+	assert.Equal(t, 3, stats.Bugs)
+	assert.Equal(t, 1, stats.Open)
+	assert.Equal(t, 2, stats.Closed)
+	assert.Equal(t, map[string]int{"bug": 2, "ui": 1}, stats.ByLabel)
+	assert.Equal(t, map[string]int{"testuser": 2, "other": 1}, stats.ByAuthor)
+	assert.Equal(t, 2*24*time.Hour, stats.AverageTimeToClose)
+
+	// the close time survive a reload of the cache
+	assert.NoError(t, c.Rebuild())
+	assert.Equal(t, stats, c.Statistics())
+}
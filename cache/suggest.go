@@ -0,0 +1,245 @@
+package cache
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// codeRefRegex match the references to the source code in the texts of a
+// bug: a file path, optionally anchored to a line or a range of lines, like
+// "cache/alias.go" or "cache/alias.go:42-50"
+var codeRefRegex = regexp.MustCompile(`((?:[\w.-]+/)*[\w-]+\.[A-Za-z]\w*)(?::(\d+)(?:-(\d+))?)?`)
+
+// maxCodeRefs is the maximum number of references to the source code
+// considered for a bug
+const maxCodeRefs = 20
+
+// suggestActivityPeriod is how far back the activity on the related bugs is
+// considered
+const suggestActivityPeriod = 90 * 24 * time.Hour
+
+// suggestActivityCap is the number of recent operations on related bugs
+// giving the maximum activity score
+const suggestActivityCap = 10
+
+// CodeRef is a reference to the source code found in a bug
+type CodeRef struct {
+	Path string
+	// Start and End are the lines referenced, zero if the whole file is
+	Start int
+	End   int
+}
+
+func (r CodeRef) String() string {
+	switch {
+	case r.Start == 0:
+		return r.Path
+	case r.Start == r.End:
+		return fmt.Sprintf("%s:%d", r.Path, r.Start)
+	default:
+		return fmt.Sprintf("%s:%d-%d", r.Path, r.Start, r.End)
+	}
+}
+
+// AssigneeSuggestion is a person likely to own a bug
+type AssigneeSuggestion struct {
+	Person bug.Person
+	Score  float64
+	// Reasons explain the score, like "wrote 80% of cache/alias.go"
+	Reasons []string
+}
+
+// FindCodeRefs return the references to the source code in the title and the
+// comments of a bug, in order of appearance and without duplicates
+func FindCodeRefs(snap *bug.Snapshot) []CodeRef {
+	texts := []string{snap.Title}
+	for _, comment := range snap.Comments {
+		texts = append(texts, comment.Message)
+	}
+
+	var result []CodeRef
+	seen := make(map[CodeRef]bool)
+
+	for _, text := range texts {
+		for _, match := range codeRefRegex.FindAllStringSubmatch(text, -1) {
+			ref := CodeRef{Path: match[1]}
+
+			if match[2] != "" {
+				ref.Start, _ = strconv.Atoi(match[2])
+				ref.End = ref.Start
+			}
+			if match[3] != "" {
+				ref.End, _ = strconv.Atoi(match[3])
+			}
+			if ref.End < ref.Start {
+				ref.Start, ref.End = ref.End, ref.Start
+			}
+
+			if seen[ref] {
+				continue
+			}
+			seen[ref] = true
+			result = append(result, ref)
+
+			if len(result) == maxCodeRefs {
+				return result
+			}
+		}
+	}
+
+	return result
+}
+
+// SuggestAssignees rank the people likely to own a bug, according to:
+// - who last modified the source code referenced in the bug (git blame)
+// - who recently worked on the bugs sharing a label with this one
+//
+// At most limit suggestions are returned, best first.
+func (c *RepoCache) SuggestAssignees(id string, limit int) ([]AssigneeSuggestion, error) {
+	b, err := c.ResolveBug(id)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := b.Snapshot()
+	scores := make(map[string]*AssigneeSuggestion)
+
+	add := func(person bug.Person, score float64, reason string) {
+		key := strings.ToLower(person.Email)
+		if key == "" {
+			key = strings.ToLower(person.Name)
+		}
+
+		suggestion, ok := scores[key]
+		if !ok {
+			suggestion = &AssigneeSuggestion{Person: person}
+			scores[key] = suggestion
+		}
+
+		// the persons from the bugs know more than their name and email
+		if suggestion.Person.Login == "" && person.Login != "" {
+			suggestion.Person = person
+		}
+
+		suggestion.Score += score
+		suggestion.Reasons = append(suggestion.Reasons, reason)
+	}
+
+	if history, ok := c.repo.(repository.HistoryRepo); ok {
+		for _, ref := range FindCodeRefs(snap) {
+			authors, err := history.BlameFile(ref.Path, ref.Start, ref.End)
+			if err != nil {
+				// most likely not a file of the repository
+				continue
+			}
+
+			total := 0
+			for _, lines := range authors {
+				total += lines
+			}
+
+			for author, lines := range authors {
+				person := bug.Person{Name: author.Name, Email: author.Email}
+				add(person, float64(lines)/float64(total),
+					fmt.Sprintf("last modified %d/%d lines of %s", lines, total, ref))
+			}
+		}
+	}
+
+	if len(snap.Labels) > 0 {
+		err = c.suggestFromActivity(snap, add)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]AssigneeSuggestion, 0, len(scores))
+	for _, suggestion := range scores {
+		result = append(result, *suggestion)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].Person.DisplayName() < result[j].Person.DisplayName()
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+// suggestFromActivity score the people who recently edited the bugs sharing a
+// label with the given bug
+func (c *RepoCache) suggestFromActivity(snap *bug.Snapshot, add func(bug.Person, float64, string)) error {
+	cutoff := time.Now().Add(-suggestActivityPeriod).Unix()
+
+	labels := make(map[bug.Label]bool)
+	for _, label := range snap.Labels {
+		labels[label] = true
+	}
+
+	var ids []string
+
+	c.muBug.RLock()
+	for id, excerpt := range c.excerpts {
+		if id == snap.Id() || excerpt.EditUnixTime < cutoff {
+			continue
+		}
+		for _, label := range excerpt.Labels {
+			if labels[label] {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+	c.muBug.RUnlock()
+
+	type activity struct {
+		person bug.Person
+		ops    int
+	}
+	activities := make(map[string]*activity)
+
+	for _, id := range ids {
+		b, err := c.ResolveBug(id)
+		if err != nil {
+			return err
+		}
+
+		for _, op := range b.Snapshot().Operations {
+			// reporting a bug is not working on it
+			if _, ok := op.(*bug.CreateOperation); ok || op.GetUnixTime() < cutoff {
+				continue
+			}
+
+			author := op.GetAuthor()
+			key := strings.ToLower(author.Email) + "/" + strings.ToLower(author.Name)
+			if _, ok := activities[key]; !ok {
+				activities[key] = &activity{person: author}
+			}
+			activities[key].ops++
+		}
+	}
+
+	for _, a := range activities {
+		ops := a.ops
+		if ops > suggestActivityCap {
+			ops = suggestActivityCap
+		}
+		add(a.person, float64(ops)/suggestActivityCap,
+			fmt.Sprintf("%d recent edits on related bugs", a.ops))
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindCodeRefs(t *testing.T) {
+	snap := &bug.Snapshot{
+		Title: "crash in cache/alias.go",
+		Comments: []bug.Comment{
+			{Message: "see cache/alias.go:42-50 and main.go:7, also cache/alias.go"},
+			{Message: "nothing here"},
+		},
+	}
+
+	assert.Equal(t, []CodeRef{
+		{Path: "cache/alias.go"},
+		{Path: "cache/alias.go", Start: 42, End: 50},
+		{Path: "main.go", Start: 7, End: 7},
+	}, FindCodeRefs(snap))
+}
+
+func TestSuggestAssigneesFromActivity(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	reporter := bug.Person{Name: "reporter", Email: "reporter@example.com"}
+	alice := bug.Person{Name: "alice", Email: "alice@example.com"}
+	bob := bug.Person{Name: "bob", Email: "bob@example.com"}
+	now := time.Now()
+
+	related, err := c.NewBugRaw(reporter, now, "related", "message", nil, nil)
+	assert.NoError(t, err)
+	_, err = related.ChangeLabelsRaw(alice, now, []string{"ui"}, nil, nil)
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, related.AddCommentRaw(alice, now, "working on it", nil, nil))
+	}
+	assert.NoError(t, related.AddCommentRaw(bob, now, "me too", nil, nil))
+	assert.NoError(t, related.Commit())
+
+	unrelated, err := c.NewBugRaw(reporter, now, "unrelated", "message", nil, nil)
+	assert.NoError(t, err)
+	_, err = unrelated.ChangeLabelsRaw(bob, now, []string{"backend"}, nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, unrelated.Commit())
+
+	target, err := c.NewBugRaw(reporter, now, "target", "message", nil, nil)
+	assert.NoError(t, err)
+	_, err = target.ChangeLabelsRaw(reporter, now, []string{"ui"}, nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, target.Commit())
+
+	suggestions, err := c.SuggestAssignees(target.Id(), 0)
+	assert.NoError(t, err)
+
+	// the reporter of the related bug is not considered working on it
+	assert.Len(t, suggestions, 2)
+	assert.Equal(t, alice, suggestions[0].Person)
+	assert.Equal(t, bob, suggestions[1].Person)
+	assert.True(t, suggestions[0].Score > suggestions[1].Score)
+
+	suggestions, err = c.SuggestAssignees(target.Id(), 1)
+	assert.NoError(t, err)
+	assert.Len(t, suggestions, 1)
+}
@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// SyncResult is the outcome of a Sync
+type SyncResult struct {
+	// Fetch is the output of the fetch
+	Fetch string
+	// Merged are the results of the merge of the bugs that changed locally,
+	// were rejected or were put in quarantine
+	Merged []bug.MergeResult
+	// Diverged are the ids of the bugs changed both locally and on the
+	// remote, whose local operations were rebased on the remote ones
+	Diverged []string
+	// Pushed are the bugs sent to the remote
+	Pushed []bug.PushResult
+	// Warnings are the problems that didn't stop the sync, like a rejected
+	// remote policy
+	Warnings []error
+}
+
+// Sync fetch a remote, merge its bugs and push the local changes to it. With
+// dryRun, the remote is only fetched and the result tells what the merge and
+// the push would do.
+func (c *RepoCache) Sync(remote string, dryRun bool) (SyncResult, error) {
+	var result SyncResult
+
+	if !dryRun {
+		if err := c.checkWritable(); err != nil {
+			return result, err
+		}
+	}
+
+	stdout, err := c.Fetch(remote)
+	result.Fetch = stdout
+	if err != nil {
+		return result, err
+	}
+
+	// the bugs changed locally and not pushed yet are the ones that might
+	// diverge from the remote
+	unpushed, err := bug.PushPreview(c.repo, remote)
+	if err != nil {
+		return result, err
+	}
+
+	changedLocally := make(map[string]bool, len(unpushed))
+	for _, push := range unpushed {
+		changedLocally[push.Id] = true
+	}
+
+	var merges <-chan bug.MergeResult
+	if dryRun {
+		merges = bug.PreviewMergeAll(c.repo, remote)
+	} else {
+		merges = c.MergeAll(remote)
+	}
+
+	for merge := range merges {
+		if merge.Err != nil {
+			// drain the channel to let the merge finish
+			for range merges {
+			}
+			return result, merge.Err
+		}
+
+		if merge.Warning != nil {
+			result.Warnings = append(result.Warnings, merge.Warning)
+			continue
+		}
+
+		if merge.Status == bug.MergeStatusNothing {
+			continue
+		}
+
+		result.Merged = append(result.Merged, merge)
+
+		if merge.Status == bug.MergeStatusUpdated && changedLocally[merge.Id] {
+			result.Diverged = append(result.Diverged, merge.Id)
+		}
+	}
+
+	if dryRun {
+		result.Pushed = unpushed
+		return result, nil
+	}
+
+	result.Pushed, err = c.Push(remote)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
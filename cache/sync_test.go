@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSync(t *testing.T) {
+	repoA := createTestRepo(t)
+	defer os.RemoveAll(repoA.GetPath())
+	repoB := createTestRepo(t)
+	defer os.RemoveAll(repoB.GetPath())
+
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	_, err = repository.InitBareGitRepo(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, repoA.AddRemote("origin", "file://"+dir))
+	require.NoError(t, repoB.AddRemote("origin", "file://"+dir))
+
+	cacheA, err := NewRepoCache(repoA)
+	require.NoError(t, err)
+	defer cacheA.Close()
+
+	cacheB, err := NewRepoCache(repoB)
+	require.NoError(t, err)
+	defer cacheB.Close()
+
+	bugA, err := cacheA.NewBug("title", "message")
+	require.NoError(t, err)
+
+	result, err := cacheA.Sync("origin", false)
+	require.NoError(t, err)
+	assert.Empty(t, result.Merged)
+	require.Len(t, result.Pushed, 1)
+	assert.Equal(t, bugA.Id(), result.Pushed[0].Id)
+
+	// a dry run tells about the new bug, without merging it
+	result, err = cacheB.Sync("origin", true)
+	require.NoError(t, err)
+	require.Len(t, result.Merged, 1)
+	assert.Equal(t, bug.MergeStatusNew, result.Merged[0].Status)
+	assert.Len(t, cacheB.AllBugsIds(), 0)
+
+	result, err = cacheB.Sync("origin", false)
+	require.NoError(t, err)
+	require.Len(t, result.Merged, 1)
+	assert.Empty(t, result.Pushed)
+	assert.Len(t, cacheB.AllBugsIds(), 1)
+
+	// both sides change the bug
+	err = bugA.AddComment("from A")
+	require.NoError(t, err)
+	require.NoError(t, bugA.Commit())
+	_, err = cacheA.Sync("origin", false)
+	require.NoError(t, err)
+
+	bugB, err := cacheB.ResolveBug(bugA.Id())
+	require.NoError(t, err)
+	err = bugB.AddComment("from B")
+	require.NoError(t, err)
+	require.NoError(t, bugB.Commit())
+
+	result, err = cacheB.Sync("origin", true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{bugA.Id()}, result.Diverged)
+	require.Len(t, result.Pushed, 1)
+	assert.Equal(t, 1, result.Pushed[0].OpCount)
+
+	result, err = cacheB.Sync("origin", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{bugA.Id()}, result.Diverged)
+	require.Len(t, result.Pushed, 1)
+
+	bugB, err = cacheB.ResolveBug(bugA.Id())
+	require.NoError(t, err)
+	assert.Len(t, bugB.Snapshot().Comments, 3)
+
+	result, err = cacheA.Sync("origin", false)
+	require.NoError(t, err)
+	require.Len(t, result.Merged, 1)
+	assert.Equal(t, bug.MergeStatusUpdated, result.Merged[0].Status)
+	assert.Empty(t, result.Diverged)
+	assert.Empty(t, result.Pushed)
+}
+
+func TestSyncRejectedPolicy(t *testing.T) {
+	repoA := createTestRepo(t)
+	defer os.RemoveAll(repoA.GetPath())
+	repoB := createTestRepo(t)
+	defer os.RemoveAll(repoB.GetPath())
+
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	_, err = repository.InitBareGitRepo(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, repoA.AddRemote("origin", "file://"+dir))
+	require.NoError(t, repoB.AddRemote("origin", "file://"+dir))
+
+	// an unsigned policy, rejected by the clones requiring a signature
+	policy, err := bug.ParsePolicy([]byte("restrict close someone@example.com"))
+	require.NoError(t, err)
+	require.NoError(t, bug.WritePolicy(repoA, policy, false))
+
+	cacheA, err := NewRepoCache(repoA)
+	require.NoError(t, err)
+	defer cacheA.Close()
+
+	_, err = cacheA.NewBug("title", "message")
+	require.NoError(t, err)
+	_, err = cacheA.Sync("origin", false)
+	require.NoError(t, err)
+
+	cacheB, err := NewRepoCache(repoB)
+	require.NoError(t, err)
+	defer cacheB.Close()
+
+	// the sync goes on with the local policy
+	result, err := cacheB.Sync("origin", false)
+	require.NoError(t, err)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0].Error(), "remote policy rejected")
+	require.Len(t, result.Merged, 1)
+	assert.Equal(t, bug.MergeStatusNew, result.Merged[0].Status)
+	assert.Len(t, cacheB.AllBugsIds(), 1)
+}
@@ -0,0 +1,151 @@
+package cache
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// titleSearchThreshold is the minimal score of the titles matching a search
+const titleSearchThreshold = 0.5
+
+// SearchBugsByTitle return the ids of the bugs whose title fuzzily match the
+// search, the best matches first. Every word of the search has to be found in
+// the title, exactly, as the start or a part of a word, or with a typo.
+func (c *RepoCache) SearchBugsByTitle(search string) []string {
+	words := titleWords(search)
+	if len(words) == 0 {
+		return nil
+	}
+
+	type match struct {
+		excerpt *BugExcerpt
+		score   float64
+	}
+
+	var matches []match
+
+	c.muBug.RLock()
+	for _, excerpt := range c.excerpts {
+		score := titleMatchScore(words, titleWords(excerpt.Title))
+		if score >= titleSearchThreshold {
+			matches = append(matches, match{excerpt: excerpt, score: score})
+		}
+	}
+	c.muBug.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		// the most recently edited first
+		return matches[i].excerpt.EditUnixTime > matches[j].excerpt.EditUnixTime
+	})
+
+	result := make([]string, len(matches))
+	for i, m := range matches {
+		result[i] = m.excerpt.Id
+	}
+
+	return result
+}
+
+func titleWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// titleMatchScore return how well the words of a title match the words of a
+// search, between 0 and 1. It's 0 if any word of the search is missing, but
+// the short words like "on" or "a" are only counted when found, unless the
+// search is only made of them.
+func titleMatchScore(search []string, title []string) float64 {
+	onlyShort := true
+	for _, s := range search {
+		if len([]rune(s)) >= 3 {
+			onlyShort = false
+		}
+	}
+
+	total := 0.0
+	count := 0
+
+	for _, s := range search {
+		best := 0.0
+		for _, t := range title {
+			if score := wordMatchScore(s, t); score > best {
+				best = score
+			}
+		}
+		if best == 0 && !onlyShort && len([]rune(s)) < 3 {
+			continue
+		}
+		if best == 0 {
+			return 0
+		}
+		total += best
+		count++
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	return total / float64(count)
+}
+
+func wordMatchScore(s string, t string) float64 {
+	switch {
+	case s == t:
+		return 1
+	case strings.HasPrefix(t, s):
+		return 0.9
+	case strings.Contains(t, s):
+		return 0.8
+	}
+
+	// tolerate a typo every 4 letters
+	allowed := len([]rune(s)) / 4
+	if allowed > 0 && levenshtein(s, t) <= allowed {
+		return 0.6
+	}
+
+	return 0
+}
+
+// levenshtein return the edit distance between two strings
+func levenshtein(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchBugsByTitle(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	defer c.Close()
+
+	crash, err := c.NewBug("Crash on start with an empty config", "message")
+	require.NoError(t, err)
+	crashes, err := c.NewBug("The app crashes when starting offline", "message")
+	require.NoError(t, err)
+	_, err = c.NewBug("Typo in the documentation", "message")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{crash.Id(), crashes.Id()}, c.SearchBugsByTitle("crash on start"))
+	assert.Equal(t, []string{crash.Id()}, c.SearchBugsByTitle("empty confg"))
+	assert.Equal(t, []string{crashes.Id()}, c.SearchBugsByTitle("offline"))
+	assert.Empty(t, c.SearchBugsByTitle("crash documentation"))
+	assert.Empty(t, c.SearchBugsByTitle(""))
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("crash", "crash"))
+	assert.Equal(t, 1, levenshtein("confg", "config"))
+	assert.Equal(t, 3, levenshtein("kitten", "sitting"))
+	assert.Equal(t, 4, levenshtein("", "four"))
+}
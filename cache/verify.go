@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// CacheReport is the outcome of the verification of the cache file against
+// the bugs of the repository
+type CacheReport struct {
+	// Corrupted is the error preventing to read the cache file, if any. The
+	// other fields are empty in that case.
+	Corrupted error
+	// Checked is the number of bugs checked
+	Checked int
+	// Missing are the bugs without an entry in the cache
+	Missing []string
+	// Stale are the bugs whose entry doesn't match the bug anymore
+	Stale []string
+	// Orphaned are the entries of bugs that don't exist anymore
+	Orphaned []string
+}
+
+// Clean return true if no problem has been found
+func (r CacheReport) Clean() bool {
+	return r.Corrupted == nil && len(r.Missing) == 0 && len(r.Stale) == 0 && len(r.Orphaned) == 0
+}
+
+// VerifyCache cross-check the cache file on disk against the bugs of the
+// repository. Every bug is compiled again to detect the entries that don't
+// match the bug, even if its ref didn't move.
+func (c *RepoCache) VerifyCache() (CacheReport, error) {
+	var report CacheReport
+
+	content, err := readCacheFile(cacheFilePath(c.repo))
+	if err != nil {
+		report.Corrupted = err
+		return report, nil
+	}
+
+	current, err := bug.ListLocalRefHashes(c.repo)
+	if err != nil {
+		return report, err
+	}
+
+	ids := make([]string, 0, len(current))
+	for id := range current {
+		ids = append(ids, id)
+	}
+
+	prefix := "Verifying bug cache... "
+	_, _ = fmt.Fprint(os.Stderr, prefix)
+
+	excerpts, refHashes, err := c.compileBugs(ids, stderrProgress(prefix))
+	if err != nil {
+		return report, err
+	}
+
+	_, _ = fmt.Fprintln(os.Stderr, "Done.")
+
+	report.Checked = len(ids)
+
+	for _, id := range ids {
+		cached, ok := content.excerpts[id]
+		if !ok {
+			report.Missing = append(report.Missing, id)
+			continue
+		}
+
+		same, err := sameExcerpt(cached, excerpts[id])
+		if err != nil {
+			return report, err
+		}
+		if !same || content.refHashes[id] != refHashes[id] {
+			report.Stale = append(report.Stale, id)
+		}
+	}
+
+	for id := range content.excerpts {
+		if _, ok := current[id]; !ok {
+			report.Orphaned = append(report.Orphaned, id)
+		}
+	}
+
+	sort.Strings(report.Missing)
+	sort.Strings(report.Stale)
+	sort.Strings(report.Orphaned)
+
+	return report, nil
+}
+
+// sameExcerpt compare two excerpts as they would be written in the cache file
+func sameExcerpt(a, b *BugExcerpt) (bool, error) {
+	dataA, err := json.Marshal(a)
+	if err != nil {
+		return false, err
+	}
+
+	dataB, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(dataA, dataB), nil
+}
+
+// RepairCache fix the problems found by VerifyCache: the missing and stale
+// entries are compiled again, and the orphaned ones are removed. A corrupted
+// cache file is rebuilt entirely, unless it has been already when the cache
+// was opened.
+func (c *RepoCache) RepairCache(report CacheReport) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+
+	if report.Corrupted != nil {
+		if _, err := readCacheFile(cacheFilePath(c.repo)); err == nil {
+			return nil
+		}
+		return c.Rebuild()
+	}
+
+	outdated := append(append([]string{}, report.Missing...), report.Stale...)
+
+	excerpts, refHashes, err := c.compileBugs(outdated, nil)
+	if err != nil {
+		return err
+	}
+
+	c.muBug.Lock()
+	for id, excerpt := range excerpts {
+		// the version loaded in memory might be as wrong as its excerpt,
+		// unless it has operations not committed yet that would be lost
+		if b, ok := c.bugs[id]; ok && !b.hasPendingOp() {
+			c.removeLoadedBug(id)
+		}
+
+		c.setExcerpt(id, excerpt)
+		c.refHashes[id] = refHashes[id]
+	}
+	for _, id := range report.Orphaned {
+		c.removeLoadedBug(id)
+		c.removeExcerpt(id)
+		delete(c.refHashes, id)
+	}
+	c.muBug.Unlock()
+
+	err = c.assignAliases()
+	if err != nil {
+		return err
+	}
+
+	return c.write()
+}
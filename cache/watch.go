@@ -0,0 +1,207 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// BugEventKind is the kind of change of a bug
+type BugEventKind int
+
+const (
+	_ BugEventKind = iota
+	// BugCreated is sent when a bug appears in the repository
+	BugCreated
+	// BugUpdated is sent when a bug changed
+	BugUpdated
+	// BugRemoved is sent when a bug disappears from the repository
+	BugRemoved
+)
+
+func (k BugEventKind) String() string {
+	switch k {
+	case BugCreated:
+		return "created"
+	case BugUpdated:
+		return "updated"
+	case BugRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// BugEvent notify the subscribers of a change of a bug
+type BugEvent struct {
+	Kind BugEventKind
+	Id   string
+}
+
+// subscriberBufferSize is how many events a subscriber can lag behind before
+// the events are dropped
+const subscriberBufferSize = 64
+
+// DefaultWatchInterval is how often a long-running process should refresh
+// the cache by default
+const DefaultWatchInterval = 2 * time.Second
+
+func mergeEvent(result bug.MergeResult) BugEvent {
+	if result.Status == bug.MergeStatusNew {
+		return BugEvent{Kind: BugCreated, Id: result.Id}
+	}
+	return BugEvent{Kind: BugUpdated, Id: result.Id}
+}
+
+// Subscribe return a channel receiving the changes of the bugs, made through
+// this cache or found by Refresh. The events are dropped if the subscriber
+// doesn't keep up. The returned function cancel the subscription and close
+// the channel. The channel is also closed when the cache is closed.
+func (c *RepoCache) Subscribe() (<-chan BugEvent, func()) {
+	ch := make(chan BugEvent, subscriberBufferSize)
+
+	c.muSubscribers.Lock()
+	c.subscribers[ch] = struct{}{}
+	c.muSubscribers.Unlock()
+
+	cancel := func() {
+		c.muSubscribers.Lock()
+		defer c.muSubscribers.Unlock()
+
+		if _, ok := c.subscribers[ch]; ok {
+			delete(c.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return ch, cancel
+}
+
+// notify send an event to the subscribers, without blocking
+func (c *RepoCache) notify(event BugEvent) {
+	c.muSubscribers.Lock()
+	defer c.muSubscribers.Unlock()
+
+	for ch := range c.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// closeSubscribers close the channel of all the subscribers
+func (c *RepoCache) closeSubscribers() {
+	c.muSubscribers.Lock()
+	defer c.muSubscribers.Unlock()
+
+	for ch := range c.subscribers {
+		delete(c.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Refresh bring the cache up to date with the bugs changed in the repository
+// by another process, such as a git push to this repository. The changes are
+// sent to the subscribers and returned.
+func (c *RepoCache) Refresh() ([]BugEvent, error) {
+	outdated, removed, err := c.changedBugs()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(outdated) == 0 && len(removed) == 0 {
+		return nil, nil
+	}
+
+	excerpts, refHashes, err := c.compileBugs(outdated, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]BugEvent, 0, len(excerpts)+len(removed))
+	// the outdated bugs loaded in memory with operations not committed yet,
+	// that would be lost if the bugs were dropped
+	var pending []*BugCache
+
+	c.muBug.Lock()
+	for id, excerpt := range excerpts {
+		kind := BugUpdated
+		if _, ok := c.excerpts[id]; !ok {
+			kind = BugCreated
+		}
+
+		if b, ok := c.bugs[id]; ok {
+			if b.hasPendingOp() {
+				pending = append(pending, b)
+			} else {
+				c.removeLoadedBug(id)
+			}
+		}
+
+		c.setExcerpt(id, excerpt)
+		c.refHashes[id] = refHashes[id]
+		events = append(events, BugEvent{Kind: kind, Id: id})
+	}
+	for _, id := range removed {
+		c.removeLoadedBug(id)
+		c.removeExcerpt(id)
+		delete(c.refHashes, id)
+		events = append(events, BugEvent{Kind: BugRemoved, Id: id})
+	}
+	c.muBug.Unlock()
+
+	// the lock of the cache is released first, the one of a bug being taken
+	// before
+	for _, b := range pending {
+		err := b.rebase()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the other process might have numbered the new bugs already
+	aliases, err := bug.ReadAliases(c.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	c.muBug.Lock()
+	c.aliases = aliases
+	c.muBug.Unlock()
+
+	err = c.assignAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.write()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		c.notify(event)
+	}
+
+	return events, nil
+}
+
+// Watch refresh the cache at the given interval, until stop is closed. It
+// return the first error encountered, if any.
+func (c *RepoCache) Watch(interval time.Duration, stop <-chan struct{}) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+			_, err := c.Refresh()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
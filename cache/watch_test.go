@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheRefresh(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	events, cancel := c.Subscribe()
+	defer cancel()
+
+	local, err := c.NewBug("local", "message")
+	assert.NoError(t, err)
+	assert.Equal(t, BugEvent{Kind: BugCreated, Id: local.Id()}, <-events)
+
+	// nothing changed outside of the cache
+	changes, err := c.Refresh()
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// another process write directly in the repository
+	author := bug.Person{Name: "other", Email: "other@example.com"}
+	external, _, err := bug.Create(author, time.Now().Unix(), "external", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, external.Commit(repo))
+
+	loaded, err := bug.ReadLocalBug(repo, local.Id())
+	assert.NoError(t, err)
+	_, err = bug.AddComment(loaded, author, time.Now().Unix(), "external comment")
+	assert.NoError(t, err)
+	assert.NoError(t, loaded.Commit(repo))
+
+	changes, err = c.Refresh()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []BugEvent{
+		{Kind: BugCreated, Id: external.Id()},
+		{Kind: BugUpdated, Id: local.Id()},
+	}, changes)
+
+	assert.ElementsMatch(t, changes, []BugEvent{<-events, <-events})
+
+	// the new bug is visible and numbered
+	assert.Len(t, c.AllBugsIds(), 2)
+	_, ok := c.Alias(external.Id())
+	assert.True(t, ok)
+
+	// the bug loaded in memory has been updated
+	b, err := c.ResolveBug(local.Id())
+	assert.NoError(t, err)
+	assert.Len(t, b.Snapshot().Comments, 2)
+
+	// the channel is closed when the subscription is cancelled
+	cancel()
+	_, ok = <-events
+	assert.False(t, ok)
+}
+
+func TestCacheRefreshPendingOperations(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	local, err := c.NewBug("local", "message")
+	assert.NoError(t, err)
+
+	assert.NoError(t, local.AddComment("pending comment"))
+
+	// another process commit on the bug while the cache has pending operations
+	author := bug.Person{Name: "other", Email: "other@example.com"}
+	loaded, err := bug.ReadLocalBug(repo, local.Id())
+	assert.NoError(t, err)
+	_, err = bug.AddComment(loaded, author, time.Now().Unix(), "external comment")
+	assert.NoError(t, err)
+	assert.NoError(t, loaded.Commit(repo))
+
+	_, err = c.Refresh()
+	assert.NoError(t, err)
+
+	// the bug in memory is up to date and still has its pending operations
+	b, err := c.ResolveBug(local.Id())
+	assert.NoError(t, err)
+	assert.True(t, b == local)
+	comments := local.Snapshot().Comments
+	assert.Len(t, comments, 3)
+	assert.Equal(t, "external comment", comments[1].Message)
+	assert.Equal(t, "pending comment", comments[2].Message)
+
+	// the commit keep the external one
+	assert.NoError(t, local.Commit())
+
+	hashes, err := repo.ListCommits("refs/bugs/" + local.Id())
+	assert.NoError(t, err)
+	assert.Len(t, hashes, 3)
+	assert.Equal(t, loaded.LastCommit(), hashes[1])
+}
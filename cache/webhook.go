@@ -0,0 +1,371 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/backoff"
+)
+
+// webhookConfigSection is the git config section holding the webhooks, as
+// git-bug.webhook.<name>.<key>
+const webhookConfigSection = "git-bug.webhook"
+
+// HookTest is the kind of the event sent by `git bug webhook test`. It
+// doesn't trigger the hook scripts.
+const HookTest HookEventKind = "test"
+
+// the headers of the requests of the webhooks
+const (
+	WebhookEventHeader     = "X-Git-Bug-Event"
+	WebhookDeliveryHeader  = "X-Git-Bug-Delivery"
+	WebhookSignatureHeader = "X-Git-Bug-Signature"
+)
+
+// the retries of a failed delivery, and how long a closing cache wait for the
+// pending deliveries before abandoning them
+var (
+	webhookTimeout      = 10 * time.Second
+	webhookAttempts     = 5
+	webhookRetryDelay   = time.Second
+	webhookMaxDelay     = time.Minute
+	webhookCloseTimeout = 10 * time.Second
+)
+
+var webhookNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// Webhook is an URL receiving the events of the repository as JSON, POSTed
+// after each matching mutation
+type Webhook struct {
+	Name string
+	URL  string
+	// Secret sign the payloads with HMAC-SHA256, if set
+	Secret string
+	// Events are the kinds of events sent, all of them if empty
+	Events []HookEventKind
+}
+
+// Accept tell if the events of this kind are sent to the webhook
+func (w Webhook) Accept(kind HookEventKind) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, k := range w.Events {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate check that the webhook can be stored and used
+func (w Webhook) Validate() error {
+	if !webhookNameRegexp.MatchString(w.Name) {
+		return fmt.Errorf("invalid webhook name %q, only letters, digits, - and _ are allowed", w.Name)
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook url %q, expected an http or https url", w.URL)
+	}
+
+	for _, kind := range w.Events {
+		if !knownHookKind(kind) {
+			return fmt.Errorf("unknown event %q", kind)
+		}
+	}
+
+	return nil
+}
+
+func knownHookKind(kind HookEventKind) bool {
+	for _, k := range HookKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseHookKinds parse a comma separated list of kinds of events
+func ParseHookKinds(str string) ([]HookEventKind, error) {
+	var result []HookEventKind
+
+	for _, item := range strings.Split(str, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		kind := HookEventKind(item)
+		if !knownHookKind(kind) {
+			return nil, fmt.Errorf("unknown event %q", item)
+		}
+		result = append(result, kind)
+	}
+
+	return result, nil
+}
+
+// ReadWebhooks read the webhooks configured in the repository, sorted by name
+func ReadWebhooks(repo repository.RepoCommon) ([]Webhook, error) {
+	configs, err := repo.ReadConfigs(webhookConfigSection + ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]map[string]string)
+	for key, value := range configs {
+		key = strings.TrimPrefix(key, webhookConfigSection+".")
+		i := strings.LastIndex(key, ".")
+		if i < 0 {
+			continue
+		}
+		name, field := key[:i], key[i+1:]
+		if byName[name] == nil {
+			byName[name] = make(map[string]string)
+		}
+		byName[name][field] = value
+	}
+
+	webhooks := make([]Webhook, 0, len(byName))
+	for name, fields := range byName {
+		if fields["url"] == "" {
+			continue
+		}
+
+		events, err := ParseHookKinds(fields["events"])
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s.events: %v", webhookConfigSection, name, err)
+		}
+
+		webhooks = append(webhooks, Webhook{
+			Name:   name,
+			URL:    fields["url"],
+			Secret: fields["secret"],
+			Events: events,
+		})
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].Name < webhooks[j].Name
+	})
+
+	return webhooks, nil
+}
+
+// AddWebhook store a new webhook in the configuration of the repository
+func AddWebhook(repo repository.RepoCommon, webhook Webhook) error {
+	if err := webhook.Validate(); err != nil {
+		return err
+	}
+
+	webhooks, err := ReadWebhooks(repo)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range webhooks {
+		if w.Name == webhook.Name {
+			return fmt.Errorf("a webhook named %s already exist", webhook.Name)
+		}
+	}
+
+	events := make([]string, len(webhook.Events))
+	for i, kind := range webhook.Events {
+		events[i] = string(kind)
+	}
+
+	fields := map[string]string{
+		"url":    webhook.URL,
+		"secret": webhook.Secret,
+		"events": strings.Join(events, ","),
+	}
+
+	for key, value := range fields {
+		if value == "" {
+			continue
+		}
+		err := repo.StoreConfig(fmt.Sprintf("%s.%s.%s", webhookConfigSection, webhook.Name, key), value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveWebhook remove a webhook from the configuration of the repository
+func RemoveWebhook(repo repository.RepoCommon, name string) error {
+	webhooks, err := ReadWebhooks(repo)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range webhooks {
+		if w.Name == name {
+			return repo.RmConfigs(fmt.Sprintf("%s.%s", webhookConfigSection, name))
+		}
+	}
+
+	return fmt.Errorf("no webhook named %s", name)
+}
+
+// WebhookSignature return the signature of a payload, as sent in the
+// X-Git-Bug-Signature header: "sha256=" followed by the hex encoded
+// HMAC-SHA256 of the payload, keyed with the secret of the webhook
+func WebhookSignature(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Deliver POST an event to the webhook, once. A response with a status other
+// than 2xx is an error.
+func (w Webhook) Deliver(ctx context.Context, event HookEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return w.post(ctx, event.Kind, newDeliveryId(), payload)
+}
+
+func (w Webhook) post(ctx context.Context, kind HookEventKind, delivery string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "git-bug")
+	req.Header.Set(WebhookEventHeader, string(kind))
+	req.Header.Set(WebhookDeliveryHeader, delivery)
+	if w.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, WebhookSignature(w.Secret, payload))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// deliverWithRetries POST an event to the webhook, retrying with a growing
+// delay when it fails. All the attempts share the same delivery id, so that
+// the receiver can ignore the duplicates.
+func (w Webhook) deliverWithRetries(ctx context.Context, event HookEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	delivery := newDeliveryId()
+	b := backoff.New(webhookRetryDelay, webhookMaxDelay, 0.1)
+
+	for {
+		err = w.post(ctx, event.Kind, delivery, payload)
+		if err == nil {
+			return nil
+		}
+
+		b.Failure()
+		if b.Failures() >= webhookAttempts {
+			return fmt.Errorf("%v, after %d attempts", err, b.Failures())
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%v, abandoned after %d attempts", err, b.Failures())
+		case <-time.After(b.Next()):
+		}
+	}
+}
+
+func newDeliveryId() string {
+	raw := make([]byte, 16)
+	_, _ = rand.Read(raw)
+	return hex.EncodeToString(raw)
+}
+
+// loadWebhooks read the webhooks from the configuration
+func (c *RepoCache) loadWebhooks() error {
+	webhooks, err := ReadWebhooks(c.repo)
+	if err != nil {
+		return err
+	}
+
+	c.webhooks = webhooks
+	c.webhooksCtx, c.webhooksCancel = context.WithCancel(context.Background())
+
+	return nil
+}
+
+// sendWebhooks deliver an event to the matching webhooks, in the background.
+// A failing delivery is reported but doesn't fail the mutation, which is
+// already done.
+func (c *RepoCache) sendWebhooks(event HookEvent) {
+	for _, w := range c.webhooks {
+		if !w.Accept(event.Kind) {
+			continue
+		}
+
+		c.deliveries.Add(1)
+		go func(w Webhook) {
+			defer c.deliveries.Done()
+
+			err := w.deliverWithRetries(c.webhooksCtx, event)
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "webhook %s failed: %v\n", w.Name, err)
+			}
+		}(w)
+	}
+}
+
+// waitWebhooks wait for the pending deliveries of the webhooks, abandoning
+// their retries after a while
+func (c *RepoCache) waitWebhooks() {
+	if c.webhooksCancel == nil {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		c.deliveries.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(webhookCloseTimeout):
+		c.webhooksCancel()
+		<-done
+	}
+
+	c.webhooksCancel()
+}
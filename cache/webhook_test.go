@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookConfig(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	assert.Error(t, AddWebhook(repo, Webhook{Name: "bad name", URL: "http://example.com"}))
+	assert.Error(t, AddWebhook(repo, Webhook{Name: "chat", URL: "ftp://example.com"}))
+	assert.Error(t, AddWebhook(repo, Webhook{Name: "chat", URL: "http://example.com", Events: []HookEventKind{"foo"}}))
+
+	assert.NoError(t, AddWebhook(repo, Webhook{
+		Name:   "chat",
+		URL:    "https://chat.example.com/hook",
+		Secret: "secret",
+		Events: []HookEventKind{HookBugCreated, HookCommentAdded},
+	}))
+	assert.NoError(t, AddWebhook(repo, Webhook{Name: "ci", URL: "http://ci.example.com"}))
+	assert.Error(t, AddWebhook(repo, Webhook{Name: "ci", URL: "http://ci.example.com"}))
+
+	webhooks, err := ReadWebhooks(repo)
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 2)
+	assert.Equal(t, "chat", webhooks[0].Name)
+	assert.Equal(t, "secret", webhooks[0].Secret)
+	assert.True(t, webhooks[0].Accept(HookCommentAdded))
+	assert.False(t, webhooks[0].Accept(HookStatusChanged))
+	assert.True(t, webhooks[1].Accept(HookStatusChanged))
+
+	assert.NoError(t, RemoveWebhook(repo, "chat"))
+	assert.Error(t, RemoveWebhook(repo, "chat"))
+
+	webhooks, err = ReadWebhooks(repo)
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 1)
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	retryDelay := webhookRetryDelay
+	webhookRetryDelay = 10 * time.Millisecond
+	defer func() { webhookRetryDelay = retryDelay }()
+
+	var mu sync.Mutex
+	var events []HookEvent
+	deliveries := make(map[string]int)
+	failed := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, WebhookSignature("secret", payload), r.Header.Get(WebhookSignatureHeader))
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		deliveries[r.Header.Get(WebhookDeliveryHeader)]++
+
+		// the first delivery fail once, and is retried
+		if !failed {
+			failed = true
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		var event HookEvent
+		assert.NoError(t, json.Unmarshal(payload, &event))
+		assert.Equal(t, string(event.Kind), r.Header.Get(WebhookEventHeader))
+		events = append(events, event)
+	}))
+	defer server.Close()
+
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	assert.NoError(t, AddWebhook(repo, Webhook{
+		Name:   "test",
+		URL:    server.URL,
+		Secret: "secret",
+		Events: []HookEventKind{HookBugCreated, HookBugUpdated},
+	}))
+
+	c, err := NewRepoCache(repo)
+	assert.NoError(t, err)
+
+	b, err := c.NewBug("title", "message")
+	assert.NoError(t, err)
+	// not sent, the webhook doesn't accept this kind
+	assert.NoError(t, b.AddComment("comment"))
+	assert.NoError(t, b.SetTitle("new title"))
+
+	// closing the cache wait for the pending deliveries
+	assert.NoError(t, c.Close())
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Len(t, events, 2)
+	assert.Len(t, deliveries, 2)
+
+	kinds := []HookEventKind{events[0].Kind, events[1].Kind}
+	assert.Contains(t, kinds, HookBugCreated)
+	assert.Contains(t, kinds, HookBugUpdated)
+
+	for _, event := range events {
+		assert.Equal(t, b.Id(), event.BugId)
+	}
+
+	// the retry kept the delivery id
+	attempts := 0
+	for _, n := range deliveries {
+		attempts += n
+	}
+	assert.Equal(t, 3, attempts)
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"testing"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheLinkedWorktree(t *testing.T) {
+	repo := createTestRepo(t)
+	defer os.RemoveAll(repo.GetPath())
+
+	worktreeDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(worktreeDir)
+
+	// a worktree needs a commit to check out
+	for _, args := range [][]string{
+		{"commit", "--allow-empty", "-m", "initial"},
+		{"worktree", "add", path.Join(worktreeDir, "wt")},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo.GetPath()
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	_, err = cache.NewBug("title", "message")
+	require.NoError(t, err)
+	require.NoError(t, cache.Close())
+
+	worktree, err := repository.NewGitRepo(path.Join(worktreeDir, "wt"), bug.Witnesser)
+	require.NoError(t, err)
+
+	// the worktree share the git directory of the main repository
+	assert.Equal(t, path.Join(worktreeDir, "wt"), worktree.GetPath())
+	assert.Equal(t, repo.GetGitDir(), worktree.GetGitDir())
+
+	cache, err = NewRepoCache(worktree)
+	require.NoError(t, err)
+	defer cache.Close()
+
+	assert.Len(t, cache.AllBugsIds(), 1)
+}
@@ -1,23 +1,46 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
 
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/input"
 	"github.com/MichaelMure/git-bug/util/interrupt"
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 var (
-	addTitle       string
-	addMessage     string
-	addMessageFile string
+	addTitle        string
+	addMessage      string
+	addMessageFile  string
+	addStdinMessage bool
+	addLabels       []string
+	addAssignee     string
+	addAttachments  []string
 )
 
 func runAddBug(cmd *cobra.Command, args []string) error {
 	var err error
 
+	if addStdinMessage && (addMessage != "" || addMessageFile != "") {
+		return errors.New("--stdin-message can't be used with --message or --file")
+	}
+	if addStdinMessage && addTitle == "" {
+		return errors.New("--stdin-message requires a title with --title")
+	}
+
+	for _, label := range addLabels {
+		if err := bug.Label(strings.TrimSpace(label)).Validate(); err != nil {
+			return fmt.Errorf("invalid label %q: %v", label, err)
+		}
+	}
+
 	backend, err := cache.NewRepoCache(repo)
 	if err != nil {
 		return err
@@ -25,6 +48,28 @@ func runAddBug(cmd *cobra.Command, args []string) error {
 	defer backend.Close()
 	interrupt.RegisterCleaner(backend.Close)
 
+	// resolved first, to not create the bug if the assignee is wrong
+	var assignee *bug.Person
+	if addAssignee != "" {
+		assignee, err = resolveAssignee(backend, addAssignee)
+		if err != nil {
+			return err
+		}
+	}
+
+	files, references, err := storeAttachments(backend, addAttachments)
+	if err != nil {
+		return err
+	}
+
+	if addStdinMessage {
+		data, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		addMessage = strings.TrimSpace(string(data))
+	}
+
 	if addMessageFile != "" && addMessage == "" {
 		addTitle, addMessage, err = input.BugCreateFileInput(addMessageFile)
 		if err != nil {
@@ -32,7 +77,11 @@ func runAddBug(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if addMessageFile == "" && (addMessage == "" || addTitle == "") {
+	// without a terminal, a title is enough
+	interactive := addMessageFile == "" && !addStdinMessage &&
+		(addTitle == "" || (addMessage == "" && terminal.IsTerminal(int(os.Stdin.Fd()))))
+
+	if interactive {
 		addTitle, addMessage, err = input.BugCreateEditorInput(backend, addTitle, addMessage)
 
 		if err == input.ErrEmptyTitle {
@@ -44,19 +93,78 @@ func runAddBug(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	b, err := backend.NewBug(addTitle, addMessage)
+	b, err := backend.NewBugWithFiles(addTitle, withReferences(addMessage, references), files)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("%s created\n", b.HumanId())
+	if len(addLabels) > 0 {
+		_, err = b.ChangeLabels(addLabels, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	if assignee != nil {
+		err = b.SetAssignee(assignee)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(addLabels) > 0 || assignee != nil {
+		err = b.Commit()
+		if err != nil {
+			return err
+		}
+	}
+
+	if interactive {
+		fmt.Printf("%s created\n", b.HumanId())
+	} else {
+		// only the id, for the scripts
+		fmt.Println(b.Id())
+	}
 
 	return nil
 }
 
+// resolveAssignee find the only person matching a query among the people
+// known in the repository
+func resolveAssignee(backend *cache.RepoCache, query string) (*bug.Person, error) {
+	identities, err := backend.QueryIdentities(query)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(identities) {
+	case 0:
+		return nil, fmt.Errorf("nobody matching %q", query)
+	case 1:
+		person := identities[0].Person()
+		return &person, nil
+	default:
+		names := make([]string, len(identities))
+		for i, identity := range identities {
+			names[i] = identity.DisplayName()
+		}
+		return nil, fmt.Errorf("several people match %q: %s", query, strings.Join(names, ", "))
+	}
+}
+
 var addCmd = &cobra.Command{
 	Use:     "add",
+	Aliases: []string{"new"},
 	Short:   "Create a new bug",
+	Long: `Create a new bug.
+
+Without a title, or without a message when run from a terminal, an editor is
+opened to write them. Otherwise, the bug is created without any interaction
+and only its id is printed, which allows scripts and CI systems to open bugs.`,
+	Example: `git bug add
+git bug add --title "Crash on start" --label crash --assignee jane
+make test 2>&1 | git bug add --title "Tests failing" --stdin-message
+git bug add --title "Broken layout" --attach screenshot.png`,
 	PreRunE: loadRepo,
 	RunE:    runAddBug,
 }
@@ -75,4 +183,16 @@ func init() {
 	addCmd.Flags().StringVarP(&addMessageFile, "file", "F", "",
 		"Take the message from the given file. Use - to read the message from the standard input",
 	)
+	addCmd.Flags().BoolVarP(&addStdinMessage, "stdin-message", "", false,
+		"Read the message from the standard input, the title being given with --title",
+	)
+	addCmd.Flags().StringArrayVarP(&addLabels, "label", "l", nil,
+		"Add a label to the bug. Can be repeated",
+	)
+	addCmd.Flags().StringVarP(&addAssignee, "assignee", "a", "",
+		"Assign the bug to the only person matching this name or login",
+	)
+	addCmd.Flags().StringArrayVarP(&addAttachments, "attach", "", nil,
+		"Attach a file to the bug, referenced at the end of the message. Can be repeated",
+	)
 }
@@ -0,0 +1,14 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Produce and check tamper-evident logs of the activity on the bugs",
+}
+
+func init() {
+	RootCmd.AddCommand(auditCmd)
+}
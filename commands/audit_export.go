@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditExportOutput string
+	auditExportSign   bool
+)
+
+func runAuditExport(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	log, err := backend.AuditLog()
+	if err != nil {
+		return err
+	}
+
+	if auditExportSign {
+		err = backend.SignAuditLog(log)
+		if err != nil {
+			return err
+		}
+	}
+
+	var out io.Writer = os.Stdout
+
+	if auditExportOutput != "" && auditExportOutput != "-" {
+		f, err := os.Create(auditExportOutput)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	err = cache.WriteAuditLog(out, log)
+	if err != nil {
+		return err
+	}
+
+	if out != os.Stdout {
+		fmt.Printf("%d operation(s) exported to %s\n", len(log.Entries), auditExportOutput)
+		fmt.Printf("Head: %s\n", log.Head())
+	}
+
+	return nil
+}
+
+var auditExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a hash-chained log of all the operations on the bugs",
+	Long: `Export a log of all the operations on the bugs, with their authors and
+times, as JSON lines ordered by time.
+
+Each entry holds the hash of the previous one, so that changing, removing or
+reordering entries breaks the chain. With --sign, the hash of the last entry,
+which vouch for the whole log, is signed with the GPG key git uses to sign
+commits, and the signature is written as the last line.
+
+Keep the last hash, or the signed log, somewhere safe: as long as no operation
+older than the last one is pulled, a later log starts with the same entries.
+Use "git bug audit verify" to check a log.`,
+	Example: `git bug audit export --sign -o audit.jsonl`,
+	PreRunE: loadRepo,
+	RunE:    runAuditExport,
+}
+
+func init() {
+	auditCmd.AddCommand(auditExportCmd)
+
+	auditExportCmd.Flags().SortFlags = false
+
+	auditExportCmd.Flags().StringVarP(&auditExportOutput, "output", "o", "",
+		"Write the log to the given file instead of the standard output")
+	auditExportCmd.Flags().BoolVarP(&auditExportSign, "sign", "s", false,
+		"Sign the log with your GPG key")
+}
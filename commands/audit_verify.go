@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditVerifyHead string
+)
+
+func runAuditVerify(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("You must provide the file of the log to verify")
+	}
+
+	var in io.Reader = os.Stdin
+
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	log, err := cache.ReadAuditLog(in)
+	if err != nil {
+		return err
+	}
+
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	err = backend.VerifyAuditLog(log)
+	if err != nil {
+		return err
+	}
+
+	if auditVerifyHead != "" {
+		found := false
+		for _, entry := range log.Entries {
+			if entry.Hash == auditVerifyHead {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("the log doesn't contain the entry %s", auditVerifyHead)
+		}
+	}
+
+	fmt.Printf("%d operation(s), chain valid\n", len(log.Entries))
+	if log.Signature != "" {
+		fmt.Println("Signature valid")
+	} else {
+		fmt.Println("Not signed")
+	}
+	fmt.Printf("Head: %s\n", log.Head())
+
+	return nil
+}
+
+var auditVerifyCmd = &cobra.Command{
+	Use:   "verify <file|->",
+	Short: "Check that an audit log has not been tampered with",
+	Long: `Check that the entries of an audit log are correctly chained and, if the log
+is signed, that its signature is valid.
+
+With --head, also check that the log extends an earlier one, by containing the
+last entry of that earlier log.`,
+	Example: `git bug audit verify audit.jsonl
+git bug audit verify --head 5f0e2b... audit.jsonl`,
+	PreRunE: loadRepo,
+	RunE:    runAuditVerify,
+}
+
+func init() {
+	auditCmd.AddCommand(auditVerifyCmd)
+
+	auditVerifyCmd.Flags().SortFlags = false
+
+	auditVerifyCmd.Flags().StringVarP(&auditVerifyHead, "head", "H", "",
+		"The hash of the last entry of an earlier log that this log must contain")
+}
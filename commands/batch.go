@@ -0,0 +1,348 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	batchStopOnError bool
+	batchJson        bool
+)
+
+// batchCommand is a mutation read by the batch mode, either as a JSON object
+// or parsed from a command line
+type batchCommand struct {
+	Action  string   `json:"action"`
+	Bug     string   `json:"bug,omitempty"`
+	Title   string   `json:"title,omitempty"`
+	Message string   `json:"message,omitempty"`
+	Labels  []string `json:"labels,omitempty"`
+	Remove  []string `json:"remove,omitempty"`
+}
+
+// batchResult is the outcome of a batchCommand
+type batchResult struct {
+	Line  int    `json:"line"`
+	Bug   string `json:"bug,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+func runBatch(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	var input io.Reader = os.Stdin
+	if len(args) > 0 && args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	failed := 0
+
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(nil, 1<<20)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		result := batchResult{Line: line}
+
+		command, err := parseBatchCommand(text)
+		if err == nil {
+			result.Bug, err = applyBatchCommand(backend, command)
+		}
+		if err != nil {
+			result.Error = err.Error()
+			failed++
+		}
+
+		switch {
+		case batchJson:
+			if err := encoder.Encode(result); err != nil {
+				return err
+			}
+		case result.Error != "":
+			fmt.Fprintf(os.Stderr, "line %d: %s\n", line, result.Error)
+		default:
+			fmt.Println(result.Bug)
+		}
+
+		if result.Error != "" && batchStopOnError {
+			break
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d command(s) failed", failed)
+	}
+
+	return nil
+}
+
+// parseBatchCommand read a command given as a JSON object, or as a command
+// line like the ones of git bug
+func parseBatchCommand(text string) (batchCommand, error) {
+	var command batchCommand
+
+	if strings.HasPrefix(text, "{") {
+		err := json.Unmarshal([]byte(text), &command)
+		return command, err
+	}
+
+	words, err := splitBatchLine(text)
+	if err != nil {
+		return command, err
+	}
+
+	// the value flags of the commands
+	var positional []string
+	for i := 0; i < len(words); i++ {
+		switch words[i] {
+		case "-t", "--title", "-m", "--message", "-l", "--label":
+			if i+1 >= len(words) {
+				return command, fmt.Errorf("missing value for %s", words[i])
+			}
+			switch words[i] {
+			case "-t", "--title":
+				command.Title = words[i+1]
+			case "-m", "--message":
+				command.Message = words[i+1]
+			default:
+				command.Labels = append(command.Labels, words[i+1])
+			}
+			i++
+		default:
+			positional = append(positional, words[i])
+		}
+	}
+
+	if len(positional) == 0 {
+		return command, errors.New("empty command")
+	}
+
+	// "comment add", "label rm" ... are flattened as a single action
+	action, params := positional[0], positional[1:]
+	switch action {
+	case "comment", "label", "status", "title":
+		if len(params) == 0 {
+			return command, fmt.Errorf("missing sub-command for %s", action)
+		}
+		action, params = action+" "+params[0], params[1:]
+	}
+
+	command.Action = action
+
+	switch action {
+	case "add", "new", "label add", "label rm", "comment add", "title edit", "status open", "status close":
+	default:
+		return command, fmt.Errorf("unknown command %s", action)
+	}
+
+	switch action {
+	case "add", "new":
+		if len(params) > 0 {
+			return command, fmt.Errorf("unexpected arguments %s, the title is given with -t", strings.Join(params, " "))
+		}
+		return command, nil
+	}
+
+	if len(params) == 0 {
+		return command, errors.New("missing bug id")
+	}
+	command.Bug, params = params[0], params[1:]
+
+	switch action {
+	case "label add":
+		command.Labels = append(command.Labels, params...)
+	case "label rm":
+		command.Remove = params
+	default:
+		if len(params) > 0 {
+			return command, fmt.Errorf("unexpected arguments %s", strings.Join(params, " "))
+		}
+	}
+
+	return command, nil
+}
+
+// splitBatchLine split a command line on the spaces, except in single or
+// double quotes. A backslash escape the next character.
+func splitBatchLine(text string) ([]string, error) {
+	var result []string
+	var current strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+
+	for _, r := range text {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				result = append(result, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, errors.New("unterminated quote or escape")
+	}
+
+	if inWord {
+		result = append(result, current.String())
+	}
+
+	return result, nil
+}
+
+// applyBatchCommand apply a command and return the id of the bug changed
+func applyBatchCommand(backend *cache.RepoCache, command batchCommand) (string, error) {
+	if command.Action == "add" || command.Action == "new" {
+		if command.Title == "" {
+			return "", errors.New("a new bug needs a title")
+		}
+
+		b, err := backend.NewBug(command.Title, command.Message)
+		if err != nil {
+			return "", err
+		}
+
+		if len(command.Labels) > 0 {
+			if _, err := b.ChangeLabels(command.Labels, nil); err != nil {
+				return b.Id(), err
+			}
+			if err := b.Commit(); err != nil {
+				return b.Id(), err
+			}
+		}
+
+		return b.Id(), nil
+	}
+
+	if command.Bug == "" {
+		return "", errors.New("missing bug id")
+	}
+
+	b, err := backend.ResolveBugPrefix(command.Bug)
+	if err != nil {
+		return "", err
+	}
+
+	switch command.Action {
+	case "comment add":
+		if command.Message == "" {
+			return b.Id(), errors.New("empty message")
+		}
+		err = b.AddComment(command.Message)
+	case "label add", "label rm", "label":
+		_, err = b.ChangeLabels(command.Labels, command.Remove)
+	case "status open":
+		err = b.Open()
+	case "status close":
+		err = b.Close()
+	case "title edit":
+		if command.Title == "" {
+			return b.Id(), errors.New("empty title")
+		}
+		err = b.SetTitle(command.Title)
+	default:
+		return b.Id(), fmt.Errorf("unknown action %s", command.Action)
+	}
+
+	if err != nil {
+		return b.Id(), err
+	}
+
+	return b.Id(), b.Commit()
+}
+
+var batchCmd = &cobra.Command{
+	Use:   "batch [<file>]",
+	Short: "Apply a sequence of changes read from the standard input",
+	Long: `Apply a sequence of changes read from the standard input, or from a file, with the repository opened only once. This is much faster than running a git bug command per change when scripting a lot of them.
+
+Each line is a change, given as a command line like the ones of git bug:
+  add -t <title> [-m <message>] [-l <label>]...
+  comment add <id> -m <message>
+  label add <id> <label>...
+  label rm <id> <label>...
+  status open <id>
+  status close <id>
+  title edit <id> -t <title>
+
+or as a JSON object with an "action" among "add", "comment add", "label",
+"status open", "status close" and "title edit", and the fields "bug",
+"title", "message", "labels" (added) and "remove" (labels removed).
+
+The empty lines and the lines starting with # are ignored.
+
+For each change, the id of the bug is printed, or an error on the standard
+error. With --json, a JSON object is printed for each change, with the line,
+the bug and the error, if any.`,
+	Example: `git bug batch <<EOF
+add -t "Crash on start" -m "It crashes"
+label add 2f15 bug crash
+{"action": "status close", "bug": "5a36"}
+EOF`,
+	PreRunE: loadRepo,
+	RunE:    runBatch,
+}
+
+func init() {
+	RootCmd.AddCommand(batchCmd)
+
+	batchCmd.Flags().SortFlags = false
+
+	batchCmd.Flags().BoolVarP(&batchStopOnError, "stop-on-error", "s", false,
+		"Stop at the first change that fails")
+	batchCmd.Flags().BoolVarP(&batchJson, "json", "j", false,
+		"Print the result of each change as JSON")
+}
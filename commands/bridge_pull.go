@@ -37,8 +37,12 @@ func runBridgePull(cmd *cobra.Command, args []string) error {
 }
 
 var bridgePullCmd = &cobra.Command{
-	Use:     "pull [<name>]",
-	Short:   "Pull updates",
+	Use:   "pull [<name>]",
+	Short: "Pull updates",
+	Long: `Pull updates from the other bug tracker.
+
+After a first complete import, only the issues updated since the last
+successful pull are imported, when the bridge supports it.`,
 	PreRunE: loadRepo,
 	RunE:    runBridgePull,
 }
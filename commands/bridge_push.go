@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"github.com/MichaelMure/git-bug/bridge"
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runBridgePush(cmd *cobra.Command, args []string) error {
+	// the ids of the exported issues and comments are stored in the bugs
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	var b *core.Bridge
+
+	if len(args) == 0 {
+		b, err = bridge.DefaultBridge(backend)
+	} else {
+		b, err = bridge.NewBridgeFromFullName(backend, args[0])
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return b.ExportAll()
+}
+
+var bridgePushCmd = &cobra.Command{
+	Use:   "push [<name>]",
+	Short: "Push updates",
+	Long: `Push updates to the other bug tracker.
+
+After a first complete export, only the bugs changed since the last successful
+push are exported, when the bridge supports it. The bugs restricted to an
+audience (see git bug visibility) are not exported.`,
+	PreRunE: loadRepo,
+	RunE:    runBridgePush,
+}
+
+func init() {
+	bridgeCmd.AddCommand(bridgePushCmd)
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runCache(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	info, err := backend.CacheInfo()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("path: %s\n", info.Path)
+	fmt.Printf("format version: %d\n", info.FormatVersion)
+	fmt.Printf("bugs: %d\n", info.Bugs)
+	fmt.Printf("identities: %d\n", info.Identities)
+	fmt.Printf("size: %d bytes\n", info.Size)
+
+	return nil
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Display information about the bug cache",
+	Long: `Display information about the bug cache.
+
+git-bug keep an excerpt of each bug in a cache file to query them without
+reading the whole history from git. The cache is updated automatically when
+the bugs change.`,
+	PreRunE: loadRepo,
+	RunE:    runCache,
+}
+
+func init() {
+	RootCmd.AddCommand(cacheCmd)
+}
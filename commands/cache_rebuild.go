@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runCacheRebuild(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	return backend.Rebuild()
+}
+
+var cacheRebuildCmd = &cobra.Command{
+	Use:     "rebuild",
+	Short:   "Discard the bug cache and build it again from the repository",
+	PreRunE: loadRepo,
+	RunE:    runCacheRebuild,
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheRebuildCmd)
+}
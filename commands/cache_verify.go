@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cacheVerifyDryRun bool
+)
+
+func runCacheVerify(cmd *cobra.Command, args []string) error {
+	// the check doesn't need the lock, to not modify the cache file before
+	// it's inspected
+	reader, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	interrupt.RegisterCleaner(reader.Close)
+
+	report, err := reader.VerifyCache()
+	if err != nil {
+		return err
+	}
+
+	if report.Corrupted != nil {
+		fmt.Printf("%s the cache file can't be read: %v\n", colors.Red("corrupted"), report.Corrupted)
+	}
+	for _, id := range report.Missing {
+		fmt.Printf("%s %s\n", colors.Yellow("missing "), id)
+	}
+	for _, id := range report.Stale {
+		fmt.Printf("%s %s\n", colors.Yellow("stale   "), id)
+	}
+	for _, id := range report.Orphaned {
+		fmt.Printf("%s %s\n", colors.Yellow("orphaned"), id)
+	}
+
+	if report.Clean() {
+		fmt.Printf("%d bugs checked, the cache is valid\n", report.Checked)
+		return nil
+	}
+
+	if cacheVerifyDryRun {
+		return fmt.Errorf("the cache is invalid, run without --dry-run to repair it")
+	}
+
+	err = reader.Close()
+	if err != nil {
+		return err
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	err = backend.RepairCache(report)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("the cache has been repaired")
+
+	return nil
+}
+
+var cacheVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check the bug cache against the repository and repair it",
+	Long: `Check the bug cache against the repository and repair it.
+
+Every bug is compiled again and compared with its entry in the cache. The bugs
+missing from the cache, the entries that don't match their bug anymore and the
+entries of bugs that don't exist anymore are reported, then repaired.`,
+	PreRunE: loadRepo,
+	RunE:    runCacheVerify,
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheVerifyCmd)
+
+	cacheVerifyCmd.Flags().SortFlags = false
+
+	cacheVerifyCmd.Flags().BoolVarP(&cacheVerifyDryRun, "dry-run", "n", false,
+		"Only report the problems, without repairing them")
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cacheWarmCount int
+)
+
+func runCacheWarm(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	progress := func(done, total int) {
+		_, _ = fmt.Fprintf(os.Stderr, "\rCompiling bugs... %d/%d ", done, total)
+	}
+
+	loaded, err := backend.Warm(cacheWarmCount, progress)
+	if loaded > 0 {
+		_, _ = fmt.Fprintln(os.Stderr)
+	}
+	if err != nil {
+		return err
+	}
+
+	info, err := backend.CacheInfo()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d bugs in the cache, %d bugs compiled\n", info.Bugs, loaded)
+
+	return nil
+}
+
+var cacheWarmCmd = &cobra.Command{
+	Use:   "warm",
+	Short: "Build or update the bug cache, and compile the most recently edited bugs",
+	Long: `Build or update the bug cache, and compile the most recently edited bugs.
+
+This is useful to prepare the cache ahead of time, for example when building
+a container image or in a CI job, so that the first command or request doesn't
+have to build it. The most recently edited bugs are read and compiled once, to
+check that they are valid and to bring their data in the file system cache.`,
+	PreRunE: loadRepo,
+	RunE:    runCacheWarm,
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheWarmCmd)
+
+	cacheWarmCmd.Flags().SortFlags = false
+
+	cacheWarmCmd.Flags().IntVarP(&cacheWarmCount, "count", "n", 100,
+		"The number of recently edited bugs to compile",
+	)
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	clonePush bool
+)
+
+func runClone(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return errors.New("You must provide the url of the repository, and optionally a directory")
+	}
+
+	url := args[0]
+	dir := cloneDirectory(url)
+	if len(args) == 2 {
+		dir = args[1]
+	}
+
+	cloned, err := repository.CloneGitRepo(url, dir, os.Stderr, bug.Witnesser)
+	if err != nil {
+		return err
+	}
+
+	err = setupRemote(cloned, "origin", clonePush)
+	if err != nil {
+		return err
+	}
+
+	backend, err := cache.NewRepoCache(cloned)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	_, err = backend.Fetch("origin")
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for merge := range backend.MergeAll("origin") {
+		if merge.Err != nil {
+			return merge.Err
+		}
+		if merge.Warning != nil {
+			fmt.Printf("warning: %s\n", merge.Warning)
+			continue
+		}
+		if merge.Status == bug.MergeStatusNew {
+			count++
+		} else if merge.Status != bug.MergeStatusNothing {
+			fmt.Printf("%s: %s\n", bug.FormatHumanID(merge.Id), merge)
+		}
+	}
+
+	fmt.Printf("%d bug(s) retrieved\n", count)
+
+	return nil
+}
+
+// cloneDirectory return the directory git would clone a repository into
+func cloneDirectory(url string) string {
+	name := strings.TrimRight(url, "/")
+	name = strings.TrimSuffix(name, ".git")
+	name = path.Base(strings.Replace(name, ":", "/", -1))
+	return name
+}
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone <url> [<directory>]",
+	Short: "Clone a repository along with its bugs",
+	Long: `Clone a repository with git, configure its remote so that "git fetch" also
+retrieve the bugs (see "git bug remote setup"), and retrieve the bugs.`,
+	Example: `git bug clone https://github.com/MichaelMure/git-bug.git
+git bug clone --push git@example.com:team/project.git project`,
+	RunE: runClone,
+}
+
+func init() {
+	RootCmd.AddCommand(cloneCmd)
+
+	cloneCmd.Flags().SortFlags = false
+
+	cloneCmd.Flags().BoolVarP(&clonePush, "push", "p", false,
+		"Also configure git push to send the bugs")
+}
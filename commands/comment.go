@@ -13,7 +13,7 @@ import (
 )
 
 func runComment(cmd *cobra.Command, args []string) error {
-	backend, err := cache.NewRepoCache(repo)
+	backend, err := cache.NewRepoCacheReadOnly(repo)
 	if err != nil {
 		return err
 	}
@@ -27,19 +27,20 @@ func runComment(cmd *cobra.Command, args []string) error {
 
 	snap := b.Snapshot()
 
-	commentsTextOutput(snap.Comments)
+	commentsTextOutput(snap.CommentItems())
 
 	return nil
 }
 
-func commentsTextOutput(comments []bug.Comment) {
+func commentsTextOutput(comments []*bug.CommentTimelineItem) {
 	for i, comment := range comments {
 		if i != 0 {
 			fmt.Println()
 		}
 
-		fmt.Printf("Author: %s\n", colors.Magenta(comment.Author))
-		fmt.Printf("Date: %s\n\n", comment.FormatTime())
+		fmt.Printf("Comment: %s %s\n", colors.Cyan(fmt.Sprintf("#%d", i)), comment.Hash().String()[:7])
+		fmt.Printf("Author: %s\n", colors.Magenta(comment.Author.DisplayName()))
+		fmt.Printf("Date: %s\n\n", comment.CreatedAt.Time().Format("Mon Jan 2 15:04:05 2006 -0700"))
 		fmt.Println(text.LeftPad(comment.Message, 4))
 	}
 }
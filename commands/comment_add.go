@@ -13,6 +13,7 @@ import (
 var (
 	commentAddMessageFile string
 	commentAddMessage     string
+	commentAddAttachments []string
 )
 
 func runCommentAdd(cmd *cobra.Command, args []string) error {
@@ -28,6 +29,11 @@ func runCommentAdd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	files, references, err := storeAttachments(backend, commentAddAttachments)
+	if err != nil {
+		return err
+	}
+
 	if commentAddMessageFile != "" && commentAddMessage == "" {
 		commentAddMessage, err = input.BugCommentFileInput(commentAddMessageFile)
 		if err != nil {
@@ -46,12 +52,12 @@ func runCommentAdd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	err = b.AddComment(commentAddMessage)
+	err = b.AddCommentWithFiles(withReferences(commentAddMessage, references), files)
 	if err != nil {
 		return err
 	}
 
-	return b.Commit()
+	return commitAndEcho(b)
 }
 
 var commentAddCmd = &cobra.Command{
@@ -73,4 +79,8 @@ func init() {
 	commentAddCmd.Flags().StringVarP(&commentAddMessage, "message", "m", "",
 		"Provide the new message from the command line",
 	)
+
+	commentAddCmd.Flags().StringArrayVarP(&commentAddAttachments, "attach", "", nil,
+		"Attach a file to the comment, referenced at the end of the message. Can be repeated",
+	)
 }
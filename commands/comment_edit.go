@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/input"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	commentEditMessageFile string
+	commentEditMessage     string
+)
+
+func runCommentEdit(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return errors.New("You must provide a comment")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	// with a single argument, it's the comment of the selected bug: it must
+	// not be mistaken for a bug prefix
+	bugArgs := args[:len(args)-1]
+	b, _, err := _select.ResolveBug(backend, bugArgs)
+	if err != nil {
+		return err
+	}
+
+	comment, index, err := b.Snapshot().SearchComment(args[len(args)-1])
+	if err != nil {
+		return err
+	}
+
+	if commentEditMessageFile != "" && commentEditMessage == "" {
+		commentEditMessage, err = input.BugCommentFileInput(commentEditMessageFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	if commentEditMessageFile == "" && commentEditMessage == "" {
+		commentEditMessage, err = input.BugCommentEditorInput(backend, comment.Message)
+		if err == input.ErrEmptyMessage {
+			fmt.Println("Empty message, aborting.")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if commentEditMessage == comment.Message {
+		fmt.Println("No changes found, aborting.")
+		return nil
+	}
+
+	err = b.EditComment(comment.Hash(), commentEditMessage)
+	if err != nil {
+		return err
+	}
+
+	err = commitAndEcho(b)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Comment #%d edited\n", index)
+
+	return nil
+}
+
+var commentEditCmd = &cobra.Command{
+	Use:   "edit [<id>] <comment>",
+	Short: "Edit a comment",
+	Long: `Edit a comment, in the editor pre-filled with the current message unless
+the new one is given.
+
+The comment is given by its index, like 0 for the description of the bug or
+#2 for the second comment, or by a prefix of its hash, of at least 4
+characters, as shown by "git bug comment".`,
+	PreRunE: loadRepo,
+	RunE:    runCommentEdit,
+}
+
+func init() {
+	commentCmd.AddCommand(commentEditCmd)
+
+	commentEditCmd.Flags().SortFlags = false
+
+	commentEditCmd.Flags().StringVarP(&commentEditMessageFile, "file", "F", "",
+		"Take the message from the given file. Use - to read the message from the standard input",
+	)
+
+	commentEditCmd.Flags().StringVarP(&commentEditMessage, "message", "m", "",
+		"Provide the new message from the command line",
+	)
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/misc/editor"
+	"github.com/spf13/cobra"
+)
+
+var (
+	completionEditor string
+)
+
+func runCompletion(cmd *cobra.Command, args []string) error {
+	if completionEditor != "" {
+		if len(args) > 0 {
+			return errors.New("A shell can't be given along with --editor")
+		}
+		return editor.Generate(os.Stdout, completionEditor, RootCmd)
+	}
+
+	if len(args) == 0 {
+		return errors.New("You must provide a shell or an editor")
+	}
+
+	switch args[0] {
+	case "bash":
+		return RootCmd.GenBashCompletion(os.Stdout)
+	case "zsh":
+		return RootCmd.GenZshCompletion(os.Stdout)
+	default:
+		return fmt.Errorf("unsupported shell %s, valid values are [bash,zsh]", args[0])
+	}
+}
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [<shell>]",
+	Short: "Generate the completion for a shell or the integration for an editor",
+	Long: `Generate the completion script for a shell, or the integration for an editor.
+
+The editor integration highlights the bug edit buffer, shows the bug referenced
+by a commit trailer such as "Fixes: #12" and runs the git-bug commands with
+completion. It is generated from the installed version of git-bug, so it should
+be generated again after an upgrade.`,
+	Example: `Generate the bash completion:
+git bug completion bash > /etc/bash_completion.d/git-bug
+
+Generate the vim integration:
+git bug completion --editor vim > ~/.vim/plugin/git-bug.vim`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCompletion,
+}
+
+func init() {
+	RootCmd.AddCommand(completionCmd)
+
+	completionCmd.Flags().SortFlags = false
+
+	completionCmd.Flags().StringVarP(&completionEditor, "editor", "e", "",
+		fmt.Sprintf("Generate the integration for an editor. Valid values are [%s]",
+			strings.Join(editor.Editors(), ",")))
+}
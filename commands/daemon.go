@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bridge"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/backoff"
+	"github.com/MichaelMure/git-bug/util/systemd"
+	"github.com/spf13/cobra"
+)
+
+// minSyncInterval is the shortest interval accepted, to not hammer the
+// remotes and the bridged services
+const minSyncInterval = time.Minute
+
+// maxSyncBackoff is the longest delay between two synchronisations after
+// consecutive failures
+const maxSyncBackoff = 6 * time.Hour
+
+// syncJitter is the fraction of the interval randomly added or removed
+const syncJitter = 0.1
+
+var (
+	daemonSyncInterval time.Duration
+	daemonRemotes      []string
+	daemonNoBridges    bool
+)
+
+func runDaemon(cmd *cobra.Command, args []string) error {
+	if daemonSyncInterval < minSyncInterval {
+		return fmt.Errorf("the sync interval can't be shorter than %s", minSyncInterval)
+	}
+
+	remotes := daemonRemotes
+	if len(remotes) == 0 {
+		var err error
+		remotes, err = configuredRemotes()
+		if err != nil {
+			return err
+		}
+	}
+
+	var bridges []string
+	if !daemonNoBridges {
+		var err error
+		bridges, err = bridge.ConfiguredBridges(repo)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(remotes) == 0 && len(bridges) == 0 {
+		return errors.New("no remote or bridge to synchronise with")
+	}
+
+	schedule := backoff.New(daemonSyncInterval, maxSyncBackoff, syncJitter)
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+
+	logDaemon("syncing %s every %s", daemonSources(remotes, bridges), daemonSyncInterval)
+
+	err := systemd.Notify("READY=1")
+	if err != nil {
+		logDaemon("%v", err)
+	}
+
+	for {
+		if syncAll(remotes, bridges) {
+			schedule.Success()
+		} else {
+			schedule.Failure()
+		}
+
+		delay := schedule.Next()
+		if schedule.Failures() > 0 {
+			logDaemon("%d consecutive failed sync, next one in %s", schedule.Failures(), delay.Round(time.Second))
+		}
+
+		select {
+		case <-quit:
+			logDaemon("shutting down")
+			_ = systemd.Notify("STOPPING=1")
+			return nil
+		case <-time.After(delay):
+		}
+	}
+}
+
+// syncAll fetch and merge the remotes, then pull the bridges. The cache is
+// only held during the synchronisation, so that the other commands can run
+// in between. It return false if any of them failed.
+func syncAll(remotes []string, bridges []string) bool {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		logDaemon("%v", err)
+		return false
+	}
+	defer backend.Close()
+
+	ok := true
+
+	for _, remote := range remotes {
+		err := syncRemote(backend, remote)
+		if err != nil {
+			logDaemon("remote %s: %v", remote, err)
+			backend.FireSyncFailed(remote, "", err)
+			ok = false
+		}
+	}
+
+	for _, name := range bridges {
+		err := syncBridge(backend, name)
+		if err != nil {
+			logDaemon("bridge %s: %v", name, err)
+			backend.FireSyncFailed("", name, err)
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+func syncRemote(backend *cache.RepoCache, remote string) error {
+	_, err := backend.Fetch(remote)
+	if err != nil {
+		return err
+	}
+
+	var created, updated int
+	var mergeErr error
+
+	// the merged bugs are announced to the subscribers and the merge-applied
+	// hooks by the cache
+	for merge := range backend.MergeAll(remote) {
+		switch {
+		case merge.Err != nil:
+			mergeErr = merge.Err
+		case merge.Warning != nil:
+			logDaemon("remote %s: %s", remote, merge.Warning)
+		case merge.Status == bug.MergeStatusNew:
+			created++
+		case merge.Status == bug.MergeStatusUpdated:
+			updated++
+		}
+	}
+
+	if created > 0 || updated > 0 {
+		logDaemon("remote %s: %d new, %d updated", remote, created, updated)
+	}
+
+	return mergeErr
+}
+
+func syncBridge(backend *cache.RepoCache, name string) error {
+	b, err := bridge.NewBridgeFromFullName(backend, name)
+	if err != nil {
+		return err
+	}
+
+	return b.ImportAll()
+}
+
+// configuredRemotes return the name of the git remotes of the repository
+func configuredRemotes() ([]string, error) {
+	configs, err := repo.ReadConfigs("remote.")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for key := range configs {
+		if !strings.HasSuffix(key, ".url") {
+			continue
+		}
+		result = append(result, strings.TrimSuffix(strings.TrimPrefix(key, "remote."), ".url"))
+	}
+
+	sort.Strings(result)
+
+	return result, nil
+}
+
+func daemonSources(remotes []string, bridges []string) string {
+	var sources []string
+	for _, remote := range remotes {
+		sources = append(sources, "remote "+remote)
+	}
+	for _, name := range bridges {
+		sources = append(sources, "bridge "+name)
+	}
+	return strings.Join(sources, ", ")
+}
+
+func logDaemon(format string, a ...interface{}) {
+	_, _ = fmt.Fprintf(os.Stderr, "%s %s\n", time.Now().Format("2006-01-02 15:04:05"), fmt.Sprintf(format, a...))
+}
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Synchronise the bugs with the remotes and the bridges in the background",
+	Long: `Periodically fetch and merge the bugs from the git remotes, then pull the
+configured bridges, so that the data is fresh without manual pulls.
+
+The synchronisations are spread with some jitter, and spaced out after
+consecutive failures up to a few hours. The repository is only locked during
+a synchronisation, so the other commands can be used while the daemon runs.
+
+The merged bugs trigger the merge-applied hooks, and a failed synchronisation
+triggers the sync-failed hook, to be notified of them.`,
+	PreRunE: loadRepo,
+	RunE:    runDaemon,
+}
+
+func init() {
+	RootCmd.AddCommand(daemonCmd)
+
+	daemonCmd.Flags().SortFlags = false
+
+	daemonCmd.Flags().DurationVar(&daemonSyncInterval, "sync-interval", 10*time.Minute,
+		"Interval between two synchronisations")
+	daemonCmd.Flags().StringSliceVarP(&daemonRemotes, "remote", "r", nil,
+		"Git remote to synchronise with, instead of all of them")
+	daemonCmd.Flags().BoolVar(&daemonNoBridges, "no-bridges", false,
+		"Don't pull the bridges")
+}
@@ -0,0 +1,14 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var debugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Tools to understand and test the inner workings of git-bug",
+}
+
+func init() {
+	RootCmd.AddCommand(debugCmd)
+}
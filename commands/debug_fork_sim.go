@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/misc/forksim"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	forkSimLeft  []string
+	forkSimRight []string
+	forkSimDir   string
+)
+
+func runDebugForkSim(cmd *cobra.Command, args []string) error {
+	if len(forkSimLeft) == 0 && len(forkSimRight) == 0 {
+		return errors.New("You must provide the operations of at least one side")
+	}
+
+	left, err := parseForkSimSteps(forkSimLeft)
+	if err != nil {
+		return err
+	}
+
+	right, err := parseForkSimSteps(forkSimRight)
+	if err != nil {
+		return err
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, _, err := _select.ResolveBug(backend, args)
+	if err != nil {
+		return err
+	}
+
+	sim, err := forksim.New(repo, b.Id(), forkSimDir)
+	if err != nil {
+		return err
+	}
+	defer sim.Close()
+
+	report, err := sim.Run(left, right)
+	if err != nil {
+		return err
+	}
+
+	forkSimTextOutput(sim, report)
+
+	return nil
+}
+
+func parseForkSimSteps(raw []string) ([]forksim.Step, error) {
+	steps := make([]forksim.Step, len(raw))
+
+	for i, s := range raw {
+		step, err := forksim.ParseStep(s)
+		if err != nil {
+			return nil, err
+		}
+		steps[i] = step
+	}
+
+	return steps, nil
+}
+
+func forkSimTextOutput(sim *forksim.Simulation, report *forksim.Report) {
+	printSide := func(name string, steps []forksim.Step) {
+		fmt.Printf("%s applied %d operation(s)\n", colors.Bold(name), len(steps))
+		for _, step := range steps {
+			fmt.Printf("    %s\n", step)
+		}
+	}
+
+	printSide("left", report.LeftSteps)
+	printSide("right", report.RightSteps)
+	fmt.Println()
+
+	fmt.Printf("left pushed, right pulled: %s\n", report.RightMerge)
+	fmt.Printf("right pushed, left pulled: %s\n", report.LeftMerge)
+
+	if report.Converged {
+		fmt.Printf("outcome: %s\n", colors.Green("converged"))
+	} else {
+		fmt.Printf("outcome: %s\n", colors.Red("diverged"))
+	}
+	fmt.Println()
+
+	snap := report.Final.Compile()
+
+	var labels []string
+	for _, l := range snap.Labels {
+		labels = append(labels, l.String())
+	}
+
+	fmt.Printf("%s %s\n", colors.Yellow("["+snap.Status.String()+"]"), colors.Bold(snap.Title))
+	fmt.Printf("labels: %s\n", strings.Join(labels, ", "))
+	fmt.Printf("comments: %d\n", len(snap.Comments))
+	fmt.Println()
+
+	fmt.Println("merged history:")
+	for _, entry := range bug.History(report.Final) {
+		for _, change := range entry.Diff.Changes() {
+			fmt.Printf("    %s %s\n", colors.Magenta(entry.Author.DisplayName()), change)
+		}
+	}
+
+	if forkSimDir != "" {
+		fmt.Println()
+		fmt.Printf("The sandboxes are kept in %s\n", sim.Dir)
+	}
+}
+
+var debugForkSimCmd = &cobra.Command{
+	Use:   "fork-sim [<id>]",
+	Short: "Simulate concurrent edits of a bug in two clones and show the merge",
+	Long: fmt.Sprintf(`Simulate concurrent edits of a bug in two clones and show the merge.
+
+A copy of the bug is made in sandboxes: a remote and two clones, left and
+right. Each clone apply its own operations, then left push, right pull and push,
+and left pull. The outcome of each merge and the final state of the bug are
+reported. The repository itself is not modified.
+
+An operation is given in the form "kind:value", where kind is one of:
+%s
+
+With --dir, the sandboxes are kept in the given directory, for example to be
+used as a fixture in a test.`, "\t"+strings.Join(forksim.StepKinds, "\n\t")),
+	Example: `git bug debug fork-sim --left "title:Crash on start" --right "status:closed" --right "comment:Not reproducible"`,
+	PreRunE: loadRepo,
+	RunE:    runDebugForkSim,
+}
+
+func init() {
+	debugCmd.AddCommand(debugForkSimCmd)
+
+	debugForkSimCmd.Flags().SortFlags = false
+
+	debugForkSimCmd.Flags().StringArrayVarP(&forkSimLeft, "left", "l", nil,
+		"An operation to apply in the left clone, can be repeated",
+	)
+	debugForkSimCmd.Flags().StringArrayVarP(&forkSimRight, "right", "r", nil,
+		"An operation to apply in the right clone, can be repeated",
+	)
+	debugForkSimCmd.Flags().StringVarP(&forkSimDir, "dir", "d", "",
+		"Keep the sandboxes in this directory",
+	)
+}
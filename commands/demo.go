@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/misc/random_bugs"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	demoWebUI bool
+	demoBugs  int
+	demoSeed  int64
+	demoKeep  bool
+)
+
+func runDemo(cmd *cobra.Command, args []string) error {
+	if demoBugs <= 0 {
+		return fmt.Errorf("the number of bugs must be positive")
+	}
+
+	dir, err := ioutil.TempDir("", "git-bug-demo")
+	if err != nil {
+		return err
+	}
+
+	cleanup := func() error {
+		if demoKeep {
+			fmt.Printf("The demo repository is kept in %s\n", dir)
+			return nil
+		}
+		return os.RemoveAll(dir)
+	}
+	defer cleanup()
+	// registered first, so that it runs after the cache is closed
+	interrupt.RegisterCleaner(cleanup)
+
+	demoRepo, err := repository.InitGitRepo(dir)
+	if err != nil {
+		return err
+	}
+
+	err = demoRepo.StoreConfig("user.name", "Demo User")
+	if err != nil {
+		return err
+	}
+	err = demoRepo.StoreConfig("user.email", "demo@example.com")
+	if err != nil {
+		return err
+	}
+
+	seed := demoSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	fmt.Printf("Generating %d bugs in %s (seed %d) ...\n", demoBugs, dir, seed)
+
+	opts := random_bugs.DefaultOptions()
+	opts.BugNumber = demoBugs
+	random_bugs.CommitRandomBugsWithSeed(demoRepo, opts, seed)
+
+	// the repository is loaded again to witness the clocks of the new bugs
+	repo, err = repository.NewGitRepo(dir, bug.Witnesser)
+	if err != nil {
+		return err
+	}
+
+	if demoWebUI {
+		return runWebUI(cmd, nil)
+	}
+
+	return runTermUI(cmd, nil)
+}
+
+var demoCmd = &cobra.Command{
+	Use:   "demo",
+	Short: "Explore git-bug in a throwaway repository filled with random bugs",
+	Long: `Explore git-bug in a throwaway repository filled with random bugs.
+
+A temporary repository is created and filled with generated bugs, then the terminal UI or the web UI is launched on it. The repository is removed on exit, your own repositories are never touched.`,
+	Args: cobra.NoArgs,
+	RunE: runDemo,
+}
+
+func init() {
+	RootCmd.AddCommand(demoCmd)
+
+	demoCmd.Flags().SortFlags = false
+
+	demoCmd.Flags().BoolVarP(&demoWebUI, "webui", "w", false,
+		"Launch the web UI instead of the terminal UI",
+	)
+	demoCmd.Flags().IntVarP(&demoBugs, "bugs", "n", random_bugs.DefaultOptions().BugNumber,
+		"The number of bugs to generate",
+	)
+	demoCmd.Flags().Int64VarP(&demoSeed, "seed", "s", 0,
+		"The seed of the random generator, to generate the same bugs again (default random)",
+	)
+	demoCmd.Flags().BoolVarP(&demoKeep, "keep", "k", false,
+		"Keep the repository on exit",
+	)
+}
@@ -1,12 +1,19 @@
 package commands
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/commands/select"
 	"github.com/MichaelMure/git-bug/util/interrupt"
 	"github.com/spf13/cobra"
 )
 
+var (
+	deselectAll bool
+)
+
 func runDeselect(cmd *cobra.Command, args []string) error {
 	backend, err := cache.NewRepoCache(repo)
 	if err != nil {
@@ -15,17 +22,38 @@ func runDeselect(cmd *cobra.Command, args []string) error {
 	defer backend.Close()
 	interrupt.RegisterCleaner(backend.Close)
 
-	err = _select.Clear(backend)
+	if deselectAll {
+		err = _select.ClearHistory(backend)
+		if err != nil {
+			return err
+		}
+
+		err = _select.Clear(backend)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	b, err := _select.Deselect(backend)
 	if err != nil {
 		return err
 	}
 
+	if b != nil {
+		fmt.Printf("selected back bug %s: %s\n", b.HumanId(), b.Snapshot().Title)
+	}
+
 	return nil
 }
 
 var deselectCmd = &cobra.Command{
 	Use:   "deselect",
 	Short: "Clear the implicitly selected bug",
+	Long: `Clear the implicitly selected bug, and select back the bug that was selected before it, if any.
+
+With --all, the previously selected bugs are forgotten as well.`,
 	Example: `git bug select 2f15
 git bug comment
 git bug status
@@ -38,4 +66,8 @@ git bug deselect
 func init() {
 	RootCmd.AddCommand(deselectCmd)
 	deselectCmd.Flags().SortFlags = false
+
+	deselectCmd.Flags().BoolVarP(&deselectAll, "all", "a", false,
+		"Clear the selection and forget the previously selected bugs",
+	)
 }
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/input"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var (
+	doctorFix bool
+)
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	diagnostics, err := cache.Diagnose(repo)
+	if err != nil {
+		return err
+	}
+
+	if len(diagnostics) == 0 {
+		fmt.Println("No problem found")
+		return nil
+	}
+
+	// only ask to fix the problems when someone can answer
+	interactive := terminal.IsTerminal(int(os.Stdin.Fd()))
+	remaining := 0
+
+	for i, diagnostic := range diagnostics {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		fmt.Printf("%s %s\n", colors.Yellow("["+diagnostic.Check+"]"), diagnostic.Problem)
+
+		if diagnostic.Fix == nil {
+			fmt.Printf("  %s\n", diagnostic.Advice)
+			remaining++
+			continue
+		}
+
+		fix := doctorFix
+		if !fix && interactive {
+			fix, err = input.PromptYesNo(fmt.Sprintf("  Fix: %s?", diagnostic.FixDescription))
+			if err == io.EOF {
+				fix = false
+				interactive = false
+			} else if err != nil {
+				return err
+			}
+		}
+
+		if !fix {
+			if diagnostic.Advice != "" {
+				fmt.Printf("  %s\n", diagnostic.Advice)
+			} else {
+				fmt.Printf("  can be fixed: %s\n", diagnostic.FixDescription)
+			}
+			remaining++
+			continue
+		}
+
+		err = diagnostic.Fix()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  %s %s\n", colors.Green("fixed:"), diagnostic.FixDescription)
+	}
+
+	if remaining > 0 {
+		fmt.Printf("\n%d problem(s) remaining\n", remaining)
+	}
+
+	return nil
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the repository for common misconfigurations",
+	Long: `Check the repository and its configuration for common problems: missing
+identity, remotes not fetching the bugs, lock left after a crash, outdated
+cache, git too old, and filesystems where the lock doesn't work.
+
+For each problem that can be fixed automatically, you are asked whether to
+fix it. With --fix, all of them are fixed without asking.
+
+The problems most likely to cause errors are also reported as warnings when
+running the other commands.`,
+	Example: `git bug doctor
+git bug doctor --fix`,
+	PreRunE: loadRepoSkipChecks,
+	RunE:    runDoctor,
+}
+
+func init() {
+	RootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().SortFlags = false
+
+	doctorCmd.Flags().BoolVarP(&doctorFix, "fix", "f", false,
+		"Fix all the problems that can be, without asking")
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/input"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	editTitle       string
+	editMessage     string
+	editMessageFile string
+)
+
+func runEdit(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, args, err := _select.ResolveBug(backend, args)
+	if err != nil {
+		return err
+	}
+
+	snap := b.Snapshot()
+
+	items := snap.CommentItems()
+	if len(items) == 0 {
+		return errors.New("the bug has no description")
+	}
+	description := items[0]
+
+	title, message := snap.Title, description.Message
+
+	switch {
+	case editMessageFile != "":
+		title, message, err = input.BugCreateFileInput(editMessageFile)
+		if err != nil {
+			return err
+		}
+
+	case editTitle == "" && editMessage == "":
+		title, message, err = input.BugCreateEditorInput(backend, title, message)
+		if err == input.ErrEmptyTitle {
+			fmt.Println("Empty title, aborting.")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+	default:
+		if editTitle != "" {
+			title = editTitle
+		}
+		if editMessage != "" {
+			message = editMessage
+		}
+	}
+
+	if title == snap.Title && message == description.Message {
+		fmt.Println("No change, aborting.")
+		return nil
+	}
+
+	if title != snap.Title {
+		err = b.SetTitle(title)
+		if err != nil {
+			return err
+		}
+	}
+
+	if message != description.Message {
+		err = b.EditComment(description.Hash(), message)
+		if err != nil {
+			return err
+		}
+	}
+
+	return commitAndEcho(b)
+}
+
+var editCmd = &cobra.Command{
+	Use:   "edit [<id>]",
+	Short: "Edit the title and the description of a bug",
+	Long: `Edit the title and the description of a bug, that is its first comment.
+
+Without flag, an editor is opened with the current title and description,
+like when creating a bug. With --title or --message, only the given one is
+changed. The previous versions are kept in the history of the bug.`,
+	Example: `git bug edit 2f15
+git bug edit 2f15 --message "Steps to reproduce: ..."
+git bug edit 2f15 --file description.txt`,
+	PreRunE: loadRepo,
+	RunE:    runEdit,
+}
+
+func init() {
+	RootCmd.AddCommand(editCmd)
+
+	editCmd.Flags().SortFlags = false
+
+	editCmd.Flags().StringVarP(&editTitle, "title", "t", "",
+		"Provide the new title",
+	)
+	editCmd.Flags().StringVarP(&editMessage, "message", "m", "",
+		"Provide the new description",
+	)
+	editCmd.Flags().StringVarP(&editMessageFile, "file", "F", "",
+		"Take the title and the description from the given file, the first line being the title. Use - to read them from the standard input",
+	)
+}
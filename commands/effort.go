@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	effortSince  string
+	effortUntil  string
+	effortFormat string
+)
+
+func runEffort(cmd *cobra.Command, args []string) error {
+	switch effortFormat {
+	case "default", "json":
+	default:
+		return fmt.Errorf("unknown format %s", effortFormat)
+	}
+
+	since, err := cache.ParseDate(effortSince)
+	if err != nil {
+		return err
+	}
+
+	var until time.Time
+	if effortUntil != "" {
+		until, err = cache.ParseDate(effortUntil)
+		if err != nil {
+			return err
+		}
+	}
+
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	report, err := backend.Effort(since, until)
+	if err != nil {
+		return err
+	}
+
+	if effortFormat == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	fmt.Printf("From %s to %s\n\n",
+		report.Since.Format("2006-01-02"),
+		report.Until.Format("2006-01-02"),
+	)
+
+	printEffortWeeks(report.Weeks)
+
+	fmt.Println()
+
+	printContributors(report.Contributions)
+
+	return nil
+}
+
+// printEffortWeeks print a row per week, with the bugs opened as "+" and the
+// bugs closed as "-"
+func printEffortWeeks(weeks []cache.EffortWeek) {
+	fmt.Printf("%-10s %6s %6s %6s\n", "week", "opened", "closed", "open")
+
+	for _, week := range weeks {
+		fmt.Printf("%-10s %6d %6d %6d %s%s\n",
+			week.Start.Format("2006-01-02"),
+			week.Opened,
+			week.Closed,
+			week.Open,
+			colors.Red(strings.Repeat("+", week.Opened)),
+			colors.Green(strings.Repeat("-", week.Closed)),
+		)
+	}
+}
+
+var effortCmd = &cobra.Command{
+	Use:   "effort",
+	Short: "Report the bugs opened and closed by week, and the activity of each person",
+	Long: `Report the activity on the bugs during a period: for each week, the bugs opened and closed and the bugs still open at its end, then the activity of each person, like "git bug report contributors".
+
+The period is given with --since and --until, as a day (2018-09-01), a time
+(2018-09-01T15:04:05Z) or a duration before now (12h, 30d, 2w). It covers the
+last 4 weeks by default. The weeks start on monday.`,
+	Example: `git bug effort
+git bug effort --since 2018-09-01 --until 2018-10-01
+git bug effort --since 1w --format json`,
+	PreRunE: loadRepo,
+	RunE:    runEffort,
+}
+
+func init() {
+	RootCmd.AddCommand(effortCmd)
+
+	effortCmd.Flags().SortFlags = false
+
+	effortCmd.Flags().StringVarP(&effortSince, "since", "s", "4w",
+		"Start of the period, included")
+	effortCmd.Flags().StringVarP(&effortUntil, "until", "u", "",
+		"End of the period, excluded, now by default")
+	effortCmd.Flags().StringVarP(&effortFormat, "format", "f", "default",
+		"Select the output format. Valid values are [default,json]")
+}
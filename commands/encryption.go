@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/spf13/cobra"
+)
+
+func runEncryption(cmd *cobra.Command, args []string) error {
+	key, err := bug.ReadEncryptionKey(repo)
+	if err != nil {
+		return err
+	}
+
+	if key == nil {
+		fmt.Println("encryption: disabled")
+	} else {
+		fmt.Printf("encryption: %s\n", colors.Green("enabled"))
+	}
+
+	return nil
+}
+
+var encryptionCmd = &cobra.Command{
+	Use:   "encryption",
+	Short: "Display or configure the encryption of the bugs",
+	Long: `Display or configure the encryption of the bugs.
+
+Once enabled, the content of the bugs and their attached files are encrypted
+with a key of the repository before being stored in git, so that they remain
+confidential when pushed to a remote that is not fully trusted. The key is kept in the local git
+configuration and never pushed: it must be shared out-of-band with the people
+who need to read the bugs.
+
+The changes and files made before enabling the encryption stay readable by
+everyone. The bug ids, the lamport clocks and the cache of the local
+repository are not encrypted.`,
+	PreRunE: loadRepo,
+	RunE:    runEncryption,
+}
+
+func init() {
+	RootCmd.AddCommand(encryptionCmd)
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/spf13/cobra"
+)
+
+var (
+	encryptionEnableForce bool
+)
+
+func runEncryptionEnable(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return errors.New("Only one key can be given")
+	}
+
+	current, err := bug.ReadEncryptionKey(repo)
+	if err != nil {
+		return err
+	}
+
+	// the bugs encrypted with the previous key would not be readable anymore
+	if current != nil && !encryptionEnableForce {
+		return errors.New("the encryption is already enabled, use --force to replace the key")
+	}
+
+	if len(args) == 1 {
+		return bug.SetEncryptionKey(repo, args[0])
+	}
+
+	key, err := bug.GenerateEncryptionKey()
+	if err != nil {
+		return err
+	}
+
+	err = bug.SetEncryptionKey(repo, key)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Share this key out-of-band with the people who need to read the bugs:")
+	fmt.Println()
+	fmt.Printf("\t%s\n", key)
+	fmt.Println()
+	fmt.Println("They can then use it with:")
+	fmt.Println()
+	fmt.Printf("\tgit bug encryption enable %s\n", key)
+
+	return nil
+}
+
+var encryptionEnableCmd = &cobra.Command{
+	Use:     "enable [<key>]",
+	Short:   "Encrypt the bugs with a new key, or with the given key of the repository",
+	PreRunE: loadRepo,
+	RunE:    runEncryptionEnable,
+}
+
+func init() {
+	encryptionCmd.AddCommand(encryptionEnableCmd)
+
+	encryptionEnableCmd.Flags().SortFlags = false
+
+	encryptionEnableCmd.Flags().BoolVarP(&encryptionEnableForce, "force", "f", false,
+		"Replace the current key, making unreadable the bugs encrypted with it")
+}
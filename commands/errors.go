@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/pkg/errors"
+)
+
+// The exit codes, for the scripts to react to the errors without parsing
+// the messages. They are part of the interface of git-bug: an existing code
+// must not change.
+const (
+	exitError      = 1
+	exitUsage      = 2
+	exitNotFound   = 3
+	exitAmbiguous  = 4
+	exitLocked     = 5
+	exitInvalid    = 6
+	exitNotAllowed = 7
+)
+
+// errorClasses are the names of the exit codes in the porcelain output
+var errorClasses = map[int]string{
+	exitError:      "error",
+	exitUsage:      "usage",
+	exitNotFound:   "not-found",
+	exitAmbiguous:  "ambiguous",
+	exitLocked:     "locked",
+	exitInvalid:    "invalid",
+	exitNotAllowed: "not-allowed",
+}
+
+// usageError is an error in the command line, like an unknown flag
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string {
+	return e.err.Error()
+}
+
+// exitCode return the exit code matching the kind of an error
+func exitCode(err error) int {
+	switch err := errors.Cause(err).(type) {
+	case usageError:
+		return exitUsage
+	case bug.ErrMultipleMatch, cache.ErrMultipleMatchIdentity, cache.ErrMultipleMatchOp:
+		return exitAmbiguous
+	case cache.ErrLocked:
+		return exitLocked
+	case bug.ErrInvalidField, cache.ErrQuerySyntax:
+		return exitInvalid
+	case cache.ErrNotAllowed:
+		return exitNotAllowed
+	default:
+		switch err {
+		case _select.ErrNoValidId:
+			return exitUsage
+		case bug.ErrBugNotExist, cache.ErrIdentityNotExist, cache.ErrNoMatchingOp:
+			return exitNotFound
+		case cache.ErrReadOnly:
+			return exitNotAllowed
+		}
+	}
+
+	// cobra doesn't give a way to recognize this one
+	if strings.HasPrefix(err.Error(), "unknown command ") {
+		return exitUsage
+	}
+
+	return exitError
+}
+
+// porcelainError is the error printed with --porcelain
+type porcelainError struct {
+	Error   string `json:"error"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// printPorcelainError print an error as a single line of JSON on the
+// standard error
+func printPorcelainError(err error, code int) {
+	data, marshalErr := json.Marshal(porcelainError{
+		Error:   errorClasses[code],
+		Code:    code,
+		Message: err.Error(),
+	})
+	if marshalErr != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, string(data))
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runEstimate(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, args, err := _select.ResolveBug(backend, args)
+	if err != nil {
+		return err
+	}
+
+	snap := b.Snapshot()
+
+	fmt.Println(snap.Estimate)
+
+	return nil
+}
+
+var estimateCmd = &cobra.Command{
+	Use:     "estimate [<id>]",
+	Short:   "Display or change the estimated effort of a bug",
+	PreRunE: loadRepo,
+	RunE:    runEstimate,
+}
+
+func init() {
+	RootCmd.AddCommand(estimateCmd)
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runEstimateSet(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, args, err := _select.ResolveBug(backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return errors.New("You must provide an estimate")
+	}
+
+	estimate, err := strconv.ParseFloat(args[0], 64)
+	if err != nil {
+		return err
+	}
+
+	err = b.SetEstimate(estimate)
+	if err != nil {
+		return err
+	}
+
+	return commitAndEcho(b)
+}
+
+var estimateSetCmd = &cobra.Command{
+	Use:   "set [<id>] <estimate>",
+	Short: "Set the estimated effort of a bug",
+	Long: `Set the estimated effort needed to resolve a bug.
+
+The unit is up to you (story points, hours, days ...) but should be consistent
+across the bugs of a milestone to compute meaningful reports.`,
+	PreRunE: loadRepo,
+	RunE:    runEstimateSet,
+}
+
+func init() {
+	estimateCmd.AddCommand(estimateSetCmd)
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportOutput string
+)
+
+func runExport(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	var query *cache.Query
+	if len(args) >= 1 {
+		query, err = backend.ParseQuery(strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+	}
+
+	ids := backend.QueryBugs(query)
+
+	bundle, err := backend.ExportBundle(ids)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+
+	if exportOutput != "" && exportOutput != "-" {
+		f, err := os.Create(exportOutput)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(bundle)
+	if err != nil {
+		return err
+	}
+
+	if out != os.Stdout {
+		fmt.Printf("%d bug(s) exported to %s\n", len(ids), exportOutput)
+	}
+
+	return nil
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export [<query>]",
+	Short: "Export bugs to a portable JSON bundle",
+	Long: `Export the bugs matching a query, or all of them, to a portable JSON bundle
+that "git bug import" can read in another repository.
+
+The bundle holds the operations of the bugs as stored in git, along with the
+files they reference, and is meant for backups, migrations, and exchanging
+bugs with other tools without the git transport. Its format is documented in
+doc/bundle.md.
+
+The bundle is never encrypted, even when the repository is, apart from the
+attached files: they are kept as stored, for their hashes to stay valid.`,
+	Example: `git bug export -o bugs.json
+git bug export label:security --output security.json`,
+	PreRunE: loadRepo,
+	RunE:    runExport,
+}
+
+func init() {
+	RootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().SortFlags = false
+
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "",
+		"Write the bundle to the given file instead of the standard output")
+}
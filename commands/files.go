@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	filesExport string
+	filesJson   bool
+)
+
+func runFiles(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, args, err := _select.ResolveBug(backend, args)
+	if err != nil {
+		return err
+	}
+
+	attachments := b.Attachments()
+
+	if filesExport != "" {
+		return exportAttachments(backend, attachments, filesExport)
+	}
+
+	if filesJson {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(attachments)
+	}
+
+	if len(attachments) == 0 {
+		fmt.Println("No file attached")
+		return nil
+	}
+
+	for _, attachment := range attachments {
+		fmt.Printf("%s %s\t%s\n",
+			colors.Cyan(attachment.Hash.String()[:7]),
+			colors.Yellow(fmt.Sprintf("#%d", attachment.Comment)),
+			attachment.Name,
+		)
+	}
+
+	return nil
+}
+
+// exportAttachments write the attached files in a directory, named as in the
+// messages, or after their hash when they are not referenced
+func exportAttachments(backend *cache.RepoCache, attachments []cache.Attachment, dir string) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	used := make(map[string]bool)
+
+	for _, attachment := range attachments {
+		name := filepath.Base(attachment.Name)
+		if name == "." || name == "/" || name == "" {
+			name = attachment.Hash.String()
+		}
+
+		// the same name can be attached several times
+		if used[name] {
+			name = fmt.Sprintf("%s-%s", attachment.Hash.String()[:7], name)
+		}
+		used[name] = true
+
+		data, err := backend.ReadAttachment(attachment.Hash)
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(dir, name)
+
+		err = ioutil.WriteFile(path, data, 0644)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(path)
+	}
+
+	return nil
+}
+
+// storeAttachments store the given files in the repository, and return their
+// hashes and the references to add to the message
+func storeAttachments(backend *cache.RepoCache, paths []string) ([]git.Hash, []string, error) {
+	var hashes []git.Hash
+	var references []string
+
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		hash, reference, err := backend.StoreAttachment(path, data)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		hashes = append(hashes, hash)
+		references = append(references, reference)
+	}
+
+	return hashes, references, nil
+}
+
+// withReferences add the references to the attached files at the end of a
+// message
+func withReferences(message string, references []string) string {
+	if len(references) == 0 {
+		return message
+	}
+	if message == "" {
+		return strings.Join(references, "\n")
+	}
+	return message + "\n\n" + strings.Join(references, "\n")
+}
+
+var filesCmd = &cobra.Command{
+	Use:   "files [<id>]",
+	Short: "List or export the files attached to a bug",
+	Long: `List the files attached to a bug, with the comment they are attached to and their name, or write them in a directory with --export.
+
+Files are attached with the --attach flag of "git bug add" and "git bug comment add", or uploaded with the web UI.`,
+	Example: `git bug files 2f15
+git bug files 2f15 --export /tmp/2f15`,
+	PreRunE: loadRepo,
+	RunE:    runFiles,
+}
+
+func init() {
+	RootCmd.AddCommand(filesCmd)
+
+	filesCmd.Flags().SortFlags = false
+
+	filesCmd.Flags().StringVarP(&filesExport, "export", "e", "",
+		"Write the attached files in this directory")
+	filesCmd.Flags().BoolVarP(&filesJson, "json", "j", false,
+		"List the attached files as JSON")
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runFixedIn(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, args, err := _select.ResolveBug(backend, args)
+	if err != nil {
+		return err
+	}
+
+	snap := b.Snapshot()
+
+	for _, fix := range snap.FixedIn {
+		fmt.Println(fix)
+	}
+
+	return nil
+}
+
+var fixedInCmd = &cobra.Command{
+	Use:   "fixed-in [<id>]",
+	Short: "Display or add the releases and commits fixing a bug",
+	Long: `Display or add the releases and commits fixing a bug.
+
+A bug can be linked to the release (usually a git tag) and to the commit or the
+commit range fixing it, to answer "which release fixed this?". The bugs fixed
+in a release can then be listed with:
+
+	git bug ls fixed-in:v1.2.0
+
+The links can also be recorded automatically from the "Fixes: <bug>" trailers
+of the commit messages with "git bug fixed-in scan".`,
+	PreRunE: loadRepo,
+	RunE:    runFixedIn,
+}
+
+func init() {
+	RootCmd.AddCommand(fixedInCmd)
+}
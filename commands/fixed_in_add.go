@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fixedInAddRelease string
+	fixedInAddCommit  string
+)
+
+func runFixedInAdd(cmd *cobra.Command, args []string) error {
+	if fixedInAddRelease == "" && fixedInAddCommit == "" {
+		return errors.New("You must provide a release or a commit")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, args, err := _select.ResolveBug(backend, args)
+	if err != nil {
+		return err
+	}
+
+	err = b.AddFixedIn(fixedInAddRelease, fixedInAddCommit)
+	if err != nil {
+		return err
+	}
+
+	return commitAndEcho(b)
+}
+
+var fixedInAddCmd = &cobra.Command{
+	Use:     "add [<id>]",
+	Short:   "Link a bug to the release and the commits fixing it",
+	PreRunE: loadRepo,
+	RunE:    runFixedInAdd,
+}
+
+func init() {
+	fixedInCmd.AddCommand(fixedInAddCmd)
+
+	fixedInAddCmd.Flags().SortFlags = false
+
+	fixedInAddCmd.Flags().StringVarP(&fixedInAddRelease, "release", "r", "",
+		"The release fixing the bug, usually a git tag",
+	)
+	fixedInAddCmd.Flags().StringVarP(&fixedInAddCommit, "commit", "c", "",
+		"The commit hash or the commit range (\"a..b\") fixing the bug",
+	)
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fixedInScanRelease string
+	fixedInScanClose   bool
+)
+
+func runFixedInScan(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("You must provide a revision range")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	fixes, err := backend.ScanFixes(args[0], fixedInScanRelease, fixedInScanClose)
+	if err != nil {
+		return err
+	}
+
+	for _, fix := range fixes {
+		switch {
+		case fix.Closed:
+			fmt.Printf("%s: fixed by %s, closed\n", bug.FormatHumanID(fix.BugId), fix.Commit.String()[:7])
+		case fix.Recorded:
+			fmt.Printf("%s: fixed by %s\n", bug.FormatHumanID(fix.BugId), fix.Commit.String()[:7])
+		default:
+			fmt.Printf("%s: fixed by %s, already known\n", bug.FormatHumanID(fix.BugId), fix.Commit.String()[:7])
+		}
+	}
+
+	return nil
+}
+
+var fixedInScanCmd = &cobra.Command{
+	Use:   "scan <revision-range>",
+	Short: "Link the bugs to the commits referencing them in a trailer",
+	Long: `Link the bugs to the commits referencing them in a trailer.
+
+The commit messages of the revision range are scanned for trailers such as
+"Fixes: <bug>", "Closes: <bug>" or "Resolves: <bug>", where <bug> is a bug id
+prefix of at least 7 characters or a sequential alias. The referenced bugs are
+linked to the commit and, if given, to the release. Scanning the same commits
+again doesn't duplicate the links.`,
+	Example: `Record the bugs fixed in the v1.2.0 release and close them:
+git bug fixed-in scan --release v1.2.0 --close v1.1.0..v1.2.0`,
+	PreRunE: loadRepo,
+	RunE:    runFixedInScan,
+}
+
+func init() {
+	fixedInCmd.AddCommand(fixedInScanCmd)
+
+	fixedInScanCmd.Flags().SortFlags = false
+
+	fixedInScanCmd.Flags().StringVarP(&fixedInScanRelease, "release", "r", "",
+		"The release containing the commits, usually a git tag",
+	)
+	fixedInScanCmd.Flags().BoolVarP(&fixedInScanClose, "close", "c", false,
+		"Close the referenced bugs still open",
+	)
+}
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, args, err := _select.ResolveBug(backend, args)
+	if err != nil {
+		return err
+	}
+
+	comments := timelineComments(b.Snapshot())
+
+	if len(args) > 0 {
+		index, err := findComment(comments, args[0])
+		if err != nil {
+			return err
+		}
+
+		return commentHistoryTextOutput(index, comments[index])
+	}
+
+	edited := false
+	for i, comment := range comments {
+		if !comment.Edited() {
+			continue
+		}
+
+		if edited {
+			fmt.Println()
+		}
+		edited = true
+
+		err := commentHistoryTextOutput(i, comment)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !edited {
+		fmt.Println("No comment has been edited")
+	}
+
+	return nil
+}
+
+// timelineComments return the comments of a bug as timeline items, in the
+// same order as the comments of the snapshot
+func timelineComments(snap *bug.Snapshot) []*bug.CommentTimelineItem {
+	var result []*bug.CommentTimelineItem
+
+	for _, item := range snap.Timeline {
+		switch item := item.(type) {
+		case *bug.CreateTimelineItem:
+			result = append(result, &item.CommentTimelineItem)
+		case *bug.AddCommentTimelineItem:
+			result = append(result, &item.CommentTimelineItem)
+		}
+	}
+
+	return result
+}
+
+// findComment find a comment by its index, as displayed by "git bug show", or
+// by a prefix of its hash
+func findComment(comments []*bug.CommentTimelineItem, ref string) (int, error) {
+	index, err := strconv.Atoi(strings.TrimPrefix(ref, "#"))
+	if err == nil {
+		if index < 0 || index >= len(comments) {
+			return 0, fmt.Errorf("no comment #%d, the bug has %d comments", index, len(comments))
+		}
+		return index, nil
+	}
+
+	found := -1
+	for i, comment := range comments {
+		if strings.HasPrefix(string(comment.Hash()), ref) {
+			if found >= 0 {
+				return 0, fmt.Errorf("multiple comments match the prefix %s", ref)
+			}
+			found = i
+		}
+	}
+
+	if found < 0 {
+		return 0, fmt.Errorf("no comment matching %s", ref)
+	}
+
+	return found, nil
+}
+
+func commentHistoryTextOutput(index int, comment *bug.CommentTimelineItem) error {
+	fmt.Printf("Comment #%d %s by %s\n",
+		index,
+		colors.Cyan(comment.Hash().String()[:7]),
+		colors.Magenta(comment.Author.DisplayName()),
+	)
+
+	for i, step := range comment.History {
+		// the first step doesn't record the author, it's the comment author
+		author := step.Author
+		if i == 0 {
+			author = comment.Author
+		}
+
+		fmt.Println()
+
+		if i == 0 {
+			fmt.Printf("Created by %s on %s\n",
+				colors.Magenta(author.DisplayName()),
+				step.UnixTime.Time().Format("Mon Jan 2 15:04:05 2006 -0700"),
+			)
+			continue
+		}
+
+		fmt.Printf("Edit %d by %s on %s\n",
+			i,
+			colors.Magenta(author.DisplayName()),
+			step.UnixTime.Time().Format("Mon Jan 2 15:04:05 2006 -0700"),
+		)
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(comment.History[i-1].Message),
+			B:        difflib.SplitLines(step.Message),
+			FromFile: fmt.Sprintf("version %d", i-1),
+			ToFile:   fmt.Sprintf("version %d", i),
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+
+		if diff == "" {
+			fmt.Println("    (no change)")
+			continue
+		}
+
+		for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+			switch {
+			case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+				fmt.Printf("    %s\n", line)
+			case strings.HasPrefix(line, "+"):
+				fmt.Printf("    %s\n", colors.Green(line))
+			case strings.HasPrefix(line, "-"):
+				fmt.Printf("    %s\n", colors.Red(line))
+			case strings.HasPrefix(line, "@@"):
+				fmt.Printf("    %s\n", colors.Cyan(line))
+			default:
+				fmt.Printf("    %s\n", line)
+			}
+		}
+	}
+
+	return nil
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history [<id>] [<comment>]",
+	Short: "Display the edit history of the comments of a bug",
+	Long: `Display the edit history of the comments of a bug.
+
+Without <comment>, the history of all the edited comments is displayed. A comment
+can be designated by its index, as displayed by "git bug show", or by a prefix of
+its hash.`,
+	PreRunE: loadRepo,
+	RunE:    runHistory,
+}
+
+func init() {
+	RootCmd.AddCommand(historyCmd)
+}
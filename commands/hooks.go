@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/spf13/cobra"
+)
+
+func runHooks(cmd *cobra.Command, args []string) error {
+	dir := cache.HooksDir(repo)
+
+	for _, kind := range cache.HookKinds {
+		script := path.Join(dir, string(kind))
+
+		state := "not installed"
+		stat, err := os.Stat(script)
+		switch {
+		case os.IsNotExist(err):
+		case err != nil:
+			return err
+		case stat.Mode()&0111 == 0:
+			state = colors.Yellow("not executable")
+		default:
+			state = colors.Green("installed")
+		}
+
+		fmt.Printf("%-15s %s\n", kind, state)
+	}
+
+	return nil
+}
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "List the events triggering the hooks and the installed scripts",
+	Long: `List the events triggering the hooks and the installed scripts.
+
+A hook is an executable script in .git/git-bug/hooks/, named after the event
+it reacts to. It runs after each matching mutation of a bug, or each failed
+synchronisation of the daemon, with the event as JSON on its standard input,
+and the GIT_BUG_EVENT and GIT_BUG_BUG_ID environment variables set. A failing hook is reported but doesn't cancel the
+mutation.`,
+	PreRunE: loadRepo,
+	RunE:    runHooks,
+}
+
+func init() {
+	RootCmd.AddCommand(hooksCmd)
+}
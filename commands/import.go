@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runImport(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("You must provide a bundle file")
+	}
+
+	var in io.Reader = os.Stdin
+
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var bundle cache.Bundle
+	err := json.NewDecoder(in).Decode(&bundle)
+	if err != nil {
+		return fmt.Errorf("invalid bundle: %v", err)
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	result, err := backend.ImportBundle(&bundle)
+
+	for _, id := range result.New {
+		fmt.Printf("%s new\n", colors.Cyan(backend.DisplayId(id)))
+	}
+	for _, id := range result.Updated {
+		fmt.Printf("%s updated\n", colors.Cyan(backend.DisplayId(id)))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d new, %d updated, %d unchanged\n", len(result.New), len(result.Updated), result.Unchanged)
+
+	return nil
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import bugs from a portable JSON bundle",
+	Long: `Import the bugs of a JSON bundle made by "git bug export", or - to read it
+from the standard input.
+
+The bugs already known, because they come from this repository or have been
+imported before, only receive the operations they don't have yet. The other
+bugs are created with a new id, but their operations unchanged.`,
+	Example: `git bug import bugs.json`,
+	PreRunE: loadRepo,
+	RunE:    runImport,
+}
+
+func init() {
+	RootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,271 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bridge"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/input"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+// labelPolicyDescriptions explain the label policies, in the order of
+// bug.LabelPolicies
+var labelPolicyDescriptions = []string{
+	`trim: remove the extra whitespaces ("Good  first issue " -> "Good first issue")`,
+	`lowercase: trim and lowercase ("Good first issue" -> "good first issue")`,
+	`none: keep the labels as they are typed`,
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	for _, step := range []struct {
+		title string
+		run   func(*cache.RepoCache) error
+	}{
+		{"Identity", initIdentity},
+		{"Remotes", initRemotes},
+		{"Bridges", initBridges},
+		{"Labels", initLabelPolicy},
+	} {
+		fmt.Printf("%s\n", colors.Bold(step.title))
+		if err := step.run(backend); err != nil {
+			return err
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("git-bug is ready, create a bug with \"git bug add\"")
+
+	return nil
+}
+
+// initIdentity configure the identity in git if needed, and propose to adopt
+// an existing identity with the same email
+func initIdentity(backend *cache.RepoCache) error {
+	name, err := backend.GetUserName()
+	if err != nil {
+		return err
+	}
+	for name == "" {
+		name, err = input.Prompt("Your name", "")
+		if err != nil {
+			return err
+		}
+		if name != "" {
+			err = backend.StoreConfig("user.name", name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	email, err := backend.GetUserEmail()
+	if err != nil {
+		return err
+	}
+	for email == "" {
+		email, err = input.Prompt("Your email", "")
+		if err != nil {
+			return err
+		}
+		if email != "" {
+			err = backend.StoreConfig("user.email", email)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	user, err := bug.GetUser(repo)
+	if err != nil {
+		return err
+	}
+
+	candidates, err := backend.IdentitiesByEmail(email)
+	if err != nil {
+		return err
+	}
+
+	choices := []string{fmt.Sprintf("keep %s", formatPerson(user))}
+	var others []*cache.IdentityExcerpt
+
+	for _, candidate := range candidates {
+		p := candidate.Person()
+		if p.Name == user.Name && p.Email == user.Email && p.Login == user.Login {
+			continue
+		}
+		others = append(others, candidate)
+		choices = append(choices, fmt.Sprintf("adopt %s %s", candidate.HumanId(), formatPerson(p)))
+	}
+
+	if len(others) == 0 {
+		fmt.Printf("You are %s\n", formatPerson(user))
+		return nil
+	}
+
+	fmt.Printf("Existing identities have the email %s, you can adopt one of them\n", email)
+
+	index, err := input.PromptChoice("identity", choices, 0)
+	if err != nil || index == 0 {
+		return err
+	}
+
+	adopted := others[index-1]
+
+	err = backend.SetUser(adopted.Person())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Adopted the identity %s %s\n", colors.Cyan(adopted.HumanId()), formatPerson(adopted.Person()))
+
+	return nil
+}
+
+// initRemotes propose to configure the refspecs of the remotes not set up yet
+func initRemotes(backend *cache.RepoCache) error {
+	remotes, err := backend.ListRemotes()
+	if err != nil {
+		return err
+	}
+
+	if len(remotes) == 0 {
+		fmt.Println("No git remote, the bugs can be shared later with \"git bug remote setup\"")
+		return nil
+	}
+
+	for _, remote := range remotes {
+		setup, err := backend.RemoteIsSetup(remote)
+		if err != nil {
+			return err
+		}
+		if setup {
+			fmt.Printf("The remote %s is already set up\n", remote)
+			continue
+		}
+
+		fetch, err := input.PromptYesNo(fmt.Sprintf("Fetch the bugs of the remote %s with a plain git fetch?", remote))
+		if err != nil {
+			return err
+		}
+		if !fetch {
+			continue
+		}
+
+		push, err := input.PromptYesNo(fmt.Sprintf("Push the bugs to the remote %s with a plain git push?", remote))
+		if err != nil {
+			return err
+		}
+
+		added, err := backend.SetupRemote(remote, push)
+		if err != nil {
+			return err
+		}
+		for _, refSpec := range added {
+			fmt.Printf("added %s\n", refSpec)
+		}
+	}
+
+	return nil
+}
+
+// initBridges propose to configure a bridge with another bug tracker
+func initBridges(backend *cache.RepoCache) error {
+	configured, err := bridge.ConfiguredBridges(backend)
+	if err != nil {
+		return err
+	}
+
+	question := "Configure a bridge with another bug tracker?"
+	if len(configured) > 0 {
+		fmt.Printf("Configured bridges: %s\n", strings.Join(configured, ", "))
+		question = "Configure another bridge?"
+	}
+
+	ok, err := input.PromptYesNo(question)
+	if err != nil || !ok {
+		return err
+	}
+
+	targets := bridge.Targets()
+	index, err := input.PromptChoice("target", targets, 0)
+	if err != nil {
+		return err
+	}
+
+	name, err := input.Prompt("name", "default")
+	if err != nil {
+		return err
+	}
+
+	b, err := bridge.NewBridge(backend, targets[index], name)
+	if err != nil {
+		return err
+	}
+
+	return b.Configure()
+}
+
+// initLabelPolicy propose to choose how the labels are written
+func initLabelPolicy(backend *cache.RepoCache) error {
+	current, err := backend.LabelPolicy()
+	if err != nil {
+		return err
+	}
+
+	currentIndex := 0
+	for i, policy := range bug.LabelPolicies {
+		if policy == current {
+			currentIndex = i
+		}
+	}
+
+	fmt.Println("How should the labels added to the bugs be written?")
+
+	index, err := input.PromptChoice("label policy", labelPolicyDescriptions, currentIndex)
+	if err != nil {
+		return err
+	}
+
+	if index == currentIndex {
+		return nil
+	}
+
+	return backend.SetLabelPolicy(bug.LabelPolicies[index])
+}
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Set up git-bug in the repository, step by step",
+	Long: `Set up git-bug in the repository, step by step.
+
+The questions walk through:
+- the identity authoring your changes, configured in git if it isn't yet, or
+  adopted from an existing identity with the same email
+- the remotes, for a plain git fetch and git push to exchange the bugs
+- the bridges with other bug trackers
+- the label policy, telling how the new labels are written
+
+Every step can be skipped, and the command can be run again later to change
+the answers. Each step is also available as its own command: "git bug user
+adopt", "git bug remote setup" and "git bug bridge configure".`,
+	Example: `git bug init`,
+	PreRunE: loadRepo,
+	RunE:    runInit,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	RootCmd.AddCommand(initCmd)
+}
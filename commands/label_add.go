@@ -32,12 +32,22 @@ func runLabelAdd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	return b.Commit()
+	return commitAndEcho(b)
 }
 
 var labelAddCmd = &cobra.Command{
 	Use:     "add [<id>] <label>[...]",
 	Short:   "Add a label",
+	Long: `Add a label to a bug.
+
+Labels are canonicalized according to the label policy of the repository,
+configured with "git config git-bug.label-policy <policy>":
+- trim (default): remove the extra whitespaces
+- lowercase: remove the extra whitespaces and lowercase the label
+- none: keep the label as it is
+
+If an equivalent label (ignoring the casing and whitespaces) is already used
+in the repository, its spelling is reused.`,
 	PreRunE: loadRepo,
 	RunE:    runLabelAdd,
 }
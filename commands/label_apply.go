@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	labelApplyQuery  string
+	labelApplyAdd    []string
+	labelApplyRemove []string
+	labelApplyDryRun bool
+)
+
+func runLabelApply(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return errors.New("the bugs are selected with --query")
+	}
+	if labelApplyQuery == "" {
+		return errors.New("You must select the bugs with --query")
+	}
+	if len(labelApplyAdd) == 0 && len(labelApplyRemove) == 0 {
+		return errors.New("You must provide the labels to change with --add or --remove")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	query, err := backend.ParseQuery(labelApplyQuery)
+	if err != nil {
+		return err
+	}
+
+	ids := backend.QueryBugs(query)
+
+	edits, err := backend.PlanLabelEdit(ids, labelApplyAdd, labelApplyRemove)
+	if err != nil {
+		return err
+	}
+
+	for _, edit := range edits {
+		b, err := backend.ResolveBug(edit.Id)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s %s %s\n",
+			colors.Cyan(backend.DisplayId(edit.Id)),
+			fmt.Sprintf("%-40.40s", b.Snapshot().Title),
+			formatLabelEdit(edit),
+		)
+
+		if labelApplyDryRun {
+			continue
+		}
+
+		err = b.ApplyLabelEdit(edit)
+		if err != nil {
+			return fmt.Errorf("bug %s: %v", b.HumanId(), err)
+		}
+	}
+
+	verb := "changed"
+	if labelApplyDryRun {
+		verb = "would be changed"
+	}
+	fmt.Printf("%d bug(s) matching, %d %s, %d already up to date\n",
+		len(ids), len(edits), verb, len(ids)-len(edits))
+
+	return nil
+}
+
+var labelApplyCmd = &cobra.Command{
+	Use:   "apply --query <query> [--add <label>]... [--remove <label>]...",
+	Short: "Add and remove labels on all the bugs matching a query",
+	Long: `Add and remove labels on all the bugs matching a query, with one label change
+per bug, then print a summary.
+
+The labels to add are canonicalized as with "git bug label add", and the
+labels equivalent to the ones to remove are removed.`,
+	Example: `git bug label apply --query "status:open label:old" --add new --remove old
+git bug label apply -q "author:jane" -a needs-review --dry-run`,
+	PreRunE: loadRepo,
+	RunE:    runLabelApply,
+}
+
+func init() {
+	labelCmd.AddCommand(labelApplyCmd)
+
+	labelApplyCmd.Flags().SortFlags = false
+
+	labelApplyCmd.Flags().StringVarP(&labelApplyQuery, "query", "q", "",
+		"The query selecting the bugs to change")
+	labelApplyCmd.Flags().StringArrayVarP(&labelApplyAdd, "add", "a", nil,
+		"A label to add. Can be repeated")
+	labelApplyCmd.Flags().StringArrayVarP(&labelApplyRemove, "remove", "r", nil,
+		"A label to remove. Can be repeated")
+	labelApplyCmd.Flags().BoolVarP(&labelApplyDryRun, "dry-run", "n", false,
+		"Only show the changes, without applying them")
+}
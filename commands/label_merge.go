@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	labelMergeInto   string
+	labelMergeDryRun bool
+)
+
+func runLabelMerge(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return errors.New("You must provide the labels to merge")
+	}
+	if labelMergeInto == "" {
+		return errors.New("You must provide the label to merge into with --into")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	return renameLabels(backend, args, labelMergeInto, labelMergeDryRun)
+}
+
+// renameLabels replace the labels on all the bugs, and print the changes
+func renameLabels(backend *cache.RepoCache, sources []string, target string, dryRun bool) error {
+	renames, err := backend.PlanLabelRename(sources, target)
+	if err != nil {
+		return err
+	}
+
+	if len(renames) == 0 {
+		fmt.Println("No bug to change")
+		return nil
+	}
+
+	for _, rename := range renames {
+		fmt.Printf("%s %s\n", colors.Cyan(backend.DisplayId(rename.Id)), formatLabelEdit(rename))
+	}
+
+	if dryRun {
+		fmt.Printf("%d bug(s) would be changed\n", len(renames))
+		return nil
+	}
+
+	progress, finish := progressBar("Changing the labels... ")
+	defer finish()
+
+	for i, rename := range renames {
+		b, err := backend.ResolveBug(rename.Id)
+		if err != nil {
+			return err
+		}
+
+		err = b.ApplyLabelEdit(rename)
+		if err != nil {
+			return fmt.Errorf("bug %s: %v", b.HumanId(), err)
+		}
+
+		progress(i+1, len(renames))
+	}
+
+	finish()
+	fmt.Printf("%d bug(s) changed\n", len(renames))
+
+	return nil
+}
+
+func formatLabelEdit(edit cache.LabelEdit) string {
+	var changes []string
+	for _, label := range edit.Removed {
+		changes = append(changes, colors.Red("-"+label.String()))
+	}
+	for _, label := range edit.Added {
+		changes = append(changes, colors.Green("+"+label.String()))
+	}
+	return strings.Join(changes, " ")
+}
+
+var labelMergeCmd = &cobra.Command{
+	Use:   "merge <label>... --into <label>",
+	Short: "Merge several labels into one on all the bugs",
+	Long: `Replace several labels by a single one on all the bugs having any of them,
+with one label change per bug.`,
+	Example: `git bug label merge bug defect --into kind/bug
+git bug label merge bug defect --into kind/bug --dry-run`,
+	PreRunE: loadRepo,
+	RunE:    runLabelMerge,
+}
+
+func init() {
+	labelCmd.AddCommand(labelMergeCmd)
+
+	labelMergeCmd.Flags().SortFlags = false
+
+	labelMergeCmd.Flags().StringVarP(&labelMergeInto, "into", "i", "",
+		"The label replacing the others")
+	labelMergeCmd.Flags().BoolVarP(&labelMergeDryRun, "dry-run", "n", false,
+		"Only show the changes, without applying them")
+}
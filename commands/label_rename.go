@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	labelRenameDryRun bool
+)
+
+func runLabelRename(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return errors.New("You must provide the label to rename and its new name")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	return renameLabels(backend, args[:1], args[1], labelRenameDryRun)
+}
+
+var labelRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a label on all the bugs",
+	Long: `Rename a label on all the bugs having it, or an equivalent spelling of it,
+with one label change per bug.
+
+The new name is kept as given, which allows to change the casing of a label.
+
+The changes are listed first, then applied with a progress bar.`,
+	Example: `git bug label rename "good first issue" good-first-issue
+git bug label rename --dry-run Bug bug`,
+	PreRunE: loadRepo,
+	RunE:    runLabelRename,
+}
+
+func init() {
+	labelCmd.AddCommand(labelRenameCmd)
+
+	labelRenameCmd.Flags().SortFlags = false
+
+	labelRenameCmd.Flags().BoolVarP(&labelRenameDryRun, "dry-run", "n", false,
+		"Only show the changes, without applying them")
+}
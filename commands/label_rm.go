@@ -32,7 +32,7 @@ func runLabelRm(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	return b.Commit()
+	return commitAndEcho(b)
 }
 
 var labelRmCmd = &cobra.Command{
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runLog(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, args, err := _select.ResolveBug(backend, args)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range b.History() {
+		fmt.Printf("%s %s\n",
+			colors.Yellow(entry.Operation.Time().Format("2006-01-02 15:04 -0700")),
+			colors.Magenta(entry.Author.DisplayName()),
+		)
+
+		for _, change := range entry.Diff.Changes() {
+			fmt.Printf("    %s\n", change)
+		}
+	}
+
+	return nil
+}
+
+var logCmd = &cobra.Command{
+	Use:     "log [<id>]",
+	Short:   "Display the history of a bug",
+	PreRunE: loadRepo,
+	RunE:    runLog,
+}
+
+func init() {
+	RootCmd.AddCommand(logCmd)
+}
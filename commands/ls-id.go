@@ -5,14 +5,22 @@ import (
 	"strings"
 
 	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
 	"github.com/spf13/cobra"
 )
 
 func runLsID(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
 
-	var backend *cache.RepoCache
-
-	prefix := args[0]
+	var prefix string
+	if len(args) > 0 {
+		prefix = strings.ToLower(args[0])
+	}
 
 	for _, id := range backend.AllBugsIds() {
 		if prefix == "" || strings.HasPrefix(id, prefix) {
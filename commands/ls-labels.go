@@ -9,7 +9,7 @@ import (
 )
 
 func runLsLabel(cmd *cobra.Command, args []string) error {
-	backend, err := cache.NewRepoCache(repo)
+	backend, err := cache.NewRepoCacheReadOnly(repo)
 	if err != nil {
 		return err
 	}
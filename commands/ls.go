@@ -1,8 +1,12 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
@@ -18,10 +22,26 @@ var (
 	lsNoQuery       []string
 	lsSortBy        string
 	lsSortDirection string
+	lsWorkspace     bool
+	lsAsOf          string
+	lsFormat        string
 )
 
 func runLsBug(cmd *cobra.Command, args []string) error {
-	backend, err := cache.NewRepoCache(repo)
+	switch lsFormat {
+	case "default", "plain", "json", "org-mode":
+	default:
+		return fmt.Errorf("unknown format %s", lsFormat)
+	}
+
+	if lsWorkspace {
+		if lsAsOf != "" {
+			return fmt.Errorf("--as-of can't be used with --workspace")
+		}
+		return runLsWorkspace(args)
+	}
+
+	backend, err := cache.NewRepoCacheReadOnly(repo)
 	if err != nil {
 		return err
 	}
@@ -30,7 +50,7 @@ func runLsBug(cmd *cobra.Command, args []string) error {
 
 	var query *cache.Query
 	if len(args) >= 1 {
-		query, err = cache.ParseQuery(strings.Join(args, " "))
+		query, err = backend.ParseQuery(strings.Join(args, " "))
 
 		if err != nil {
 			return err
@@ -42,37 +62,248 @@ func runLsBug(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if lsAsOf != "" {
+		return runLsAsOf(backend, query)
+	}
+
 	allIds := backend.QueryBugs(query)
 
-	for _, id := range allIds {
+	bugs := make([]lsBug, len(allIds))
+	for i, id := range allIds {
 		b, err := backend.ResolveBug(id)
 		if err != nil {
 			return err
 		}
 
-		snapshot := b.Snapshot()
+		bugs[i] = lsBug{
+			displayId: backend.DisplayId(b.Id()),
+			snapshot:  b.Snapshot(),
+		}
+	}
 
-		var author bug.Person
+	return printLs(bugs)
+}
 
-		if len(snapshot.Comments) > 0 {
-			create := snapshot.Comments[0]
-			author = create.Author
+// runLsAsOf list the bugs matching the query as they were at the time given
+// with --as-of
+func runLsAsOf(backend *cache.RepoCache, query *cache.Query) error {
+	asOf, err := cache.ParseDate(lsAsOf)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := backend.QueryBugsAt(query, asOf)
+	if err != nil {
+		return err
+	}
+
+	bugs := make([]lsBug, len(snapshots))
+	for i, snapshot := range snapshots {
+		bugs[i] = lsBug{
+			displayId: backend.DisplayId(snapshot.Id()),
+			snapshot:  snapshot,
 		}
+	}
+
+	return printLs(bugs)
+}
+
+// runLsWorkspace list the bugs of all the repositories of the workspace,
+// sorted together
+func runLsWorkspace(args []string) error {
+	multi, err := openWorkspace(true)
+	if err != nil {
+		return err
+	}
+	defer multi.Close()
+	interrupt.RegisterCleaner(multi.Close)
+
+	// the saved queries are specific to a repository
+	var query *cache.Query
+	if len(args) >= 1 {
+		query, err = cache.ParseQuery(strings.Join(args, " "))
+	} else {
+		query, err = lsQueryFromFlags()
+	}
+	if err != nil {
+		return err
+	}
+
+	ids := multi.QueryBugs(query)
+
+	bugs := make([]lsBug, len(ids))
+	for i, id := range ids {
+		backend, err := multi.ResolveRepo(id.Repo)
+		if err != nil {
+			return err
+		}
+
+		b, err := backend.ResolveBug(id.Id)
+		if err != nil {
+			return err
+		}
+
+		bugs[i] = lsBug{
+			repo:      id.Repo,
+			displayId: multi.DisplayId(id),
+			snapshot:  b.Snapshot(),
+		}
+	}
+
+	return printLs(bugs)
+}
+
+// lsBug is a bug to list
+type lsBug struct {
+	// repo is the name of the repository of the bug in the workspace, if any
+	repo      string
+	displayId string
+	snapshot  *bug.Snapshot
+}
+
+// fullId return the complete id of the bug, prefixed by the name of its
+// repository in the workspace
+func (b lsBug) fullId() string {
+	if b.repo != "" {
+		return b.repo + "/" + b.snapshot.Id()
+	}
+	return b.snapshot.Id()
+}
+
+func (b lsBug) labels() []string {
+	result := make([]string, len(b.snapshot.Labels))
+	for i, label := range b.snapshot.Labels {
+		result[i] = label.String()
+	}
+	return result
+}
+
+func printLs(bugs []lsBug) error {
+	switch lsFormat {
+	case "plain":
+		printLsPlain(bugs)
+	case "json":
+		return printLsJson(bugs)
+	case "org-mode":
+		printLsOrgMode(bugs)
+	default:
+		printLsDefault(bugs)
+	}
+
+	return nil
+}
+
+func printLsDefault(bugs []lsBug) {
+	for _, b := range bugs {
+		snapshot := b.snapshot
 
 		// truncate + pad if needed
 		titleFmt := fmt.Sprintf("%-50.50s", snapshot.Title)
-		authorFmt := fmt.Sprintf("%-15.15s", author.DisplayName())
+		authorFmt := fmt.Sprintf("%-15.15s", snapshot.Author.DisplayName())
 
 		fmt.Printf("%s %s\t%s\t%s\t%s\n",
-			colors.Cyan(b.HumanId()),
+			colors.Cyan(b.displayId),
 			colors.Yellow(snapshot.Status),
 			titleFmt,
 			colors.Magenta(authorFmt),
 			snapshot.Summary(),
 		)
 	}
+}
 
-	return nil
+// printLsPlain print a line of tab separated values per bug: the id, the
+// status, the title, the labels separated by commas, the author, the creation
+// and the last edition time
+func printLsPlain(bugs []lsBug) {
+	clean := strings.NewReplacer("\t", " ", "\n", " ")
+
+	for _, b := range bugs {
+		snapshot := b.snapshot
+
+		fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			b.fullId(),
+			snapshot.Status,
+			clean.Replace(snapshot.Title),
+			clean.Replace(strings.Join(b.labels(), ",")),
+			clean.Replace(snapshot.Author.DisplayName()),
+			snapshot.CreatedAt.Format(time.RFC3339),
+			snapshot.LastEditTime().Format(time.RFC3339),
+		)
+	}
+}
+
+type lsJsonBug struct {
+	Repo      string    `json:"repo,omitempty"`
+	Id        string    `json:"id"`
+	HumanId   string    `json:"human_id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	Labels    []string  `json:"labels"`
+	Author    string    `json:"author"`
+	Comments  int       `json:"comments"`
+	CreatedAt time.Time `json:"created_at"`
+	EditedAt  time.Time `json:"edited_at"`
+}
+
+func printLsJson(bugs []lsBug) error {
+	result := make([]lsJsonBug, len(bugs))
+
+	for i, b := range bugs {
+		snapshot := b.snapshot
+
+		result[i] = lsJsonBug{
+			Repo:      b.repo,
+			Id:        snapshot.Id(),
+			HumanId:   b.displayId,
+			Title:     snapshot.Title,
+			Status:    snapshot.Status.String(),
+			Labels:    b.labels(),
+			Author:    snapshot.Author.DisplayName(),
+			Comments:  len(snapshot.Comments) - 1,
+			CreatedAt: snapshot.CreatedAt,
+			EditedAt:  snapshot.LastEditTime(),
+		}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// orgTagRegex match the characters not allowed in an org-mode tag
+var orgTagRegex = regexp.MustCompile(`[^\pL\pN_@#%]`)
+
+// printLsOrgMode print a heading per bug, TODO or DONE, with the labels as
+// tags and the other values as properties
+func printLsOrgMode(bugs []lsBug) {
+	const orgTime = "2006-01-02 Mon 15:04"
+
+	for _, b := range bugs {
+		snapshot := b.snapshot
+
+		keyword := "TODO"
+		if snapshot.Status == bug.ClosedStatus {
+			keyword = "DONE"
+		}
+
+		heading := fmt.Sprintf("* %s %s", keyword, strings.Replace(snapshot.Title, "\n", " ", -1))
+
+		if len(snapshot.Labels) > 0 {
+			tags := make([]string, len(snapshot.Labels))
+			for i, label := range b.labels() {
+				tags[i] = orgTagRegex.ReplaceAllString(label, "_")
+			}
+			heading += " :" + strings.Join(tags, ":") + ":"
+		}
+
+		fmt.Println(heading)
+		fmt.Println("  :PROPERTIES:")
+		fmt.Printf("  :ID:       %s\n", b.fullId())
+		fmt.Printf("  :AUTHOR:   %s\n", snapshot.Author.DisplayName())
+		fmt.Printf("  :CREATED:  [%s]\n", snapshot.CreatedAt.Format(orgTime))
+		fmt.Printf("  :EDITED:   [%s]\n", snapshot.LastEditTime().Format(orgTime))
+		fmt.Println("  :END:")
+	}
 }
 
 // Transform the command flags into a query
@@ -113,6 +344,8 @@ func lsQueryFromFlags() (*cache.Query, error) {
 		query.OrderBy = cache.OrderByCreation
 	case "edit":
 		query.OrderBy = cache.OrderByEdit
+	case "votes":
+		query.OrderBy = cache.OrderByVotes
 	default:
 		return nil, fmt.Errorf("unknown sort flag %s", lsSortBy)
 	}
@@ -134,14 +367,30 @@ var lsCmd = &cobra.Command{
 	Short: "List bugs",
 	Long: `Display a summary of each bugs.
 
-You can pass an additional query to filter and order the list. This query can be expressed either with a simple query language or with flags.`,
+You can pass an additional query to filter and order the list. This query can be expressed either with a simple query language or with flags.
+
+A query saved with "git bug query save" can be used with "@<name>".
+
+With --as-of, the query is evaluated against the bugs as they were at the given time, a day (2019-01-01), a time (2019-01-01T15:04:05Z) or a duration before now (30d), according to the timestamps of their operations.`,
 	Example: `List open bugs sorted by last edition with a query:
 git bug ls status:open sort:edit-desc
 
 List closed bugs sorted by creation with flags:
 git bug ls --status closed --by creation
+
+List the bugs matching a saved query:
+git bug ls @mine
+
+List the open bugs of all the repositories of the workspace:
+git bug ls --workspace status:open
+
+List the open bugs as JSON:
+git bug ls --format json status:open
+
+List the bugs that were open at the start of the year:
+git bug ls --as-of 2019-01-01 status:open
 `,
-	PreRunE: loadRepo,
+	PreRunE: loadRepoOrWorkspace,
 	RunE:    runLsBug,
 }
 
@@ -159,7 +408,13 @@ func init() {
 	lsCmd.Flags().StringSliceVarP(&lsNoQuery, "no", "n", nil,
 		"Filter by absence of something. Valid values are [label]")
 	lsCmd.Flags().StringVarP(&lsSortBy, "by", "b", "creation",
-		"Sort the results by a characteristic. Valid values are [id,creation,edit]")
+		"Sort the results by a characteristic. Valid values are [id,creation,edit,votes]")
 	lsCmd.Flags().StringVarP(&lsSortDirection, "direction", "d", "asc",
 		"Select the sorting direction. Valid values are [asc,desc]")
+	lsCmd.Flags().BoolVarP(&lsWorkspace, "workspace", "w", false,
+		"List the bugs of all the repositories of the workspace")
+	lsCmd.Flags().StringVar(&lsAsOf, "as-of", "",
+		"List the bugs as they were at the given time")
+	lsCmd.Flags().StringVarP(&lsFormat, "format", "f", "default",
+		"Select the output format. Valid values are [default,plain,json,org-mode]")
 }
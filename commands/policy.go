@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runPolicy(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	policy := backend.Policy()
+
+	if policy.IsEmpty() {
+		fmt.Println("No policy, everyone is allowed to do everything")
+		return nil
+	}
+
+	fmt.Print(policy.String())
+
+	return nil
+}
+
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Display the policy restricting who can do what on the bugs",
+	Long: fmt.Sprintf(`Display the policy restricting who can do what on the bugs.
+
+The policy is stored in the repository and shared with the bugs. It is
+enforced when merging remote bugs: bugs with operations not allowed by the
+policy are rejected. A policy is a list of lines:
+
+	# a comment
+	team <name> <email or login>,...
+	restrict <action>,... <team, email or login>,... [label:<label>]...
+
+For example, to only allow the maintainers to close the bugs, and to only
+allow the security team to change the labels of the security bugs:
+
+	team maintainers john@example.com,jane
+	restrict close maintainers
+	restrict label-change security-team label:security
+
+Everything not restricted is allowed to everyone. The actions are: %s.
+
+The policy of a remote is only adopted if it's signed with a key trusted by
+your GPG keyring. To accept unsigned policies:
+
+	git config git-bug.policy-require-signature false`, strings.Join(bug.PolicyActions, ", ")),
+	PreRunE: loadRepo,
+	RunE:    runPolicy,
+}
+
+func init() {
+	RootCmd.AddCommand(policyCmd)
+}
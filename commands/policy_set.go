@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	policySetSign bool
+)
+
+func runPolicySet(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("You must provide a policy file, or - to read from the standard input")
+	}
+
+	var data []byte
+	var err error
+
+	if args[0] == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	policy, err := bug.ParsePolicy(data)
+	if err != nil {
+		return err
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	return backend.SetPolicy(policy, policySetSign)
+}
+
+var policySetCmd = &cobra.Command{
+	Use:     "set <file>",
+	Short:   "Replace the policy with the content of a file",
+	PreRunE: loadRepo,
+	RunE:    runPolicySet,
+}
+
+func init() {
+	policyCmd.AddCommand(policySetCmd)
+
+	policySetCmd.Flags().SortFlags = false
+
+	policySetCmd.Flags().BoolVarP(&policySetSign, "sign", "S", true,
+		"Sign the policy with your GPG key, for the other clones to adopt it",
+	)
+}
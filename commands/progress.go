@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// progressBarWidth is the number of characters of a full progress bar
+const progressBarWidth = 30
+
+// progressBar return a progress callback drawing a bar after the given
+// prefix on the standard error, and a function to call when done to end the
+// line, which can be called several times. Nothing is drawn if the standard
+// error is not a terminal, to keep the logs clean.
+func progressBar(prefix string) (progress func(done, total int), finish func()) {
+	if !terminal.IsTerminal(int(os.Stderr.Fd())) {
+		return func(done, total int) {}, func() {}
+	}
+
+	drawn := false
+
+	progress = func(done, total int) {
+		filled := progressBarWidth
+		if total > 0 {
+			filled = done * progressBarWidth / total
+		}
+
+		_, _ = fmt.Fprintf(os.Stderr, "\r%s[%s%s] %d/%d ",
+			prefix,
+			strings.Repeat("#", filled),
+			strings.Repeat(" ", progressBarWidth-filled),
+			done, total,
+		)
+		drawn = true
+	}
+
+	finish = func() {
+		if drawn {
+			_, _ = fmt.Fprintln(os.Stderr)
+			drawn = false
+		}
+	}
+
+	return progress, finish
+}
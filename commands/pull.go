@@ -41,6 +41,11 @@ func runPull(cmd *cobra.Command, args []string) error {
 	for merge := range backend.MergeAll(remote) {
 		if merge.Err != nil {
 			fmt.Println(merge.Err)
+			continue
+		}
+		if merge.Warning != nil {
+			fmt.Printf("warning: %s\n", merge.Warning)
+			continue
 		}
 
 		if merge.Status != bug.MergeStatusNothing {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/util/interrupt"
 	"github.com/spf13/cobra"
@@ -26,12 +27,18 @@ func runPush(cmd *cobra.Command, args []string) error {
 	defer backend.Close()
 	interrupt.RegisterCleaner(backend.Close)
 
-	stdout, err := backend.Push(remote)
+	results, err := backend.Push(remote)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println(stdout)
+	if len(results) == 0 {
+		fmt.Println("Everything up-to-date")
+	}
+
+	for _, result := range results {
+		fmt.Printf("%s: %s\n", bug.FormatHumanID(result.Id), result)
+	}
 
 	return nil
 }
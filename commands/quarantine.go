@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runQuarantine(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	quarantined, err := backend.ListQuarantined()
+	if err != nil {
+		return err
+	}
+
+	for _, q := range quarantined {
+		snapshot := q.Bug.Compile()
+
+		names := make([]string, len(q.Untrusted))
+		for i, p := range q.Untrusted {
+			names[i] = p.DisplayName()
+		}
+
+		// truncate + pad if needed
+		titleFmt := fmt.Sprintf("%-50.50s", snapshot.Title)
+
+		fmt.Printf("%s %s\t%s\n",
+			colors.Cyan(q.Bug.HumanId()),
+			titleFmt,
+			colors.Magenta(strings.Join(names, ", ")),
+		)
+	}
+
+	return nil
+}
+
+var quarantineCmd = &cobra.Command{
+	Use:   "quarantine",
+	Short: "List the remote bugs waiting for a review before being merged",
+	Long: `List the remote bugs waiting for a review before being merged.
+
+When an allow-list of trusted authors is configured, the remote bugs with
+operations from other authors are put in quarantine during a pull instead of
+being merged. The allow-list is a comma separated list of emails or logins:
+
+	git config git-bug.trusted-authors "john@example.com,jane"`,
+	PreRunE: loadRepo,
+	RunE:    runQuarantine,
+}
+
+func init() {
+	RootCmd.AddCommand(quarantineCmd)
+}
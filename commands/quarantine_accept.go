@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runQuarantineAccept(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return errors.New("You must provide a bug id")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	for _, prefix := range args {
+		result, err := backend.AcceptQuarantined(prefix)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s: %s\n", bug.FormatHumanID(result.Id), result)
+	}
+
+	return nil
+}
+
+var quarantineAcceptCmd = &cobra.Command{
+	Use:     "accept <id>[...]",
+	Short:   "Merge a bug in quarantine",
+	PreRunE: loadRepo,
+	RunE:    runQuarantineAccept,
+}
+
+func init() {
+	quarantineCmd.AddCommand(quarantineAcceptCmd)
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runQuarantineReject(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return errors.New("You must provide a bug id")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	for _, prefix := range args {
+		id, err := backend.RejectQuarantined(prefix)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s: rejected\n", bug.FormatHumanID(id))
+	}
+
+	return nil
+}
+
+var quarantineRejectCmd = &cobra.Command{
+	Use:     "reject <id>[...]",
+	Short:   "Drop a bug in quarantine without merging it",
+	PreRunE: loadRepo,
+	RunE:    runQuarantineReject,
+}
+
+func init() {
+	quarantineCmd.AddCommand(quarantineRejectCmd)
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runQuery(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	saved, err := backend.SavedQueries()
+	if err != nil {
+		return err
+	}
+
+	for _, query := range saved {
+		fmt.Printf("%s\t%s\n", colors.Cyan("@"+query.Name), query.Query)
+	}
+
+	return nil
+}
+
+var queryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "List, save or remove named queries",
+	Long: `List, save or remove named queries.
+
+A saved query can be used in place of a filter with "@<name>", for example with
+"git bug ls @mine" or "git bug ls @mine -label:wontfix". The saved queries are
+stored in the git config of the repository, under git-bug.query.<name>.`,
+	PreRunE: loadRepo,
+	RunE:    runQuery,
+}
+
+func init() {
+	RootCmd.AddCommand(queryCmd)
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runQueryRm(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("You must provide a name")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	return backend.RemoveSavedQuery(args[0])
+}
+
+var queryRmCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Short:   "Remove a saved query",
+	PreRunE: loadRepo,
+	RunE:    runQueryRm,
+}
+
+func init() {
+	queryCmd.AddCommand(queryRmCmd)
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runQuerySave(cmd *cobra.Command, args []string) error {
+	if len(args) < 2 {
+		return errors.New("You must provide a name and a query")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	return backend.SaveQuery(args[0], strings.Join(args[1:], " "))
+}
+
+var querySaveCmd = &cobra.Command{
+	Use:   "save <name> <query>",
+	Short: "Save a query under a name, to be used with @<name>",
+	Example: `git bug query save mine 'author:"René Descartes" status:open sort:edit'
+git bug ls @mine`,
+	PreRunE: loadRepo,
+	RunE:    runQuerySave,
+}
+
+func init() {
+	queryCmd.AddCommand(querySaveCmd)
+}
@@ -0,0 +1,14 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var remoteCmd = &cobra.Command{
+	Use:   "remote",
+	Short: "Configure the git remotes to share the bugs",
+}
+
+func init() {
+	RootCmd.AddCommand(remoteCmd)
+}
@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/spf13/cobra"
+)
+
+var (
+	remoteSetupPush  bool
+	remoteSetupCheck bool
+)
+
+func runRemoteSetup(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return errors.New("Only setting up one remote at a time is supported")
+	}
+
+	remote := "origin"
+	if len(args) == 1 {
+		remote = args[0]
+	}
+
+	remoteRepo, ok := repo.(repository.RemoteRepo)
+	if !ok {
+		return errors.New("the repository can't configure its remotes")
+	}
+
+	remotes, err := remoteRepo.ListRemotes()
+	if err != nil {
+		return err
+	}
+	if !contains(remotes, remote) {
+		return fmt.Errorf("unknown remote %s", remote)
+	}
+
+	if remoteSetupCheck {
+		return checkRemoteRefSpecs(remoteRepo, remote)
+	}
+
+	return setupRemote(remoteRepo, remote, remoteSetupPush)
+}
+
+// setupRemote configure the refspecs of a remote and print the changes
+func setupRemote(remoteRepo repository.RemoteRepo, remote string, push bool) error {
+	added, err := bug.AddFetchRefSpecs(remoteRepo, remote)
+	if err != nil {
+		return err
+	}
+	for _, refSpec := range added {
+		fmt.Printf("fetch %s\n", refSpec)
+	}
+
+	if push {
+		pushAdded, err := bug.AddPushRefSpecs(remoteRepo, remote)
+		if err != nil {
+			return err
+		}
+		for _, refSpec := range pushAdded {
+			fmt.Printf("push %s\n", refSpec)
+		}
+		added = append(added, pushAdded...)
+	}
+
+	if len(added) == 0 {
+		fmt.Printf("The remote %s is already set up\n", remote)
+	}
+
+	return nil
+}
+
+func checkRemoteRefSpecs(remoteRepo repository.RemoteRepo, remote string) error {
+	missing, err := bug.MissingFetchRefSpecs(remoteRepo, remote)
+	if err != nil {
+		return err
+	}
+	for _, refSpec := range missing {
+		fmt.Printf("missing fetch %s\n", refSpec)
+	}
+
+	if remoteSetupPush {
+		pushMissing, err := bug.MissingPushRefSpecs(remoteRepo, remote)
+		if err != nil {
+			return err
+		}
+		for _, refSpec := range pushMissing {
+			fmt.Printf("missing push %s\n", refSpec)
+		}
+		missing = append(missing, pushMissing...)
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%d refspec(s) missing, use \"git bug remote setup\" to add them", len(missing))
+	}
+
+	fmt.Printf("The remote %s is set up\n", remote)
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+var remoteSetupCmd = &cobra.Command{
+	Use:   "setup [<remote>]",
+	Short: "Configure a git remote so that git fetch also retrieve the bugs",
+	Long: `Configure the refspecs of a git remote so that a plain "git fetch" also
+retrieve the bugs, the policy and the aliases, in the same place as
+"git bug pull". The fetched bugs still need to be merged with "git bug pull".
+
+With --push, "git push" also send them. As git only push the configured
+refspecs when there are some, the current branch is added as well if nothing
+was configured.
+
+With --check, only report the missing refspecs, and fail if there are some.`,
+	Example: `git bug remote setup
+git bug remote setup upstream --push
+git bug remote setup --check`,
+	PreRunE: loadRepo,
+	RunE:    runRemoteSetup,
+}
+
+func init() {
+	remoteCmd.AddCommand(remoteSetupCmd)
+
+	remoteSetupCmd.Flags().SortFlags = false
+
+	remoteSetupCmd.Flags().BoolVarP(&remoteSetupPush, "push", "p", false,
+		"Also configure git push to send the bugs")
+	remoteSetupCmd.Flags().BoolVarP(&remoteSetupCheck, "check", "c", false,
+		"Only report the missing refspecs")
+}
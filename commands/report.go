@@ -0,0 +1,14 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate reports about the bugs",
+}
+
+func init() {
+	RootCmd.AddCommand(reportCmd)
+}
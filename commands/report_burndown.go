@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+const burndownHeight = 10
+
+func runReportBurndown(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("You must provide a milestone")
+	}
+
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	burndown, err := backend.Burndown(args[0], time.Now())
+	if err != nil {
+		return err
+	}
+
+	if burndown.Bugs == 0 {
+		fmt.Printf("No bug in milestone %s\n", burndown.Milestone)
+		return nil
+	}
+
+	printBurndown(burndown)
+
+	last := burndown.Points[len(burndown.Points)-1]
+
+	fmt.Println()
+	fmt.Printf("Milestone:   %s\n", burndown.Milestone)
+	fmt.Printf("Bugs:        %d (%d open)\n", burndown.Bugs, last.Open)
+	fmt.Printf("Remaining:   %g\n", last.Remaining)
+
+	if burndown.Unestimated > 0 {
+		fmt.Printf("Unestimated: %d\n", burndown.Unestimated)
+	}
+
+	return nil
+}
+
+func printBurndown(burndown *cache.Burndown) {
+	max := 0.0
+	for _, point := range burndown.Points {
+		max = math.Max(max, point.Remaining)
+	}
+
+	if max == 0 {
+		fmt.Println("Nothing to plot, no open bug has an estimate")
+		return
+	}
+
+	for row := burndownHeight; row > 0; row-- {
+		threshold := max * float64(row) / burndownHeight
+
+		var line strings.Builder
+		for _, point := range burndown.Points {
+			// the small epsilon avoid missing the top row on rounding errors
+			if point.Remaining >= threshold-1e-9 {
+				line.WriteByte('#')
+			} else {
+				line.WriteByte(' ')
+			}
+		}
+
+		fmt.Printf("%8.4g |%s\n", threshold, line.String())
+	}
+
+	fmt.Printf("%8s +%s\n", "", strings.Repeat("-", len(burndown.Points)))
+
+	first := burndown.Points[0].Time.Format("2006-01-02")
+	last := burndown.Points[len(burndown.Points)-1].Time.Format("2006-01-02")
+	fmt.Printf("%8s  %s -> %s\n", "", first, last)
+}
+
+var reportBurndownCmd = &cobra.Command{
+	Use:   "burndown <milestone>",
+	Short: "Display a burndown chart of a milestone",
+	Long: `Display a burndown chart of a milestone.
+
+A milestone is a label. The chart show, for each day, the sum of the estimates
+of the bugs of the milestone still open at the end of the day.`,
+	PreRunE: loadRepo,
+	RunE:    runReportBurndown,
+}
+
+func init() {
+	reportCmd.AddCommand(reportBurndownCmd)
+}
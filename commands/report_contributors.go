@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+// contributorsBarWidth is the width of the bar of the most active person
+const contributorsBarWidth = 30
+
+var (
+	contributorsSince  string
+	contributorsUntil  string
+	contributorsFormat string
+)
+
+func runReportContributors(cmd *cobra.Command, args []string) error {
+	switch contributorsFormat {
+	case "default", "json", "markdown":
+	default:
+		return fmt.Errorf("unknown format %s", contributorsFormat)
+	}
+
+	var since, until time.Time
+	var err error
+
+	if contributorsSince != "" {
+		since, err = cache.ParseDate(contributorsSince)
+		if err != nil {
+			return err
+		}
+	}
+	if contributorsUntil != "" {
+		until, err = cache.ParseDate(contributorsUntil)
+		if err != nil {
+			return err
+		}
+	}
+
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	contributions, err := backend.Contributions(since, until)
+	if err != nil {
+		return err
+	}
+
+	switch contributorsFormat {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(contributions)
+	case "markdown":
+		printContributorsMarkdown(contributions, since, until)
+	default:
+		printContributors(contributions)
+	}
+
+	return nil
+}
+
+// printContributors print the contributions with a bar proportional to the
+// number of operations of each person
+func printContributors(contributions []cache.Contribution) {
+	if len(contributions) == 0 {
+		fmt.Println("No activity in this period")
+		return
+	}
+
+	max := contributions[0].Operations
+
+	fmt.Printf("%-30s %6s %6s %8s %5s\n", "", "opened", "closed", "comments", "ops")
+
+	for _, contribution := range contributions {
+		bar := contribution.Operations * contributorsBarWidth / max
+		if bar == 0 {
+			bar = 1
+		}
+
+		// padded before coloring, as the escape codes would count
+		fmt.Printf("%s %6d %6d %8d %5d %s\n",
+			colors.Magenta(fmt.Sprintf("%-30s", truncate(contribution.DisplayName(), 30))),
+			contribution.Opened,
+			contribution.Closed,
+			contribution.Comments,
+			contribution.Operations,
+			colors.Green(strings.Repeat("#", bar)),
+		)
+	}
+}
+
+func printContributorsMarkdown(contributions []cache.Contribution, since time.Time, until time.Time) {
+	period := "all time"
+	switch {
+	case !since.IsZero() && !until.IsZero():
+		period = fmt.Sprintf("from %s to %s", since.Format("2006-01-02"), until.Format("2006-01-02"))
+	case !since.IsZero():
+		period = fmt.Sprintf("since %s", since.Format("2006-01-02"))
+	case !until.IsZero():
+		period = fmt.Sprintf("until %s", until.Format("2006-01-02"))
+	}
+
+	fmt.Printf("## Contributors (%s)\n\n", period)
+
+	if len(contributions) == 0 {
+		fmt.Println("No activity in this period.")
+		return
+	}
+
+	fmt.Println("| Rank | Contributor | Opened | Closed | Comments | Operations |")
+	fmt.Println("| ---: | --- | ---: | ---: | ---: | ---: |")
+
+	for i, contribution := range contributions {
+		fmt.Printf("| %d | %s | %d | %d | %d | %d |\n",
+			i+1,
+			strings.Replace(contribution.DisplayName(), "|", "\\|", -1),
+			contribution.Opened,
+			contribution.Closed,
+			contribution.Comments,
+			contribution.Operations,
+		)
+	}
+}
+
+// truncate shorten a string to a maximum number of runes
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "…"
+}
+
+var reportContributorsCmd = &cobra.Command{
+	Use:   "contributors",
+	Short: "Rank the people by their activity on the bugs",
+	Long: `Rank the people by their activity on the bugs during a period: the bugs they
+opened and closed, the comments they added, and their operations of any kind.
+
+The period is given with --since and --until, as a day (2018-09-01), a time
+(2018-09-01T15:04:05Z) or a duration before now (12h, 30d, 2w).`,
+	Example: `git bug report contributors --since 30d
+git bug report contributors --since 2018-01-01 --until 2019-01-01 --format markdown`,
+	PreRunE: loadRepo,
+	RunE:    runReportContributors,
+}
+
+func init() {
+	reportCmd.AddCommand(reportContributorsCmd)
+
+	reportContributorsCmd.Flags().SortFlags = false
+
+	reportContributorsCmd.Flags().StringVarP(&contributorsSince, "since", "s", "",
+		"Start of the period, included")
+	reportContributorsCmd.Flags().StringVarP(&contributorsUntil, "until", "u", "",
+		"End of the period, excluded")
+	reportContributorsCmd.Flags().StringVarP(&contributorsFormat, "format", "f", "default",
+		"Select the output format. Valid values are [default,json,markdown]")
+}
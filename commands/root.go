@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/repository"
 	"github.com/spf13/cobra"
 )
@@ -15,6 +16,14 @@ const rootCommandName = "git-bug"
 // package scoped var to hold the repo after the PreRun execution
 var repo repository.ClockedRepo
 
+// rootRepoPath is the path of the repository to use instead of the current
+// directory
+var rootRepoPath string
+
+// rootPorcelain print the errors in a format stable for the scripts. As it
+// must apply to the errors of the flags parsing, Execute look for it directly.
+var rootPorcelain bool
+
 // RootCmd represents the base command when called without any subcommands
 var RootCmd = &cobra.Command{
 	Use:   rootCommandName,
@@ -25,6 +34,18 @@ git-bug use git objects to store the bug tracking separated from the files
 history. As bugs are regular git objects, they can be pushed and pulled from/to
 the same git remote your are already using to collaborate with other peoples.
 
+The exit code tells the kind of error, for the scripts:
+  1: other error
+  2: invalid command line
+  3: bug, identity or operation not found
+  4: ambiguous id prefix
+  5: repository locked by another process
+  6: invalid value
+  7: not allowed by the policy, or read-only
+
+With --porcelain, the error is printed on the standard error as a single line
+of JSON, like {"error":"not-found","code":3,"message":"bug doesn't exist"}.
+
 `,
 
 	// For the root command, force the execution of the PreRun
@@ -47,26 +68,100 @@ _git_bug() {
 `,
 }
 
+func init() {
+	RootCmd.PersistentFlags().StringVar(&rootRepoPath, "repo", "",
+		"Path to the git repository to use, instead of the current directory",
+	)
+	RootCmd.PersistentFlags().BoolVar(&rootPorcelain, "porcelain", false,
+		"Print the errors as JSON, in a format stable for the scripts",
+	)
+
+	RootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return usageError{err: err}
+	})
+}
+
 func Execute() {
-	if err := RootCmd.Execute(); err != nil {
-		os.Exit(1)
+	// the errors happening before the flags are parsed must be silenced as
+	// well, so the flag is looked for directly
+	for _, arg := range os.Args[1:] {
+		if arg == "--" {
+			break
+		}
+		if arg == "--porcelain" {
+			RootCmd.SilenceErrors = true
+			RootCmd.SilenceUsage = true
+		}
 	}
+
+	err := RootCmd.Execute()
+	if err == nil {
+		return
+	}
+
+	code := exitCode(err)
+
+	if RootCmd.SilenceErrors {
+		printPorcelainError(err, code)
+	}
+
+	os.Exit(code)
 }
 
 func loadRepo(cmd *cobra.Command, args []string) error {
-	cwd, err := os.Getwd()
+	err := loadRepoSkipChecks(cmd, args)
 	if err != nil {
-		return fmt.Errorf("Unable to get the current working directory: %q\n", err)
+		return err
 	}
 
-	repo, err = repository.NewGitRepo(cwd, bug.Witnesser)
+	warnStartupProblems()
+
+	return nil
+}
+
+// loadRepoSkipChecks load the repository without warning about the problems
+// found at startup
+func loadRepoSkipChecks(cmd *cobra.Command, args []string) error {
+	path := rootRepoPath
+	if path == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("Unable to get the current working directory: %q\n", err)
+		}
+		path = cwd
+	} else if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("Unable to use the repository %s: %v\n", path, err)
+	}
+
+	var err error
+	repo, err = repository.NewGitRepo(path, bug.Witnesser)
+	if err == repository.ErrNotARepo && rootRepoPath != "" {
+		return fmt.Errorf("%s is not a git repo.\n", rootRepoPath)
+	}
 	if err == repository.ErrNotARepo {
-		return fmt.Errorf("%s must be run from within a git repo.\n", rootCommandName)
+		return fmt.Errorf("%s must be run from within a git repo, or with --repo.\n", rootCommandName)
 	}
 
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return bug.LoadDisplayLocation(repo)
+}
+
+// warnStartupProblems print a warning for the problems that are likely to
+// cause errors hard to understand, and point to the doctor command
+func warnStartupProblems() {
+	diagnostics, err := cache.DiagnoseStartup(repo)
+	if err != nil {
+		return
+	}
+
+	for _, diagnostic := range diagnostics {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", diagnostic.Problem)
+	}
+
+	if len(diagnostics) > 0 {
+		fmt.Fprintln(os.Stderr, "warning: run \"git bug doctor\" for more details")
+	}
 }
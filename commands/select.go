@@ -1,18 +1,28 @@
 package commands
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/colors"
 	"github.com/MichaelMure/git-bug/util/interrupt"
 	"github.com/spf13/cobra"
 )
 
+// selectMaxChoices is the maximum number of bugs offered when several match
+// the title search
+const selectMaxChoices = 10
+
 func runSelect(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
-		return errors.New("You must provide a bug id")
+		return errors.New("You must provide a bug id or a part of its title")
 	}
 
 	backend, err := cache.NewRepoCache(repo)
@@ -22,11 +32,21 @@ func runSelect(cmd *cobra.Command, args []string) error {
 	defer backend.Close()
 	interrupt.RegisterCleaner(backend.Close)
 
-	prefix := args[0]
+	var b *cache.BugCache
 
-	b, err := backend.ResolveBugPrefix(prefix)
-	if err != nil {
-		return err
+	if len(args) == 1 {
+		b, err = backend.ResolveBugPrefix(args[0])
+		if err != nil && err != bug.ErrBugNotExist {
+			return err
+		}
+	}
+
+	// not an id, search by title instead
+	if b == nil {
+		b, err = selectByTitle(backend, strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
 	}
 
 	err = _select.Select(backend, b.Id())
@@ -39,12 +59,85 @@ func runSelect(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// commitAndEcho commit the changes of a bug, and tell on stderr which bug it
+// was, to notice quickly when a command acted on the wrong selected bug
+func commitAndEcho(b *cache.BugCache) error {
+	err := b.Commit()
+	if err != nil {
+		return err
+	}
+
+	echoBug(b)
+
+	return nil
+}
+
+// echoBug tell on stderr which bug a command acted on
+func echoBug(b *cache.BugCache) {
+	fmt.Fprintf(os.Stderr, "bug %s: %s\n", colors.Cyan(b.HumanId()), b.Snapshot().Title)
+}
+
+// selectByTitle find the bug whose title match a search, asking which one
+// if several do
+func selectByTitle(backend *cache.RepoCache, search string) (*cache.BugCache, error) {
+	ids := backend.SearchBugsByTitle(search)
+
+	switch len(ids) {
+	case 0:
+		return nil, fmt.Errorf("no bug matching \"%s\"", search)
+	case 1:
+		return backend.ResolveBug(ids[0])
+	}
+
+	if len(ids) > selectMaxChoices {
+		ids = ids[:selectMaxChoices]
+	}
+
+	fmt.Printf("Several bugs match \"%s\":\n", search)
+
+	for i, id := range ids {
+		b, err := backend.ResolveBug(id)
+		if err != nil {
+			return nil, err
+		}
+		snap := b.Snapshot()
+		fmt.Printf("%3d. %s %s\t%s\n",
+			i+1,
+			colors.Cyan(backend.DisplayId(id)),
+			colors.Yellow(snap.Status),
+			snap.Title,
+		)
+	}
+
+	fmt.Printf("Select a bug [1-%d]: ", len(ids))
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && strings.TrimSpace(line) == "" {
+		fmt.Println()
+		return nil, errors.New("no bug selected, give an id or a more precise title")
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil || n < 1 || n > len(ids) {
+		return nil, fmt.Errorf("invalid choice \"%s\"", strings.TrimSpace(line))
+	}
+
+	return backend.ResolveBug(ids[n-1])
+}
+
 var selectCmd = &cobra.Command{
-	Use:   "select <id>",
+	Use:   "select <id|title>",
 	Short: "Select a bug for implicit use in future commands",
+	Long: `Select a bug for implicit use in future commands.
+
+The bug is given by a prefix of its id, or by a part of its title when it's
+not an id. The title search tolerates partial words and typos, and asks which
+bug to select when several match.`,
 	Example: `git bug select 2f15
+git bug select "crash on start"
 git bug comment
 git bug status
+git bug selected
 `,
 	PreRunE: loadRepo,
 	RunE:    runSelect,
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
@@ -15,6 +16,10 @@ import (
 )
 
 const selectFile = "select"
+const historyFile = "select-history"
+
+// historyMaxLength is the number of previously selected bugs remembered
+const historyMaxLength = 20
 
 var ErrNoValidId = errors.New("you must provide a bug id")
 
@@ -68,8 +73,30 @@ func ResolveBug(repo *cache.RepoCache, args []string) (*cache.BugCache, []string
 	return nil, nil, ErrNoValidId
 }
 
-// Select will select a bug for future use
+// Select will select a bug for future use. The previously selected bug is
+// pushed in the history, to come back to it with Deselect.
 func Select(repo *cache.RepoCache, id string) error {
+	previous, err := selectedId(repo)
+	if err != nil {
+		return err
+	}
+
+	if previous != "" && previous != id {
+		history, err := History(repo)
+		if err != nil {
+			return err
+		}
+
+		err = writeHistory(repo, append([]string{previous}, history...))
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeSelectFile(repo, id)
+}
+
+func writeSelectFile(repo *cache.RepoCache, id string) error {
 	selectPath := selectFilePath(repo)
 
 	f, err := os.OpenFile(selectPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
@@ -92,6 +119,120 @@ func Clear(repo *cache.RepoCache) error {
 	return os.Remove(selectPath)
 }
 
+// Deselect clear the selected bug and select back the previous one of the
+// history that still exist. It return the newly selected bug, nil if the
+// history is exhausted.
+func Deselect(repo *cache.RepoCache) (*cache.BugCache, error) {
+	history, err := History(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	for len(history) > 0 {
+		id := history[0]
+		history = history[1:]
+
+		b, err := repo.ResolveBug(id)
+		if err == bug.ErrBugNotExist {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = writeHistory(repo, history)
+		if err != nil {
+			return nil, err
+		}
+
+		return b, writeSelectFile(repo, id)
+	}
+
+	err = writeHistory(repo, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = Clear(repo)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ClearHistory forget the previously selected bugs
+func ClearHistory(repo *cache.RepoCache) error {
+	return writeHistory(repo, nil)
+}
+
+// History return the ids of the previously selected bugs, the most recent
+// first
+func History(repo *cache.RepoCache) ([]string, error) {
+	data, err := ioutil.ReadFile(historyFilePath(repo))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, line := range strings.Split(string(data), "\n") {
+		h := git.Hash(line)
+		if h.IsValid() {
+			result = append(result, line)
+		}
+	}
+
+	return result, nil
+}
+
+func writeHistory(repo *cache.RepoCache, history []string) error {
+	if len(history) == 0 {
+		err := os.Remove(historyFilePath(repo))
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	// keep only the most recent selection of a bug
+	seen := make(map[string]bool)
+	var deduplicated []string
+	for _, id := range history {
+		if !seen[id] {
+			seen[id] = true
+			deduplicated = append(deduplicated, id)
+		}
+	}
+
+	if len(deduplicated) > historyMaxLength {
+		deduplicated = deduplicated[:historyMaxLength]
+	}
+
+	data := strings.Join(deduplicated, "\n") + "\n"
+	return ioutil.WriteFile(historyFilePath(repo), []byte(data), 0666)
+}
+
+// selectedId return the id written in the select file, without checking it
+func selectedId(repo *cache.RepoCache) (string, error) {
+	data, err := ioutil.ReadFile(selectFilePath(repo))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Selected return the selected bug, or nil if no bug is selected
+func Selected(repo *cache.RepoCache) (*cache.BugCache, error) {
+	return selected(repo)
+}
+
 func selected(repo *cache.RepoCache) (*cache.BugCache, error) {
 	selectPath := selectFilePath(repo)
 
@@ -136,5 +277,9 @@ func selected(repo *cache.RepoCache) (*cache.BugCache, error) {
 }
 
 func selectFilePath(repo repository.RepoCommon) string {
-	return path.Join(repo.GetPath(), ".git", "git-bug", selectFile)
+	return path.Join(repo.GetGitDir(), "git-bug", selectFile)
+}
+
+func historyFilePath(repo repository.RepoCommon) string {
+	return path.Join(repo.GetGitDir(), "git-bug", historyFile)
 }
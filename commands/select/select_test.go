@@ -85,6 +85,53 @@ func TestSelect(t *testing.T) {
 	}
 }
 
+func TestSelectHistory(t *testing.T) {
+	repo, err := cache.NewRepoCache(createRepo())
+	checkErr(t, err)
+
+	b1, err := repo.NewBug("first", "message")
+	checkErr(t, err)
+	b2, err := repo.NewBug("second", "message")
+	checkErr(t, err)
+	b3, err := repo.NewBug("third", "message")
+	checkErr(t, err)
+
+	for _, b := range []*cache.BugCache{b1, b2, b1, b3} {
+		checkErr(t, Select(repo, b.Id()))
+	}
+
+	// only the most recent selection of a bug is kept
+	history, err := History(repo)
+	checkErr(t, err)
+	if len(history) != 2 || history[0] != b1.Id() || history[1] != b2.Id() {
+		t.Fatal("unexpected history", history)
+	}
+
+	b, err := Deselect(repo)
+	checkErr(t, err)
+	if b == nil || b.Id() != b1.Id() {
+		t.Fatal("expected to come back to the first bug")
+	}
+
+	b, err = Deselect(repo)
+	checkErr(t, err)
+	if b == nil || b.Id() != b2.Id() {
+		t.Fatal("expected to come back to the second bug")
+	}
+
+	b, err = Deselect(repo)
+	checkErr(t, err)
+	if b != nil {
+		t.Fatal("expected no selected bug")
+	}
+
+	b, err = Selected(repo)
+	checkErr(t, err)
+	if b != nil {
+		t.Fatal("expected no selected bug")
+	}
+}
+
 func createRepo() *repository.GitRepo {
 	dir, err := ioutil.TempDir("", "")
 	if err != nil {
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	selectedId bool
+)
+
+func runSelected(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, err := _select.Selected(backend)
+	if err != nil && err != bug.ErrBugNotExist {
+		return err
+	}
+
+	if selectedId {
+		if b != nil {
+			fmt.Println(b.Id())
+		}
+		return nil
+	}
+
+	if b == nil {
+		fmt.Println("No bug selected")
+	} else {
+		fmt.Printf("Selected: %s\n", formatSelectedBug(backend, b))
+	}
+
+	history, err := _select.History(backend)
+	if err != nil {
+		return err
+	}
+
+	var previous []*cache.BugCache
+	for _, id := range history {
+		b, err := backend.ResolveBug(id)
+		if err == bug.ErrBugNotExist {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		previous = append(previous, b)
+	}
+
+	if len(previous) > 0 {
+		fmt.Println()
+		fmt.Println("Previously selected:")
+		for _, b := range previous {
+			fmt.Printf("  %s\n", formatSelectedBug(backend, b))
+		}
+	}
+
+	return nil
+}
+
+func formatSelectedBug(backend *cache.RepoCache, b *cache.BugCache) string {
+	snap := b.Snapshot()
+	return fmt.Sprintf("%s %s %s",
+		colors.Cyan(backend.DisplayId(b.Id())),
+		colors.Yellow("["+snap.Status.String()+"]"),
+		snap.Title,
+	)
+}
+
+var selectedCmd = &cobra.Command{
+	Use:   "selected",
+	Short: "Show the selected bug and the previously selected ones",
+	Long: `Show the bug selected with "git bug select", used by the commands when no bug id is given, and the previously selected bugs that "git bug deselect" comes back to.
+
+With --id, only the full id of the selected bug is printed, or nothing if no bug is selected, to be used in scripts or in the shell prompt.`,
+	Example: `git bug selected
+PS1='$(git bug selected --id | cut -c1-7) \$ '`,
+	PreRunE: loadRepo,
+	RunE:    runSelected,
+}
+
+func init() {
+	RootCmd.AddCommand(selectedCmd)
+
+	selectedCmd.Flags().SortFlags = false
+
+	selectedCmd.Flags().BoolVarP(&selectedId, "id", "i", false,
+		"Only print the full id of the selected bug",
+	)
+}
@@ -17,7 +17,11 @@ var (
 )
 
 func runShowBug(cmd *cobra.Command, args []string) error {
-	backend, err := cache.NewRepoCache(repo)
+	if len(args) > 0 && isWorkspaceId(args[0]) {
+		return runShowWorkspaceBug(args[0])
+	}
+
+	backend, err := cache.NewRepoCacheReadOnly(repo)
 	if err != nil {
 		return err
 	}
@@ -29,6 +33,31 @@ func runShowBug(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	return showBug(backend, b, "")
+}
+
+// runShowWorkspaceBug show a bug of the workspace, given by the name of its
+// repository and its id
+func runShowWorkspaceBug(qualifiedId string) error {
+	multi, err := openWorkspace(true)
+	if err != nil {
+		return err
+	}
+	defer multi.Close()
+	interrupt.RegisterCleaner(multi.Close)
+
+	backend, b, err := multi.ResolveBugPrefix(qualifiedId)
+	if err != nil {
+		return err
+	}
+
+	repoName, _, _ := cache.SplitQualifiedId(qualifiedId)
+
+	return showBug(backend, b, repoName)
+}
+
+// showBug print a bug. The name of its repository, if any, prefix its id.
+func showBug(backend *cache.RepoCache, b *cache.BugCache, repoName string) error {
 	snapshot := b.Snapshot()
 
 	if len(snapshot.Comments) == 0 {
@@ -45,17 +74,32 @@ func runShowBug(cmd *cobra.Command, args []string) error {
 			fmt.Printf("%s\n", firstComment.Author.Email)
 		case "createTime":
 			fmt.Printf("%s\n", firstComment.FormatTime())
+		case "fixedIn":
+			for _, fix := range snapshot.FixedIn {
+				fmt.Printf("%s\n", fix)
+			}
 		case "id":
 			fmt.Printf("%s\n", snapshot.Id())
 		case "labels":
 			var labels = make([]string, len(snapshot.Labels))
 			fmt.Printf("%s\n", strings.Join(labels, ", "))
+		case "assignee":
+			if snapshot.Assignee != nil {
+				fmt.Printf("%s\n", snapshot.Assignee.DisplayName())
+			}
+		case "alias":
+			alias, _ := backend.Alias(snapshot.Id())
+			fmt.Printf("%s\n", alias)
 		case "shortId":
 			fmt.Printf("%s\n", snapshot.HumanId())
 		case "status":
 			fmt.Printf("%s\n", snapshot.Status)
 		case "title":
 			fmt.Printf("%s\n", snapshot.Title)
+		case "visibility":
+			fmt.Printf("%s\n", snapshot.Visibility)
+		case "votes":
+			fmt.Printf("%d\n", len(snapshot.Votes))
 		default:
 			return fmt.Errorf("\nUnsupported field: %s\n", showFieldsQuery)
 		}
@@ -64,9 +108,17 @@ func runShowBug(cmd *cobra.Command, args []string) error {
 	}
 
 	// Header
+	humanId := snapshot.HumanId()
+	if alias, ok := backend.Alias(snapshot.Id()); ok && backend.IdScheme() == cache.IdSchemeSequential {
+		humanId = fmt.Sprintf("%s %s", alias, humanId)
+	}
+	if repoName != "" {
+		humanId = repoName + "/" + humanId
+	}
+
 	fmt.Printf("[%s] %s %s\n\n",
 		colors.Yellow(snapshot.Status),
-		colors.Cyan(snapshot.HumanId()),
+		colors.Cyan(humanId),
 		snapshot.Title,
 	)
 
@@ -80,10 +132,34 @@ func runShowBug(cmd *cobra.Command, args []string) error {
 		labels[i] = string(snapshot.Labels[i])
 	}
 
-	fmt.Printf("labels: %s\n\n",
+	fmt.Printf("labels: %s\n",
 		strings.Join(labels, ", "),
 	)
 
+	fmt.Printf("votes: %d\n", len(snapshot.Votes))
+
+	if snapshot.Assignee != nil {
+		fmt.Printf("assignee: %s\n", snapshot.Assignee.DisplayName())
+	}
+
+	if !snapshot.Visibility.IsPublic() {
+		fmt.Printf("visibility: %s\n", snapshot.Visibility)
+	}
+
+	if len(snapshot.FixedIn) > 0 {
+		var fixes = make([]string, len(snapshot.FixedIn))
+		for i, fix := range snapshot.FixedIn {
+			fixes[i] = fix.String()
+		}
+		fmt.Printf("fixed in: %s\n", strings.Join(fixes, ", "))
+	}
+
+	if hints := b.Hints(); len(hints) > 0 {
+		fmt.Printf("hints: %s\n", colors.Red(strings.Join(hints, ", ")))
+	}
+
+	fmt.Println()
+
 	// Comments
 	indent := "  "
 
@@ -112,14 +188,17 @@ func runShowBug(cmd *cobra.Command, args []string) error {
 }
 
 var showCmd = &cobra.Command{
-	Use:     "show [<id>]",
-	Short:   "Display the details of a bug",
-	PreRunE: loadRepo,
+	Use:   "show [<id>]",
+	Short: "Display the details of a bug",
+	Long: `Display the details of a bug.
+
+A bug of the workspace can be shown from any directory with the name of its repository followed by its id, like "project/3f5a".`,
+	PreRunE: loadRepoOrWorkspace,
 	RunE:    runShowBug,
 }
 
 func init() {
 	RootCmd.AddCommand(showCmd)
 	showCmd.Flags().StringVarP(&showFieldsQuery, "field", "f", "",
-		"Select field to display. Valid values are [author,authorEmail,createTime,id,labels,shortId,status,title]")
+		"Select field to display. Valid values are [alias,assignee,author,authorEmail,createTime,fixedIn,id,labels,shortId,status,title,visibility,votes]")
 }
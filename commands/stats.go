@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsJson bool
+)
+
+func runStats(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	stats := backend.Statistics()
+
+	if statsJson {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+
+	fmt.Printf("bugs: %d (%s open, %s closed)\n",
+		stats.Bugs,
+		colors.Green(stats.Open),
+		colors.Red(stats.Closed),
+	)
+
+	if stats.AverageTimeToClose > 0 {
+		fmt.Printf("average time to close: %s\n", formatDuration(stats.AverageTimeToClose))
+	}
+
+	printCounts("labels", stats.ByLabel)
+	printCounts("authors", stats.ByAuthor)
+
+	return nil
+}
+
+// printCounts print the counts, the highest first
+func printCounts(title string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	fmt.Printf("\n%s:\n", title)
+	for _, key := range keys {
+		fmt.Printf("%6d  %s\n", counts[key], key)
+	}
+}
+
+// formatDuration format a duration in days and hours, or minutes for the
+// shortest ones
+func formatDuration(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	hours := int(d%(24*time.Hour)) / int(time.Hour)
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, int(d%time.Hour)/int(time.Minute))
+	default:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	}
+}
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Display statistics about the bugs",
+	Long: `Display statistics about the bugs: the number of open and closed bugs, the number of bugs per label and per author, and the average time to close a bug.
+
+The statistics are computed from the bug cache, without reading the bugs.`,
+	PreRunE: loadRepo,
+	RunE:    runStats,
+}
+
+func init() {
+	RootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().SortFlags = false
+
+	statsCmd.Flags().BoolVarP(&statsJson, "json", "j", false,
+		"Output the statistics as JSON")
+}
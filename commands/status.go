@@ -10,7 +10,7 @@ import (
 )
 
 func runStatus(cmd *cobra.Command, args []string) error {
-	backend, err := cache.NewRepoCache(repo)
+	backend, err := cache.NewRepoCacheReadOnly(repo)
 	if err != nil {
 		return err
 	}
@@ -25,7 +25,7 @@ func runStatusClose(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	return b.Commit()
+	return commitAndEcho(b)
 }
 
 var closeCmd = &cobra.Command{
@@ -25,7 +25,7 @@ func runStatusOpen(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	return b.Commit()
+	return commitAndEcho(b)
 }
 
 var openCmd = &cobra.Command{
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	suggestAssigneeCount   int
+	suggestAssigneeReasons bool
+)
+
+func runSuggestAssignee(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, args, err := _select.ResolveBug(backend, args)
+	if err != nil {
+		return err
+	}
+
+	suggestions, err := backend.SuggestAssignees(b.Id(), suggestAssigneeCount)
+	if err != nil {
+		return err
+	}
+
+	if len(suggestions) == 0 {
+		fmt.Println("No suggestion, the bug doesn't reference the source code or share a label with recent bugs")
+		return nil
+	}
+
+	for _, suggestion := range suggestions {
+		fmt.Printf("%5.2f %s\n",
+			suggestion.Score,
+			colors.Magenta(suggestion.Person.DisplayName()),
+		)
+
+		if suggestAssigneeReasons {
+			for _, reason := range suggestion.Reasons {
+				fmt.Printf("      %s\n", reason)
+			}
+		}
+	}
+
+	return nil
+}
+
+var suggestAssigneeCmd = &cobra.Command{
+	Use:   "suggest-assignee [<id>]",
+	Short: "Suggest who could take care of a bug",
+	Long: `Suggest who could take care of a bug.
+
+The people are ranked according to who last modified the files referenced in the bug, like "cache/alias.go" or "cache/alias.go:42-50", and who recently worked on the bugs sharing a label with this one.`,
+	PreRunE: loadRepo,
+	RunE:    runSuggestAssignee,
+}
+
+func init() {
+	RootCmd.AddCommand(suggestAssigneeCmd)
+
+	suggestAssigneeCmd.Flags().SortFlags = false
+
+	suggestAssigneeCmd.Flags().IntVarP(&suggestAssigneeCount, "count", "n", 5,
+		"The maximum number of suggestions",
+	)
+	suggestAssigneeCmd.Flags().BoolVarP(&suggestAssigneeReasons, "reasons", "r", false,
+		"Explain each suggestion",
+	)
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	syncDryRun bool
+)
+
+func runSync(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return errors.New("Only syncing with one remote at a time is supported")
+	}
+
+	remote := "origin"
+	if len(args) == 1 {
+		remote = args[0]
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	result, err := backend.Sync(remote, syncDryRun)
+	if err != nil {
+		return err
+	}
+
+	printSyncResult(backend, result)
+
+	return nil
+}
+
+func printSyncResult(backend *cache.RepoCache, result cache.SyncResult) {
+	var created, updated, rejected int
+
+	for _, merge := range result.Merged {
+		switch merge.Status {
+		case bug.MergeStatusNew:
+			created++
+		case bug.MergeStatusUpdated:
+			updated++
+		default:
+			rejected++
+		}
+	}
+
+	verb := ""
+	if syncDryRun {
+		verb = "would be "
+	}
+
+	fmt.Printf("%d new, %d updated, %d rejected, %d %spushed\n",
+		created, updated, rejected, len(result.Pushed), verb)
+
+	for _, warning := range result.Warnings {
+		fmt.Printf("  %s\n", colors.Yellow(fmt.Sprintf("warning: %s", warning)))
+	}
+
+	for _, merge := range result.Merged {
+		var status string
+		switch merge.Status {
+		case bug.MergeStatusNew:
+			status = colors.Green("new")
+		case bug.MergeStatusUpdated:
+			status = colors.Yellow("updated")
+		default:
+			status = colors.Red(merge.String())
+		}
+
+		title := ""
+		if merge.Bug != nil {
+			title = merge.Bug.Compile().Title
+		}
+
+		fmt.Printf("  %s %s %s\n", colors.Cyan(backend.DisplayId(merge.Id)), status, title)
+	}
+
+	for _, id := range result.Diverged {
+		fmt.Printf("  %s %s\n", colors.Cyan(backend.DisplayId(id)),
+			colors.Yellow("changed on both sides, the local changes "+verb+"rebased on the remote ones"))
+	}
+
+	for _, push := range result.Pushed {
+		if syncDryRun {
+			fmt.Printf("  %s %d operation(s) to send\n", colors.Cyan(backend.DisplayId(push.Id)), push.OpCount)
+		} else {
+			fmt.Printf("  %s %s\n", colors.Cyan(backend.DisplayId(push.Id)), push)
+		}
+	}
+}
+
+var syncCmd = &cobra.Command{
+	Use:   "sync [<remote>]",
+	Short: "Pull and push bugs updates with a git remote in one go",
+	Long: `Fetch the bugs of a git remote, merge them, and push the local changes to it,
+then print a summary of the bugs created, updated, rejected and pushed.
+
+The bugs changed both locally and on the remote are reported as such: their
+local changes are rebased on top of the remote ones before being pushed.
+
+With --dry-run, the remote is fetched but nothing else is changed, locally
+or on the remote.`,
+	Example: `git bug sync
+git bug sync upstream --dry-run`,
+	PreRunE: loadRepo,
+	RunE:    runSync,
+}
+
+func init() {
+	RootCmd.AddCommand(syncCmd)
+
+	syncCmd.Flags().SortFlags = false
+
+	syncCmd.Flags().BoolVarP(&syncDryRun, "dry-run", "n", false,
+		"Only show what would be merged and pushed")
+}
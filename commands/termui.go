@@ -7,8 +7,31 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	termUIReadOnly  bool
+	termUIWorkspace bool
+)
+
 func runTermUI(cmd *cobra.Command, args []string) error {
-	backend, err := cache.NewRepoCache(repo)
+	if termUIWorkspace {
+		multi, err := openWorkspace(termUIReadOnly)
+		if err != nil {
+			return err
+		}
+		defer multi.Close()
+		interrupt.RegisterCleaner(multi.Close)
+
+		return termui.RunWorkspace(multi)
+	}
+
+	var backend *cache.RepoCache
+	var err error
+
+	if termUIReadOnly {
+		backend, err = cache.NewRepoCacheReadOnly(repo)
+	} else {
+		backend, err = cache.NewRepoCache(repo)
+	}
 	if err != nil {
 		return err
 	}
@@ -19,12 +42,26 @@ func runTermUI(cmd *cobra.Command, args []string) error {
 }
 
 var termUICmd = &cobra.Command{
-	Use:     "termui",
-	Short:   "Launch the terminal UI",
-	PreRunE: loadRepo,
+	Use:   "termui",
+	Short: "Launch the terminal UI",
+	Long: `Launch the terminal UI.
+
+With --read-only, the terminal UI can be opened while another git-bug process, like the web UI, is running. The bugs can then be browsed but not modified.
+
+With --workspace, the terminal UI operate on the repositories of the workspace, from any directory. They are shown in turn with 'w'.`,
+	PreRunE: loadRepoOrWorkspace,
 	RunE:    runTermUI,
 }
 
 func init() {
 	RootCmd.AddCommand(termUICmd)
+
+	termUICmd.Flags().SortFlags = false
+
+	termUICmd.Flags().BoolVarP(&termUIReadOnly, "read-only", "r", false,
+		"Open the bugs read-only, without locking the repository",
+	)
+	termUICmd.Flags().BoolVarP(&termUIWorkspace, "workspace", "w", false,
+		"Operate on the repositories of the workspace",
+	)
 }
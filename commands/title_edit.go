@@ -49,7 +49,7 @@ func runTitleEdit(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	return b.Commit()
+	return commitAndEcho(b)
 }
 
 var titleEditCmd = &cobra.Command{
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runTrash(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, args, err := _select.ResolveBug(backend, args)
+	if err != nil {
+		return err
+	}
+
+	err = backend.TrashBug(b.Id())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s moved to the trash\n", b.HumanId())
+
+	return nil
+}
+
+var trashCmd = &cobra.Command{
+	Use:   "trash [<id>]",
+	Short: "Soft-delete a bug",
+	Long: `Soft-delete a bug.
+
+A bug in the trash is hidden from the queries and is not pushed anymore. It is
+not merged again when pulling from a remote. It can be restored with
+"git bug trash restore" or definitely deleted with "git bug trash purge".`,
+	PreRunE: loadRepo,
+	RunE:    runTrash,
+}
+
+func init() {
+	RootCmd.AddCommand(trashCmd)
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runTrashLs(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	trashed, err := backend.ListTrashed()
+	if err != nil {
+		return err
+	}
+
+	for _, b := range trashed {
+		snapshot := b.Compile()
+
+		fmt.Printf("%s %s\t%s\n",
+			colors.Cyan(b.HumanId()),
+			colors.Yellow(snapshot.Status),
+			snapshot.Title,
+		)
+	}
+
+	return nil
+}
+
+var trashLsCmd = &cobra.Command{
+	Use:     "ls",
+	Short:   "List the bugs in the trash",
+	PreRunE: loadRepo,
+	RunE:    runTrashLs,
+}
+
+func init() {
+	trashCmd.AddCommand(trashLsCmd)
+}
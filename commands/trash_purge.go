@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runTrashPurge(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return errors.New("You must provide a bug id")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	for _, prefix := range args {
+		id, err := backend.PurgeBug(prefix)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s deleted\n", bug.FormatHumanID(id))
+	}
+
+	return nil
+}
+
+var trashPurgeCmd = &cobra.Command{
+	Use:   "purge <id>[...]",
+	Short: "Definitely delete a bug in the trash",
+	Long: `Definitely delete a bug in the trash.
+
+Note that if a remote still has this bug, it will be merged again on the next
+pull.`,
+	PreRunE: loadRepo,
+	RunE:    runTrashPurge,
+}
+
+func init() {
+	trashCmd.AddCommand(trashPurgeCmd)
+}
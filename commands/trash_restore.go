@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runTrashRestore(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return errors.New("You must provide a bug id")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	for _, prefix := range args {
+		b, err := backend.RestoreBug(prefix)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s restored\n", b.HumanId())
+	}
+
+	return nil
+}
+
+var trashRestoreCmd = &cobra.Command{
+	Use:     "restore <id>[...]",
+	Short:   "Restore a bug from the trash",
+	PreRunE: loadRepo,
+	RunE:    runTrashRestore,
+}
+
+func init() {
+	trashCmd.AddCommand(trashRestoreCmd)
+}
@@ -0,0 +1,423 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/input"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// triageDefaultQuery is the query used when none is given
+const triageDefaultQuery = "status:open sort:creation-asc"
+
+// triageMessageLines is the number of lines of the first message shown
+const triageMessageLines = 10
+
+// errTriageAborted is returned when the user abort the triage, to discard
+// the changes
+var errTriageAborted = errors.New("triage aborted, no change made")
+
+// triageChange is the changes decided for a bug, applied at the end
+type triageChange struct {
+	bug      *cache.BugCache
+	close    bool
+	added    []string
+	removed  []string
+	assign   bool
+	assignee *bug.Person
+	comments []string
+}
+
+func (c *triageChange) isEmpty() bool {
+	return !c.close && len(c.added) == 0 && len(c.removed) == 0 && !c.assign && len(c.comments) == 0
+}
+
+// String return a one line summary of the changes
+func (c *triageChange) String() string {
+	var changes []string
+	if len(c.comments) > 0 {
+		changes = append(changes, fmt.Sprintf("%d comment(s)", len(c.comments)))
+	}
+	if len(c.added) > 0 {
+		changes = append(changes, "+"+strings.Join(c.added, " +"))
+	}
+	if len(c.removed) > 0 {
+		changes = append(changes, "-"+strings.Join(c.removed, " -"))
+	}
+	if c.assign {
+		if c.assignee == nil {
+			changes = append(changes, "unassigned")
+		} else {
+			changes = append(changes, "assigned to "+c.assignee.DisplayName())
+		}
+	}
+	if c.close {
+		changes = append(changes, "closed")
+	}
+	return strings.Join(changes, ", ")
+}
+
+func runTriage(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	queryStr := triageDefaultQuery
+	if len(args) > 0 {
+		queryStr = strings.Join(args, " ")
+	}
+
+	query, err := backend.ParseQuery(queryStr)
+	if err != nil {
+		return err
+	}
+
+	ids := backend.QueryBugs(query)
+	if len(ids) == 0 {
+		fmt.Println("No bug to triage")
+		return nil
+	}
+
+	prompt := newTriagePrompt()
+	var changes []*triageChange
+
+	for i, id := range ids {
+		b, err := backend.ResolveBug(id)
+		if err != nil {
+			return err
+		}
+
+		change, quit, err := triageBug(backend, prompt, b, i+1, len(ids))
+		if err == errTriageAborted {
+			fmt.Println(err)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !change.isEmpty() {
+			changes = append(changes, change)
+		}
+		if quit {
+			break
+		}
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("No change made")
+		return nil
+	}
+
+	fmt.Printf("Applying the changes to %d bug(s)\n", len(changes))
+
+	for _, change := range changes {
+		err := applyTriageChange(change)
+		if err != nil {
+			return fmt.Errorf("bug %s: %v", change.bug.HumanId(), err)
+		}
+		fmt.Printf("%s: %s\n", colors.Cyan(backend.DisplayId(change.bug.Id())), change)
+	}
+
+	return nil
+}
+
+// triageBug show a bug and record the actions chosen for it, until the user
+// move to the next one or quit
+func triageBug(backend *cache.RepoCache, prompt *triagePrompt, b *cache.BugCache, index int, total int) (*triageChange, bool, error) {
+	change := &triageChange{bug: b}
+
+	printTriageBug(backend, b, index, total)
+
+	for {
+		if !change.isEmpty() {
+			fmt.Printf("pending: %s\n", colors.Green(change.String()))
+		}
+
+		key, err := prompt.key("[c]lose [l]abel [a]ssign [e]dit comment [s]kip [q]uit ")
+		if err != nil {
+			return nil, false, err
+		}
+
+		switch key {
+		case 'c':
+			change.close = true
+			return change, false, nil
+
+		case 's', 'n', ' ', '\r', '\n':
+			return change, false, nil
+
+		case 'q':
+			return change, true, nil
+
+		case 'l':
+			line, err := prompt.line("Labels (label to add, -label to remove): ")
+			if err != nil {
+				return nil, false, err
+			}
+			for _, field := range strings.Fields(line) {
+				if strings.HasPrefix(field, "-") {
+					change.removed = append(change.removed, strings.TrimPrefix(field, "-"))
+				} else {
+					change.added = append(change.added, strings.TrimPrefix(field, "+"))
+				}
+			}
+
+		case 'a':
+			assignee, ok, err := triageAssignee(backend, prompt, b)
+			if err != nil {
+				return nil, false, err
+			}
+			if ok {
+				change.assign = true
+				change.assignee = assignee
+			}
+
+		case 'e':
+			message, err := input.BugCommentEditorInput(repo, "")
+			if err == input.ErrEmptyMessage {
+				fmt.Println("Empty message, no comment added")
+				continue
+			}
+			if err != nil {
+				return nil, false, err
+			}
+			change.comments = append(change.comments, message)
+
+		default:
+			fmt.Printf("Unknown action %q\n", key)
+		}
+	}
+}
+
+// triageAssignee ask who to assign the bug to, among the suggestions or the
+// known identities. It return false if nothing has been chosen.
+func triageAssignee(backend *cache.RepoCache, prompt *triagePrompt, b *cache.BugCache) (*bug.Person, bool, error) {
+	suggestions, err := backend.SuggestAssignees(b.Id(), 3)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for i, suggestion := range suggestions {
+		fmt.Printf("  %d. %s\n", i+1, colors.Magenta(suggestion.Person.DisplayName()))
+	}
+
+	line, err := prompt.line("Assignee (number, name, or - to unassign): ")
+	if err != nil {
+		return nil, false, err
+	}
+	line = strings.TrimSpace(line)
+
+	if line == "" {
+		return nil, false, nil
+	}
+	if line == "-" {
+		return nil, true, nil
+	}
+	if n, err := strconv.Atoi(line); err == nil && n >= 1 && n <= len(suggestions) {
+		person := suggestions[n-1].Person
+		return &person, true, nil
+	}
+
+	identities, err := backend.QueryIdentities(line)
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch len(identities) {
+	case 0:
+		fmt.Printf("Nobody matching %q\n", line)
+		return nil, false, nil
+	case 1:
+		person := identities[0].Person()
+		return &person, true, nil
+	default:
+		fmt.Printf("Several people match %q:\n", line)
+		for _, identity := range identities {
+			fmt.Printf("  %s\n", identity.DisplayName())
+		}
+		return nil, false, nil
+	}
+}
+
+func printTriageBug(backend *cache.RepoCache, b *cache.BugCache, index int, total int) {
+	snap := b.Snapshot()
+
+	fmt.Printf("\n(%d/%d) [%s] %s %s\n",
+		index, total,
+		colors.Yellow(snap.Status),
+		colors.Cyan(backend.DisplayId(snap.Id())),
+		snap.Title,
+	)
+
+	fmt.Printf("%s opened this issue %s\n",
+		colors.Magenta(snap.Author.DisplayName()),
+		snap.Comments[0].FormatTimeRel(),
+	)
+
+	if len(snap.Labels) > 0 {
+		labels := make([]string, len(snap.Labels))
+		for i, label := range snap.Labels {
+			labels[i] = string(label)
+		}
+		fmt.Printf("labels: %s\n", strings.Join(labels, ", "))
+	}
+
+	if snap.Assignee != nil {
+		fmt.Printf("assignee: %s\n", snap.Assignee.DisplayName())
+	}
+
+	if hints := b.Hints(); len(hints) > 0 {
+		fmt.Printf("hints: %s\n", colors.Red(strings.Join(hints, ", ")))
+	}
+
+	fmt.Println()
+
+	lines := strings.Split(snap.Comments[0].Message, "\n")
+	if len(lines) > triageMessageLines {
+		lines = append(lines[:triageMessageLines], colors.GreyBold("[...]"))
+	}
+	for _, line := range lines {
+		fmt.Printf("  %s\n", line)
+	}
+
+	if len(snap.Comments) > 1 {
+		fmt.Printf("\n  %s\n", colors.GreyBold(fmt.Sprintf("%d more comment(s)", len(snap.Comments)-1)))
+	}
+
+	fmt.Println()
+}
+
+// applyTriageChange record the changes of a bug and commit them
+func applyTriageChange(change *triageChange) error {
+	b := change.bug
+
+	for _, message := range change.comments {
+		err := b.AddComment(message)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(change.added) > 0 || len(change.removed) > 0 {
+		results, err := b.ChangeLabels(change.added, change.removed)
+		// the results are only given back for an error if the labels were
+		// already as asked, which is fine
+		if err != nil && len(results) == 0 {
+			return err
+		}
+	}
+
+	if change.assign {
+		err := b.SetAssignee(change.assignee)
+		if err != nil {
+			return err
+		}
+	}
+
+	if change.close && b.Snapshot().Status != bug.ClosedStatus {
+		err := b.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.CommitAsNeeded()
+}
+
+// triagePrompt read the answers of the user, a single key at a time when
+// the standard input is a terminal, or a line at a time otherwise
+type triagePrompt struct {
+	reader   *bufio.Reader
+	terminal bool
+}
+
+func newTriagePrompt() *triagePrompt {
+	return &triagePrompt{
+		reader:   bufio.NewReader(os.Stdin),
+		terminal: terminal.IsTerminal(int(os.Stdin.Fd())),
+	}
+}
+
+// key read a single key. Ctrl+C abort the triage.
+func (p *triagePrompt) key(question string) (byte, error) {
+	fmt.Print(question)
+
+	if !p.terminal {
+		line, err := p.line("")
+		if err != nil {
+			return 0, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return '\n', nil
+		}
+		return line[0], nil
+	}
+
+	state, err := terminal.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return 0, err
+	}
+	key, err := p.reader.ReadByte()
+	_ = terminal.Restore(int(os.Stdin.Fd()), state)
+	fmt.Println()
+
+	if err == io.EOF || key == 3 {
+		return 0, errTriageAborted
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return key, nil
+}
+
+// line read a whole line
+func (p *triagePrompt) line(question string) (string, error) {
+	fmt.Print(question)
+
+	line, err := p.reader.ReadString('\n')
+	if err == io.EOF && line == "" {
+		return "", errTriageAborted
+	}
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+var triageCmd = &cobra.Command{
+	Use:   "triage [<query>]",
+	Short: "Go through the matching bugs one by one to triage them",
+	Long: `Go through the bugs matching a query one by one, the oldest open bugs by
+default, and choose what to do with each of them with a single key: close it,
+change its labels, assign it, comment it in the editor, or skip it.
+
+The changes are recorded as you go and committed all together at the end,
+when all the bugs have been seen or when quitting with "q". Ctrl+C aborts the
+triage without changing anything.`,
+	Example: `git bug triage
+git bug triage no:label
+git bug triage status:open no:assignee sort:votes`,
+	PreRunE: loadRepo,
+	RunE:    runTriage,
+}
+
+func init() {
+	RootCmd.AddCommand(triageCmd)
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runUser(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return errors.New("only one identity id can be displayed")
+	}
+
+	var identity *cache.IdentityExcerpt
+
+	if len(args) == 0 {
+		user, err := bug.GetUser(repo)
+		if err != nil {
+			return err
+		}
+		identity = cache.NewIdentityExcerpt(user)
+	} else {
+		backend, err := cache.NewRepoCacheReadOnly(repo)
+		if err != nil {
+			return err
+		}
+		defer backend.Close()
+		interrupt.RegisterCleaner(backend.Close)
+
+		identity, err = backend.ResolveIdentityPrefix(args[0])
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Id: %s\n", identity.Id)
+	fmt.Printf("Name: %s\n", identity.Name)
+	fmt.Printf("Email: %s\n", identity.Email)
+	if identity.Login != "" {
+		fmt.Printf("Login: %s\n", identity.Login)
+	}
+	if identity.AvatarUrl != "" {
+		fmt.Printf("Avatar: %s\n", identity.AvatarUrl)
+	}
+
+	return nil
+}
+
+var userCmd = &cobra.Command{
+	Use:   "user [<id>]",
+	Short: "Display or list the identities",
+	Long: `Display an identity, given a prefix of its id, or the current user if no id is given.
+
+The identities are the authors of the operations on the bugs of the repository.`,
+	PreRunE: loadRepo,
+	RunE:    runUser,
+}
+
+func init() {
+	RootCmd.AddCommand(userCmd)
+}
@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/input"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var (
+	userAdoptReset bool
+)
+
+func runUserAdopt(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return errors.New("only one identity can be adopted")
+	}
+
+	if userAdoptReset {
+		if len(args) > 0 {
+			return errors.New("an identity can't be given with --reset")
+		}
+
+		err := bug.ResetUser(repo)
+		if err != nil {
+			return err
+		}
+
+		user, err := bug.GetUser(repo)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Back to the identity configured in git: %s\n", formatPerson(user))
+		return nil
+	}
+
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	var identity *cache.IdentityExcerpt
+
+	if len(args) == 1 {
+		identity, err = backend.ResolveIdentityPrefix(args[0])
+		if err != nil {
+			return err
+		}
+	} else {
+		identity, err = proposeIdentity(backend)
+		if err != nil || identity == nil {
+			return err
+		}
+	}
+
+	err = bug.SetUser(repo, identity.Person())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Adopted the identity %s %s\n", colors.Cyan(identity.HumanId()), formatPerson(identity.Person()))
+
+	return nil
+}
+
+// proposeIdentity look for the identities having the email configured in
+// git, and ask the user to confirm the best one. It return nil if the user
+// declined.
+func proposeIdentity(backend *cache.RepoCache) (*cache.IdentityExcerpt, error) {
+	email, err := repo.GetUserEmail()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := backend.IdentitiesByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no identity with the email \"%s\", give the id of the identity to adopt (see \"git bug user ls\")", email)
+	}
+
+	if len(candidates) > 1 || !terminal.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Printf("Identities with the email %s:\n", email)
+		for _, candidate := range candidates {
+			fmt.Printf("%s %s\n", colors.Cyan(candidate.HumanId()), formatPerson(candidate.Person()))
+		}
+		return nil, errors.New("give the id of the identity to adopt")
+	}
+
+	candidate := candidates[0]
+
+	ok, err := input.PromptYesNo(fmt.Sprintf("Adopt the identity %s %s?",
+		colors.Cyan(candidate.HumanId()), formatPerson(candidate.Person())))
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return candidate, nil
+}
+
+// formatPerson display a person with its email, if any
+func formatPerson(p bug.Person) string {
+	if p.Email == "" {
+		return p.DisplayName()
+	}
+	return fmt.Sprintf("%s <%s>", p.DisplayName(), p.Email)
+}
+
+var userAdoptCmd = &cobra.Command{
+	Use:   "adopt [<id>]",
+	Short: "Take over an existing identity as your own",
+	Long: `Make an existing identity, like one imported by a bridge or used on another computer, the author of your next changes, instead of the identity built from the user.name and user.email of git.
+
+Without id, the identity having the email configured in git is proposed.
+
+The adopted identity is stored in the git-bug configuration of the repository, the git commits are not affected.`,
+	Example: `git bug user adopt
+git bug user adopt 3f5a2c1
+git bug user adopt --reset`,
+	PreRunE: loadRepo,
+	RunE:    runUserAdopt,
+}
+
+func init() {
+	userCmd.AddCommand(userAdoptCmd)
+
+	userAdoptCmd.Flags().SortFlags = false
+
+	userAdoptCmd.Flags().BoolVarP(&userAdoptReset, "reset", "r", false,
+		"Go back to the identity configured in git",
+	)
+}
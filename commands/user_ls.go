@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runUserLs(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	identities, err := backend.QueryIdentities(strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+
+	for _, identity := range identities {
+		fmt.Printf("%s %s\t%s\n",
+			colors.Cyan(identity.HumanId()),
+			identity.DisplayName(),
+			colors.Yellow(identity.Email),
+		)
+	}
+
+	return nil
+}
+
+var userLsCmd = &cobra.Command{
+	Use:   "ls [<query>]",
+	Short: "List or search the identities",
+	Long: `List the identities, optionally filtered by a query.
+
+A query is a list of terms that must all match. A term can be restricted to a
+field with "name:", "email:" or "login:", otherwise it can match any of them.`,
+	Example: `git bug user ls
+git bug user ls name:rene
+git bug user ls email:example.com`,
+	PreRunE: loadRepo,
+	RunE:    runUserLs,
+}
+
+func init() {
+	userCmd.AddCommand(userLsCmd)
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	verifyJson bool
+)
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	var report bug.VerifyReport
+	var err error
+
+	if len(args) == 1 {
+		remote := args[0]
+
+		remoteRepo, ok := repo.(repository.RemoteRepo)
+		if !ok {
+			return errors.New("the repository can't list its remotes")
+		}
+		var remotes []string
+		remotes, err = remoteRepo.ListRemotes()
+		if err != nil {
+			return err
+		}
+		if !contains(remotes, remote) {
+			return fmt.Errorf("unknown remote %s", remote)
+		}
+
+		report, err = bug.VerifyRemoteBugs(repo, remote)
+	} else {
+		report, err = bug.VerifyLocalBugs(repo)
+	}
+	if err != nil {
+		return err
+	}
+
+	if verifyJson {
+		if report.Problems == nil {
+			report.Problems = []bug.VerifyProblem{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		for _, problem := range report.Problems {
+			fmt.Printf("%s %s\n", colors.Red("[invalid]"), problem)
+		}
+	}
+
+	if len(report.Problems) > 0 {
+		return fmt.Errorf("%d problem(s) found in %d bug(s) checked", len(report.Problems), report.Checked)
+	}
+
+	if !verifyJson {
+		fmt.Printf("%d bugs checked, no problem found\n", report.Checked)
+	}
+
+	return nil
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [<remote>]",
+	Short: "Check the data of all the bugs for corrupted or forged entries",
+	Long: `Check the data of all the bugs for corrupted or forged entries.
+
+Every commit of every bug is read and checked: the operations must be valid,
+their authors well formed and the lamport clocks increasing along the history
+of the bug. The signature of the policy is verified as well, unless
+git-bug.policy-require-signature is set to false.
+
+With a remote, the bugs and the policy fetched from this remote are checked
+instead of the local ones. With the refspecs of "git bug remote setup", a plain
+git fetch retrieve the bugs without merging them: check them before running
+"git bug pull". Reading the data doesn't update the local clocks.
+
+The command fails when a problem is found.`,
+	Example: `git bug verify
+git bug verify origin --json`,
+	PreRunE: loadRepo,
+	RunE:    runVerify,
+	Args:    cobra.MaximumNArgs(1),
+}
+
+func init() {
+	RootCmd.AddCommand(verifyCmd)
+
+	verifyCmd.Flags().SortFlags = false
+
+	verifyCmd.Flags().BoolVarP(&verifyJson, "json", "j", false,
+		"Output the report as JSON")
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runVisibility(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCacheReadOnly(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, args, err := _select.ResolveBug(backend, args)
+	if err != nil {
+		return err
+	}
+
+	snap := b.Snapshot()
+
+	fmt.Println(snap.Visibility)
+
+	return nil
+}
+
+var visibilityCmd = &cobra.Command{
+	Use:   "visibility [<id>]",
+	Short: "Display or change the audience allowed to see a bug",
+	Long: `Display or change the audience allowed to see a bug.
+
+A bug can be public (the default), internal or restricted to a team
+(team-<name>). The web UI only expose the bugs visible to its audience,
+configured with:
+
+	git config git-bug.webui-audience "internal,team-backend"
+
+Note that anyone with a clone of the repository can read all the bugs.`,
+	PreRunE: loadRepo,
+	RunE:    runVisibility,
+}
+
+func init() {
+	RootCmd.AddCommand(visibilityCmd)
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runVisibilitySet(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, args, err := _select.ResolveBug(backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return errors.New("You must provide a visibility")
+	}
+
+	visibility, err := bug.VisibilityFromString(args[0])
+	if err != nil {
+		return err
+	}
+
+	err = b.SetVisibility(visibility)
+	if err != nil {
+		return err
+	}
+
+	return commitAndEcho(b)
+}
+
+var visibilitySetCmd = &cobra.Command{
+	Use:     "set [<id>] <visibility>",
+	Short:   "Set the audience allowed to see a bug: public, internal or team-<name>",
+	PreRunE: loadRepo,
+	RunE:    runVisibilitySet,
+}
+
+func init() {
+	visibilityCmd.AddCommand(visibilitySetCmd)
+}
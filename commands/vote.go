@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runVote(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, args, err := _select.ResolveBug(backend, args)
+	if err != nil {
+		return err
+	}
+
+	err = b.AddVote()
+	if err != nil {
+		return err
+	}
+
+	return commitAndEcho(b)
+}
+
+var voteCmd = &cobra.Command{
+	Use:     "vote [<id>]",
+	Short:   "Vote for a bug",
+	PreRunE: loadRepo,
+	RunE:    runVote,
+}
+
+func init() {
+	RootCmd.AddCommand(voteCmd)
+}
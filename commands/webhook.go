@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/spf13/cobra"
+)
+
+func runWebhook(cmd *cobra.Command, args []string) error {
+	webhooks, err := cache.ReadWebhooks(repo)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		events := "all events"
+		if len(webhook.Events) > 0 {
+			kinds := make([]string, len(webhook.Events))
+			for i, kind := range webhook.Events {
+				kinds[i] = string(kind)
+			}
+			events = strings.Join(kinds, ",")
+		}
+
+		signed := "unsigned"
+		if webhook.Secret != "" {
+			signed = "signed"
+		}
+
+		fmt.Printf("%s\t%s\t%s\t%s\n", colors.Cyan(webhook.Name), webhook.URL, events, signed)
+	}
+
+	return nil
+}
+
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "List, add, remove or test the webhooks",
+	Long: `List, add, remove or test the webhooks.
+
+A webhook is an URL receiving the same events as the hooks (see git bug hooks)
+as a JSON POST request, after each matching mutation of a bug. The kind of the
+event is in the X-Git-Bug-Event header, and a random id in the
+X-Git-Bug-Delivery header. When the webhook has a secret, the payload is
+signed in the X-Git-Bug-Signature header, as "sha256=" followed by the hex
+encoded HMAC-SHA256 of the payload keyed with the secret.
+
+The deliveries happen in the background. A failed delivery, either a network
+error or a status other than 2xx, is retried a few times with a growing delay,
+with the same delivery id. A command exiting waits a few seconds for the
+pending deliveries.
+
+The webhooks are stored in the git config of the repository, under
+git-bug.webhook.<name>.`,
+	PreRunE: loadRepo,
+	RunE:    runWebhook,
+}
+
+func init() {
+	RootCmd.AddCommand(webhookCmd)
+}
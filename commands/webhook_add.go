@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/spf13/cobra"
+)
+
+var (
+	webhookSecret string
+	webhookEvents string
+)
+
+func runWebhookAdd(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return errors.New("You must provide a name and an url")
+	}
+
+	events, err := cache.ParseHookKinds(webhookEvents)
+	if err != nil {
+		return err
+	}
+
+	return cache.AddWebhook(repo, cache.Webhook{
+		Name:   args[0],
+		URL:    args[1],
+		Secret: webhookSecret,
+		Events: events,
+	})
+}
+
+var webhookAddCmd = &cobra.Command{
+	Use:   "add <name> <url>",
+	Short: "Add a webhook receiving the events of the bugs",
+	Example: `git bug webhook add chat https://chat.example.com/hooks/x3b --events bug-created,comment-added
+git bug webhook add ci https://ci.example.com/trigger --secret "$CI_SECRET"`,
+	PreRunE: loadRepo,
+	RunE:    runWebhookAdd,
+}
+
+func init() {
+	webhookCmd.AddCommand(webhookAddCmd)
+
+	webhookAddCmd.Flags().SortFlags = false
+
+	webhookAddCmd.Flags().StringVar(&webhookSecret, "secret", "",
+		"The secret signing the payloads")
+	webhookAddCmd.Flags().StringVar(&webhookEvents, "events", "",
+		"The comma separated kinds of events sent, among bug-created, bug-updated, status-changed, comment-added, merge-applied and sync-failed (default: all)")
+}
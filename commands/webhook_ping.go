@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/spf13/cobra"
+)
+
+func runWebhookTest(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("You must provide a name")
+	}
+
+	webhooks, err := cache.ReadWebhooks(repo)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.Name != args[0] {
+			continue
+		}
+
+		err := webhook.Deliver(context.Background(), cache.HookEvent{Kind: cache.HookTest})
+		if err != nil {
+			return fmt.Errorf("delivery to %s failed: %v", webhook.URL, err)
+		}
+
+		fmt.Printf("test event delivered to %s\n", webhook.URL)
+		return nil
+	}
+
+	return fmt.Errorf("no webhook named %s", args[0])
+}
+
+var webhookTestCmd = &cobra.Command{
+	Use:   "test <name>",
+	Short: "Send a test event to a webhook",
+	Long: `Send a test event to a webhook, once, and report the result.
+
+The event has the kind "test" and no bug.`,
+	PreRunE: loadRepo,
+	RunE:    runWebhookTest,
+}
+
+func init() {
+	webhookCmd.AddCommand(webhookTestCmd)
+}
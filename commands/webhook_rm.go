@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/spf13/cobra"
+)
+
+func runWebhookRm(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("You must provide a name")
+	}
+
+	return cache.RemoveWebhook(repo, args[0])
+}
+
+var webhookRmCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Short:   "Remove a webhook",
+	PreRunE: loadRepo,
+	RunE:    runWebhookRm,
+}
+
+func init() {
+	webhookCmd.AddCommand(webhookRmCmd)
+}
@@ -7,43 +7,81 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/99designs/gqlgen/handler"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/graphql"
+	"github.com/MichaelMure/git-bug/graphql/resolvers"
 	"github.com/MichaelMure/git-bug/repository"
 	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/MichaelMure/git-bug/util/systemd"
 	"github.com/MichaelMure/git-bug/webui"
+	"github.com/MichaelMure/git-bug/workspace"
 	"github.com/gorilla/mux"
 	"github.com/phayes/freeport"
 	"github.com/skratchdot/open-golang/open"
 	"github.com/spf13/cobra"
 )
 
-var port int
+var (
+	port           int
+	webUINoOpen    bool
+	webUIWarm      int
+	webUIWorkspace bool
+	webUIRoot      string
+	webUIServe     []string
+)
 
 func runWebUI(cmd *cobra.Command, args []string) error {
-	if port == 0 {
-		var err error
-		port, err = freeport.GetFreePort()
-		if err != nil {
-			return err
-		}
+	listener, activated, err := webUIListener()
+	if err != nil {
+		return err
 	}
 
-	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	addr := listener.Addr().String()
 	webUiAddr := fmt.Sprintf("http://%s", addr)
 
 	router := mux.NewRouter()
 
-	graphqlHandler, err := graphql.NewHandler(repo)
+	repos, err := webUIRepositories()
 	if err != nil {
 		return err
 	}
 
+	var graphqlHandler graphql.Handler
+	if repos == nil {
+		graphqlHandler, err = graphql.NewHandler(repo)
+		repos = map[string]repository.ClockedRepo{"": repo}
+	} else {
+		graphqlHandler, err = graphql.NewMultiRepoHandler(repos)
+	}
+	if err != nil {
+		return err
+	}
+
+	if webUIWarm > 0 {
+		for _, name := range graphqlHandler.Names() {
+			backend, err := graphqlHandler.ResolveRepo(name)
+			if err != nil {
+				return err
+			}
+
+			_, err = backend.Warm(webUIWarm, nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	assetsHandler := &fileSystemWithDefault{
 		FileSystem:  webui.WebUIAssets,
 		defaultFile: "index.html",
@@ -52,25 +90,50 @@ func runWebUI(cmd *cobra.Command, args []string) error {
 	// Routes
 	router.Path("/playground").Handler(handler.Playground("git-bug", "/graphql"))
 	router.Path("/graphql").Handler(graphqlHandler)
-	router.Path("/gitfile/{hash}").Handler(newGitFileHandler(repo))
-	router.Path("/upload").Methods("POST").Handler(newGitUploadFileHandler(repo))
+	router.Path("/healthz").Handler(graphqlHandler.HealthHandler())
+	router.Path("/readyz").Handler(graphqlHandler.ReadyHandler())
+	router.Path("/api/media/{hash}").Handler(newMediaHandler(graphqlHandler))
+	// the attachments are referenced this way in the messages
+	router.Path("/gitfile/{hash}").Handler(newMediaHandler(graphqlHandler))
+	router.Path("/upload").Methods("POST").Handler(newGitUploadFileHandler(repos, graphqlHandler.Limits.MaxUploadSize))
 	router.PathPrefix("/").Handler(http.FileServer(assetsHandler))
 
+	var configs []repository.RepoCommon
+	for _, repo := range repos {
+		configs = append(configs, repo)
+	}
+
+	auth, err := graphql.NewAuth(configs...)
+	if err != nil {
+		return err
+	}
+
 	srv := &http.Server{
-		Addr:    addr,
-		Handler: router,
+		Handler: graphqlHandler.Limits.RateLimiter(auth.Middleware(router)),
 	}
 
+	// pick up the bugs pushed to this repository while running
+	stopWatch := make(chan struct{})
+	go func() {
+		err := graphqlHandler.Watch(cache.DefaultWatchInterval, stopWatch)
+		if err != nil {
+			fmt.Printf("Error while watching the repository: %v\n", err)
+		}
+	}()
+
 	done := make(chan bool)
 	quit := make(chan os.Signal, 1)
 
-	// register as handler of the interrupt signal to trigger the teardown
-	signal.Notify(quit, os.Interrupt)
+	// register as handler of the interrupt and termination signals to trigger
+	// the teardown
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
 		<-quit
 		fmt.Println("WebUI is shutting down...")
 
+		_ = systemd.Notify("STOPPING=1")
+
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
@@ -79,7 +142,10 @@ func runWebUI(cmd *cobra.Command, args []string) error {
 			log.Fatalf("Could not gracefully shutdown the WebUI: %v\n", err)
 		}
 
-		// Teardown
+		close(stopWatch)
+
+		// Teardown: in-flight requests are drained, now flush the caches and
+		// release the locks
 		err := graphqlHandler.Close()
 		if err != nil {
 			fmt.Println(err)
@@ -88,17 +154,32 @@ func runWebUI(cmd *cobra.Command, args []string) error {
 		close(done)
 	}()
 
+	if auth.Enabled() {
+		fmt.Println("Access restricted to the tokens of \"git bug webui token\", open the web UI with ?token=<token>")
+	}
+
+	if names := graphqlHandler.Names(); len(names) > 1 || names[0] != "" {
+		fmt.Printf("Repositories: %s\n", strings.Join(names, ", "))
+	}
+
 	fmt.Printf("Web UI: %s\n", webUiAddr)
 	fmt.Printf("Graphql API: http://%s/graphql\n", addr)
 	fmt.Printf("Graphql Playground: http://%s/playground\n", addr)
 	fmt.Println("Press Ctrl+c to quit")
 
-	err = open.Run(webUiAddr)
+	if !webUINoOpen && !activated {
+		err = open.Run(webUiAddr)
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	err = systemd.Notify("READY=1")
 	if err != nil {
 		fmt.Println(err)
 	}
 
-	err = srv.ListenAndServe()
+	err = srv.Serve(listener)
 	if err != nil && err != http.ErrServerClosed {
 		return err
 	}
@@ -109,6 +190,94 @@ func runWebUI(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// webUIRepositories return the repositories to serve by name, given with
+// --workspace, --root and --serve, or nil to serve the repository of the
+// current directory alone
+func webUIRepositories() (map[string]repository.ClockedRepo, error) {
+	if !webUIWorkspace && webUIRoot == "" && len(webUIServe) == 0 {
+		return nil, nil
+	}
+
+	ws := &workspace.Workspace{Repos: make(map[string]string)}
+
+	if webUIWorkspace {
+		loaded, err := workspace.Load()
+		if err != nil {
+			return nil, err
+		}
+		ws = loaded
+	}
+
+	if webUIRoot != "" {
+		discovered, err := workspace.Discover(webUIRoot)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range discovered.Names() {
+			if err := ws.Add(name, discovered.Repos[name]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, arg := range webUIServe {
+		split := strings.SplitN(arg, "=", 2)
+		if len(split) != 2 {
+			return nil, fmt.Errorf("invalid repository %q, expected <name>=<path>", arg)
+		}
+
+		path, err := filepath.Abs(split[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if err := ws.Add(split[0], path); err != nil {
+			return nil, err
+		}
+	}
+
+	return ws.OpenRepos()
+}
+
+// loadWebUIRepo load the repository of the current directory, unless other
+// repositories are given to serve
+func loadWebUIRepo(cmd *cobra.Command, args []string) error {
+	for _, flag := range []string{"workspace", "root", "serve"} {
+		if cmd.Flags().Lookup(flag).Changed {
+			return nil
+		}
+	}
+
+	return loadRepo(cmd, args)
+}
+
+// webUIListener return the socket passed by systemd if the process is socket
+// activated, or a new socket on localhost otherwise
+func webUIListener() (net.Listener, bool, error) {
+	listeners, err := systemd.Listeners()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(listeners) > 0 {
+		return listeners[0], true, nil
+	}
+
+	if port == 0 {
+		port, err = freeport.GetFreePort()
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return nil, false, err
+	}
+
+	return listener, false, nil
+}
+
 // implement a http.FileSystem that will serve a default file when the looked up
 // file doesn't exist. Useful for Single-Page App that implement routing client
 // side, where the server has to return the root index.html file for every route.
@@ -125,18 +294,22 @@ func (fswd *fileSystemWithDefault) Open(name string) (http.File, error) {
 	return f, err
 }
 
-// implement a http.Handler that will read and server git blob.
-type gitFileHandler struct {
-	repo repository.Repo
+// implement a http.Handler serving the files attached to the bugs, so that
+// the images render inline in the web UI. As a file is stored under the hash
+// of its content, it never change and can be cached forever.
+// Only the files attached to a bug visible by the audience of its repository
+// are served, the other blobs of the repositories being reported as not found.
+type mediaHandler struct {
+	handler graphql.Handler
 }
 
-func newGitFileHandler(repo repository.Repo) http.Handler {
-	return &gitFileHandler{
-		repo: repo,
+func newMediaHandler(handler graphql.Handler) http.Handler {
+	return &mediaHandler{
+		handler: handler,
 	}
 }
 
-func (gfh *gitFileHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+func (mh *mediaHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	hash := git.Hash(mux.Vars(r)["hash"])
 
 	if !hash.IsValid() {
@@ -144,36 +317,132 @@ func (gfh *gitFileHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	backend, err := mh.resolveRepo(r.Context(), hash)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if backend == nil {
+		http.Error(rw, "file not found", http.StatusNotFound)
+		return
+	}
+
+	etag := fmt.Sprintf(`"%s"`, hash)
+	rw.Header().Set("ETag", etag)
+	rw.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+
+	if r.Header.Get("If-None-Match") == etag {
+		rw.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	// TODO: this mean that the whole file will he buffered in memory
 	// This can be a problem for big files. There might be a way around
 	// that by implementing a io.ReadSeeker that would read and discard
 	// data when a seek is called.
-	data, err := gfh.repo.ReadData(git.Hash(hash))
+	data, err := backend.ReadAttachment(hash)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		http.Error(rw, "file not found", http.StatusNotFound)
 		return
 	}
 
-	http.ServeContent(rw, r, "", time.Now(), bytes.NewReader(data))
+	contentType := mediaContentType(data)
+
+	rw.Header().Set("Content-Type", contentType)
+	// never run what an attached file could contain, like the scripts of an
+	// HTML page or a SVG image
+	rw.Header().Set("X-Content-Type-Options", "nosniff")
+	rw.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	if !mediaInline(contentType) {
+		rw.Header().Set("Content-Disposition", "attachment")
+	}
+
+	http.ServeContent(rw, r, "", time.Time{}, bytes.NewReader(data))
+}
+
+// resolveRepo return the first repository readable by the user of the
+// request having the file attached to a bug visible by its audience, or nil
+// if there is none
+func (mh *mediaHandler) resolveRepo(ctx context.Context, hash git.Hash) (*cache.RepoCache, error) {
+	for _, name := range mh.handler.Names() {
+		backend, err := mh.handler.ResolveRepo(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !resolvers.CanRead(ctx, backend.GetPath()) {
+			continue
+		}
+
+		if backend.AttachmentVisible(hash, mh.handler.Audience(name)) {
+			return backend, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// mediaContentType sniff the type of an attached file. SVG images are
+// recognized on top of what http.DetectContentType does.
+func mediaContentType(data []byte) string {
+	contentType := http.DetectContentType(data)
+
+	if strings.HasPrefix(contentType, "text/xml") || strings.HasPrefix(contentType, "text/plain") {
+		head := data
+		if len(head) > 512 {
+			head = head[:512]
+		}
+		if bytes.Contains(head, []byte("<svg")) {
+			return "image/svg+xml"
+		}
+	}
+
+	return contentType
+}
+
+// mediaInline tell if a type of file is displayed by the browsers, the
+// others are downloaded
+func mediaInline(contentType string) bool {
+	for _, prefix := range []string{"image/", "video/", "audio/", "text/plain", "application/pdf"} {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
 }
 
 // implement a http.Handler that will accept and store content into git blob.
 type gitUploadFileHandler struct {
-	repo repository.Repo
+	repos map[string]repository.ClockedRepo
+	// maxSize is the largest file accepted, 0 for no limit
+	maxSize int64
 }
 
-func newGitUploadFileHandler(repo repository.Repo) http.Handler {
+func newGitUploadFileHandler(repos map[string]repository.ClockedRepo, maxSize int64) http.Handler {
 	return &gitUploadFileHandler{
-		repo: repo,
+		repos:   repos,
+		maxSize: maxSize,
 	}
 }
 
 func (gufh *gitUploadFileHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
-	// 100MB (github limit)
-	var maxUploadSize int64 = 100 * 1000 * 1000
-	r.Body = http.MaxBytesReader(rw, r.Body, maxUploadSize)
-	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
-		http.Error(rw, "file too big (100MB max)", http.StatusBadRequest)
+	// the files are kept in memory up to this size, the rest go to temporary
+	// files
+	var maxMemory int64 = 32 << 20
+
+	if gufh.maxSize > 0 {
+		r.Body = http.MaxBytesReader(rw, r.Body, gufh.maxSize)
+		if gufh.maxSize < maxMemory {
+			maxMemory = gufh.maxSize
+		}
+	}
+
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		if gufh.maxSize > 0 {
+			http.Error(rw, fmt.Sprintf("file too big (%d bytes max)", gufh.maxSize), http.StatusRequestEntityTooLarge)
+		} else {
+			http.Error(rw, "invalid upload", http.StatusBadRequest)
+		}
 		return
 	}
 
@@ -196,7 +465,24 @@ func (gufh *gitUploadFileHandler) ServeHTTP(rw http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	hash, err := gufh.repo.StoreData(fileBytes)
+	// the repository is chosen with the "repo" field when several are served
+	repo, ok := gufh.repos[r.FormValue("repo")]
+	if !ok && len(gufh.repos) == 1 {
+		for _, repo = range gufh.repos {
+		}
+		ok = true
+	}
+	if !ok {
+		http.Error(rw, "unknown repository", http.StatusBadRequest)
+		return
+	}
+
+	if !resolvers.CanWrite(r.Context(), repo.GetPath()) {
+		http.Error(rw, "read-only access", http.StatusForbidden)
+		return
+	}
+
+	hash, err := bug.StoreMedia(repo, fileBytes)
 	if err != nil {
 		http.Error(rw, err.Error(), http.StatusInternalServerError)
 		return
@@ -223,9 +509,28 @@ func (gufh *gitUploadFileHandler) ServeHTTP(rw http.ResponseWriter, r *http.Requ
 }
 
 var webUICmd = &cobra.Command{
-	Use:     "webui",
-	Short:   "Launch the web UI",
-	PreRunE: loadRepo,
+	Use:   "webui",
+	Short: "Launch the web UI",
+	Long: `Launch the web UI.
+
+The web UI support the systemd socket activation: when started with a socket
+passed by systemd, the --port flag is ignored and this socket is used instead.
+
+The bugs pushed to the repository while the web UI is running are picked up
+automatically.
+
+With --workspace, --root or --serve, the web UI serve several repositories
+instead of the one of the current directory. The GraphQL API then access them
+with repository(ref: "name"), and list them with repositories.
+
+Once a token is added with "git bug webui token add", the web UI is only
+accessible with a token. See "git bug webui token".
+
+Before exposing the web UI publicly, consider limiting the rate of the
+requests and the size of the queries and uploads, with the git-bug.webui-*
+settings of the git config (rate-limit, max-request-size, max-query-depth,
+max-query-complexity and max-upload-size).`,
+	PreRunE: loadWebUIRepo,
 	RunE:    runWebUI,
 }
 
@@ -235,4 +540,9 @@ func init() {
 	webUICmd.Flags().SortFlags = false
 
 	webUICmd.Flags().IntVarP(&port, "port", "p", 0, "Port to listen to")
+	webUICmd.Flags().BoolVar(&webUINoOpen, "no-open", false, "Don't open the web UI in the default browser")
+	webUICmd.Flags().IntVar(&webUIWarm, "warm", 0, "Compile this number of recently edited bugs before serving")
+	webUICmd.Flags().BoolVar(&webUIWorkspace, "workspace", false, "Serve the repositories of the workspace")
+	webUICmd.Flags().StringVar(&webUIRoot, "root", "", "Serve the git repositories found directly under this directory, named after their directory")
+	webUICmd.Flags().StringArrayVar(&webUIServe, "serve", nil, "Serve a repository, given as <name>=<path>. Can be repeated.")
 }
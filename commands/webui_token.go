@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/graphql"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/spf13/cobra"
+)
+
+func runWebUIToken(cmd *cobra.Command, args []string) error {
+	tokens, err := graphql.ReadTokens(repo)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		author := "repository user"
+		if token.Identity != nil {
+			author = token.Identity.DisplayName()
+			if token.Identity.Email != "" {
+				author = fmt.Sprintf("%s <%s>", author, token.Identity.Email)
+			}
+		}
+
+		fmt.Printf("%s\t%s\t%s\n", colors.Cyan(token.Name), token.Role, author)
+	}
+
+	return nil
+}
+
+var webUITokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "List, add or remove the tokens giving access to the web UI",
+	Long: `List, add or remove the tokens giving access to the web UI.
+
+As long as no token exist, the web UI is accessible to everybody able to
+connect to it, and the changes are made as the user of the repository. Once a
+token is added, every request need a valid token, given either as a
+"Authorization: Bearer <token>" header, or by opening the web UI once with
+?token=<token> to store it in a cookie of the browser.
+
+A token has a role, "read" or "write", and optionally an identity authoring
+the changes made with it. The tokens are stored hashed in the git config of
+the repository, under git-bug.webui-token.<name>.`,
+	PreRunE: loadRepo,
+	RunE:    runWebUIToken,
+}
+
+func init() {
+	webUICmd.AddCommand(webUITokenCmd)
+}
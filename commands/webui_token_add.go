@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/graphql"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	webUITokenReadOnly bool
+	webUITokenIdentity string
+)
+
+func runWebUITokenAdd(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("You must provide a name")
+	}
+
+	var identity *bug.Person
+
+	if webUITokenIdentity != "" {
+		backend, err := cache.NewRepoCacheReadOnly(repo)
+		if err != nil {
+			return err
+		}
+		defer backend.Close()
+		interrupt.RegisterCleaner(backend.Close)
+
+		excerpt, err := backend.ResolveIdentityPrefix(webUITokenIdentity)
+		if err != nil {
+			return err
+		}
+
+		person := excerpt.Person()
+		identity = &person
+	}
+
+	role := graphql.RoleWrite
+	if webUITokenReadOnly {
+		role = graphql.RoleRead
+	}
+
+	secret, err := graphql.AddToken(repo, args[0], role, identity)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(secret)
+
+	return nil
+}
+
+var webUITokenAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a token giving access to the web UI, and print it",
+	Long: `Add a token giving access to the web UI, and print it.
+
+The token is only shown once, as only its hash is stored.`,
+	Example: `git bug webui token add alice --identity 3f7a
+git bug webui token add dashboard --read-only`,
+	PreRunE: loadRepo,
+	RunE:    runWebUITokenAdd,
+}
+
+func init() {
+	webUITokenCmd.AddCommand(webUITokenAddCmd)
+
+	webUITokenAddCmd.Flags().SortFlags = false
+
+	webUITokenAddCmd.Flags().BoolVar(&webUITokenReadOnly, "read-only", false,
+		"Only allow to read the bugs")
+	webUITokenAddCmd.Flags().StringVar(&webUITokenIdentity, "identity", "",
+		"The identity authoring the changes made with the token, given as an id prefix (default: the user of the repository)")
+}
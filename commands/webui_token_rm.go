@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/MichaelMure/git-bug/graphql"
+	"github.com/spf13/cobra"
+)
+
+func runWebUITokenRm(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("You must provide a name")
+	}
+
+	return graphql.RemoveToken(repo, args[0])
+}
+
+var webUITokenRmCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Short:   "Revoke a token giving access to the web UI",
+	PreRunE: loadRepo,
+	RunE:    runWebUITokenRm,
+}
+
+func init() {
+	webUITokenCmd.AddCommand(webUITokenRmCmd)
+}
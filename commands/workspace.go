@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/workspace"
+	"github.com/spf13/cobra"
+)
+
+func runWorkspace(cmd *cobra.Command, args []string) error {
+	ws, err := workspace.Load()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range ws.Names() {
+		fmt.Printf("%s\t%s\n", colors.Cyan(name), ws.Repos[name])
+	}
+
+	return nil
+}
+
+// openWorkspace open the caches of the repositories of the workspace
+func openWorkspace(readOnly bool) (*cache.MultiRepoCache, error) {
+	ws, err := workspace.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return ws.Open(readOnly)
+}
+
+// isWorkspaceId return true if the argument is a bug id prefixed by the name
+// of a repository of the workspace, like "project/3f5a"
+func isWorkspaceId(arg string) bool {
+	name, _, ok := cache.SplitQualifiedId(arg)
+	if !ok {
+		return false
+	}
+
+	ws, err := workspace.Load()
+	if err != nil {
+		return false
+	}
+
+	_, ok = ws.Repos[name]
+	return ok
+}
+
+// loadRepoOrWorkspace load the repository of the current directory, unless
+// the command operate on the workspace, with a --workspace flag or with a bug
+// id prefixed by the name of a repository of the workspace
+func loadRepoOrWorkspace(cmd *cobra.Command, args []string) error {
+	if flag := cmd.Flags().Lookup("workspace"); flag != nil && flag.Changed {
+		return nil
+	}
+
+	if len(args) > 0 && isWorkspaceId(args[0]) {
+		return nil
+	}
+
+	return loadRepo(cmd, args)
+}
+
+var workspaceCmd = &cobra.Command{
+	Use:   "workspace",
+	Short: "List the repositories of the workspace",
+	Long: `List the repositories of the workspace.
+
+The workspace is a set of named repositories that "git bug ls --workspace", "git bug show" and "git bug termui --workspace" can operate on together, from any directory. The bugs are then identified by the name of their repository followed by their id, like "project/3f5a".
+
+The workspace is stored in $XDG_CONFIG_HOME/git-bug/workspace.json, or in the file given by $GIT_BUG_WORKSPACE.`,
+	RunE: runWorkspace,
+}
+
+func init() {
+	RootCmd.AddCommand(workspaceCmd)
+}
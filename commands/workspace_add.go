@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	workspaceAddName string
+)
+
+func runWorkspaceAdd(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("You must provide the path of a repository")
+	}
+
+	path, err := filepath.Abs(args[0])
+	if err != nil {
+		return err
+	}
+
+	r, err := repository.NewGitRepo(path, bug.Witnesser)
+	if err == repository.ErrNotARepo {
+		return fmt.Errorf("%s is not a git repository", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	path = r.GetPath()
+
+	name := workspaceAddName
+	if name == "" {
+		name = filepath.Base(path)
+	}
+
+	ws, err := workspace.Load()
+	if err != nil {
+		return err
+	}
+
+	err = ws.Add(name, path)
+	if err != nil {
+		return err
+	}
+
+	err = ws.Save()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s added to the workspace as %s\n", path, name)
+
+	return nil
+}
+
+var workspaceAddCmd = &cobra.Command{
+	Use:   "add <path>",
+	Short: "Add a repository to the workspace",
+	Long: `Add a repository to the workspace.
+
+The repository is named after its directory, unless a name is given with --name.`,
+	RunE: runWorkspaceAdd,
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceAddCmd)
+
+	workspaceAddCmd.Flags().SortFlags = false
+
+	workspaceAddCmd.Flags().StringVarP(&workspaceAddName, "name", "n", "",
+		"The name of the repository in the workspace")
+}
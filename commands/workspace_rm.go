@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/MichaelMure/git-bug/workspace"
+	"github.com/spf13/cobra"
+)
+
+func runWorkspaceRm(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("You must provide a name")
+	}
+
+	ws, err := workspace.Load()
+	if err != nil {
+		return err
+	}
+
+	err = ws.Remove(args[0])
+	if err != nil {
+		return err
+	}
+
+	return ws.Save()
+}
+
+var workspaceRmCmd = &cobra.Command{
+	Use:   "rm <name>",
+	Short: "Remove a repository from the workspace",
+	Long: `Remove a repository from the workspace.
+
+Only the registration is removed, the repository and its bugs are left untouched.`,
+	RunE: runWorkspaceRm,
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceRmCmd)
+}
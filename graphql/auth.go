@@ -0,0 +1,286 @@
+package graphql
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/graphql/resolvers"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// tokenConfigSection is the git config section holding the tokens giving
+// access to the web UI, as git-bug.webui-token.<name>.<key>
+const tokenConfigSection = "git-bug.webui-token"
+
+// tokenCookie is the cookie holding the token of a browser, set when the
+// web UI is opened with ?token=<token>
+const tokenCookie = "git-bug-token"
+
+const (
+	RoleRead  = "read"
+	RoleWrite = "write"
+)
+
+var tokenNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// Token give access to the web UI. Only the hash of the secret is stored in
+// the configuration, the secret itself is shown once when the token is added.
+type Token struct {
+	Name string
+	Hash string
+	Role string
+	// Identity is the author of the changes made with the token. If nil, the
+	// user of the repository is the author.
+	Identity *bug.Person
+}
+
+// ReadOnly tell if the token only allow to read the repository
+func (t Token) ReadOnly() bool {
+	return t.Role != RoleWrite
+}
+
+// ReadTokens read the tokens giving access to the web UI, sorted by name
+func ReadTokens(repo repository.RepoCommon) ([]Token, error) {
+	configs, err := repo.ReadConfigs(tokenConfigSection + ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]map[string]string)
+	for key, value := range configs {
+		key = strings.TrimPrefix(key, tokenConfigSection+".")
+		i := strings.LastIndex(key, ".")
+		if i < 0 {
+			continue
+		}
+		name, field := key[:i], key[i+1:]
+		if byName[name] == nil {
+			byName[name] = make(map[string]string)
+		}
+		byName[name][field] = value
+	}
+
+	tokens := make([]Token, 0, len(byName))
+	for name, fields := range byName {
+		if fields["hash"] == "" {
+			continue
+		}
+
+		token := Token{
+			Name: name,
+			Hash: fields["hash"],
+			Role: fields["role"],
+		}
+
+		if fields["name"] != "" {
+			token.Identity = &bug.Person{
+				Name:      fields["name"],
+				Email:     fields["email"],
+				Login:     fields["login"],
+				AvatarUrl: fields["avatar-url"],
+			}
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Name < tokens[j].Name
+	})
+
+	return tokens, nil
+}
+
+// AddToken create a new token giving access to the web UI and return its
+// secret. The changes made with the token are authored by the given
+// identity, or by the user of the repository if nil.
+func AddToken(repo repository.RepoCommon, name string, role string, identity *bug.Person) (string, error) {
+	if !tokenNameRegexp.MatchString(name) {
+		return "", fmt.Errorf("invalid token name %q, only letters, digits, - and _ are allowed", name)
+	}
+
+	if role != RoleRead && role != RoleWrite {
+		return "", fmt.Errorf("unknown role %q, expected %s or %s", role, RoleRead, RoleWrite)
+	}
+
+	if identity != nil {
+		if err := identity.Validate(); err != nil {
+			return "", err
+		}
+	}
+
+	tokens, err := ReadTokens(repo)
+	if err != nil {
+		return "", err
+	}
+
+	for _, token := range tokens {
+		if token.Name == name {
+			return "", fmt.Errorf("a token named %s already exist", name)
+		}
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	secret := hex.EncodeToString(raw)
+
+	fields := map[string]string{
+		"hash": hashToken(secret),
+		"role": role,
+	}
+
+	if identity != nil {
+		fields["name"] = identity.Name
+		fields["email"] = identity.Email
+		fields["login"] = identity.Login
+		fields["avatar-url"] = identity.AvatarUrl
+	}
+
+	for key, value := range fields {
+		if value == "" {
+			continue
+		}
+		err := repo.StoreConfig(fmt.Sprintf("%s.%s.%s", tokenConfigSection, name, key), value)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return secret, nil
+}
+
+// RemoveToken revoke a token giving access to the web UI
+func RemoveToken(repo repository.RepoCommon, name string) error {
+	tokens, err := ReadTokens(repo)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		if token.Name == name {
+			return repo.RmConfigs(fmt.Sprintf("%s.%s", tokenConfigSection, name))
+		}
+	}
+
+	return fmt.Errorf("no token named %s", name)
+}
+
+func hashToken(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// Auth restrict the access to the web UI to the holders of a token. As long
+// as no token is configured, everybody has a full access, as the author
+// configured in the repository.
+type Auth struct {
+	// tokens indexed by the hash of their secret, then by the path of the
+	// repository they belong to
+	tokens map[string]map[string]Token
+}
+
+// NewAuth read the tokens configured in the served repositories. A token
+// only give access to the repository it's configured in.
+func NewAuth(repos ...repository.RepoCommon) (*Auth, error) {
+	a := &Auth{tokens: make(map[string]map[string]Token)}
+
+	for _, repo := range repos {
+		tokens, err := ReadTokens(repo)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, token := range tokens {
+			if a.tokens[token.Hash] == nil {
+				a.tokens[token.Hash] = make(map[string]Token)
+			}
+			a.tokens[token.Hash][repo.GetPath()] = token
+		}
+	}
+
+	return a, nil
+}
+
+// Enabled tell if a token is required to access the web UI
+func (a *Auth) Enabled() bool {
+	return len(a.tokens) > 0
+}
+
+// Middleware reject the requests without a valid token and attach the user
+// of the token to the context of the others. The token is read from the
+// Authorization header (Bearer <token>), or from a cookie. Opening any page
+// with ?token=<token> store the token in the cookie, for the browsers.
+func (a *Auth) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.Enabled() || r.URL.Path == "/healthz" || r.URL.Path == "/readyz" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if secret := r.URL.Query().Get("token"); secret != "" {
+			if _, ok := a.tokens[hashToken(secret)]; !ok {
+				http.Error(w, "invalid token", http.StatusUnauthorized)
+				return
+			}
+
+			http.SetCookie(w, &http.Cookie{
+				Name:     tokenCookie,
+				Value:    secret,
+				Path:     "/",
+				HttpOnly: true,
+				SameSite: http.SameSiteStrictMode,
+			})
+
+			// drop the token from the URL, to keep it out of the history
+			query := r.URL.Query()
+			query.Del("token")
+			u := *r.URL
+			u.RawQuery = query.Encode()
+			http.Redirect(w, r, u.RequestURI(), http.StatusFound)
+			return
+		}
+
+		tokens, ok := a.tokens[hashToken(requestSecret(r))]
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="git-bug"`)
+			http.Error(w, "a valid token is required", http.StatusUnauthorized)
+			return
+		}
+
+		users := make(map[string]resolvers.User, len(tokens))
+		for path, token := range tokens {
+			users[path] = resolvers.User{
+				Name:     token.Name,
+				ReadOnly: token.ReadOnly(),
+				Identity: token.Identity,
+			}
+		}
+
+		ctx := resolvers.WithUsers(r.Context(), users)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requestSecret return the secret of the token given with a request, if any
+func requestSecret(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+	if strings.HasPrefix(header, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+	}
+
+	if cookie, err := r.Cookie(tokenCookie); err == nil {
+		return cookie.Value
+	}
+
+	return ""
+}
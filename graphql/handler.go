@@ -4,19 +4,36 @@
 package graphql
 
 import (
+	"fmt"
+	"net/http"
+
 	"github.com/99designs/gqlgen/handler"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/graphql/graph"
 	"github.com/MichaelMure/git-bug/graphql/resolvers"
 	"github.com/MichaelMure/git-bug/repository"
-	"net/http"
+	"github.com/pkg/errors"
 )
 
+// audienceConfigKey is the git config key holding the audience of the web
+// UI, that is, the visibilities of the bugs it exposes on top of the public
+// ones. By default, every bug is exposed.
+const audienceConfigKey = "git-bug.webui-audience"
+
 // Handler is the root GraphQL http handler
 type Handler struct {
 	http.HandlerFunc
 	*resolvers.RootResolver
+	// Limits are the limits of the served repositories, applied to the
+	// GraphQL queries
+	Limits Limits
+	// the audience of each served repository, by name
+	audiences map[string]bug.Audience
 }
 
+// NewHandler create a handler serving a single repository, as the default
+// repository
 func NewHandler(repo repository.ClockedRepo) (Handler, error) {
 	h := Handler{
 		RootResolver: resolvers.NewRootResolver(),
@@ -27,11 +44,105 @@ func NewHandler(repo repository.ClockedRepo) (Handler, error) {
 		return Handler{}, err
 	}
 
+	return h.serve(map[string]repository.ClockedRepo{"": repo})
+}
+
+// NewMultiRepoHandler create a handler serving several repositories, by
+// name. There is no default repository then, the repositories are accessed
+// with repository(ref: "name").
+func NewMultiRepoHandler(repos map[string]repository.ClockedRepo) (Handler, error) {
+	h := Handler{
+		RootResolver: resolvers.NewRootResolver(),
+	}
+
+	for name, repo := range repos {
+		if name == "" {
+			return Handler{}, fmt.Errorf("a repository has no name")
+		}
+
+		err := h.RootResolver.RegisterRepository(name, repo)
+		if err != nil {
+			_ = h.RootResolver.Close()
+			return Handler{}, errors.Wrapf(err, "repository %s", name)
+		}
+	}
+
+	return h.serve(repos)
+}
+
+func (h Handler) serve(repos map[string]repository.ClockedRepo) (Handler, error) {
 	config := graph.Config{
 		Resolvers: h.RootResolver,
 	}
 
-	h.HandlerFunc = handler.GraphQL(graph.NewExecutableSchema(config))
+	// each repository expose the bugs of its own audience
+	audiences := make(map[*cache.RepoCache]bug.Audience, len(repos))
+	h.audiences = make(map[string]bug.Audience, len(repos))
+	for name, repo := range repos {
+		audience, err := readAudience(repo)
+		if err != nil {
+			return Handler{}, err
+		}
+
+		repoCache, err := h.RootResolver.ResolveRepo(name)
+		if err != nil {
+			return Handler{}, err
+		}
+
+		audiences[repoCache] = audience
+		h.audiences[name] = audience
+	}
+
+	var configs []repository.RepoCommon
+	for _, repo := range repos {
+		configs = append(configs, repo)
+	}
+
+	limits, err := ReadLimits(configs...)
+	if err != nil {
+		return Handler{}, err
+	}
+	h.Limits = limits
+
+	var options []handler.Option
+	if limits.MaxQueryComplexity > 0 {
+		options = append(options, handler.ComplexityLimit(limits.MaxQueryComplexity))
+	}
+	if limits.MaxQueryDepth > 0 {
+		options = append(options, handler.RequestMiddleware(queryDepthMiddleware(limits.MaxQueryDepth)))
+	}
+
+	gqlHandler := handler.GraphQL(graph.NewExecutableSchema(config), options...)
+
+	h.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		if limits.MaxRequestSize > 0 {
+			r.Body = http.MaxBytesReader(w, r.Body, limits.MaxRequestSize)
+		}
+
+		ctx := resolvers.WithAudiences(r.Context(), audiences)
+		gqlHandler(w, r.WithContext(ctx))
+	}
 
 	return h, nil
 }
+
+// Audience return the audience of a served repository, nil if every bug is
+// exposed
+func (h Handler) Audience(name string) bug.Audience {
+	return h.audiences[name]
+}
+
+// readAudience read the audience of the web UI from the configuration
+func readAudience(repo repository.RepoCommon) (bug.Audience, error) {
+	configs, err := repo.ReadConfigs(audienceConfigKey)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := configs[audienceConfigKey]
+	if !ok {
+		return nil, nil
+	}
+
+	return bug.AudienceFromString(value)
+}
@@ -0,0 +1,53 @@
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+type healthResponse struct {
+	Status string             `json:"status"`
+	Repos  []cache.RepoHealth `json:"repos,omitempty"`
+}
+
+// HealthHandler serve a liveness probe: it always succeed as long as the
+// server is able to answer.
+func (h Handler) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		writeHealth(rw, http.StatusOK, healthResponse{Status: "ok"})
+	})
+}
+
+// ReadyHandler serve a readiness probe: it succeed only if all the
+// repositories have their cache loaded and are accessible. The state of each
+// repository is reported in the response.
+func (h Handler) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		resp := healthResponse{
+			Status: "ok",
+			Repos:  h.RootResolver.Health(),
+		}
+
+		status := http.StatusOK
+		if !h.RootResolver.Ready() {
+			resp.Status = "unavailable"
+			status = http.StatusServiceUnavailable
+		}
+
+		writeHealth(rw, status, resp)
+	})
+}
+
+func writeHealth(rw http.ResponseWriter, status int, resp healthResponse) {
+	js, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	_, _ = rw.Write(js)
+}
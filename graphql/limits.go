@@ -0,0 +1,265 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// the git config keys holding the limits of the web UI
+const (
+	rateLimitConfigKey          = "git-bug.webui-rate-limit"
+	maxRequestSizeConfigKey     = "git-bug.webui-max-request-size"
+	maxQueryDepthConfigKey      = "git-bug.webui-max-query-depth"
+	maxQueryComplexityConfigKey = "git-bug.webui-max-query-complexity"
+	maxUploadSizeConfigKey      = "git-bug.webui-max-upload-size"
+)
+
+// DefaultMaxUploadSize is the largest file accepted by the web UI by default
+// (GitHub limit)
+const DefaultMaxUploadSize = 100 * 1000 * 1000
+
+// Limits protect a web UI exposed publicly from the abusive clients. A zero
+// value is no limit.
+type Limits struct {
+	// RateLimit is the number of requests allowed per minute and per IP
+	RateLimit int
+	// MaxRequestSize is the largest body of a GraphQL request, in bytes
+	MaxRequestSize int64
+	// MaxQueryDepth is the deepest nesting of fields of a GraphQL query,
+	// the introspection fields not counted
+	MaxQueryDepth int
+	// MaxQueryComplexity is the highest complexity of a GraphQL query, each
+	// field counting for one
+	MaxQueryComplexity int
+	// MaxUploadSize is the largest file accepted, in bytes
+	MaxUploadSize int64
+}
+
+// ReadLimits read the limits of the web UI from the configuration of the
+// served repositories. When several repositories are served, the strictest
+// limits apply.
+func ReadLimits(repos ...repository.RepoCommon) (Limits, error) {
+	limits := Limits{MaxUploadSize: DefaultMaxUploadSize}
+
+	for i, repo := range repos {
+		configs, err := repo.ReadConfigs("git-bug.webui-")
+		if err != nil {
+			return Limits{}, err
+		}
+
+		var values [5]int64
+		for j, key := range []string{
+			rateLimitConfigKey,
+			maxRequestSizeConfigKey,
+			maxQueryDepthConfigKey,
+			maxQueryComplexityConfigKey,
+			maxUploadSizeConfigKey,
+		} {
+			values[j], err = readLimit(configs, key)
+			if err != nil {
+				return Limits{}, err
+			}
+		}
+
+		read := Limits{
+			RateLimit:          int(values[0]),
+			MaxRequestSize:     values[1],
+			MaxQueryDepth:      int(values[2]),
+			MaxQueryComplexity: int(values[3]),
+			MaxUploadSize:      values[4],
+		}
+		if _, ok := configs[maxUploadSizeConfigKey]; !ok {
+			read.MaxUploadSize = DefaultMaxUploadSize
+		}
+
+		if i == 0 {
+			limits = read
+			continue
+		}
+
+		limits.RateLimit = int(strictest(int64(limits.RateLimit), int64(read.RateLimit)))
+		limits.MaxRequestSize = strictest(limits.MaxRequestSize, read.MaxRequestSize)
+		limits.MaxQueryDepth = int(strictest(int64(limits.MaxQueryDepth), int64(read.MaxQueryDepth)))
+		limits.MaxQueryComplexity = int(strictest(int64(limits.MaxQueryComplexity), int64(read.MaxQueryComplexity)))
+		limits.MaxUploadSize = strictest(limits.MaxUploadSize, read.MaxUploadSize)
+	}
+
+	return limits, nil
+}
+
+// readLimit read a limit from the configuration, zero if not set
+func readLimit(configs map[string]string, key string) (int64, error) {
+	value, ok := configs[key]
+	if !ok {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid value %q for %s, expected a positive number", value, key)
+	}
+
+	return n, nil
+}
+
+// strictest return the lowest of two limits, zero being no limit
+func strictest(a, b int64) int64 {
+	switch {
+	case a == 0:
+		return b
+	case b == 0:
+		return a
+	case a < b:
+		return a
+	default:
+		return b
+	}
+}
+
+// queryDepthMiddleware reject the GraphQL queries nesting their fields deeper
+// than the limit
+func queryDepthMiddleware(limit int) graphql.RequestMiddleware {
+	return func(ctx context.Context, next func(ctx context.Context) []byte) []byte {
+		reqCtx := graphql.GetRequestContext(ctx)
+
+		for _, op := range reqCtx.Doc.Operations {
+			if depth := selectionDepth(op.SelectionSet); depth > limit {
+				graphql.AddErrorf(ctx, "operation has a depth of %d, which exceeds the limit of %d", depth, limit)
+				return nil
+			}
+		}
+
+		return next(ctx)
+	}
+}
+
+// selectionDepth return the deepest nesting of fields of a selection. The
+// introspection fields, like __schema, aren't counted.
+func selectionDepth(selections ast.SelectionSet) int {
+	depth := 0
+
+	for _, selection := range selections {
+		var d int
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name, "__") {
+				continue
+			}
+			d = 1 + selectionDepth(selection.SelectionSet)
+		case *ast.InlineFragment:
+			d = selectionDepth(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			// the cycles between fragments are rejected by the validation
+			if selection.Definition != nil {
+				d = selectionDepth(selection.Definition.SelectionSet)
+			}
+		}
+
+		if d > depth {
+			depth = d
+		}
+	}
+
+	return depth
+}
+
+// RateLimiter reject the requests of a client going beyond the rate limit,
+// with a 429 status. The clients are identified by their IP, the address of
+// the connection: behind a reverse proxy, the proxy itself is limited.
+func (l Limits) RateLimiter(next http.Handler) http.Handler {
+	if l.RateLimit <= 0 {
+		return next
+	}
+
+	limiter := &rateLimiter{
+		rate:    float64(l.RateLimit) / 60,
+		burst:   float64(l.RateLimit),
+		buckets: make(map[string]*tokenBucket),
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" || r.URL.Path == "/readyz" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+
+		if wait := limiter.take(ip, time.Now()); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rateLimiter is a token bucket per client: a bucket hold up to burst
+// tokens, refilled at rate tokens per second, and each request take one.
+type rateLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	buckets     map[string]*tokenBucket
+	lastCleanup time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take a token from the bucket of a client, returning how long to wait
+// before retrying if the bucket is empty
+func (rl *rateLimiter) take(client string, now time.Time) time.Duration {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.cleanup(now)
+
+	bucket, ok := rl.buckets[client]
+	if !ok {
+		bucket = &tokenBucket{tokens: rl.burst, last: now}
+		rl.buckets[client] = bucket
+	}
+
+	bucket.tokens = math.Min(rl.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*rl.rate)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		return time.Duration((1 - bucket.tokens) / rl.rate * float64(time.Second))
+	}
+
+	bucket.tokens--
+	return 0
+}
+
+// cleanup forget the clients whose bucket is full again, once a minute
+func (rl *rateLimiter) cleanup(now time.Time) {
+	if now.Sub(rl.lastCleanup) < time.Minute {
+		return
+	}
+	rl.lastCleanup = now
+
+	for client, bucket := range rl.buckets {
+		if bucket.tokens+now.Sub(bucket.last).Seconds()*rl.rate >= rl.burst {
+			delete(rl.buckets, client)
+		}
+	}
+}
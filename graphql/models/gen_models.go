@@ -29,6 +29,14 @@ type BugEdge struct {
 	Node   bug.Snapshot `json:"node"`
 }
 
+// The result of a mutation on a bug.
+type BugPayload struct {
+	ClientMutationID *string       `json:"clientMutationId"`
+	Bug              *bug.Snapshot `json:"bug"`
+	Operation        bug.Operation `json:"operation"`
+	Errors           []UserError   `json:"errors"`
+}
+
 type CommentConnection struct {
 	Edges      []CommentEdge `json:"edges"`
 	Nodes      []bug.Comment `json:"nodes"`
@@ -41,6 +49,13 @@ type CommentEdge struct {
 	Node   bug.Comment `json:"node"`
 }
 
+// A metadata to store in the operation created by a mutation. The keys are
+// usually namespaced by the tool setting them, like "myapp-request-id".
+type MetadataInput struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
 // The connection type for an Operation
 type OperationConnection struct {
 	Edges      []OperationEdge `json:"edges"`
@@ -63,6 +78,12 @@ type PageInfo struct {
 	EndCursor       string `json:"endCursor"`
 }
 
+// A score of a bug computed by the cache, between 0 and 1.
+type Score struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
 // The connection type for TimelineItem
 type TimelineItemConnection struct {
 	Edges      []TimelineItemEdge `json:"edges"`
@@ -77,6 +98,14 @@ type TimelineItemEdge struct {
 	Node   bug.TimelineItem `json:"node"`
 }
 
+// An error caused by the input of a mutation, meant to be shown to the user
+// next to the offending field.
+type UserError struct {
+	Field   *string       `json:"field"`
+	Message string        `json:"message"`
+	Code    UserErrorCode `json:"code"`
+}
+
 type Status string
 
 const (
@@ -112,3 +141,90 @@ func (e *Status) UnmarshalGQL(v interface{}) error {
 func (e Status) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+// The kinds of timeline items, to filter the timeline
+type TimelineItemKind string
+
+const (
+	// The creation of the bug and the comments
+	TimelineItemKindComment TimelineItemKind = "COMMENT"
+	// The changes of labels
+	TimelineItemKindLabelChange TimelineItemKind = "LABEL_CHANGE"
+	// The openings and closings
+	TimelineItemKindStatusChange TimelineItemKind = "STATUS_CHANGE"
+	// The changes of title
+	TimelineItemKindTitleChange TimelineItemKind = "TITLE_CHANGE"
+)
+
+func (e TimelineItemKind) IsValid() bool {
+	switch e {
+	case TimelineItemKindComment, TimelineItemKindLabelChange, TimelineItemKindStatusChange, TimelineItemKindTitleChange:
+		return true
+	}
+	return false
+}
+
+func (e TimelineItemKind) String() string {
+	return string(e)
+}
+
+func (e *TimelineItemKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TimelineItemKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TimelineItemKind", str)
+	}
+	return nil
+}
+
+func (e TimelineItemKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type UserErrorCode string
+
+const (
+	// The value of a field is invalid.
+	UserErrorCodeInvalid UserErrorCode = "INVALID"
+	// The bug doesn't exist.
+	UserErrorCodeNotFound UserErrorCode = "NOT_FOUND"
+	// The prefix match multiple bugs.
+	UserErrorCodeAmbiguous UserErrorCode = "AMBIGUOUS"
+	// The policy of the repository doesn't allow the user to do that.
+	UserErrorCodeNotAllowed UserErrorCode = "NOT_ALLOWED"
+	// The repository is opened read-only.
+	UserErrorCodeReadOnly UserErrorCode = "READ_ONLY"
+)
+
+func (e UserErrorCode) IsValid() bool {
+	switch e {
+	case UserErrorCodeInvalid, UserErrorCodeNotFound, UserErrorCodeAmbiguous, UserErrorCodeNotAllowed, UserErrorCodeReadOnly:
+		return true
+	}
+	return false
+}
+
+func (e UserErrorCode) String() string {
+	return string(e)
+}
+
+func (e *UserErrorCode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserErrorCode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserErrorCode", str)
+	}
+	return nil
+}
+
+func (e UserErrorCode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -15,6 +15,8 @@ type ConnectionInput struct {
 type Repository struct {
 	Cache *cache.MultiRepoCache
 	Repo  *cache.RepoCache
+	// Name is the name of the repository, nil for the default one
+	Name *string
 }
 
 type RepositoryMutation struct {
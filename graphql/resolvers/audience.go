@@ -0,0 +1,39 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+type audienceKey struct{}
+
+// WithAudiences return a context restricting the bugs exposed by the
+// resolvers to the ones visible by the audience of their repository
+func WithAudiences(ctx context.Context, audiences map[*cache.RepoCache]bug.Audience) context.Context {
+	return context.WithValue(ctx, audienceKey{}, audiences)
+}
+
+// audienceFromContext return the audience of a repository for a request, nil
+// (everything is visible) if none is set
+func audienceFromContext(ctx context.Context, repo *cache.RepoCache) bug.Audience {
+	audiences, _ := ctx.Value(audienceKey{}).(map[*cache.RepoCache]bug.Audience)
+	return audiences[repo]
+}
+
+// resolveVisibleBug retrieve a bug matching an id prefix, as long as it's
+// visible by the audience of the request. A hidden bug is reported as not
+// existing to not leak its existence.
+func resolveVisibleBug(ctx context.Context, repo *cache.RepoCache, prefix string) (*cache.BugCache, error) {
+	b, err := repo.ResolveBugPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if !audienceFromContext(ctx, repo).CanSee(b.Snapshot().Visibility) {
+		return nil, bug.ErrBugNotExist
+	}
+
+	return b, nil
+}
@@ -0,0 +1,97 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+// ErrReadOnlyUser is returned when a user with a read-only access try to
+// change the repository
+var ErrReadOnlyUser = errors.New("your access to this repository is read-only")
+
+// ErrNoAccess is returned when the token of a user doesn't belong to the
+// repository accessed
+var ErrNoAccess = errors.New("your token doesn't give access to this repository")
+
+type userKey struct{}
+
+// User is the user authenticated for a request
+type User struct {
+	// Name is the name of the token used
+	Name     string
+	ReadOnly bool
+	// Identity is the author of the changes of the user, the user of the
+	// repository if nil
+	Identity *bug.Person
+}
+
+// WithUsers return a context making the resolvers act on behalf of the
+// given users, indexed by the path of the repository their token belongs
+// to. The repositories not in the index can't be accessed.
+func WithUsers(ctx context.Context, users map[string]User) context.Context {
+	return context.WithValue(ctx, userKey{}, users)
+}
+
+// userFromContext return the user of a request for the repository at the
+// given path. authenticated is false if the request is not authenticated,
+// an error is returned if the request has no access to the repository.
+func userFromContext(ctx context.Context, repoPath string) (user User, authenticated bool, err error) {
+	users, ok := ctx.Value(userKey{}).(map[string]User)
+	if !ok {
+		return User{}, false, nil
+	}
+
+	user, ok = users[repoPath]
+	if !ok {
+		return User{}, true, ErrNoAccess
+	}
+
+	return user, true, nil
+}
+
+// CanRead tell if the user of a request is allowed to read the repository
+// at the given path. Without authentication, everybody is.
+func CanRead(ctx context.Context, repoPath string) bool {
+	_, _, err := userFromContext(ctx, repoPath)
+	return err == nil
+}
+
+// CanWrite tell if the user of a request is allowed to change the
+// repository at the given path. Without authentication, everybody is.
+func CanWrite(ctx context.Context, repoPath string) bool {
+	user, _, err := userFromContext(ctx, repoPath)
+	return err == nil && !user.ReadOnly
+}
+
+// checkRead fail if the user of a request is not allowed to read the
+// repository
+func checkRead(ctx context.Context, repo *cache.RepoCache) error {
+	_, _, err := userFromContext(ctx, repo.GetPath())
+	return err
+}
+
+// authorFromContext return the author of the changes made by a request: the
+// identity of the authenticated user, or the user of the repository
+func authorFromContext(ctx context.Context, repo *cache.RepoCache) (bug.Person, error) {
+	user, ok, err := userFromContext(ctx, repo.GetPath())
+	if err != nil {
+		return bug.Person{}, err
+	}
+
+	if !ok {
+		return repo.GetUser()
+	}
+
+	if user.ReadOnly {
+		return bug.Person{}, ErrReadOnlyUser
+	}
+
+	if user.Identity != nil {
+		return *user.Identity, nil
+	}
+
+	return repo.GetUser()
+}
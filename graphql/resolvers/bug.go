@@ -2,14 +2,82 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/graphql/connections"
 	"github.com/MichaelMure/git-bug/graphql/models"
+	"github.com/MichaelMure/git-bug/util/git"
 )
 
-type bugResolver struct{}
+type bugResolver struct {
+	cache *cache.MultiRepoCache
+}
+
+func (r bugResolver) Alias(ctx context.Context, obj *bug.Snapshot) (*string, error) {
+	alias, ok := r.cache.Alias(obj.Id())
+	if !ok {
+		return nil, nil
+	}
+	return &alias, nil
+}
+
+func (r bugResolver) SuggestedAssignees(ctx context.Context, obj *bug.Snapshot, first *int) ([]cache.AssigneeSuggestion, error) {
+	repo, ok := r.cache.RepoOfBug(obj.Id())
+	if !ok {
+		return []cache.AssigneeSuggestion{}, nil
+	}
+
+	limit := 0
+	if first != nil {
+		limit = *first
+	}
+
+	return repo.SuggestAssignees(obj.Id(), limit)
+}
+
+func (r bugResolver) Scores(ctx context.Context, obj *bug.Snapshot) ([]models.Score, error) {
+	b, err := r.cachedBug(obj)
+	if err != nil || b == nil {
+		return []models.Score{}, err
+	}
+
+	result := []models.Score{}
+	for _, scorer := range cache.Scorers() {
+		if value, ok := b.Scores()[scorer.Name()]; ok {
+			result = append(result, models.Score{Name: scorer.Name(), Value: value})
+		}
+	}
+
+	return result, nil
+}
+
+func (r bugResolver) Hints(ctx context.Context, obj *bug.Snapshot) ([]string, error) {
+	b, err := r.cachedBug(obj)
+	if err != nil || b == nil {
+		return []string{}, err
+	}
+
+	hints := b.Hints()
+	if hints == nil {
+		return []string{}, nil
+	}
+
+	return hints, nil
+}
+
+// cachedBug return the bug of the cache matching a snapshot, if its repository
+// is still served
+func (r bugResolver) cachedBug(obj *bug.Snapshot) (*cache.BugCache, error) {
+	repo, ok := r.cache.RepoOfBug(obj.Id())
+	if !ok {
+		return nil, nil
+	}
+
+	return repo.ResolveBug(obj.Id())
+}
 
 func (bugResolver) Status(ctx context.Context, obj *bug.Snapshot) (models.Status, error) {
 	return convertStatus(obj.Status)
@@ -69,7 +137,7 @@ func (bugResolver) Operations(ctx context.Context, obj *bug.Snapshot, after *str
 	return connections.BugOperationCon(obj.Operations, edger, conMaker, input)
 }
 
-func (bugResolver) Timeline(ctx context.Context, obj *bug.Snapshot, after *string, before *string, first *int, last *int) (models.TimelineItemConnection, error) {
+func (r bugResolver) Timeline(ctx context.Context, obj *bug.Snapshot, after *string, before *string, first *int, last *int, kinds []models.TimelineItemKind, afterHash *git.Hash) (models.TimelineItemConnection, error) {
 	input := models.ConnectionInput{
 		Before: before,
 		After:  after,
@@ -84,7 +152,46 @@ func (bugResolver) Timeline(ctx context.Context, obj *bug.Snapshot, after *strin
 		}
 	}
 
-	conMaker := func(edges []models.TimelineItemEdge, nodes []bug.TimelineItem, info models.PageInfo, totalCount int) (models.TimelineItemConnection, error) {
+	b, err := r.cachedBug(obj)
+	if err != nil || b == nil {
+		return models.TimelineItemConnection{
+			Edges: []models.TimelineItemEdge{},
+			Nodes: []bug.TimelineItem{},
+		}, err
+	}
+
+	// when paging forward, only the items up to the end of the page, and one
+	// more to know if there is a next page, are compiled
+	limit := -1
+	if first != nil && before == nil && last == nil {
+		offset := 0
+		if after != nil {
+			offset, err = connections.CursorToOffset(*after)
+			if err != nil {
+				return models.TimelineItemConnection{}, err
+			}
+			offset++
+		}
+		limit = offset + *first + 1
+	}
+
+	filter, found := timelineFilter(kinds, afterHash)
+	source := b.TimelinePage(filter, 0, limit)
+	if !*found {
+		return models.TimelineItemConnection{}, fmt.Errorf("no timeline item with the hash %s", *afterHash)
+	}
+
+	// the items of the snapshot compiled by the cache are counted, as the
+	// page may not cover the whole timeline
+	totalCount := 0
+	countFilter, _ := timelineFilter(kinds, afterHash)
+	for _, item := range obj.Timeline {
+		if countFilter(item) {
+			totalCount++
+		}
+	}
+
+	conMaker := func(edges []models.TimelineItemEdge, nodes []bug.TimelineItem, info models.PageInfo, _ int) (models.TimelineItemConnection, error) {
 		return models.TimelineItemConnection{
 			Edges:      edges,
 			Nodes:      nodes,
@@ -93,7 +200,52 @@ func (bugResolver) Timeline(ctx context.Context, obj *bug.Snapshot, after *strin
 		}, nil
 	}
 
-	return connections.BugTimelineItemCon(obj.Timeline, edger, conMaker, input)
+	return connections.BugTimelineItemCon(source, edger, conMaker, input)
+}
+
+// timelineFilter return a filter accepting the timeline items of some kinds,
+// following the item having a given hash. found tell if this item has been
+// met by the filter so far.
+func timelineFilter(kinds []models.TimelineItemKind, afterHash *git.Hash) (filter func(bug.TimelineItem) bool, found *bool) {
+	met := afterHash == nil
+
+	filter = func(item bug.TimelineItem) bool {
+		if !met {
+			met = item.Hash() == *afterHash
+			return false
+		}
+
+		if len(kinds) == 0 {
+			return true
+		}
+
+		for _, kind := range kinds {
+			if timelineItemKind(item) == kind {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return filter, &met
+}
+
+// timelineItemKind return the kind of a timeline item, an empty string if
+// it has none
+func timelineItemKind(item bug.TimelineItem) models.TimelineItemKind {
+	switch item.(type) {
+	case *bug.CreateTimelineItem, *bug.AddCommentTimelineItem:
+		return models.TimelineItemKindComment
+	case *bug.LabelChangeTimelineItem:
+		return models.TimelineItemKindLabelChange
+	case *bug.SetStatusTimelineItem:
+		return models.TimelineItemKindStatusChange
+	case *bug.SetTitleTimelineItem:
+		return models.TimelineItemKindTitleChange
+	default:
+		return ""
+	}
 }
 
 func (bugResolver) LastEdit(ctx context.Context, obj *bug.Snapshot) (time.Time, error) {
@@ -0,0 +1,37 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+type identityResolver struct{}
+
+func (identityResolver) Name(ctx context.Context, obj *cache.IdentityExcerpt) (*string, error) {
+	if obj.Name == "" {
+		return nil, nil
+	}
+	return &obj.Name, nil
+}
+
+func (identityResolver) Email(ctx context.Context, obj *cache.IdentityExcerpt) (*string, error) {
+	if obj.Email == "" {
+		return nil, nil
+	}
+	return &obj.Email, nil
+}
+
+func (identityResolver) Login(ctx context.Context, obj *cache.IdentityExcerpt) (*string, error) {
+	if obj.Login == "" {
+		return nil, nil
+	}
+	return &obj.Login, nil
+}
+
+func (identityResolver) AvatarURL(ctx context.Context, obj *cache.IdentityExcerpt) (*string, error) {
+	if obj.AvatarUrl == "" {
+		return nil, nil
+	}
+	return &obj.AvatarUrl, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/graphql/models"
 	"github.com/MichaelMure/git-bug/util/git"
 )
 
@@ -12,152 +13,266 @@ type mutationResolver struct {
 	cache *cache.MultiRepoCache
 }
 
-func (r mutationResolver) getRepo(repoRef *string) (*cache.RepoCache, error) {
-	if repoRef != nil {
-		return r.cache.ResolveRepo(*repoRef)
+func (r mutationResolver) getRepo(ctx context.Context, repoRef *string) (*cache.RepoCache, error) {
+	repo, err := resolveRepo(r.cache, repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkRead(ctx, repo); err != nil {
+		return nil, err
 	}
 
-	return r.cache.DefaultRepo()
+	return repo, nil
 }
 
-func (r mutationResolver) NewBug(ctx context.Context, repoRef *string, title string, message string, files []git.Hash) (bug.Snapshot, error) {
-	repo, err := r.getRepo(repoRef)
+// resolveBug retrieve the bug to change, along with the author of the change
+func (r mutationResolver) resolveBug(ctx context.Context, repoRef *string, prefix string) (*cache.BugCache, bug.Person, error) {
+	repo, err := r.getRepo(ctx, repoRef)
 	if err != nil {
-		return bug.Snapshot{}, err
+		return nil, bug.Person{}, err
 	}
 
-	b, err := repo.NewBugWithFiles(title, message, files)
+	author, err := authorFromContext(ctx, repo)
 	if err != nil {
-		return bug.Snapshot{}, err
+		return nil, bug.Person{}, err
 	}
 
-	snap := b.Snapshot()
+	b, err := resolveVisibleBug(ctx, repo, prefix)
+	if err != nil {
+		return nil, bug.Person{}, err
+	}
 
-	return *snap, nil
+	return b, author, nil
 }
 
-func (r mutationResolver) Commit(ctx context.Context, repoRef *string, prefix string) (bug.Snapshot, error) {
-	repo, err := r.getRepo(repoRef)
-	if err != nil {
-		return bug.Snapshot{}, err
+// bugPayload build the result of a mutation. The errors caused by the input
+// are returned in the payload so that the UIs can show them next to the
+// offending field, the others are returned as GraphQL errors.
+func bugPayload(snap *bug.Snapshot, err error) (models.BugPayload, error) {
+	if err == nil {
+		return models.BugPayload{Bug: snap, Errors: []models.UserError{}}, nil
 	}
 
-	b, err := repo.ResolveBugPrefix(prefix)
+	userErr, ok := toUserError(err)
+	if !ok {
+		return models.BugPayload{}, err
+	}
+
+	return models.BugPayload{Errors: []models.UserError{userErr}}, nil
+}
+
+// operationPayload build the result of a mutation creating an operation on
+// a bug, with the operation and the identifier given by the client
+func operationPayload(clientMutationId *string, b *cache.BugCache, err error) (models.BugPayload, error) {
+	var payload models.BugPayload
+
 	if err != nil {
-		return bug.Snapshot{}, err
+		payload, err = bugPayload(nil, err)
+	} else {
+		payload, err = bugPayload(b.Snapshot(), nil)
+		payload.Operation = b.LastOperation()
 	}
 
-	err = b.Commit()
 	if err != nil {
-		return bug.Snapshot{}, err
+		return payload, err
 	}
 
-	snap := b.Snapshot()
+	payload.ClientMutationID = clientMutationId
 
-	return *snap, nil
+	return payload, nil
 }
 
-func (r mutationResolver) AddComment(ctx context.Context, repoRef *string, prefix string, message string, files []git.Hash) (bug.Snapshot, error) {
-	repo, err := r.getRepo(repoRef)
-	if err != nil {
-		return bug.Snapshot{}, err
+// metadataMap convert the metadata given to a mutation
+func metadataMap(metadata []models.MetadataInput) (map[string]string, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+
+	result := make(map[string]string, len(metadata))
+	for _, m := range metadata {
+		if m.Key == "" {
+			return nil, bug.ErrInvalidField{Field: "metadata", Message: "metadata key is empty"}
+		}
+		result[m.Key] = m.Value
+	}
+
+	return result, nil
+}
+
+// toUserError convert an error caused by the input of a mutation
+func toUserError(err error) (models.UserError, bool) {
+	userErr := models.UserError{Message: err.Error()}
+
+	prefix := "prefix"
+
+	switch err := err.(type) {
+	case bug.ErrInvalidField:
+		field := err.Field
+		userErr.Field = &field
+		userErr.Code = models.UserErrorCodeInvalid
+	case bug.ErrMultipleMatch:
+		userErr.Field = &prefix
+		userErr.Code = models.UserErrorCodeAmbiguous
+	case cache.ErrNotAllowed:
+		userErr.Code = models.UserErrorCodeNotAllowed
+	default:
+		switch err {
+		case bug.ErrBugNotExist:
+			userErr.Field = &prefix
+			userErr.Code = models.UserErrorCodeNotFound
+		case cache.ErrReadOnly, ErrReadOnlyUser:
+			userErr.Code = models.UserErrorCodeReadOnly
+		default:
+			return models.UserError{}, false
+		}
 	}
 
-	b, err := repo.ResolveBugPrefix(prefix)
+	return userErr, true
+}
+
+func (r mutationResolver) NewBug(ctx context.Context, repoRef *string, title string, message string, files []git.Hash) (models.BugPayload, error) {
+	repo, err := r.getRepo(ctx, repoRef)
 	if err != nil {
-		return bug.Snapshot{}, err
+		return bugPayload(nil, err)
 	}
 
-	err = b.AddCommentWithFiles(message, files)
+	author, err := authorFromContext(ctx, repo)
 	if err != nil {
-		return bug.Snapshot{}, err
+		return bugPayload(nil, err)
 	}
 
-	snap := b.Snapshot()
+	b, err := repo.NewBugAs(author, title, message, files)
+	if err != nil {
+		return bugPayload(nil, err)
+	}
 
-	return *snap, nil
+	return bugPayload(b.Snapshot(), nil)
 }
 
-func (r mutationResolver) ChangeLabels(ctx context.Context, repoRef *string, prefix string, added []string, removed []string) (bug.Snapshot, error) {
-	repo, err := r.getRepo(repoRef)
+func (r mutationResolver) Commit(ctx context.Context, repoRef *string, prefix string) (models.BugPayload, error) {
+	repo, err := r.getRepo(ctx, repoRef)
 	if err != nil {
-		return bug.Snapshot{}, err
+		return bugPayload(nil, err)
 	}
 
-	b, err := repo.ResolveBugPrefix(prefix)
-	if err != nil {
-		return bug.Snapshot{}, err
+	if !CanWrite(ctx, repo.GetPath()) {
+		return bugPayload(nil, ErrReadOnlyUser)
 	}
 
-	_, err = b.ChangeLabels(added, removed)
+	b, err := resolveVisibleBug(ctx, repo, prefix)
 	if err != nil {
-		return bug.Snapshot{}, err
+		return bugPayload(nil, err)
 	}
 
-	snap := b.Snapshot()
+	err = b.Commit()
+	if err != nil {
+		return bugPayload(nil, err)
+	}
 
-	return *snap, nil
+	return bugPayload(b.Snapshot(), nil)
 }
 
-func (r mutationResolver) Open(ctx context.Context, repoRef *string, prefix string) (bug.Snapshot, error) {
-	repo, err := r.getRepo(repoRef)
+func (r mutationResolver) AddComment(ctx context.Context, repoRef *string, prefix string, message string, files []git.Hash) (models.BugPayload, error) {
+	b, author, err := r.resolveBug(ctx, repoRef, prefix)
+	if err != nil {
+		return bugPayload(nil, err)
+	}
+
+	err = b.AddCommentAs(author, message, files, nil)
 	if err != nil {
-		return bug.Snapshot{}, err
+		return bugPayload(nil, err)
 	}
 
-	b, err := repo.ResolveBugPrefix(prefix)
+	return bugPayload(b.Snapshot(), nil)
+}
+
+func (r mutationResolver) EditComment(ctx context.Context, repoRef *string, prefix string, target git.Hash, message string, clientMutationId *string, metadata []models.MetadataInput) (models.BugPayload, error) {
+	b, author, err := r.resolveBug(ctx, repoRef, prefix)
 	if err != nil {
-		return bug.Snapshot{}, err
+		return operationPayload(clientMutationId, nil, err)
 	}
 
-	err = b.Open()
+	meta, err := metadataMap(metadata)
 	if err != nil {
-		return bug.Snapshot{}, err
+		return operationPayload(clientMutationId, nil, err)
 	}
 
-	snap := b.Snapshot()
+	err = b.EditCommentAs(author, target, message, meta)
 
-	return *snap, nil
+	return operationPayload(clientMutationId, b, err)
 }
 
-func (r mutationResolver) Close(ctx context.Context, repoRef *string, prefix string) (bug.Snapshot, error) {
-	repo, err := r.getRepo(repoRef)
+func (r mutationResolver) ChangeLabels(ctx context.Context, repoRef *string, prefix string, added []string, removed []string, clientMutationId *string, metadata []models.MetadataInput) (models.BugPayload, error) {
+	b, author, err := r.resolveBug(ctx, repoRef, prefix)
+	if err != nil {
+		return operationPayload(clientMutationId, nil, err)
+	}
+
+	meta, err := metadataMap(metadata)
 	if err != nil {
-		return bug.Snapshot{}, err
+		return operationPayload(clientMutationId, nil, err)
 	}
 
-	b, err := repo.ResolveBugPrefix(prefix)
+	_, err = b.ChangeLabelsAs(author, added, removed, meta)
+
+	return operationPayload(clientMutationId, b, err)
+}
+
+func (r mutationResolver) OpenBug(ctx context.Context, repoRef *string, prefix string, clientMutationId *string, metadata []models.MetadataInput) (models.BugPayload, error) {
+	b, author, err := r.resolveBug(ctx, repoRef, prefix)
 	if err != nil {
-		return bug.Snapshot{}, err
+		return operationPayload(clientMutationId, nil, err)
 	}
 
-	err = b.Close()
+	meta, err := metadataMap(metadata)
 	if err != nil {
-		return bug.Snapshot{}, err
+		return operationPayload(clientMutationId, nil, err)
 	}
 
-	snap := b.Snapshot()
+	err = b.OpenAs(author, meta)
 
-	return *snap, nil
+	return operationPayload(clientMutationId, b, err)
 }
 
-func (r mutationResolver) SetTitle(ctx context.Context, repoRef *string, prefix string, title string) (bug.Snapshot, error) {
-	repo, err := r.getRepo(repoRef)
+func (r mutationResolver) CloseBug(ctx context.Context, repoRef *string, prefix string, clientMutationId *string, metadata []models.MetadataInput) (models.BugPayload, error) {
+	b, author, err := r.resolveBug(ctx, repoRef, prefix)
+	if err != nil {
+		return operationPayload(clientMutationId, nil, err)
+	}
+
+	meta, err := metadataMap(metadata)
 	if err != nil {
-		return bug.Snapshot{}, err
+		return operationPayload(clientMutationId, nil, err)
 	}
 
-	b, err := repo.ResolveBugPrefix(prefix)
+	err = b.CloseAs(author, meta)
+
+	return operationPayload(clientMutationId, b, err)
+}
+
+func (r mutationResolver) SetTitle(ctx context.Context, repoRef *string, prefix string, title string, clientMutationId *string, metadata []models.MetadataInput) (models.BugPayload, error) {
+	b, author, err := r.resolveBug(ctx, repoRef, prefix)
 	if err != nil {
-		return bug.Snapshot{}, err
+		return operationPayload(clientMutationId, nil, err)
 	}
 
-	err = b.SetTitle(title)
+	meta, err := metadataMap(metadata)
 	if err != nil {
-		return bug.Snapshot{}, err
+		return operationPayload(clientMutationId, nil, err)
 	}
 
-	snap := b.Snapshot()
+	err = b.SetTitleAs(author, title, meta)
+
+	return operationPayload(clientMutationId, b, err)
+}
+
+// Open is deprecated in favor of OpenBug
+func (r mutationResolver) Open(ctx context.Context, repoRef *string, prefix string) (models.BugPayload, error) {
+	return r.OpenBug(ctx, repoRef, prefix, nil, nil)
+}
 
-	return *snap, nil
+// Close is deprecated in favor of CloseBug
+func (r mutationResolver) Close(ctx context.Context, repoRef *string, prefix string) (models.BugPayload, error) {
+	return r.CloseBug(ctx, repoRef, prefix, nil, nil)
 }
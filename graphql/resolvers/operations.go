@@ -21,6 +21,48 @@ func (addCommentOperationResolver) Date(ctx context.Context, obj *bug.AddComment
 	return obj.Time(), nil
 }
 
+type addVoteOperationResolver struct{}
+
+func (addVoteOperationResolver) Date(ctx context.Context, obj *bug.AddVoteOperation) (time.Time, error) {
+	return obj.Time(), nil
+}
+
+type removeVoteOperationResolver struct{}
+
+func (removeVoteOperationResolver) Date(ctx context.Context, obj *bug.RemoveVoteOperation) (time.Time, error) {
+	return obj.Time(), nil
+}
+
+type setEstimateOperationResolver struct{}
+
+func (setEstimateOperationResolver) Date(ctx context.Context, obj *bug.SetEstimateOperation) (time.Time, error) {
+	return obj.Time(), nil
+}
+
+type setVisibilityOperationResolver struct{}
+
+func (setVisibilityOperationResolver) Date(ctx context.Context, obj *bug.SetVisibilityOperation) (time.Time, error) {
+	return obj.Time(), nil
+}
+
+type addFixedInOperationResolver struct{}
+
+func (addFixedInOperationResolver) Date(ctx context.Context, obj *bug.AddFixedInOperation) (time.Time, error) {
+	return obj.Time(), nil
+}
+
+type setAssigneeOperationResolver struct{}
+
+func (setAssigneeOperationResolver) Date(ctx context.Context, obj *bug.SetAssigneeOperation) (time.Time, error) {
+	return obj.Time(), nil
+}
+
+type setMetadataOperationResolver struct{}
+
+func (setMetadataOperationResolver) Date(ctx context.Context, obj *bug.SetMetadataOperation) (time.Time, error) {
+	return obj.Time(), nil
+}
+
 type editCommentOperationResolver struct{}
 
 func (editCommentOperationResolver) Date(ctx context.Context, obj *bug.EditCommentOperation) (time.Time, error) {
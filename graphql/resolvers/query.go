@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/graphql/models"
@@ -18,21 +19,83 @@ func (r rootQueryResolver) DefaultRepository(ctx context.Context) (*models.Repos
 		return nil, err
 	}
 
+	if err := checkRead(ctx, repo); err != nil {
+		return nil, err
+	}
+
 	return &models.Repository{
 		Cache: r.cache,
 		Repo:  repo,
 	}, nil
 }
 
-func (r rootQueryResolver) Repository(ctx context.Context, id string) (*models.Repository, error) {
-	repo, err := r.cache.ResolveRepo(id)
+func (r rootQueryResolver) Repository(ctx context.Context, ref *string, id *string) (*models.Repository, error) {
+	// id is the deprecated name of ref
+	if ref == nil {
+		ref = id
+	}
+	if ref == nil {
+		return nil, fmt.Errorf("the name of the repository is missing")
+	}
+
+	repo, err := r.cache.ResolveRepo(*ref)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err := checkRead(ctx, repo); err != nil {
+		return nil, err
+	}
+
 	return &models.Repository{
 		Cache: r.cache,
 		Repo:  repo,
+		Name:  repoName(*ref),
 	}, nil
 }
+
+func (r rootQueryResolver) Repositories(ctx context.Context) ([]models.Repository, error) {
+	names := r.cache.Names()
+	result := make([]models.Repository, 0, len(names))
+
+	for _, name := range names {
+		repo, err := r.cache.ResolveRepo(name)
+		if err != nil {
+			return nil, err
+		}
+
+		// the repositories the token of the user doesn't give access to
+		// are not listed
+		if !CanRead(ctx, repo.GetPath()) {
+			continue
+		}
+
+		result = append(result, models.Repository{
+			Cache: r.cache,
+			Repo:  repo,
+			Name:  repoName(name),
+		})
+	}
+
+	return result, nil
+}
+
+// resolveRepo retrieve a repository by name, or the default one if no name
+// is given
+func resolveRepo(c *cache.MultiRepoCache, repoRef *string) (*cache.RepoCache, error) {
+	if repoRef != nil {
+		return c.ResolveRepo(*repoRef)
+	}
+
+	return c.DefaultRepo()
+}
+
+// repoName return the name of a repository for the API, nil for the
+// default repository
+func repoName(name string) *string {
+	if name == "" {
+		return nil
+	}
+	return &name
+}
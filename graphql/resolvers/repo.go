@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
@@ -11,7 +12,12 @@ import (
 
 type repoResolver struct{}
 
-func (repoResolver) AllBugs(ctx context.Context, obj *models.Repository, after *string, before *string, first *int, last *int, queryStr *string) (models.BugConnection, error) {
+// AllBugs is deprecated in favor of Bugs
+func (r repoResolver) AllBugs(ctx context.Context, obj *models.Repository, after *string, before *string, first *int, last *int, queryStr *string) (models.BugConnection, error) {
+	return r.Bugs(ctx, obj, after, before, first, last, queryStr)
+}
+
+func (repoResolver) Bugs(ctx context.Context, obj *models.Repository, after *string, before *string, first *int, last *int, queryStr *string) (models.BugConnection, error) {
 	input := models.ConnectionInput{
 		Before: before,
 		After:  after,
@@ -21,7 +27,7 @@ func (repoResolver) AllBugs(ctx context.Context, obj *models.Repository, after *
 
 	var query *cache.Query
 	if queryStr != nil {
-		query2, err := cache.ParseQuery(*queryStr)
+		query2, err := obj.Repo.ParseQuery(*queryStr)
 		if err != nil {
 			return models.BugConnection{}, err
 		}
@@ -30,8 +36,7 @@ func (repoResolver) AllBugs(ctx context.Context, obj *models.Repository, after *
 		query = cache.NewQuery()
 	}
 
-	// Simply pass a []string with the ids to the pagination algorithm
-	source := obj.Repo.QueryBugs(query)
+	query.Audience = audienceFromContext(ctx, obj.Repo)
 
 	// The edger create a custom edge holding just the id
 	edger := func(id string, offset int) connections.Edge {
@@ -70,11 +75,55 @@ func (repoResolver) AllBugs(ctx context.Context, obj *models.Repository, after *
 		}, nil
 	}
 
+	// When paginating forward, only the requested page is extracted from the
+	// cache instead of sorting every bug
+	if input.First != nil && input.Before == nil && input.Last == nil {
+		return bugPage(obj.Repo, query, edger, conMaker, input)
+	}
+
+	// Simply pass a []string with the ids to the pagination algorithm
+	source := obj.Repo.QueryBugs(query)
+
 	return connections.StringCon(source, edger, conMaker, input)
 }
 
+// bugPage paginate forward the bugs matching a query
+func bugPage(repo *cache.RepoCache, query *cache.Query, edger connections.StringEdgeMaker, conMaker connections.StringConMaker, input models.ConnectionInput) (models.BugConnection, error) {
+	if *input.First < 0 {
+		return models.BugConnection{}, fmt.Errorf("first less than zero")
+	}
+
+	offset := 0
+	if input.After != nil {
+		after, err := connections.CursorToOffset(*input.After)
+		if err != nil {
+			return models.BugConnection{}, err
+		}
+		offset = after + 1
+	}
+
+	ids, total := repo.QueryBugsPage(query, offset, *input.First)
+
+	edges := make([]connections.LazyBugEdge, len(ids))
+	for i, id := range ids {
+		edges[i] = edger(id, offset+i).(connections.LazyBugEdge)
+	}
+
+	pageInfo := models.PageInfo{
+		HasPreviousPage: offset > 0,
+		HasNextPage:     offset+len(ids) < total,
+	}
+
+	if len(edges) > 0 {
+		pageInfo.StartCursor = edges[0].Cursor
+		pageInfo.EndCursor = edges[len(edges)-1].Cursor
+	}
+
+	return conMaker(edges, ids, pageInfo, total)
+}
+
 func (repoResolver) Bug(ctx context.Context, obj *models.Repository, prefix string) (*bug.Snapshot, error) {
-	b, err := obj.Repo.ResolveBugPrefix(prefix)
+	b, err := resolveVisibleBug(ctx, obj.Repo, prefix)
 
 	if err != nil {
 		return nil, err
@@ -82,3 +131,44 @@ func (repoResolver) Bug(ctx context.Context, obj *models.Repository, prefix stri
 
 	return b.Snapshot(), nil
 }
+
+func (repoResolver) AllowedActions(ctx context.Context, obj *models.Repository, prefix string) ([]string, error) {
+	b, err := resolveVisibleBug(ctx, obj.Repo, prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := authorFromContext(ctx, obj.Repo)
+	if err == ErrReadOnlyUser {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return b.AllowedActionsAs(author), nil
+}
+
+func (repoResolver) AllIdentities(ctx context.Context, obj *models.Repository, query *string) ([]cache.IdentityExcerpt, error) {
+	queryStr := ""
+	if query != nil {
+		queryStr = *query
+	}
+
+	identities, err := obj.Repo.QueryIdentities(queryStr)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]cache.IdentityExcerpt, len(identities))
+	for i, identity := range identities {
+		result[i] = *identity
+	}
+
+	return result, nil
+}
+
+func (repoResolver) Identity(ctx context.Context, obj *models.Repository, prefix string) (*cache.IdentityExcerpt, error) {
+	return obj.Repo.ResolveIdentityPrefix(prefix)
+}
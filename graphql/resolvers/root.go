@@ -28,14 +28,26 @@ func (r RootResolver) Mutation() graph.MutationResolver {
 	}
 }
 
-func (RootResolver) Bug() graph.BugResolver {
-	return &bugResolver{}
+func (r RootResolver) Subscription() graph.SubscriptionResolver {
+	return &subscriptionResolver{
+		cache: &r.MultiRepoCache,
+	}
+}
+
+func (r RootResolver) Bug() graph.BugResolver {
+	return &bugResolver{
+		cache: &r.MultiRepoCache,
+	}
 }
 
 func (r RootResolver) Person() graph.PersonResolver {
 	return &personResolver{}
 }
 
+func (RootResolver) Identity() graph.IdentityResolver {
+	return &identityResolver{}
+}
+
 func (RootResolver) CommentHistoryStep() graph.CommentHistoryStepResolver {
 	return &commentHistoryStepResolver{}
 }
@@ -68,6 +80,34 @@ func (RootResolver) AddCommentOperation() graph.AddCommentOperationResolver {
 	return &addCommentOperationResolver{}
 }
 
+func (RootResolver) AddVoteOperation() graph.AddVoteOperationResolver {
+	return &addVoteOperationResolver{}
+}
+
+func (RootResolver) RemoveVoteOperation() graph.RemoveVoteOperationResolver {
+	return &removeVoteOperationResolver{}
+}
+
+func (RootResolver) SetEstimateOperation() graph.SetEstimateOperationResolver {
+	return &setEstimateOperationResolver{}
+}
+
+func (RootResolver) SetVisibilityOperation() graph.SetVisibilityOperationResolver {
+	return &setVisibilityOperationResolver{}
+}
+
+func (RootResolver) AddFixedInOperation() graph.AddFixedInOperationResolver {
+	return &addFixedInOperationResolver{}
+}
+
+func (RootResolver) SetAssigneeOperation() graph.SetAssigneeOperationResolver {
+	return &setAssigneeOperationResolver{}
+}
+
+func (RootResolver) SetMetadataOperation() graph.SetMetadataOperationResolver {
+	return &setMetadataOperationResolver{}
+}
+
 func (r RootResolver) EditCommentOperation() graph.EditCommentOperationResolver {
 	return &editCommentOperationResolver{}
 }
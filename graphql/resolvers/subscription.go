@@ -0,0 +1,102 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+type subscriptionResolver struct {
+	cache *cache.MultiRepoCache
+}
+
+func (r subscriptionResolver) getRepo(ctx context.Context, repoRef *string) (*cache.RepoCache, error) {
+	repo, err := resolveRepo(r.cache, repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkRead(ctx, repo); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+func (r subscriptionResolver) BugUpdated(ctx context.Context, repoRef *string, prefix *string) (<-chan bug.Snapshot, error) {
+	repo, err := r.getRepo(ctx, repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	id := ""
+	if prefix != nil {
+		b, err := resolveVisibleBug(ctx, repo, *prefix)
+		if err != nil {
+			return nil, err
+		}
+		id = b.Id()
+	}
+
+	return subscribeBugs(ctx, repo, func(event cache.BugEvent) bool {
+		return event.Kind == cache.BugUpdated && (id == "" || event.Id == id)
+	}), nil
+}
+
+func (r subscriptionResolver) BugCreated(ctx context.Context, repoRef *string) (<-chan bug.Snapshot, error) {
+	repo, err := r.getRepo(ctx, repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscribeBugs(ctx, repo, func(event cache.BugEvent) bool {
+		return event.Kind == cache.BugCreated
+	}), nil
+}
+
+// subscribeBugs send the bugs of the events of the cache accepted by the
+// filter, until the subscription ends. The bugs the audience can't see are
+// skipped.
+func subscribeBugs(ctx context.Context, repo *cache.RepoCache, filter func(cache.BugEvent) bool) <-chan bug.Snapshot {
+	events, cancel := repo.Subscribe()
+	out := make(chan bug.Snapshot)
+
+	go func() {
+		defer close(out)
+		defer cancel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+
+				if !filter(event) {
+					continue
+				}
+
+				b, err := repo.ResolveBug(event.Id)
+				if err != nil {
+					continue
+				}
+
+				snap := b.Snapshot()
+				if !audienceFromContext(ctx, repo).CanSee(snap.Visibility) {
+					continue
+				}
+
+				select {
+				case out <- *snap:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
@@ -17,7 +17,8 @@ import (
 	"github.com/pkg/errors"
 )
 
-const messageFilename = "BUG_MESSAGE_EDITMSG"
+// MessageFilename is the name of the file edited to write a bug message
+const MessageFilename = "BUG_MESSAGE_EDITMSG"
 
 // ErrEmptyMessage is returned when the required message has not been entered
 var ErrEmptyMessage = errors.New("empty message")
@@ -42,7 +43,7 @@ func BugCreateEditorInput(repo repository.RepoCommon, preTitle string, preMessag
 
 	template := fmt.Sprintf(bugTitleCommentTemplate, preTitle, preMessage)
 
-	raw, err := launchEditorWithTemplate(repo, messageFilename, template)
+	raw, err := launchEditorWithTemplate(repo, MessageFilename, template)
 
 	if err != nil {
 		return "", "", err
@@ -103,7 +104,7 @@ const bugCommentTemplate = `%s
 // template for the user to fill. The file is then processed to extract a comment.
 func BugCommentEditorInput(repo repository.RepoCommon, preMessage string) (string, error) {
 	template := fmt.Sprintf(bugCommentTemplate, preMessage)
-	raw, err := launchEditorWithTemplate(repo, messageFilename, template)
+	raw, err := launchEditorWithTemplate(repo, MessageFilename, template)
 
 	if err != nil {
 		return "", err
@@ -154,7 +155,7 @@ const bugTitleTemplate = `%s
 // template for the user to fill. The file is then processed to extract a title.
 func BugTitleEditorInput(repo repository.RepoCommon, preTitle string) (string, error) {
 	template := fmt.Sprintf(bugTitleTemplate, preTitle)
-	raw, err := launchEditorWithTemplate(repo, messageFilename, template)
+	raw, err := launchEditorWithTemplate(repo, MessageFilename, template)
 
 	if err != nil {
 		return "", err
@@ -213,7 +214,7 @@ const queryTemplate = `%s
 // template for the user to fill. The file is then processed to extract a query.
 func QueryEditorInput(repo repository.RepoCommon, preQuery string) (string, error) {
 	template := fmt.Sprintf(queryTemplate, preQuery)
-	raw, err := launchEditorWithTemplate(repo, messageFilename, template)
+	raw, err := launchEditorWithTemplate(repo, MessageFilename, template)
 
 	if err != nil {
 		return "", err
@@ -238,7 +239,7 @@ func QueryEditorInput(repo repository.RepoCommon, preQuery string) (string, erro
 // launchEditorWithTemplate will launch an editor as launchEditor do, but with a
 // provided template.
 func launchEditorWithTemplate(repo repository.RepoCommon, fileName string, template string) (string, error) {
-	path := fmt.Sprintf("%s/.git/%s", repo.GetPath(), fileName)
+	path := fmt.Sprintf("%s/%s", repo.GetGitDir(), fileName)
 
 	err := ioutil.WriteFile(path, []byte(template), 0644)
 
@@ -253,13 +254,13 @@ func launchEditorWithTemplate(repo repository.RepoCommon, fileName string, templ
 // method blocks until the editor command has returned.
 //
 // The specified filename should be a temporary file and provided as a relative path
-// from the repo (e.g. "FILENAME" will be converted to ".git/FILENAME"). This file
+// from the git directory of the repo (e.g. "FILENAME" will be converted to ".git/FILENAME"). This file
 // will be deleted after the editor is closed and its contents have been read.
 //
 // This method returns the text that was read from the temporary file, or
 // an error if any step in the process failed.
 func launchEditor(repo repository.RepoCommon, fileName string) (string, error) {
-	path := fmt.Sprintf("%s/.git/%s", repo.GetPath(), fileName)
+	path := fmt.Sprintf("%s/%s", repo.GetGitDir(), fileName)
 	defer os.Remove(path)
 
 	editor, err := repo.GetCoreEditor()
@@ -0,0 +1,77 @@
+package input
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// stdin is shared by the prompts, so that the input buffered by one is not
+// lost for the next
+var stdin = bufio.NewReader(os.Stdin)
+
+// PromptYesNo ask a yes/no question on the terminal, with no as the default
+// answer
+func PromptYesNo(question string) (bool, error) {
+	fmt.Printf("%s [y/N]: ", question)
+
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Println()
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// Prompt ask a question on the terminal and return the answer, or the
+// default value if the answer is empty
+func Prompt(question string, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Printf("%s [%s]: ", question, defaultValue)
+	} else {
+		fmt.Printf("%s: ", question)
+	}
+
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Println()
+		return "", err
+	}
+
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return defaultValue, nil
+	}
+
+	return line, nil
+}
+
+// PromptChoice ask to choose one of the choices by its number, and return
+// its index. An empty answer select the default choice.
+func PromptChoice(question string, choices []string, defaultChoice int) (int, error) {
+	for i, choice := range choices {
+		fmt.Printf("[%d]: %s\n", i+1, choice)
+	}
+
+	for {
+		answer, err := Prompt(question, strconv.Itoa(defaultChoice+1))
+		if err != nil {
+			return 0, err
+		}
+
+		index, err := strconv.Atoi(answer)
+		if err == nil && index > 0 && index <= len(choices) {
+			return index - 1, nil
+		}
+
+		fmt.Println("invalid input")
+	}
+}
@@ -0,0 +1,117 @@
+// Package editor generate the configuration snippets integrating git-bug in
+// text editors: syntax highlighting of the bug edit buffer, jumping to the
+// bug referenced by a commit trailer and running the git-bug commands.
+//
+// The snippets are generated from the live command tree, so that they stay
+// in sync with the installed version of git-bug.
+package editor
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/input"
+	"github.com/spf13/cobra"
+)
+
+var templates = map[string]string{
+	"vim":   vimTemplate,
+	"emacs": emacsTemplate,
+}
+
+// Editors return the name of the supported editors
+func Editors() []string {
+	var result []string
+	for name := range templates {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// CommandNode is a command and the name of its sub-commands, for the
+// completion in the editors
+type CommandNode struct {
+	// Path is the path of the command, without the root command. Empty for
+	// the root command.
+	Path        string
+	SubCommands []string
+}
+
+type templateData struct {
+	RootCommand     string
+	MessageFilename string
+	TrailerKeywords []string
+	Commands        []CommandNode
+}
+
+// Generate write the configuration snippet for an editor
+func Generate(w io.Writer, editor string, root *cobra.Command) error {
+	raw, ok := templates[editor]
+	if !ok {
+		return fmt.Errorf("unsupported editor %s, valid values are [%s]",
+			editor, strings.Join(Editors(), ","))
+	}
+
+	funcs := template.FuncMap{
+		"join": strings.Join,
+	}
+
+	tmpl, err := template.New(editor).Funcs(funcs).Parse(raw)
+	if err != nil {
+		return err
+	}
+
+	data := templateData{
+		RootCommand:     root.Name(),
+		MessageFilename: input.MessageFilename,
+		TrailerKeywords: cache.FixTrailerKeywords,
+		Commands:        commandTree(root),
+	}
+
+	return tmpl.Execute(w, data)
+}
+
+// commandTree walk the available commands, in a stable order
+func commandTree(root *cobra.Command) []CommandNode {
+	var result []CommandNode
+
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		var subs []*cobra.Command
+		for _, sub := range cmd.Commands() {
+			if sub.IsAvailableCommand() {
+				subs = append(subs, sub)
+			}
+		}
+
+		if len(subs) == 0 {
+			return
+		}
+
+		node := CommandNode{
+			Path: strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), root.CommandPath())),
+		}
+		for _, sub := range subs {
+			node.SubCommands = append(node.SubCommands, sub.Name())
+		}
+		sort.Strings(node.SubCommands)
+		result = append(result, node)
+
+		for _, sub := range subs {
+			walk(sub)
+		}
+	}
+
+	walk(root)
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+
+	return result
+}
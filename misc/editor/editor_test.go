@@ -0,0 +1,51 @@
+package editor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func testCommandTree() *cobra.Command {
+	run := func(cmd *cobra.Command, args []string) {}
+
+	root := &cobra.Command{Use: "git-bug", Run: run}
+	label := &cobra.Command{Use: "label", Run: run}
+	label.AddCommand(&cobra.Command{Use: "rm", Run: run})
+	label.AddCommand(&cobra.Command{Use: "add", Run: run})
+	root.AddCommand(label)
+	root.AddCommand(&cobra.Command{Use: "ls", Run: run})
+	root.AddCommand(&cobra.Command{Use: "secret", Run: run, Hidden: true})
+
+	return root
+}
+
+func TestCommandTree(t *testing.T) {
+	tree := commandTree(testCommandTree())
+
+	assert.Equal(t, []CommandNode{
+		{Path: "", SubCommands: []string{"label", "ls"}},
+		{Path: "label", SubCommands: []string{"add", "rm"}},
+	}, tree)
+}
+
+func TestGenerate(t *testing.T) {
+	for _, editor := range Editors() {
+		var buf bytes.Buffer
+		err := Generate(&buf, editor, testCommandTree())
+		assert.NoError(t, err, editor)
+
+		output := buf.String()
+		assert.True(t, strings.Contains(output, "BUG_MESSAGE_EDITMSG"), editor)
+		assert.True(t, strings.Contains(output, "resolves"), editor)
+		assert.True(t, strings.Contains(output, "'label': ['add', 'rm']") ||
+			strings.Contains(output, `("label" "add" "rm")`), editor)
+		assert.False(t, strings.Contains(output, "secret"), editor)
+	}
+
+	err := Generate(&bytes.Buffer{}, "notepad", testCommandTree())
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package editor
+
+const emacsTemplate = `;;; git-bug.el --- git-bug integration  -*- lexical-binding: t -*-
+
+;; Generated by "{{.RootCommand}} completion --editor emacs", regenerate it after
+;; upgrading git-bug. To install it:
+;;
+;;   {{.RootCommand}} completion --editor emacs > ~/.emacs.d/lisp/git-bug.el
+;;
+;; and add (require 'git-bug) to your init file, with ~/.emacs.d/lisp in your
+;; load-path.
+;;
+;; - the bug edit buffer ({{.MessageFilename}}) use git-bug-message-mode
+;; - M-x git-bug-show show the bug at point or referenced by the trailer of
+;;   the line, such as "Fixes: #12"
+;; - M-x git-bug run a git-bug command, with completion
+
+;;; Code:
+
+(defconst git-bug-program "{{.RootCommand}}")
+
+(defconst git-bug-trailer-regexp
+  "^\\(?:{{join .TrailerKeywords "\\\\|"}}\\)\\(?:-bug\\)?:[ \t]*")
+
+(defconst git-bug-commands
+  '(
+{{- range .Commands}}
+    ("{{.Path}}"{{range .SubCommands}} "{{.}}"{{end}})
+{{- end}}
+    ))
+
+(defvar git-bug-message-font-lock-keywords
+  '(("^#.*$" . font-lock-comment-face)
+    ("\\` + "`" + ` *\n*\\([^#\n].*\\)$" 1 font-lock-function-name-face)
+    ("#[0-9]+\\b" . font-lock-constant-face)))
+
+(define-derived-mode git-bug-message-mode text-mode "git-bug"
+  "Major mode for the git-bug edit buffer."
+  (setq-local comment-start "#")
+  (setq font-lock-defaults '(git-bug-message-font-lock-keywords)))
+
+(add-to-list 'auto-mode-alist
+             '("/{{.MessageFilename}}\\'" . git-bug-message-mode))
+
+(defun git-bug-ref-at-point ()
+  "Return the bug reference at point, or the first one of the trailer of the line."
+  (let ((word (save-excursion
+                (skip-chars-backward "^ \t\n,")
+                (let ((start (point)))
+                  (skip-chars-forward "^ \t\n,")
+                  (buffer-substring-no-properties start (point)))))
+        (case-fold-search t))
+    (if (or (string-match-p "\\` + "`" + `#[0-9]+\\'" word)
+            (string-match-p "\\` + "`" + `[0-9a-f]\\{7,\\}\\'" word))
+        word
+      (let ((line (buffer-substring-no-properties
+                   (line-beginning-position) (line-end-position))))
+        (when (string-match git-bug-trailer-regexp line)
+          (car (split-string (substring line (match-end 0)) "[, \t]+" t)))))))
+
+(defun git-bug-show (ref)
+  "Show the bug REF, by default the one at point."
+  (interactive
+   (list (read-string "Bug: " (git-bug-ref-at-point))))
+  (let ((buffer (get-buffer-create (format "*git-bug %s*" ref))))
+    (with-current-buffer buffer
+      (let ((inhibit-read-only t))
+        (erase-buffer)
+        (call-process git-bug-program nil t nil "show" ref)
+        (goto-char (point-min)))
+      (special-mode))
+    (pop-to-buffer buffer)))
+
+(defun git-bug-read-command ()
+  "Read a git-bug command, one level of sub-commands at a time."
+  (let ((path ""))
+    (catch 'done
+      (while t
+        (let ((subs (cdr (assoc path git-bug-commands))))
+          (unless subs
+            (throw 'done path))
+          (let ((sub (completing-read
+                      (format "%s %s: " git-bug-program path) subs)))
+            (when (string= sub "")
+              (throw 'done path))
+            (setq path (string-trim (concat path " " sub)))))))))
+
+(defun git-bug (command args)
+  "Run the git-bug COMMAND with ARGS."
+  (interactive
+   (let ((command (git-bug-read-command)))
+     (list command (read-string (format "%s %s " git-bug-program command)))))
+  (async-shell-command
+   (mapconcat #'identity
+              (delete "" (list git-bug-program command args))
+              " ")))
+
+(provide 'git-bug)
+
+;;; git-bug.el ends here
+`
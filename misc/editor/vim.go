@@ -0,0 +1,100 @@
+package editor
+
+const vimTemplate = `" git-bug integration for vim
+"
+" Generated by "{{.RootCommand}} completion --editor vim", regenerate it after
+" upgrading git-bug. To install it:
+"
+"   {{.RootCommand}} completion --editor vim > ~/.vim/plugin/git-bug.vim
+"
+" - the bug edit buffer ({{.MessageFilename}}) is highlighted
+" - in a commit message, "gb" show the bug under the cursor or referenced by
+"   the trailer of the line, such as "Fixes: #12"
+" - ":GitBug <command>" run a git-bug command, with completion
+
+if exists('g:loaded_git_bug')
+  finish
+endif
+let g:loaded_git_bug = 1
+
+let s:trailer_pattern = '\c^\%({{join .TrailerKeywords "\\|"}}\)\%(-bug\)\?:'
+
+let s:commands = {
+{{- range .Commands}}
+  \ '{{.Path}}': [{{range $i, $sub := .SubCommands}}{{if $i}}, {{end}}'{{$sub}}'{{end}}],
+{{- end}}
+  \ }
+
+function! s:MessageSyntax() abort
+  syntax match gitbugComment /^#.*/
+  syntax match gitbugTitle /\%^\_s*\zs[^#].*/
+  syntax match gitbugAlias /#\d\+\>/
+  highlight default link gitbugComment Comment
+  highlight default link gitbugTitle Title
+  highlight default link gitbugAlias Identifier
+endfunction
+
+function! s:TrailerSyntax() abort
+  execute 'syntax match gitbugTrailer /' . s:trailer_pattern . '.*/ containedin=ALL'
+  highlight default link gitbugTrailer Special
+endfunction
+
+" Return the bug reference under the cursor, or the first one of the trailer
+" of the line
+function! GitBugRefAtCursor() abort
+  let l:word = substitute(expand('<cWORD>'), '[,;.]\+$', '', '')
+  if l:word =~# '^#\d\+$' || l:word =~# '^\x\{7,}$'
+    return l:word
+  endif
+
+  let l:line = getline('.')
+  if l:line =~# s:trailer_pattern
+    let l:refs = split(substitute(l:line, s:trailer_pattern, '', ''), '[, \t]\+')
+    if !empty(l:refs)
+      return l:refs[0]
+    endif
+  endif
+
+  return ''
+endfunction
+
+function! GitBugShow(ref) abort
+  if a:ref ==# ''
+    echohl ErrorMsg | echo 'No bug reference under the cursor' | echohl None
+    return
+  endif
+
+  let l:output = systemlist('{{.RootCommand}} show ' . shellescape(a:ref))
+  if v:shell_error
+    echohl ErrorMsg | echo join(l:output, "\n") | echohl None
+    return
+  endif
+
+  new
+  setlocal buftype=nofile bufhidden=wipe noswapfile
+  execute 'file git-bug\ ' . fnameescape(a:ref)
+  call setline(1, l:output)
+  setlocal nomodifiable
+endfunction
+
+function! GitBugComplete(arglead, cmdline, cursorpos) abort
+  let l:args = split(strpart(a:cmdline, 0, a:cursorpos))[1:]
+  if a:arglead !=# ''
+    let l:args = l:args[:-2]
+  endif
+  let l:subs = get(s:commands, join(l:args, ' '), [])
+  return filter(copy(l:subs), 'stridx(v:val, a:arglead) == 0')
+endfunction
+
+command! -nargs=+ -complete=customlist,GitBugComplete GitBug
+  \ execute '!{{.RootCommand}} ' . <q-args>
+
+augroup git_bug
+  autocmd!
+  autocmd BufRead,BufNewFile {{.MessageFilename}} setfiletype gitbug
+  autocmd FileType gitbug call s:MessageSyntax()
+  autocmd FileType gitcommit call s:TrailerSyntax()
+  autocmd FileType gitcommit,gitbug
+    \ nnoremap <buffer> <silent> gb :call GitBugShow(GitBugRefAtCursor())<CR>
+augroup END
+`
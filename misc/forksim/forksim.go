@@ -0,0 +1,307 @@
+// Package forksim simulate two people editing the same bug concurrently, in
+// two clones sharing a remote, to show how the divergent operations are merged.
+//
+// The simulation runs in sandboxes holding a copy of the bug: a bare remote
+// and two clones, left and right. Each clone apply its own operations, then
+// left push first, and right pull and push, as a user would do when its push
+// is rejected. Finally left pull the merged result.
+//
+// The sandboxes can be kept to be inspected, or used as test fixtures.
+package forksim
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+const remoteName = "origin"
+
+// Step is an operation applied on one side of the simulation
+type Step struct {
+	Kind  string
+	Value string
+}
+
+// StepKinds are the supported kind of steps, with the expected value
+var StepKinds = []string{
+	"comment:<message>",
+	"title:<title>",
+	"label:<label>",
+	"unlabel:<label>",
+	"status:<open|closed>",
+}
+
+// ParseStep parse a step in the form "kind:value"
+func ParseStep(s string) (Step, error) {
+	split := strings.SplitN(s, ":", 2)
+	if len(split) != 2 || split[1] == "" {
+		return Step{}, fmt.Errorf("invalid step \"%s\", expected one of [%s]",
+			s, strings.Join(StepKinds, ","))
+	}
+
+	step := Step{Kind: split[0], Value: split[1]}
+
+	switch step.Kind {
+	case "comment", "title", "label", "unlabel":
+	case "status":
+		if _, err := bug.StatusFromString(step.Value); err != nil {
+			return Step{}, err
+		}
+	default:
+		return Step{}, fmt.Errorf("unknown step kind \"%s\", expected one of [%s]",
+			step.Kind, strings.Join(StepKinds, ","))
+	}
+
+	return step, nil
+}
+
+func (s Step) String() string {
+	return s.Kind + ":" + s.Value
+}
+
+// apply stage the step on a bug
+func (s Step) apply(b *bug.Bug, author bug.Person, unixTime int64) error {
+	var err error
+
+	switch s.Kind {
+	case "comment":
+		_, err = bug.AddComment(b, author, unixTime, s.Value)
+	case "title":
+		_, err = bug.SetTitle(b, author, unixTime, s.Value)
+	case "label":
+		_, _, err = bug.ChangeLabels(b, author, unixTime, []string{s.Value}, nil)
+	case "unlabel":
+		_, _, err = bug.ChangeLabels(b, author, unixTime, nil, []string{s.Value})
+	case "status":
+		status, _ := bug.StatusFromString(s.Value)
+		if status == bug.OpenStatus {
+			_, err = bug.Open(b, author, unixTime)
+		} else {
+			_, err = bug.Close(b, author, unixTime)
+		}
+	}
+
+	return err
+}
+
+// Simulation hold the sandboxes of a simulation
+type Simulation struct {
+	// Dir is the directory holding the sandboxes
+	Dir string
+
+	bugId  string
+	author bug.Person
+	keep   bool
+
+	remote *repository.GitRepo
+	left   *repository.GitRepo
+	right  *repository.GitRepo
+}
+
+// Report is the outcome of a simulation
+type Report struct {
+	LeftSteps  []Step
+	RightSteps []Step
+
+	// RightMerge is the merge of the left changes in right
+	RightMerge bug.MergeResult
+	// LeftMerge is the merge of the merged result back in left
+	LeftMerge bug.MergeResult
+
+	// Converged is true if both clones end up with the same operations
+	Converged bool
+	// Final is the state of the bug in left at the end of the simulation
+	Final *bug.Bug
+}
+
+// New create the sandboxes holding a copy of a bug of the repository. If dir
+// is empty, the sandboxes are created in a temporary directory removed by
+// Close, otherwise they are kept in dir.
+func New(repo repository.ClockedRepo, bugId string, dir string) (*Simulation, error) {
+	author, err := bug.GetUser(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	sim := &Simulation{
+		Dir:    dir,
+		bugId:  bugId,
+		author: author,
+		keep:   dir != "",
+	}
+
+	if sim.keep {
+		err = os.MkdirAll(dir, 0755)
+	} else {
+		sim.Dir, err = ioutil.TempDir("", "git-bug-fork-sim")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = sim.setup(repo)
+	if err != nil {
+		_ = sim.Close()
+		return nil, err
+	}
+
+	return sim, nil
+}
+
+func (s *Simulation) setup(repo repository.ClockedRepo) error {
+	var err error
+
+	s.remote, err = repository.InitBareGitRepo(filepath.Join(s.Dir, "remote"))
+	if err != nil {
+		return err
+	}
+
+	bugRef := "refs/bugs/" + s.bugId
+	_, err = s.remote.FetchRefs(repo.GetPath(), bugRef+":"+bugRef)
+	if err != nil {
+		return err
+	}
+
+	s.left, err = s.clone("left")
+	if err != nil {
+		return err
+	}
+
+	s.right, err = s.clone("right")
+	return err
+}
+
+// clone create a sandbox with the bug pulled from the remote
+func (s *Simulation) clone(name string) (*repository.GitRepo, error) {
+	repo, err := repository.InitGitRepo(filepath.Join(s.Dir, name))
+	if err != nil {
+		return nil, err
+	}
+
+	configs := map[string]string{
+		"user.name":                              s.author.Name,
+		"user.email":                             s.author.Email,
+		fmt.Sprintf("remote.%s.url", remoteName): s.remote.GetPath(),
+	}
+
+	for key, value := range configs {
+		err := repo.StoreConfig(key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return repo, bug.Pull(repo, remoteName)
+}
+
+// Close remove the sandboxes, unless they are kept
+func (s *Simulation) Close() error {
+	if s.keep {
+		return nil
+	}
+	return os.RemoveAll(s.Dir)
+}
+
+// Run apply the steps in each clone, merge the changes and report the outcome
+func (s *Simulation) Run(left []Step, right []Step) (*Report, error) {
+	report := &Report{
+		LeftSteps:  left,
+		RightSteps: right,
+	}
+
+	// the steps of right happen after the ones of left
+	unixTime := time.Now().Unix()
+
+	err := s.applySteps(s.left, left, unixTime)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.applySteps(s.right, right, unixTime+int64(len(left)))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = bug.Push(s.left, remoteName)
+	if err != nil {
+		return nil, err
+	}
+
+	report.RightMerge, err = s.pull(s.right)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = bug.Push(s.right, remoteName)
+	if err != nil {
+		return nil, err
+	}
+
+	report.LeftMerge, err = s.pull(s.left)
+	if err != nil {
+		return nil, err
+	}
+
+	leftBug, err := bug.ReadLocalBug(s.left, s.bugId)
+	if err != nil {
+		return nil, err
+	}
+
+	rightBug, err := bug.ReadLocalBug(s.right, s.bugId)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Converged = leftBug.LastCommit() == rightBug.LastCommit()
+	report.Final = leftBug
+
+	return report, nil
+}
+
+func (s *Simulation) applySteps(repo *repository.GitRepo, steps []Step, unixTime int64) error {
+	if len(steps) == 0 {
+		return nil
+	}
+
+	b, err := bug.ReadLocalBug(repo, s.bugId)
+	if err != nil {
+		return err
+	}
+
+	for i, step := range steps {
+		err := step.apply(b, s.author, unixTime+int64(i))
+		if err != nil {
+			return fmt.Errorf("step \"%s\": %v", step, err)
+		}
+	}
+
+	return b.Commit(repo)
+}
+
+// pull fetch the remote and merge the bug, returning the result of the merge
+func (s *Simulation) pull(repo *repository.GitRepo) (bug.MergeResult, error) {
+	_, err := bug.Fetch(repo, remoteName)
+	if err != nil {
+		return bug.MergeResult{}, err
+	}
+
+	result := bug.MergeResult{Id: s.bugId, Status: bug.MergeStatusNothing}
+
+	for merge := range bug.MergeAll(repo, remoteName) {
+		if merge.Err != nil {
+			return merge, merge.Err
+		}
+		if merge.Id == s.bugId {
+			result = merge
+		}
+	}
+
+	return result, nil
+}
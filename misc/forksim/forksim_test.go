@@ -0,0 +1,72 @@
+package forksim
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStep(t *testing.T) {
+	step, err := ParseStep("comment:hello: world")
+	assert.NoError(t, err)
+	assert.Equal(t, Step{Kind: "comment", Value: "hello: world"}, step)
+
+	_, err = ParseStep("status:closed")
+	assert.NoError(t, err)
+
+	for _, invalid := range []string{"comment", "title:", "status:maybe", "vote:1"} {
+		_, err = ParseStep(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestSimulation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	repo, err := repository.InitGitRepo(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.StoreConfig("user.name", "René Descartes"))
+	assert.NoError(t, repo.StoreConfig("user.email", "rene@descartes.fr"))
+
+	author := bug.Person{Name: "René Descartes", Email: "rene@descartes.fr"}
+	b, _, err := bug.Create(author, time.Now().Unix(), "title", "message")
+	assert.NoError(t, err)
+	assert.NoError(t, b.Commit(repo))
+
+	sim, err := New(repo, b.Id(), "")
+	assert.NoError(t, err)
+	defer sim.Close()
+
+	report, err := sim.Run(
+		[]Step{{Kind: "title", Value: "left"}, {Kind: "label", Value: "ui"}},
+		[]Step{{Kind: "title", Value: "right"}, {Kind: "status", Value: "closed"}},
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, bug.MergeStatusUpdated, report.RightMerge.Status)
+	assert.Equal(t, bug.MergeStatusUpdated, report.LeftMerge.Status)
+	assert.True(t, report.Converged)
+
+	// the operations of right are rebased on top of the ones of left
+	snap := report.Final.Compile()
+	assert.Equal(t, "right", snap.Title)
+	assert.Equal(t, bug.ClosedStatus, snap.Status)
+	assert.Equal(t, []bug.Label{"ui"}, snap.Labels)
+
+	// the repository itself is not modified
+	original, err := bug.ReadLocalBug(repo, b.Id())
+	assert.NoError(t, err)
+	assert.Equal(t, b.LastCommit(), original.LastCommit())
+
+	// the temporary sandboxes are removed
+	assert.NoError(t, sim.Close())
+	_, err = os.Stat(sim.Dir)
+	assert.True(t, os.IsNotExist(err))
+}
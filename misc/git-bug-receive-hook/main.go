@@ -0,0 +1,120 @@
+// git-bug-receive-hook validate the bugs pushed to a shared repository. It's
+// meant to be installed by the administrators of a git hosting as the
+// pre-receive hook of the repository:
+//
+//	go build github.com/MichaelMure/git-bug/misc/git-bug-receive-hook
+//	cp git-bug-receive-hook /path/to/repo.git/hooks/pre-receive
+//
+// Each pushed bug must be well formed and only fast-forwarded, its new
+// operations must be allowed by the policy of the repository, and it must
+// stay within the limits:
+//
+//	git config git-bug.receive.max-pack-size 1048576
+//	git config git-bug.receive.max-operations 1000
+//
+// An updated policy must be valid, and signed unless
+// git-bug.policy-require-signature is set to false. A push updating an invalid
+// ref is rejected as a whole. The refs not belonging to git-bug are left alone.
+//
+// To accept encrypted bugs, the encryption key of the repository must be
+// configured, as for a clone.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/MichaelMure/git-bug/util/lamport"
+)
+
+// hookRepo is the repository receiving the push. The hook must not change
+// it, so the clocks are only kept in memory.
+type hookRepo struct {
+	*repository.GitRepo
+	createClock lamport.Clock
+	editClock   lamport.Clock
+}
+
+func (r *hookRepo) LoadClocks() error {
+	return nil
+}
+
+func (r *hookRepo) WriteClocks() error {
+	return nil
+}
+
+func (r *hookRepo) CreateTimeIncrement() (lamport.Time, error) {
+	return r.createClock.Increment(), nil
+}
+
+func (r *hookRepo) EditTimeIncrement() (lamport.Time, error) {
+	return r.editClock.Increment(), nil
+}
+
+func (r *hookRepo) CreateWitness(time lamport.Time) error {
+	r.createClock.Witness(time)
+	return nil
+}
+
+func (r *hookRepo) EditWitness(time lamport.Time) error {
+	r.editClock.Witness(time)
+	return nil
+}
+
+func main() {
+	rejected, err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "git-bug: %v\n", err)
+		os.Exit(1)
+	}
+	if rejected {
+		os.Exit(1)
+	}
+}
+
+// run validate each ref update given on the standard input, as
+// "<old-value> <new-value> <ref-name>" lines. It return true if any of them
+// is rejected.
+func run() (bool, error) {
+	// hooks run in the git directory of the repository, bare or not
+	dir, err := os.Getwd()
+	if err != nil {
+		return false, err
+	}
+
+	repo := &hookRepo{
+		GitRepo:     &repository.GitRepo{Path: dir, GitDir: dir},
+		createClock: lamport.NewClock(),
+		editClock:   lamport.NewClock(),
+	}
+
+	limits, err := bug.ReadReceiveLimits(repo)
+	if err != nil {
+		return false, err
+	}
+
+	rejected := false
+	scanner := bufio.NewScanner(os.Stdin)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			return false, fmt.Errorf("unexpected input: %s", scanner.Text())
+		}
+
+		oldHash, newHash, ref := git.Hash(fields[0]), git.Hash(fields[1]), fields[2]
+
+		err := bug.ValidateReceivedRef(repo, ref, oldHash, newHash, limits)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "git-bug: rejected %s: %v\n", ref, err)
+			rejected = true
+		}
+	}
+
+	return rejected, scanner.Err()
+}
@@ -3,35 +3,47 @@ package repository
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/MichaelMure/git-bug/util/git"
 	"github.com/MichaelMure/git-bug/util/lamport"
 )
 
-const createClockFile = "/.git/git-bug/create-clock"
-const editClockFile = "/.git/git-bug/edit-clock"
+const createClockFile = "/git-bug/create-clock"
+const editClockFile = "/git-bug/edit-clock"
 
 // ErrNotARepo is the error returned when the git repo root wan't be found
 var ErrNotARepo = errors.New("not a git repository")
 
 // GitRepo represents an instance of a (local) git repository.
 type GitRepo struct {
-	Path        string
+	Path string
+	// GitDir is the git directory, shared by all the worktrees of the repo
+	GitDir      string
 	createClock *lamport.Persisted
 	editClock   *lamport.Persisted
 }
 
 // Run the given git command with the given I/O reader/writers, returning an error if it fails.
 func (repo *GitRepo) runGitCommandWithIO(stdin io.Reader, stdout, stderr io.Writer, args ...string) error {
+	return repo.runGitCommandWithIOContext(context.Background(), stdin, stdout, stderr, args...)
+}
+
+// Run the given git command with the given I/O reader/writers, killing it if
+// the context is canceled before it finishes.
+func (repo *GitRepo) runGitCommandWithIOContext(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, args ...string) error {
 	//fmt.Println("Running git", strings.Join(args, " "))
 
-	cmd := exec.Command("git", args...)
+	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Dir = repo.Path
 	cmd.Stdin = stdin
 	cmd.Stdout = stdout
@@ -42,9 +54,15 @@ func (repo *GitRepo) runGitCommandWithIO(stdin io.Reader, stdout, stderr io.Writ
 
 // Run the given git command and return its stdout, or an error if the command fails.
 func (repo *GitRepo) runGitCommandRaw(stdin io.Reader, args ...string) (string, string, error) {
+	return repo.runGitCommandRawContext(context.Background(), stdin, args...)
+}
+
+// Run the given git command and return its stdout, or an error if the command
+// fails or the context is canceled.
+func (repo *GitRepo) runGitCommandRawContext(ctx context.Context, stdin io.Reader, args ...string) (string, string, error) {
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
-	err := repo.runGitCommandWithIO(stdin, &stdout, &stderr, args...)
+	err := repo.runGitCommandWithIOContext(ctx, stdin, &stdout, &stderr, args...)
 	return strings.TrimSpace(stdout.String()), strings.TrimSpace(stderr.String()), err
 }
 
@@ -82,6 +100,18 @@ func NewGitRepo(path string, witnesser Witnesser) (*GitRepo, error) {
 	// Fix the path to be sure we are at the root
 	repo.Path = stdout
 
+	// In a linked worktree, .git is a file pointing to a directory private to
+	// this worktree. The bugs and the data of git-bug are shared by all the
+	// worktrees, so they live in the common git directory.
+	gitDir, err := repo.runGitCommand("rev-parse", "--git-common-dir")
+	if err != nil {
+		return nil, err
+	}
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(repo.Path, gitDir)
+	}
+	repo.GitDir = gitDir
+
 	err = repo.LoadClocks()
 
 	if err != nil {
@@ -109,7 +139,7 @@ func NewGitRepo(path string, witnesser Witnesser) (*GitRepo, error) {
 
 // InitGitRepo create a new empty git repo at the given path
 func InitGitRepo(path string) (*GitRepo, error) {
-	repo := &GitRepo{Path: path}
+	repo := &GitRepo{Path: path, GitDir: path + "/.git"}
 	err := repo.createClocks()
 	if err != nil {
 		return nil, err
@@ -123,9 +153,23 @@ func InitGitRepo(path string) (*GitRepo, error) {
 	return repo, nil
 }
 
+// CloneGitRepo clone a remote repository at the given path, with the
+// progress of git written to the given writer
+func CloneGitRepo(url string, path string, progress io.Writer, witnesser Witnesser) (*GitRepo, error) {
+	cmd := exec.Command("git", "clone", "--progress", url, path)
+	cmd.Stdout = progress
+	cmd.Stderr = progress
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git clone failed: %v", err)
+	}
+
+	return NewGitRepo(path, witnesser)
+}
+
 // InitBareGitRepo create a new --bare empty git repo at the given path
 func InitBareGitRepo(path string) (*GitRepo, error) {
-	repo := &GitRepo{Path: path}
+	repo := &GitRepo{Path: path, GitDir: path + "/.git"}
 	err := repo.createClocks()
 	if err != nil {
 		return nil, err
@@ -144,14 +188,38 @@ func (repo *GitRepo) GetPath() string {
 	return repo.Path
 }
 
-// GetUserName returns the name the the user has used to configure git
+// GetGitDir returns the path to the git directory of the repo, shared by all
+// its worktrees.
+func (repo *GitRepo) GetGitDir() string {
+	return repo.GitDir
+}
+
+// GetUserName returns the name the the user has used to configure git, or an
+// empty string if it's not configured
 func (repo *GitRepo) GetUserName() (string, error) {
-	return repo.runGitCommand("config", "user.name")
+	return repo.readConfigValue("user.name")
 }
 
-// GetUserEmail returns the email address that the user has used to configure git.
+// GetUserEmail returns the email address that the user has used to configure
+// git, or an empty string if it's not configured
 func (repo *GitRepo) GetUserEmail() (string, error) {
-	return repo.runGitCommand("config", "user.email")
+	return repo.readConfigValue("user.email")
+}
+
+// readConfigValue return the value of a configuration key, or an empty
+// string if it doesn't exist
+func (repo *GitRepo) readConfigValue(key string) (string, error) {
+	stdout, stderr, err := repo.runGitCommandRaw(nil, "config", key)
+
+	// git fail silently when the key doesn't exist
+	if err != nil && stderr == "" {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s", stderr)
+	}
+
+	return stdout, nil
 }
 
 // GetCoreEditor returns the name of the editor that the user has used to configure git.
@@ -189,7 +257,8 @@ func (repo *GitRepo) ReadConfigs(keyPrefix string) (map[string]string, error) {
 			continue
 		}
 
-		parts := strings.Fields(line)
+		// the value can contain spaces
+		parts := strings.SplitN(line, " ", 2)
 		if len(parts) != 2 {
 			return nil, fmt.Errorf("bad git config: %s", line)
 		}
@@ -204,23 +273,52 @@ func (repo *GitRepo) ReadConfigs(keyPrefix string) (map[string]string, error) {
 func (repo *GitRepo) RmConfigs(keyPrefix string) error {
 	_, err := repo.runGitCommand("config", "--remove-section", keyPrefix)
 
+	// the prefix might be a single key rather than a section
+	if err != nil {
+		_, err = repo.runGitCommand("config", "--unset-all", keyPrefix)
+	}
+
 	return err
 }
 
 // FetchRefs fetch git refs from a remote
 func (repo *GitRepo) FetchRefs(remote, refSpec string) (string, error) {
-	stdout, err := repo.runGitCommand("fetch", remote, refSpec)
+	return repo.FetchRefsContext(context.Background(), remote, refSpec)
+}
+
+// FetchRefsContext fetch git refs from a remote, stopping git if the context
+// is canceled
+func (repo *GitRepo) FetchRefsContext(ctx context.Context, remote, refSpec string) (string, error) {
+	stdout, stderr, err := repo.runGitCommandRawContext(ctx, nil, "fetch", remote, refSpec)
+
+	if ctx.Err() != nil {
+		return stdout, ctx.Err()
+	}
 
 	if err != nil {
-		return stdout, fmt.Errorf("failed to fetch from the remote '%s': %v", remote, err)
+		if stderr == "" {
+			stderr = "Error running git command: fetch " + remote + " " + refSpec
+		}
+		return stdout, fmt.Errorf("failed to fetch from the remote '%s': %v", remote, stderr)
 	}
 
-	return stdout, err
+	return stdout, nil
 }
 
 // PushRefs push git refs to a remote
-func (repo *GitRepo) PushRefs(remote string, refSpec string) (string, error) {
-	stdout, stderr, err := repo.runGitCommandRaw(nil, "push", remote, refSpec)
+func (repo *GitRepo) PushRefs(remote string, refSpecs ...string) (string, error) {
+	return repo.PushRefsContext(context.Background(), remote, refSpecs...)
+}
+
+// PushRefsContext push git refs to a remote, stopping git if the context is
+// canceled
+func (repo *GitRepo) PushRefsContext(ctx context.Context, remote string, refSpecs ...string) (string, error) {
+	args := append([]string{"push", remote}, refSpecs...)
+	stdout, stderr, err := repo.runGitCommandRawContext(ctx, nil, args...)
+
+	if ctx.Err() != nil {
+		return stdout + stderr, ctx.Err()
+	}
 
 	if err != nil {
 		return stdout + stderr, fmt.Errorf("failed to push to the remote '%s': %v", remote, stderr)
@@ -287,6 +385,93 @@ func (repo *GitRepo) StoreCommitWithParent(treeHash git.Hash, parent git.Hash) (
 	return git.Hash(stdout), nil
 }
 
+// StoreSignedCommit will store a signed Git commit with the given Git tree
+// and parent, if not empty
+func (repo *GitRepo) StoreSignedCommit(treeHash git.Hash, parent git.Hash) (git.Hash, error) {
+	args := []string{"commit-tree", "-S", string(treeHash)}
+	if parent != "" {
+		args = append(args, "-p", string(parent))
+	}
+
+	stdout, err := repo.runGitCommand(args...)
+
+	if err != nil {
+		return "", err
+	}
+
+	return git.Hash(stdout), nil
+}
+
+// VerifyCommit will check that a commit has a valid signature
+func (repo *GitRepo) VerifyCommit(hash git.Hash) error {
+	_, err := repo.runGitCommand("verify-commit", string(hash))
+
+	return err
+}
+
+// SignData will return an armored detached signature of the data, made with
+// the GPG key git uses to sign commits
+func (repo *GitRepo) SignData(data []byte) (string, error) {
+	args := []string{"--batch", "--detach-sign", "--armor"}
+
+	key, err := repo.runGitCommand("config", "user.signingkey")
+	if err == nil && key != "" {
+		args = append(args, "--local-user", key)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(repo.gpgProgram(), args...)
+	cmd.Dir = repo.Path
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("signing failed: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+// VerifyData will check that an armored detached signature of the data is
+// valid
+func (repo *GitRepo) VerifyData(data []byte, signature string) error {
+	sigFile, err := ioutil.TempFile("", "git-bug-signature")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(sigFile.Name())
+
+	_, err = sigFile.WriteString(signature)
+	if closeErr := sigFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(repo.gpgProgram(), "--batch", "--verify", sigFile.Name(), "-")
+	cmd.Dir = repo.Path
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("invalid signature: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// gpgProgram return the GPG program configured for git
+func (repo *GitRepo) gpgProgram() string {
+	program, err := repo.runGitCommand("config", "gpg.program")
+	if err != nil || program == "" {
+		return "gpg"
+	}
+	return program
+}
+
 // UpdateRef will create or update a Git reference
 func (repo *GitRepo) UpdateRef(ref string, hash git.Hash) error {
 	_, err := repo.runGitCommand("update-ref", ref, string(hash))
@@ -311,6 +496,33 @@ func (repo *GitRepo) ListRefs(refspec string) ([]string, error) {
 	return split, nil
 }
 
+// ListRefHashes will return the commit hash of each Git ref matching the
+// given refspec
+func (repo *GitRepo) ListRefHashes(refspec string) (map[string]git.Hash, error) {
+	stdout, err := repo.runGitCommand("for-each-ref", "--format=%(objectname) %(refname)", refspec)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]git.Hash)
+
+	for _, line := range strings.Split(stdout, "\n") {
+		if line == "" {
+			continue
+		}
+
+		split := strings.SplitN(line, " ", 2)
+		if len(split) != 2 {
+			return nil, fmt.Errorf("unexpected output of git for-each-ref: %s", line)
+		}
+
+		result[split[1]] = git.Hash(split[0])
+	}
+
+	return result, nil
+}
+
 // RefExist will check if a reference exist in Git
 func (repo *GitRepo) RefExist(ref string) (bool, error) {
 	stdout, err := repo.runGitCommand("for-each-ref", ref)
@@ -329,6 +541,13 @@ func (repo *GitRepo) CopyRef(source string, dest string) error {
 	return err
 }
 
+// RemoveRef will remove a Git reference
+func (repo *GitRepo) RemoveRef(ref string) error {
+	_, err := repo.runGitCommand("update-ref", "-d", ref)
+
+	return err
+}
+
 // ListCommits will return the list of commit hashes of a ref, in chronological order
 func (repo *GitRepo) ListCommits(ref string) ([]git.Hash, error) {
 	stdout, err := repo.runGitCommand("rev-list", "--first-parent", "--reverse", ref)
@@ -348,6 +567,76 @@ func (repo *GitRepo) ListCommits(ref string) ([]git.Hash, error) {
 
 }
 
+// ReadCommitMessages will return the hash and the message of the commits of a
+// revision range, in chronological order
+func (repo *GitRepo) ReadCommitMessages(revRange string) ([]CommitMessage, error) {
+	if strings.HasPrefix(revRange, "-") {
+		return nil, fmt.Errorf("invalid revision range %s", revRange)
+	}
+
+	stdout, err := repo.runGitCommand("log", "--reverse", "--format=%H%n%B%x00", revRange, "--")
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []CommitMessage
+
+	for _, entry := range strings.Split(stdout, "\x00") {
+		entry = strings.TrimLeft(entry, "\n")
+		if entry == "" {
+			continue
+		}
+
+		split := strings.SplitN(entry, "\n", 2)
+		commit := CommitMessage{Hash: git.Hash(split[0])}
+		if len(split) == 2 {
+			commit.Message = strings.TrimSpace(split[1])
+		}
+
+		result = append(result, commit)
+	}
+
+	return result, nil
+}
+
+// BlameFile will return how many lines of the current version of a file each
+// author last modified. If end is not zero, only the lines from start to end
+// are considered.
+func (repo *GitRepo) BlameFile(path string, start int, end int) (map[CodeAuthor]int, error) {
+	if strings.HasPrefix(path, "-") {
+		return nil, fmt.Errorf("invalid path %s", path)
+	}
+
+	args := []string{"blame", "--line-porcelain"}
+	if end != 0 {
+		args = append(args, "-L", fmt.Sprintf("%d,%d", start, end))
+	}
+	args = append(args, "HEAD", "--", path)
+
+	stdout, err := repo.runGitCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[CodeAuthor]int)
+	var author CodeAuthor
+
+	// each line of the file is preceded by the header of the commit that
+	// last modified it, with the author name then the author email
+	for _, line := range strings.Split(stdout, "\n") {
+		switch {
+		case strings.HasPrefix(line, "author "):
+			author.Name = strings.TrimPrefix(line, "author ")
+		case strings.HasPrefix(line, "author-mail "):
+			author.Email = strings.Trim(strings.TrimPrefix(line, "author-mail "), "<>")
+			result[author]++
+		}
+	}
+
+	return result, nil
+}
+
 // ListEntries will return the list of entries in a Git tree
 func (repo *GitRepo) ListEntries(hash git.Hash) ([]TreeEntry, error) {
 	stdout, err := repo.runGitCommand("ls-tree", string(hash))
@@ -381,6 +670,58 @@ func (repo *GitRepo) GetTreeHash(commit git.Hash) (git.Hash, error) {
 	return git.Hash(stdout), nil
 }
 
+// ListRemotes will return the names of the remotes
+func (repo *GitRepo) ListRemotes() ([]string, error) {
+	stdout, err := repo.runGitCommand("remote")
+	if err != nil {
+		return nil, err
+	}
+
+	if stdout == "" {
+		return []string{}, nil
+	}
+
+	return strings.Split(stdout, "\n"), nil
+}
+
+// ReadConfigValues will return all the values of a configuration key
+func (repo *GitRepo) ReadConfigValues(key string) ([]string, error) {
+	stdout, stderr, err := repo.runGitCommandRaw(nil, "config", "--get-all", key)
+
+	// git fail silently when the key doesn't exist
+	if err != nil && stderr == "" {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s", stderr)
+	}
+
+	return strings.Split(stdout, "\n"), nil
+}
+
+// AddConfig will add a value to a configuration key, keeping the others
+func (repo *GitRepo) AddConfig(key string, value string) error {
+	_, err := repo.runGitCommand("config", "--add", key, value)
+
+	return err
+}
+
+// GitVersion will return the version of the installed git, like "2.20.1"
+func GitVersion() (string, error) {
+	stdout, err := exec.Command("git", "--version").Output()
+	if err != nil {
+		return "", err
+	}
+
+	// "git version 2.20.1", possibly followed by the build details
+	fields := strings.Fields(string(stdout))
+	if len(fields) < 3 {
+		return "", fmt.Errorf("unexpected git version: %s", strings.TrimSpace(string(stdout)))
+	}
+
+	return fields[2], nil
+}
+
 // AddRemote add a new remote to the repository
 // Not in the interface because it's only used for testing
 func (repo *GitRepo) AddRemote(name string, url string) error {
@@ -390,13 +731,13 @@ func (repo *GitRepo) AddRemote(name string, url string) error {
 }
 
 func (repo *GitRepo) createClocks() error {
-	createPath := path.Join(repo.Path, createClockFile)
+	createPath := path.Join(repo.GitDir, createClockFile)
 	createClock, err := lamport.NewPersisted(createPath)
 	if err != nil {
 		return err
 	}
 
-	editPath := path.Join(repo.Path, editClockFile)
+	editPath := path.Join(repo.GitDir, editClockFile)
 	editClock, err := lamport.NewPersisted(editPath)
 	if err != nil {
 		return err
@@ -410,12 +751,12 @@ func (repo *GitRepo) createClocks() error {
 
 // LoadClocks read the clocks values from the on-disk repo
 func (repo *GitRepo) LoadClocks() error {
-	createClock, err := lamport.LoadPersisted(repo.GetPath() + createClockFile)
+	createClock, err := lamport.LoadPersisted(repo.GetGitDir() + createClockFile)
 	if err != nil {
 		return err
 	}
 
-	editClock, err := lamport.LoadPersisted(repo.GetPath() + editClockFile)
+	editClock, err := lamport.LoadPersisted(repo.GetGitDir() + editClockFile)
 	if err != nil {
 		return err
 	}
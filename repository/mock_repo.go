@@ -42,6 +42,11 @@ func (r *mockRepoForTest) GetPath() string {
 	return "~/mockRepo/"
 }
 
+// GetGitDir returns the path to the git directory of the repo.
+func (r *mockRepoForTest) GetGitDir() string {
+	return "~/mockRepo/.git"
+}
+
 func (r *mockRepoForTest) GetUserName() (string, error) {
 	return "René Descartes", nil
 }
@@ -83,7 +88,7 @@ func (r *mockRepoForTest) RmConfigs(keyPrefix string) error {
 }
 
 // PushRefs push git refs to a remote
-func (r *mockRepoForTest) PushRefs(remote string, refSpec string) (string, error) {
+func (r *mockRepoForTest) PushRefs(remote string, refSpecs ...string) (string, error) {
 	return "", nil
 }
 
@@ -157,6 +162,11 @@ func (r *mockRepoForTest) CopyRef(source string, dest string) error {
 	return nil
 }
 
+func (r *mockRepoForTest) RemoveRef(ref string) error {
+	delete(r.refs, ref)
+	return nil
+}
+
 func (r *mockRepoForTest) ListRefs(refspec string) ([]string, error) {
 	keys := make([]string, len(r.refs))
 
@@ -169,6 +179,18 @@ func (r *mockRepoForTest) ListRefs(refspec string) ([]string, error) {
 	return keys, nil
 }
 
+func (r *mockRepoForTest) ListRefHashes(refspec string) (map[string]git.Hash, error) {
+	result := make(map[string]git.Hash)
+
+	for ref, hash := range r.refs {
+		if strings.HasPrefix(ref, refspec) {
+			result[ref] = hash
+		}
+	}
+
+	return result, nil
+}
+
 func (r *mockRepoForTest) ListCommits(ref string) ([]git.Hash, error) {
 	var hashes []git.Hash
 
@@ -3,6 +3,7 @@ package repository
 
 import (
 	"bytes"
+	"context"
 	"strings"
 
 	"github.com/MichaelMure/git-bug/util/git"
@@ -14,6 +15,10 @@ type RepoCommon interface {
 	// GetPath returns the path to the repo.
 	GetPath() string
 
+	// GetGitDir returns the path to the git directory of the repo, shared by
+	// all its worktrees.
+	GetGitDir() string
+
 	// GetUserName returns the name the the user has used to configure git
 	GetUserName() (string, error)
 
@@ -41,7 +46,7 @@ type Repo interface {
 	FetchRefs(remote string, refSpec string) (string, error)
 
 	// PushRefs push git refs to a remote
-	PushRefs(remote string, refSpec string) (string, error)
+	PushRefs(remote string, refSpecs ...string) (string, error)
 
 	// StoreData will store arbitrary data and return the corresponding hash
 	StoreData(data []byte) (git.Hash, error)
@@ -64,12 +69,19 @@ type Repo interface {
 	// ListRefs will return a list of Git ref matching the given refspec
 	ListRefs(refspec string) ([]string, error)
 
+	// ListRefHashes will return the commit hash of each Git ref matching the
+	// given refspec
+	ListRefHashes(refspec string) (map[string]git.Hash, error)
+
 	// RefExist will check if a reference exist in Git
 	RefExist(ref string) (bool, error)
 
 	// CopyRef will create a new reference with the same value as another one
 	CopyRef(source string, dest string) error
 
+	// RemoveRef will remove a Git reference
+	RemoveRef(ref string) error
+
 	// ListCommits will return the list of tree hashes of a ref, in chronological order
 	ListCommits(ref string) ([]git.Hash, error)
 
@@ -83,6 +95,73 @@ type Repo interface {
 	GetTreeHash(commit git.Hash) (git.Hash, error)
 }
 
+// SigningRepo is implemented by the repositories able to sign commits and
+// data with the key of the user and to verify their signature
+type SigningRepo interface {
+	// StoreSignedCommit will store a signed Git commit with the given Git tree
+	// and parent, if not empty
+	StoreSignedCommit(treeHash git.Hash, parent git.Hash) (git.Hash, error)
+
+	// VerifyCommit will check that a commit has a valid signature
+	VerifyCommit(hash git.Hash) error
+
+	// SignData will return an armored detached signature of the data
+	SignData(data []byte) (string, error)
+
+	// VerifyData will check that an armored detached signature of the data is
+	// valid
+	VerifyData(data []byte, signature string) error
+}
+
+// CancelableRepo is implemented by the repositories able to stop a fetch or
+// a push in progress when a context is canceled
+type CancelableRepo interface {
+	// FetchRefsContext fetch git refs from a remote
+	FetchRefsContext(ctx context.Context, remote string, refSpec string) (string, error)
+
+	// PushRefsContext push git refs to a remote
+	PushRefsContext(ctx context.Context, remote string, refSpecs ...string) (string, error)
+}
+
+// RemoteRepo is implemented by the repositories able to list their remotes
+// and to handle the configuration keys with several values, such as the
+// refspecs of the remotes
+type RemoteRepo interface {
+	// ListRemotes will return the names of the remotes
+	ListRemotes() ([]string, error)
+
+	// ReadConfigValues will return all the values of a configuration key
+	ReadConfigValues(key string) ([]string, error)
+
+	// AddConfig will add a value to a configuration key, keeping the others
+	AddConfig(key string, value string) error
+}
+
+// HistoryRepo is implemented by the repositories able to read the history of
+// the source code
+type HistoryRepo interface {
+	// ReadCommitMessages will return the hash and the message of the commits
+	// of a revision range, in chronological order
+	ReadCommitMessages(revRange string) ([]CommitMessage, error)
+
+	// BlameFile will return how many lines of the current version of a file
+	// each author last modified. If end is not zero, only the lines from start
+	// to end are considered.
+	BlameFile(path string, start int, end int) (map[CodeAuthor]int, error)
+}
+
+// CommitMessage is the message of a commit of the source code
+type CommitMessage struct {
+	Hash    git.Hash
+	Message string
+}
+
+// CodeAuthor is the author of a commit of the source code
+type CodeAuthor struct {
+	Name  string
+	Email string
+}
+
 type ClockedRepo interface {
 	Repo
 
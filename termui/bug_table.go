@@ -1,15 +1,13 @@
 package termui
 
 import (
-	"bytes"
 	"fmt"
+	"strings"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
-	"github.com/MichaelMure/git-bug/util/colors"
 	"github.com/MichaelMure/git-bug/util/text"
 	"github.com/MichaelMure/gocui"
-	"github.com/dustin/go-humanize"
 )
 
 const bugTableView = "bugTableView"
@@ -28,21 +26,80 @@ type bugTable struct {
 	bugs         []*cache.BugCache
 	pageCursor   int
 	selectCursor int
+	// savedCursor is the position in the cycle of the default query and the
+	// saved queries, 0 for the default query
+	savedCursor int
+	// columns are the columns shown, from the configuration
+	columns []tableColumn
+	// sort is the order of the bugs when the query doesn't give one, empty
+	// for the default order
+	sort string
+	// notice confirm the last quick action, until the selection moves
+	notice string
 }
 
-func newBugTable(c *cache.RepoCache) *bugTable {
-	query, err := cache.ParseQuery(defaultQuery)
-	if err != nil {
-		panic(err)
-	}
-
-	return &bugTable{
-		repo:         c,
-		query:        query,
+func newBugTable(c *cache.RepoCache) (*bugTable, error) {
+	bt := &bugTable{
 		queryStr:     defaultQuery,
 		pageCursor:   0,
 		selectCursor: 0,
 	}
+
+	err := bt.setRepo(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return bt, nil
+}
+
+// setRepo replace the repository of the bugs shown, keeping the query if
+// possible
+func (bt *bugTable) setRepo(c *cache.RepoCache) error {
+	columns, sort, err := readTableConfig(c)
+	if err != nil {
+		return err
+	}
+
+	bt.repo = c
+	bt.columns = columns
+	bt.sort = sort
+	bt.pageCursor = 0
+	bt.selectCursor = 0
+	bt.savedCursor = 0
+
+	// a saved query might not exist in this repository
+	query, err := bt.parseQuery(bt.queryStr)
+	if err != nil {
+		query, err = bt.parseQuery(defaultQuery)
+		if err != nil {
+			return err
+		}
+		bt.queryStr = defaultQuery
+	}
+	bt.query = query
+
+	return nil
+}
+
+// parseQuery parse a query, sorted in the configured order if it doesn't
+// give one
+func (bt *bugTable) parseQuery(queryStr string) (*cache.Query, error) {
+	query, err := bt.repo.ParseQuery(queryStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if !query.Sorted && bt.sort != "" {
+		sorted, err := cache.ParseQuery("sort:" + bt.sort)
+		if err != nil {
+			return nil, err
+		}
+		query.OrderBy = sorted.OrderBy
+		query.OrderDirection = sorted.OrderDirection
+	}
+
+	return query, nil
 }
 
 func (bt *bugTable) layout(g *gocui.Gui) error {
@@ -75,8 +132,8 @@ func (bt *bugTable) layout(g *gocui.Gui) error {
 
 		v.Frame = false
 		v.Highlight = true
-		v.SelBgColor = gocui.ColorWhite
-		v.SelFgColor = gocui.ColorBlack
+		v.SelBgColor = ui.theme.selectionBg
+		v.SelFgColor = ui.theme.selectionFg
 
 		// restore the cursor
 		// window is too small to set the cursor properly, ignoring the error
@@ -118,9 +175,10 @@ func (bt *bugTable) layout(g *gocui.Gui) error {
 		}
 
 		v.Frame = false
-		v.BgColor = gocui.ColorBlue
+		v.FgColor = ui.theme.instructionsFg
+		v.BgColor = ui.theme.instructionsBg
 
-		_, _ = fmt.Fprintf(v, "[q] Quit [s] Search [←↓↑→,hjkl] Navigation [↵] Open bug [n] New bug [i] Pull [o] Push")
+		_, _ = fmt.Fprint(v, ui.keys.instructions(bugTableView))
 	}
 
 	_, err = g.SetCurrentView(bugTableView)
@@ -128,84 +186,98 @@ func (bt *bugTable) layout(g *gocui.Gui) error {
 }
 
 func (bt *bugTable) keybindings(g *gocui.Gui) error {
+	// Help
+	if err := ui.keys.bind(g, bugTableView, "help", showHelp(bugTableView)); err != nil {
+		return err
+	}
+
 	// Quit
-	if err := g.SetKeybinding(bugTableView, 'q', gocui.ModNone, quit); err != nil {
+	if err := ui.keys.bind(g, bugTableView, "quit", quit); err != nil {
 		return err
 	}
 
 	// Down
-	if err := g.SetKeybinding(bugTableView, 'j', gocui.ModNone,
-		bt.cursorDown); err != nil {
+	if err := ui.keys.bind(g, bugTableView, "down", bt.cursorDown); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(bugTableView, gocui.KeyArrowDown, gocui.ModNone,
-		bt.cursorDown); err != nil {
+
+	// Up
+	if err := ui.keys.bind(g, bugTableView, "up", bt.cursorUp); err != nil {
 		return err
 	}
-	// Up
-	if err := g.SetKeybinding(bugTableView, 'k', gocui.ModNone,
-		bt.cursorUp); err != nil {
+
+	// Previous page
+	if err := ui.keys.bind(g, bugTableView, "previous-page", bt.previousPage); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(bugTableView, gocui.KeyArrowUp, gocui.ModNone,
-		bt.cursorUp); err != nil {
+
+	// Next page
+	if err := ui.keys.bind(g, bugTableView, "next-page", bt.nextPage); err != nil {
 		return err
 	}
 
-	// Previous page
-	if err := g.SetKeybinding(bugTableView, 'h', gocui.ModNone,
-		bt.previousPage); err != nil {
+	// New bug
+	if err := ui.keys.bind(g, bugTableView, "new", bt.newBug); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(bugTableView, gocui.KeyArrowLeft, gocui.ModNone,
-		bt.previousPage); err != nil {
+
+	// Open bug
+	if err := ui.keys.bind(g, bugTableView, "open", bt.openBug); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(bugTableView, gocui.KeyPgup, gocui.ModNone,
-		bt.previousPage); err != nil {
+
+	// Pull
+	if err := ui.keys.bind(g, bugTableView, "pull", bt.pull); err != nil {
 		return err
 	}
-	// Next page
-	if err := g.SetKeybinding(bugTableView, 'l', gocui.ModNone,
-		bt.nextPage); err != nil {
+
+	// Push
+	if err := ui.keys.bind(g, bugTableView, "push", bt.push); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(bugTableView, gocui.KeyArrowRight, gocui.ModNone,
-		bt.nextPage); err != nil {
+
+	// Search
+	if err := ui.keys.bind(g, bugTableView, "search", bt.search); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(bugTableView, gocui.KeyPgdn, gocui.ModNone,
-		bt.nextPage); err != nil {
+
+	// Saved queries
+	if err := ui.keys.bind(g, bugTableView, "saved-query", bt.nextSavedQuery); err != nil {
 		return err
 	}
 
-	// New bug
-	if err := g.SetKeybinding(bugTableView, 'n', gocui.ModNone,
-		bt.newBug); err != nil {
+	// Sort
+	if err := ui.keys.bind(g, bugTableView, "sort", bt.nextSort); err != nil {
 		return err
 	}
 
-	// Open bug
-	if err := g.SetKeybinding(bugTableView, gocui.KeyEnter, gocui.ModNone,
-		bt.openBug); err != nil {
+	// Close/reopen
+	if err := ui.keys.bind(g, bugTableView, "close", bt.toggleOpenClose); err != nil {
 		return err
 	}
 
-	// Pull
-	if err := g.SetKeybinding(bugTableView, 'i', gocui.ModNone,
-		bt.pull); err != nil {
+	// Toggle a label
+	if err := ui.keys.bind(g, bugTableView, "label", bt.toggleLabel); err != nil {
 		return err
 	}
 
-	// Push
-	if err := g.SetKeybinding(bugTableView, 'o', gocui.ModNone,
-		bt.push); err != nil {
+	// Assign to me
+	if err := ui.keys.bind(g, bugTableView, "assign", bt.assignToMe); err != nil {
 		return err
 	}
 
-	// Query
-	if err := g.SetKeybinding(bugTableView, 's', gocui.ModNone,
-		bt.changeQuery); err != nil {
+	// Command palette
+	if err := ui.keys.bind(g, bugTableView, "command", bt.openPalette); err != nil {
+		return err
+	}
+
+	// Identities
+	if err := ui.keys.bind(g, bugTableView, "identities", bt.openIdentities); err != nil {
+		return err
+	}
+
+	// Workspace
+	if err := ui.keys.bind(g, bugTableView, "next-repo", bt.nextRepo); err != nil {
 		return err
 	}
 
@@ -267,78 +339,83 @@ func (bt *bugTable) getTableLength() int {
 	return len(bt.bugs)
 }
 
-func (bt *bugTable) getColumnWidths(maxX int) map[string]int {
-	m := make(map[string]int)
-	m["id"] = 9
-	m["status"] = 7
+// getColumnWidths return the width of each column. The fixed widths are
+// given first, then the automatic ones take a share of the space left and the
+// one taking the rest get what remains.
+func (bt *bugTable) getColumnWidths(maxX int) []int {
+	widths := make([]int, len(bt.columns))
+
+	// the columns are separated by a space
+	left := maxX - (len(bt.columns) - 1)
 
-	left := maxX - 5 - m["id"] - m["status"]
+	for i, column := range bt.columns {
+		if column.width <= 0 {
+			continue
+		}
+		widths[i] = column.width
+		if column.name == "id" && len(bt.bugs) > 0 {
+			// the aliases are padded to the same width
+			widths[i] = maxInt(widths[i], len(ui.displayId(bt.bugs[0].Id()))+2)
+		}
+		left -= widths[i]
+	}
 
-	m["summary"] = 10
-	left -= m["summary"]
-	m["lastEdit"] = 19
-	left -= m["lastEdit"]
+	auto := minInt(maxInt(left/3, 15), 10+left/8)
+	for i, column := range bt.columns {
+		if column.width == widthAuto {
+			widths[i] = auto
+			left -= auto
+		}
+	}
 
-	m["author"] = minInt(maxInt(left/3, 15), 10+left/8)
-	m["title"] = maxInt(left-m["author"], 10)
+	for i, column := range bt.columns {
+		if column.width == widthRest {
+			widths[i] = maxInt(left, 10)
+		}
+	}
 
-	return m
+	return widths
 }
 
 func (bt *bugTable) render(v *gocui.View, maxX int) {
 	columnWidths := bt.getColumnWidths(maxX)
 
 	for _, b := range bt.bugs {
-		person := bug.Person{}
 		snap := b.Snapshot()
-		if len(snap.Comments) > 0 {
-			create := snap.Comments[0]
-			person = create.Author
-		}
-
-		summaryTxt := fmt.Sprintf("C:%-2d L:%-2d",
-			len(snap.Comments)-1,
-			len(snap.Labels),
-		)
 
-		id := text.LeftPadMaxLine(snap.HumanId(), columnWidths["id"], 1)
-		status := text.LeftPadMaxLine(snap.Status.String(), columnWidths["status"], 1)
-		title := text.LeftPadMaxLine(snap.Title, columnWidths["title"], 1)
-		author := text.LeftPadMaxLine(person.DisplayName(), columnWidths["author"], 1)
-		summary := text.LeftPadMaxLine(summaryTxt, columnWidths["summary"], 1)
-		lastEdit := text.LeftPadMaxLine(humanize.Time(snap.LastEditTime()), columnWidths["lastEdit"], 1)
+		cells := make([]string, len(bt.columns))
+		for i, column := range bt.columns {
+			cells[i] = column.cell(snap, columnWidths[i])
+		}
 
-		_, _ = fmt.Fprintf(v, "%s %s %s %s %s %s\n",
-			colors.Cyan(id),
-			colors.Yellow(status),
-			title,
-			colors.Magenta(author),
-			summary,
-			lastEdit,
-		)
+		_, _ = fmt.Fprintln(v, strings.Join(cells, " "))
 	}
 }
 
 func (bt *bugTable) renderHeader(v *gocui.View, maxX int) {
 	columnWidths := bt.getColumnWidths(maxX)
 
-	id := text.LeftPadMaxLine("ID", columnWidths["id"], 1)
-	status := text.LeftPadMaxLine("STATUS", columnWidths["status"], 1)
-	title := text.LeftPadMaxLine("TITLE", columnWidths["title"], 1)
-	author := text.LeftPadMaxLine("AUTHOR", columnWidths["author"], 1)
-	summary := text.LeftPadMaxLine("SUMMARY", columnWidths["summary"], 1)
-	lastEdit := text.LeftPadMaxLine("LAST EDIT", columnWidths["lastEdit"], 1)
+	headers := make([]string, len(bt.columns))
+	for i, column := range bt.columns {
+		headers[i] = text.LeftPadMaxLine(column.header, columnWidths[i], 1)
+	}
 
 	_, _ = fmt.Fprintf(v, "\n")
-	_, _ = fmt.Fprintf(v, "%s %s %s %s %s %s\n", id, status, title, author, summary, lastEdit)
-
+	_, _ = fmt.Fprintln(v, strings.Join(headers, " "))
 }
 
 func (bt *bugTable) renderFooter(v *gocui.View, maxX int) {
-	_, _ = fmt.Fprintf(v, " \nShowing %d of %d bugs", len(bt.bugs), len(bt.allIds))
+	_, _ = fmt.Fprintf(v, " %s\nShowing %d of %d bugs for %s", bt.notice, len(bt.bugs), len(bt.allIds), bt.queryStr)
+	if name := sortName(bt.query); name != "" {
+		_, _ = fmt.Fprintf(v, ", sorted by %s", name)
+	}
+	if ui.workspace != nil {
+		_, _ = fmt.Fprintf(v, " in %s", ui.repoName)
+	}
 }
 
 func (bt *bugTable) cursorDown(g *gocui.Gui, v *gocui.View) error {
+	bt.notice = ""
 	_, y := v.Cursor()
 
 	// If we are at the bottom of the page, switch to the next one.
@@ -365,6 +442,7 @@ func (bt *bugTable) cursorDown(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (bt *bugTable) cursorUp(g *gocui.Gui, v *gocui.View) error {
+	bt.notice = ""
 	_, y := v.Cursor()
 
 	// If we are at the top of the page, switch to the previous one.
@@ -437,88 +515,306 @@ func (bt *bugTable) openBug(g *gocui.Gui, v *gocui.View) error {
 	return ui.activateWindow(ui.showBug)
 }
 
-func (bt *bugTable) pull(g *gocui.Gui, v *gocui.View) error {
-	// Note: this is very hacky
+// selectedBug return the bug under the cursor, or nil after telling that
+// there is none
+func (bt *bugTable) selectedBug(v *gocui.View) *cache.BugCache {
+	_, y := v.Cursor()
+	if y >= len(bt.bugs) {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "No bug selected.")
+		return nil
+	}
+	return bt.bugs[y]
+}
 
-	ui.msgPopup.Activate("Pull from remote "+defaultRemote, "...")
+// quickAction apply a change to the selected bug and confirm it in the footer
+func (bt *bugTable) quickAction(b *cache.BugCache, action func() (string, error)) error {
+	notice, err := action()
+	if err == nil {
+		err = b.CommitAsNeeded()
+	}
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	bt.notice = fmt.Sprintf("%s %s", ui.theme.id(ui.displayId(b.Id())), notice)
+	return nil
+}
+
+func (bt *bugTable) toggleOpenClose(g *gocui.Gui, v *gocui.View) error {
+	b := bt.selectedBug(v)
+	if b == nil || readOnlyPopup() {
+		return nil
+	}
+
+	return bt.quickAction(b, func() (string, error) {
+		switch b.Snapshot().Status {
+		case bug.OpenStatus:
+			return "closed", b.Close()
+		default:
+			return "reopened", b.Open()
+		}
+	})
+}
+
+func (bt *bugTable) toggleLabel(g *gocui.Gui, v *gocui.View) error {
+	b := bt.selectedBug(v)
+	if b == nil || readOnlyPopup() {
+		return nil
+	}
+
+	c := ui.inputPopup.Activate("Label to add or remove")
 
 	go func() {
-		stdout, err := bt.repo.Fetch(defaultRemote)
+		input := strings.TrimSpace(<-c)
+		if input == "" {
+			return
+		}
 
-		if err != nil {
-			g.Update(func(gui *gocui.Gui) error {
-				ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
-				return nil
-			})
-		} else {
-			g.Update(func(gui *gocui.Gui) error {
-				ui.msgPopup.UpdateMessage(stdout)
-				return nil
+		g.Update(func(g *gocui.Gui) error {
+			return bt.quickAction(b, func() (string, error) {
+				for _, label := range b.Snapshot().Labels {
+					if label.Equivalent(bug.Label(input)) {
+						_, err := b.ChangeLabels(nil, []string{label.String()})
+						return fmt.Sprintf("label %s removed", label), err
+					}
+				}
+				_, err := b.ChangeLabels([]string{input}, nil)
+				return fmt.Sprintf("label %s added", input), err
 			})
+		})
+	}()
+
+	return nil
+}
+
+func (bt *bugTable) assignToMe(g *gocui.Gui, v *gocui.View) error {
+	b := bt.selectedBug(v)
+	if b == nil || readOnlyPopup() {
+		return nil
+	}
+
+	return bt.quickAction(b, func() (string, error) {
+		user, err := bt.repo.GetUser()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("assigned to %s", user.DisplayName()), b.SetAssignee(&user)
+	})
+}
+
+func (bt *bugTable) openPalette(g *gocui.Gui, v *gocui.View) error {
+	_, y := v.Cursor()
+	if y >= len(bt.bugs) {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "No bug selected.")
+		return nil
+	}
+	ui.palette.Activate(bt.bugs[y])
+	return nil
+}
+
+func (bt *bugTable) pull(g *gocui.Gui, v *gocui.View) error {
+	// TODO: make the remote configurable
+	ctx := ui.progressPopup.Activate("Pull from remote " + defaultRemote)
+
+	go func() {
+		ui.progressPopup.SetSummary(g, "Fetching ...")
+
+		_, err := bt.repo.FetchContext(ctx, defaultRemote)
+		if err != nil {
+			ui.progressPopup.Finish(g, "fetch failed", err)
+			return
+		}
+
+		total, err := bt.repo.RemoteBugCount(defaultRemote)
+		if err != nil {
+			ui.progressPopup.Finish(g, "fetch failed", err)
+			return
 		}
 
-		var buffer bytes.Buffer
-		beginLine := ""
+		var counts mergeCounts
 
-		for merge := range bt.repo.MergeAll(defaultRemote) {
-			if merge.Status == bug.MergeStatusNothing {
+		for merge := range bt.repo.MergeAllContext(ctx, defaultRemote) {
+			if merge.Err != nil {
+				err = merge.Err
+				if merge.Id != "" {
+					ui.progressPopup.AddLine(g, fmt.Sprintf("%s: %s",
+						ui.theme.id(bug.FormatHumanID(merge.Id)), ui.theme.hint(merge.Err)))
+				}
 				continue
 			}
 
-			if merge.Err != nil {
-				g.Update(func(gui *gocui.Gui) error {
-					ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
-					return nil
-				})
-			} else {
-				_, _ = fmt.Fprintf(&buffer, "%s%s: %s",
-					beginLine, colors.Cyan(merge.Bug.HumanId()), merge,
-				)
-
-				beginLine = "\n"
-
-				g.Update(func(gui *gocui.Gui) error {
-					ui.msgPopup.UpdateMessage(buffer.String())
-					return nil
-				})
+			if merge.Warning != nil {
+				ui.progressPopup.AddLine(g, ui.theme.hint(fmt.Sprintf("warning: %s", merge.Warning)))
+				continue
 			}
-		}
 
-		_, _ = fmt.Fprintf(&buffer, "%sdone", beginLine)
+			counts.add(merge.Status)
 
-		g.Update(func(gui *gocui.Gui) error {
-			ui.msgPopup.UpdateMessage(buffer.String())
-			return nil
-		})
+			if merge.Status != bug.MergeStatusNothing {
+				ui.progressPopup.AddLine(g, fmt.Sprintf("%s: %s",
+					ui.theme.id(bug.FormatHumanID(merge.Id)), merge))
+			}
 
+			ui.progressPopup.SetSummary(g, fmt.Sprintf("Merging %d/%d: %s", counts.total(), total, counts))
+		}
+
+		ui.progressPopup.Finish(g, fmt.Sprintf("%d/%d merged: %s", counts.total(), total, counts), err)
 	}()
 
 	return nil
 }
 
+// mergeCounts count the merged bugs by status
+type mergeCounts struct {
+	new, updated, invalid, quarantined, unchanged int
+}
+
+func (mc *mergeCounts) add(status bug.MergeStatus) {
+	switch status {
+	case bug.MergeStatusNew:
+		mc.new++
+	case bug.MergeStatusUpdated:
+		mc.updated++
+	case bug.MergeStatusInvalid:
+		mc.invalid++
+	case bug.MergeStatusQuarantined:
+		mc.quarantined++
+	case bug.MergeStatusNothing:
+		mc.unchanged++
+	}
+}
+
+func (mc mergeCounts) total() int {
+	return mc.new + mc.updated + mc.invalid + mc.quarantined + mc.unchanged
+}
+
+func (mc mergeCounts) String() string {
+	return fmt.Sprintf("%d new, %d updated, %d invalid, %d quarantined, %d unchanged",
+		mc.new, mc.updated, mc.invalid, mc.quarantined, mc.unchanged)
+}
+
 func (bt *bugTable) push(g *gocui.Gui, v *gocui.View) error {
-	ui.msgPopup.Activate("Push to remote "+defaultRemote, "...")
+	// TODO: make the remote configurable
+	ctx := ui.progressPopup.Activate("Push to remote " + defaultRemote)
 
 	go func() {
-		// TODO: make the remote configurable
-		stdout, err := bt.repo.Push(defaultRemote)
+		ui.progressPopup.SetSummary(g, "Pushing ...")
 
+		results, err := bt.repo.PushContext(ctx, defaultRemote)
 		if err != nil {
-			g.Update(func(gui *gocui.Gui) error {
-				ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
-				return nil
-			})
-		} else {
-			g.Update(func(gui *gocui.Gui) error {
-				ui.msgPopup.UpdateMessage(stdout)
-				return nil
-			})
+			ui.progressPopup.Finish(g, "nothing pushed", err)
+			return
 		}
+
+		for _, result := range results {
+			ui.progressPopup.AddLine(g, fmt.Sprintf("%s: %s",
+				ui.theme.id(bug.FormatHumanID(result.Id)), result))
+		}
+
+		if len(results) == 0 {
+			ui.progressPopup.Finish(g, "Everything up-to-date", nil)
+			return
+		}
+
+		ui.progressPopup.Finish(g, fmt.Sprintf("%d bugs pushed", len(results)), nil)
 	}()
 
 	return nil
 }
 
-func (bt *bugTable) changeQuery(g *gocui.Gui, v *gocui.View) error {
-	return editQueryWithEditor(bt)
+func (bt *bugTable) nextRepo(g *gocui.Gui, v *gocui.View) error {
+	if ui.workspace == nil {
+		return nil
+	}
+
+	err := ui.nextRepo()
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+	}
+
+	return nil
+}
+
+func (bt *bugTable) openIdentities(g *gocui.Gui, v *gocui.View) error {
+	err := ui.identityList.load(ui.cache)
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	return ui.activateWindow(ui.identityList)
+}
+
+func (bt *bugTable) search(g *gocui.Gui, v *gocui.View) error {
+	err := ui.searchBar.Activate()
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+	}
+
+	return nil
+}
+
+// nextSavedQuery cycle through the default query and the saved queries
+func (bt *bugTable) nextSavedQuery(g *gocui.Gui, v *gocui.View) error {
+	saved, err := bt.repo.SavedQueries()
+	if err != nil {
+		return err
+	}
+
+	if len(saved) == 0 {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "No saved query, use \"git bug query save\" to create one")
+		return nil
+	}
+
+	bt.savedCursor = (bt.savedCursor + 1) % (len(saved) + 1)
+
+	queryStr := defaultQuery
+	if bt.savedCursor > 0 {
+		queryStr = "@" + saved[bt.savedCursor-1].Name
+	}
+
+	query, err := bt.parseQuery(queryStr)
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	bt.queryStr = queryStr
+	bt.query = query
+	bt.pageCursor = 0
+	bt.selectCursor = 0
+
+	return nil
+}
+
+// nextSort cycle through the orders of the bugs, and store the new one in the
+// configuration to be used the next time
+func (bt *bugTable) nextSort(g *gocui.Gui, v *gocui.View) error {
+	next := tableSorts[0]
+	current := sortName(bt.query)
+	for i, name := range tableSorts {
+		if name == current {
+			next = tableSorts[(i+1)%len(tableSorts)]
+		}
+	}
+
+	sorted, err := cache.ParseQuery("sort:" + next)
+	if err != nil {
+		return err
+	}
+
+	err = bt.repo.StoreConfig(tableSortConfigKey, next)
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	bt.sort = next
+	bt.query.OrderBy = sorted.OrderBy
+	bt.query.OrderDirection = sorted.OrderDirection
+	bt.pageCursor = 0
+	bt.selectCursor = 0
+
+	return nil
 }
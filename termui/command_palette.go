@@ -0,0 +1,425 @@
+package termui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/gocui"
+)
+
+const commandPaletteView = "commandPaletteView"
+
+const commandPaletteTitle = "Command ([tab] complete, [↵] run, [esc] cancel)"
+
+// paletteCommand is a command of the palette, mirroring the command line
+// subcommands. A command has a run function, subcommands, or both.
+type paletteCommand struct {
+	name string
+	sub  []*paletteCommand
+	run  func(b *cache.BugCache, args []string) error
+	// action is the policy action performed by the command, if any
+	action string
+	// complete return the candidates for the arguments, if any
+	complete func(b *cache.BugCache) []string
+}
+
+var paletteCommands = []*paletteCommand{
+	{
+		name: "comment",
+		sub: []*paletteCommand{
+			{name: "add", run: paletteCommentAdd, action: bug.ActionAddComment},
+		},
+	},
+	{
+		name: "estimate",
+		sub: []*paletteCommand{
+			{name: "set", run: paletteEstimateSet, action: bug.ActionSetEstimate},
+		},
+	},
+	{
+		name: "label",
+		sub: []*paletteCommand{
+			{name: "add", run: paletteLabelAdd, complete: paletteMissingLabels, action: bug.ActionLabelChange},
+			{name: "rm", run: paletteLabelRm, complete: paletteBugLabels, action: bug.ActionLabelChange},
+		},
+	},
+	{
+		name: "status",
+		sub: []*paletteCommand{
+			{name: "close", run: func(b *cache.BugCache, args []string) error { return b.Close() }, action: bug.ActionClose},
+			{name: "open", run: func(b *cache.BugCache, args []string) error { return b.Open() }, action: bug.ActionReopen},
+		},
+	},
+	{
+		name: "title",
+		sub: []*paletteCommand{
+			{name: "edit", run: paletteTitleEdit, action: bug.ActionSetTitle},
+		},
+	},
+	{
+		name: "visibility",
+		sub: []*paletteCommand{
+			{name: "set", run: paletteVisibilitySet, complete: paletteVisibilities, action: bug.ActionSetVisibility},
+		},
+	},
+	{
+		name:   "vote",
+		run:    func(b *cache.BugCache, args []string) error { return b.AddVote() },
+		action: bug.ActionAddVote,
+		sub: []*paletteCommand{
+			{name: "rm", run: func(b *cache.BugCache, args []string) error { return b.RemoveVote() }, action: bug.ActionRemoveVote},
+		},
+	},
+}
+
+// commandPalette is a popup accepting the same subcommands as the command
+// line, applied on the current bug
+type commandPalette struct {
+	active bool
+	bug    *cache.BugCache
+	// hint show the possible completions, if any
+	hint string
+}
+
+func newCommandPalette() *commandPalette {
+	return &commandPalette{}
+}
+
+func (cp *commandPalette) keybindings(g *gocui.Gui) error {
+	// Close
+	if err := g.SetKeybinding(commandPaletteView, gocui.KeyEsc, gocui.ModNone, cp.close); err != nil {
+		return err
+	}
+
+	// Complete
+	if err := g.SetKeybinding(commandPaletteView, gocui.KeyTab, gocui.ModNone, cp.complete); err != nil {
+		return err
+	}
+
+	// Run
+	if err := g.SetKeybinding(commandPaletteView, gocui.KeyEnter, gocui.ModNone, cp.validate); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (cp *commandPalette) layout(g *gocui.Gui) error {
+	if !cp.active {
+		return nil
+	}
+
+	maxX, maxY := g.Size()
+
+	v, err := g.SetView(commandPaletteView, -1, maxY-3, maxX, maxY)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = true
+		v.Editable = true
+	}
+
+	v.Title = commandPaletteTitle
+	if cp.hint != "" {
+		v.Title = cp.hint
+	}
+
+	if _, err := g.SetViewOnTop(commandPaletteView); err != nil {
+		return err
+	}
+
+	if _, err := g.SetCurrentView(commandPaletteView); err != nil {
+		return err
+	}
+
+	g.Cursor = true
+
+	return nil
+}
+
+func (cp *commandPalette) close(g *gocui.Gui, v *gocui.View) error {
+	cp.active = false
+	cp.bug = nil
+	cp.hint = ""
+	g.Cursor = false
+	return g.DeleteView(commandPaletteView)
+}
+
+func (cp *commandPalette) complete(g *gocui.Gui, v *gocui.View) error {
+	input := strings.TrimRight(v.Buffer(), "\n")
+
+	completed, candidates := paletteComplete(cp.bug, input)
+
+	cp.hint = ""
+	if len(candidates) > 1 {
+		cp.hint = strings.Join(candidates, " ")
+	}
+
+	if completed == input {
+		return nil
+	}
+
+	v.Clear()
+	_, _ = fmt.Fprint(v, completed)
+	if err := v.SetOrigin(0, 0); err != nil {
+		return err
+	}
+	return v.SetCursor(len(completed), 0)
+}
+
+func (cp *commandPalette) validate(g *gocui.Gui, v *gocui.View) error {
+	input := strings.TrimSpace(v.Buffer())
+	b := cp.bug
+
+	err := cp.close(g, v)
+	if err != nil {
+		return err
+	}
+
+	if input == "" {
+		return nil
+	}
+
+	err = paletteRun(b, input)
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	return b.CommitAsNeeded()
+}
+
+// Activate open the palette to act on the given bug
+func (cp *commandPalette) Activate(b *cache.BugCache) {
+	cp.active = true
+	cp.bug = b
+	cp.hint = ""
+}
+
+// paletteRun parse and execute a command line on a bug
+func paletteRun(b *cache.BugCache, input string) error {
+	words := strings.Fields(input)
+
+	commands := paletteCommands
+	var cmd *paletteCommand
+
+	for len(words) > 0 {
+		next := findPaletteCommand(commands, words[0])
+		if next == nil {
+			break
+		}
+		cmd = next
+		commands = cmd.sub
+		words = words[1:]
+	}
+
+	if cmd == nil {
+		return fmt.Errorf("unknown command \"%s\"", input)
+	}
+
+	if cmd.run == nil {
+		return fmt.Errorf("missing subcommand for \"%s\", expected one of: %s",
+			cmd.name, strings.Join(paletteCommandNames(cmd.sub), ", "))
+	}
+
+	return cmd.run(b, words)
+}
+
+// paletteComplete complete the last word of the input. It returns the new
+// input and the possible candidates.
+func paletteComplete(b *cache.BugCache, input string) (string, []string) {
+	words := strings.Fields(input)
+
+	// the last word is being typed, unless the input end with a space
+	partial := ""
+	if len(words) > 0 && !strings.HasSuffix(input, " ") {
+		partial = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	commands := paletteCommands
+	var cmd *paletteCommand
+	argsStarted := false
+
+	for _, word := range words {
+		next := findPaletteCommand(commands, word)
+		if next == nil {
+			argsStarted = true
+			break
+		}
+		cmd = next
+		commands = cmd.sub
+	}
+
+	var available []string
+	if !argsStarted {
+		available = paletteCommandNames(paletteAllowedCommands(b, commands))
+	}
+	if cmd != nil && cmd.complete != nil {
+		available = append(available, cmd.complete(b)...)
+	}
+
+	var candidates []string
+	for _, candidate := range available {
+		if strings.HasPrefix(candidate, partial) {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return input, nil
+	}
+
+	prefix := input[:len(input)-len(partial)]
+
+	if len(candidates) == 1 {
+		return prefix + candidates[0] + " ", candidates
+	}
+
+	return prefix + commonPrefix(candidates), candidates
+}
+
+// paletteAllowedCommands filter out the commands that the policy doesn't
+// allow on the bug, so that they are not suggested
+func paletteAllowedCommands(b *cache.BugCache, commands []*paletteCommand) []*paletteCommand {
+	allowed, err := b.AllowedActions()
+	if err != nil {
+		return commands
+	}
+
+	var result []*paletteCommand
+
+outer:
+	for _, cmd := range commands {
+		if cmd.action == "" {
+			result = append(result, cmd)
+			continue
+		}
+		for _, action := range allowed {
+			if action == cmd.action {
+				result = append(result, cmd)
+				continue outer
+			}
+		}
+	}
+
+	return result
+}
+
+func findPaletteCommand(commands []*paletteCommand, name string) *paletteCommand {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+func paletteCommandNames(commands []*paletteCommand) []string {
+	names := make([]string, len(commands))
+	for i, cmd := range commands {
+		names[i] = cmd.name
+	}
+	return names
+}
+
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+func paletteCommentAdd(b *cache.BugCache, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a message is required")
+	}
+	return b.AddComment(strings.Join(args, " "))
+}
+
+func paletteEstimateSet(b *cache.BugCache, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("an estimate is required")
+	}
+
+	estimate, err := strconv.ParseFloat(args[0], 64)
+	if err != nil {
+		return err
+	}
+
+	return b.SetEstimate(estimate)
+}
+
+func paletteLabelAdd(b *cache.BugCache, args []string) error {
+	_, err := b.ChangeLabels(args, nil)
+	return err
+}
+
+func paletteLabelRm(b *cache.BugCache, args []string) error {
+	_, err := b.ChangeLabels(nil, args)
+	return err
+}
+
+func paletteTitleEdit(b *cache.BugCache, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a title is required")
+	}
+	return b.SetTitle(strings.Join(args, " "))
+}
+
+func paletteVisibilitySet(b *cache.BugCache, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("a visibility is required")
+	}
+
+	visibility, err := bug.VisibilityFromString(args[0])
+	if err != nil {
+		return err
+	}
+
+	return b.SetVisibility(visibility)
+}
+
+// paletteVisibilities return the visibilities that don't need a team name
+func paletteVisibilities(b *cache.BugCache) []string {
+	return []string{string(bug.VisibilityPublic), string(bug.VisibilityInternal)}
+}
+
+// paletteBugLabels return the labels of the bug
+func paletteBugLabels(b *cache.BugCache) []string {
+	var result []string
+	for _, label := range b.Snapshot().Labels {
+		result = append(result, label.String())
+	}
+	return result
+}
+
+// paletteMissingLabels return the labels used in the repo but not on the bug
+func paletteMissingLabels(b *cache.BugCache) []string {
+	current := b.Snapshot().Labels
+
+	var result []string
+
+outer:
+	for _, label := range ui.cache.ValidLabels() {
+		for _, l := range current {
+			if l == label {
+				continue outer
+			}
+		}
+		result = append(result, label.String())
+	}
+
+	sort.Strings(result)
+
+	return result
+}
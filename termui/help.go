@@ -0,0 +1,135 @@
+package termui
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/gocui"
+)
+
+const helpView = "helpView"
+
+const helpTitle = "Help ([↓↑,jk] scroll, [q,esc,?] close)"
+
+// helpScreen is an overlay listing the keys of every view
+type helpScreen struct {
+	active bool
+	// view is the view the help was asked from, listed first
+	view   string
+	scroll int
+}
+
+func newHelpScreen() *helpScreen {
+	return &helpScreen{}
+}
+
+func (hs *helpScreen) keybindings(g *gocui.Gui) error {
+	// Close
+	for _, key := range []interface{}{'q', '?', gocui.KeyEsc} {
+		if err := g.SetKeybinding(helpView, key, gocui.ModNone, hs.close); err != nil {
+			return err
+		}
+	}
+
+	// Scrolling
+	for _, key := range []interface{}{'j', gocui.KeyArrowDown} {
+		if err := g.SetKeybinding(helpView, key, gocui.ModNone, hs.scrollDown); err != nil {
+			return err
+		}
+	}
+	for _, key := range []interface{}{'k', gocui.KeyArrowUp} {
+		if err := g.SetKeybinding(helpView, key, gocui.ModNone, hs.scrollUp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (hs *helpScreen) layout(g *gocui.Gui) error {
+	if !hs.active {
+		return nil
+	}
+
+	maxX, maxY := g.Size()
+
+	width := minInt(80, maxX-2)
+	x0 := (maxX - width) / 2
+
+	v, err := g.SetView(helpView, x0, 1, x0+width, maxY-2)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = true
+		v.Title = helpTitle
+	}
+
+	v.Clear()
+
+	// the view the help was asked from first, then the others
+	var views []int
+	for i, kv := range keyViews {
+		if kv.view == hs.view {
+			views = append([]int{i}, views...)
+		} else {
+			views = append(views, i)
+		}
+	}
+
+	for i, index := range views {
+		if i > 0 {
+			_, _ = fmt.Fprintln(v)
+		}
+		_, _ = fmt.Fprintln(v, ui.theme.emphasis(keyViews[index].title))
+		_, _ = fmt.Fprintln(v, ui.keys.help(keyViews[index].view))
+	}
+
+	_, height := v.Size()
+	lines := len(v.BufferLines())
+	hs.scroll = maxInt(0, minInt(hs.scroll, lines-height))
+
+	if err := v.SetOrigin(0, hs.scroll); err != nil {
+		return err
+	}
+
+	if _, err := g.SetViewOnTop(helpView); err != nil {
+		return err
+	}
+
+	if _, err := g.SetCurrentView(helpView); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (hs *helpScreen) scrollDown(g *gocui.Gui, v *gocui.View) error {
+	hs.scroll++
+	return nil
+}
+
+func (hs *helpScreen) scrollUp(g *gocui.Gui, v *gocui.View) error {
+	hs.scroll = maxInt(0, hs.scroll-1)
+	return nil
+}
+
+func (hs *helpScreen) close(g *gocui.Gui, v *gocui.View) error {
+	hs.active = false
+	return g.DeleteView(helpView)
+}
+
+// Activate open the help, starting with the keys of the given view
+func (hs *helpScreen) Activate(view string) {
+	hs.active = true
+	hs.view = view
+	hs.scroll = 0
+}
+
+// showHelp return a keybinding handler opening the help from a view
+func showHelp(view string) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		ui.help.Activate(view)
+		return nil
+	}
+}
@@ -0,0 +1,333 @@
+package termui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/text"
+	"github.com/MichaelMure/gocui"
+)
+
+const identitiesView = "identitiesView"
+const identityDetailView = "identityDetailView"
+const identitiesInstructionView = "identitiesInstructionView"
+
+// identityList is a window listing the identities known in the repository,
+// where the current user can be switched
+type identityList struct {
+	cache      *cache.RepoCache
+	identities []*cache.IdentityExcerpt
+	// user is the identity authoring the changes
+	user     bug.Person
+	adopted  bool
+	selected int
+	// notice confirm the last change, if any
+	notice string
+}
+
+func newIdentityList() *identityList {
+	return &identityList{}
+}
+
+// load read the identities of a repository and its current user
+func (il *identityList) load(c *cache.RepoCache) error {
+	all, err := c.QueryIdentities("")
+	if err != nil {
+		return err
+	}
+
+	user, err := c.GetUser()
+	if err != nil {
+		return err
+	}
+
+	adopted, err := c.AdoptedUser()
+	if err != nil {
+		return err
+	}
+
+	il.cache = c
+	il.identities = all
+	il.user = user
+	il.adopted = adopted != nil
+	il.selected = minInt(il.selected, maxInt(len(all)-1, 0))
+
+	return nil
+}
+
+func (il *identityList) keybindings(g *gocui.Gui) error {
+	// Help
+	if err := ui.keys.bind(g, identitiesView, "help", showHelp(identitiesView)); err != nil {
+		return err
+	}
+
+	// Return
+	if err := ui.keys.bind(g, identitiesView, "quit", il.back); err != nil {
+		return err
+	}
+
+	// Down
+	if err := ui.keys.bind(g, identitiesView, "down", il.selectNext); err != nil {
+		return err
+	}
+
+	// Up
+	if err := ui.keys.bind(g, identitiesView, "up", il.selectPrevious); err != nil {
+		return err
+	}
+
+	// Adopt
+	if err := ui.keys.bind(g, identitiesView, "adopt", il.adopt); err != nil {
+		return err
+	}
+
+	// New identity
+	if err := ui.keys.bind(g, identitiesView, "new", il.create); err != nil {
+		return err
+	}
+
+	// Back to git
+	if err := ui.keys.bind(g, identitiesView, "reset", il.reset); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (il *identityList) layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+
+	if maxY < 4 {
+		// window too small !
+		return nil
+	}
+
+	listWidth := minInt(maxX/2, 60)
+
+	v, err := g.SetView(identitiesView, -1, -1, listWidth, maxY-2)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Highlight = true
+		v.SelBgColor = ui.theme.selectionBg
+		v.SelFgColor = ui.theme.selectionFg
+	}
+
+	v.Clear()
+	il.renderList(v, listWidth)
+
+	_, height := v.Size()
+	oy := maxInt(0, il.selected-height+1)
+	if err := v.SetOrigin(0, oy); err != nil {
+		return err
+	}
+	// window is too small to set the cursor properly, ignoring the error
+	_ = v.SetCursor(0, il.selected-oy)
+
+	v, err = g.SetView(identityDetailView, listWidth, -1, maxX, maxY-2)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Wrap = true
+	}
+
+	v.Clear()
+	il.renderDetail(v)
+
+	v, err = g.SetView(identitiesInstructionView, -1, maxY-2, maxX, maxY)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.FgColor = ui.theme.instructionsFg
+		v.BgColor = ui.theme.instructionsBg
+	}
+
+	v.Clear()
+	_, _ = fmt.Fprint(v, ui.keys.instructions(identitiesView))
+
+	_, err = g.SetCurrentView(identitiesView)
+	return err
+}
+
+func (il *identityList) renderList(v *gocui.View, width int) {
+	if len(il.identities) == 0 {
+		_, _ = fmt.Fprintln(v, ui.theme.muted("No identity in this repository yet."))
+		return
+	}
+
+	for _, identity := range il.identities {
+		current := "  "
+		if identity.Person() == il.user {
+			current = "* "
+		}
+
+		// the marker, the id and a space take 10 cells
+		person := text.LeftPadMaxLine(formatPerson(identity.Person()), width-10, 0)
+		_, _ = fmt.Fprintf(v, "%s%s %s\n", current, ui.theme.id(identity.HumanId()), ui.theme.author(person))
+	}
+}
+
+func (il *identityList) renderDetail(v *gocui.View) {
+	source := "configured in git"
+	if il.adopted {
+		source = "adopted"
+	}
+
+	_, _ = fmt.Fprintf(v, "%s\n", ui.theme.emphasis("Current user"))
+	_, _ = fmt.Fprintf(v, "%s %s, %s\n", il.user.TerminalAvatar(), formatPerson(il.user), source)
+
+	if il.notice != "" {
+		_, _ = fmt.Fprintf(v, "%s\n", ui.theme.hint(il.notice))
+	}
+
+	if il.selected >= len(il.identities) {
+		return
+	}
+
+	identity := il.identities[il.selected]
+
+	_, _ = fmt.Fprintf(v, "\n%s\n", ui.theme.emphasis("Selected identity"))
+	_, _ = fmt.Fprintf(v, "Id: %s\n", ui.theme.id(identity.Id))
+	_, _ = fmt.Fprintf(v, "Name: %s\n", identity.Name)
+	_, _ = fmt.Fprintf(v, "Email: %s\n", identity.Email)
+	if identity.Login != "" {
+		_, _ = fmt.Fprintf(v, "Login: %s\n", identity.Login)
+	}
+	if identity.AvatarUrl != "" {
+		_, _ = fmt.Fprintf(v, "Avatar: %s\n", identity.AvatarUrl)
+	}
+
+	// the identities are immutable, a person changing their name or login
+	// has an identity for each version, linked by their email
+	versions, err := il.cache.IdentitiesByEmail(identity.Email)
+	if err != nil || len(versions) < 2 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(v, "\n%s\n", ui.theme.emphasis("Other versions with this email"))
+	for _, version := range versions {
+		if version.Id == identity.Id {
+			continue
+		}
+		_, _ = fmt.Fprintf(v, "%s %s\n", ui.theme.id(version.HumanId()), formatPerson(version.Person()))
+	}
+}
+
+func (il *identityList) disable(g *gocui.Gui) error {
+	for _, view := range []string{identitiesView, identityDetailView, identitiesInstructionView} {
+		if err := g.DeleteView(view); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+	}
+	return nil
+}
+
+func (il *identityList) selectNext(g *gocui.Gui, v *gocui.View) error {
+	il.notice = ""
+	il.selected = minInt(il.selected+1, maxInt(len(il.identities)-1, 0))
+	return nil
+}
+
+func (il *identityList) selectPrevious(g *gocui.Gui, v *gocui.View) error {
+	il.notice = ""
+	il.selected = maxInt(il.selected-1, 0)
+	return nil
+}
+
+func (il *identityList) back(g *gocui.Gui, v *gocui.View) error {
+	il.notice = ""
+	return ui.activateWindow(ui.bugTable)
+}
+
+// adopt make the selected identity the author of the next changes
+func (il *identityList) adopt(g *gocui.Gui, v *gocui.View) error {
+	if il.selected >= len(il.identities) {
+		return nil
+	}
+
+	identity := il.identities[il.selected]
+
+	return il.setUser(identity.Person())
+}
+
+// create ask for the name and the email of a new identity, and adopt it
+func (il *identityList) create(g *gocui.Gui, v *gocui.View) error {
+	c := ui.inputPopup.Activate("Name of the new identity")
+
+	go func() {
+		name := strings.TrimSpace(<-c)
+		if name == "" {
+			return
+		}
+
+		g.Update(func(g *gocui.Gui) error {
+			c := ui.inputPopup.Activate("Email of the new identity")
+
+			go func() {
+				email := strings.TrimSpace(<-c)
+
+				g.Update(func(g *gocui.Gui) error {
+					return il.setUser(bug.Person{Name: name, Email: email})
+				})
+			}()
+
+			return nil
+		})
+	}()
+
+	return nil
+}
+
+// reset go back to the identity configured in git
+func (il *identityList) reset(g *gocui.Gui, v *gocui.View) error {
+	err := il.cache.ResetUser()
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	err = il.load(il.cache)
+	if err != nil {
+		return err
+	}
+
+	il.notice = "back to the identity configured in git"
+
+	return nil
+}
+
+func (il *identityList) setUser(p bug.Person) error {
+	err := il.cache.SetUser(p)
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	err = il.load(il.cache)
+	if err != nil {
+		return err
+	}
+
+	il.notice = fmt.Sprintf("%s is now the author of your changes", p.DisplayName())
+
+	return nil
+}
+
+// formatPerson display a person with its email, if any
+func formatPerson(p bug.Person) string {
+	if p.Email == "" {
+		return p.DisplayName()
+	}
+	return fmt.Sprintf("%s <%s>", p.DisplayName(), p.Email)
+}
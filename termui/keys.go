@@ -0,0 +1,378 @@
+package termui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/gocui"
+)
+
+// keyConfigPrefix is the prefix of the git config keys rebinding an action,
+// like "git-bug.tui.key.close=c". Several keys are separated by commas.
+const keyConfigPrefix = "git-bug.tui.key."
+
+// keyAction is an action of a view, bound to its default keys unless the
+// configuration say otherwise. An action with the same name in several views
+// is rebound in all of them.
+type keyAction struct {
+	view string
+	name string
+	help string
+	// short is the text of the action in the instructions of the view, if
+	// shown there
+	short string
+	keys  []interface{}
+}
+
+// the views listed in the help screen, with their rebindable actions
+var keyViews = []struct {
+	view  string
+	title string
+}{
+	{bugTableView, "Bug table"},
+	{showBugView, "Bug"},
+	{labelSelectView, "Labels"},
+	{identitiesView, "Identities"},
+}
+
+var keyActions = []keyAction{
+	{bugTableView, "help", "Show this help", "Help", []interface{}{'?'}},
+	{bugTableView, "quit", "Quit", "Quit", []interface{}{'q'}},
+	{bugTableView, "down", "Select the next bug", "", []interface{}{'j', gocui.KeyArrowDown}},
+	{bugTableView, "up", "Select the previous bug", "", []interface{}{'k', gocui.KeyArrowUp}},
+	{bugTableView, "previous-page", "Show the previous page", "", []interface{}{'h', gocui.KeyArrowLeft, gocui.KeyPgup}},
+	{bugTableView, "next-page", "Show the next page", "", []interface{}{'l', gocui.KeyArrowRight, gocui.KeyPgdn}},
+	{bugTableView, "search", "Search the bugs with a query", "Search", []interface{}{'/', 's'}},
+	{bugTableView, "saved-query", "Cycle through the saved queries", "Saved queries", []interface{}{'f'}},
+	{bugTableView, "sort", "Cycle through the orders of the bugs", "Sort", []interface{}{'S'}},
+	{bugTableView, "open", "Open the selected bug", "Open bug", []interface{}{gocui.KeyEnter}},
+	{bugTableView, "close", "Close or reopen the selected bug", "Close/reopen", []interface{}{'x'}},
+	{bugTableView, "label", "Add or remove a label of the selected bug", "Label", []interface{}{'L'}},
+	{bugTableView, "assign", "Assign the selected bug to me", "Assign to me", []interface{}{'a'}},
+	{bugTableView, "new", "Create a new bug", "New bug", []interface{}{'n'}},
+	{bugTableView, "pull", "Pull the bugs from the default remote", "Pull", []interface{}{'i'}},
+	{bugTableView, "push", "Push the bugs to the default remote", "Push", []interface{}{'o'}},
+	{bugTableView, "command", "Run a command on the selected bug", "Command", []interface{}{':'}},
+	{bugTableView, "identities", "Manage the identities and the current user", "Identities", []interface{}{'u'}},
+	{bugTableView, "next-repo", "Show the next repository of the workspace", "Next repository", []interface{}{'w'}},
+
+	{showBugView, "help", "Show this help", "Help", []interface{}{'?'}},
+	{showBugView, "quit", "Save and return to the bug table", "Save and return", []interface{}{'q'}},
+	{showBugView, "down", "Select the next item", "", []interface{}{'j', gocui.KeyArrowDown}},
+	{showBugView, "up", "Select the previous item", "", []interface{}{'k', gocui.KeyArrowUp}},
+	{showBugView, "left", "Select in the timeline", "", []interface{}{'h', gocui.KeyArrowLeft}},
+	{showBugView, "right", "Select in the sidebar", "", []interface{}{'l', gocui.KeyArrowRight}},
+	{showBugView, "scroll-up", "Scroll up", "", []interface{}{gocui.KeyPgup}},
+	{showBugView, "scroll-down", "Scroll down", "", []interface{}{gocui.KeyPgdn}},
+	{showBugView, "close", "Close or reopen the bug", "Toggle open/close", []interface{}{'o'}},
+	{showBugView, "edit", "Edit the selected comment or title", "Edit", []interface{}{'e'}},
+	{showBugView, "comment", "Add a comment", "Comment", []interface{}{'c'}},
+	{showBugView, "title", "Change the title", "Change title", []interface{}{'t'}},
+	{showBugView, "label", "Choose the labels", "Labels", []interface{}{'L'}},
+	{showBugView, "command", "Run a command on the bug", "Command", []interface{}{':'}},
+	{showBugView, "open", "Open a link or an attached file of the selected comment", "Open link", []interface{}{'O'}},
+
+	{labelSelectView, "help", "Show this help", "Help", []interface{}{'?'}},
+	{labelSelectView, "quit", "Save and close", "Save and close", []interface{}{'q'}},
+	{labelSelectView, "cancel", "Close without saving", "Cancel", []interface{}{gocui.KeyEsc}},
+	{labelSelectView, "down", "Select the next label", "", []interface{}{'j', gocui.KeyArrowDown}},
+	{labelSelectView, "up", "Select the previous label", "", []interface{}{'k', gocui.KeyArrowUp}},
+	{labelSelectView, "toggle", "Toggle the selected label", "Toggle", []interface{}{gocui.KeySpace, 'x', gocui.KeyEnter}},
+	{labelSelectView, "add", "Add a new label", "Add label", []interface{}{'a'}},
+
+	{identitiesView, "help", "Show this help", "Help", []interface{}{'?'}},
+	{identitiesView, "quit", "Return to the bug table", "Return", []interface{}{'q'}},
+	{identitiesView, "down", "Select the next identity", "", []interface{}{'j', gocui.KeyArrowDown}},
+	{identitiesView, "up", "Select the previous identity", "", []interface{}{'k', gocui.KeyArrowUp}},
+	{identitiesView, "adopt", "Make the selected identity the author of your changes", "Adopt", []interface{}{'a', gocui.KeyEnter}},
+	{identitiesView, "new", "Create a new identity and adopt it", "New identity", []interface{}{'n'}},
+	{identitiesView, "reset", "Go back to the identity configured in git", "Reset to git", []interface{}{'r'}},
+}
+
+// the names of the special keys, as written in the configuration, and as
+// shown to the user
+var keyNames = []struct {
+	key     gocui.Key
+	name    string
+	display string
+}{
+	{gocui.KeyEnter, "enter", "↵"},
+	{gocui.KeyEsc, "esc", "esc"},
+	{gocui.KeySpace, "space", "space"},
+	{gocui.KeyTab, "tab", "tab"},
+	{gocui.KeyArrowUp, "up", "↑"},
+	{gocui.KeyArrowDown, "down", "↓"},
+	{gocui.KeyArrowLeft, "left", "←"},
+	{gocui.KeyArrowRight, "right", "→"},
+	{gocui.KeyPgup, "pgup", "pgup"},
+	{gocui.KeyPgdn, "pgdn", "pgdn"},
+	{gocui.KeyHome, "home", "home"},
+	{gocui.KeyEnd, "end", "end"},
+	{gocui.KeyDelete, "delete", "del"},
+	{gocui.KeyBackspace2, "backspace", "backspace"},
+	{gocui.KeyF1, "f1", "F1"},
+	{gocui.KeyF2, "f2", "F2"},
+	{gocui.KeyF3, "f3", "F3"},
+	{gocui.KeyF4, "f4", "F4"},
+	{gocui.KeyF5, "f5", "F5"},
+	{gocui.KeyF6, "f6", "F6"},
+	{gocui.KeyF7, "f7", "F7"},
+	{gocui.KeyF8, "f8", "F8"},
+	{gocui.KeyF9, "f9", "F9"},
+	{gocui.KeyF10, "f10", "F10"},
+	{gocui.KeyF11, "f11", "F11"},
+	{gocui.KeyF12, "f12", "F12"},
+}
+
+// keyMap hold the keys of the actions, as configured by the user
+type keyMap struct {
+	// configured are the keys set in the configuration, by action name
+	configured map[string][]interface{}
+	// handlers are the functions bound to the actions, by view and action
+	handlers map[string]func(*gocui.Gui, *gocui.View) error
+}
+
+// instructionButton is an action shown in the instructions of a view, that
+// can be clicked
+type instructionButton struct {
+	action string
+	text   string
+	// start and end are the columns of the button in the instructions
+	start, end int
+}
+
+// readKeyMap read the rebound actions from the configuration of the repo
+func readKeyMap(c *cache.RepoCache) (*keyMap, error) {
+	configs, err := c.ReadConfigs(keyConfigPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	km := &keyMap{
+		configured: make(map[string][]interface{}),
+		handlers:   make(map[string]func(*gocui.Gui, *gocui.View) error),
+	}
+
+	for configKey, value := range configs {
+		name := strings.TrimPrefix(configKey, keyConfigPrefix)
+
+		if !knownAction(name) {
+			return nil, fmt.Errorf("%s: unknown action %s", configKey, name)
+		}
+
+		keys, err := parseKeys(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", configKey, err)
+		}
+
+		km.configured[name] = keys
+	}
+
+	// a key can't trigger two actions of the same view
+	for _, kv := range keyViews {
+		seen := make(map[interface{}]string)
+		for _, action := range keyActions {
+			if action.view != kv.view {
+				continue
+			}
+			for _, key := range km.keys(action) {
+				if other, ok := seen[key]; ok {
+					return nil, fmt.Errorf("the key %s is bound to both %s and %s in the %s view",
+						keyDisplay(key), other, action.name, strings.ToLower(kv.title))
+				}
+				seen[key] = action.name
+			}
+		}
+	}
+
+	return km, nil
+}
+
+// bind register the keys of an action of a view
+func (km *keyMap) bind(g *gocui.Gui, view string, name string, handler func(*gocui.Gui, *gocui.View) error) error {
+	action, ok := findAction(view, name)
+	if !ok {
+		return fmt.Errorf("unknown action %s in %s", name, view)
+	}
+
+	for _, key := range km.keys(action) {
+		if err := g.SetKeybinding(view, key, gocui.ModNone, handler); err != nil {
+			return err
+		}
+	}
+
+	km.handlers[view+"/"+name] = handler
+
+	return nil
+}
+
+// handler return the function bound to an action of a view, if any
+func (km *keyMap) handler(view string, name string) func(*gocui.Gui, *gocui.View) error {
+	return km.handlers[view+"/"+name]
+}
+
+// keys return the keys of an action, configured or by default
+func (km *keyMap) keys(action keyAction) []interface{} {
+	if keys, ok := km.configured[action.name]; ok {
+		return keys
+	}
+	return action.keys
+}
+
+// buttons return the main actions of a view, as shown at the bottom of the
+// screen
+func (km *keyMap) buttons(view string) []instructionButton {
+	var result []instructionButton
+	column := 0
+
+	for _, action := range keyActions {
+		if action.view != view || action.short == "" {
+			continue
+		}
+		// the workspace is only there with several repositories
+		if action.name == "next-repo" && ui.workspace == nil {
+			continue
+		}
+		keys := km.keys(action)
+		if len(keys) == 0 {
+			continue
+		}
+
+		text := fmt.Sprintf("[%s] %s", keysDisplay(keys), action.short)
+		width := utf8.RuneCountInString(text)
+		result = append(result, instructionButton{
+			action: action.name,
+			text:   text,
+			start:  column,
+			end:    column + width,
+		})
+		column += width + 1
+	}
+
+	return result
+}
+
+// instructions return the text listing the main actions of a view
+func (km *keyMap) instructions(view string) string {
+	buttons := km.buttons(view)
+	texts := make([]string, len(buttons))
+	for i, button := range buttons {
+		texts[i] = button.text
+	}
+	return strings.Join(texts, " ")
+}
+
+// help return the text listing all the actions of a view
+func (km *keyMap) help(view string) string {
+	var lines []string
+
+	for _, action := range keyActions {
+		if action.view != view {
+			continue
+		}
+		keys := "-"
+		if len(km.keys(action)) > 0 {
+			keys = keysDisplay(km.keys(action))
+		}
+		lines = append(lines, fmt.Sprintf("  %-14s %-16s %s", keys, action.name, action.help))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func findAction(view string, name string) (keyAction, bool) {
+	for _, action := range keyActions {
+		if action.view == view && action.name == name {
+			return action, true
+		}
+	}
+	return keyAction{}, false
+}
+
+func knownAction(name string) bool {
+	for _, action := range keyActions {
+		if action.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// parseKeys parse a list of keys as found in the configuration, like "c",
+// "enter" or "ctrl-o,x". An empty list unbind the action.
+func parseKeys(str string) ([]interface{}, error) {
+	var result []interface{}
+
+	for _, item := range strings.Split(str, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		key, err := parseKey(item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, key)
+	}
+
+	return result, nil
+}
+
+func parseKey(str string) (interface{}, error) {
+	if utf8.RuneCountInString(str) == 1 {
+		r, _ := utf8.DecodeRuneInString(str)
+		return r, nil
+	}
+
+	lower := strings.ToLower(str)
+
+	if lower == "comma" {
+		return ',', nil
+	}
+
+	for _, kn := range keyNames {
+		if kn.name == lower {
+			return kn.key, nil
+		}
+	}
+
+	if strings.HasPrefix(lower, "ctrl-") && len(lower) == len("ctrl-")+1 {
+		c := lower[len(lower)-1]
+		if c >= 'a' && c <= 'z' {
+			return gocui.KeyCtrlA + gocui.Key(c-'a'), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown key %s", str)
+}
+
+func keysDisplay(keys []interface{}) string {
+	result := make([]string, len(keys))
+	for i, key := range keys {
+		result[i] = keyDisplay(key)
+	}
+	return strings.Join(result, ",")
+}
+
+func keyDisplay(key interface{}) string {
+	switch key := key.(type) {
+	case rune:
+		if key == ',' {
+			return "comma"
+		}
+		return string(key)
+	case gocui.Key:
+		for _, kn := range keyNames {
+			if kn.key == key {
+				return kn.display
+			}
+		}
+		if key >= gocui.KeyCtrlA && key <= gocui.KeyCtrlZ {
+			return fmt.Sprintf("ctrl-%c", 'a'+rune(key-gocui.KeyCtrlA))
+		}
+	}
+	return "?"
+}
@@ -54,42 +54,41 @@ func (ls *labelSelect) SetBug(cache *cache.RepoCache, bug *cache.BugCache) {
 }
 
 func (ls *labelSelect) keybindings(g *gocui.Gui) error {
+	// Help
+	if err := ui.keys.bind(g, labelSelectView, "help", showHelp(labelSelectView)); err != nil {
+		return err
+	}
+
 	// Abort
-	if err := g.SetKeybinding(labelSelectView, gocui.KeyEsc, gocui.ModNone, ls.abort); err != nil {
+	if err := ui.keys.bind(g, labelSelectView, "cancel", ls.abort); err != nil {
 		return err
 	}
+
 	// Save and return
-	if err := g.SetKeybinding(labelSelectView, 'q', gocui.ModNone, ls.saveAndReturn); err != nil {
+	if err := ui.keys.bind(g, labelSelectView, "quit", ls.saveAndReturn); err != nil {
 		return err
 	}
+
 	// Up
-	if err := g.SetKeybinding(labelSelectView, gocui.KeyArrowUp, gocui.ModNone, ls.selectPrevious); err != nil {
-		return err
-	}
-	if err := g.SetKeybinding(labelSelectView, 'k', gocui.ModNone, ls.selectPrevious); err != nil {
+	if err := ui.keys.bind(g, labelSelectView, "up", ls.selectPrevious); err != nil {
 		return err
 	}
+
 	// Down
-	if err := g.SetKeybinding(labelSelectView, gocui.KeyArrowDown, gocui.ModNone, ls.selectNext); err != nil {
-		return err
-	}
-	if err := g.SetKeybinding(labelSelectView, 'j', gocui.ModNone, ls.selectNext); err != nil {
+	if err := ui.keys.bind(g, labelSelectView, "down", ls.selectNext); err != nil {
 		return err
 	}
+
 	// Select
-	if err := g.SetKeybinding(labelSelectView, gocui.KeySpace, gocui.ModNone, ls.selectItem); err != nil {
-		return err
-	}
-	if err := g.SetKeybinding(labelSelectView, 'x', gocui.ModNone, ls.selectItem); err != nil {
-		return err
-	}
-	if err := g.SetKeybinding(labelSelectView, gocui.KeyEnter, gocui.ModNone, ls.selectItem); err != nil {
+	if err := ui.keys.bind(g, labelSelectView, "toggle", ls.selectItem); err != nil {
 		return err
 	}
+
 	// Add
-	if err := g.SetKeybinding(labelSelectView, 'a', gocui.ModNone, ls.addItem); err != nil {
+	if err := ui.keys.bind(g, labelSelectView, "add", ls.addItem); err != nil {
 		return err
 	}
+
 	return nil
 }
 
@@ -138,10 +137,11 @@ func (ls *labelSelect) layout(g *gocui.Gui) error {
 			return err
 		}
 		v.Frame = false
-		v.BgColor = gocui.ColorBlue
+		v.FgColor = ui.theme.instructionsFg
+		v.BgColor = ui.theme.instructionsBg
 	}
 	v.Clear()
-	fmt.Fprint(v, "[q] Save and close [↓↑,jk] Nav [a] Add item")
+	fmt.Fprint(v, ui.keys.instructions(labelSelectView))
 	if _, err = g.SetViewOnTop(labelSelectInstructionsView); err != nil {
 		return err
 	}
@@ -222,13 +222,16 @@ func (ls *labelSelect) addItem(g *gocui.Gui, v *gocui.View) error {
 	go func() {
 		input := <-c
 
-		// Standardize label format
-		input = strings.TrimSuffix(input, "\n")
-		input = strings.Replace(input, " ", "-", -1)
+		// the label is written according to the label policy when it's
+		// applied, only the extra whitespaces are removed here
+		input = strings.Join(strings.Fields(input), " ")
+		if input == "" {
+			return
+		}
 
 		// Check if label already exists
 		for i, label := range ls.labels {
-			if input == label.String() {
+			if label.Equivalent(bug.Label(input)) {
 				ls.labelSelect[i] = true
 				ls.selected = i
 
@@ -246,7 +249,11 @@ func (ls *labelSelect) addItem(g *gocui.Gui, v *gocui.View) error {
 		ls.selected = len(ls.labels) - 1
 
 		g.Update(func(g *gocui.Gui) error {
-			return nil
+			// the view of the new label has to exist to be focused
+			if err := ls.layout(g); err != nil {
+				return err
+			}
+			return ls.focusView(g)
 		})
 	}()
 
@@ -296,6 +303,10 @@ func (ls *labelSelect) saveAndReturn(g *gocui.Gui, v *gocui.View) error {
 		}
 	}
 
+	if len(newLabels) == 0 && len(rmLabels) == 0 {
+		return ui.activateWindow(ui.showBug)
+	}
+
 	if _, err := ls.bug.ChangeLabels(newLabels, rmLabels); err != nil {
 		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
 	}
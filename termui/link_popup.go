@@ -0,0 +1,240 @@
+package termui
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/MichaelMure/git-bug/util/text"
+	"github.com/MichaelMure/gocui"
+	"github.com/skratchdot/open-golang/open"
+)
+
+const linkPopupView = "linkPopupView"
+
+const linkPopupTitle = "Open ([↓↑,jk] select, [↵] open, [q,esc] close)"
+
+// urlRegexp match the URLs written in a message
+var urlRegexp = regexp.MustCompile(`https?://[^\s<>()\[\]"'` + "`" + `]+`)
+
+// link is something of a comment that can be opened, either an URL or an
+// attached file
+type link struct {
+	url  string
+	file git.Hash
+	// name is the name of the attached file, if any
+	name string
+}
+
+func (l link) String() string {
+	if l.url != "" {
+		return l.url
+	}
+	if l.name != "" {
+		return fmt.Sprintf("%s %s", l.name, ui.theme.muted(l.file.String()[:7]))
+	}
+	return l.file.String()
+}
+
+// commentLinks return the URLs and the attached files of a comment
+func commentLinks(b *cache.BugCache, comment *bug.CommentTimelineItem) []link {
+	var result []link
+
+	for _, url := range urlRegexp.FindAllString(comment.Message, -1) {
+		url = strings.TrimRight(url, ".,;:!?")
+		if !containsLink(result, url) {
+			result = append(result, link{url: url})
+		}
+	}
+
+	names := make(map[git.Hash]string)
+	for _, attachment := range b.Attachments() {
+		if attachment.Name != "" {
+			names[attachment.Hash] = attachment.Name
+		}
+	}
+
+	for _, hash := range comment.Files {
+		result = append(result, link{file: hash, name: names[hash]})
+	}
+
+	return result
+}
+
+func containsLink(links []link, url string) bool {
+	for _, l := range links {
+		if l.url == url {
+			return true
+		}
+	}
+	return false
+}
+
+// openLink open an URL or an attached file with the program of the system
+// handling it. The attached files are exported in a temporary directory
+// first, and left there as the program might read them later.
+func openLink(repo *cache.RepoCache, l link) error {
+	if l.url != "" {
+		return open.Start(l.url)
+	}
+
+	data, err := repo.ReadAttachment(l.file)
+	if err != nil {
+		return err
+	}
+
+	dir, err := ioutil.TempDir("", "git-bug-")
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Base(l.name)
+	if l.name == "" || name == "." || name == string(os.PathSeparator) {
+		name = l.file.String()
+	}
+
+	path := filepath.Join(dir, name)
+
+	err = ioutil.WriteFile(path, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	return open.Start(path)
+}
+
+// linkPopup let the user choose which link of a comment to open, when there
+// is more than one
+type linkPopup struct {
+	active   bool
+	repo     *cache.RepoCache
+	links    []link
+	selected int
+}
+
+func newLinkPopup() *linkPopup {
+	return &linkPopup{}
+}
+
+func (lp *linkPopup) keybindings(g *gocui.Gui) error {
+	// Close
+	for _, key := range []interface{}{'q', gocui.KeyEsc} {
+		if err := g.SetKeybinding(linkPopupView, key, gocui.ModNone, lp.close); err != nil {
+			return err
+		}
+	}
+
+	// Open
+	if err := g.SetKeybinding(linkPopupView, gocui.KeyEnter, gocui.ModNone, lp.open); err != nil {
+		return err
+	}
+
+	// Selection
+	for _, key := range []interface{}{'j', gocui.KeyArrowDown} {
+		if err := g.SetKeybinding(linkPopupView, key, gocui.ModNone, lp.selectNext); err != nil {
+			return err
+		}
+	}
+	for _, key := range []interface{}{'k', gocui.KeyArrowUp} {
+		if err := g.SetKeybinding(linkPopupView, key, gocui.ModNone, lp.selectPrevious); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (lp *linkPopup) layout(g *gocui.Gui) error {
+	if !lp.active {
+		return nil
+	}
+
+	maxX, maxY := g.Size()
+
+	width := minInt(80, maxX-2)
+	height := minInt(len(lp.links)+1, maxY-3)
+	x0 := (maxX - width) / 2
+	y0 := (maxY - height) / 2
+
+	v, err := g.SetView(linkPopupView, x0, y0, x0+width, y0+height)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = true
+		v.Title = linkPopupTitle
+		v.Highlight = true
+		v.SelBgColor = ui.theme.selectionBg
+		v.SelFgColor = ui.theme.selectionFg
+	}
+
+	v.Clear()
+	for _, l := range lp.links {
+		_, _ = fmt.Fprintln(v, text.LeftPadMaxLine(l.String(), width-1, 0))
+	}
+
+	_, viewHeight := v.Size()
+	oy := maxInt(0, lp.selected-viewHeight+1)
+	if err := v.SetOrigin(0, oy); err != nil {
+		return err
+	}
+	// window is too small to set the cursor properly, ignoring the error
+	_ = v.SetCursor(0, lp.selected-oy)
+
+	if _, err := g.SetViewOnTop(linkPopupView); err != nil {
+		return err
+	}
+
+	if _, err := g.SetCurrentView(linkPopupView); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (lp *linkPopup) selectNext(g *gocui.Gui, v *gocui.View) error {
+	lp.selected = minInt(lp.selected+1, len(lp.links)-1)
+	return nil
+}
+
+func (lp *linkPopup) selectPrevious(g *gocui.Gui, v *gocui.View) error {
+	lp.selected = maxInt(lp.selected-1, 0)
+	return nil
+}
+
+func (lp *linkPopup) open(g *gocui.Gui, v *gocui.View) error {
+	l := lp.links[lp.selected]
+
+	err := lp.close(g, v)
+	if err != nil {
+		return err
+	}
+
+	err = openLink(lp.repo, l)
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+	}
+
+	return nil
+}
+
+func (lp *linkPopup) close(g *gocui.Gui, v *gocui.View) error {
+	lp.active = false
+	lp.links = nil
+	return g.DeleteView(linkPopupView)
+}
+
+// Activate open the popup to choose one of the links
+func (lp *linkPopup) Activate(repo *cache.RepoCache, links []link) {
+	lp.active = true
+	lp.repo = repo
+	lp.links = links
+	lp.selected = 0
+}
@@ -0,0 +1,247 @@
+package termui
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/gocui"
+)
+
+// the instruction views, with the view their buttons act on
+var instructionViews = map[string]string{
+	bugTableInstructionView:     bugTableView,
+	showBugInstructionView:      showBugView,
+	labelSelectInstructionsView: labelSelectView,
+	identitiesInstructionView:   identitiesView,
+}
+
+// mouseBindings register the mouse handlers. The mouse events are sent to the
+// view under the pointer, whatever the current view, so the handlers are
+// global and dispatch the events according to the active window.
+func mouseBindings(g *gocui.Gui) error {
+	// Selection
+	if err := g.SetKeybinding("", gocui.MouseLeft, gocui.ModNone, mouseClick); err != nil {
+		return err
+	}
+
+	// Scrolling
+	if err := g.SetKeybinding("", gocui.MouseWheelDown, gocui.ModNone, mouseWheel(true)); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", gocui.MouseWheelUp, gocui.ModNone, mouseWheel(false)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// popupActive tell if a popup has the focus, in which case the mouse is
+// ignored and the keyboard is used instead
+func popupActive() bool {
+	return ui.msgPopup.active || ui.inputPopup.active || ui.progressPopup.active ||
+		ui.linkPopup.active || ui.palette.active || ui.searchBar.active || ui.help.active
+}
+
+func mouseClick(g *gocui.Gui, v *gocui.View) error {
+	if v == nil {
+		return nil
+	}
+
+	if popupActive() {
+		// the click moved the cursor of the table already, put it back
+		if v.Name() == bugTableView {
+			_ = v.SetCursor(0, ui.bugTable.selectCursor)
+		}
+		return nil
+	}
+
+	if view, ok := instructionViews[v.Name()]; ok {
+		return clickButton(g, v, view)
+	}
+
+	switch ui.activeWindow {
+	case ui.bugTable:
+		return ui.bugTable.click(g, v)
+	case ui.showBug:
+		return ui.showBug.click(g, v)
+	case ui.labelSelect:
+		return ui.labelSelect.click(g, v)
+	case ui.identityList:
+		return ui.identityList.click(g, v)
+	}
+
+	return nil
+}
+
+func mouseWheel(down bool) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		if ui.help.active {
+			if down {
+				return ui.help.scrollDown(g, v)
+			}
+			return ui.help.scrollUp(g, v)
+		}
+
+		if ui.progressPopup.active {
+			popup, err := g.View(progressPopupView)
+			if err != nil {
+				return nil
+			}
+			if down {
+				return ui.progressPopup.scrollDown(g, popup)
+			}
+			return ui.progressPopup.scrollUp(g, popup)
+		}
+
+		if popupActive() {
+			return nil
+		}
+
+		var view string
+		var handler func(*gocui.Gui, *gocui.View) error
+
+		switch ui.activeWindow {
+		case ui.bugTable:
+			view, handler = bugTableView, ui.bugTable.previousPage
+			if down {
+				handler = ui.bugTable.nextPage
+			}
+		case ui.showBug:
+			view, handler = showBugView, ui.showBug.scrollUp
+			if down {
+				handler = ui.showBug.scrollDown
+			}
+		case ui.labelSelect:
+			view, handler = labelSelectView, ui.labelSelect.selectPrevious
+			if down {
+				handler = ui.labelSelect.selectNext
+			}
+		case ui.identityList:
+			view, handler = identitiesView, ui.identityList.selectPrevious
+			if down {
+				handler = ui.identityList.selectNext
+			}
+		default:
+			return nil
+		}
+
+		// act as if the key was typed in the main view of the window
+		mainView, err := g.View(view)
+		if err != nil {
+			return nil
+		}
+
+		return handler(g, mainView)
+	}
+}
+
+// clickButton run the action of the button clicked in the instructions
+func clickButton(g *gocui.Gui, v *gocui.View, view string) error {
+	x, _ := v.Cursor()
+
+	for _, button := range ui.keys.buttons(view) {
+		if x < button.start || x >= button.end {
+			continue
+		}
+
+		handler := ui.keys.handler(view, button.action)
+		if handler == nil {
+			return nil
+		}
+
+		mainView, err := g.View(view)
+		if err != nil {
+			return nil
+		}
+
+		return handler(g, mainView)
+	}
+
+	return nil
+}
+
+// click select the bug clicked, or open it if already selected
+func (bt *bugTable) click(g *gocui.Gui, v *gocui.View) error {
+	if v.Name() != bugTableView {
+		return nil
+	}
+
+	bt.notice = ""
+
+	// the cursor has been moved on the click already
+	_, y := v.Cursor()
+	if y >= len(bt.bugs) {
+		_ = v.SetCursor(0, bt.selectCursor)
+		return nil
+	}
+
+	if y == bt.selectCursor {
+		return bt.openBug(g, v)
+	}
+
+	bt.selectCursor = y
+
+	return nil
+}
+
+// click select the comment or the sidebar item clicked
+func (sb *showBug) click(g *gocui.Gui, v *gocui.View) error {
+	name := v.Name()
+
+	for _, selectable := range sb.mainSelectableView {
+		if selectable == name {
+			sb.isOnSide = false
+			sb.selected = name
+			return sb.focusView(g)
+		}
+	}
+
+	for _, selectable := range sb.sideSelectableView {
+		if selectable == name {
+			sb.isOnSide = true
+			sb.selected = name
+			return sb.focusView(g)
+		}
+	}
+
+	return nil
+}
+
+// click select the label clicked, or toggle it if already selected
+func (ls *labelSelect) click(g *gocui.Gui, v *gocui.View) error {
+	var i int
+	if _, err := fmt.Sscanf(v.Name(), "view%d", &i); err != nil || i >= len(ls.labels) {
+		return nil
+	}
+
+	if i == ls.selected {
+		return ls.selectItem(g, v)
+	}
+
+	ls.selected = i
+
+	return ls.focusView(g)
+}
+
+// click select the identity clicked, or adopt it if already selected
+func (il *identityList) click(g *gocui.Gui, v *gocui.View) error {
+	if v.Name() != identitiesView {
+		return nil
+	}
+
+	il.notice = ""
+
+	// the cursor has been moved on the click already
+	_, y := v.Cursor()
+	_, oy := v.Origin()
+	if oy+y >= len(il.identities) {
+		return nil
+	}
+
+	if oy+y == il.selected {
+		return il.adopt(g, v)
+	}
+
+	il.selected = oy + y
+
+	return nil
+}
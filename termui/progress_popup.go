@@ -0,0 +1,213 @@
+package termui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/util/text"
+	"github.com/MichaelMure/gocui"
+)
+
+const progressPopupView = "progressPopupView"
+
+// progressPopup show the progress of a long operation, like a pull or a
+// push, as a summary followed by a scrollable list of results. The operation
+// can be canceled with Esc while it runs.
+type progressPopup struct {
+	active  bool
+	title   string
+	summary string
+	lines   []string
+	running bool
+	cancel  context.CancelFunc
+	// scroll is the first line shown, or -1 to follow the new lines
+	scroll int
+}
+
+func newProgressPopup() *progressPopup {
+	return &progressPopup{}
+}
+
+func (pp *progressPopup) keybindings(g *gocui.Gui) error {
+	// Cancel or close
+	if err := g.SetKeybinding(progressPopupView, gocui.KeyEsc, gocui.ModNone, pp.cancelOrClose); err != nil {
+		return err
+	}
+
+	// Close, once done
+	for _, key := range []interface{}{'q', gocui.KeyEnter, gocui.KeySpace} {
+		if err := g.SetKeybinding(progressPopupView, key, gocui.ModNone, pp.close); err != nil {
+			return err
+		}
+	}
+
+	// Scrolling
+	for _, key := range []interface{}{'j', gocui.KeyArrowDown} {
+		if err := g.SetKeybinding(progressPopupView, key, gocui.ModNone, pp.scrollDown); err != nil {
+			return err
+		}
+	}
+	for _, key := range []interface{}{'k', gocui.KeyArrowUp} {
+		if err := g.SetKeybinding(progressPopupView, key, gocui.ModNone, pp.scrollUp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pp *progressPopup) layout(g *gocui.Gui) error {
+	if !pp.active {
+		return nil
+	}
+
+	maxX, maxY := g.Size()
+
+	width := minInt(70, maxX-2)
+
+	summary, lines := text.Wrap(ui.theme.emphasis(pp.summary), width-2)
+	content := []string{summary}
+	for _, line := range pp.lines {
+		wrapped, n := text.Wrap(line, width-2)
+		content = append(content, wrapped)
+		lines += n
+	}
+
+	height := maxInt(2, minInt(lines+1, maxY-4))
+	x0 := (maxX - width) / 2
+	y0 := (maxY - height) / 2
+
+	v, err := g.SetView(progressPopupView, x0, y0, x0+width, y0+height)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = true
+	}
+
+	if pp.running {
+		v.Title = pp.title + " ([esc] cancel)"
+	} else {
+		v.Title = pp.title + " ([↓↑,jk] scroll, [q,esc] close)"
+	}
+
+	v.Clear()
+	for _, line := range content {
+		_, _ = fmt.Fprintln(v, line)
+	}
+
+	// follow the new lines, unless scrolled by the user
+	bottom := maxInt(0, lines-(height-1))
+	if pp.scroll < 0 || pp.scroll >= bottom {
+		pp.scroll = -1
+		if err := v.SetOrigin(0, bottom); err != nil {
+			return err
+		}
+	} else if err := v.SetOrigin(0, pp.scroll); err != nil {
+		return err
+	}
+
+	if _, err := g.SetViewOnTop(progressPopupView); err != nil {
+		return err
+	}
+
+	if _, err := g.SetCurrentView(progressPopupView); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (pp *progressPopup) scrollDown(g *gocui.Gui, v *gocui.View) error {
+	if pp.scroll >= 0 {
+		pp.scroll++
+	}
+	return nil
+}
+
+func (pp *progressPopup) scrollUp(g *gocui.Gui, v *gocui.View) error {
+	if pp.scroll < 0 {
+		_, oy := v.Origin()
+		pp.scroll = oy
+	}
+	pp.scroll = maxInt(0, pp.scroll-1)
+	return nil
+}
+
+// cancelOrClose cancel the operation if it's still running, or close the
+// popup otherwise
+func (pp *progressPopup) cancelOrClose(g *gocui.Gui, v *gocui.View) error {
+	if pp.running {
+		pp.cancel()
+		pp.summary = "Canceling ..."
+		return nil
+	}
+
+	return pp.close(g, v)
+}
+
+func (pp *progressPopup) close(g *gocui.Gui, v *gocui.View) error {
+	if pp.running {
+		return nil
+	}
+
+	pp.active = false
+	pp.lines = nil
+	return g.DeleteView(progressPopupView)
+}
+
+// Activate open the popup for a new operation, returning the context to
+// give to the operation to be able to cancel it
+func (pp *progressPopup) Activate(title string) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	pp.active = true
+	pp.title = title
+	pp.summary = "..."
+	pp.lines = nil
+	pp.running = true
+	pp.cancel = cancel
+	pp.scroll = -1
+
+	return ctx
+}
+
+// SetSummary change the first line of the popup, from the goroutine running
+// the operation
+func (pp *progressPopup) SetSummary(g *gocui.Gui, summary string) {
+	g.Update(func(g *gocui.Gui) error {
+		pp.summary = summary
+		return nil
+	})
+}
+
+// AddLine append a result to the popup, from the goroutine running the
+// operation
+func (pp *progressPopup) AddLine(g *gocui.Gui, line string) {
+	g.Update(func(g *gocui.Gui) error {
+		pp.lines = append(pp.lines, line)
+		return nil
+	})
+}
+
+// Finish mark the operation as done, with its final summary or the error
+// which stopped it
+func (pp *progressPopup) Finish(g *gocui.Gui, summary string, err error) {
+	g.Update(func(g *gocui.Gui) error {
+		pp.running = false
+		pp.cancel()
+
+		switch {
+		case err == context.Canceled:
+			pp.summary = "Canceled, " + summary
+		case err != nil:
+			pp.summary = summary
+			pp.lines = append(pp.lines, ui.theme.hint(err.Error()))
+		default:
+			pp.summary = summary
+		}
+
+		return nil
+	})
+}
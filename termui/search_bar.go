@@ -0,0 +1,275 @@
+package termui
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/gocui"
+)
+
+const searchBarView = "searchBarView"
+
+const searchBarTitle = "Search ([↵] keep, [↑↓] history, [esc] cancel)"
+
+const queryHistoryFile = "termui-query-history"
+const queryHistoryMaxLength = 20
+
+// searchBar is an input bar filtering the bug table as the query is typed
+type searchBar struct {
+	active bool
+	// previous is the query shown before the search, restored on cancel
+	previous string
+	// history are the recent queries, the most recent first
+	history []string
+	// historyCursor is the position in the history, -1 when editing a new query
+	historyCursor int
+	// typed is the query being typed, kept while browsing the history
+	typed string
+	// err is the error of the query being typed, if any
+	err error
+}
+
+func newSearchBar() *searchBar {
+	return &searchBar{}
+}
+
+func (sb *searchBar) keybindings(g *gocui.Gui) error {
+	// Cancel
+	if err := g.SetKeybinding(searchBarView, gocui.KeyEsc, gocui.ModNone, sb.cancel); err != nil {
+		return err
+	}
+
+	// Keep the query
+	if err := g.SetKeybinding(searchBarView, gocui.KeyEnter, gocui.ModNone, sb.validate); err != nil {
+		return err
+	}
+
+	// History
+	if err := g.SetKeybinding(searchBarView, gocui.KeyArrowUp, gocui.ModNone, sb.historyUp); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(searchBarView, gocui.KeyArrowDown, gocui.ModNone, sb.historyDown); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (sb *searchBar) layout(g *gocui.Gui) error {
+	if !sb.active {
+		return nil
+	}
+
+	maxX, maxY := g.Size()
+
+	v, err := g.SetView(searchBarView, -1, maxY-3, maxX, maxY)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = true
+		v.Editable = true
+		v.Editor = gocui.EditorFunc(sb.edit)
+
+		if err := sb.setQuery(v, sb.previous); err != nil {
+			return err
+		}
+	}
+
+	v.Title = searchBarTitle
+	if sb.err != nil {
+		v.Title = sb.err.Error()
+	}
+
+	if _, err := g.SetViewOnTop(searchBarView); err != nil {
+		return err
+	}
+
+	if _, err := g.SetCurrentView(searchBarView); err != nil {
+		return err
+	}
+
+	g.Cursor = true
+
+	return nil
+}
+
+// edit apply the keys typed in the bar, and filter the table accordingly
+func (sb *searchBar) edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+	gocui.DefaultEditor.Edit(v, key, ch, mod)
+
+	sb.historyCursor = -1
+	sb.apply(v.Buffer())
+}
+
+// apply show the result of a query in the bug table. An invalid query, often
+// one being typed, leave the table as it is.
+func (sb *searchBar) apply(queryStr string) {
+	bt := ui.bugTable
+	queryStr = strings.TrimSpace(queryStr)
+
+	query, err := bt.parseQuery(queryStr)
+	sb.err = err
+	if err != nil {
+		return
+	}
+
+	if queryStr == bt.queryStr {
+		return
+	}
+
+	bt.queryStr = queryStr
+	bt.query = query
+	bt.pageCursor = 0
+	bt.selectCursor = 0
+}
+
+// setQuery replace the content of the bar and filter the table accordingly
+func (sb *searchBar) setQuery(v *gocui.View, queryStr string) error {
+	v.Clear()
+	_, _ = fmt.Fprint(v, queryStr)
+	if err := v.SetOrigin(0, 0); err != nil {
+		return err
+	}
+	if err := v.SetCursor(len(queryStr), 0); err != nil {
+		return err
+	}
+
+	sb.apply(queryStr)
+
+	return nil
+}
+
+func (sb *searchBar) historyUp(g *gocui.Gui, v *gocui.View) error {
+	if sb.historyCursor+1 >= len(sb.history) {
+		return nil
+	}
+
+	if sb.historyCursor == -1 {
+		sb.typed = strings.TrimSpace(v.Buffer())
+	}
+
+	sb.historyCursor++
+
+	return sb.setQuery(v, sb.history[sb.historyCursor])
+}
+
+func (sb *searchBar) historyDown(g *gocui.Gui, v *gocui.View) error {
+	if sb.historyCursor == -1 {
+		return nil
+	}
+
+	sb.historyCursor--
+
+	if sb.historyCursor == -1 {
+		return sb.setQuery(v, sb.typed)
+	}
+
+	return sb.setQuery(v, sb.history[sb.historyCursor])
+}
+
+func (sb *searchBar) validate(g *gocui.Gui, v *gocui.View) error {
+	queryStr := strings.TrimSpace(v.Buffer())
+
+	sb.apply(queryStr)
+	if sb.err != nil {
+		// keep the bar open to fix the query
+		return nil
+	}
+
+	err := sb.close(g)
+	if err != nil {
+		return err
+	}
+
+	if queryStr == "" {
+		return nil
+	}
+
+	err = writeQueryHistory(ui.bugTable.repo, append([]string{queryStr}, sb.history...))
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+	}
+
+	return nil
+}
+
+func (sb *searchBar) cancel(g *gocui.Gui, v *gocui.View) error {
+	sb.apply(sb.previous)
+	return sb.close(g)
+}
+
+func (sb *searchBar) close(g *gocui.Gui) error {
+	sb.active = false
+	sb.history = nil
+	sb.typed = ""
+	sb.err = nil
+	g.Cursor = false
+	return g.DeleteView(searchBarView)
+}
+
+// Activate open the search bar, starting from the query shown in the table
+func (sb *searchBar) Activate() error {
+	history, err := readQueryHistory(ui.bugTable.repo)
+	if err != nil {
+		return err
+	}
+
+	sb.active = true
+	sb.previous = ui.bugTable.queryStr
+	sb.history = history
+	sb.historyCursor = -1
+	sb.typed = ""
+	sb.err = nil
+
+	return nil
+}
+
+// readQueryHistory return the recent queries of the search bar, the most
+// recent first
+func readQueryHistory(repo *cache.RepoCache) ([]string, error) {
+	data, err := ioutil.ReadFile(queryHistoryFilePath(repo))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) != "" {
+			result = append(result, line)
+		}
+	}
+
+	return result, nil
+}
+
+func writeQueryHistory(repo *cache.RepoCache, history []string) error {
+	// keep only the most recent use of a query
+	seen := make(map[string]bool)
+	var deduplicated []string
+	for _, queryStr := range history {
+		if !seen[queryStr] {
+			seen[queryStr] = true
+			deduplicated = append(deduplicated, queryStr)
+		}
+	}
+
+	if len(deduplicated) > queryHistoryMaxLength {
+		deduplicated = deduplicated[:queryHistoryMaxLength]
+	}
+
+	data := strings.Join(deduplicated, "\n") + "\n"
+	return ioutil.WriteFile(queryHistoryFilePath(repo), []byte(data), 0666)
+}
+
+func queryHistoryFilePath(repo *cache.RepoCache) string {
+	return path.Join(repo.GetGitDir(), "git-bug", queryHistoryFile)
+}
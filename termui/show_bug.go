@@ -7,16 +7,21 @@ import (
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
-	"github.com/MichaelMure/git-bug/util/colors"
 	"github.com/MichaelMure/git-bug/util/git"
 	"github.com/MichaelMure/git-bug/util/text"
 	"github.com/MichaelMure/gocui"
+	"github.com/dustin/go-humanize"
 )
 
 const showBugView = "showBugView"
 const showBugSidebarView = "showBugSidebarView"
 const showBugInstructionView = "showBugInstructionView"
 const showBugHeaderView = "showBugHeaderView"
+const showBugMoreView = "showBugMoreView"
+
+// timelinePageSize is the number of timeline items displayed at first, and
+// added each time the end of the timeline is reached
+const timelinePageSize = 100
 
 const timeLayout = "Jan 2 2006"
 
@@ -29,6 +34,12 @@ type showBug struct {
 	selected           string
 	isOnSide           bool
 	scroll             int
+
+	// timeline is the displayed page of the timeline, compiled again only
+	// when the snapshot it was compiled for change or when it grows
+	timeline      []bug.TimelineItem
+	timelineSnap  *bug.Snapshot
+	timelineLimit int
 }
 
 func newShowBug(cache *cache.RepoCache) *showBug {
@@ -42,6 +53,47 @@ func (sb *showBug) SetBug(bug *cache.BugCache) {
 	sb.scroll = 0
 	sb.selected = ""
 	sb.isOnSide = false
+	sb.timeline = nil
+	sb.timelineSnap = nil
+	sb.timelineLimit = timelinePageSize
+}
+
+// loadTimeline compile the displayed page of the timeline, if the bug changed
+// since it was compiled
+func (sb *showBug) loadTimeline(snap *bug.Snapshot) {
+	if snap == sb.timelineSnap {
+		return
+	}
+
+	sb.timeline = sb.bug.TimelinePage(nil, 0, sb.timelineLimit)
+	sb.timelineSnap = snap
+}
+
+// loadMore extend the displayed page of the timeline, if it's not complete.
+// It returns false if there is nothing more to display.
+func (sb *showBug) loadMore() bool {
+	if sb.timelineSnap == nil || len(sb.timeline) >= len(sb.timelineSnap.Timeline) {
+		return false
+	}
+
+	sb.timelineLimit += timelinePageSize
+	sb.timelineSnap = nil
+	return true
+}
+
+// bugChanged reload the displayed bug if it changed in the cache
+func (sb *showBug) bugChanged(event cache.BugEvent) error {
+	if sb.bug == nil || sb.bug.Id() != event.Id || event.Kind != cache.BugUpdated {
+		return nil
+	}
+
+	b, err := sb.cache.ResolveBug(event.Id)
+	if err != nil {
+		return err
+	}
+
+	sb.bug = b
+	return nil
 }
 
 func (sb *showBug) layout(g *gocui.Gui) error {
@@ -91,11 +143,12 @@ func (sb *showBug) layout(g *gocui.Gui) error {
 
 		sb.childViews = append(sb.childViews, showBugInstructionView)
 		v.Frame = false
-		v.BgColor = gocui.ColorBlue
+		v.FgColor = ui.theme.instructionsFg
+		v.BgColor = ui.theme.instructionsBg
 	}
 
 	v.Clear()
-	_, _ = fmt.Fprintf(v, "[q] Save and return [←↓↑→,hjkl] Navigation [o] Toggle open/close [e] Edit [c] Comment [t] Change title")
+	_, _ = fmt.Fprint(v, ui.keys.instructions(showBugView))
 
 	_, err = g.SetViewOnTop(showBugInstructionView)
 	if err != nil {
@@ -107,80 +160,78 @@ func (sb *showBug) layout(g *gocui.Gui) error {
 }
 
 func (sb *showBug) keybindings(g *gocui.Gui) error {
-	// Return
-	if err := g.SetKeybinding(showBugView, 'q', gocui.ModNone, sb.saveAndBack); err != nil {
+	// Help
+	if err := ui.keys.bind(g, showBugView, "help", showHelp(showBugView)); err != nil {
 		return err
 	}
 
-	// Scrolling
-	if err := g.SetKeybinding(showBugView, gocui.KeyPgup, gocui.ModNone,
-		sb.scrollUp); err != nil {
+	// Return
+	if err := ui.keys.bind(g, showBugView, "quit", sb.saveAndBack); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(showBugView, gocui.KeyPgdn, gocui.ModNone,
-		sb.scrollDown); err != nil {
+
+	// Scrolling up
+	if err := ui.keys.bind(g, showBugView, "scroll-up", sb.scrollUp); err != nil {
 		return err
 	}
 
-	// Down
-	if err := g.SetKeybinding(showBugView, 'j', gocui.ModNone,
-		sb.selectNext); err != nil {
+	// Scrolling down
+	if err := ui.keys.bind(g, showBugView, "scroll-down", sb.scrollDown); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(showBugView, gocui.KeyArrowDown, gocui.ModNone,
-		sb.selectNext); err != nil {
+
+	// Down
+	if err := ui.keys.bind(g, showBugView, "down", sb.selectNext); err != nil {
 		return err
 	}
+
 	// Up
-	if err := g.SetKeybinding(showBugView, 'k', gocui.ModNone,
-		sb.selectPrevious); err != nil {
-		return err
-	}
-	if err := g.SetKeybinding(showBugView, gocui.KeyArrowUp, gocui.ModNone,
-		sb.selectPrevious); err != nil {
+	if err := ui.keys.bind(g, showBugView, "up", sb.selectPrevious); err != nil {
 		return err
 	}
 
 	// Left
-	if err := g.SetKeybinding(showBugView, 'h', gocui.ModNone,
-		sb.left); err != nil {
-		return err
-	}
-	if err := g.SetKeybinding(showBugView, gocui.KeyArrowLeft, gocui.ModNone,
-		sb.left); err != nil {
+	if err := ui.keys.bind(g, showBugView, "left", sb.left); err != nil {
 		return err
 	}
+
 	// Right
-	if err := g.SetKeybinding(showBugView, 'l', gocui.ModNone,
-		sb.right); err != nil {
-		return err
-	}
-	if err := g.SetKeybinding(showBugView, gocui.KeyArrowRight, gocui.ModNone,
-		sb.right); err != nil {
+	if err := ui.keys.bind(g, showBugView, "right", sb.right); err != nil {
 		return err
 	}
 
 	// Comment
-	if err := g.SetKeybinding(showBugView, 'c', gocui.ModNone,
-		sb.comment); err != nil {
+	if err := ui.keys.bind(g, showBugView, "comment", sb.comment); err != nil {
 		return err
 	}
 
 	// Open/close
-	if err := g.SetKeybinding(showBugView, 'o', gocui.ModNone,
-		sb.toggleOpenClose); err != nil {
+	if err := ui.keys.bind(g, showBugView, "close", sb.toggleOpenClose); err != nil {
 		return err
 	}
 
 	// Title
-	if err := g.SetKeybinding(showBugView, 't', gocui.ModNone,
-		sb.setTitle); err != nil {
+	if err := ui.keys.bind(g, showBugView, "title", sb.setTitle); err != nil {
 		return err
 	}
 
 	// Edit
-	if err := g.SetKeybinding(showBugView, 'e', gocui.ModNone,
-		sb.edit); err != nil {
+	if err := ui.keys.bind(g, showBugView, "edit", sb.edit); err != nil {
+		return err
+	}
+
+	// Labels
+	if err := ui.keys.bind(g, showBugView, "label", sb.labels); err != nil {
+		return err
+	}
+
+	// Command palette
+	if err := ui.keys.bind(g, showBugView, "command", sb.openPalette); err != nil {
+		return err
+	}
+
+	// Open a link or an attached file
+	if err := ui.keys.bind(g, showBugView, "open", sb.openLink); err != nil {
 		return err
 	}
 
@@ -214,13 +265,16 @@ func (sb *showBug) renderMain(g *gocui.Gui, mainView *gocui.View) error {
 	}
 
 	bugHeader := fmt.Sprintf("[%s] %s\n\n[%s] %s opened this bug on %s%s",
-		colors.Cyan(snap.HumanId()),
-		colors.Bold(snap.Title),
-		colors.Yellow(snap.Status),
-		colors.Magenta(snap.Author.DisplayName()),
+		ui.theme.id(strings.TrimSpace(ui.displayId(snap.Id()))),
+		ui.theme.emphasis(snap.Title),
+		ui.theme.status(snap.Status),
+		snap.Author.TerminalAvatar()+" "+ui.theme.author(snap.Author.DisplayName()),
 		snap.CreatedAt.Format(timeLayout),
 		edited,
 	)
+	if hints := sb.bug.Hints(); len(hints) > 0 {
+		bugHeader += "\n" + ui.theme.hint(strings.Join(hints, ", "))
+	}
 	bugHeader, lines := text.Wrap(bugHeader, maxX)
 
 	v, err := sb.createOpView(g, showBugHeaderView, x0, y0, maxX+1, lines, false)
@@ -231,7 +285,9 @@ func (sb *showBug) renderMain(g *gocui.Gui, mainView *gocui.View) error {
 	_, _ = fmt.Fprint(v, bugHeader)
 	y0 += lines + 1
 
-	for _, op := range snap.Timeline {
+	sb.loadTimeline(snap)
+
+	for _, op := range sb.timeline {
 		viewName := op.Hash().String()
 
 		// TODO: me might skip the rendering of blocks that are outside of the view
@@ -273,8 +329,9 @@ func (sb *showBug) renderMain(g *gocui.Gui, mainView *gocui.View) error {
 				message, _ = text.WrapLeftPadded(comment.Message, maxX-1, 4)
 			}
 
-			content := fmt.Sprintf("%s commented on %s%s\n\n%s",
-				colors.Magenta(comment.Author.DisplayName()),
+			content := fmt.Sprintf("%s %s commented on %s%s\n\n%s",
+				comment.Author.TerminalAvatar(),
+				ui.theme.author(comment.Author.DisplayName()),
 				comment.CreatedAt.Time().Format(timeLayout),
 				edited,
 				message,
@@ -291,48 +348,38 @@ func (sb *showBug) renderMain(g *gocui.Gui, mainView *gocui.View) error {
 		case *bug.SetTitleTimelineItem:
 			setTitle := op.(*bug.SetTitleTimelineItem)
 
-			content := fmt.Sprintf("%s changed the title to %s on %s",
-				colors.Magenta(setTitle.Author.DisplayName()),
-				colors.Bold(setTitle.Title),
-				setTitle.UnixTime.Time().Format(timeLayout),
-			)
-			content, lines := text.Wrap(content, maxX)
+			action := fmt.Sprintf("changed the title to %s", ui.theme.emphasis(setTitle.Title))
+			if setTitle.Was != "" {
+				action = fmt.Sprintf("changed the title from %s to %s",
+					ui.theme.emphasis(setTitle.Was), ui.theme.emphasis(setTitle.Title))
+			}
 
-			v, err := sb.createOpView(g, viewName, x0, y0, maxX+1, lines, true)
+			y0, err = sb.renderEvent(g, viewName, x0, y0, maxX, setTitle.Author, action, setTitle.UnixTime)
 			if err != nil {
 				return err
 			}
-			_, _ = fmt.Fprint(v, content)
-			y0 += lines + 2
 
 		case *bug.SetStatusTimelineItem:
 			setStatus := op.(*bug.SetStatusTimelineItem)
 
-			content := fmt.Sprintf("%s %s the bug on %s",
-				colors.Magenta(setStatus.Author.DisplayName()),
-				colors.Bold(setStatus.Status.Action()),
-				setStatus.UnixTime.Time().Format(timeLayout),
-			)
-			content, lines := text.Wrap(content, maxX)
+			action := fmt.Sprintf("%s the bug", ui.theme.status(setStatus.Status.Action()))
 
-			v, err := sb.createOpView(g, viewName, x0, y0, maxX+1, lines, true)
+			y0, err = sb.renderEvent(g, viewName, x0, y0, maxX, setStatus.Author, action, setStatus.UnixTime)
 			if err != nil {
 				return err
 			}
-			_, _ = fmt.Fprint(v, content)
-			y0 += lines + 2
 
 		case *bug.LabelChangeTimelineItem:
 			labelChange := op.(*bug.LabelChangeTimelineItem)
 
 			var added []string
 			for _, label := range labelChange.Added {
-				added = append(added, colors.Bold("\""+label+"\""))
+				added = append(added, ui.theme.emphasis("\""+label+"\""))
 			}
 
 			var removed []string
 			for _, label := range labelChange.Removed {
-				removed = append(removed, colors.Bold("\""+label+"\""))
+				removed = append(removed, ui.theme.emphasis("\""+label+"\""))
 			}
 
 			var action bytes.Buffer
@@ -357,28 +404,52 @@ func (sb *showBug) renderMain(g *gocui.Gui, mainView *gocui.View) error {
 				action.WriteString(" label")
 			}
 
-			content := fmt.Sprintf("%s %s on %s",
-				colors.Magenta(labelChange.Author.DisplayName()),
-				action.String(),
-				labelChange.UnixTime.Time().Format(timeLayout),
-			)
-			content, lines := text.Wrap(content, maxX)
-
-			v, err := sb.createOpView(g, viewName, x0, y0, maxX+1, lines, true)
+			y0, err = sb.renderEvent(g, viewName, x0, y0, maxX, labelChange.Author, action.String(), labelChange.UnixTime)
 			if err != nil {
 				return err
 			}
-			_, _ = fmt.Fprint(v, content)
-			y0 += lines + 2
 		}
 	}
 
+	if remaining := len(snap.Timeline) - len(sb.timeline); remaining > 0 {
+		more := ui.theme.muted(fmt.Sprintf("%d more items, scroll down to display them", remaining))
+		v, err := sb.createOpView(g, showBugMoreView, x0, y0, maxX+1, 1, false)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprint(v, more)
+	} else if err := g.DeleteView(showBugMoreView); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+
 	return nil
 }
 
+// renderEvent render a timeline item other than a comment, like a label
+// change, as a compact line with its author and the relative time, and
+// return where the next item start. The events being a single line, they
+// share the row of their frames with the next item.
+func (sb *showBug) renderEvent(g *gocui.Gui, name string, x0 int, y0 int, maxX int, author bug.Person, action string, unixTime bug.Timestamp) (int, error) {
+	content := fmt.Sprintf("%s %s %s · %s",
+		ui.theme.muted("•"),
+		ui.theme.author(author.DisplayName()),
+		action,
+		humanize.Time(unixTime.Time()),
+	)
+	content, lines := text.WrapLeftPadded(content, maxX-1, 2)
+
+	v, err := sb.createOpView(g, name, x0, y0, maxX+1, lines, true)
+	if err != nil {
+		return 0, err
+	}
+	_, _ = fmt.Fprint(v, content)
+
+	return y0 + lines + 1, nil
+}
+
 // emptyMessagePlaceholder return a formatted placeholder for an empty message
 func emptyMessagePlaceholder() string {
-	return colors.GreyBold("No description provided.")
+	return ui.theme.muted("No description provided.")
 }
 
 func (sb *showBug) createOpView(g *gocui.Gui, name string, x0 int, y0 int, maxX int, height int, selectable bool) (*gocui.View, error) {
@@ -435,7 +506,7 @@ func (sb *showBug) renderSidebar(g *gocui.Gui, sideView *gocui.View) error {
 	labels := strings.Join(labelStr, "\n")
 	labels, lines := text.WrapLeftPadded(labels, maxX, 2)
 
-	content := fmt.Sprintf("%s\n\n%s", colors.Bold("Labels"), labels)
+	content := fmt.Sprintf("%s\n\n%s", ui.theme.emphasis("Labels"), labels)
 
 	v, err := sb.createSideView(g, "sideLabels", x0, y0, maxX, lines+2)
 	if err != nil {
@@ -493,6 +564,11 @@ func (sb *showBug) scrollDown(g *gocui.Gui, v *gocui.View) error {
 
 	maxScroll := vy0 + sb.scroll + vMaxY - maxY
 
+	// the end of the displayed timeline is reached, display the next items
+	if sb.scroll >= maxScroll && sb.loadMore() {
+		return nil
+	}
+
 	sb.scroll += maxY / 2
 
 	sb.scroll = minInt(sb.scroll, maxScroll)
@@ -537,6 +613,12 @@ func (sb *showBug) selectNext(g *gocui.Gui, v *gocui.View) error {
 
 	for i, name := range selectable {
 		if name == sb.selected {
+			// the last item of the displayed timeline is selected, display
+			// the next ones
+			if !sb.isOnSide && i == len(selectable)-1 && sb.loadMore() {
+				return nil
+			}
+
 			sb.selected = selectable[minInt(i+1, len(selectable)-1)]
 			return sb.focusView(g)
 		}
@@ -619,7 +701,16 @@ func (sb *showBug) setTitle(g *gocui.Gui, v *gocui.View) error {
 	return setTitleWithEditor(sb.bug)
 }
 
+func (sb *showBug) openPalette(g *gocui.Gui, v *gocui.View) error {
+	ui.palette.Activate(sb.bug)
+	return nil
+}
+
 func (sb *showBug) toggleOpenClose(g *gocui.Gui, v *gocui.View) error {
+	if readOnlyPopup() {
+		return nil
+	}
+
 	switch sb.bug.Snapshot().Status {
 	case bug.OpenStatus:
 		return sb.bug.Close()
@@ -637,6 +728,11 @@ func (sb *showBug) edit(g *gocui.Gui, v *gocui.View) error {
 		return sb.editLabels(g, snap)
 	}
 
+	if sb.selected == "" {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "Select a comment or the title to edit.")
+		return nil
+	}
+
 	op, err := snap.SearchTimelineItem(git.Hash(sb.selected))
 	if err != nil {
 		return err
@@ -649,6 +745,8 @@ func (sb *showBug) edit(g *gocui.Gui, v *gocui.View) error {
 	case *bug.CreateTimelineItem:
 		preMessage := op.(*bug.CreateTimelineItem).Message
 		return editCommentWithEditor(sb.bug, op.Hash(), preMessage)
+	case *bug.SetTitleTimelineItem:
+		return setTitleWithEditor(sb.bug)
 	case *bug.LabelChangeTimelineItem:
 		return sb.editLabels(g, snap)
 	}
@@ -657,6 +755,46 @@ func (sb *showBug) edit(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
+// openLink open the URL or the attached file of the selected comment, or let
+// the user choose one if there are several
+func (sb *showBug) openLink(g *gocui.Gui, v *gocui.View) error {
+	if sb.isOnSide || sb.selected == "" {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "Select a comment to open its links.")
+		return nil
+	}
+
+	op, err := sb.bug.Snapshot().SearchTimelineItem(git.Hash(sb.selected))
+	if err != nil {
+		return err
+	}
+
+	var links []link
+
+	switch op.(type) {
+	case *bug.AddCommentTimelineItem:
+		links = commentLinks(sb.bug, &op.(*bug.AddCommentTimelineItem).CommentTimelineItem)
+	case *bug.CreateTimelineItem:
+		links = commentLinks(sb.bug, &op.(*bug.CreateTimelineItem).CommentTimelineItem)
+	}
+
+	switch len(links) {
+	case 0:
+		ui.msgPopup.Activate(msgPopupErrorTitle, "No link or attached file in the selected comment.")
+	case 1:
+		if err := openLink(sb.cache, links[0]); err != nil {
+			ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		}
+	default:
+		ui.linkPopup.Activate(sb.cache, links)
+	}
+
+	return nil
+}
+
+func (sb *showBug) labels(g *gocui.Gui, v *gocui.View) error {
+	return sb.editLabels(g, sb.bug.Snapshot())
+}
+
 func (sb *showBug) editLabels(g *gocui.Gui, snap *bug.Snapshot) error {
 	ui.labelSelect.SetBug(sb.cache, sb.bug)
 	return ui.activateWindow(ui.labelSelect)
@@ -0,0 +1,221 @@
+package termui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/text"
+	"github.com/dustin/go-humanize"
+)
+
+// tableColumnsConfigKey is the git config key holding the columns of the bug
+// table, like "id,status,title,labels:25,last-edit"
+const tableColumnsConfigKey = "git-bug.termui.columns"
+
+// tableSortConfigKey is the git config key holding the order of the bugs in
+// the bug table when the query doesn't give one, like "edit-desc"
+const tableSortConfigKey = "git-bug.termui.sort"
+
+const defaultTableColumns = "id,status,title,author,summary,last-edit"
+
+// the special widths of the columns
+const (
+	// widthRest take the space left by the other columns
+	widthRest = 0
+	// widthAuto take a share of the space left, growing slower than the title
+	widthAuto = -1
+)
+
+// tableColumn is a column of the bug table
+type tableColumn struct {
+	name   string
+	header string
+	width  int
+	cell   func(snap *bug.Snapshot, width int) string
+}
+
+var tableColumns = []tableColumn{
+	{
+		name:   "id",
+		header: "ID",
+		width:  9,
+		cell: func(snap *bug.Snapshot, width int) string {
+			return ui.theme.id(text.LeftPadMaxLine(ui.displayId(snap.Id()), width, 1))
+		},
+	},
+	{
+		name:   "status",
+		header: "STATUS",
+		width:  7,
+		cell: func(snap *bug.Snapshot, width int) string {
+			return ui.theme.status(text.LeftPadMaxLine(snap.Status.String(), width, 1))
+		},
+	},
+	{
+		name:   "title",
+		header: "TITLE",
+		width:  widthRest,
+		cell: func(snap *bug.Snapshot, width int) string {
+			return text.LeftPadMaxLine(snap.Title, width, 1)
+		},
+	},
+	{
+		name:   "author",
+		header: "AUTHOR",
+		width:  widthAuto,
+		cell: func(snap *bug.Snapshot, width int) string {
+			person := bug.Person{}
+			if len(snap.Comments) > 0 {
+				person = snap.Comments[0].Author
+			}
+			// the avatar take 4 cells of the column
+			return person.TerminalAvatar() + ui.theme.author(text.LeftPadMaxLine(person.DisplayName(), width-4, 1))
+		},
+	},
+	{
+		name:   "labels",
+		header: "LABELS",
+		width:  20,
+		cell: func(snap *bug.Snapshot, width int) string {
+			labels := make([]string, len(snap.Labels))
+			for i, label := range snap.Labels {
+				labels[i] = label.String()
+			}
+			return text.LeftPadMaxLine(strings.Join(labels, ","), width, 1)
+		},
+	},
+	{
+		name:   "assignee",
+		header: "ASSIGNEE",
+		width:  15,
+		cell: func(snap *bug.Snapshot, width int) string {
+			assignee := ""
+			if snap.Assignee != nil {
+				assignee = snap.Assignee.DisplayName()
+			}
+			return ui.theme.author(text.LeftPadMaxLine(assignee, width, 1))
+		},
+	},
+	{
+		name:   "votes",
+		header: "VOTES",
+		width:  6,
+		cell: func(snap *bug.Snapshot, width int) string {
+			return text.LeftPadMaxLine(strconv.Itoa(len(snap.Votes)), width, 1)
+		},
+	},
+	{
+		name:   "summary",
+		header: "SUMMARY",
+		width:  10,
+		cell: func(snap *bug.Snapshot, width int) string {
+			summary := fmt.Sprintf("C:%-2d L:%-2d", len(snap.Comments)-1, len(snap.Labels))
+			return text.LeftPadMaxLine(summary, width, 1)
+		},
+	},
+	{
+		name:   "last-edit",
+		header: "LAST EDIT",
+		width:  19,
+		cell: func(snap *bug.Snapshot, width int) string {
+			return text.LeftPadMaxLine(humanize.Time(snap.LastEditTime()), width, 1)
+		},
+	},
+}
+
+// tableSorts are the orders the bug table cycle through, as in the query
+// language
+var tableSorts = []string{
+	"creation-desc", "creation-asc",
+	"edit-desc", "edit-asc",
+	"votes-desc", "votes-asc",
+	"id-asc", "id-desc",
+}
+
+// sortName return the name of the order of a query, as in the query language
+func sortName(q *cache.Query) string {
+	for _, name := range tableSorts {
+		sorted, err := cache.ParseQuery("sort:" + name)
+		if err == nil && sorted.OrderBy == q.OrderBy && sorted.OrderDirection == q.OrderDirection {
+			return name
+		}
+	}
+	return ""
+}
+
+// parseTableColumns parse a list of columns as found in the configuration,
+// each one optionally followed by its width
+func parseTableColumns(str string) ([]tableColumn, error) {
+	var result []tableColumn
+
+	for _, item := range strings.Split(str, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name := item
+		width := 0
+
+		if i := strings.Index(item, ":"); i >= 0 {
+			name = item[:i]
+			w, err := strconv.Atoi(item[i+1:])
+			if err != nil || w < 3 {
+				return nil, fmt.Errorf("invalid width for the column %s", name)
+			}
+			width = w
+		}
+
+		found := false
+		for _, column := range tableColumns {
+			if column.name == name {
+				if width > 0 {
+					column.width = width
+				}
+				result = append(result, column)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown column %s", name)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no column")
+	}
+
+	return result, nil
+}
+
+// readTableConfig read the columns and the order of the bug table from the
+// configuration of the repo
+func readTableConfig(c *cache.RepoCache) ([]tableColumn, string, error) {
+	configs, err := c.ReadConfigs("git-bug.termui.")
+	if err != nil {
+		return nil, "", err
+	}
+
+	str := configs[tableColumnsConfigKey]
+	if str == "" {
+		str = defaultTableColumns
+	}
+
+	columns, err := parseTableColumns(str)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %v", tableColumnsConfigKey, err)
+	}
+
+	sort := configs[tableSortConfigKey]
+	if sort != "" {
+		if _, err := cache.ParseQuery("sort:" + sort); err != nil {
+			return nil, "", fmt.Errorf("%s: %v", tableSortConfigKey, err)
+		}
+	}
+
+	return columns, sort, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/MichaelMure/git-bug/input"
 	"github.com/MichaelMure/git-bug/util/git"
 	"github.com/MichaelMure/gocui"
+	"github.com/fatih/color"
 	"github.com/pkg/errors"
 )
 
@@ -16,13 +17,28 @@ type termUI struct {
 	gError chan error
 	cache  *cache.RepoCache
 
+	// workspace is set when the termui operate on the repositories of a
+	// workspace, repoName being the one shown
+	workspace *cache.MultiRepoCache
+	repoName  string
+	// stopWatch stop the watch of the current repository
+	stopWatch chan struct{}
+
 	activeWindow window
 
-	bugTable    *bugTable
-	showBug     *showBug
-	labelSelect *labelSelect
-	msgPopup    *msgPopup
-	inputPopup  *inputPopup
+	bugTable      *bugTable
+	showBug       *showBug
+	labelSelect   *labelSelect
+	identityList  *identityList
+	msgPopup      *msgPopup
+	inputPopup    *inputPopup
+	progressPopup *progressPopup
+	linkPopup     *linkPopup
+	palette       *commandPalette
+	searchBar     *searchBar
+	help          *helpScreen
+	keys          *keyMap
+	theme         *theme
 }
 
 func (tui *termUI) activateWindow(window window) error {
@@ -45,21 +61,76 @@ type window interface {
 
 // Run will launch the termUI in the terminal
 func Run(cache *cache.RepoCache) error {
+	return run(cache, nil, "")
+}
+
+// RunWorkspace will launch the termUI in the terminal, on the repositories of
+// a workspace. It starts with the first one, the next ones being shown in turn
+// with 'w'.
+func RunWorkspace(workspace *cache.MultiRepoCache) error {
+	names := workspace.Names()
+	if len(names) == 0 {
+		return errors.New("the workspace is empty")
+	}
+
+	c, err := workspace.ResolveRepo(names[0])
+	if err != nil {
+		return err
+	}
+
+	return run(c, workspace, names[0])
+}
+
+func run(cache *cache.RepoCache, workspace *cache.MultiRepoCache, repoName string) error {
+	bugTable, err := newBugTable(cache)
+	if err != nil {
+		return err
+	}
+
+	keys, err := readKeyMap(cache)
+	if err != nil {
+		return err
+	}
+
+	theme, err := readTheme(cache)
+	if err != nil {
+		return err
+	}
+
+	if theme.noColor {
+		noColor := color.NoColor
+		color.NoColor = true
+		defer func() { color.NoColor = noColor }()
+	}
+
 	ui = &termUI{
-		gError:      make(chan error, 1),
-		cache:       cache,
-		bugTable:    newBugTable(cache),
-		showBug:     newShowBug(cache),
-		labelSelect: newLabelSelect(),
-		msgPopup:    newMsgPopup(),
-		inputPopup:  newInputPopup(),
+		gError:        make(chan error, 1),
+		cache:         cache,
+		workspace:     workspace,
+		repoName:      repoName,
+		bugTable:      bugTable,
+		showBug:       newShowBug(cache),
+		labelSelect:   newLabelSelect(),
+		identityList:  newIdentityList(),
+		msgPopup:      newMsgPopup(),
+		inputPopup:    newInputPopup(),
+		progressPopup: newProgressPopup(),
+		linkPopup:     newLinkPopup(),
+		palette:       newCommandPalette(),
+		searchBar:     newSearchBar(),
+		help:          newHelpScreen(),
+		keys:          keys,
+		theme:         theme,
 	}
 
 	ui.activeWindow = ui.bugTable
 
+	ui.startWatch()
+	defer ui.stopWatching()
+
 	initGui(nil)
 
-	err := <-ui.gError
+	err = <-ui.gError
 
 	if err != nil && err != gocui.ErrQuit {
 		return err
@@ -68,6 +139,95 @@ func Run(cache *cache.RepoCache) error {
 	return nil
 }
 
+func (tui *termUI) startWatch() {
+	tui.stopWatch = make(chan struct{})
+	go watchRepo(tui.cache, tui.stopWatch)
+}
+
+func (tui *termUI) stopWatching() {
+	close(tui.stopWatch)
+}
+
+// nextRepo show the next repository of the workspace
+func (tui *termUI) nextRepo() error {
+	names := tui.workspace.Names()
+
+	next := names[0]
+	for i, name := range names {
+		if name == tui.repoName && i+1 < len(names) {
+			next = names[i+1]
+		}
+	}
+
+	c, err := tui.workspace.ResolveRepo(next)
+	if err != nil {
+		return err
+	}
+
+	tui.stopWatching()
+
+	err = tui.bugTable.setRepo(c)
+	if err != nil {
+		return err
+	}
+
+	tui.cache = c
+	tui.repoName = next
+	tui.showBug.cache = c
+
+	tui.startWatch()
+
+	return nil
+}
+
+// displayId return the id of a bug to show, prefixed by the name of its
+// repository in a workspace
+func (tui *termUI) displayId(id string) string {
+	if tui.workspace == nil {
+		return tui.cache.DisplayId(id)
+	}
+	return tui.repoName + "/" + tui.cache.DisplayId(id)
+}
+
+// watchRepo refresh the cache when the bugs change outside of the termui, for
+// example with a git push to this repository, and redraw the screen. It stops
+// when stop is closed.
+func watchRepo(repo *cache.RepoCache, stop <-chan struct{}) {
+	events, cancel := repo.Subscribe()
+	defer cancel()
+
+	go func() {
+		err := repo.Watch(cache.DefaultWatchInterval, stop)
+		if err != nil && ui.g != nil {
+			ui.g.Update(func(g *gocui.Gui) error {
+				ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+				return nil
+			})
+		}
+	}()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			// the screen is rebuilt anyway when the gui restart
+			g := ui.g
+			if g == nil {
+				continue
+			}
+
+			g.Update(func(g *gocui.Gui) error {
+				return ui.showBug.bugChanged(event)
+			})
+		}
+	}
+}
+
 func initGui(action func(ui *termUI) error) {
 	g, err := gocui.NewGui(gocui.OutputNormal)
 
@@ -81,6 +241,11 @@ func initGui(action func(ui *termUI) error) {
 	ui.g.SetManagerFunc(layout)
 
 	ui.g.InputEsc = true
+	ui.g.Mouse = true
+
+	// the frame of the popups, the other views have none
+	ui.g.Highlight = true
+	ui.g.SelFgColor = ui.theme.frameFg
 
 	err = keybindings(ui.g)
 
@@ -128,6 +293,26 @@ func layout(g *gocui.Gui) error {
 		return err
 	}
 
+	if err := ui.progressPopup.layout(g); err != nil {
+		return err
+	}
+
+	if err := ui.linkPopup.layout(g); err != nil {
+		return err
+	}
+
+	if err := ui.palette.layout(g); err != nil {
+		return err
+	}
+
+	if err := ui.searchBar.layout(g); err != nil {
+		return err
+	}
+
+	if err := ui.help.layout(g); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -149,6 +334,10 @@ func keybindings(g *gocui.Gui) error {
 		return err
 	}
 
+	if err := ui.identityList.keybindings(g); err != nil {
+		return err
+	}
+
 	if err := ui.msgPopup.keybindings(g); err != nil {
 		return err
 	}
@@ -157,6 +346,30 @@ func keybindings(g *gocui.Gui) error {
 		return err
 	}
 
+	if err := ui.progressPopup.keybindings(g); err != nil {
+		return err
+	}
+
+	if err := ui.linkPopup.keybindings(g); err != nil {
+		return err
+	}
+
+	if err := ui.palette.keybindings(g); err != nil {
+		return err
+	}
+
+	if err := ui.searchBar.keybindings(g); err != nil {
+		return err
+	}
+
+	if err := ui.help.keybindings(g); err != nil {
+		return err
+	}
+
+	if err := mouseBindings(g); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -164,7 +377,21 @@ func quit(g *gocui.Gui, v *gocui.View) error {
 	return gocui.ErrQuit
 }
 
+// readOnlyPopup show an error popup and return true if the bugs can't be
+// modified because the cache is read-only
+func readOnlyPopup() bool {
+	if !ui.cache.ReadOnly() {
+		return false
+	}
+	ui.msgPopup.Activate(msgPopupErrorTitle, cache.ErrReadOnly.Error())
+	return true
+}
+
 func newBugWithEditor(repo *cache.RepoCache) error {
+	if readOnlyPopup() {
+		return nil
+	}
+
 	// This is somewhat hacky.
 	// As there is no way to pause gocui, run the editor and restart gocui,
 	// we have to stop it entirely and start a new one later.
@@ -205,6 +432,10 @@ func newBugWithEditor(repo *cache.RepoCache) error {
 }
 
 func addCommentWithEditor(bug *cache.BugCache) error {
+	if readOnlyPopup() {
+		return nil
+	}
+
 	// This is somewhat hacky.
 	// As there is no way to pause gocui, run the editor and restart gocui,
 	// we have to stop it entirely and start a new one later.
@@ -226,9 +457,10 @@ func addCommentWithEditor(bug *cache.BugCache) error {
 	if err == input.ErrEmptyMessage {
 		ui.msgPopup.Activate(msgPopupErrorTitle, "Empty message, aborting.")
 	} else {
+		// a refused change is reported in the gui instead of closing it
 		err := bug.AddComment(message)
 		if err != nil {
-			return err
+			ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
 		}
 	}
 
@@ -238,6 +470,10 @@ func addCommentWithEditor(bug *cache.BugCache) error {
 }
 
 func editCommentWithEditor(bug *cache.BugCache, target git.Hash, preMessage string) error {
+	if readOnlyPopup() {
+		return nil
+	}
+
 	// This is somewhat hacky.
 	// As there is no way to pause gocui, run the editor and restart gocui,
 	// we have to stop it entirely and start a new one later.
@@ -261,9 +497,10 @@ func editCommentWithEditor(bug *cache.BugCache, target git.Hash, preMessage stri
 	} else if message == preMessage {
 		ui.msgPopup.Activate(msgPopupErrorTitle, "No changes found, aborting.")
 	} else {
+		// a refused change is reported in the gui instead of closing it
 		err := bug.EditComment(target, message)
 		if err != nil {
-			return err
+			ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
 		}
 	}
 
@@ -273,6 +510,10 @@ func editCommentWithEditor(bug *cache.BugCache, target git.Hash, preMessage stri
 }
 
 func setTitleWithEditor(bug *cache.BugCache) error {
+	if readOnlyPopup() {
+		return nil
+	}
+
 	// This is somewhat hacky.
 	// As there is no way to pause gocui, run the editor and restart gocui,
 	// we have to stop it entirely and start a new one later.
@@ -298,9 +539,10 @@ func setTitleWithEditor(bug *cache.BugCache) error {
 	} else if title == snap.Title {
 		ui.msgPopup.Activate(msgPopupErrorTitle, "No change, aborting.")
 	} else {
+		// a refused change is reported in the gui instead of closing it
 		err := bug.SetTitle(title)
 		if err != nil {
-			return err
+			ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
 		}
 	}
 
@@ -309,40 +551,6 @@ func setTitleWithEditor(bug *cache.BugCache) error {
 	return errTerminateMainloop
 }
 
-func editQueryWithEditor(bt *bugTable) error {
-	// This is somewhat hacky.
-	// As there is no way to pause gocui, run the editor and restart gocui,
-	// we have to stop it entirely and start a new one later.
-	//
-	// - an error channel is used to route the returned error of this new
-	// 		instance into the original launch function
-	// - a custom error (errTerminateMainloop) is used to terminate the original
-	//		instance's mainLoop. This error is then filtered.
-
-	ui.g.Close()
-	ui.g = nil
-
-	queryStr, err := input.QueryEditorInput(bt.repo, bt.queryStr)
-
-	if err != nil {
-		return err
-	}
-
-	bt.queryStr = queryStr
-
-	query, err := cache.ParseQuery(queryStr)
-
-	if err != nil {
-		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
-	} else {
-		bt.query = query
-	}
-
-	initGui(nil)
-
-	return errTerminateMainloop
-}
-
 func maxInt(a, b int) int {
 	if a > b {
 		return a
@@ -0,0 +1,267 @@
+package termui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/gocui"
+	"github.com/fatih/color"
+)
+
+// themeConfigKey is the git config key holding the name of the theme of the
+// termui
+const themeConfigKey = "git-bug.tui.theme"
+
+// themeColorConfigPrefix is the prefix of the git config keys overriding the
+// style of an element of the theme, like "git-bug.tui.color.id=blue bold"
+const themeColorConfigPrefix = "git-bug.tui.color."
+
+// the styles of the elements, by theme. A style is a list of words, colors or
+// bold, underline and reverse, optionally followed by "on" and the color of
+// the background.
+var themes = map[string]map[string]string{
+	"default": {
+		"id":           "cyan",
+		"status":       "yellow",
+		"author":       "magenta",
+		"emphasis":     "bold",
+		"hint":         "red",
+		"muted":        "bold on black",
+		"selection":    "black on white",
+		"instructions": "default on blue",
+		"frame":        "default",
+	},
+	// for the terminals with a light background
+	"light": {
+		"id":           "blue",
+		"status":       "red",
+		"author":       "magenta",
+		"emphasis":     "bold",
+		"hint":         "red bold",
+		"muted":        "bold",
+		"selection":    "white on black",
+		"instructions": "black on cyan",
+		"frame":        "blue",
+	},
+	// no color at all, the selection being shown in reverse video
+	"monochrome": {
+		"id":           "",
+		"status":       "",
+		"author":       "",
+		"emphasis":     "bold",
+		"hint":         "bold",
+		"muted":        "",
+		"selection":    "reverse",
+		"instructions": "reverse",
+		"frame":        "bold",
+	},
+}
+
+const defaultTheme = "default"
+
+// the colors, in the order of the terminal colors
+var colorNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// style is the appearance of an element of a theme
+type style struct {
+	// fg and bg are indexes in colorNames, -1 for the default color
+	fg, bg    int
+	bold      bool
+	underline bool
+	reverse   bool
+}
+
+// theme is the appearance of the termui
+type theme struct {
+	// noColor remove the colors of every text, the avatars included
+	noColor bool
+
+	id       func(a ...interface{}) string
+	status   func(a ...interface{}) string
+	author   func(a ...interface{}) string
+	emphasis func(a ...interface{}) string
+	hint     func(a ...interface{}) string
+	muted    func(a ...interface{}) string
+
+	selectionFg, selectionBg       gocui.Attribute
+	instructionsFg, instructionsBg gocui.Attribute
+	frameFg                        gocui.Attribute
+}
+
+// readTheme read the theme of the termui from the configuration of the repo.
+// The NO_COLOR environment variable, when set, always select the monochrome
+// theme (https://no-color.org).
+func readTheme(c *cache.RepoCache) (*theme, error) {
+	if os.Getenv("NO_COLOR") != "" {
+		return newTheme("monochrome", nil)
+	}
+
+	configs, err := c.ReadConfigs("git-bug.tui.")
+	if err != nil {
+		return nil, err
+	}
+
+	name := configs[themeConfigKey]
+	if name == "" {
+		name = defaultTheme
+	}
+
+	overrides := make(map[string]string)
+	for key, value := range configs {
+		if strings.HasPrefix(key, themeColorConfigPrefix) {
+			overrides[strings.TrimPrefix(key, themeColorConfigPrefix)] = value
+		}
+	}
+
+	return newTheme(name, overrides)
+}
+
+// newTheme build a theme from its name, with some of its elements changed
+func newTheme(name string, overrides map[string]string) (*theme, error) {
+	specs, ok := themes[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown theme %s, expected one of: default, light, monochrome", themeConfigKey, name)
+	}
+
+	styles := make(map[string]style)
+	for element, spec := range specs {
+		s, err := parseStyle(spec)
+		if err != nil {
+			return nil, err
+		}
+		styles[element] = s
+	}
+
+	for element, spec := range overrides {
+		if _, ok := specs[element]; !ok {
+			return nil, fmt.Errorf("%s%s: unknown element %s", themeColorConfigPrefix, element, element)
+		}
+		s, err := parseStyle(spec)
+		if err != nil {
+			return nil, fmt.Errorf("%s%s: %v", themeColorConfigPrefix, element, err)
+		}
+		styles[element] = s
+	}
+
+	return &theme{
+		noColor:        name == "monochrome",
+		id:             styles["id"].sprint(),
+		status:         styles["status"].sprint(),
+		author:         styles["author"].sprint(),
+		emphasis:       styles["emphasis"].sprint(),
+		hint:           styles["hint"].sprint(),
+		muted:          styles["muted"].sprint(),
+		selectionFg:    styles["selection"].fgAttribute(),
+		selectionBg:    styles["selection"].bgAttribute(),
+		instructionsFg: styles["instructions"].fgAttribute(),
+		instructionsBg: styles["instructions"].bgAttribute(),
+		frameFg:        styles["frame"].fgAttribute(),
+	}, nil
+}
+
+// parseStyle parse a style like "bold", "red" or "black on white"
+func parseStyle(spec string) (style, error) {
+	s := style{fg: -1, bg: -1}
+
+	words := strings.Fields(strings.ToLower(spec))
+	background := false
+
+	for _, word := range words {
+		switch word {
+		case "on":
+			if background {
+				return style{}, fmt.Errorf("invalid style \"%s\"", spec)
+			}
+			background = true
+			continue
+		case "bold":
+			s.bold = true
+			continue
+		case "underline":
+			s.underline = true
+			continue
+		case "reverse":
+			s.reverse = true
+			continue
+		}
+
+		index, err := parseColorName(word)
+		if err != nil {
+			return style{}, err
+		}
+		if background {
+			s.bg = index
+		} else {
+			s.fg = index
+		}
+	}
+
+	return s, nil
+}
+
+func parseColorName(name string) (int, error) {
+	if name == "default" {
+		return -1, nil
+	}
+	for i, colorName := range colorNames {
+		if colorName == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown color %s, expected one of: default, %s", name, strings.Join(colorNames, ", "))
+}
+
+// sprint return the function writing a text with this style
+func (s style) sprint() func(a ...interface{}) string {
+	var attributes []color.Attribute
+
+	if s.fg >= 0 {
+		attributes = append(attributes, color.FgBlack+color.Attribute(s.fg))
+	}
+	if s.bg >= 0 {
+		attributes = append(attributes, color.BgBlack+color.Attribute(s.bg))
+	}
+	if s.bold {
+		attributes = append(attributes, color.Bold)
+	}
+	if s.underline {
+		attributes = append(attributes, color.Underline)
+	}
+	if s.reverse {
+		attributes = append(attributes, color.ReverseVideo)
+	}
+
+	if len(attributes) == 0 {
+		return fmt.Sprint
+	}
+
+	return color.New(attributes...).SprintFunc()
+}
+
+// fgAttribute return the foreground of this style for a view
+func (s style) fgAttribute() gocui.Attribute {
+	result := gocui.ColorDefault
+	if s.fg >= 0 {
+		result = gocui.ColorBlack + gocui.Attribute(s.fg)
+	}
+	if s.bold {
+		result |= gocui.AttrBold
+	}
+	if s.underline {
+		result |= gocui.AttrUnderline
+	}
+	if s.reverse {
+		result |= gocui.AttrReverse
+	}
+	return result
+}
+
+// bgAttribute return the background of this style for a view
+func (s style) bgAttribute() gocui.Attribute {
+	if s.bg >= 0 {
+		return gocui.ColorBlack + gocui.Attribute(s.bg)
+	}
+	return gocui.ColorDefault
+}
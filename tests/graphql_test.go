@@ -1,11 +1,17 @@
 package tests
 
 import (
+	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/graphql"
 	"github.com/MichaelMure/git-bug/graphql/models"
+	"github.com/MichaelMure/git-bug/repository"
 	"github.com/vektah/gqlgen/client"
 )
 
@@ -146,3 +152,584 @@ func TestQueries(t *testing.T) {
 
 	c.MustPost(query, &resp)
 }
+
+func TestMutations(t *testing.T) {
+	repo := createFilledRepo(1)
+
+	handler, err := graphql.NewHandler(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(handler)
+	c := client.New(srv.URL)
+
+	var created struct {
+		NewBug struct {
+			Bug struct {
+				HumanId string `json:"humanId"`
+			}
+		} `json:"newBug"`
+	}
+
+	c.MustPost(`mutation { newBug(title: "title", message: "message") { bug { humanId } } }`, &created)
+
+	query := `
+      mutation($prefix: String!) {
+        setTitle(prefix: $prefix, title: "new title", clientMutationId: "abc",
+                 metadata: [{key: "origin", value: "test"}]) {
+          clientMutationId
+          bug {
+            title
+          }
+          operation {
+            hash
+            ... on SetTitleOperation {
+              title
+              was
+            }
+          }
+          errors {
+            message
+          }
+        }
+      }`
+
+	var resp struct {
+		SetTitle struct {
+			ClientMutationId string `json:"clientMutationId"`
+			Bug              struct {
+				Title string
+			}
+			Operation struct {
+				Hash  string
+				Title string
+				Was   string
+			}
+			Errors []struct {
+				Message string
+			}
+		} `json:"setTitle"`
+	}
+
+	c.MustPost(query, &resp, client.Var("prefix", created.NewBug.Bug.HumanId))
+
+	if len(resp.SetTitle.Errors) > 0 {
+		t.Fatal(resp.SetTitle.Errors[0].Message)
+	}
+	if resp.SetTitle.ClientMutationId != "abc" {
+		t.Fatalf("unexpected client mutation id %q", resp.SetTitle.ClientMutationId)
+	}
+	if resp.SetTitle.Bug.Title != "new title" {
+		t.Fatalf("unexpected title %q", resp.SetTitle.Bug.Title)
+	}
+	if resp.SetTitle.Operation.Title != "new title" || resp.SetTitle.Operation.Was != "title" {
+		t.Fatalf("unexpected operation %+v", resp.SetTitle.Operation)
+	}
+
+	var closed struct {
+		CloseBug struct {
+			Errors []struct {
+				Message string
+			}
+		} `json:"closeBug"`
+	}
+
+	c.MustPost(`mutation($prefix: String!) { closeBug(prefix: $prefix, metadata: [{key: "", value: "x"}]) { errors { message } } }`,
+		&closed, client.Var("prefix", created.NewBug.Bug.HumanId))
+
+	if len(closed.CloseBug.Errors) != 1 {
+		t.Fatal("an empty metadata key should be rejected")
+	}
+}
+
+func TestSubscriptions(t *testing.T) {
+	repo := createFilledRepo(1)
+
+	handler, err := graphql.NewHandler(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(handler)
+	c := client.New(srv.URL)
+
+	sub := c.Websocket(`subscription { bugCreated { title } }`)
+	defer sub.Close()
+
+	// the subscription is registered asynchronously, create bugs until one
+	// is received
+	received := make(chan string)
+	go func() {
+		var resp struct {
+			BugCreated struct {
+				Title string
+			} `json:"bugCreated"`
+		}
+		if err := sub.Next(&resp); err != nil {
+			close(received)
+			return
+		}
+		received <- resp.BugCreated.Title
+	}()
+
+	for i := 0; ; i++ {
+		var resp map[string]interface{}
+		c.MustPost(`mutation { newBug(title: "live", message: "message") { bug { id } } }`, &resp)
+
+		select {
+		case title, ok := <-received:
+			if !ok {
+				t.Fatal("the subscription failed")
+			}
+			if title != "live" {
+				t.Fatalf("unexpected title %q", title)
+			}
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		if i == 50 {
+			t.Fatal("no bug received")
+		}
+	}
+}
+
+func TestBugsQuery(t *testing.T) {
+	repo := createFilledRepo(10)
+
+	handler, err := graphql.NewHandler(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(handler)
+	c := client.New(srv.URL)
+
+	query := `
+      query($query: String!) {
+        defaultRepository {
+          bugs(first: 2, query: $query) {
+            totalCount
+            pageInfo {
+              hasNextPage
+            }
+            nodes {
+              status
+            }
+          }
+        }
+      }`
+
+	type response struct {
+		DefaultRepository struct {
+			Bugs struct {
+				TotalCount int `json:"totalCount"`
+				PageInfo   models.PageInfo
+				Nodes      []struct {
+					Status string
+				}
+			}
+		}
+	}
+
+	total := 0
+
+	for _, status := range []string{"open", "closed"} {
+		var resp response
+		c.MustPost(query, &resp, client.Var("query", "status:"+status+" sort:edit"))
+
+		bugs := resp.DefaultRepository.Bugs
+		for _, node := range bugs.Nodes {
+			if node.Status != strings.ToUpper(status) {
+				t.Fatalf("unexpected status %s for the query status:%s", node.Status, status)
+			}
+		}
+		if bugs.PageInfo.HasNextPage != (bugs.TotalCount > len(bugs.Nodes)) {
+			t.Fatal("inconsistent pagination")
+		}
+
+		total += bugs.TotalCount
+	}
+
+	if total != 10 {
+		t.Fatalf("expected 10 bugs open or closed, got %d", total)
+	}
+}
+
+// bearerTransport authenticate the requests with a token
+type bearerTransport string
+
+func (t bearerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r.Header.Set("Authorization", "Bearer "+string(t))
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestAuth(t *testing.T) {
+	repo := createFilledRepo(1)
+
+	reader, err := graphql.AddToken(repo, "reader", graphql.RoleRead, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice := &bug.Person{Name: "Alice", Email: "alice@example.com"}
+	writer, err := graphql.AddToken(repo, "alice", graphql.RoleWrite, alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler, err := graphql.NewHandler(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	auth, err := graphql.NewAuth(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(auth.Middleware(handler))
+
+	mutation := `mutation { newBug(title: "title", message: "message") { bug { author { name } } errors { code } } }`
+
+	type response struct {
+		NewBug struct {
+			Bug struct {
+				Author struct {
+					Name string
+				}
+			}
+			Errors []struct {
+				Code string
+			}
+		} `json:"newBug"`
+	}
+
+	var resp response
+	if err := client.New(srv.URL).Post(mutation, &resp); err == nil {
+		t.Fatal("a request without token should be rejected")
+	}
+
+	resp = response{}
+	client.New(srv.URL, &http.Client{Transport: bearerTransport(reader)}).MustPost(mutation, &resp)
+	if len(resp.NewBug.Errors) != 1 || resp.NewBug.Errors[0].Code != "READ_ONLY" {
+		t.Fatalf("a read-only token should not create bugs, got %+v", resp.NewBug)
+	}
+
+	resp = response{}
+	client.New(srv.URL, &http.Client{Transport: bearerTransport(writer)}).MustPost(mutation, &resp)
+	if len(resp.NewBug.Errors) > 0 {
+		t.Fatal(resp.NewBug.Errors[0].Code)
+	}
+	if resp.NewBug.Bug.Author.Name != "Alice" {
+		t.Fatalf("the bug should be authored by the identity of the token, got %q", resp.NewBug.Bug.Author.Name)
+	}
+
+	if err := graphql.RemoveToken(repo, "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	tokens, err := graphql.ReadTokens(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 1 || tokens[0].Name != "reader" || !tokens[0].ReadOnly() {
+		t.Fatalf("unexpected tokens %+v", tokens)
+	}
+}
+
+func TestAuthMultiRepo(t *testing.T) {
+	front := createFilledRepo(1)
+	back := createFilledRepo(1)
+
+	writer, err := graphql.AddToken(front, "writer", graphql.RoleWrite, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler, err := graphql.NewMultiRepoHandler(map[string]repository.ClockedRepo{
+		"front": front,
+		"back":  back,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	auth, err := graphql.NewAuth(front, back)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(auth.Middleware(handler))
+	c := client.New(srv.URL, &http.Client{Transport: bearerTransport(writer)})
+
+	var resp struct {
+		Repositories []struct {
+			Name string
+		}
+	}
+	c.MustPost(`query { repositories { name } }`, &resp)
+	if len(resp.Repositories) != 1 || resp.Repositories[0].Name != "front" {
+		t.Fatalf("only the repository of the token should be listed, got %+v", resp.Repositories)
+	}
+
+	var repoResp map[string]interface{}
+	if err := c.Post(`query { repository(ref: "back") { name } }`, &repoResp); err == nil {
+		t.Fatal("the token of a repository should not give access to another one")
+	}
+
+	var bugResp map[string]interface{}
+	err = c.Post(`mutation { newBug(repoRef: "back", title: "title", message: "message") { bug { id } } }`, &bugResp)
+	if err == nil {
+		t.Fatal("the token of a repository should not allow to change another one")
+	}
+
+	bugResp = nil
+	c.MustPost(`mutation { newBug(repoRef: "front", title: "title", message: "message") { bug { id } } }`, &bugResp)
+}
+
+func TestMultiRepo(t *testing.T) {
+	handler, err := graphql.NewMultiRepoHandler(map[string]repository.ClockedRepo{
+		"front": createFilledRepo(2),
+		"back":  createFilledRepo(3),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(handler)
+	c := client.New(srv.URL)
+
+	var resp struct {
+		DefaultRepository *struct{} `json:"defaultRepository"`
+		Repositories      []struct {
+			Name string
+			Bugs struct {
+				TotalCount int `json:"totalCount"`
+			}
+		}
+		Repository struct {
+			Name string
+			Bugs struct {
+				TotalCount int `json:"totalCount"`
+			}
+		}
+	}
+
+	query := `
+      query {
+        defaultRepository { name }
+        repositories { name bugs { totalCount } }
+        repository(ref: "back") { name bugs { totalCount } }
+      }`
+
+	// the default repository is null, reported as an error
+	_ = c.Post(query, &resp)
+
+	if resp.DefaultRepository != nil {
+		t.Fatal("there should be no default repository")
+	}
+	if len(resp.Repositories) != 2 || resp.Repositories[0].Name != "back" || resp.Repositories[1].Name != "front" {
+		t.Fatalf("unexpected repositories %+v", resp.Repositories)
+	}
+	if resp.Repositories[1].Bugs.TotalCount != 2 {
+		t.Fatalf("unexpected number of bugs %d", resp.Repositories[1].Bugs.TotalCount)
+	}
+	if resp.Repository.Name != "back" || resp.Repository.Bugs.TotalCount != 3 {
+		t.Fatalf("unexpected repository %+v", resp.Repository)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	repo := createFilledRepo(1)
+
+	for key, value := range map[string]string{
+		"git-bug.webui-max-query-depth":      "3",
+		"git-bug.webui-max-query-complexity": "20",
+		"git-bug.webui-rate-limit":           "5",
+	} {
+		if err := repo.StoreConfig(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	handler, err := graphql.NewHandler(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(handler.Limits.RateLimiter(handler))
+	c := client.New(srv.URL)
+
+	var resp map[string]interface{}
+
+	// depth 3
+	if err := c.Post(`query { defaultRepository { bugs { totalCount } } }`, &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	// depth 4
+	err = c.Post(`query { defaultRepository { bugs { nodes { title } } } }`, &resp)
+	if err == nil || !strings.Contains(err.Error(), "depth") {
+		t.Fatalf("a query too deep should be rejected, got %v", err)
+	}
+
+	err = c.Post(`query { defaultRepository {
+		a: bugs { totalCount } b: bugs { totalCount } c: bugs { totalCount }
+		d: bugs { totalCount } e: bugs { totalCount } f: bugs { totalCount }
+		g: bugs { totalCount } h: bugs { totalCount } i: bugs { totalCount }
+		j: bugs { totalCount } k: bugs { totalCount } } }`, &resp)
+	if err == nil || !strings.Contains(err.Error(), "complexity") {
+		t.Fatalf("a query too complex should be rejected, got %v", err)
+	}
+
+	// the three first requests are counted, the limit is reached after two
+	// more
+	for i := 0; i < 2; i++ {
+		if err := c.Post(`query { defaultRepository { bugs { totalCount } } }`, &resp); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := http.Post(srv.URL, "application/json", strings.NewReader(`{"query": "{ defaultRepository { name } }"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Body.Close()
+
+	if r.StatusCode != http.StatusTooManyRequests || r.Header.Get("Retry-After") == "" {
+		t.Fatalf("the rate limit should be enforced, got status %d", r.StatusCode)
+	}
+}
+
+func TestTimelineFilter(t *testing.T) {
+	repo := createFilledRepo(1)
+
+	handler, err := graphql.NewHandler(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(handler)
+	c := client.New(srv.URL)
+
+	query := `
+      query($kinds: [TimelineItemKind!], $afterHash: Hash) {
+        defaultRepository {
+          bugs(first: 1) {
+            nodes {
+              timeline(kinds: $kinds, afterHash: $afterHash) {
+                totalCount
+                nodes {
+                  __typename
+                  hash
+                }
+              }
+            }
+          }
+        }
+      }`
+
+	type response struct {
+		DefaultRepository struct {
+			Bugs struct {
+				Nodes []struct {
+					Timeline struct {
+						TotalCount int `json:"totalCount"`
+						Nodes      []struct {
+							Typename string `json:"__typename"`
+							Hash     string
+						}
+					}
+				}
+			}
+		}
+	}
+
+	timeline := func(options ...client.Option) (int, []string, []string) {
+		var resp response
+		c.MustPost(query, &resp, options...)
+		tl := resp.DefaultRepository.Bugs.Nodes[0].Timeline
+		var types, hashes []string
+		for _, node := range tl.Nodes {
+			types = append(types, node.Typename)
+			hashes = append(hashes, node.Hash)
+		}
+		return tl.TotalCount, types, hashes
+	}
+
+	total, _, hashes := timeline()
+
+	comments, types, _ := timeline(client.Var("kinds", []string{"COMMENT"}))
+	if comments == 0 || comments > total {
+		t.Fatalf("unexpected number of comments %d out of %d", comments, total)
+	}
+	for _, typename := range types {
+		if typename != "CreateTimelineItem" && typename != "AddCommentTimelineItem" {
+			t.Fatalf("unexpected item %s", typename)
+		}
+	}
+
+	after, _, afterHashes := timeline(client.Var("afterHash", hashes[0]))
+	if after != total-1 || (after > 0 && afterHashes[0] != hashes[1]) {
+		t.Fatalf("unexpected items after the first one: %d out of %d", after, total)
+	}
+
+	pageQuery := `
+      query($after: String) {
+        defaultRepository {
+          bugs(first: 1) {
+            nodes {
+              timeline(first: 1, after: $after) {
+                totalCount
+                nodes { hash }
+                pageInfo { hasNextPage endCursor }
+              }
+            }
+          }
+        }
+      }`
+
+	var page struct {
+		DefaultRepository struct {
+			Bugs struct {
+				Nodes []struct {
+					Timeline struct {
+						TotalCount int `json:"totalCount"`
+						Nodes      []struct {
+							Hash string
+						}
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+					}
+				}
+			}
+		}
+	}
+
+	// the timeline is paged one item at a time
+	var paged []string
+	var cursor *string
+	for {
+		c.MustPost(pageQuery, &page, client.Var("after", cursor))
+		tl := page.DefaultRepository.Bugs.Nodes[0].Timeline
+		if tl.TotalCount != total {
+			t.Fatalf("unexpected total count %d, expected %d", tl.TotalCount, total)
+		}
+		for _, node := range tl.Nodes {
+			paged = append(paged, node.Hash)
+		}
+		if !tl.PageInfo.HasNextPage {
+			break
+		}
+		endCursor := tl.PageInfo.EndCursor
+		cursor = &endCursor
+	}
+
+	if !reflect.DeepEqual(paged, hashes) {
+		t.Fatalf("the pages %v don't match the timeline %v", paged, hashes)
+	}
+}
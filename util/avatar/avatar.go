@@ -0,0 +1,86 @@
+// Package avatar generate deterministic avatars showing the initials of a
+// person, for the persons without an avatar of their own. The same person
+// always get the same color, to keep the lists with many authors scannable.
+package avatar
+
+import (
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/fatih/color"
+)
+
+type swatch struct {
+	// background and text colors for the web
+	background string
+	foreground string
+	// the closest terminal colors
+	termBackground color.Attribute
+	termForeground color.Attribute
+}
+
+var palette = []swatch{
+	{"#e53935", "#ffffff", color.BgRed, color.FgWhite},
+	{"#43a047", "#ffffff", color.BgGreen, color.FgBlack},
+	{"#fdd835", "#000000", color.BgYellow, color.FgBlack},
+	{"#1e88e5", "#ffffff", color.BgBlue, color.FgWhite},
+	{"#8e24aa", "#ffffff", color.BgMagenta, color.FgWhite},
+	{"#00acc1", "#000000", color.BgCyan, color.FgBlack},
+}
+
+// Initials return up to two uppercase letters from a name: the first letter
+// of the first and last words, or of the only word.
+func Initials(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if len(words) == 0 {
+		return "?"
+	}
+
+	first := []rune(words[0])[0]
+	if len(words) == 1 {
+		return string(unicode.ToUpper(first))
+	}
+
+	last := []rune(words[len(words)-1])[0]
+	return string([]rune{unicode.ToUpper(first), unicode.ToUpper(last)})
+}
+
+// pick select the colors of an avatar from a key identifying the person
+func pick(key string) swatch {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return palette[h.Sum32()%uint32(len(palette))]
+}
+
+// SVG return a square SVG image of the given size, showing the initials on a
+// background colored according to the key
+func SVG(initials string, key string, size int) string {
+	s := pick(key)
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="%[1]d" viewBox="0 0 100 100">`+
+		`<rect width="100" height="100" fill="%[2]s"/>`+
+		`<text x="50" y="50" dy="0.35em" text-anchor="middle" font-family="sans-serif" font-size="42" fill="%[3]s">%[4]s</text>`+
+		`</svg>`,
+		size, s.background, s.foreground, html.EscapeString(initials))
+}
+
+// DataURL return the SVG avatar as an url, usable in place of the url of an
+// image
+func DataURL(initials string, key string) string {
+	svg := SVG(initials, key, 64)
+	return "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(svg))
+}
+
+// Terminal return a colored block showing the initials, always two cells
+// wide plus a space on each side
+func Terminal(initials string, key string) string {
+	s := pick(key)
+	return color.New(s.termBackground, s.termForeground).Sprintf(" %-2s ", initials)
+}
@@ -0,0 +1,25 @@
+package avatar
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitials(t *testing.T) {
+	assert.Equal(t, "RD", Initials("René Descartes"))
+	assert.Equal(t, "ÉC", Initials("émilie du Châtelet"))
+	assert.Equal(t, "JM", Initials("Jean-Michel"))
+	assert.Equal(t, "A", Initials("alice"))
+	assert.Equal(t, "?", Initials(" - "))
+}
+
+func TestDeterministic(t *testing.T) {
+	assert.Equal(t, SVG("RD", "rene@descartes.fr", 32), SVG("RD", "rene@descartes.fr", 32))
+	assert.Equal(t, DataURL("RD", "rene@descartes.fr"), DataURL("RD", "rene@descartes.fr"))
+	assert.True(t, strings.HasPrefix(DataURL("RD", "rene@descartes.fr"), "data:image/svg+xml;base64,"))
+
+	// the initials are escaped
+	assert.Contains(t, SVG("<>", "key", 32), "&lt;&gt;")
+}
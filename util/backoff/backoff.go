@@ -0,0 +1,70 @@
+// Package backoff compute the delays between the runs of a periodic task,
+// spread with some jitter and spaced out after consecutive failures.
+package backoff
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff hold the state of a periodic task
+type Backoff struct {
+	// Interval is the delay after a success
+	Interval time.Duration
+	// Max is the longest delay after consecutive failures
+	Max time.Duration
+	// Jitter is the fraction of the delay randomly added or removed, so that
+	// several instances don't run in lockstep
+	Jitter float64
+
+	failures int
+	rand     *rand.Rand
+}
+
+// New create a Backoff doubling the interval after each failure, up to max
+func New(interval time.Duration, max time.Duration, jitter float64) *Backoff {
+	return &Backoff{
+		Interval: interval,
+		Max:      max,
+		Jitter:   jitter,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Success reset the delay to the interval
+func (b *Backoff) Success() {
+	b.failures = 0
+}
+
+// Failure double the delay, up to the maximum
+func (b *Backoff) Failure() {
+	b.failures++
+}
+
+// Failures return the number of consecutive failures
+func (b *Backoff) Failures() int {
+	return b.failures
+}
+
+// Base return the delay before the next run, without the jitter
+func (b *Backoff) Base() time.Duration {
+	delay := b.Interval
+	for i := 0; i < b.failures && delay < b.Max; i++ {
+		delay *= 2
+	}
+	if b.failures > 0 && delay > b.Max {
+		delay = b.Max
+	}
+	return delay
+}
+
+// Next return the delay before the next run, with the jitter
+func (b *Backoff) Next() time.Duration {
+	delay := b.Base()
+	if b.Jitter <= 0 {
+		return delay
+	}
+
+	spread := float64(delay) * b.Jitter
+	return delay + time.Duration(spread*(2*b.rand.Float64()-1))
+}
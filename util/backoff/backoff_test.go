@@ -0,0 +1,36 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	b := New(10*time.Minute, time.Hour, 0)
+	assert.Equal(t, 10*time.Minute, b.Next())
+
+	b.Failure()
+	assert.Equal(t, 20*time.Minute, b.Next())
+	b.Failure()
+	assert.Equal(t, 40*time.Minute, b.Next())
+	b.Failure()
+	assert.Equal(t, time.Hour, b.Next())
+	b.Failure()
+	assert.Equal(t, time.Hour, b.Next())
+	assert.Equal(t, 4, b.Failures())
+
+	b.Success()
+	assert.Equal(t, 10*time.Minute, b.Next())
+}
+
+func TestJitter(t *testing.T) {
+	b := New(10*time.Minute, time.Hour, 0.1)
+
+	for i := 0; i < 100; i++ {
+		delay := b.Next()
+		assert.True(t, delay >= 9*time.Minute, delay)
+		assert.True(t, delay <= 11*time.Minute, delay)
+	}
+}
@@ -5,11 +5,14 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 )
 
 type Persisted struct {
 	Clock
 	filePath string
+	// serialize the writes of the file
+	muWrite sync.Mutex
 }
 
 // NewPersisted create a new persisted Lamport clock
@@ -79,6 +82,9 @@ func (c *Persisted) read() error {
 }
 
 func (c *Persisted) Write() error {
-	data := []byte(fmt.Sprintf("%d", c.counter))
+	c.muWrite.Lock()
+	defer c.muWrite.Unlock()
+
+	data := []byte(fmt.Sprintf("%d", c.Time()))
 	return ioutil.WriteFile(c.filePath, data, 0644)
 }
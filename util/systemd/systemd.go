@@ -0,0 +1,72 @@
+// Package systemd implement the small subset of the systemd protocols needed
+// to run git-bug as a service: socket activation and readiness notification.
+package systemd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+)
+
+// listenFdsStart is the first file descriptor passed by systemd
+const listenFdsStart = 3
+
+// Listeners return the sockets passed by systemd with socket activation, if
+// any. The environment variables are cleared so that they are not inherited
+// by the child processes.
+func Listeners() ([]net.Listener, error) {
+	defer func() {
+		_ = os.Unsetenv("LISTEN_PID")
+		_ = os.Unsetenv("LISTEN_FDS")
+		_ = os.Unsetenv("LISTEN_FDNAMES")
+	}()
+
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		// not activated, or the sockets are meant for another process
+		return nil, nil
+	}
+
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count <= 0 {
+		return nil, nil
+	}
+
+	listeners := make([]net.Listener, count)
+
+	for i := 0; i < count; i++ {
+		fd := listenFdsStart + i
+		file := os.NewFile(uintptr(fd), fmt.Sprintf("LISTEN_FD_%d", fd))
+
+		listeners[i], err = net.FileListener(file)
+		if err != nil {
+			return nil, fmt.Errorf("invalid activation socket %d: %v", fd, err)
+		}
+
+		// the listener hold its own copy of the file descriptor
+		_ = file.Close()
+	}
+
+	return listeners, nil
+}
+
+// Notify send a state change (ex: "READY=1") to systemd. It does nothing if
+// the process is not supervised by systemd.
+func Notify(state string) error {
+	socketPath := os.Getenv("NOTIFY_SOCKET")
+	if socketPath == "" {
+		return nil
+	}
+
+	addr := &net.UnixAddr{Name: socketPath, Net: "unixgram"}
+
+	conn, err := net.DialUnix("unixgram", nil, addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+	return err
+}
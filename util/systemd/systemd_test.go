@@ -0,0 +1,48 @@
+package systemd
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListenersNotActivated(t *testing.T) {
+	// sockets meant for another process
+	assert.NoError(t, os.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()+1)))
+	assert.NoError(t, os.Setenv("LISTEN_FDS", "1"))
+
+	listeners, err := Listeners()
+	assert.NoError(t, err)
+	assert.Empty(t, listeners)
+
+	// the environment is cleared
+	_, ok := os.LookupEnv("LISTEN_FDS")
+	assert.False(t, ok)
+}
+
+func TestNotify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socketPath := path.Join(dir, "notify")
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	assert.NoError(t, os.Setenv("NOTIFY_SOCKET", socketPath))
+	defer os.Unsetenv("NOTIFY_SOCKET")
+
+	assert.NoError(t, Notify("READY=1"))
+
+	buf := make([]byte, 64)
+	n, err := conn.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "READY=1", string(buf[:n]))
+}
@@ -1,6 +1,8 @@
 package text
 
 import (
+	"bytes"
+	"fmt"
 	"net/url"
 	"strings"
 	"unicode"
@@ -33,6 +35,64 @@ func Safe(s string) bool {
 	return true
 }
 
+// Sanitize return a safe version of the string: the unsafe characters (see
+// Safe) are escaped, the invalid UTF-8 sequences are replaced by U+FFFD and the
+// line endings are normalized.
+func Sanitize(s string) string {
+	s = strings.Replace(s, "\r\n", "\n", -1)
+
+	var buffer bytes.Buffer
+
+	// ranging over a string yield U+FFFD for invalid UTF-8 sequences
+	for _, r := range s {
+		switch r {
+		case '\t', '\r', '\n':
+			buffer.WriteRune(r)
+			continue
+		}
+
+		if unicode.IsControl(r) {
+			_, _ = fmt.Fprintf(&buffer, "\\x%02x", r)
+			continue
+		}
+
+		buffer.WriteRune(r)
+	}
+
+	return buffer.String()
+}
+
+// Policy define how the unsafe characters are handled
+type Policy int
+
+const (
+	// PolicyStrict keep the text as is, which will then be rejected by the
+	// validation if unsafe
+	PolicyStrict Policy = iota
+	// PolicyLenient sanitize the text so it pass the validation
+	PolicyLenient
+)
+
+// PolicyFromString parse a Policy as found in the configuration
+func PolicyFromString(str string) (Policy, error) {
+	switch strings.ToLower(str) {
+	case "", "strict":
+		return PolicyStrict, nil
+	case "lenient":
+		return PolicyLenient, nil
+	default:
+		return 0, fmt.Errorf("unknown text policy %s", str)
+	}
+}
+
+// Apply process the string according to the policy
+func (p Policy) Apply(s string) string {
+	if p == PolicyLenient {
+		return Sanitize(s)
+	}
+	return s
+}
+
 // ValidUrl will tell if the string contains what seems to be a valid URL
 func ValidUrl(s string) bool {
 	if strings.Contains(s, "\n") {
@@ -0,0 +1,29 @@
+package text
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	cases := []struct {
+		Input, Output string
+	}{
+		{"simple", "simple"},
+		{"emoji 🐛 and accents éà", "emoji 🐛 and accents éà"},
+		{"line\r\nbreak", "line\nbreak"},
+		{"tab\tand\nnewline", "tab\tand\nnewline"},
+		{"\x1b[31mred\x1b[0m", "\\x1b[31mred\\x1b[0m"},
+		{"null\x00byte", "null\\x00byte"},
+		{"invalid \xff utf8", "invalid � utf8"},
+	}
+
+	for i, tc := range cases {
+		actual := Sanitize(tc.Input)
+		if actual != tc.Output {
+			t.Fatalf("Case %d Input:\n\n`%s`\n\nExpected Output:\n\n`%s`\n\nActual Output:\n\n`%s`",
+				i, tc.Input, tc.Output, actual)
+		}
+
+		if !Safe(actual) {
+			t.Fatalf("Case %d: sanitized output is not safe: %q", i, actual)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+import MuiAvatar from '@material-ui/core/Avatar';
+import { withStyles } from '@material-ui/core/styles';
+import React from 'react';
+
+const styles = {
+  avatar: {
+    display: 'inline-flex',
+    verticalAlign: 'middle',
+    width: 20,
+    height: 20,
+    marginRight: 6,
+  },
+};
+
+// The server provide a generated avatar with the initials of the persons
+// without their own, so that one is always available
+const Avatar = ({ author, classes }) => (
+  <MuiAvatar
+    className={classes.avatar}
+    src={author.avatar}
+    alt={author.displayName}
+  />
+);
+
+export default withStyles(styles)(Avatar);
@@ -2,9 +2,10 @@ import Tooltip from '@material-ui/core/Tooltip/Tooltip';
 import * as moment from 'moment';
 import React from 'react';
 
+// The dates are sent in the timezone configured for the repository, keep it
 const Date = ({ date }) => (
-  <Tooltip title={moment(date).format('MMMM D, YYYY, h:mm a')}>
-    <span> {moment(date).fromNow()} </span>
+  <Tooltip title={moment.parseZone(date).format('MMMM D, YYYY, h:mm a Z')}>
+    <span> {moment.parseZone(date).fromNow()} </span>
   </Tooltip>
 );
 
@@ -6,6 +6,7 @@ import Author from '../Author';
 import Date from '../Date';
 import TimelineQuery from './TimelineQuery';
 import Label from '../Label';
+import SuggestedAssignees from './SuggestedAssignees';
 
 const styles = theme => ({
   main: {
@@ -21,6 +22,9 @@ const styles = theme => ({
     ...theme.typography.subheading,
     marginLeft: 15,
   },
+  hint: {
+    color: theme.palette.error.main,
+  },
   container: {
     display: 'flex',
     marginBottom: 30,
@@ -50,13 +54,21 @@ const Bug = ({ bug, classes }) => (
   <main className={classes.main}>
     <div className={classes.header}>
       <span className={classes.title}>{bug.title}</span>
-      <span className={classes.id}>{bug.humanId}</span>
+      <span className={classes.id}>
+        {bug.alias && `${bug.alias} `}
+        {bug.humanId}
+      </span>
 
       <Typography color={'textSecondary'}>
         <Author author={bug.author} />
         <span> opened this bug </span>
         <Date date={bug.createdAt} />
       </Typography>
+      {bug.hints.map((h, i) => (
+        <Typography className={classes.hint} key={i}>
+          {h}
+        </Typography>
+      ))}
     </div>
 
     <div className={classes.container}>
@@ -72,6 +84,23 @@ const Bug = ({ bug, classes }) => (
             </li>
           ))}
         </ul>
+        {bug.visibility !== 'public' && (
+          <React.Fragment>
+            <Typography variant={'subheading'}>Visibility</Typography>
+            <Typography color={'textSecondary'}>{bug.visibility}</Typography>
+          </React.Fragment>
+        )}
+        {bug.fixedIn.length > 0 && (
+          <React.Fragment>
+            <Typography variant={'subheading'}>Fixed in</Typography>
+            {bug.fixedIn.map((f, i) => (
+              <Typography color={'textSecondary'} key={i}>
+                {f.release || f.commit.substring(0, 7)}
+              </Typography>
+            ))}
+          </React.Fragment>
+        )}
+        <SuggestedAssignees suggestions={bug.suggestedAssignees} />
       </div>
     </div>
   </main>
@@ -81,16 +110,26 @@ Bug.fragment = gql`
   fragment Bug on Bug {
     id
     humanId
+    alias
     status
     title
     labels
+    visibility
+    fixedIn {
+      release
+      commit
+    }
+    hints
     createdAt
     author {
       email
       name
       displayName
     }
+    ...SuggestedAssignees
   }
+
+  ${SuggestedAssignees.fragment}
 `;
 
 export default withStyles(styles)(Bug);
@@ -3,6 +3,7 @@ import Typography from '@material-ui/core/Typography';
 import gql from 'graphql-tag';
 import React from 'react';
 import Author from '../Author';
+import Avatar from '../Avatar';
 import Date from '../Date';
 
 const styles = theme => ({
@@ -25,45 +26,78 @@ const styles = theme => ({
     padding: 5,
     whiteSpace: 'pre-wrap',
   },
+  files: {
+    display: 'flex',
+    flexWrap: 'wrap',
+    marginTop: 5,
+  },
+  file: {
+    maxWidth: '100%',
+    maxHeight: 400,
+    marginRight: 5,
+  },
 });
 
+const mediaUrl = hash => `/api/media/${hash}`;
+
+const Files = ({ files, classes }) =>
+  files && files.length > 0 ? (
+    <div className={classes.files}>
+      {files.map(hash => (
+        <a key={hash} href={mediaUrl(hash)} title={hash}>
+          <img
+            className={classes.file}
+            src={mediaUrl(hash)}
+            alt={hash.slice(0, 7)}
+          />
+        </a>
+      ))}
+    </div>
+  ) : null;
+
 const Message = ({ op, classes }) => (
   <div>
     <div className={classes.header}>
+      <Avatar author={op.author} />
       <Author className={classes.author} author={op.author} bold />
       <span> commented </span>
-      <Date date={op.date} />
+      <Date date={op.createdAt} />
     </div>
     <div className={classes.message}>
       <Typography>{op.message}</Typography>
+      <Files files={op.files} classes={classes} />
     </div>
   </div>
 );
 
 Message.createFragment = gql`
-  fragment Create on Operation {
-    ... on CreateOperation {
-      date
+  fragment Create on TimelineItem {
+    ... on CreateTimelineItem {
+      createdAt
       author {
         name
         email
         displayName
+        avatar
       }
       message
+      files
     }
   }
 `;
 
 Message.commentFragment = gql`
-  fragment Comment on Operation {
-    ... on AddCommentOperation {
-      date
+  fragment Comment on TimelineItem {
+    ... on AddCommentTimelineItem {
+      createdAt
       author {
         name
         email
         displayName
+        avatar
       }
       message
+      files
     }
   }
 `;
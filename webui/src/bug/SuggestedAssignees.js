@@ -0,0 +1,54 @@
+import MenuItem from '@material-ui/core/MenuItem';
+import Select from '@material-ui/core/Select';
+import Typography from '@material-ui/core/Typography/Typography';
+import gql from 'graphql-tag';
+import React from 'react';
+
+class SuggestedAssignees extends React.Component {
+  state = { selected: 0 };
+
+  handleChange = event => {
+    this.setState({ selected: event.target.value });
+  };
+
+  render() {
+    const { suggestions } = this.props;
+    if (suggestions.length === 0) {
+      return null;
+    }
+
+    const selected = suggestions[this.state.selected];
+
+    return (
+      <React.Fragment>
+        <Typography variant={'subheading'}>Suggested assignees</Typography>
+        <Select value={this.state.selected} onChange={this.handleChange}>
+          {suggestions.map((s, i) => (
+            <MenuItem value={i} key={i}>
+              {s.person.displayName}
+            </MenuItem>
+          ))}
+        </Select>
+        {selected.reasons.map((r, i) => (
+          <Typography color={'textSecondary'} key={i}>
+            {r}
+          </Typography>
+        ))}
+      </React.Fragment>
+    );
+  }
+}
+
+SuggestedAssignees.fragment = gql`
+  fragment SuggestedAssignees on Bug {
+    suggestedAssignees(first: 5) {
+      person {
+        displayName
+      }
+      score
+      reasons
+    }
+  }
+`;
+
+export default SuggestedAssignees;
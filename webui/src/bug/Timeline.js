@@ -1,3 +1,4 @@
+import Button from '@material-ui/core/Button';
 import { withStyles } from '@material-ui/core/styles';
 import React from 'react';
 import LabelChange from './LabelChange';
@@ -15,34 +16,68 @@ const styles = theme => ({
 
 class Timeline extends React.Component {
   props: {
-    ops: Array,
+    timeline: any,
     fetchMore: any => any,
     classes: any,
   };
 
+  // the timeline is paged, the next items are appended to the ones displayed
+  loadMore = () => {
+    const { timeline, fetchMore } = this.props;
+
+    fetchMore({
+      variables: { after: timeline.pageInfo.endCursor },
+      updateQuery: (previousResult, { fetchMoreResult }) => {
+        if (!fetchMoreResult) {
+          return previousResult;
+        }
+
+        const previous = previousResult.defaultRepository.bug.timeline;
+        const next = fetchMoreResult.defaultRepository.bug.timeline;
+
+        return {
+          ...fetchMoreResult,
+          defaultRepository: {
+            ...fetchMoreResult.defaultRepository,
+            bug: {
+              ...fetchMoreResult.defaultRepository.bug,
+              timeline: {
+                ...next,
+                nodes: [...previous.nodes, ...next.nodes],
+              },
+            },
+          },
+        };
+      },
+    });
+  };
+
   render() {
-    const { ops, classes } = this.props;
+    const { timeline, classes } = this.props;
 
     return (
       <div className={classes.main}>
-        {ops.map((op, index) => {
-          switch (op.__typename) {
-            case 'CreateOperation':
-              return <Message key={index} op={op} />;
-            case 'AddCommentOperation':
-              return <Message key={index} op={op} />;
-            case 'LabelChangeOperation':
-              return <LabelChange key={index} op={op} />;
-            case 'SetTitleOperation':
-              return <SetTitle key={index} op={op} />;
-            case 'SetStatusOperation':
-              return <SetStatus key={index} op={op} />;
+        {timeline.nodes.map((item, index) => {
+          switch (item.__typename) {
+            case 'CreateTimelineItem':
+              return <Message key={index} op={item} />;
+            case 'AddCommentTimelineItem':
+              return <Message key={index} op={item} />;
+            case 'LabelChangeTimelineItem':
+              return <LabelChange key={index} op={item} />;
+            case 'SetTitleTimelineItem':
+              return <SetTitle key={index} op={item} />;
+            case 'SetStatusTimelineItem':
+              return <SetStatus key={index} op={item} />;
 
             default:
-              console.log('unsupported operation type ' + op.__typename);
+              console.log('unsupported timeline item type ' + item.__typename);
               return null;
           }
         })}
+        {timeline.pageInfo.hasNextPage && (
+          <Button onClick={this.loadMore}>Load more</Button>
+        )}
       </div>
     );
   }
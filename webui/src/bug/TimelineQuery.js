@@ -12,7 +12,7 @@ const QUERY = gql`
   query($id: String!, $first: Int = 10, $after: String) {
     defaultRepository {
       bug(prefix: $id) {
-        operations(first: $first, after: $after) {
+        timeline(first: $first, after: $after) {
           nodes {
             ...Create
             ...Comment
@@ -42,7 +42,7 @@ const TimelineQuery = ({ id }) => (
       if (error) return <p>Error: {error}</p>;
       return (
         <Timeline
-          ops={data.defaultRepository.bug.operations.nodes}
+          timeline={data.defaultRepository.bug.timeline}
           fetchMore={fetchMore}
         />
       );
@@ -75,6 +75,7 @@ const BugRow = ({ bug, classes }) => (
           </div>
         </Link>
         <Typography color={'textSecondary'}>
+          {bug.alias && `${bug.alias} `}
           {bug.humanId} opened
           <Date date={bug.createdAt} />
           by {bug.author.displayName}
@@ -88,6 +89,7 @@ BugRow.fragment = gql`
   fragment BugRow on Bug {
     id
     humanId
+    alias
     title
     status
     createdAt
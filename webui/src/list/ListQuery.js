@@ -9,7 +9,7 @@ import List from './List';
 const QUERY = gql`
   query($first: Int = 10, $last: Int, $after: String, $before: String) {
     defaultRepository {
-      bugs: allBugs(
+      bugs(
         first: $first
         last: $last
         after: $after
@@ -0,0 +1,221 @@
+// Package workspace hold the set of repositories operated together by the
+// CLI, for the developers working on many projects
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/pkg/errors"
+)
+
+// EnvPath is the environment variable overriding the path of the workspace
+// file
+const EnvPath = "GIT_BUG_WORKSPACE"
+
+const fileName = "workspace.json"
+
+// Workspace is a set of named repositories
+type Workspace struct {
+	path string
+	// Repos are the paths of the repositories, by name
+	Repos map[string]string `json:"repos"`
+}
+
+// FilePath return the path of the file holding the workspace, in the user
+// configuration directory
+func FilePath() (string, error) {
+	if path := os.Getenv(EnvPath); path != "" {
+		return path, nil
+	}
+
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home := os.Getenv("HOME")
+		if home == "" {
+			return "", fmt.Errorf("unable to find the configuration directory, $HOME is not defined")
+		}
+		dir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(dir, "git-bug", fileName), nil
+}
+
+// Load read the workspace of the user. A missing file is an empty workspace.
+func Load() (*Workspace, error) {
+	path, err := FilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workspace{
+		path:  path,
+		Repos: make(map[string]string),
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return w, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, w)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid workspace file %s", path)
+	}
+
+	if w.Repos == nil {
+		w.Repos = make(map[string]string)
+	}
+
+	return w, nil
+}
+
+// Save write the workspace on disk
+func (w *Workspace) Save() error {
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(w.path), 0755)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(w.path, data, 0644)
+}
+
+// Add register a repository under a name
+func (w *Workspace) Add(name string, path string) error {
+	if name == "" || strings.ContainsAny(name, "/ \t\n") {
+		return fmt.Errorf("invalid repository name \"%s\", it can't be empty or contain a slash or a space", name)
+	}
+
+	if existing, ok := w.Repos[name]; ok {
+		return fmt.Errorf("the name %s is already used by %s", name, existing)
+	}
+
+	for other, existing := range w.Repos {
+		if existing == path {
+			return fmt.Errorf("%s is already registered as %s", path, other)
+		}
+	}
+
+	w.Repos[name] = path
+	return nil
+}
+
+// Remove unregister a repository
+func (w *Workspace) Remove(name string) error {
+	if _, ok := w.Repos[name]; !ok {
+		return fmt.Errorf("unknown repository %s", name)
+	}
+
+	delete(w.Repos, name)
+	return nil
+}
+
+// Names return the names of the repositories, sorted
+func (w *Workspace) Names() []string {
+	result := make([]string, 0, len(w.Repos))
+	for name := range w.Repos {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Discover build a workspace, not saved, from the git repositories found
+// directly under a directory, named after their directory
+func Discover(root string) (*Workspace, error) {
+	entries, err := ioutil.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workspace{
+		Repos: make(map[string]string),
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path, err := filepath.Abs(filepath.Join(root, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		if !isGitRepo(path) {
+			continue
+		}
+
+		if err := w.Add(entry.Name(), path); err != nil {
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+// isGitRepo tell if a directory is a git repository. Unlike git, the parent
+// directories are not looked at.
+func isGitRepo(path string) bool {
+	_, err := os.Stat(filepath.Join(path, ".git"))
+	return err == nil
+}
+
+// OpenRepos open every repository of the workspace, by name
+func (w *Workspace) OpenRepos() (map[string]repository.ClockedRepo, error) {
+	if len(w.Repos) == 0 {
+		return nil, fmt.Errorf("the workspace is empty, use \"git bug workspace add\" to register repositories")
+	}
+
+	repos := make(map[string]repository.ClockedRepo, len(w.Repos))
+
+	for _, name := range w.Names() {
+		repo, err := repository.NewGitRepo(w.Repos[name], bug.Witnesser)
+		if err != nil {
+			return nil, errors.Wrapf(err, "repository %s", name)
+		}
+		repos[name] = repo
+	}
+
+	return repos, nil
+}
+
+// Open open the cache of every repository of the workspace
+func (w *Workspace) Open(readOnly bool) (*cache.MultiRepoCache, error) {
+	repos, err := w.OpenRepos()
+	if err != nil {
+		return nil, err
+	}
+
+	multi := cache.NewMultiRepoCache()
+
+	for _, name := range w.Names() {
+		if readOnly {
+			err = multi.RegisterRepositoryReadOnly(name, repos[name])
+		} else {
+			err = multi.RegisterRepository(name, repos[name])
+		}
+		if err != nil {
+			_ = multi.Close()
+			return nil, errors.Wrapf(err, "repository %s", name)
+		}
+	}
+
+	return &multi, nil
+}
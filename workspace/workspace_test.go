@@ -0,0 +1,63 @@
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkspace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sub", fileName)
+	assert.NoError(t, os.Setenv(EnvPath, path))
+	defer os.Unsetenv(EnvPath)
+
+	// a missing file is an empty workspace
+	ws, err := Load()
+	assert.NoError(t, err)
+	assert.Empty(t, ws.Names())
+
+	_, err = ws.Open(true)
+	assert.Error(t, err)
+
+	assert.NoError(t, ws.Add("b", "/path/b"))
+	assert.NoError(t, ws.Add("a", "/path/a"))
+	assert.Error(t, ws.Add("a", "/path/other"))
+	assert.Error(t, ws.Add("c", "/path/a"))
+	assert.Error(t, ws.Add("with/slash", "/path/other"))
+	assert.Error(t, ws.Add("", "/path/other"))
+	assert.NoError(t, ws.Save())
+
+	ws, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, ws.Names())
+	assert.Equal(t, "/path/a", ws.Repos["a"])
+
+	assert.NoError(t, ws.Remove("a"))
+	assert.Error(t, ws.Remove("a"))
+	assert.Equal(t, []string{"b"}, ws.Names())
+}
+
+func TestDiscover(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = repository.InitGitRepo(filepath.Join(dir, "front"))
+	assert.NoError(t, err)
+	_, err = repository.InitGitRepo(filepath.Join(dir, "back"))
+	assert.NoError(t, err)
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "notes"), 0755))
+
+	ws, err := Discover(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"back", "front"}, ws.Names())
+	assert.Equal(t, filepath.Join(dir, "back"), ws.Repos["back"])
+}